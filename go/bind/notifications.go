@@ -233,6 +233,14 @@ func HandleBackgroundNotification(strConvID, body, serverMessageBody, sender str
 		kbCtx.Log.CDebugf(ctx, "Failed to get conversation info", err)
 		return err
 	}
+	if chat.IsDoNotDisturb(ctx, gc, convID, membersType) {
+		// Ack so the server does not fall back to its generic notification.
+		kbCtx.Log.CDebugf(ctx, "HandleBackgroundNotification: suppressed by do not disturb")
+		if ack != nil {
+			ack.Ack(ctx, []string{pushID})
+		}
+		return nil
+	}
 
 	currentUsername := string(kbCtx.Env.GetUsername())
 	title := "Keybase"
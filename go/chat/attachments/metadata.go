@@ -0,0 +1,617 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/keybase/client/go/chat/utils"
+)
+
+// maxStripMetadataSize is the largest file we strip metadata from, since we
+// do it in memory.
+const maxStripMetadataSize = 200 * 1024 * 1024
+
+type metadataFormat int
+
+const (
+	metadataFormatNone metadataFormat = iota
+	metadataFormatJPEG
+	metadataFormatPNG
+	metadataFormatWebP
+	metadataFormatHEIF
+	metadataFormatPDF
+	metadataFormatZip
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// StripMetadataError is returned when an attachment has metadata we can't
+// strip. We never upload such a file as is unless the user asks for that.
+type StripMetadataError struct {
+	Reason string
+}
+
+func (e StripMetadataError) Error() string {
+	return fmt.Sprintf("couldn't strip metadata: %s, retry with --keep-metadata to upload the file as is", e.Reason)
+}
+
+// detectMetadataFormat looks at the head of a file for a format we know how
+// to strip metadata from. We don't trust the detected content type here, since
+// callers can provide their own.
+func detectMetadataFormat(head []byte) metadataFormat {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xff, 0xd8, 0xff}):
+		return metadataFormatJPEG
+	case bytes.HasPrefix(head, pngSignature):
+		return metadataFormatPNG
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WEBP":
+		return metadataFormatWebP
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		switch string(head[8:12]) {
+		case "heic", "heix", "heim", "heis", "hevc", "hevx", "mif1", "msf1", "avif":
+			return metadataFormatHEIF
+		}
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return metadataFormatPDF
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		return metadataFormatZip
+	}
+	return metadataFormatNone
+}
+
+// StripMetadata removes metadata that could identify the sender from an
+// attachment, like the location a photo was taken at, the serial number of the
+// camera, or the author of a document. It returns nil if there is nothing to
+// strip, otherwise the contents to upload instead of src, which is size bytes
+// long. Files we can't strip fail with a StripMetadataError.
+func StripMetadata(ctx context.Context, log utils.DebugLabeler, src ReadResetter, size int64) (res []byte, err error) {
+	defer func() {
+		if err := src.Reset(); err != nil {
+			log.Debug(ctx, "StripMetadata: reset failed: %+v", err)
+		}
+	}()
+	head := make([]byte, 16)
+	n, err := io.ReadFull(src, head)
+	switch {
+	case err == nil:
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+	default:
+		return nil, err
+	}
+	format := detectMetadataFormat(head[:n])
+	if format == metadataFormatNone {
+		return nil, nil
+	}
+	tooLarge := StripMetadataError{Reason: fmt.Sprintf("file is larger than %d MB", maxStripMetadataSize/(1024*1024))}
+	if size > maxStripMetadataSize {
+		return nil, tooLarge
+	}
+	if err := src.Reset(); err != nil {
+		return nil, err
+	}
+	dat, err := io.ReadAll(io.LimitReader(src, maxStripMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) > maxStripMetadataSize {
+		return nil, tooLarge
+	}
+	switch format {
+	case metadataFormatJPEG:
+		res, err = stripJPEGMetadata(dat)
+	case metadataFormatPNG:
+		res, err = stripPNGMetadata(dat)
+	case metadataFormatWebP:
+		res, err = stripWebPMetadata(dat)
+	case metadataFormatHEIF:
+		res, err = stripHEIFMetadata(dat)
+	case metadataFormatPDF:
+		res, err = stripPDFMetadata(dat)
+	case metadataFormatZip:
+		res, err = stripOOXMLMetadata(dat)
+	}
+	if err != nil {
+		return nil, StripMetadataError{Reason: err.Error()}
+	}
+	if res == nil {
+		return nil, nil
+	}
+	log.Debug(ctx, "StripMetadata: stripped metadata: format: %d size: %d -> %d", format, len(dat), len(res))
+	return res, nil
+}
+
+var errTruncated = errors.New("truncated file")
+
+// JPEG markers
+const (
+	jpegSOI   = 0xd8
+	jpegEOI   = 0xd9
+	jpegSOS   = 0xda
+	jpegAPP0  = 0xe0
+	jpegAPP1  = 0xe1
+	jpegAPP2  = 0xe2
+	jpegAPP14 = 0xee
+	jpegAPP15 = 0xef
+	jpegCOM   = 0xfe
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	iccHeader  = []byte("ICC_PROFILE\x00")
+)
+
+// exifOrientation reads the orientation tag out of an Exif APP1 segment.
+func exifOrientation(seg []byte) (uint16, bool) {
+	if !bytes.HasPrefix(seg, exifHeader) {
+		return 0, false
+	}
+	tiff := seg[len(exifHeader):]
+	if len(tiff) < 8 {
+		return 0, false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0, false
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + 12*i
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+		// orientation is a single SHORT, stored in the value field
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			return order.Uint16(tiff[entry+8:]), true
+		}
+	}
+	return 0, false
+}
+
+// orientationSegment is an Exif APP1 segment with nothing but the
+// orientation, so the image still shows the right way up.
+func orientationSegment(orientation uint16) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xff, jpegAPP1})
+	_ = binary.Write(&b, binary.BigEndian, uint16(2+len(exifHeader)+8+2+12+4))
+	b.Write(exifHeader)
+	b.WriteString("MM\x00\x2a")
+	_ = binary.Write(&b, binary.BigEndian, uint32(8))
+	_ = binary.Write(&b, binary.BigEndian, uint16(1))
+	_ = binary.Write(&b, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(&b, binary.BigEndian, uint32(1))
+	_ = binary.Write(&b, binary.BigEndian, []uint16{orientation, 0})
+	_ = binary.Write(&b, binary.BigEndian, uint32(0))
+	return b.Bytes()
+}
+
+// keepJPEGSegment decides which segments to keep. Application segments hold
+// Exif, XMP, IPTC and the like, so we only keep the ones needed to show the
+// image: JFIF, ICC color profiles and the Adobe color transform.
+func keepJPEGSegment(marker byte, seg []byte) bool {
+	switch {
+	case marker == jpegCOM:
+		return false
+	case marker == jpegAPP0, marker == jpegAPP14:
+		return true
+	case marker == jpegAPP2:
+		return bytes.HasPrefix(seg, iccHeader)
+	case marker >= jpegAPP0 && marker <= jpegAPP15:
+		return false
+	default:
+		return true
+	}
+}
+
+// stripJPEGMetadata drops the metadata segments of a JPEG. Anything after the
+// end of the image is dropped too, which is where cameras put the extra images
+// of a multi-picture file, each with its own metadata.
+func stripJPEGMetadata(dat []byte) ([]byte, error) {
+	if len(dat) < 2 || dat[0] != 0xff || dat[1] != jpegSOI {
+		return nil, errors.New("not a JPEG")
+	}
+	res := make([]byte, 0, len(dat))
+	res = append(res, dat[:2]...)
+	var orientation uint16
+	insertAt := len(res)
+	pos := 2
+	for {
+		// find the next marker, skipping any fill bytes
+		if pos >= len(dat) || dat[pos] != 0xff {
+			return nil, fmt.Errorf("expected marker at %d", pos)
+		}
+		for pos < len(dat) && dat[pos] == 0xff {
+			pos++
+		}
+		if pos >= len(dat) {
+			return nil, errTruncated
+		}
+		marker := dat[pos]
+		pos++
+		if marker == jpegEOI {
+			res = append(res, 0xff, jpegEOI)
+			break
+		}
+		if pos+2 > len(dat) {
+			return nil, errTruncated
+		}
+		length := int(binary.BigEndian.Uint16(dat[pos:]))
+		if length < 2 || pos+length > len(dat) {
+			return nil, errTruncated
+		}
+		seg := dat[pos+2 : pos+length]
+		start := pos - 2
+		pos += length
+		if marker == jpegAPP1 && orientation == 0 {
+			if o, ok := exifOrientation(seg); ok {
+				orientation = o
+			}
+		}
+		if !keepJPEGSegment(marker, seg) {
+			continue
+		}
+		res = append(res, dat[start:pos]...)
+		if marker == jpegAPP0 && insertAt == 2 {
+			// JFIF wants to come first, so put the orientation after it
+			insertAt = len(res)
+		}
+		if marker != jpegSOS {
+			continue
+		}
+		// copy the entropy coded data up to the next marker
+		scanStart := pos
+		for {
+			if pos+1 >= len(dat) {
+				return nil, errTruncated
+			}
+			if dat[pos] == 0xff && dat[pos+1] != 0 && (dat[pos+1] < 0xd0 || dat[pos+1] > 0xd7) {
+				break
+			}
+			pos++
+		}
+		res = append(res, dat[scanStart:pos]...)
+	}
+	if orientation > 1 {
+		seg := orientationSegment(orientation)
+		res = append(res[:insertAt], append(seg, res[insertAt:]...)...)
+	}
+	return res, nil
+}
+
+// stripPNGMetadata drops the text, Exif and timestamp chunks of a PNG.
+func stripPNGMetadata(dat []byte) ([]byte, error) {
+	if !bytes.HasPrefix(dat, pngSignature) {
+		return nil, errors.New("not a PNG")
+	}
+	res := make([]byte, 0, len(dat))
+	res = append(res, pngSignature...)
+	pos := len(pngSignature)
+	for pos < len(dat) {
+		if pos+8 > len(dat) {
+			return nil, errTruncated
+		}
+		length := int(binary.BigEndian.Uint32(dat[pos:]))
+		typ := string(dat[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(dat) || end < pos {
+			return nil, errTruncated
+		}
+		switch typ {
+		case "tEXt", "zTXt", "iTXt", "eXIf", "tIME":
+		default:
+			res = append(res, dat[pos:end]...)
+		}
+		pos = end
+		if typ == "IEND" {
+			break
+		}
+	}
+	return res, nil
+}
+
+// WebP extended format flags for the metadata chunks.
+const (
+	webpFlagXMP  = 0x04
+	webpFlagEXIF = 0x08
+)
+
+// stripWebPMetadata drops the EXIF and XMP chunks of a WebP.
+func stripWebPMetadata(dat []byte) ([]byte, error) {
+	if len(dat) < 12 || string(dat[:4]) != "RIFF" || string(dat[8:12]) != "WEBP" {
+		return nil, errors.New("not a WebP")
+	}
+	res := make([]byte, 0, len(dat))
+	res = append(res, dat[:12]...)
+	pos := 12
+	for pos < len(dat) {
+		if pos+8 > len(dat) {
+			return nil, errTruncated
+		}
+		fourCC := string(dat[pos : pos+4])
+		length := int(binary.LittleEndian.Uint32(dat[pos+4:]))
+		end := pos + 8 + length + length%2
+		if length < 0 || end > len(dat) || end < pos {
+			// the padding byte is sometimes missing on the last chunk
+			if end == len(dat)+1 {
+				end = len(dat)
+			} else {
+				return nil, errTruncated
+			}
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, dat[pos:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= webpFlagXMP | webpFlagEXIF
+			}
+			res = append(res, chunk...)
+		default:
+			res = append(res, dat[pos:end]...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(res[4:], uint32(len(res)-8))
+	return res, nil
+}
+
+// isobmffBox is a box of an ISO base media file, like HEIF.
+type isobmffBox struct {
+	typ   string
+	start int // start of the box header
+	data  int // start of the box contents
+	end   int
+}
+
+func readISOBMFFBoxes(dat []byte, start, end int) (res []isobmffBox, err error) {
+	pos := start
+	for pos+8 <= end {
+		size := int(binary.BigEndian.Uint32(dat[pos:]))
+		box := isobmffBox{typ: string(dat[pos+4 : pos+8]), start: pos, data: pos + 8}
+		switch size {
+		case 0:
+			size = end - pos
+		case 1:
+			if pos+16 > end {
+				return nil, errTruncated
+			}
+			size = int(binary.BigEndian.Uint64(dat[pos+8:]))
+			box.data = pos + 16
+		}
+		if size < box.data-pos || pos+size > end || pos+size < pos {
+			return nil, errTruncated
+		}
+		box.end = pos + size
+		res = append(res, box)
+		pos = box.end
+	}
+	return res, nil
+}
+
+func findISOBMFFBox(boxes []isobmffBox, typ string) (isobmffBox, bool) {
+	for _, box := range boxes {
+		if box.typ == typ {
+			return box, true
+		}
+	}
+	return isobmffBox{}, false
+}
+
+// isobmffReader reads the fields of a box, and remembers if it ran out.
+type isobmffReader struct {
+	dat []byte
+	pos int
+	end int
+	err error
+}
+
+func (r *isobmffReader) uint(size int) uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos+size > r.end {
+		r.err = errTruncated
+		return 0
+	}
+	var v uint64
+	for i := 0; i < size; i++ {
+		v = v<<8 | uint64(r.dat[r.pos+i])
+	}
+	r.pos += size
+	return v
+}
+
+func (r *isobmffReader) cstring() string {
+	if r.err != nil {
+		return ""
+	}
+	idx := bytes.IndexByte(r.dat[r.pos:r.end], 0)
+	if idx < 0 {
+		r.err = errTruncated
+		return ""
+	}
+	s := string(r.dat[r.pos : r.pos+idx])
+	r.pos += idx + 1
+	return s
+}
+
+// heifMetadataItems finds the items of a HEIF holding Exif or XMP, and returns
+// their types.
+func heifMetadataItems(dat []byte, iinf isobmffBox) (res map[uint64]string, err error) {
+	res = make(map[uint64]string)
+	r := &isobmffReader{dat: dat, pos: iinf.data, end: iinf.end}
+	version := r.uint(1)
+	r.uint(3)
+	if version == 0 {
+		r.uint(2)
+	} else {
+		r.uint(4)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	infes, err := readISOBMFFBoxes(dat, r.pos, iinf.end)
+	if err != nil {
+		return nil, err
+	}
+	for _, infe := range infes {
+		if infe.typ != "infe" {
+			continue
+		}
+		r := &isobmffReader{dat: dat, pos: infe.data, end: infe.end}
+		version := r.uint(1)
+		r.uint(3)
+		if version < 2 {
+			// older item infos have no type, and can't be Exif
+			continue
+		}
+		idSize := 2
+		if version >= 3 {
+			idSize = 4
+		}
+		id := r.uint(idSize)
+		r.uint(2) // protection index
+		typ := string(dat[min(r.pos, r.end):min(r.pos+4, r.end)])
+		r.uint(4)
+		switch typ {
+		case "Exif":
+			res[id] = typ
+		case "mime":
+			r.cstring() // name
+			if r.cstring() == "application/rdf+xml" {
+				res[id] = typ
+			}
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+	}
+	return res, nil
+}
+
+// emptyHEIFExif is an Exif item with an empty TIFF directory.
+var emptyHEIFExif = []byte{0, 0, 0, 0, 'M', 'M', 0, 0x2a, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0}
+
+// stripHEIFMetadata blanks out the Exif and XMP items of a HEIF in place, so
+// none of the offsets in the file change. It returns nil if there are none. The orientation of a HEIF image is
+// in its item properties, so we don't need to keep any of the Exif.
+func stripHEIFMetadata(dat []byte) ([]byte, error) {
+	top, err := readISOBMFFBoxes(dat, 0, len(dat))
+	if err != nil {
+		return nil, err
+	}
+	meta, ok := findISOBMFFBox(top, "meta")
+	if !ok {
+		return nil, nil
+	}
+	// meta is a full box, skip the version and flags
+	children, err := readISOBMFFBoxes(dat, meta.data+4, meta.end)
+	if err != nil {
+		return nil, err
+	}
+	iinf, ok := findISOBMFFBox(children, "iinf")
+	if !ok {
+		return nil, nil
+	}
+	items, err := heifMetadataItems(dat, iinf)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	iloc, ok := findISOBMFFBox(children, "iloc")
+	if !ok {
+		return nil, errors.New("missing item locations")
+	}
+	idat, hasIdat := findISOBMFFBox(children, "idat")
+
+	res := append([]byte{}, dat...)
+	r := &isobmffReader{dat: dat, pos: iloc.data, end: iloc.end}
+	version := r.uint(1)
+	r.uint(3)
+	sizes := r.uint(2)
+	offsetSize, lengthSize := int(sizes>>12), int(sizes>>8&0xf)
+	baseOffsetSize, indexSize := int(sizes>>4&0xf), 0
+	if version == 1 || version == 2 {
+		indexSize = int(sizes & 0xf)
+	}
+	var count uint64
+	if version < 2 {
+		count = r.uint(2)
+	} else {
+		count = r.uint(4)
+	}
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		var id uint64
+		if version < 2 {
+			id = r.uint(2)
+		} else {
+			id = r.uint(4)
+		}
+		method := uint64(0)
+		if version == 1 || version == 2 {
+			method = r.uint(2) & 0xf
+		}
+		r.uint(2) // data reference index
+		base := r.uint(baseOffsetSize)
+		extents := r.uint(2)
+		for j := uint64(0); j < extents && r.err == nil; j++ {
+			r.uint(indexSize)
+			offset := base + r.uint(offsetSize)
+			length := r.uint(lengthSize)
+			typ, ok := items[id]
+			if !ok || r.err != nil {
+				continue
+			}
+			start := int(offset)
+			switch method {
+			case 0:
+			case 1:
+				if !hasIdat {
+					return nil, errors.New("missing item data")
+				}
+				start += idat.data
+			default:
+				return nil, fmt.Errorf("unsupported item construction method: %d", method)
+			}
+			end := start + int(length)
+			if length == 0 {
+				end = len(dat)
+			}
+			if start < 0 || end > len(res) || end < start {
+				return nil, errTruncated
+			}
+			extent := res[start:end]
+			if typ == "Exif" {
+				for k := range extent {
+					extent[k] = 0
+				}
+				if j == 0 && len(extent) >= len(emptyHEIFExif) {
+					copy(extent, emptyHEIFExif)
+				}
+			} else {
+				// XMP is text, so leave it as whitespace
+				for k := range extent {
+					extent[k] = ' '
+				}
+			}
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return res, nil
+}
@@ -0,0 +1,430 @@
+package attachments
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/adler32"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	pdfObjRegexp      = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfTrailerRegexp  = regexp.MustCompile(`\btrailer\s*<<`)
+	pdfInfoRegexp     = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R\b`)
+	pdfRefRegexp      = regexp.MustCompile(`(\d+)\s+(\d+)\s+R\b`)
+	pdfXRefRegexp     = regexp.MustCompile(`/Type\s*/XRef\b`)
+	pdfMetadataRegexp = regexp.MustCompile(`/Type\s*/Metadata\b`)
+	pdfXMLRegexp      = regexp.MustCompile(`/Subtype\s*/XML\b`)
+	pdfFilterRegexp   = regexp.MustCompile(`/Filter\b`)
+	pdfFlateRegexp    = regexp.MustCompile(`/Filter\s*(\[\s*)?/FlateDecode\s*\]?`)
+	pdfLengthRegexp   = regexp.MustCompile(`/Length\s+(\d+)(\s+\d+\s+R)?`)
+	pdfStreamRegexp   = regexp.MustCompile(`^\s*stream(\r\n|\n|\r)`)
+)
+
+func isPDFWhitespace(b byte) bool {
+	switch b {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func skipPDFWhitespace(dat []byte, pos int) int {
+	for pos < len(dat) && isPDFWhitespace(dat[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// pdfLiteralStringEnd returns the position after the literal string starting
+// at pos, which may contain balanced or escaped parens.
+func pdfLiteralStringEnd(dat []byte, pos int) (int, error) {
+	depth := 0
+	for i := pos; i < len(dat); i++ {
+		switch dat[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, errTruncated
+}
+
+func pdfHexStringEnd(dat []byte, pos int) (int, error) {
+	idx := bytes.IndexByte(dat[pos:], '>')
+	if idx < 0 {
+		return 0, errTruncated
+	}
+	return pos + idx + 1, nil
+}
+
+// pdfDictEnd returns the position after the dictionary starting at pos.
+func pdfDictEnd(dat []byte, pos int) (end int, err error) {
+	depth := 0
+	for i := pos; i < len(dat); {
+		switch {
+		case dat[i] == '(':
+			if i, err = pdfLiteralStringEnd(dat, i); err != nil {
+				return 0, err
+			}
+		case dat[i] == '%':
+			for i < len(dat) && dat[i] != '\n' && dat[i] != '\r' {
+				i++
+			}
+		case bytes.HasPrefix(dat[i:], []byte("<<")):
+			depth++
+			i += 2
+		case bytes.HasPrefix(dat[i:], []byte(">>")):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i, nil
+			}
+		case dat[i] == '<':
+			if i, err = pdfHexStringEnd(dat, i); err != nil {
+				return 0, err
+			}
+		default:
+			i++
+		}
+	}
+	return 0, errTruncated
+}
+
+// blankPDFString overwrites the contents of the string at pos with spaces,
+// leaving the delimiters alone. It returns the end of the string, or 0 if
+// there is no string there.
+func blankPDFString(res []byte, pos int) (end int, err error) {
+	switch {
+	case pos >= len(res):
+		return 0, nil
+	case res[pos] == '(':
+		end, err = pdfLiteralStringEnd(res, pos)
+	case res[pos] == '<' && !bytes.HasPrefix(res[pos:], []byte("<<")):
+		end, err = pdfHexStringEnd(res, pos)
+	default:
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for i := pos + 1; i < end-1; i++ {
+		res[i] = ' '
+	}
+	return end, nil
+}
+
+// blankPDFDictStrings overwrites all the strings in the dictionary at pos, and
+// returns the objects it refers to.
+func blankPDFDictStrings(res []byte, pos int) (refs []string, err error) {
+	end, err := pdfDictEnd(res, pos)
+	if err != nil {
+		return nil, err
+	}
+	for i := pos + 2; i < end; {
+		if bytes.HasPrefix(res[i:], []byte("<<")) {
+			i += 2
+			continue
+		}
+		strEnd, err := blankPDFString(res, i)
+		switch {
+		case err != nil:
+			return nil, err
+		case strEnd > 0:
+			i = strEnd
+		default:
+			i++
+		}
+	}
+	for _, m := range pdfRefRegexp.FindAllSubmatch(res[pos:end], -1) {
+		refs = append(refs, string(m[1])+" "+string(m[2]))
+	}
+	return refs, nil
+}
+
+// pdfObject is an object definition in a PDF. Since files can be updated in
+// place, the same object can be defined many times.
+type pdfObject struct {
+	id   string
+	body int
+}
+
+func pdfObjects(dat []byte) (res []pdfObject) {
+	for _, m := range pdfObjRegexp.FindAllSubmatchIndex(dat, -1) {
+		// make sure we matched a whole object number
+		if m[0] > 0 && dat[m[0]-1] >= '0' && dat[m[0]-1] <= '9' {
+			continue
+		}
+		id := string(dat[m[2]:m[3]]) + " " + string(dat[m[4]:m[5]])
+		res = append(res, pdfObject{id: id, body: skipPDFWhitespace(dat, m[1])})
+	}
+	return res
+}
+
+// zlibStoredSpaces makes a zlib stream of exactly size bytes, which inflates
+// to spaces. It uses uncompressed blocks so that we can control the size.
+func zlibStoredSpaces(size int) ([]byte, error) {
+	const maxBlock = 0xffff
+	const headerSize, trailerSize, blockHeaderSize = 2, 4, 5
+	avail := size - headerSize - trailerSize
+	if avail < blockHeaderSize {
+		return nil, errors.New("stream too small")
+	}
+	blocks := (avail + maxBlock + blockHeaderSize - 1) / (maxBlock + blockHeaderSize)
+	spaces := bytes.Repeat([]byte{' '}, avail-blocks*blockHeaderSize)
+	res := make([]byte, 0, size)
+	res = append(res, 0x78, 0x01)
+	rest := spaces
+	for i := 0; i < blocks; i++ {
+		n := min(len(rest), maxBlock)
+		final := byte(0)
+		if i == blocks-1 {
+			final = 1
+		}
+		res = append(res, final)
+		res = binary.LittleEndian.AppendUint16(res, uint16(n))
+		res = binary.LittleEndian.AppendUint16(res, ^uint16(n))
+		res = append(res, rest[:n]...)
+		rest = rest[n:]
+	}
+	res = binary.BigEndian.AppendUint32(res, adler32.Checksum(spaces))
+	return res, nil
+}
+
+// blankPDFMetadataStream overwrites the contents of an XMP metadata stream
+// whose dictionary runs from pos to dictEnd.
+func blankPDFMetadataStream(res []byte, pos, dictEnd int) error {
+	dict := res[pos:dictEnd]
+	m := pdfStreamRegexp.FindIndex(res[dictEnd:])
+	if m == nil {
+		return errors.New("metadata stream missing data")
+	}
+	start := dictEnd + m[1]
+	var end int
+	if lm := pdfLengthRegexp.FindSubmatch(dict); lm != nil && len(lm[2]) == 0 {
+		length, err := strconv.Atoi(string(lm[1]))
+		if err != nil {
+			return err
+		}
+		end = start + length
+	} else {
+		// the length is somewhere else, so look for the end of the stream
+		idx := bytes.Index(res[start:], []byte("endstream"))
+		if idx < 0 {
+			return errTruncated
+		}
+		end = start + idx
+		switch {
+		case bytes.HasSuffix(res[start:end], []byte("\r\n")):
+			end -= 2
+		case bytes.HasSuffix(res[start:end], []byte("\n")), bytes.HasSuffix(res[start:end], []byte("\r")):
+			end--
+		}
+	}
+	if end > len(res) || end < start {
+		return errTruncated
+	}
+	switch {
+	case pdfFlateRegexp.Match(dict):
+		blank, err := zlibStoredSpaces(end - start)
+		if err != nil {
+			return err
+		}
+		copy(res[start:end], blank)
+	case pdfFilterRegexp.Match(dict):
+		return errors.New("unsupported metadata stream filter")
+	default:
+		for i := start; i < end; i++ {
+			res[i] = ' '
+		}
+	}
+	return nil
+}
+
+// stripPDFMetadata blanks out the document info dictionary and XMP metadata
+// streams of a PDF. Everything is overwritten in place, so the offsets in the
+// cross reference tables stay valid. It returns nil if there is nothing to
+// strip.
+func stripPDFMetadata(dat []byte) ([]byte, error) {
+	res := append([]byte{}, dat...)
+	objs := pdfObjects(dat)
+	byID := make(map[string][]int)
+	for _, obj := range objs {
+		byID[obj.id] = append(byID[obj.id], obj.body)
+	}
+
+	// the info dictionary is referenced from the trailers, or from cross
+	// reference streams in newer files
+	infos := make(map[string]bool)
+	addInfos := func(dict []byte) {
+		for _, m := range pdfInfoRegexp.FindAllSubmatch(dict, -1) {
+			infos[string(m[1])+" "+string(m[2])] = true
+		}
+	}
+	for _, m := range pdfTrailerRegexp.FindAllIndex(dat, -1) {
+		start := m[1] - 2
+		end, err := pdfDictEnd(dat, start)
+		if err != nil {
+			continue
+		}
+		addInfos(dat[start:end])
+	}
+	changed := false
+	for _, obj := range objs {
+		if !bytes.HasPrefix(dat[obj.body:], []byte("<<")) {
+			continue
+		}
+		end, err := pdfDictEnd(dat, obj.body)
+		if err != nil {
+			// stream data can look like an object, so just move on
+			continue
+		}
+		dict := dat[obj.body:end]
+		switch {
+		case pdfXRefRegexp.Match(dict):
+			addInfos(dict)
+		case pdfMetadataRegexp.Match(dict) && pdfXMLRegexp.Match(dict):
+			if err := blankPDFMetadataStream(res, obj.body, end); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+
+	for id := range infos {
+		bodies, ok := byID[id]
+		if !ok {
+			return nil, errors.New("document info is in a compressed object stream")
+		}
+		for _, body := range bodies {
+			if !bytes.HasPrefix(res[body:], []byte("<<")) {
+				continue
+			}
+			refs, err := blankPDFDictStrings(res, body)
+			if err != nil {
+				return nil, err
+			}
+			changed = true
+			// values can be strings kept in objects of their own
+			for _, ref := range refs {
+				for _, refBody := range byID[ref] {
+					if _, err := blankPDFString(res, refBody); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return res, nil
+}
+
+const ooxmlContentTypes = "[Content_Types].xml"
+
+// ooxmlDocProps are empty versions of the document properties of Office files,
+// which hold the author, company, editing time and so on.
+var ooxmlDocProps = map[string]string{
+	"docProps/core.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\r\n" +
+		`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties"` +
+		` xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/"` +
+		` xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"/>`,
+	"docProps/app.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\r\n" +
+		`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"` +
+		` xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"/>`,
+	"docProps/custom.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\r\n" +
+		`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"` +
+		` xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"/>`,
+}
+
+const ooxmlThumbnail = "docProps/thumbnail.jpeg"
+
+// stripOOXMLMetadata replaces the document properties of an Office file (docx,
+// xlsx, pptx and friends) with empty ones. Other zip files are left alone, and
+// nil is returned.
+func stripOOXMLMetadata(dat []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(dat), int64(len(dat)))
+	if err != nil {
+		return nil, err
+	}
+	isOOXML := false
+	for _, f := range zr.File {
+		if f.Name == ooxmlContentTypes {
+			isOOXML = true
+			break
+		}
+	}
+	if !isOOXML {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		var replacement []byte
+		if props, ok := ooxmlDocProps[f.Name]; ok {
+			replacement = []byte(props)
+		} else if f.Name == ooxmlThumbnail {
+			if replacement, err = stripOOXMLThumbnail(f); err != nil {
+				return nil, err
+			}
+		}
+		if replacement == nil {
+			r, err := f.OpenRaw()
+			if err != nil {
+				return nil, err
+			}
+			w, err := zw.CreateRaw(&f.FileHeader)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := io.Copy(w, r); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.Name,
+			Method:   zip.Deflate,
+			Modified: f.Modified,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(replacement); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func stripOOXMLThumbnail(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	dat, err := io.ReadAll(io.LimitReader(r, maxStripMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) > maxStripMetadataSize {
+		return nil, fmt.Errorf("thumbnail too large: %d", len(dat))
+	}
+	return stripJPEGMetadata(dat)
+}
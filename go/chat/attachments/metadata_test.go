@@ -0,0 +1,257 @@
+package attachments
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/stretchr/testify/require"
+	_ "golang.org/x/image/webp"
+)
+
+func readMetadataFixture(t *testing.T, name string) []byte {
+	dat, err := os.ReadFile("../testdata/" + name)
+	require.NoError(t, err)
+	return dat
+}
+
+func requireNoSecrets(t *testing.T, dat []byte, secrets ...string) {
+	for _, secret := range secrets {
+		require.False(t, bytes.Contains(dat, []byte(secret)), "found %q", secret)
+	}
+}
+
+func requireSameImage(t *testing.T, before, after []byte) {
+	beforeCfg, beforeFormat, err := image.DecodeConfig(bytes.NewReader(before))
+	require.NoError(t, err)
+	_, afterFormat, err := image.Decode(bytes.NewReader(after))
+	require.NoError(t, err)
+	afterCfg, _, err := image.DecodeConfig(bytes.NewReader(after))
+	require.NoError(t, err)
+	require.Equal(t, beforeFormat, afterFormat)
+	require.Equal(t, beforeCfg.Width, afterCfg.Width)
+	require.Equal(t, beforeCfg.Height, afterCfg.Height)
+}
+
+func TestDetectMetadataFormat(t *testing.T) {
+	cases := map[string]metadataFormat{
+		"metadata.jpg":     metadataFormatJPEG,
+		"metadata.png":     metadataFormatPNG,
+		"metadata.webp":    metadataFormatWebP,
+		"mysql.heic":       metadataFormatHEIF,
+		"metadata.pdf":     metadataFormatPDF,
+		"metadata.docx":    metadataFormatZip,
+		"party_parrot.gif": metadataFormatNone,
+		"empty.txt":        metadataFormatNone,
+	}
+	for name, format := range cases {
+		dat := readMetadataFixture(t, name)
+		require.Equal(t, format, detectMetadataFormat(dat[:min(len(dat), 16)]), name)
+	}
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "metadata.jpg")
+	res, err := stripJPEGMetadata(dat)
+	require.NoError(t, err)
+	requireNoSecrets(t, res, "SecretCam", "SERIAL123456", "Jane Photographer", "xmp-secret", "iptc-secret",
+		"comment-secret", "trailing-secret")
+	requireSameImage(t, dat, res)
+
+	// the orientation is all that is left of the Exif
+	idx := bytes.Index(res, exifHeader)
+	require.True(t, idx > 0)
+	orientation, ok := exifOrientation(res[idx:])
+	require.True(t, ok)
+	require.Equal(t, uint16(6), orientation)
+
+	// stripping again changes nothing
+	again, err := stripJPEGMetadata(res)
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+
+	// a photo without metadata still decodes
+	ship := readMetadataFixture(t, "ship.jpg")
+	res, err = stripJPEGMetadata(ship)
+	require.NoError(t, err)
+	requireSameImage(t, ship, res)
+
+	_, err = stripJPEGMetadata(dat[:len(dat)/2])
+	require.Error(t, err)
+}
+
+func TestStripMetadataErrors(t *testing.T) {
+	tc := libkb.SetupTest(t, "metadata", 1)
+	defer tc.Cleanup()
+	log := utils.NewDebugLabeler(tc.G, "TestStripMetadataErrors", false)
+	ctx := context.TODO()
+
+	// files we can't parse or that are too large fail instead of going out
+	// with their metadata
+	dat := readMetadataFixture(t, "metadata.jpg")
+	dat = dat[:len(dat)/2]
+	_, err := StripMetadata(ctx, log, NewBufReadResetter(dat), int64(len(dat)))
+	var serr StripMetadataError
+	require.True(t, errors.As(err, &serr))
+	_, err = StripMetadata(ctx, log, NewBufReadResetter(dat), maxStripMetadataSize+1)
+	require.True(t, errors.As(err, &serr))
+	require.Contains(t, err.Error(), "larger than 200 MB")
+
+	// formats we don't strip go out as is
+	res, err := StripMetadata(ctx, log, NewBufReadResetter([]byte("hello")), 5)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "metadata.png")
+	res, err := stripPNGMetadata(dat)
+	require.NoError(t, err)
+	requireNoSecrets(t, res, "png-secret-author", "png-secret-xmp", "SecretCam", "tIME")
+	requireSameImage(t, dat, res)
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "metadata.webp")
+	res, err := stripWebPMetadata(dat)
+	require.NoError(t, err)
+	requireNoSecrets(t, res, "webp-secret-xmp", "SecretCam", "EXIF", "XMP ")
+	requireSameImage(t, dat, res)
+	require.Equal(t, len(res)-8, int(uint32(res[4])|uint32(res[5])<<8|uint32(res[6])<<16|uint32(res[7])<<24))
+	vp8x := bytes.Index(res, []byte("VP8X"))
+	require.True(t, vp8x > 0)
+	require.Zero(t, res[vp8x+8]&(webpFlagEXIF|webpFlagXMP))
+}
+
+func TestStripHEIFMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "mysql.heic")
+	orientationEntry := []byte{0x01, 0x12, 0x00, 0x03}
+	require.True(t, bytes.Contains(dat, orientationEntry))
+	res, err := stripHEIFMetadata(dat)
+	require.NoError(t, err)
+	require.Len(t, res, len(dat))
+	require.False(t, bytes.Contains(res, orientationEntry))
+	require.True(t, bytes.Contains(res, emptyHEIFExif))
+
+	// only the Exif item changed
+	var changed int
+	for i := range dat {
+		if dat[i] != res[i] {
+			changed++
+		}
+	}
+	require.True(t, changed > 0 && changed < 256, "changed %d bytes", changed)
+
+	// stripping again changes nothing
+	again, err := stripHEIFMetadata(res)
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+}
+
+func TestStripPDFMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "metadata.pdf")
+	res, err := stripPDFMetadata(dat)
+	require.NoError(t, err)
+	require.Len(t, res, len(dat))
+	requireNoSecrets(t, res, "pdf-secret-author", "Jane", "Doe", "7064662d7365637265742d70726f6475636572",
+		"pdf-secret-title", "pdf-secret-xmp", "D:2024")
+	require.True(t, bytes.Contains(res, []byte("(Hello world)")))
+	require.True(t, bytes.Contains(res, []byte("/Trapped /False")))
+
+	// the cross reference table still points at the objects
+	xref := regexp.MustCompile(`(\d{10}) 00000 n`)
+	matches := xref.FindAllSubmatch(res, -1)
+	require.Len(t, matches, 9)
+	for i, m := range matches {
+		offset, err := strconv.Atoi(string(m[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(res[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")))
+	}
+
+	// the compressed metadata stream still inflates
+	idx := bytes.Index(res, []byte("/FlateDecode"))
+	require.True(t, idx > 0)
+	start := idx + bytes.Index(res[idx:], []byte("stream\n")) + len("stream\n")
+	zr, err := zlib.NewReader(bytes.NewReader(res[start:]))
+	require.NoError(t, err)
+	inflated, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Empty(t, bytes.TrimSpace(inflated))
+
+	// a PDF without any metadata is left alone
+	res, err = stripPDFMetadata([]byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\ntrailer\n<< /Root 1 0 R >>\n"))
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestZlibStoredSpaces(t *testing.T) {
+	for _, size := range []int{11, 12, 100, 0xffff + 11, 0xffff + 12, 0xffff + 16, 3 * 0xffff} {
+		dat, err := zlibStoredSpaces(size)
+		require.NoError(t, err)
+		require.Len(t, dat, size)
+		zr, err := zlib.NewReader(bytes.NewReader(dat))
+		require.NoError(t, err)
+		inflated, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Empty(t, bytes.TrimSpace(inflated))
+	}
+	_, err := zlibStoredSpaces(10)
+	require.Error(t, err)
+}
+
+func TestStripOOXMLMetadata(t *testing.T) {
+	dat := readMetadataFixture(t, "metadata.docx")
+	res, err := stripOOXMLMetadata(dat)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(res), int64(len(res)))
+	require.NoError(t, err)
+	require.Empty(t, zr.Comment)
+	orig, err := zip.NewReader(bytes.NewReader(dat), int64(len(dat)))
+	require.NoError(t, err)
+	require.Len(t, zr.File, len(orig.File))
+	for i, f := range zr.File {
+		require.Equal(t, orig.File[i].Name, f.Name)
+		r, err := f.Open()
+		require.NoError(t, err)
+		contents, err := io.ReadAll(r)
+		require.NoError(t, err)
+		requireNoSecrets(t, contents, "docx-secret-author", "docx-secret-editor", "docx-secret-company",
+			"SecretCam", "2024-01-02")
+		switch f.Name {
+		case "word/document.xml":
+			r, err := orig.File[i].Open()
+			require.NoError(t, err)
+			origContents, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, origContents, contents)
+		case "docProps/core.xml":
+			require.True(t, bytes.Contains(contents, []byte("<cp:coreProperties")))
+		}
+	}
+
+	// other zip files are left alone
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("docProps/core.xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("not office"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	res, err = stripOOXMLMetadata(buf.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
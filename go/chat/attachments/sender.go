@@ -128,7 +128,7 @@ func (s *Sender) PostFileAttachmentMessage(ctx context.Context, sender types.Sen
 func (s *Sender) PostFileAttachment(ctx context.Context, sender types.Sender, uid gregor1.UID,
 	convID chat1.ConversationID, tlfName string, vis keybase1.TLFVisibility, inOutboxID *chat1.OutboxID,
 	filename, title string, md []byte, clientPrev chat1.MessageID, ephemeralLifetime *gregor1.DurationSec,
-	callerPreview *chat1.MakePreviewRes,
+	callerPreview *chat1.MakePreviewRes, keepMetadata bool,
 ) (outboxID chat1.OutboxID, msgID *chat1.MessageID, err error) {
 	defer s.Trace(ctx, &err, "PostFileAttachment")()
 	var msg chat1.MessagePlaintext
@@ -138,7 +138,7 @@ func (s *Sender) PostFileAttachment(ctx context.Context, sender types.Sender, ui
 	}
 	// Start upload
 	uresCb, err := s.G().AttachmentUploader.Register(ctx, uid, convID, outboxID, title, filename, md,
-		callerPreview)
+		callerPreview, keepMetadata)
 	if err != nil {
 		return outboxID, msgID, err
 	}
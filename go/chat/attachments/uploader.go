@@ -37,6 +37,7 @@ type uploaderTask struct {
 	Title, Filename string
 	Metadata        []byte
 	CallerPreview   *chat1.MakePreviewRes
+	KeepMetadata    bool
 }
 
 type uploaderStatus struct {
@@ -291,7 +292,7 @@ func (u *Uploader) Retry(ctx context.Context, outboxID chat1.OutboxID) (res type
 			return nil, err
 		}
 		return u.upload(ctx, task.UID, task.ConvID, task.OutboxID, task.Title, task.Filename, task.Metadata,
-			task.CallerPreview)
+			task.CallerPreview, task.KeepMetadata)
 	case types.AttachmentUploaderTaskStatusSuccess:
 		ur := newUploaderResult()
 		ur.trigger(ustatus.Result)
@@ -345,6 +346,7 @@ func (u *Uploader) getTask(ctx context.Context, outboxID chat1.OutboxID) (upload
 
 func (u *Uploader) saveTask(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 	outboxID chat1.OutboxID, title, filename string, metadata []byte, callerPreview *chat1.MakePreviewRes,
+	keepMetadata bool,
 ) error {
 	task := uploaderTask{
 		UID:           uid,
@@ -354,6 +356,7 @@ func (u *Uploader) saveTask(ctx context.Context, uid gregor1.UID, convID chat1.C
 		Filename:      filename,
 		Metadata:      metadata,
 		CallerPreview: callerPreview,
+		KeepMetadata:  keepMetadata,
 	}
 	if err := u.taskStorage.saveTask(ctx, task); err != nil {
 		return err
@@ -363,10 +366,12 @@ func (u *Uploader) saveTask(ctx context.Context, uid gregor1.UID, convID chat1.C
 
 func (u *Uploader) Register(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 	outboxID chat1.OutboxID, title, filename string, metadata []byte, callerPreview *chat1.MakePreviewRes,
+	keepMetadata bool,
 ) (res types.AttachmentUploaderResultCb, err error) {
 	defer u.Trace(ctx, &err, "Register(%s)", outboxID)()
 	// Write down the task information
-	if err := u.saveTask(ctx, uid, convID, outboxID, title, filename, metadata, callerPreview); err != nil {
+	if err := u.saveTask(ctx, uid, convID, outboxID, title, filename, metadata, callerPreview,
+		keepMetadata); err != nil {
 		return nil, err
 	}
 	var ustatus uploaderStatus
@@ -375,7 +380,7 @@ func (u *Uploader) Register(ctx context.Context, uid gregor1.UID, convID chat1.C
 		return nil, err
 	}
 	// Start upload
-	return u.upload(ctx, uid, convID, outboxID, title, filename, metadata, callerPreview)
+	return u.upload(ctx, uid, convID, outboxID, title, filename, metadata, callerPreview, keepMetadata)
 }
 
 func (u *Uploader) checkAndSetUploading(uploadCtx context.Context, outboxID chat1.OutboxID,
@@ -468,8 +473,26 @@ func (u *Uploader) uploadFullFile(ctx context.Context, md chat1.AssetMetadata) (
 	return u.uploadFile(ctx, u.fullsLRU, uploadedFullsDir, "fl")
 }
 
+// stripMetadata strips identifying metadata from the file we are about to
+// upload, unless the user has turned it off or asked to keep it for this file.
+func (u *Uploader) stripMetadata(ctx context.Context, src ReadResetter, size int64, keepMetadata bool) ([]byte, error) {
+	if keepMetadata {
+		u.Debug(ctx, "stripMetadata: keeping metadata")
+		return nil, nil
+	}
+	shouldStrip, err := utils.GetGregorBool(ctx, u.G(), utils.StripMetadataGregorKey, true)
+	if err != nil {
+		return nil, err
+	}
+	if !shouldStrip {
+		return nil, nil
+	}
+	return StripMetadata(ctx, u.DebugLabeler, src, size)
+}
+
 func (u *Uploader) upload(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 	outboxID chat1.OutboxID, title, filename string, metadata []byte, callerPreview *chat1.MakePreviewRes,
+	keepMetadata bool,
 ) (res types.AttachmentUploaderResultCb, err error) {
 	// Create the errgroup first so we can register the context in the upload map
 	var g *errgroup.Group
@@ -544,6 +567,14 @@ func (u *Uploader) upload(ctx context.Context, uid gregor1.UID, convID chat1.Con
 		src.Close()
 		src = NewBufReadResetter(pre.SrcDat)
 	}
+	if dat, err := u.stripMetadata(ctx, src, fileSize, keepMetadata); err != nil {
+		u.Debug(ctx, "upload: failed to strip metadata: %s", err)
+		return res, err
+	} else if dat != nil {
+		fileSize = int64(len(dat))
+		src.Close()
+		src = NewBufReadResetter(dat)
+	}
 
 	var s3params chat1.S3Params
 	paramsCh := make(chan struct{})
@@ -1,6 +1,7 @@
 package attachments
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -214,7 +215,7 @@ func TestAttachmentUploader(t *testing.T) {
 	outboxID, err := storage.NewOutboxID()
 	require.NoError(t, err)
 	filename := "../testdata/empty.txt"
-	resChan, err := uploader.Register(context.TODO(), uid, convID, outboxID, "empty", filename, md, nil)
+	resChan, err := uploader.Register(context.TODO(), uid, convID, outboxID, "empty", filename, md, nil, false)
 	require.NoError(t, err)
 	deliverCheck(true)
 	uploadStartCheck(true, outboxID)
@@ -223,7 +224,7 @@ func TestAttachmentUploader(t *testing.T) {
 	outboxID, err = storage.NewOutboxID()
 	require.NoError(t, err)
 	filename = "../testdata/mysql.heic"
-	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "mysql", filename, md, nil)
+	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "mysql", filename, md, nil, false)
 	require.NoError(t, err)
 	deliverCheck(true)
 	uploadStartCheck(true, outboxID)
@@ -236,19 +237,44 @@ func TestAttachmentUploader(t *testing.T) {
 	outboxID, err = storage.NewOutboxID()
 	require.NoError(t, err)
 	filename = "../testdata/ship.jpg"
-	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil)
+	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil, false)
 	require.NoError(t, err)
 	deliverCheck(true)
 	uploadStartCheck(true, outboxID)
 	successCheck(resChan)
 
+	// Metadata is stripped unless we ask to keep it
+	for _, keepMetadata := range []bool{false, true} {
+		var uploaded []byte
+		store.uploadFn = func(ctx context.Context, task *UploadTask) (chat1.Asset, error) {
+			if !task.Preview {
+				dat, err := io.ReadAll(task.Plaintext)
+				if err != nil {
+					return chat1.Asset{}, err
+				}
+				uploaded = dat
+			}
+			return chat1.Asset{}, nil
+		}
+		outboxID, err = storage.NewOutboxID()
+		require.NoError(t, err)
+		resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "metadata",
+			"../testdata/metadata.jpg", md, nil, keepMetadata)
+		require.NoError(t, err)
+		deliverCheck(true)
+		uploadStartCheck(true, outboxID)
+		successCheck(resChan)
+		require.NotEmpty(t, uploaded)
+		require.Equal(t, keepMetadata, bytes.Contains(uploaded, []byte("SecretCam")))
+	}
+
 	// Broken store
 	outboxID, err = storage.NewOutboxID()
 	require.NoError(t, err)
 	store.uploadFn = func(context.Context, *UploadTask) (chat1.Asset, error) {
 		return chat1.Asset{}, errors.New("i dont work")
 	}
-	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil)
+	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil, false)
 	require.NoError(t, err)
 	uploadStartCheck(true, outboxID)
 	select {
@@ -293,7 +319,7 @@ func TestAttachmentUploader(t *testing.T) {
 		<-slowCh
 		return chat1.Asset{}, nil
 	}
-	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil)
+	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil, false)
 	require.NoError(t, err)
 	uploadStartCheck(true, outboxID)
 	deliverCheck(false)
@@ -327,7 +353,7 @@ func TestAttachmentUploader(t *testing.T) {
 		}
 		return chat1.Asset{}, nil
 	}
-	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil)
+	resChan, err = uploader.Register(context.TODO(), uid, convID, outboxID, "ship", filename, md, nil, false)
 	require.NoError(t, err)
 	uploadStartCheck(true, outboxID)
 	deliverCheck(false)
@@ -0,0 +1,168 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+const bookmarksStorageName = "__chat_bookmarks"
+
+type bookmarksRecord struct {
+	Bookmarks []chat1.ChatBookmark
+}
+
+// BookmarkManager keeps private bookmarks of messages. They live in a
+// conversation of the user with themselves, so they are encrypted to the
+// user's own key and the same on all of their devices.
+type BookmarkManager struct {
+	globals.Contextified
+	utils.DebugLabeler
+	sync.Mutex
+
+	storage types.UserConversationBackedStorage
+}
+
+var _ types.BookmarkManager = (*BookmarkManager)(nil)
+
+func NewBookmarkManager(g *globals.Context, storage types.UserConversationBackedStorage) *BookmarkManager {
+	return &BookmarkManager{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "BookmarkManager", false),
+		storage:      storage,
+	}
+}
+
+// addBookmark puts bookmark at the front of bookmarks. Bookmarking a message
+// again only updates its note.
+func addBookmark(bookmarks []chat1.ChatBookmark, bookmark chat1.ChatBookmark) []chat1.ChatBookmark {
+	for i, b := range bookmarks {
+		if b.ConvID.Eq(bookmark.ConvID) && b.MsgID == bookmark.MsgID {
+			bookmarks[i].Note = bookmark.Note
+			return bookmarks
+		}
+	}
+	return append([]chat1.ChatBookmark{bookmark}, bookmarks...)
+}
+
+func removeBookmark(bookmarks []chat1.ChatBookmark, convID chat1.ConversationID,
+	msgID chat1.MessageID,
+) ([]chat1.ChatBookmark, bool) {
+	for i, b := range bookmarks {
+		if b.ConvID.Eq(convID) && b.MsgID == msgID {
+			return append(bookmarks[:i:i], bookmarks[i+1:]...), true
+		}
+	}
+	return bookmarks, false
+}
+
+func (m *BookmarkManager) get(ctx context.Context, uid gregor1.UID) ([]chat1.ChatBookmark, error) {
+	var record bookmarksRecord
+	found, err := m.storage.Get(ctx, uid, bookmarksStorageName, &record)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return record.Bookmarks, nil
+}
+
+func (m *BookmarkManager) Add(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	msgID chat1.MessageID, note string,
+) (err error) {
+	defer m.Trace(ctx, &err, "Add(%s,%d)", convID, msgID)()
+	reason := chat1.GetThreadReason_GENERAL
+	msgs, err := m.G().ConvSource.GetMessages(ctx, convID, uid, []chat1.MessageID{msgID}, &reason, nil, true)
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 || !msgs[0].IsValidFull() {
+		return fmt.Errorf("message %d can't be bookmarked", msgID)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	bookmarks, err := m.get(ctx, uid)
+	if err != nil {
+		return err
+	}
+	bookmarks = addBookmark(bookmarks, chat1.ChatBookmark{
+		ConvID: convID,
+		MsgID:  msgID,
+		Note:   note,
+		Ctime:  gregor1.ToTime(m.G().GetClock().Now()),
+	})
+	return m.storage.Put(ctx, uid, bookmarksStorageName, bookmarksRecord{Bookmarks: bookmarks})
+}
+
+func (m *BookmarkManager) Remove(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	msgID chat1.MessageID,
+) (err error) {
+	defer m.Trace(ctx, &err, "Remove(%s,%d)", convID, msgID)()
+	m.Lock()
+	defer m.Unlock()
+	bookmarks, err := m.get(ctx, uid)
+	if err != nil {
+		return err
+	}
+	bookmarks, removed := removeBookmark(bookmarks, convID, msgID)
+	if !removed {
+		return errors.New("no such bookmark")
+	}
+	return m.storage.Put(ctx, uid, bookmarksStorageName, bookmarksRecord{Bookmarks: bookmarks})
+}
+
+// List returns the bookmarks, newest first, along with the messages they
+// point at.
+func (m *BookmarkManager) List(ctx context.Context, uid gregor1.UID) (res []chat1.BookmarkedMessage, err error) {
+	defer m.Trace(ctx, &err, "List")()
+	m.Lock()
+	bookmarks, err := m.get(ctx, uid)
+	m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the messages a conversation at a time.
+	msgIDs := make(map[chat1.ConvIDStr][]chat1.MessageID)
+	for _, b := range bookmarks {
+		msgIDs[b.ConvID.ConvIDStr()] = append(msgIDs[b.ConvID.ConvIDStr()], b.MsgID)
+	}
+	msgs := make(map[chat1.ConvIDStr]map[chat1.MessageID]chat1.MessageUnboxed)
+	reason := chat1.GetThreadReason_GENERAL
+	for _, b := range bookmarks {
+		convIDStr := b.ConvID.ConvIDStr()
+		if _, ok := msgs[convIDStr]; ok {
+			continue
+		}
+		msgs[convIDStr] = make(map[chat1.MessageID]chat1.MessageUnboxed)
+		convMsgs, err := m.G().ConvSource.GetMessages(ctx, b.ConvID, uid, msgIDs[convIDStr], &reason, nil, true)
+		if err != nil {
+			// We might not be in the conversation anymore, keep the bookmarks
+			// around without their messages.
+			m.Debug(ctx, "List: failed to load messages for %s: %s", b.ConvID, err)
+			continue
+		}
+		for _, msg := range convMsgs {
+			msgs[convIDStr][msg.GetMessageID()] = msg
+		}
+	}
+
+	res = make([]chat1.BookmarkedMessage, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		bm := chat1.BookmarkedMessage{Bookmark: b}
+		if msg, ok := msgs[b.ConvID.ConvIDStr()][b.MsgID]; ok {
+			bm.Message = &msg
+		}
+		res = append(res, bm)
+	}
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package chat
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkList(t *testing.T) {
+	conv1 := chat1.ConversationID(bytes.Repeat([]byte{1}, chat1.DbShortFormLen))
+	conv2 := chat1.ConversationID(bytes.Repeat([]byte{2}, chat1.DbShortFormLen))
+
+	var bookmarks []chat1.ChatBookmark
+	bookmarks = addBookmark(bookmarks, chat1.ChatBookmark{ConvID: conv1, MsgID: 5, Ctime: 1})
+	bookmarks = addBookmark(bookmarks, chat1.ChatBookmark{ConvID: conv2, MsgID: 5, Ctime: 2})
+	bookmarks = addBookmark(bookmarks, chat1.ChatBookmark{ConvID: conv1, MsgID: 7, Ctime: 3})
+	require.Len(t, bookmarks, 3)
+	require.Equal(t, chat1.MessageID(7), bookmarks[0].MsgID)
+	require.True(t, bookmarks[1].ConvID.Eq(conv2))
+
+	// bookmarking a message again keeps its place and only updates the note
+	bookmarks = addBookmark(bookmarks, chat1.ChatBookmark{ConvID: conv1, MsgID: 5, Note: "later", Ctime: 4})
+	require.Len(t, bookmarks, 3)
+	require.Equal(t, chat1.ChatBookmark{ConvID: conv1, MsgID: 5, Note: "later", Ctime: 1}, bookmarks[2])
+
+	bookmarks, removed := removeBookmark(bookmarks, conv2, 5)
+	require.True(t, removed)
+	require.Len(t, bookmarks, 2)
+	_, removed = removeBookmark(bookmarks, conv2, 5)
+	require.False(t, removed)
+	require.Equal(t, chat1.MessageID(7), bookmarks[0].MsgID)
+	require.Equal(t, chat1.MessageID(5), bookmarks[1].MsgID)
+}
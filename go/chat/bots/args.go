@@ -0,0 +1,244 @@
+package bots
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// Types of arguments that bots can declare for their commands
+const (
+	ArgTypeString   = "string"
+	ArgTypeInt      = "int"
+	ArgTypeEnum     = "enum"
+	ArgTypeUser     = "user"
+	ArgTypeChannel  = "channel"
+	ArgTypeDuration = "duration"
+)
+
+const maxCommandArgs = 20
+
+var argNameRegexp = regexp.MustCompile(`^[0-9a-zA-Z_-]+$`)
+var channelArgRegexp = regexp.MustCompile(`^[0-9a-zA-Z_-]+$`)
+
+// ArgError is returned when the text of a bot command does not match the
+// arguments the bot declared for it.
+type ArgError struct {
+	Command string
+	Arg     string
+	// Missing is set when a required argument was not given at all
+	Missing bool
+	Msg     string
+}
+
+func (e ArgError) Error() string {
+	if len(e.Arg) == 0 {
+		return fmt.Sprintf("!%s: %s", e.Command, e.Msg)
+	}
+	return fmt.Sprintf("!%s: %s: %s", e.Command, e.Arg, e.Msg)
+}
+
+// CheckCommandArgs validates the arguments a bot declares for a command.
+func CheckCommandArgs(cmd chat1.UserBotCommandInput) error {
+	if len(cmd.Args) > maxCommandArgs {
+		return fmt.Errorf("command %s: too many arguments, at most %d are allowed", cmd.Name, maxCommandArgs)
+	}
+	seen := make(map[string]bool)
+	seenOptional := false
+	for _, arg := range cmd.Args {
+		if !argNameRegexp.MatchString(arg.Name) {
+			return fmt.Errorf("command %s: invalid argument name: %q", cmd.Name, arg.Name)
+		}
+		if seen[arg.Name] {
+			return fmt.Errorf("command %s: duplicate argument: %s", cmd.Name, arg.Name)
+		}
+		seen[arg.Name] = true
+		switch arg.Typ {
+		case ArgTypeString, ArgTypeInt, ArgTypeUser, ArgTypeChannel, ArgTypeDuration:
+			if len(arg.Values) > 0 {
+				return fmt.Errorf("command %s: values are only allowed for enum arguments: %s", cmd.Name, arg.Name)
+			}
+		case ArgTypeEnum:
+			if len(arg.Values) == 0 {
+				return fmt.Errorf("command %s: enum argument %s needs values", cmd.Name, arg.Name)
+			}
+			for _, v := range arg.Values {
+				if len(v) == 0 || strings.IndexFunc(v, unicode.IsSpace) >= 0 {
+					return fmt.Errorf("command %s: invalid value of %s: %q", cmd.Name, arg.Name, v)
+				}
+			}
+		default:
+			return fmt.Errorf("command %s: unknown type of argument %s: %q", cmd.Name, arg.Name, arg.Typ)
+		}
+		if arg.Optional {
+			seenOptional = true
+		} else if seenOptional {
+			return fmt.Errorf("command %s: required argument %s follows an optional one", cmd.Name, arg.Name)
+		}
+	}
+	return nil
+}
+
+// ArgsUsage builds a usage string from the arguments of a command, with
+// required arguments in angle brackets and optional ones in square brackets.
+func ArgsUsage(args []chat1.UserBotCommandArg) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		desc := arg.Name
+		if arg.Typ == ArgTypeEnum {
+			desc = strings.Join(arg.Values, "|")
+		}
+		if arg.Optional {
+			parts = append(parts, fmt.Sprintf("[%s]", desc))
+		} else {
+			parts = append(parts, fmt.Sprintf("<%s>", desc))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// MatchCommand finds the command invoked by the text of a message, where the
+// command name has to be followed by whitespace or the end of the text. It
+// returns false when no command matches, or when commands of several bots
+// match equally well.
+func MatchCommand(cmds []chat1.UserBotCommandOutput, text string) (res chat1.UserBotCommandOutput, ok bool) {
+	if !strings.HasPrefix(text, "!") {
+		return res, false
+	}
+	name := text[1:]
+	if index := strings.IndexFunc(name, unicode.IsSpace); index >= 0 {
+		name = name[:index]
+	}
+	for _, cmd := range cmds {
+		if cmd.Name != name {
+			continue
+		}
+		if ok && cmd.Username != res.Username {
+			return res, false
+		}
+		res, ok = cmd, true
+	}
+	return res, ok
+}
+
+type argToken struct {
+	value string
+	start int
+}
+
+// tokenizeArgs splits text on whitespace, where single or double quotes at the
+// start of a word group words up to the closing quote.
+func tokenizeArgs(text string) (res []argToken) {
+	var cur strings.Builder
+	start := -1
+	var quote rune
+	for index, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case start < 0 && (r == '"' || r == '\'') && strings.ContainsRune(text[index+1:], r):
+			start = index
+			quote = r
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				res = append(res, argToken{value: cur.String(), start: start})
+				cur.Reset()
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = index
+			}
+			cur.WriteRune(r)
+		}
+	}
+	if start >= 0 {
+		res = append(res, argToken{value: cur.String(), start: start})
+	}
+	return res
+}
+
+func parseArgValue(arg chat1.UserBotCommandArg, value string) (res chat1.BotCommandArgValue, err error) {
+	res = chat1.BotCommandArgValue{Name: arg.Name, Typ: arg.Typ, Value: value}
+	switch arg.Typ {
+	case ArgTypeInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return res, fmt.Errorf("%q is not a number", value)
+		}
+		res.Number = &n
+	case ArgTypeDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return res, fmt.Errorf("%q is not a duration, try something like 1h30m", value)
+		}
+		secs := int64(d / time.Second)
+		res.Number = &secs
+	case ArgTypeEnum:
+		for _, v := range arg.Values {
+			if strings.EqualFold(v, value) {
+				res.Value = v
+				return res, nil
+			}
+		}
+		return res, fmt.Errorf("%q is not one of %s", value, strings.Join(arg.Values, ", "))
+	case ArgTypeUser:
+		res.Value = strings.TrimPrefix(value, "@")
+		if !libkb.CheckUsername.F(res.Value) {
+			return res, fmt.Errorf("%q is not a username", value)
+		}
+		res.Value = libkb.NewNormalizedUsername(res.Value).String()
+	case ArgTypeChannel:
+		res.Value = strings.TrimPrefix(value, "#")
+		if !channelArgRegexp.MatchString(res.Value) {
+			return res, fmt.Errorf("%q is not a channel", value)
+		}
+	}
+	return res, nil
+}
+
+// ParseCommandArgs parses the text of a message invoking cmd against the
+// arguments the bot declared for it. A string argument that comes last takes
+// the rest of the text.
+func ParseCommandArgs(cmd chat1.UserBotCommandOutput, text string) (res chat1.BotCommandInvocation, err error) {
+	res = chat1.BotCommandInvocation{
+		Name:     cmd.Name,
+		Username: cmd.Username,
+		Args:     []chat1.BotCommandArgValue{},
+	}
+	rest := strings.TrimPrefix(text, "!"+cmd.Name)
+	tokens := tokenizeArgs(rest)
+	for index, arg := range cmd.Args {
+		if index >= len(tokens) {
+			if arg.Optional {
+				break
+			}
+			return res, ArgError{Command: cmd.Name, Arg: arg.Name, Missing: true, Msg: "missing"}
+		}
+		value := tokens[index].value
+		if index == len(cmd.Args)-1 && arg.Typ == ArgTypeString && len(tokens) > len(cmd.Args) {
+			value = strings.TrimSpace(rest[tokens[index].start:])
+			tokens = tokens[:index+1]
+		}
+		parsed, err := parseArgValue(arg, value)
+		if err != nil {
+			return res, ArgError{Command: cmd.Name, Arg: arg.Name, Msg: err.Error()}
+		}
+		res.Args = append(res.Args, parsed)
+	}
+	if len(tokens) > len(cmd.Args) {
+		return res, ArgError{Command: cmd.Name, Msg: fmt.Sprintf("too many arguments, usage: !%s %s",
+			cmd.Name, ArgsUsage(cmd.Args))}
+	}
+	return res, nil
+}
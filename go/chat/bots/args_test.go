@@ -0,0 +1,120 @@
+package bots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCommandArgs(t *testing.T) {
+	check := func(args ...chat1.UserBotCommandArg) error {
+		return CheckCommandArgs(chat1.UserBotCommandInput{Name: "deploy", Args: args})
+	}
+	require.NoError(t, check())
+	require.NoError(t, check(
+		chat1.UserBotCommandArg{Name: "env", Typ: ArgTypeEnum, Values: []string{"prod", "staging"}},
+		chat1.UserBotCommandArg{Name: "owner", Typ: ArgTypeUser},
+		chat1.UserBotCommandArg{Name: "delay", Typ: ArgTypeDuration, Optional: true},
+		chat1.UserBotCommandArg{Name: "note", Typ: ArgTypeString, Optional: true},
+	))
+	require.Error(t, check(chat1.UserBotCommandArg{Name: "x", Typ: "float"}))
+	require.Error(t, check(chat1.UserBotCommandArg{Name: "has space", Typ: ArgTypeString}))
+	require.Error(t, check(chat1.UserBotCommandArg{Name: "env", Typ: ArgTypeEnum}))
+	require.Error(t, check(chat1.UserBotCommandArg{Name: "env", Typ: ArgTypeEnum, Values: []string{"a b"}}))
+	require.Error(t, check(chat1.UserBotCommandArg{Name: "n", Typ: ArgTypeInt, Values: []string{"1"}}))
+	require.Error(t, check(
+		chat1.UserBotCommandArg{Name: "a", Typ: ArgTypeInt},
+		chat1.UserBotCommandArg{Name: "a", Typ: ArgTypeInt},
+	))
+	require.Error(t, check(
+		chat1.UserBotCommandArg{Name: "a", Typ: ArgTypeInt, Optional: true},
+		chat1.UserBotCommandArg{Name: "b", Typ: ArgTypeInt},
+	))
+}
+
+func TestMatchCommand(t *testing.T) {
+	cmds := []chat1.UserBotCommandOutput{
+		{Name: "deploy", Username: "deploybot"},
+		{Name: "deployall", Username: "deploybot"},
+		{Name: "help", Username: "deploybot"},
+		{Name: "help", Username: "otherbot"},
+	}
+	cmd, ok := MatchCommand(cmds, "!deploy prod")
+	require.True(t, ok)
+	require.Equal(t, "deploy", cmd.Name)
+	cmd, ok = MatchCommand(cmds, "!deployall")
+	require.True(t, ok)
+	require.Equal(t, "deployall", cmd.Name)
+	_, ok = MatchCommand(cmds, "!deployer")
+	require.False(t, ok)
+	_, ok = MatchCommand(cmds, "deploy")
+	require.False(t, ok)
+	// ambiguous between bots
+	_, ok = MatchCommand(cmds, "!help me")
+	require.False(t, ok)
+}
+
+func TestParseCommandArgs(t *testing.T) {
+	cmd := chat1.UserBotCommandOutput{
+		Name:     "deploy",
+		Username: "deploybot",
+		Args: []chat1.UserBotCommandArg{
+			{Name: "env", Typ: ArgTypeEnum, Values: []string{"prod", "staging"}},
+			{Name: "count", Typ: ArgTypeInt},
+			{Name: "owner", Typ: ArgTypeUser},
+			{Name: "channel", Typ: ArgTypeChannel},
+			{Name: "delay", Typ: ArgTypeDuration, Optional: true},
+			{Name: "note", Typ: ArgTypeString, Optional: true},
+		},
+	}
+	num := func(n int64) *int64 { return &n }
+
+	res, err := ParseCommandArgs(cmd, `!deploy PROD 3 @Alice #general 1h30m "don't" wait up`)
+	require.NoError(t, err)
+	require.Equal(t, chat1.BotCommandInvocation{
+		Name:     "deploy",
+		Username: "deploybot",
+		Args: []chat1.BotCommandArgValue{
+			{Name: "env", Typ: ArgTypeEnum, Value: "prod"},
+			{Name: "count", Typ: ArgTypeInt, Value: "3", Number: num(3)},
+			{Name: "owner", Typ: ArgTypeUser, Value: "alice"},
+			{Name: "channel", Typ: ArgTypeChannel, Value: "general"},
+			{Name: "delay", Typ: ArgTypeDuration, Value: "1h30m", Number: num(5400)},
+			{Name: "note", Typ: ArgTypeString, Value: `"don't" wait up`},
+		},
+	}, res)
+
+	// a single quoted word loses its quotes, optional arguments can be left out
+	res, err = ParseCommandArgs(cmd, `!deploy staging 1 bob random 5s "it's fine"`)
+	require.NoError(t, err)
+	require.Equal(t, "it's fine", res.Args[5].Value)
+	res, err = ParseCommandArgs(cmd, "!deploy staging 1 bob random")
+	require.NoError(t, err)
+	require.Len(t, res.Args, 4)
+
+	checkErr := func(text, arg string, missing bool) {
+		_, err := ParseCommandArgs(cmd, text)
+		var argErr ArgError
+		require.True(t, errors.As(err, &argErr), text)
+		require.Equal(t, arg, argErr.Arg, text)
+		require.Equal(t, missing, argErr.Missing, text)
+	}
+	checkErr("!deploy", "env", true)
+	checkErr("!deploy dev 1 bob random", "env", false)
+	checkErr("!deploy prod many bob random", "count", false)
+	checkErr("!deploy prod 1 bob", "channel", true)
+	checkErr("!deploy prod 1 b@b random", "owner", false)
+	checkErr("!deploy prod 1 bob #a.b", "channel", false)
+	checkErr("!deploy prod 1 bob random soon", "delay", false)
+
+	noString := chat1.UserBotCommandOutput{
+		Name: "count",
+		Args: []chat1.UserBotCommandArg{{Name: "n", Typ: ArgTypeInt}},
+	}
+	_, err = ParseCommandArgs(noString, "!count 1 2")
+	require.Error(t, err)
+	require.Equal(t, "<n>", ArgsUsage(noString.Args))
+	require.Equal(t, "<prod|staging> <count> <owner> <channel> [delay] [note]", ArgsUsage(cmd.Args))
+}
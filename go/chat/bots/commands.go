@@ -22,7 +22,7 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const storageVersion = 2
+const storageVersion = 3
 
 type uiResult struct {
 	err      error
@@ -214,6 +214,13 @@ func (b *CachingBotCommandManager) Advertise(ctx context.Context, alias *string,
 ) (err error) {
 	defer b.Trace(ctx, &err, "Advertise")()
 	remotes := make([]chat1.RemoteBotCommandsAdvertisement, 0, len(ads))
+	for _, ad := range ads {
+		for _, cmd := range ad.Commands {
+			if err := CheckCommandArgs(cmd); err != nil {
+				return err
+			}
+		}
+	}
 	for _, ad := range ads {
 		// create conversations with the commands
 		conv, err := b.createConv(ctx, ad.Typ, ad.TeamName, ad.ConvID)
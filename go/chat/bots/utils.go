@@ -24,6 +24,7 @@ func MakeConversationCommandGroups(cmds []chat1.UserBotCommandOutput) chat1.Conv
 			Usage:       cmd.Usage,
 			HasHelpText: cmd.ExtendedDescription != nil,
 			Username:    &username,
+			Args:        cmd.Args,
 		})
 	}
 	return chat1.NewConversationCommandGroupsWithCustom(chat1.ConversationCommandGroupsCustom{
@@ -72,26 +73,36 @@ func ApplyTeamBotSettings(ctx context.Context, g *globals.Context, botUID gregor
 		return false, nil
 	}
 
-	// check mentions
-	if _, ok := mentionMap[botUID.String()]; ok && botSettings.Mentions {
-		return true, nil
+	// Messages from anyone but the allowed senders are never keyed for the
+	// bot.
+	if !botSettings.SenderAllowed(keybase1.UID(msg.ClientHeader.Sender.String())) {
+		return false, nil
 	}
 
-	// See if any triggers match
 	matchText := msg.SearchableText()
-	for _, trigger := range botSettings.Triggers {
-		re, err := regexp.Compile(fmt.Sprintf("(?i)%s", trigger))
-		if err != nil {
-			debug.Debug(ctx, "unable to compile trigger regex: %v", err)
-			continue
-		}
-		if re.MatchString(matchText) {
+	// If the bot is limited to commands, mentions and triggers don't count.
+	if !botSettings.CmdsOnly {
+		// check mentions
+		if _, ok := mentionMap[botUID.String()]; ok && botSettings.Mentions {
 			return true, nil
 		}
+
+		// See if any triggers match
+		for _, trigger := range botSettings.Triggers {
+			re, err := regexp.Compile(fmt.Sprintf("(?i)%s", trigger))
+			if err != nil {
+				debug.Debug(ctx, "unable to compile trigger regex: %v", err)
+				continue
+			}
+			if re.MatchString(matchText) {
+				return true, nil
+			}
+		}
 	}
 
-	// Check if any commands match (early out if it can't be a bot message)
-	if !botSettings.Cmds || !strings.HasPrefix(matchText, "!") {
+	// Check if any commands match (early out if it can't be a bot message).
+	// CmdsOnly implies Cmds.
+	if !(botSettings.Cmds || botSettings.CmdsOnly) || !strings.HasPrefix(matchText, "!") {
 		return false, nil
 	}
 	unn, err := g.GetUPAKLoader().LookupUsername(ctx, keybase1.UID(botUID.String()))
@@ -154,6 +154,41 @@ func TestApplyTeamBotSettings(t *testing.T) {
 		Body: "!help ",
 	})
 	assertMatch(false)
+
+	// commands only ignores mentions and triggers
+	mockCmdOutput = []chat1.UserBotCommandOutput{
+		{
+			Name:     "remind me",
+			Username: "botua",
+		},
+	}
+	botSettings = keybase1.TeamBotSettings{
+		CmdsOnly: true,
+		Mentions: true,
+		Triggers: []string{"remind"},
+	}
+	mentionMap[botUID.String()] = struct{}{}
+	msg.MessageBody = chat1.NewMessageBodyWithText(chat1.MessageText{
+		Body: "@botua remind me later",
+	})
+	assertMatch(false)
+	msg.MessageBody = chat1.NewMessageBodyWithText(chat1.MessageText{
+		Body: "!remind me later",
+	})
+	assertMatch(true)
+
+	// restrict the bot to certain senders
+	alice := gregor1.UID("alice")
+	botSettings = keybase1.TeamBotSettings{
+		Mentions: true,
+		Senders:  []keybase1.UID{keybase1.UID(alice.String())},
+	}
+	assertMatch(false)
+	msg.ClientHeader.Sender = alice
+	assertMatch(true)
+	// the bot's own messages are always keyed for it
+	msg.ClientHeader.Sender = botUID
+	assertMatch(true)
 }
 
 func TestBotInfoHash(t *testing.T) {
@@ -1797,7 +1797,8 @@ func (b *Boxer) makeAllPairwiseMACs(ctx context.Context, headerSealed chat1.Sign
 
 func (b *Boxer) versionBody(ctx context.Context, messagePlaintext chat1.MessagePlaintext) chat1.BodyPlaintext {
 	switch messagePlaintext.ClientHeader.MessageType {
-	case chat1.MessageType_PIN:
+	case chat1.MessageType_PIN, chat1.MessageType_POLL, chat1.MessageType_POLLVOTE,
+		chat1.MessageType_INTERACTIVE, chat1.MessageType_INTERACTIVEACTION:
 		return chat1.NewBodyPlaintextWithV2(chat1.BodyPlaintextV2{
 			MessageBody: messagePlaintext.MessageBody,
 		})
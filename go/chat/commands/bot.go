@@ -3,8 +3,10 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/keybase/client/go/chat/bots"
 	"github.com/keybase/client/go/chat/globals"
@@ -77,28 +79,67 @@ func (b *Bot) Preview(ctx context.Context, uid gregor1.UID, convID chat1.Convers
 	// Instead, just check if any valid bot command (followed by a space) is a prefix of this message
 	for _, cmd := range cmds {
 		// If we decide to support the !<command>@<username> syntax, we can just add another check here
-		if cmd.Matches(text) && cmd.ExtendedDescription != nil {
-			var body string
+		if !cmd.Matches(text) || (cmd.ExtendedDescription == nil && len(cmd.Args) == 0) {
+			continue
+		}
+		var body string
+		var title *string
+		if cmd.ExtendedDescription != nil {
 			if b.G().IsMobileAppType() {
 				body = cmd.ExtendedDescription.MobileBody
 			} else {
 				body = cmd.ExtendedDescription.DesktopBody
 			}
-			var title *string
 			if cmd.ExtendedDescription.Title != "" {
 				title = new(string)
 				*title = utils.EscapeForDecorate(ctx, cmd.ExtendedDescription.Title)
 			}
-			err := b.getChatUI().ChatCommandMarkdown(ctx, convID, &chat1.UICommandMarkdown{
-				Body:  utils.EscapeForDecorate(ctx, body),
-				Title: title,
-			})
-			if err != nil {
-				b.Debug(ctx, "Preview: markdown error: %+v", err)
+		}
+		if len(cmd.Args) > 0 {
+			if len(body) > 0 {
+				body += "\n\n"
 			}
-			b.extendedDisplay = true
-			return
+			body += botArgsHelp(cmd, text)
 		}
+		err := b.getChatUI().ChatCommandMarkdown(ctx, convID, &chat1.UICommandMarkdown{
+			Body:  utils.EscapeForDecorate(ctx, body),
+			Title: title,
+		})
+		if err != nil {
+			b.Debug(ctx, "Preview: markdown error: %+v", err)
+		}
+		b.extendedDisplay = true
+		return
 	}
 	b.clearExtendedDisplayLocked(ctx, convID)
 }
+
+// botArgsHelp describes the arguments of cmd, along with the first problem with
+// the arguments typed so far. Arguments that are still being typed or not
+// typed at all are not reported.
+func botArgsHelp(cmd chat1.UserBotCommandOutput, text string) string {
+	lines := []string{fmt.Sprintf("`!%s %s`", cmd.Name, bots.ArgsUsage(cmd.Args))}
+	for _, arg := range cmd.Args {
+		typ := arg.Typ
+		if arg.Typ == bots.ArgTypeEnum {
+			typ = strings.Join(arg.Values, ", ")
+		}
+		if arg.Optional {
+			typ += ", optional"
+		}
+		line := fmt.Sprintf("• *%s* (%s)", arg.Name, typ)
+		if len(arg.Description) > 0 {
+			line += ": " + arg.Description
+		}
+		lines = append(lines, line)
+	}
+	// only check the words that are done being typed
+	if index := strings.LastIndexFunc(text, unicode.IsSpace); index >= 0 && strings.HasPrefix(text, "!"+cmd.Name+" ") {
+		_, err := bots.ParseCommandArgs(cmd, text[:index])
+		var argErr bots.ArgError
+		if errors.As(err, &argErr) && !argErr.Missing {
+			lines = append(lines, "", fmt.Sprintf("*Error:* %s", argErr.Error()))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
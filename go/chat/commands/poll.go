@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/msgchecker"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/clockwork"
+)
+
+type Poll struct {
+	*baseCommand
+	clock clockwork.Clock
+}
+
+func NewPoll(g *globals.Context) *Poll {
+	return &Poll{
+		baseCommand: newBaseCommand(g, "poll",
+			"[--multi] [--anonymous] [--closes <when>] <question> | <option> | <option>...",
+			"Start a poll", false),
+		clock: clockwork.NewRealClock(),
+	}
+}
+
+func (p *Poll) SetClock(clock clockwork.Clock) {
+	p.clock = clock
+}
+
+// parsePoll reads the arguments of /poll. The question and options are
+// separated by "|", and come after any flags.
+func parsePoll(args string, now time.Time) (res chat1.MessagePoll, err error) {
+	rest := strings.TrimSpace(args)
+	for strings.HasPrefix(rest, "--") {
+		flag, tail, _ := strings.Cut(rest, " ")
+		rest = strings.TrimSpace(tail)
+		switch flag {
+		case "--multi":
+			res.MultiChoice = true
+		case "--anonymous", "--anon":
+			res.Anonymous = true
+		case "--closes":
+			closeTime, tail, err := utils.ParseSendTime(rest, now)
+			if err != nil {
+				return res, err
+			}
+			gtime := gregor1.ToTime(closeTime)
+			res.CloseTime = &gtime
+			rest = strings.TrimSpace(tail)
+		default:
+			return res, fmt.Errorf("unknown option: %s", flag)
+		}
+	}
+	parts := strings.Split(rest, "|")
+	res.Question = strings.TrimSpace(parts[0])
+	for _, option := range parts[1:] {
+		if option = strings.TrimSpace(option); option != "" {
+			res.Options = append(res.Options, option)
+		}
+	}
+	if res.Question == "" {
+		return res, errors.New("missing poll question")
+	}
+	return res, nil
+}
+
+func (p *Poll) Execute(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	tlfName, text string, replyTo *chat1.MessageID,
+) (err error) {
+	defer p.Trace(ctx, &err, "Execute")()
+	if !p.Match(ctx, text) {
+		return ErrInvalidCommand
+	}
+	defer func() {
+		if err != nil {
+			_ = p.getChatUI().ChatCommandStatus(ctx, convID,
+				fmt.Sprintf("Failed to start poll: %s", err), chat1.UICommandStatusDisplayTyp_ERROR, nil)
+		}
+	}()
+	_, args, err := p.commandAndMessage(text)
+	if err != nil {
+		return err
+	}
+	poll, err := parsePoll(args, p.clock.Now())
+	if err != nil {
+		return err
+	}
+	body := chat1.NewMessageBodyWithPoll(poll)
+	if err := msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: body}); err != nil {
+		return err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, p.G(), uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return err
+	}
+	return p.G().ChatHelper.SendMsgByID(ctx, convID, tlfName, body, chat1.MessageType_POLL,
+		conv.Info.Visibility)
+}
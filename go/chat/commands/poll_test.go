@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePoll(t *testing.T) {
+	now := time.Date(2026, 4, 15, 14, 20, 0, 0, time.UTC)
+	res, err := parsePoll("lunch? | tacos |pizza|  salad ", now)
+	require.NoError(t, err)
+	require.Equal(t, chat1.MessagePoll{
+		Question: "lunch?",
+		Options:  []string{"tacos", "pizza", "salad"},
+	}, res)
+
+	res, err = parsePoll("--multi --anon --closes in 2 hours which days? | mon | tue", now)
+	require.NoError(t, err)
+	closeTime := gregor1.ToTime(now.Add(2 * time.Hour))
+	require.Equal(t, chat1.MessagePoll{
+		Question:    "which days?",
+		Options:     []string{"mon", "tue"},
+		MultiChoice: true,
+		Anonymous:   true,
+		CloseTime:   &closeTime,
+	}, res)
+
+	_, err = parsePoll("--closes soon lunch? | tacos | pizza", now)
+	require.Error(t, err)
+	_, err = parsePoll("--secret lunch? | tacos | pizza", now)
+	require.Error(t, err)
+	_, err = parsePoll(" | tacos | pizza", now)
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+)
+
+type Remind struct {
+	*baseCommand
+	clock clockwork.Clock
+}
+
+func NewRemind(g *globals.Context) *Remind {
+	return &Remind{
+		baseCommand: newBaseCommand(g, "remind", "me|@user|#channel <when> <message>",
+			"Schedule a reminder", false),
+		clock: clockwork.NewRealClock(),
+	}
+}
+
+func (r *Remind) SetClock(clock clockwork.Clock) {
+	r.clock = clock
+}
+
+// target finds the conversation a reminder goes to: the user's own
+// conversation for "me", a conversation with the user for "@user", and a
+// channel of the current team for "#channel".
+func (r *Remind) target(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	tlfName, who string,
+) (res chat1.ConversationLocal, err error) {
+	username := r.G().GetEnv().GetUsername().String()
+	switch {
+	case who == "me":
+		res, _, err = r.G().ChatHelper.NewConversation(ctx, uid, username, nil, chat1.TopicType_CHAT,
+			chat1.ConversationMembersType_IMPTEAMNATIVE, keybase1.TLFVisibility_PRIVATE)
+		return res, err
+	case strings.HasPrefix(who, "@") && len(who) > 1:
+		tlfName := fmt.Sprintf("%s,%s", username, strings.TrimPrefix(who, "@"))
+		res, _, err = r.G().ChatHelper.NewConversation(ctx, uid, tlfName, nil, chat1.TopicType_CHAT,
+			chat1.ConversationMembersType_IMPTEAMNATIVE, keybase1.TLFVisibility_PRIVATE)
+		return res, err
+	case strings.HasPrefix(who, "#") && len(who) > 1:
+		conv, err := getConvByID(ctx, r.G(), uid, convID)
+		if err != nil {
+			return res, err
+		}
+		if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+			return res, errors.New("#channel reminders only work in team conversations")
+		}
+		topicName := strings.TrimPrefix(who, "#")
+		convs, err := r.G().ChatHelper.FindConversations(ctx, tlfName, &topicName, chat1.TopicType_CHAT,
+			chat1.ConversationMembersType_TEAM, keybase1.TLFVisibility_PRIVATE)
+		if err != nil {
+			return res, err
+		}
+		if len(convs) == 0 {
+			return res, fmt.Errorf("channel #%s not found", topicName)
+		}
+		return convs[0], nil
+	}
+	return res, ErrInvalidArguments
+}
+
+func (r *Remind) Execute(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	tlfName, text string, replyTo *chat1.MessageID,
+) (err error) {
+	defer r.Trace(ctx, &err, "Execute")()
+	if !r.Match(ctx, text) {
+		return ErrInvalidCommand
+	}
+	defer func() {
+		if err != nil {
+			_ = r.getChatUI().ChatCommandStatus(ctx, convID,
+				fmt.Sprintf("Failed to schedule reminder: %s", err),
+				chat1.UICommandStatusDisplayTyp_ERROR, nil)
+		}
+	}()
+	_, args, err := r.commandAndMessage(text)
+	if err != nil {
+		return err
+	}
+	who, rest, ok := strings.Cut(strings.TrimSpace(args), " ")
+	if !ok {
+		return ErrInvalidArguments
+	}
+	sendTime, body, err := utils.ParseSendTime(rest, r.clock.Now())
+	if err != nil {
+		return err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return errors.New("missing reminder message")
+	}
+	conv, err := r.target(ctx, uid, convID, tlfName, strings.ToLower(who))
+	if err != nil {
+		return err
+	}
+	msg := chat1.MessagePlaintext{
+		ClientHeader: chat1.MessageClientHeader{
+			Conv:        conv.Info.Triple,
+			TlfName:     conv.Info.TlfName,
+			TlfPublic:   conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+			MessageType: chat1.MessageType_TEXT,
+		},
+		MessageBody: chat1.NewMessageBodyWithText(chat1.MessageText{
+			Body: fmt.Sprintf(":alarm_clock: Reminder: %s", body),
+		}),
+	}
+	identifyBehavior, _, _ := globals.CtxIdentifyMode(ctx)
+	if _, err := r.G().MessageDeliverer.Schedule(ctx, conv.GetConvID(), msg, sendTime,
+		identifyBehavior); err != nil {
+		return err
+	}
+	return r.getChatUI().ChatCommandStatus(ctx, convID,
+		fmt.Sprintf("Reminder scheduled for %s", sendTime.Format(time.RFC1123)),
+		chat1.UICommandStatusDisplayTyp_STATUS, nil)
+}
@@ -55,6 +55,8 @@ const (
 	cmdMe
 	cmdMsg
 	cmdMute
+	cmdPoll
+	cmdRemind
 	cmdShrug
 	cmdUnhide
 )
@@ -74,6 +76,8 @@ func (s *Source) allCommands() (res map[int]types.ConversationCommand) {
 	res[cmdMe] = NewMe(s.G())
 	res[cmdMsg] = NewMsg(s.G())
 	res[cmdMute] = NewMute(s.G())
+	res[cmdPoll] = NewPoll(s.G())
+	res[cmdRemind] = NewRemind(s.G())
 	res[cmdShrug] = NewShrug(s.G())
 	res[cmdUnhide] = NewUnhide(s.G())
 	return res
@@ -92,6 +96,8 @@ func (s *Source) makeBuiltins() {
 		cmds[cmdMe],
 		cmds[cmdMsg],
 		cmds[cmdMute],
+		cmds[cmdPoll],
+		cmds[cmdRemind],
 		cmds[cmdShrug],
 		cmds[cmdUnhide],
 		cmds[cmdAddEmoji],
@@ -122,6 +128,8 @@ func (s *Source) makeBuiltins() {
 func (s *Source) SetClock(clock clockwork.Clock) {
 	s.clock = clock
 	s.allCmds[cmdLocation].(*Location).SetClock(clock)
+	s.allCmds[cmdPoll].(*Poll).SetClock(clock)
+	s.allCmds[cmdRemind].(*Remind).SetClock(clock)
 }
 
 func (s *Source) GetBuiltins(ctx context.Context) (res []chat1.BuiltinCommandGroup) {
@@ -25,6 +25,12 @@ import (
 const (
 	deliverMaxAttempts            = 180 // fifteen minutes in default mode
 	deliverDisconnectLimitMinutes = 10  // need to be offline for at least 10 minutes before auto failing a send
+	// scheduleLoop checks at least this often for due scheduled messages, so a
+	// device waking up from sleep does not wait for a stale timer.
+	scheduleMaxWait = time.Minute
+	// scheduleMaxAttempts is how many rounds of the scheduleLoop can fail to
+	// queue a scheduled message before we give up on it
+	scheduleMaxAttempts = 5
 )
 
 type DelivererInfoError interface {
@@ -71,10 +77,12 @@ type Deliverer struct {
 	sender           types.Sender
 	serverConn       types.ServerConnection
 	outbox           *storage.Outbox
+	scheduled        *storage.ScheduledOutbox
 	identNotifier    types.IdentifyNotifier
 	shutdownCh       chan struct{}
 	msgSentCh        chan struct{}
 	reconnectCh      chan struct{}
+	scheduleCh       chan struct{}
 	kbfsDeliverQueue chan chat1.OutboxRecord
 	delivering       bool
 	connected        bool
@@ -82,6 +90,10 @@ type Deliverer struct {
 	clock            clockwork.Clock
 	eg               errgroup.Group
 
+	// scheduleAttempts counts the failures to queue scheduled messages by
+	// outbox ID, it is only used from the scheduleLoop
+	scheduleAttempts map[string]int
+
 	notifyFailureChsMu sync.Mutex
 	notifyFailureChs   map[string]chan []chat1.OutboxRecord
 
@@ -97,6 +109,7 @@ func NewDeliverer(g *globals.Context, sender types.Sender, serverConn types.Serv
 		DebugLabeler:     utils.NewDebugLabeler(g.ExternalG(), "Deliverer", false),
 		msgSentCh:        make(chan struct{}, 100),
 		reconnectCh:      make(chan struct{}, 100),
+		scheduleCh:       make(chan struct{}, 100),
 		kbfsDeliverQueue: make(chan chat1.OutboxRecord, 100),
 		sender:           sender,
 		identNotifier:    NewCachingIdentifyNotifier(g),
@@ -159,11 +172,15 @@ func (s *Deliverer) Start(ctx context.Context, uid gregor1.UID) {
 				chat1.ChatActivitySource_LOCAL)
 		}))
 	s.outbox.SetClock(s.clock)
+	s.scheduled = storage.NewScheduledOutbox(s.G(), uid)
+	s.scheduled.SetClock(s.clock)
+	s.scheduleAttempts = make(map[string]int)
 
 	s.delivering = true
 	s.shutdownCh = make(chan struct{})
 	s.eg.Go(func() error { return s.deliverLoop(s.shutdownCh) })
 	s.eg.Go(func() error { return s.kbfsDeliverLoop(s.shutdownCh) })
+	s.eg.Go(func() error { return s.scheduleLoop(s.shutdownCh) })
 }
 
 func (s *Deliverer) Stop(ctx context.Context) chan struct{} {
@@ -799,3 +816,139 @@ func (s *Deliverer) deliverForConv(ctx context.Context, obrs []chat1.OutboxRecor
 		}
 	}
 }
+
+// Schedule stores a message to be queued for delivery at sendTime.
+func (s *Deliverer) Schedule(ctx context.Context, convID chat1.ConversationID, msg chat1.MessagePlaintext,
+	sendTime time.Time, identifyBehavior keybase1.TLFIdentifyBehavior,
+) (res chat1.ScheduledMessage, err error) {
+	defer s.Trace(ctx, &err, "Schedule")()
+	if !s.IsDelivering() {
+		return res, errors.New("deliverer not running")
+	}
+	if res, err = s.scheduled.ScheduleMessage(ctx, convID, msg, sendTime, identifyBehavior); err != nil {
+		return res, err
+	}
+	s.Debug(ctx, "Schedule: scheduled message: convID: %s outboxID: %s sendTime: %v", convID,
+		res.OutboxID, sendTime)
+	// Wake up the schedule loop so it waits for the right message
+	s.wakeScheduleLoop()
+	return res, nil
+}
+
+func (s *Deliverer) ScheduledMessages(ctx context.Context, convID *chat1.ConversationID) (res []chat1.ScheduledMessage, err error) {
+	defer s.Trace(ctx, &err, "ScheduledMessages")()
+	if !s.IsDelivering() {
+		return nil, nil
+	}
+	return s.scheduled.ScheduledMessages(ctx, convID)
+}
+
+func (s *Deliverer) CancelScheduled(ctx context.Context, outboxID chat1.OutboxID) (err error) {
+	defer s.Trace(ctx, &err, "CancelScheduled: %s", outboxID)()
+	if !s.IsDelivering() {
+		return errors.New("deliverer not running")
+	}
+	if err := s.scheduled.RemoveMessage(ctx, outboxID); err != nil {
+		return err
+	}
+	s.wakeScheduleLoop()
+	return nil
+}
+
+func (s *Deliverer) wakeScheduleLoop() {
+	select {
+	case s.scheduleCh <- struct{}{}:
+	default:
+	}
+}
+
+// queueDueScheduled hands the scheduled messages that have come due to the
+// outbox, and returns how long to wait before checking again.
+func (s *Deliverer) queueDueScheduled(ctx context.Context) time.Duration {
+	due, err := s.scheduled.DueMessages(ctx)
+	if err != nil {
+		s.Debug(ctx, "scheduleLoop: unable to read scheduled outbox: %v", err)
+		return scheduleMaxWait
+	}
+	sender := NewNonblockingSender(s.G(), s.sender)
+	for _, rec := range due {
+		var breaks []keybase1.TLFIdentifyFailure
+		bctx := globals.ChatCtx(ctx, s.G(), rec.IdentifyBehavior, &breaks, s.identNotifier)
+		outboxID := rec.OutboxID
+		// Take the message out first, so a crash before it is removed can't
+		// send it twice.
+		if err := s.scheduled.RemoveMessage(bctx, rec.OutboxID); err != nil {
+			s.Debug(bctx, "scheduleLoop: failed to remove due scheduled message: %v", err)
+			continue
+		}
+		// The nonblocking sender fills in a fresh prev for the message and
+		// queues it with us like any other send.
+		_, _, err := sender.Send(bctx, rec.ConvID, rec.Msg, 0, &outboxID, nil, nil)
+		if err == nil {
+			delete(s.scheduleAttempts, rec.OutboxID.String())
+			continue
+		}
+		attempts := s.scheduleAttempts[rec.OutboxID.String()] + 1
+		s.Debug(bctx, "scheduleLoop: failed to queue scheduled message: convID: %s obid: %s attempts: %d err: %v",
+			rec.ConvID, rec.OutboxID, attempts, err)
+		if attempts >= scheduleMaxAttempts {
+			delete(s.scheduleAttempts, rec.OutboxID.String())
+			s.failScheduled(bctx, rec, err)
+			continue
+		}
+		s.scheduleAttempts[rec.OutboxID.String()] = attempts
+		if err := s.scheduled.RestoreMessage(bctx, rec); err != nil {
+			s.Debug(bctx, "scheduleLoop: failed to restore scheduled message: %v", err)
+		}
+	}
+	next, ok, err := s.scheduled.NextSendTime(ctx)
+	if err != nil || !ok {
+		return scheduleMaxWait
+	}
+	wait := next.Sub(s.clock.Now())
+	switch {
+	case wait < 0:
+		// a message we failed to queue, retry it on the next round
+		return scheduleMaxWait
+	case wait > scheduleMaxWait:
+		return scheduleMaxWait
+	}
+	return wait
+}
+
+// failScheduled lets the UI know about a scheduled message we gave up on. It
+// never made it to the outbox, so it only shows up as failed until reloaded.
+func (s *Deliverer) failScheduled(ctx context.Context, rec chat1.ScheduledMessage, err error) {
+	obr := chat1.OutboxRecord{
+		State: chat1.NewOutboxStateWithError(chat1.OutboxStateError{
+			Message: fmt.Sprintf("unable to send scheduled message: %v", err),
+			Typ:     chat1.OutboxErrorType_MISC,
+		}),
+		OutboxID:         rec.OutboxID,
+		ConvID:           rec.ConvID,
+		Ctime:            rec.Ctime,
+		Msg:              rec.Msg,
+		IdentifyBehavior: rec.IdentifyBehavior,
+	}
+	act := chat1.NewChatActivityWithFailedMessage(chat1.FailedMessageInfo{
+		OutboxRecords: []chat1.OutboxRecord{obr},
+	})
+	s.G().ActivityNotifier.Activity(ctx, s.outbox.GetUID(), chat1.TopicType_NONE, &act,
+		chat1.ChatActivitySource_LOCAL)
+	s.alertFailureChannels([]chat1.OutboxRecord{obr})
+}
+
+func (s *Deliverer) scheduleLoop(shutdownCh chan struct{}) error {
+	bgctx := libkb.WithLogTag(context.Background(), "DELVS")
+	s.Debug(bgctx, "scheduleLoop: starting: uid: %s", s.outbox.GetUID())
+	for {
+		wait := s.queueDueScheduled(bgctx)
+		select {
+		case <-shutdownCh:
+			s.Debug(bgctx, "scheduleLoop: shutting down: uid: %s", s.outbox.GetUID())
+			return nil
+		case <-s.scheduleCh:
+		case <-s.clock.After(wait):
+		}
+	}
+}
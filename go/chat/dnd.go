@@ -0,0 +1,192 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+const (
+	minutesPerDay = 24 * 60
+	daysPerWeek   = 7
+	maxDNDWindows = 50
+	maxDNDAllowed = 500
+)
+
+// dndLocation is the timezone the windows of a schedule are in.
+func dndLocation(schedule chat1.ChatDNDSchedule) (*time.Location, error) {
+	if len(schedule.Timezone) == 0 {
+		return time.Local, nil
+	}
+	return time.LoadLocation(schedule.Timezone)
+}
+
+func dndOnWeekday(window chat1.ChatDNDWindow, day time.Weekday) bool {
+	for _, weekday := range window.Weekdays {
+		if time.Weekday(weekday) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// dndWindowActive checks whether the local time of day falls in a window,
+// which can have started the day before when it runs past midnight.
+func dndWindowActive(window chat1.ChatDNDWindow, day time.Weekday, minute int) bool {
+	yesterday := (day + daysPerWeek - 1) % daysPerWeek
+	switch {
+	case window.StartMinute == window.EndMinute:
+		return dndOnWeekday(window, day)
+	case window.StartMinute < window.EndMinute:
+		return dndOnWeekday(window, day) && minute >= window.StartMinute && minute < window.EndMinute
+	default:
+		return (dndOnWeekday(window, day) && minute >= window.StartMinute) ||
+			(dndOnWeekday(window, yesterday) && minute < window.EndMinute)
+	}
+}
+
+// dndActive checks whether a schedule suppresses notifications at a time.
+func dndActive(schedule chat1.ChatDNDSchedule, now time.Time) (bool, error) {
+	if !schedule.Enabled {
+		return false, nil
+	}
+	loc, err := dndLocation(schedule)
+	if err != nil {
+		return false, err
+	}
+	now = now.In(loc)
+	minute := now.Hour()*60 + now.Minute()
+	for _, window := range schedule.Windows {
+		if dndWindowActive(window, now.Weekday(), minute) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// dndAllows checks whether a conversation still notifies during a schedule.
+func dndAllows(schedule chat1.ChatDNDSchedule, convID chat1.ConversationID,
+	membersType chat1.ConversationMembersType,
+) bool {
+	if schedule.AllowDMs && membersType != chat1.ConversationMembersType_TEAM {
+		return true
+	}
+	for _, allowed := range schedule.AllowConvIDs {
+		if allowed.Eq(convID) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDNDSchedule checks a schedule, sorting and deduping its weekdays
+// and its allowed conversations.
+func normalizeDNDSchedule(schedule chat1.ChatDNDSchedule) (res chat1.ChatDNDSchedule, err error) {
+	res.Enabled = schedule.Enabled
+	res.Timezone = schedule.Timezone
+	res.AllowDMs = schedule.AllowDMs
+	if _, err := dndLocation(schedule); err != nil {
+		return res, fmt.Errorf("invalid timezone %q: %s", schedule.Timezone, err)
+	}
+	if len(schedule.Windows) > maxDNDWindows {
+		return res, fmt.Errorf("at most %d do-not-disturb windows are allowed", maxDNDWindows)
+	}
+	for _, window := range schedule.Windows {
+		if window.StartMinute < 0 || window.StartMinute >= minutesPerDay ||
+			window.EndMinute < 0 || window.EndMinute >= minutesPerDay {
+			return res, fmt.Errorf("invalid do-not-disturb window: minutes must be between 0 and %d",
+				minutesPerDay-1)
+		}
+		var days [daysPerWeek]bool
+		for _, weekday := range window.Weekdays {
+			if weekday < 0 || weekday >= daysPerWeek {
+				return res, fmt.Errorf("invalid weekday: %d", weekday)
+			}
+			days[weekday] = true
+		}
+		normalized := chat1.ChatDNDWindow{
+			StartMinute: window.StartMinute,
+			EndMinute:   window.EndMinute,
+		}
+		for weekday, set := range days {
+			if set {
+				normalized.Weekdays = append(normalized.Weekdays, weekday)
+			}
+		}
+		if len(normalized.Weekdays) == 0 {
+			return res, errors.New("a do-not-disturb window needs at least one weekday")
+		}
+		res.Windows = append(res.Windows, normalized)
+	}
+	if len(schedule.AllowConvIDs) > maxDNDAllowed {
+		return res, fmt.Errorf("at most %d conversations can be allowed", maxDNDAllowed)
+	}
+	for _, convID := range schedule.AllowConvIDs {
+		if !convID.IsNil() && !dndAllows(res, convID, chat1.ConversationMembersType_TEAM) {
+			res.AllowConvIDs = append(res.AllowConvIDs, convID)
+		}
+	}
+	return res, nil
+}
+
+func decodeDNDSchedule(body []byte) (res chat1.ChatDNDSchedule, err error) {
+	if len(body) == 0 {
+		return res, nil
+	}
+	err = json.Unmarshal(body, &res)
+	return res, err
+}
+
+// getChatDNDSchedule returns the do-not-disturb schedule of the user. It is
+// kept in gregor, like the rest of the notification settings, so it is the
+// same on all devices.
+func getChatDNDSchedule(ctx context.Context, g *globals.Context) (res chat1.ChatDNDSchedule, err error) {
+	state, err := g.GregorState.State(ctx)
+	if err != nil {
+		return res, err
+	}
+	body, err := utils.GetGregorBodyFromState(state, utils.DNDScheduleGregorKey)
+	if err != nil {
+		return res, err
+	}
+	return decodeDNDSchedule(body)
+}
+
+func setChatDNDSchedule(ctx context.Context, g *globals.Context, schedule chat1.ChatDNDSchedule) error {
+	schedule, err := normalizeDNDSchedule(schedule)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	_, err = g.GregorState.UpdateCategory(ctx, utils.DNDScheduleGregorKey, body, gregor1.TimeOrOffset{})
+	return err
+}
+
+// IsDoNotDisturb checks whether notifications of a conversation are
+// suppressed right now by the do-not-disturb schedule of the user. Badges are
+// not affected.
+func IsDoNotDisturb(ctx context.Context, g *globals.Context, convID chat1.ConversationID,
+	membersType chat1.ConversationMembersType,
+) bool {
+	schedule, err := getChatDNDSchedule(ctx, g)
+	if err != nil {
+		g.GetLog().CDebugf(ctx, "IsDoNotDisturb: failed to load schedule: %v", err)
+		return false
+	}
+	active, err := dndActive(schedule, g.GetClock().Now())
+	if err != nil {
+		g.GetLog().CDebugf(ctx, "IsDoNotDisturb: invalid schedule: %v", err)
+		return false
+	}
+	return active && !dndAllows(schedule, convID, membersType)
+}
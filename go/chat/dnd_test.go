@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNDActive(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	schedule := chat1.ChatDNDSchedule{
+		Enabled:  true,
+		Timezone: "America/New_York",
+		Windows: []chat1.ChatDNDWindow{
+			// weeknights, running past midnight
+			{Weekdays: []int{1, 2, 3, 4, 5}, StartMinute: 22 * 60, EndMinute: 7 * 60},
+			// all of Sunday
+			{Weekdays: []int{0}},
+		},
+	}
+	check := func(expected bool, year int, month time.Month, day, hour, min int) {
+		active, err := dndActive(schedule, time.Date(year, month, day, hour, min, 0, 0, loc))
+		require.NoError(t, err)
+		require.Equal(t, expected, active, "%d-%d-%d %d:%d", year, month, day, hour, min)
+	}
+	// 2026-10-19 is a Monday
+	check(false, 2026, time.October, 19, 21, 59)
+	check(true, 2026, time.October, 19, 22, 0)
+	check(true, 2026, time.October, 20, 6, 59)
+	check(false, 2026, time.October, 20, 7, 0)
+	check(false, 2026, time.October, 19, 3, 0)   // Sunday night is not a weeknight
+	check(true, 2026, time.October, 24, 3, 0)    // but Friday night is
+	check(false, 2026, time.October, 24, 23, 0)  // Saturday
+	check(true, 2026, time.October, 25, 12, 0)   // Sunday
+	check(true, 2026, time.October, 25, 0, 0)    // Sunday
+	check(false, 2026, time.October, 24, 12, 30) // Saturday
+
+	// the windows are in the timezone of the schedule
+	active, err := dndActive(schedule, time.Date(2026, time.October, 20, 3, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, active) // 23:00 on Monday in New York
+	active, err = dndActive(schedule, time.Date(2026, time.October, 20, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, active)
+
+	schedule.Enabled = false
+	active, err = dndActive(schedule, time.Date(2026, time.October, 25, 12, 0, 0, 0, loc))
+	require.NoError(t, err)
+	require.False(t, active)
+}
+
+func TestDNDAllows(t *testing.T) {
+	allowed := chat1.ConversationID([]byte{1})
+	other := chat1.ConversationID([]byte{2})
+	schedule := chat1.ChatDNDSchedule{AllowConvIDs: []chat1.ConversationID{allowed}}
+	require.True(t, dndAllows(schedule, allowed, chat1.ConversationMembersType_TEAM))
+	require.False(t, dndAllows(schedule, other, chat1.ConversationMembersType_TEAM))
+	require.False(t, dndAllows(schedule, other, chat1.ConversationMembersType_IMPTEAMNATIVE))
+	schedule.AllowDMs = true
+	require.True(t, dndAllows(schedule, other, chat1.ConversationMembersType_IMPTEAMNATIVE))
+	require.False(t, dndAllows(schedule, other, chat1.ConversationMembersType_TEAM))
+}
+
+func TestNormalizeDNDSchedule(t *testing.T) {
+	convID := chat1.ConversationID(bytes.Repeat([]byte{1}, chat1.DbShortFormLen))
+	res, err := normalizeDNDSchedule(chat1.ChatDNDSchedule{
+		Enabled:      true,
+		Windows:      []chat1.ChatDNDWindow{{Weekdays: []int{5, 1, 5}, StartMinute: 60, EndMinute: 120}},
+		AllowConvIDs: []chat1.ConversationID{convID, nil, convID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 5}, res.Windows[0].Weekdays)
+	require.Equal(t, []chat1.ConversationID{convID}, res.AllowConvIDs)
+
+	invalid := []chat1.ChatDNDSchedule{
+		{Timezone: "Not/AZone"},
+		{Windows: []chat1.ChatDNDWindow{{Weekdays: []int{7}}}},
+		{Windows: []chat1.ChatDNDWindow{{Weekdays: []int{1}, EndMinute: 24 * 60}}},
+		{Windows: []chat1.ChatDNDWindow{{StartMinute: 60, EndMinute: 120}}},
+	}
+	for _, schedule := range invalid {
+		_, err := normalizeDNDSchedule(schedule)
+		require.Error(t, err, "%+v", schedule)
+	}
+}
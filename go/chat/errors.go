@@ -316,6 +316,30 @@ func (e RestrictedBotChannelError) IsImmediateFail() (chat1.OutboxErrorType, boo
 
 // =============================================================================
 
+// BotCommandArgsError is returned when a message invokes a bot command with
+// arguments that do not match the ones the bot advertised.
+type BotCommandArgsError struct {
+	Err error
+}
+
+func NewBotCommandArgsError(err error) BotCommandArgsError {
+	return BotCommandArgsError{Err: err}
+}
+
+func (e BotCommandArgsError) Error() string {
+	return fmt.Sprintf("invalid bot command: %s", e.Err)
+}
+
+func (e BotCommandArgsError) Unwrap() error {
+	return e.Err
+}
+
+func (e BotCommandArgsError) IsImmediateFail() (chat1.OutboxErrorType, bool) {
+	return chat1.OutboxErrorType_MISC, true
+}
+
+// =============================================================================
+
 type BoxingCryptKeysError struct {
 	Err error
 }
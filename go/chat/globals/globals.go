@@ -52,6 +52,13 @@ type ChatContext struct {
 	EmojiSource          types.EmojiSource                // emoji support
 	EphemeralTracker     types.EphemeralTracker           // tracking of ephemeral msg caches
 	ArchiveRegistry      types.ChatArchiveRegistry        // Metadata store of chat archives
+	WebhookDispatcher    types.WebhookDispatcher          // forward notifications to webhooks
+	WebhookServer        types.WebhookServer              // post webhook requests into conversations
+	ReplyThreadSource    types.ReplyThreadSource          // load reply threads and their unread counts
+	BookmarkManager      types.BookmarkManager            // private message bookmarks, synced between devices
+	HighlightManager     types.HighlightManager           // keywords that notify like mentions, synced between devices
+	// TeamUnfurlPolicySource is the unfurl policy set by the admins of a team
+	TeamUnfurlPolicySource types.TeamUnfurlPolicySource
 }
 
 func (c *ChatContext) Describe() string {
@@ -91,6 +98,8 @@ func (c *ChatContext) Describe() string {
   ParticipantSource %v,
   EmojiSource: %v
   EphemeralTracker: %v
+  WebhookDispatcher: %v
+  WebhookServer: %v
 }`,
 		c.CtxFactory != nil,
 		c.InboxSource != nil,
@@ -127,6 +136,8 @@ func (c *ChatContext) Describe() string {
 		c.ParticipantsSource != nil,
 		c.EmojiSource != nil,
 		c.EphemeralTracker != nil,
+		c.WebhookDispatcher != nil,
+		c.WebhookServer != nil,
 	)
 }
 
@@ -0,0 +1,234 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+const (
+	maxHighlights      = 100
+	maxHighlightLength = 256
+)
+
+// highlightMatcher checks messages against the highlights of the user.
+type highlightMatcher struct {
+	regexps []*regexp.Regexp
+}
+
+// keywordRegexp matches a keyword as a whole word, in any case.
+func keywordRegexp(keyword string) (*regexp.Regexp, error) {
+	return regexp.Compile(`(?i)(?:^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(keyword) + `(?:$|[^\p{L}\p{N}_])`)
+}
+
+func newHighlightMatcher(highlights chat1.ChatHighlights) (*highlightMatcher, error) {
+	var m highlightMatcher
+	for _, keyword := range highlights.Keywords {
+		re, err := keywordRegexp(keyword)
+		if err != nil {
+			return nil, err
+		}
+		m.regexps = append(m.regexps, re)
+	}
+	for _, expr := range highlights.Regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid highlight regex %q: %s", expr, err)
+		}
+		m.regexps = append(m.regexps, re)
+	}
+	return &m, nil
+}
+
+func (m *highlightMatcher) Match(text string) bool {
+	if len(text) == 0 {
+		return false
+	}
+	for _, re := range m.regexps {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightText is the text of a message that highlights are checked against.
+func highlightText(body chat1.MessageBody) string {
+	typ, err := body.MessageType()
+	if err != nil {
+		return ""
+	}
+	switch typ {
+	case chat1.MessageType_TEXT:
+		return body.Text().Body
+	case chat1.MessageType_ATTACHMENT:
+		return body.Attachment().Object.Title
+	default:
+		return ""
+	}
+}
+
+// normalizeHighlights drops blank and duplicate highlights, and checks that the
+// rest can be used.
+func normalizeHighlights(highlights chat1.ChatHighlights) (res chat1.ChatHighlights, err error) {
+	normalize := func(items []string, caseSensitive bool) (res []string, err error) {
+		seen := make(map[string]bool)
+		for _, item := range items {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				continue
+			}
+			if len(item) > maxHighlightLength {
+				return nil, fmt.Errorf("highlight is longer than %d characters: %s", maxHighlightLength, item)
+			}
+			key := item
+			if !caseSensitive {
+				key = strings.ToLower(item)
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			res = append(res, item)
+		}
+		return res, nil
+	}
+	if res.Keywords, err = normalize(highlights.Keywords, false); err != nil {
+		return res, err
+	}
+	if res.Regexes, err = normalize(highlights.Regexes, true); err != nil {
+		return res, err
+	}
+	if len(res.Keywords)+len(res.Regexes) > maxHighlights {
+		return res, fmt.Errorf("at most %d highlights are allowed", maxHighlights)
+	}
+	if _, err := newHighlightMatcher(res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+const (
+	highlightsStorageName = "__chat_highlights"
+	// highlightsCacheTime is how long a device goes without looking for
+	// highlights set on the other devices of the user.
+	highlightsCacheTime = time.Minute
+)
+
+type highlightsRecord struct {
+	Highlights chat1.ChatHighlights
+}
+
+// HighlightManager keeps the highlights of the user. Like bookmarks they live
+// in a conversation of the user with themselves, so they are encrypted and the
+// same on all of their devices.
+type HighlightManager struct {
+	globals.Contextified
+	utils.DebugLabeler
+	sync.Mutex
+
+	storage types.UserConversationBackedStorage
+
+	// the matcher of the current highlights, so they are not loaded and
+	// compiled again for every message
+	uid      gregor1.UID
+	matcher  *highlightMatcher
+	loadedAt time.Time
+	// gen changes with every Set, so a load which raced with it is dropped
+	gen int
+}
+
+var _ types.HighlightManager = (*HighlightManager)(nil)
+
+func NewHighlightManager(g *globals.Context, storage types.UserConversationBackedStorage) *HighlightManager {
+	return &HighlightManager{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "HighlightManager", false),
+		storage:      storage,
+	}
+}
+
+func (m *HighlightManager) Get(ctx context.Context, uid gregor1.UID) (res chat1.ChatHighlights, err error) {
+	defer m.Trace(ctx, &err, "Get")()
+	var record highlightsRecord
+	if _, err := m.storage.Get(ctx, uid, highlightsStorageName, &record); err != nil {
+		return res, err
+	}
+	return record.Highlights, nil
+}
+
+func (m *HighlightManager) Set(ctx context.Context, uid gregor1.UID, highlights chat1.ChatHighlights) (err error) {
+	defer m.Trace(ctx, &err, "Set")()
+	if highlights, err = normalizeHighlights(highlights); err != nil {
+		return err
+	}
+	matcher, err := newHighlightMatcher(highlights)
+	if err != nil {
+		return err
+	}
+	if err := m.storage.Put(ctx, uid, highlightsStorageName, highlightsRecord{Highlights: highlights}); err != nil {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.uid = uid
+	m.matcher = matcher
+	m.loadedAt = m.G().GetClock().Now()
+	m.gen++
+	return nil
+}
+
+// getMatcher returns the matcher of the highlights of uid. The highlights are
+// loaded without holding the lock, since that can go to the server.
+func (m *HighlightManager) getMatcher(ctx context.Context, uid gregor1.UID) (*highlightMatcher, error) {
+	m.Lock()
+	now := m.G().GetClock().Now()
+	if m.matcher != nil && m.uid.Eq(uid) && now.Sub(m.loadedAt) < highlightsCacheTime {
+		defer m.Unlock()
+		return m.matcher, nil
+	}
+	gen := m.gen
+	m.Unlock()
+
+	var record highlightsRecord
+	if _, err := m.storage.Get(ctx, uid, highlightsStorageName, &record); err != nil {
+		return nil, err
+	}
+	matcher, err := newHighlightMatcher(record.Highlights)
+	if err != nil {
+		return nil, err
+	}
+	m.Lock()
+	defer m.Unlock()
+	if m.gen == gen {
+		m.uid = uid
+		m.matcher = matcher
+		m.loadedAt = now
+	}
+	return matcher, nil
+}
+
+// IsHighlight checks a message against the highlights of the user, which
+// notify the same as an @-mention on desktop. Mobile pushes are sent by the
+// server, so they still follow the notification settings of the conversation.
+func (m *HighlightManager) IsHighlight(ctx context.Context, uid gregor1.UID, body chat1.MessageBody) bool {
+	text := highlightText(body)
+	if len(text) == 0 {
+		return false
+	}
+	matcher, err := m.getMatcher(ctx, uid)
+	if err != nil {
+		m.Debug(ctx, "IsHighlight: failed to load highlights: %v", err)
+		return false
+	}
+	return matcher.Match(text)
+}
@@ -0,0 +1,121 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/externalstest"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightMatcher(t *testing.T) {
+	m, err := newHighlightMatcher(chat1.ChatHighlights{
+		Keywords: []string{"outage", "c++"},
+		Regexes:  []string{`proj-\d+`},
+	})
+	require.NoError(t, err)
+	require.True(t, m.Match("big OUTAGE today"))
+	require.True(t, m.Match("outage"))
+	require.True(t, m.Match("(outage)"))
+	require.False(t, m.Match("outages everywhere"))
+	require.False(t, m.Match("the_outage_log"))
+	require.True(t, m.Match("who knows c++?"))
+	require.True(t, m.Match("see proj-42"))
+	require.False(t, m.Match("see proj-x"))
+	require.False(t, m.Match(""))
+
+	_, err = newHighlightMatcher(chat1.ChatHighlights{Regexes: []string{"("}})
+	require.Error(t, err)
+}
+
+func TestNormalizeHighlights(t *testing.T) {
+	res, err := normalizeHighlights(chat1.ChatHighlights{
+		Keywords: []string{" outage ", "Outage", "", "deploy"},
+		Regexes:  []string{"a+", "A+", "a+"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"outage", "deploy"}, res.Keywords)
+	require.Equal(t, []string{"a+", "A+"}, res.Regexes)
+
+	_, err = normalizeHighlights(chat1.ChatHighlights{Regexes: []string{"[a-"}})
+	require.Error(t, err)
+	_, err = normalizeHighlights(chat1.ChatHighlights{
+		Keywords: []string{strings.Repeat("x", maxHighlightLength+1)},
+	})
+	require.Error(t, err)
+	var many []string
+	for i := 0; i <= maxHighlights; i++ {
+		many = append(many, strings.Repeat("x", i+1))
+	}
+	_, err = normalizeHighlights(chat1.ChatHighlights{Keywords: many})
+	require.Error(t, err)
+}
+
+func TestHighlightText(t *testing.T) {
+	require.Equal(t, "hi", highlightText(chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hi"})))
+	require.Equal(t, "title", highlightText(chat1.NewMessageBodyWithAttachment(chat1.MessageAttachment{
+		Object: chat1.Asset{Title: "title"},
+	})))
+	require.Equal(t, "", highlightText(chat1.NewMessageBodyWithDelete(chat1.MessageDelete{})))
+}
+
+// memConvStorage keeps the records of a UserConversationBackedStorage in
+// memory, encoded the same way.
+type memConvStorage map[string][]byte
+
+func (s memConvStorage) Put(ctx context.Context, uid gregor1.UID, name string, data any) error {
+	dat, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	s[uid.String()+name] = dat
+	return nil
+}
+
+func (s memConvStorage) Get(ctx context.Context, uid gregor1.UID, name string, res any) (bool, error) {
+	dat, ok := s[uid.String()+name]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(dat, res)
+}
+
+func TestHighlightManager(t *testing.T) {
+	tc := externalstest.SetupTest(t, "highlights", 0)
+	defer tc.Cleanup()
+	clock := clockwork.NewFakeClock()
+	tc.G.SetClock(clock)
+
+	ctx := context.TODO()
+	uid := gregor1.UID([]byte{1})
+	storage := memConvStorage{}
+	m := NewHighlightManager(globals.NewContext(tc.G, &globals.ChatContext{}), storage)
+	text := func(body string) chat1.MessageBody {
+		return chat1.NewMessageBodyWithText(chat1.MessageText{Body: body})
+	}
+
+	highlights, err := m.Get(ctx, uid)
+	require.NoError(t, err)
+	require.Empty(t, highlights.Keywords)
+	require.False(t, m.IsHighlight(ctx, uid, text("outage")))
+
+	require.NoError(t, m.Set(ctx, uid, chat1.ChatHighlights{Keywords: []string{" outage "}}))
+	highlights, err = m.Get(ctx, uid)
+	require.NoError(t, err)
+	require.Equal(t, []string{"outage"}, highlights.Keywords)
+	require.True(t, m.IsHighlight(ctx, uid, text("big outage")))
+
+	// highlights set on another device are picked up after a while
+	other := NewHighlightManager(globals.NewContext(tc.G, &globals.ChatContext{}), storage)
+	require.NoError(t, other.Set(ctx, uid, chat1.ChatHighlights{Keywords: []string{"deploy"}}))
+	require.True(t, m.IsHighlight(ctx, uid, text("big outage")))
+	clock.Advance(highlightsCacheTime)
+	require.False(t, m.IsHighlight(ctx, uid, text("big outage")))
+	require.True(t, m.IsHighlight(ctx, uid, text("deploy now")))
+}
@@ -63,6 +63,18 @@ func checkMessageBoxedLength(msg chat1.MessageBoxed) error {
 	case chat1.MessageType_REQUESTPAYMENT:
 		return boxedFieldLengthChecker("REQUESTPAYMENT message", len(msg.BodyCiphertext.E),
 			BoxedRequestPaymentMessageBodyMaxLength)
+	case chat1.MessageType_POLL:
+		return boxedFieldLengthChecker("POLL message", len(msg.BodyCiphertext.E),
+			BoxedPollMessageBodyMaxLength)
+	case chat1.MessageType_POLLVOTE:
+		return boxedFieldLengthChecker("POLLVOTE message", len(msg.BodyCiphertext.E),
+			BoxedPollVoteMessageBodyMaxLength)
+	case chat1.MessageType_INTERACTIVE:
+		return boxedFieldLengthChecker("INTERACTIVE message", len(msg.BodyCiphertext.E),
+			BoxedInteractiveMessageBodyMaxLength)
+	case chat1.MessageType_INTERACTIVEACTION:
+		return boxedFieldLengthChecker("INTERACTIVEACTION message", len(msg.BodyCiphertext.E),
+			BoxedInteractiveActionMessageBodyMaxLength)
 	default:
 		return fmt.Errorf("unknown message type: %v", msg.GetMessageType())
 	}
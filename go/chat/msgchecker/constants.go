@@ -9,22 +9,36 @@ const (
 	HeadlineMaxLength           = 280
 	TopicMaxLength              = 20
 	RequestPaymentTextMaxLength = 240
+	PollQuestionMaxLength       = 280
+	PollOptionMaxLength         = 140
+	PollMinOptions              = 2
+	PollMaxOptions              = 20
+	InteractiveIDMaxLength      = 64
+	InteractiveLabelMaxLength   = 140
+	InteractivePayloadMaxLength = 256
+	InteractiveMaxElements      = 10
+	// Buttons plus select options, each is a numbered choice in the CLI
+	InteractiveMaxChoices = 25
 )
 
 const (
-	BoxedTextMessageBodyMaxLength           = 11000
-	DevBoxedTextMessageBodyMaxLength        = 1100000
-	BoxedEditMessageBodyMaxLength           = 11000
-	BoxedReactionMessageBodyMaxLength       = 10000
-	BoxedHeadlineMessageBodyMaxLength       = 380
-	BoxedMetadataMessageBodyMaxLength       = 200
-	BoxedJoinMessageBodyMaxLength           = 200
-	BoxedLeaveMessageBodyMaxLength          = 200
-	BoxedSystemMessageBodyMaxLength         = 5000
-	BoxedDeleteHistoryMessageBodyMaxLength  = 200
-	BoxedSendPaymentMessageBodyMaxLength    = 200
-	BoxedRequestPaymentMessageBodyMaxLength = 500
-	BoxedSanityLength                       = 5000000
+	BoxedTextMessageBodyMaxLength              = 11000
+	DevBoxedTextMessageBodyMaxLength           = 1100000
+	BoxedEditMessageBodyMaxLength              = 11000
+	BoxedReactionMessageBodyMaxLength          = 10000
+	BoxedHeadlineMessageBodyMaxLength          = 380
+	BoxedMetadataMessageBodyMaxLength          = 200
+	BoxedJoinMessageBodyMaxLength              = 200
+	BoxedLeaveMessageBodyMaxLength             = 200
+	BoxedSystemMessageBodyMaxLength            = 5000
+	BoxedDeleteHistoryMessageBodyMaxLength     = 200
+	BoxedSendPaymentMessageBodyMaxLength       = 200
+	BoxedRequestPaymentMessageBodyMaxLength    = 500
+	BoxedPollMessageBodyMaxLength              = 5000
+	BoxedPollVoteMessageBodyMaxLength          = 500
+	BoxedInteractiveMessageBodyMaxLength       = 30000
+	BoxedInteractiveActionMessageBodyMaxLength = 500
+	BoxedSanityLength                          = 5000000
 )
 
 func getMaxTextLength(topicType chat1.TopicType) (textMsgLength int) {
@@ -110,6 +110,14 @@ func checkMessagePlaintextLength(msg chat1.MessagePlaintext) error {
 	case chat1.MessageType_REQUESTPAYMENT:
 		return plaintextFieldLengthChecker("request payment note",
 			len(msg.MessageBody.Requestpayment().Note), RequestPaymentTextMaxLength)
+	case chat1.MessageType_POLL:
+		return checkPoll(msg.MessageBody.Poll())
+	case chat1.MessageType_POLLVOTE:
+		return checkPollVote(msg.MessageBody.Pollvote())
+	case chat1.MessageType_INTERACTIVE:
+		return checkInteractive(msg.MessageBody.Interactive(), textMsgLength)
+	case chat1.MessageType_INTERACTIVEACTION:
+		return checkInteractiveAction(msg.MessageBody.Interactiveaction())
 	default:
 		typ, err := msg.MessageBody.MessageType()
 		if err != nil {
@@ -119,6 +127,122 @@ func checkMessagePlaintextLength(msg chat1.MessagePlaintext) error {
 	}
 }
 
+func checkPoll(poll chat1.MessagePoll) error {
+	if len(poll.Question) == 0 {
+		return errors.New("poll question cannot be empty")
+	}
+	if err := plaintextFieldLengthChecker("poll question", len(poll.Question), PollQuestionMaxLength); err != nil {
+		return err
+	}
+	if len(poll.Options) < PollMinOptions || len(poll.Options) > PollMaxOptions {
+		return fmt.Errorf("a poll needs between %d and %d options", PollMinOptions, PollMaxOptions)
+	}
+	for _, option := range poll.Options {
+		if len(option) == 0 {
+			return errors.New("poll options cannot be empty")
+		}
+		if err := plaintextFieldLengthChecker("poll option", len(option), PollOptionMaxLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkPollVote(vote chat1.MessagePollVote) error {
+	seen := make(map[int]bool, len(vote.Choices))
+	for _, choice := range vote.Choices {
+		if choice < 0 || choice >= PollMaxOptions {
+			return fmt.Errorf("invalid poll choice: %d", choice)
+		}
+		if seen[choice] {
+			return fmt.Errorf("duplicate poll choice: %d", choice)
+		}
+		seen[choice] = true
+	}
+	return nil
+}
+
+func checkInteractiveChoice(label, payload string) error {
+	if len(label) == 0 {
+		return errors.New("interactive labels cannot be empty")
+	}
+	if err := plaintextFieldLengthChecker("interactive label", len(label), InteractiveLabelMaxLength); err != nil {
+		return err
+	}
+	if len(payload) == 0 {
+		return errors.New("interactive payloads cannot be empty")
+	}
+	return plaintextFieldLengthChecker("interactive payload", len(payload), InteractivePayloadMaxLength)
+}
+
+func checkInteractive(msg chat1.MessageInteractive, textMsgLength int) error {
+	if err := plaintextFieldLengthChecker("interactive message", len(msg.Body), textMsgLength); err != nil {
+		return err
+	}
+	if len(msg.Elements) == 0 || len(msg.Elements) > InteractiveMaxElements {
+		return fmt.Errorf("an interactive message needs between 1 and %d elements", InteractiveMaxElements)
+	}
+	var choices int
+	ids := make(map[string]bool, len(msg.Elements))
+	for _, elem := range msg.Elements {
+		if len(elem.Id) == 0 {
+			return errors.New("interactive element ids cannot be empty")
+		}
+		if err := plaintextFieldLengthChecker("interactive element id", len(elem.Id), InteractiveIDMaxLength); err != nil {
+			return err
+		}
+		if ids[elem.Id] {
+			return fmt.Errorf("duplicate interactive element id: %s", elem.Id)
+		}
+		ids[elem.Id] = true
+		switch elem.Typ {
+		case chat1.InteractiveElementType_BUTTON:
+			if len(elem.Options) > 0 {
+				return fmt.Errorf("button %s cannot have options", elem.Id)
+			}
+			if err := checkInteractiveChoice(elem.Label, elem.Payload); err != nil {
+				return err
+			}
+			choices++
+		case chat1.InteractiveElementType_SELECT:
+			if len(elem.Options) == 0 {
+				return fmt.Errorf("select %s needs at least one option", elem.Id)
+			}
+			if len(elem.Payload) > 0 {
+				return fmt.Errorf("select %s cannot have a payload, set it on its options", elem.Id)
+			}
+			if err := plaintextFieldLengthChecker("interactive label", len(elem.Label), InteractiveLabelMaxLength); err != nil {
+				return err
+			}
+			for _, opt := range elem.Options {
+				if err := checkInteractiveChoice(opt.Label, opt.Payload); err != nil {
+					return err
+				}
+			}
+			choices += len(elem.Options)
+		default:
+			return fmt.Errorf("unknown interactive element type: %v", elem.Typ)
+		}
+	}
+	if choices > InteractiveMaxChoices {
+		return fmt.Errorf("an interactive message can have at most %d buttons and options", InteractiveMaxChoices)
+	}
+	return nil
+}
+
+func checkInteractiveAction(action chat1.MessageInteractiveAction) error {
+	if action.MessageID == 0 {
+		return errors.New("interactive action needs a message ID")
+	}
+	if action.BotUID.IsNil() {
+		return errors.New("interactive action needs a bot")
+	}
+	if err := plaintextFieldLengthChecker("interactive element id", len(action.ElementID), InteractiveIDMaxLength); err != nil {
+		return err
+	}
+	return plaintextFieldLengthChecker("interactive payload", len(action.Payload), InteractivePayloadMaxLength)
+}
+
 func CheckMessagePlaintext(msg chat1.MessagePlaintext) error {
 	return checkMessagePlaintextLength(msg)
 }
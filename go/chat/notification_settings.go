@@ -32,6 +32,12 @@ func getGlobalAppNotificationSettings(ctx context.Context, g *globals.Context, r
 		return res, err
 	}
 	settings.Settings[chat1.GlobalAppNotificationSetting_CONVERTHEIC] = convertHeic
+
+	stripMetadata, err := utils.GetGregorBoolFromState(state, utils.StripMetadataGregorKey, true)
+	if err != nil {
+		return res, err
+	}
+	settings.Settings[chat1.GlobalAppNotificationSetting_STRIPMETADATA] = stripMetadata
 	return settings, nil
 }
 
@@ -60,6 +66,11 @@ func setGlobalAppNotificationSettings(ctx context.Context, g *globals.Context, r
 			if err != nil {
 				return err
 			}
+		case chat1.GlobalAppNotificationSetting_STRIPMETADATA:
+			err = utils.SetGregorBool(ctx, g, utils.StripMetadataGregorKey, v)
+			if err != nil {
+				return err
+			}
 		default:
 			settings.Settings[gkey] = v
 		}
@@ -403,6 +403,10 @@ func (g *PushHandler) shouldDisplayDesktopNotification(ctx context.Context,
 	if !utils.GetConversationStatusBehavior(conv.Info.Status).DesktopNotifications {
 		return false
 	}
+	if IsDoNotDisturb(ctx, g.G(), conv.GetConvID(), conv.GetMembersType()) {
+		g.Debug(ctx, "shouldDisplayDesktopNotification: suppressed by do not disturb")
+		return false
+	}
 	if msg.IsValid() {
 		// No notifications for our own messages
 		if msg.Valid().ClientHeader.Sender.Eq(uid) {
@@ -447,6 +451,9 @@ func (g *PushHandler) shouldDisplayDesktopNotification(ctx context.Context,
 					break
 				}
 			}
+			if kind == chat1.NotificationKind_GENERIC && g.G().HighlightManager.IsHighlight(ctx, uid, body) {
+				kind = chat1.NotificationKind_ATMENTION
+			}
 			chanMention := msg.Valid().ChannelMention
 			notifyFromChanMention := false
 			switch chanMention {
@@ -572,6 +579,11 @@ func (g *PushHandler) Activity(ctx context.Context, m gregor.OutOfBandMessage) (
 				return nil
 			}
 
+			// Team settings such as the unfurl policy live in dev conversations
+			if nm.TopicType == chat1.TopicType_DEV {
+				g.G().TeamUnfurlPolicySource.Invalidate(ctx, nm.Message.ClientHeader.Conv.Tlfid)
+			}
+
 			// Update typing status to stopped
 			g.typingMonitor.Update(ctx, chat1.TyperInfo{
 				Uid:      keybase1.UID(nm.Message.ClientHeader.Sender.String()),
@@ -1148,6 +1160,11 @@ func (g *PushHandler) SetConvRetention(ctx context.Context, m gregor.OutOfBandMe
 		if conv == nil {
 			return nil
 		}
+		// Team settings are only trusted if just admins can write to the dev
+		// conversation, so a change of its settings can change the policy
+		if conv.GetTopicType() == chat1.TopicType_DEV {
+			g.G().TeamUnfurlPolicySource.Invalidate(ctx, conv.Info.Triple.Tlfid)
+		}
 		// Send notify for the conv
 		g.G().ActivityNotifier.SetConvRetention(ctx, uid,
 			conv.GetConvID(), conv.GetTopicType(), g.presentUIItem(ctx, conv, uid,
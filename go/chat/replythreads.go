@@ -0,0 +1,147 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/storage"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// maxReplyThreadDepth bounds how far we follow reply pointers, both up to the
+// root of a thread and down through replies the thread index doesn't know
+// about yet.
+const maxReplyThreadDepth = 50
+
+// ReplyThreadSource loads reply threads using the thread index in local
+// storage. Replies that predate the index are found through the reply
+// pointers on the messages themselves.
+type ReplyThreadSource struct {
+	globals.Contextified
+	utils.DebugLabeler
+}
+
+var _ types.ReplyThreadSource = (*ReplyThreadSource)(nil)
+
+func NewReplyThreadSource(g *globals.Context) *ReplyThreadSource {
+	return &ReplyThreadSource{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "ReplyThreadSource", false),
+	}
+}
+
+func (s *ReplyThreadSource) getMessages(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	msgIDs []chat1.MessageID,
+) ([]chat1.MessageUnboxed, error) {
+	reason := chat1.GetThreadReason_GENERAL
+	return s.G().ConvSource.GetMessages(ctx, convID, uid, msgIDs, &reason, nil, true)
+}
+
+func (s *ReplyThreadSource) replyTo(msg chat1.MessageUnboxed) *chat1.MessageID {
+	if !msg.IsValidFull() {
+		return nil
+	}
+	body := msg.Valid().MessageBody
+	if body.IsType(chat1.MessageType_TEXT) && body.Text().ReplyTo != nil && *body.Text().ReplyTo > 0 {
+		return body.Text().ReplyTo
+	}
+	return nil
+}
+
+// findRoot follows the reply pointers up from msg to the start of its thread.
+func (s *ReplyThreadSource) findRoot(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	msg chat1.MessageUnboxed,
+) (chat1.MessageUnboxed, error) {
+	for i := 0; i < maxReplyThreadDepth; i++ {
+		parentID := s.replyTo(msg)
+		if parentID == nil {
+			return msg, nil
+		}
+		parents, err := s.getMessages(ctx, uid, convID, []chat1.MessageID{*parentID})
+		if err != nil {
+			return msg, err
+		}
+		if len(parents) == 0 || !parents[0].IsValidFull() {
+			// the parent is gone, so this is as far up as the thread goes
+			return msg, nil
+		}
+		msg = parents[0]
+	}
+	return msg, nil
+}
+
+func (s *ReplyThreadSource) GetThread(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	msgID chat1.MessageID,
+) (root chat1.MessageUnboxed, replies []chat1.MessageUnboxed, err error) {
+	defer s.Trace(ctx, &err, "GetThread(%s,%d)", convID, msgID)()
+	rootID, indexed, serr := storage.New(s.G(), s.G().ConvSource).GetReplyThread(ctx, convID, uid, msgID)
+	if serr != nil {
+		s.Debug(ctx, "GetThread: failed to read thread index, using reply pointers: %s", serr)
+		rootID = msgID
+	}
+	msgs, err := s.getMessages(ctx, uid, convID, []chat1.MessageID{rootID})
+	if err != nil {
+		return root, nil, err
+	}
+	if len(msgs) == 0 || !msgs[0].IsValidFull() {
+		return root, nil, fmt.Errorf("message not found: %d", rootID)
+	}
+	if root, err = s.findRoot(ctx, uid, convID, msgs[0]); err != nil {
+		return root, nil, err
+	}
+	if root.GetMessageID() != rootID {
+		if _, indexed, serr = storage.New(s.G(), s.G().ConvSource).GetReplyThread(ctx, convID, uid,
+			root.GetMessageID()); serr != nil {
+			indexed = nil
+		}
+	}
+
+	// Start from the replies in the index, and pick up any the index missed
+	// from the reply pointers on the messages we load.
+	seen := map[chat1.MessageID]bool{root.GetMessageID(): true}
+	var pending []chat1.MessageID
+	queue := func(msgIDs []chat1.MessageID) {
+		for _, msgID := range msgIDs {
+			if !seen[msgID] {
+				seen[msgID] = true
+				pending = append(pending, msgID)
+			}
+		}
+	}
+	queue(indexed)
+	queue(root.Valid().ServerHeader.Replies)
+	for i := 0; i < maxReplyThreadDepth && len(pending) > 0; i++ {
+		batch := pending
+		pending = nil
+		msgs, err := s.getMessages(ctx, uid, convID, batch)
+		if err != nil {
+			return root, nil, err
+		}
+		for _, msg := range msgs {
+			if !msg.IsValidFull() || msg.GetMessageType() == chat1.MessageType_DELETE {
+				continue
+			}
+			replies = append(replies, msg)
+			queue(msg.Valid().ServerHeader.Replies)
+		}
+	}
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].GetMessageID() < replies[j].GetMessageID()
+	})
+	return root, replies, nil
+}
+
+func (s *ReplyThreadSource) ThreadUnreads(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	readMsgID chat1.MessageID,
+) ([]chat1.ThreadUnread, error) {
+	res, err := storage.New(s.G(), s.G().ConvSource).GetThreadUnreads(ctx, convID, uid, readMsgID)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
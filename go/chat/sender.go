@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/keybase/client/go/chat/attachments"
@@ -15,6 +16,7 @@ import (
 	"github.com/keybase/client/go/chat/types"
 	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/engine"
+	"github.com/keybase/client/go/ephemeral"
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/chat1"
 	"github.com/keybase/client/go/protocol/gregor1"
@@ -699,6 +701,41 @@ func (s *BlockingSender) getUsernamesForMentions(ctx context.Context, _ gregor1.
 	}
 }
 
+// handleBotCommand parses the arguments of text messages invoking a bot command
+// that declares typed arguments, and attaches them to the message for the bot.
+// Commands missing from the cache are sent as plain text.
+func (s *BlockingSender) handleBotCommand(ctx context.Context, msg chat1.MessagePlaintext,
+	conv *chat1.ConversationLocal,
+) (chat1.MessagePlaintext, error) {
+	if conv == nil || msg.ClientHeader.Conv.TopicType != chat1.TopicType_CHAT ||
+		msg.ClientHeader.MessageType != chat1.MessageType_TEXT || !msg.MessageBody.IsType(chat1.MessageType_TEXT) {
+		return msg, nil
+	}
+	text := msg.MessageBody.Text()
+	if !strings.HasPrefix(text.Body, "!") {
+		return msg, nil
+	}
+	// Only look at the cached commands, which the UI keeps up to date while
+	// the conversation is open, so sending never waits on the update loop.
+	cmds, _, err := s.G().BotCommandManager.ListCommands(ctx, conv.GetConvID())
+	if err != nil {
+		s.Debug(ctx, "handleBotCommand: failed to list commands: %s", err)
+		return msg, nil
+	}
+	cmd, ok := bots.MatchCommand(cmds, text.Body)
+	if !ok || len(cmd.Args) == 0 {
+		return msg, nil
+	}
+	invocation, err := bots.ParseCommandArgs(cmd, text.Body)
+	if err != nil {
+		return msg, NewBotCommandArgsError(err)
+	}
+	newBody := text.DeepCopy()
+	newBody.BotCommand = &invocation
+	msg.MessageBody = chat1.NewMessageBodyWithText(newBody)
+	return msg, nil
+}
+
 func (s *BlockingSender) handleMentions(ctx context.Context, uid gregor1.UID, msg chat1.MessagePlaintext,
 	conv *chat1.ConversationLocal,
 ) (res chat1.MessagePlaintext, atMentions []gregor1.UID, chanMention chat1.ChannelMention, err error) {
@@ -921,6 +958,23 @@ func (s *BlockingSender) Prepare(ctx context.Context, plaintext chat1.MessagePla
 				s.Debug(ctx, "Prepare: error getting superseder ephemeral metadata: %s", err)
 				return res, err
 			}
+
+			// Enforce the exploding policy of the team and channel before we box
+			// anything. A mandatory team policy applies even if the channel has
+			// overridden its retention policy.
+			policy := conv.GetEphemeralPolicy()
+			if policy != nil && !policy.AllowNonExploding && msg.EphemeralMetadata() == nil &&
+				chat1.IsEphemeralNonSupersederType(msg.ClientHeader.MessageType) {
+				s.Debug(ctx, "Prepare: setting ephemeral lifetime from policy: %v", policy.Age)
+				msg.ClientHeader.EphemeralMetadata = &chat1.MsgEphemeralMetadata{
+					Lifetime: policy.Age,
+				}
+			}
+			if err := ephemeral.CheckMessagePolicy(policy, msg.ClientHeader.MessageType,
+				msg.EphemeralMetadata()); err != nil {
+				s.Debug(ctx, "Prepare: message violates ephemeral policy: %s", err)
+				return res, err
+			}
 		}
 	}
 
@@ -948,6 +1002,10 @@ func (s *BlockingSender) Prepare(ctx context.Context, plaintext chat1.MessagePla
 		s.Debug(ctx, "Prepare: error handling mentions: %s", err)
 		return res, err
 	}
+	if msg, err = s.handleBotCommand(ctx, msg, conv); err != nil {
+		s.Debug(ctx, "Prepare: error handling bot command: %s", err)
+		return res, err
+	}
 
 	// encrypt the message
 	skp, err := s.getSigningKeyPair(ctx)
@@ -971,12 +1029,29 @@ func (s *BlockingSender) Prepare(ctx context.Context, plaintext chat1.MessagePla
 		id := conv.GetConvID()
 		convID = &id
 	}
+	// Interactive messages come from restricted bots, and the actions on them
+	// are keyed for that bot alone, so no other bot of the team sees them.
+	isInteractiveAction := msg.MessageBody.IsType(chat1.MessageType_INTERACTIVEACTION)
+	switch {
+	case msg.MessageBody.IsType(chat1.MessageType_INTERACTIVE):
+		if err := s.checkRestrictedBot(ctx, uid, msg, membersType); err != nil {
+			s.Debug(ctx, "Prepare: interactive message not from a restricted bot: %s", err)
+			return res, err
+		}
+	case isInteractiveAction:
+		botUID := msg.MessageBody.Interactiveaction().BotUID
+		if err := s.checkRestrictedBot(ctx, botUID, msg, membersType); err != nil {
+			s.Debug(ctx, "Prepare: interactive action not for a restricted bot: %s", err)
+			return res, err
+		}
+		msg.ClientHeader.BotUID = &botUID
+	}
 	botUIDs, err := s.applyTeamBotSettings(ctx, uid, &msg, convID, membersType, atMentions, opts)
 	if err != nil {
 		s.Debug(ctx, "Prepare: failed to apply team bot settings: %s", err)
 		return res, err
 	}
-	if len(botUIDs) > 0 {
+	if len(botUIDs) > 0 && !isInteractiveAction {
 		// TODO HOTPOT-330 Add support for "hidden" messages for multiple bots
 		msg.ClientHeader.BotUID = &botUIDs[0]
 	}
@@ -1004,6 +1079,28 @@ func (s *BlockingSender) Prepare(ctx context.Context, plaintext chat1.MessagePla
 	}, nil
 }
 
+// checkRestrictedBot returns an error unless botUID is a restricted bot of the
+// team msg is sent to.
+func (s *BlockingSender) checkRestrictedBot(ctx context.Context, botUID gregor1.UID,
+	msg chat1.MessagePlaintext, membersType chat1.ConversationMembersType,
+) error {
+	if membersType == chat1.ConversationMembersType_KBFS {
+		return errors.New("interactive messages are not supported in KBFS conversations")
+	}
+	teamBotSettings, err := CreateNameInfoSource(ctx, s.G(), membersType).TeamBotSettings(ctx,
+		msg.ClientHeader.TlfName, msg.ClientHeader.Conv.Tlfid, membersType, msg.ClientHeader.TlfPublic)
+	if err != nil {
+		return err
+	}
+	for uv := range teamBotSettings {
+		if botUID.Eq(gregor1.UID(uv.Uid.ToBytes())) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a restricted bot of the conversation, interactive messages are only for restricted bots",
+		botUID)
+}
+
 func (s *BlockingSender) applyTeamBotSettings(ctx context.Context, uid gregor1.UID,
 	msg *chat1.MessagePlaintext, convID *chat1.ConversationID, membersType chat1.ConversationMembersType,
 	atMentions []gregor1.UID, opts chat1.SenderPrepareOptions,
@@ -325,6 +325,7 @@ func setupTest(t *testing.T, numUsers int) (context.Context, *kbtest.ChatMockWor
 	g.StellarSender = types.DummyStellarSender{}
 	g.TeamMentionLoader = types.DummyTeamMentionLoader{}
 	g.JourneyCardManager = NewJourneyCardManager(g, getRI)
+	g.ReplyThreadSource = NewReplyThreadSource(g)
 	g.BotCommandManager = types.DummyBotCommandManager{}
 	g.CommandsSource = commands.NewSource(g)
 	g.CoinFlipManager = NewFlipManager(g, getRI)
@@ -332,6 +333,7 @@ func setupTest(t *testing.T, numUsers int) (context.Context, *kbtest.ChatMockWor
 	g.UIInboxLoader = types.DummyUIInboxLoader{}
 	g.UIThreadLoader = NewUIThreadLoader(g, getRI)
 	g.ParticipantsSource = types.DummyParticipantSource{}
+	g.TeamUnfurlPolicySource = NewTeamUnfurlPolicySource(g, getRI)
 	g.EmojiSource = NewDevConvEmojiSource(g, getRI)
 
 	return ctx, world, ri, sender, baseSender, &listener
@@ -18,6 +18,7 @@ import (
 
 	"github.com/keybase/client/go/chat/attachments"
 	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/msgchecker"
 	"github.com/keybase/client/go/chat/search"
 	"github.com/keybase/client/go/chat/storage"
 	"github.com/keybase/client/go/chat/types"
@@ -354,9 +355,10 @@ func (h *Server) GetInboxAndUnboxUILocal(ctx context.Context, arg chat1.GetInbox
 	default:
 		return res, err
 	}
+	convs := utils.PresentConversationLocals(ctx, h.G(), uid, ib.Convs, utils.PresentParticipantsModeInclude)
+	utils.AttachThreadUnreads(ctx, h.G(), uid, convs)
 	return chat1.GetInboxAndUnboxUILocalRes{
-		Conversations: utils.PresentConversationLocals(ctx, h.G(), uid, ib.Convs,
-			utils.PresentParticipantsModeInclude),
+		Conversations:    convs,
 		IdentifyFailures: identBreaks,
 	}, nil
 }
@@ -743,6 +745,67 @@ func (h *Server) GetMessagesLocal(ctx context.Context, arg chat1.GetMessagesLoca
 	}, nil
 }
 
+func (h *Server) GetReplyThreadLocal(ctx context.Context, arg chat1.GetReplyThreadLocalArg) (res chat1.GetReplyThreadLocalRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, &identBreaks, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetReplyThreadLocal(%s,%d)", arg.ConvID, arg.MsgID)()
+	defer func() { h.setResultRateLimit(ctx, &res) }()
+	defer func() { err = h.handleOfflineError(ctx, err, &res) }()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	root, replies, err := h.G().ReplyThreadSource.GetThread(ctx, uid, arg.ConvID, arg.MsgID)
+	if err != nil {
+		return res, err
+	}
+	return chat1.GetReplyThreadLocalRes{
+		RootMsgID:        root.GetMessageID(),
+		Messages:         append([]chat1.MessageUnboxed{root}, replies...),
+		IdentifyFailures: identBreaks,
+	}, nil
+}
+
+func (h *Server) GetChatBookmarksLocal(ctx context.Context, identifyBehavior keybase1.TLFIdentifyBehavior) (res chat1.GetChatBookmarksLocalRes, err error) {
+	var identBreaks []keybase1.TLFIdentifyFailure
+	ctx = globals.ChatCtx(ctx, h.G(), identifyBehavior, &identBreaks, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetChatBookmarksLocal")()
+	defer func() { h.setResultRateLimit(ctx, &res) }()
+	defer func() { err = h.handleOfflineError(ctx, err, &res) }()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	bookmarks, err := h.G().BookmarkManager.List(ctx, uid)
+	if err != nil {
+		return res, err
+	}
+	return chat1.GetChatBookmarksLocalRes{
+		Bookmarks:        bookmarks,
+		IdentifyFailures: identBreaks,
+	}, nil
+}
+
+func (h *Server) AddChatBookmarkLocal(ctx context.Context, arg chat1.AddChatBookmarkLocalArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "AddChatBookmarkLocal(%s,%d)", arg.ConvID, arg.MsgID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return h.G().BookmarkManager.Add(ctx, uid, arg.ConvID, arg.MsgID, arg.Note)
+}
+
+func (h *Server) RemoveChatBookmarkLocal(ctx context.Context, arg chat1.RemoveChatBookmarkLocalArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "RemoveChatBookmarkLocal(%s,%d)", arg.ConvID, arg.MsgID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return h.G().BookmarkManager.Remove(ctx, uid, arg.ConvID, arg.MsgID)
+}
+
 func (h *Server) GetNextAttachmentMessageLocal(ctx context.Context,
 	arg chat1.GetNextAttachmentMessageLocalArg,
 ) (res chat1.GetNextAttachmentMessageLocalRes, err error) {
@@ -1271,7 +1334,7 @@ func (h *Server) PostFileAttachmentLocalNonblock(ctx context.Context,
 		return res, err
 	}
 	_, err = h.G().AttachmentUploader.Register(ctx, uid, arg.Arg.ConversationID, outboxID, arg.Arg.Title,
-		arg.Arg.Filename, nil, arg.Arg.CallerPreview)
+		arg.Arg.Filename, nil, arg.Arg.CallerPreview, arg.Arg.KeepMetadata)
 	if err != nil {
 		return res, err
 	}
@@ -1297,7 +1360,7 @@ func (h *Server) PostFileAttachmentLocal(ctx context.Context, arg chat1.PostFile
 	sender := NewBlockingSender(h.G(), h.boxer, h.remoteClient)
 	_, msgID, err := attachments.NewSender(h.G()).PostFileAttachment(ctx, sender, uid, arg.Arg.ConversationID,
 		arg.Arg.TlfName, arg.Arg.Visibility, arg.Arg.OutboxID, arg.Arg.Filename, arg.Arg.Title,
-		arg.Arg.Metadata, 0, arg.Arg.EphemeralLifetime, arg.Arg.CallerPreview)
+		arg.Arg.Metadata, 0, arg.Arg.EphemeralLifetime, arg.Arg.CallerPreview, arg.Arg.KeepMetadata)
 	if err != nil {
 		return res, err
 	}
@@ -1823,6 +1886,7 @@ func (h *Server) GetTLFConversationsLocal(ctx context.Context, arg chat1.GetTLFC
 		return res, err
 	}
 	res.Convs = utils.PresentConversationLocals(ctx, h.G(), uid, convs, utils.PresentParticipantsModeInclude)
+	utils.AttachThreadUnreads(ctx, h.G(), uid, res.Convs)
 	res.Offline = h.G().InboxSource.IsOffline(ctx)
 	return res, nil
 }
@@ -1981,6 +2045,50 @@ func (h *Server) GetGlobalAppNotificationSettingsLocal(ctx context.Context) (res
 	return getGlobalAppNotificationSettings(ctx, h.G(), h.remoteClient)
 }
 
+func (h *Server) SetChatHighlightsLocal(ctx context.Context, highlights chat1.ChatHighlights) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetChatHighlightsLocal")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return h.G().HighlightManager.Set(ctx, uid, highlights)
+}
+
+func (h *Server) GetChatHighlightsLocal(ctx context.Context) (res chat1.ChatHighlights, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetChatHighlightsLocal")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return h.G().HighlightManager.Get(ctx, uid)
+}
+
+func (h *Server) SetChatDNDScheduleLocal(ctx context.Context, schedule chat1.ChatDNDSchedule) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetChatDNDScheduleLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return err
+	}
+	return setChatDNDSchedule(ctx, h.G(), schedule)
+}
+
+func (h *Server) GetChatDNDScheduleLocal(ctx context.Context) (res chat1.GetChatDNDScheduleLocalRes, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetChatDNDScheduleLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return res, err
+	}
+	if res.Schedule, err = getChatDNDSchedule(ctx, h.G()); err != nil {
+		return res, err
+	}
+	if res.Active, err = dndActive(res.Schedule, h.G().GetClock().Now()); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
 func (h *Server) AddTeamMemberAfterReset(ctx context.Context,
 	arg chat1.AddTeamMemberAfterResetArg,
 ) (err error) {
@@ -2740,6 +2848,26 @@ func (h *Server) SaveUnfurlSettings(ctx context.Context, arg chat1.SaveUnfurlSet
 	})
 }
 
+func (h *Server) SetTeamUnfurlPolicy(ctx context.Context, arg chat1.SetTeamUnfurlPolicyArg) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "SetTeamUnfurlPolicy: teamID: %s mode: %v", arg.TeamID, arg.Policy.Mode)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return err
+	}
+	return h.G().TeamUnfurlPolicySource.Set(ctx, uid, arg.TeamID, arg.Policy)
+}
+
+func (h *Server) GetTeamUnfurlPolicy(ctx context.Context, teamID keybase1.TeamID) (res chat1.TeamUnfurlPolicy, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetTeamUnfurlPolicy: teamID: %s", teamID)()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	return h.G().TeamUnfurlPolicySource.Get(ctx, uid, teamID)
+}
+
 func (h *Server) ToggleMessageCollapse(ctx context.Context, arg chat1.ToggleMessageCollapseArg) (err error) {
 	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
 	defer h.Trace(ctx, &err, "ToggleMessageCollapse convID=%s msgID=%d collapsed=%v",
@@ -3525,7 +3653,7 @@ const welcomeMessageName = "__welcome_message"
 // values in sync!
 const welcomeMessageMaxLen = 400
 
-func getWelcomeMessageConv(ctx context.Context, g *globals.Context, uid gregor1.UID, teamID keybase1.TeamID) (res types.RemoteConversation, err error) {
+func getTeamSettingsConv(ctx context.Context, g *globals.Context, uid gregor1.UID, teamID keybase1.TeamID) (res types.RemoteConversation, err error) {
 	onePerTlf := true
 	tlfID := chat1.TLFID(teamID.ToBytes())
 	topicType := chat1.TopicType_CHAT
@@ -3547,7 +3675,7 @@ func getWelcomeMessageConv(ctx context.Context, g *globals.Context, uid gregor1.
 }
 
 func getWelcomeMessage(ctx context.Context, g *globals.Context, ri func() chat1.RemoteInterface, uid gregor1.UID, teamID keybase1.TeamID) (message chat1.WelcomeMessageDisplay, err error) {
-	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	conv, err := getTeamSettingsConv(ctx, g, uid, teamID)
 	if err != nil {
 		return message, err
 	}
@@ -3575,7 +3703,7 @@ func setWelcomeMessage(ctx context.Context, g *globals.Context, ri func() chat1.
 	if len(message.Raw) > welcomeMessageMaxLen {
 		return fmt.Errorf("welcome message must be at most %d characters; was %d", welcomeMessageMaxLen, len(message.Raw))
 	}
-	conv, err := getWelcomeMessageConv(ctx, g, uid, teamID)
+	conv, err := getTeamSettingsConv(ctx, g, uid, teamID)
 	if err != nil {
 		return err
 	}
@@ -4179,3 +4307,131 @@ func (h *Server) ArchiveChatResume(ctx context.Context, arg chat1.ArchiveChatRes
 
 	return h.G().ArchiveRegistry.Resume(ctx, arg.JobID)
 }
+
+func (h *Server) ScheduleMessageLocal(ctx context.Context, arg chat1.ScheduleMessageLocalArg) (res chat1.ScheduledMessage, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), arg.IdentifyBehavior, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ScheduleMessageLocal")()
+	uid, err := utils.AssertLoggedInUID(ctx, h.G())
+	if err != nil {
+		return res, err
+	}
+	if !arg.Msg.MessageBody.IsType(chat1.MessageType_TEXT) {
+		return res, errors.New("only text messages can be scheduled")
+	}
+	sendTime := arg.SendTime.Time()
+	if !sendTime.After(time.Now()) {
+		return res, fmt.Errorf("send time %v is in the past", sendTime.Format(time.RFC1123))
+	}
+	conv, err := utils.GetVerifiedConv(ctx, h.G(), uid, arg.ConversationID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	arg.Msg.ClientHeader.MessageType = chat1.MessageType_TEXT
+	arg.Msg.ClientHeader.Conv = conv.Info.Triple
+	arg.Msg.ClientHeader.TlfName = conv.Info.TlfName
+	arg.Msg.ClientHeader.TlfPublic = conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC
+	if err := msgchecker.CheckMessagePlaintext(arg.Msg); err != nil {
+		return res, err
+	}
+	return h.G().MessageDeliverer.Schedule(ctx, arg.ConversationID, arg.Msg, sendTime, arg.IdentifyBehavior)
+}
+
+func (h *Server) GetScheduledMessagesLocal(ctx context.Context, convID *chat1.ConversationID) (res []chat1.ScheduledMessage, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "GetScheduledMessagesLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return nil, err
+	}
+	return h.G().MessageDeliverer.ScheduledMessages(ctx, convID)
+}
+
+func (h *Server) CancelScheduledMessageLocal(ctx context.Context, outboxID chat1.OutboxID) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "CancelScheduledMessageLocal: %s", outboxID)()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return err
+	}
+	return h.G().MessageDeliverer.CancelScheduled(ctx, outboxID)
+}
+
+func (h *Server) AddChatWebhookLocal(ctx context.Context, arg chat1.AddChatWebhookLocalArg) (res chat1.ChatWebhook, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "AddChatWebhookLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return res, err
+	}
+	return h.G().WebhookDispatcher.AddHook(ctx, chat1.ChatWebhook{
+		Url:            arg.Url,
+		FilterChannels: arg.FilterChannels,
+		FilterConvIDs:  arg.FilterConvIDs,
+		Convs:          arg.Convs,
+		Wallet:         arg.Wallet,
+	})
+}
+
+func (h *Server) ListChatWebhooksLocal(ctx context.Context) (res []chat1.ChatWebhook, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ListChatWebhooksLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return nil, err
+	}
+	return h.G().WebhookDispatcher.Hooks(ctx)
+}
+
+func (h *Server) RemoveChatWebhookLocal(ctx context.Context, id string) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "RemoveChatWebhookLocal: %s", id)()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return err
+	}
+	return h.G().WebhookDispatcher.RemoveHook(ctx, id)
+}
+
+func (h *Server) AddChatIncomingWebhookLocal(ctx context.Context, arg chat1.AddChatIncomingWebhookLocalArg) (res chat1.ChatIncomingWebhook, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "AddChatIncomingWebhookLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return res, err
+	}
+	return h.G().WebhookServer.AddHook(ctx, chat1.ChatIncomingWebhook{
+		ConvID:    arg.ConvID,
+		Template:  arg.Template,
+		RateLimit: arg.RateLimit,
+	})
+}
+
+func (h *Server) ListChatIncomingWebhooksLocal(ctx context.Context) (res []chat1.ChatIncomingWebhook, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "ListChatIncomingWebhooksLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return nil, err
+	}
+	return h.G().WebhookServer.Hooks(ctx)
+}
+
+func (h *Server) RemoveChatIncomingWebhookLocal(ctx context.Context, id string) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "RemoveChatIncomingWebhookLocal: %s", id)()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return err
+	}
+	return h.G().WebhookServer.RemoveHook(ctx, id)
+}
+
+func (h *Server) StartChatWebhookServerLocal(ctx context.Context, port int) (res string, err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "StartChatWebhookServerLocal: %d", port)()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return res, err
+	}
+	return h.G().WebhookServer.Serve(ctx, port)
+}
+
+func (h *Server) StopChatWebhookServerLocal(ctx context.Context) (err error) {
+	ctx = globals.ChatCtx(ctx, h.G(), keybase1.TLFIdentifyBehavior_CHAT_GUI, nil, h.identNotifier)
+	defer h.Trace(ctx, &err, "StopChatWebhookServerLocal")()
+	if _, err = utils.AssertLoggedInUID(ctx, h.G()); err != nil {
+		return err
+	}
+	return h.G().WebhookServer.StopServing(ctx)
+}
@@ -489,6 +489,11 @@ func (c *chatTestContext) as(t *testing.T, user *kbtest.FakeUser) *chatTestUserC
 	g.EmojiSource = NewDevConvEmojiSource(g, func() chat1.RemoteInterface { return ri })
 	g.EphemeralTracker = NewEphemeralTracker(g)
 	g.EphemeralTracker.Start(context.TODO(), uid)
+	g.ReplyThreadSource = NewReplyThreadSource(g)
+	convStorage := NewDevConversationBackedStorage(g, func() chat1.RemoteInterface { return ri })
+	g.BookmarkManager = NewBookmarkManager(g, convStorage)
+	g.HighlightManager = NewHighlightManager(g, convStorage)
+	g.TeamUnfurlPolicySource = NewTeamUnfurlPolicySource(g, func() chat1.RemoteInterface { return ri })
 
 	tc.G.ChatHelper = NewHelper(g, func() chat1.RemoteInterface { return ri })
 
@@ -8102,6 +8107,108 @@ func TestTeamBotSettings(t *testing.T) {
 	})
 }
 
+func TestInteractiveActionKeyedForBot(t *testing.T) {
+	runWithMemberTypes(t, func(mt chat1.ConversationMembersType) {
+		ctc := makeChatTestContext(t, "InteractiveActionKeyedForBot", 3)
+		defer ctc.cleanup()
+		users := ctc.users()
+		tc := ctc.as(t, users[0])
+		ctx := tc.startCtx
+		listener := newServerChatListener()
+		tc.h.G().NotifyRouter.AddListener(listener)
+
+		created := mustCreateConversationForTest(t, ctc, users[0], chat1.TopicType_CHAT, mt)
+		interactive := chat1.MessageInteractive{
+			Body: "deploy?",
+			Elements: []chat1.InteractiveElement{{Typ: chat1.InteractiveElementType_BUTTON, Id: "yes", Label: "Yes",
+				Payload: "deploy"}},
+		}
+		post := func(user *kbtest.FakeUser, body chat1.MessageBody) (chat1.PostLocalRes, error) {
+			typ, err := body.MessageType()
+			require.NoError(t, err)
+			return ctc.as(t, user).chatLocalHandler().PostLocal(ctc.as(t, user).startCtx, chat1.PostLocalArg{
+				ConversationID: created.Id,
+				Msg: chat1.MessagePlaintext{
+					ClientHeader: chat1.MessageClientHeader{
+						Conv:        created.Triple,
+						TlfName:     created.TlfName,
+						MessageType: typ,
+					},
+					MessageBody: body,
+				},
+			})
+		}
+
+		// only restricted bots send interactive messages
+		_, err := post(users[0], chat1.NewMessageBodyWithInteractive(interactive))
+		require.Error(t, err)
+		if mt == chat1.ConversationMembersType_KBFS {
+			return
+		}
+
+		teamID, err := keybase1.TeamIDFromString(created.Triple.Tlfid.String())
+		require.NoError(t, err)
+		bot, other := users[1], users[2]
+		botUID := gregor1.UID(bot.User.GetUID().ToBytes())
+		otherUID := gregor1.UID(other.User.GetUID().ToBytes())
+		for i, botua := range []*kbtest.FakeUser{bot, other} {
+			err = tc.chatLocalHandler().AddBotMember(ctx, chat1.AddBotMemberArg{
+				ConvID:      created.Id,
+				Username:    botua.Username,
+				Role:        keybase1.TeamRole_RESTRICTEDBOT,
+				BotSettings: &keybase1.TeamBotSettings{},
+			})
+			require.NoError(t, err)
+			expectedSeqno := keybase1.Seqno(3 + 2*i)
+			for found := false; !found; {
+				select {
+				case teamChange := <-listener.teamChangedByID:
+					found = teamChange.TeamID == teamID && teamChange.LatestSeqno == expectedSeqno
+				case <-time.After(20 * time.Second):
+					require.Fail(t, "no team change")
+				}
+			}
+		}
+
+		res, err := post(bot, chat1.NewMessageBodyWithInteractive(interactive))
+		require.NoError(t, err)
+		action := chat1.MessageInteractiveAction{
+			MessageID: res.MessageID,
+			BotUID:    botUID,
+			ElementID: "yes",
+			Payload:   "deploy",
+		}
+		actionRes, err := post(users[0], chat1.NewMessageBodyWithInteractiveaction(action))
+		require.NoError(t, err)
+
+		// an action for someone who is not a restricted bot can't be keyed
+		// for them alone
+		action.BotUID = gregor1.UID(users[0].User.GetUID().ToBytes())
+		_, err = post(users[0], chat1.NewMessageBodyWithInteractiveaction(action))
+		require.Error(t, err)
+
+		findAction := func(user *kbtest.FakeUser, uid gregor1.UID) *chat1.MessageUnboxed {
+			tv, err := ctc.world.Tcs[user.Username].Context().ConvSource.Pull(ctc.as(t, user).startCtx,
+				created.Id, uid, chat1.GetThreadReason_GENERAL, nil, nil, nil)
+			require.NoError(t, err)
+			for _, msg := range tv.Messages {
+				if msg.GetMessageID() == actionRes.MessageID {
+					return &msg
+				}
+			}
+			return nil
+		}
+		msg := findAction(bot, botUID)
+		require.NotNil(t, msg)
+		require.True(t, msg.IsValid())
+		require.True(t, msg.Valid().MessageBody.IsType(chat1.MessageType_INTERACTIVEACTION))
+		// the other bot of the team can't read it
+		if msg := findAction(other, otherUID); msg != nil {
+			require.False(t, msg.IsValid())
+		}
+	})
+}
+
 // Filter out journey cards. The test doesn't need to know about them. Mutates thread.Messages
 func filterOutJourneycards(thread *chat1.ThreadView) {
 	filtered := thread.Messages[:0]
@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+)
+
+const scheduledOutboxVersion = 1
+
+type diskScheduledOutbox struct {
+	Version int                      `codec:"V"`
+	Records []chat1.ScheduledMessage `codec:"S"`
+}
+
+// ScheduledOutbox holds the messages scheduled to be sent in the future from
+// this device. Like the outbox, it lives in the local chat db only, so a
+// scheduled message is only sent while the device that scheduled it is
+// running.
+type ScheduledOutbox struct {
+	globals.Contextified
+	utils.DebugLabeler
+	*baseBox
+
+	uid   gregor1.UID
+	clock clockwork.Clock
+}
+
+func NewScheduledOutbox(g *globals.Context, uid gregor1.UID) *ScheduledOutbox {
+	return &ScheduledOutbox{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "ScheduledOutbox", false),
+		baseBox:      newBaseBox(g),
+		uid:          uid,
+		clock:        clockwork.NewRealClock(),
+	}
+}
+
+func (o *ScheduledOutbox) SetClock(cl clockwork.Clock) {
+	o.clock = cl
+}
+
+func (o *ScheduledOutbox) dbKey() libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatOutbox,
+		Key: fmt.Sprintf("sob:%s", o.uid),
+	}
+}
+
+func (o *ScheduledOutbox) clear(ctx context.Context) Error {
+	err := o.G().LocalChatDb.Delete(o.dbKey())
+	if err != nil {
+		return NewInternalError(ctx, o.DebugLabeler, "error clearing scheduled outbox: uid: %s err: %s",
+			o.uid, err)
+	}
+	return nil
+}
+
+func (o *ScheduledOutbox) readStorage(ctx context.Context) (res diskScheduledOutbox, err Error) {
+	found, ierr := o.readDiskBox(ctx, o.dbKey(), &res)
+	if ierr != nil {
+		if _, ok := ierr.(libkb.LoginRequiredError); ok {
+			return res, MiscError{Msg: ierr.Error()}
+		}
+		return res, NewInternalError(ctx, o.DebugLabeler, "failure to read scheduled outbox: %s", ierr)
+	}
+	if !found {
+		return diskScheduledOutbox{Version: scheduledOutboxVersion}, nil
+	}
+	if res.Version != scheduledOutboxVersion {
+		o.Debug(ctx, "on disk version not equal to program version, clearing: disk :%d program: %d",
+			res.Version, scheduledOutboxVersion)
+		if cerr := o.clear(ctx); cerr != nil {
+			return res, cerr
+		}
+		return diskScheduledOutbox{Version: scheduledOutboxVersion}, nil
+	}
+	return res, nil
+}
+
+func (o *ScheduledOutbox) writeStorage(ctx context.Context, sob diskScheduledOutbox) Error {
+	sort.SliceStable(sob.Records, func(i, j int) bool {
+		return sob.Records[i].SendTime < sob.Records[j].SendTime
+	})
+	if ierr := o.writeDiskBox(ctx, o.dbKey(), sob); ierr != nil {
+		return NewInternalError(ctx, o.DebugLabeler, "error writing scheduled outbox: err: %s", ierr)
+	}
+	return nil
+}
+
+// ScheduleMessage stores msg to be sent to convID at sendTime. The outbox ID
+// of the record is kept when the message is queued, so clients can match the
+// message that eventually gets sent to what they scheduled.
+func (o *ScheduledOutbox) ScheduleMessage(ctx context.Context, convID chat1.ConversationID,
+	msg chat1.MessagePlaintext, sendTime time.Time, identifyBehavior keybase1.TLFIdentifyBehavior,
+) (rec chat1.ScheduledMessage, err Error) {
+	locks.ScheduledOutbox.Lock()
+	defer locks.ScheduledOutbox.Unlock()
+	sob, err := o.readStorage(ctx)
+	if err != nil {
+		return rec, err
+	}
+	outboxID, ierr := NewOutboxID()
+	if ierr != nil {
+		return rec, NewInternalError(ctx, o.DebugLabeler, "error getting outboxID: err: %s", ierr)
+	}
+	msg.ClientHeader.OutboxID = &outboxID
+	rec = chat1.ScheduledMessage{
+		OutboxID:         outboxID,
+		ConvID:           convID,
+		SendTime:         gregor1.ToTime(sendTime),
+		Ctime:            gregor1.ToTime(o.clock.Now()),
+		Msg:              msg,
+		IdentifyBehavior: identifyBehavior,
+	}
+	sob.Records = append(sob.Records, rec)
+	if err := o.writeStorage(ctx, sob); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
+
+// ScheduledMessages returns the pending scheduled messages in the order they
+// are due, optionally limited to one conversation.
+func (o *ScheduledOutbox) ScheduledMessages(ctx context.Context, convID *chat1.ConversationID) (res []chat1.ScheduledMessage, err Error) {
+	locks.ScheduledOutbox.Lock()
+	defer locks.ScheduledOutbox.Unlock()
+	sob, err := o.readStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range sob.Records {
+		if convID == nil || rec.ConvID.Eq(*convID) {
+			res = append(res, rec.DeepCopy())
+		}
+	}
+	return res, nil
+}
+
+// DueMessages returns the scheduled messages whose send time has passed. They
+// stay in the scheduled outbox until removed with RemoveMessage.
+func (o *ScheduledOutbox) DueMessages(ctx context.Context) (res []chat1.ScheduledMessage, err Error) {
+	all, err := o.ScheduledMessages(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	now := gregor1.ToTime(o.clock.Now())
+	for _, rec := range all {
+		if rec.SendTime > now {
+			break
+		}
+		res = append(res, rec)
+	}
+	return res, nil
+}
+
+// NextSendTime returns when the next scheduled message is due, if there is one.
+func (o *ScheduledOutbox) NextSendTime(ctx context.Context) (res time.Time, ok bool, err Error) {
+	all, err := o.ScheduledMessages(ctx, nil)
+	if err != nil || len(all) == 0 {
+		return res, false, err
+	}
+	return all[0].SendTime.Time(), true, nil
+}
+
+// RestoreMessage puts back a message taken out with RemoveMessage, to try
+// sending it again later.
+func (o *ScheduledOutbox) RestoreMessage(ctx context.Context, rec chat1.ScheduledMessage) Error {
+	locks.ScheduledOutbox.Lock()
+	defer locks.ScheduledOutbox.Unlock()
+	sob, err := o.readStorage(ctx)
+	if err != nil {
+		return err
+	}
+	sob.Records = append(sob.Records, rec)
+	return o.writeStorage(ctx, sob)
+}
+
+func (o *ScheduledOutbox) RemoveMessage(ctx context.Context, outboxID chat1.OutboxID) Error {
+	locks.ScheduledOutbox.Lock()
+	defer locks.ScheduledOutbox.Unlock()
+	sob, err := o.readStorage(ctx)
+	if err != nil {
+		return err
+	}
+	var recs []chat1.ScheduledMessage
+	for _, rec := range sob.Records {
+		if !rec.OutboxID.Eq(&outboxID) {
+			recs = append(recs, rec)
+		}
+	}
+	if len(recs) == len(sob.Records) {
+		return MissError{Msg: fmt.Sprintf("no scheduled message with outboxID: %s", outboxID)}
+	}
+	sob.Records = recs
+	return o.writeStorage(ctx, sob)
+}
@@ -41,6 +41,7 @@ type Storage struct {
 	idtracker    *msgIDTracker
 	breakTracker *breakTracker
 	delhTracker  *delhTracker
+	threads      *threadTracker
 	assetDeleter AssetDeleter
 	clock        clockwork.Clock
 }
@@ -73,6 +74,7 @@ func New(g *globals.Context, assetDeleter AssetDeleter) *Storage {
 		idtracker:    newMsgIDTracker(g),
 		breakTracker: newBreakTracker(g),
 		delhTracker:  newDelhTracker(g),
+		threads:      newThreadTracker(g),
 		assetDeleter: assetDeleter,
 		clock:        clockwork.NewRealClock(),
 		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "Storage", false),
@@ -350,6 +352,9 @@ func (s *Storage) maybeNukeLocked(ctx context.Context, force bool, err Error, co
 		if err := s.G().EphemeralTracker.Clear(ctx, convID, uid); err != nil {
 			s.Debug(ctx, "failed to clear ephemeral tracker storage: %s", err)
 		}
+		if err := s.threads.clear(convID, uid); err != nil {
+			s.Debug(ctx, "failed to clear thread index: %s", err)
+		}
 	}
 	return err
 }
@@ -455,6 +460,12 @@ func (s *Storage) MergeHelper(ctx context.Context,
 	res.UnfurlTargets = updateRes.unfurlTargets
 	res.RepliesAffected = updateRes.repliesAffected
 
+	// Update the thread index, it is rebuilt from the replies as they come in
+	// so a failure here doesn't need to fail the merge
+	if err := s.threads.update(ctx, convID, uid, msgs); err != nil {
+		s.Debug(ctx, "MergeHelper: failed to update thread index: %s", err)
+	}
+
 	if err = s.updateMinDeletableMessage(ctx, convID, uid, msgs); err != nil {
 		return res, s.maybeNukeLocked(ctx, false, err, convID, uid)
 	}
@@ -589,12 +600,12 @@ func (s *Storage) updateAllSupersededBy(ctx context.Context, convID chat1.Conver
 						Msg:         newMsg,
 						IsMapDelete: false,
 					}
-				case chat1.MessageType_REACTION:
+				case chat1.MessageType_REACTION, chat1.MessageType_POLLVOTE:
 					// If we haven't modified any reaction data, we don't want
 					// to send it up for a notification.
 					var reactionUpdate bool
 					// reactions don't update SupersededBy, instead they rely
-					// on ReactionIDs
+					// on ReactionIDs. Poll votes are tracked the same way.
 					mvalid.ServerHeader.ReactionIDs, reactionUpdate = s.updateReactionIDs(mvalid.ServerHeader.ReactionIDs, msgid)
 					newMsg := chat1.NewMessageUnboxedWithValid(mvalid)
 					newMsgMap[newMsg.GetMessageID()] = newMsg
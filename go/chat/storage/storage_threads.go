@@ -0,0 +1,277 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// threadTracker keeps an index from the root of each reply thread in a
+// conversation to the replies in it. Replies only point at their direct
+// parent, so a reply to a reply is filed under the root of its parent, or
+// under the parent itself until the parent is indexed.
+type threadTracker struct {
+	globals.Contextified
+	utils.DebugLabeler
+}
+
+type threadTrackerEntry struct {
+	StorageVersion int `codec:"v"`
+	// Replies in each thread, keyed by the root message and sorted ascending
+	Threads map[chat1.MessageID][]chat1.MessageID `codec:"t"`
+	// The root message of each reply
+	Roots map[chat1.MessageID]chat1.MessageID `codec:"r"`
+}
+
+const threadTrackerDiskVersion = 1
+
+func newThreadTracker(g *globals.Context) *threadTracker {
+	return &threadTracker{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "ThreadTracker", false),
+	}
+}
+
+func (e *threadTrackerEntry) rootOf(msgID chat1.MessageID) chat1.MessageID {
+	if root, ok := e.Roots[msgID]; ok {
+		return root
+	}
+	return msgID
+}
+
+// addReply files replyID under the thread of parentID, and reports whether
+// the entry changed. Pages of a conversation can be stored newest first, so
+// replyID may already be the root of replies to it that came in earlier;
+// those move over to the thread of parentID.
+func (e *threadTrackerEntry) addReply(replyID, parentID chat1.MessageID) bool {
+	if _, ok := e.Roots[replyID]; ok {
+		return false
+	}
+	if e.Threads == nil {
+		e.Threads = make(map[chat1.MessageID][]chat1.MessageID)
+	}
+	if e.Roots == nil {
+		e.Roots = make(map[chat1.MessageID]chat1.MessageID)
+	}
+	root := e.rootOf(parentID)
+	replies := insertMsgID(e.Threads[root], replyID)
+	e.Roots[replyID] = root
+	for _, msgID := range e.Threads[replyID] {
+		replies = insertMsgID(replies, msgID)
+		e.Roots[msgID] = root
+	}
+	delete(e.Threads, replyID)
+	e.Threads[root] = replies
+	return true
+}
+
+// insertMsgID adds msgID to the sorted msgIDs.
+func insertMsgID(msgIDs []chat1.MessageID, msgID chat1.MessageID) []chat1.MessageID {
+	index := sort.Search(len(msgIDs), func(i int) bool { return msgIDs[i] >= msgID })
+	msgIDs = append(msgIDs, 0)
+	copy(msgIDs[index+1:], msgIDs[index:])
+	msgIDs[index] = msgID
+	return msgIDs
+}
+
+// remove takes msgID out of its thread. Deleting a root drops the whole
+// thread, since it can no longer be shown as a unit.
+func (e *threadTrackerEntry) remove(msgID chat1.MessageID) bool {
+	if replies, ok := e.Threads[msgID]; ok {
+		for _, replyID := range replies {
+			delete(e.Roots, replyID)
+		}
+		delete(e.Threads, msgID)
+		return true
+	}
+	root, ok := e.Roots[msgID]
+	if !ok {
+		return false
+	}
+	delete(e.Roots, msgID)
+	replies := e.Threads[root]
+	for i, replyID := range replies {
+		if replyID == msgID {
+			replies = append(replies[:i], replies[i+1:]...)
+			break
+		}
+	}
+	if len(replies) == 0 {
+		delete(e.Threads, root)
+	} else {
+		e.Threads[root] = replies
+	}
+	return true
+}
+
+// unreads counts the replies after readMsgID in each thread.
+func (e *threadTrackerEntry) unreads(readMsgID chat1.MessageID) (res []chat1.ThreadUnread) {
+	for root, replies := range e.Threads {
+		index := sort.Search(len(replies), func(i int) bool { return replies[i] > readMsgID })
+		if count := len(replies) - index; count > 0 {
+			res = append(res, chat1.ThreadUnread{RootMsgID: root, UnreadCount: count})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].RootMsgID < res[j].RootMsgID })
+	return res
+}
+
+func (t *threadTracker) makeDbKey(convID chat1.ConversationID, uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatBlocks,
+		Key: fmt.Sprintf("threads:%s:%s", uid, convID),
+	}
+}
+
+func (t *threadTracker) getEntry(ctx context.Context,
+	convID chat1.ConversationID, uid gregor1.UID,
+) (threadTrackerEntry, Error) {
+	var blank threadTrackerEntry
+	var res threadTrackerEntry
+
+	dbKey := t.makeDbKey(convID, uid)
+	raw, found, err := t.G().LocalChatDb.GetRaw(dbKey)
+	if err != nil {
+		return res, NewInternalError(ctx, t.DebugLabeler, "GetRaw error: %s", err.Error())
+	}
+	if !found {
+		return res, MissError{}
+	}
+
+	err = decode(raw, &res)
+	if err != nil {
+		return blank, NewInternalError(ctx, t.DebugLabeler, "decode error: %s", err.Error())
+	}
+	switch res.StorageVersion {
+	case threadTrackerDiskVersion:
+		return res, nil
+	default:
+		// ignore other versions
+		return blank, MissError{}
+	}
+}
+
+func (t *threadTracker) setEntry(ctx context.Context,
+	convID chat1.ConversationID, uid gregor1.UID, entry threadTrackerEntry,
+) Error {
+	entry.StorageVersion = threadTrackerDiskVersion
+	data, err := encode(entry)
+	if err != nil {
+		return NewInternalError(ctx, t.DebugLabeler, "encode error: %s", err.Error())
+	}
+
+	dbKey := t.makeDbKey(convID, uid)
+	err = t.G().LocalChatDb.PutRaw(dbKey, data)
+	if err != nil {
+		return NewInternalError(ctx, t.DebugLabeler, "PutRaw error: %s", err.Error())
+	}
+	return nil
+}
+
+func (t *threadTracker) getOrMakeEntry(ctx context.Context,
+	convID chat1.ConversationID, uid gregor1.UID,
+) (threadTrackerEntry, Error) {
+	entry, err := t.getEntry(ctx, convID, uid)
+	switch err.(type) {
+	case nil:
+	case MissError:
+	default:
+		return entry, err
+	}
+	return entry, nil
+}
+
+// update files any new replies in msgs under their threads, and drops deleted
+// messages from them.
+func (t *threadTracker) update(ctx context.Context, convID chat1.ConversationID, uid gregor1.UID,
+	msgs []chat1.MessageUnboxed,
+) Error {
+	// No need to use transaction here since the Storage class takes lock.
+	sorted := make([]chat1.MessageUnboxed, len(msgs))
+	copy(sorted, msgs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetMessageID() < sorted[j].GetMessageID()
+	})
+	var entry threadTrackerEntry
+	loaded, changed := false, false
+	for _, msg := range sorted {
+		if !msg.IsValid() {
+			continue
+		}
+		body := msg.Valid().MessageBody
+		var parentID *chat1.MessageID
+		var deletes []chat1.MessageID
+		switch {
+		case body.IsType(chat1.MessageType_TEXT) && body.Text().ReplyTo != nil && *body.Text().ReplyTo > 0:
+			parentID = body.Text().ReplyTo
+		case body.IsType(chat1.MessageType_DELETE):
+			deletes = body.Delete().MessageIDs
+		default:
+			continue
+		}
+		if !loaded {
+			var err Error
+			if entry, err = t.getOrMakeEntry(ctx, convID, uid); err != nil {
+				return err
+			}
+			loaded = true
+		}
+		if parentID != nil && entry.addReply(msg.GetMessageID(), *parentID) {
+			changed = true
+		}
+		for _, msgID := range deletes {
+			if entry.remove(msgID) {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return t.setEntry(ctx, convID, uid, entry)
+}
+
+func (t *threadTracker) clear(convID chat1.ConversationID, uid gregor1.UID) error {
+	return t.G().LocalChatDb.Delete(t.makeDbKey(convID, uid))
+}
+
+// GetReplyThread returns the root of the thread msgID belongs to, and the
+// replies in that thread known to local storage.
+func (s *Storage) GetReplyThread(ctx context.Context, convID chat1.ConversationID, uid gregor1.UID,
+	msgID chat1.MessageID,
+) (root chat1.MessageID, replies []chat1.MessageID, err Error) {
+	var ierr error
+	defer s.Trace(ctx, &ierr, "GetReplyThread")()
+	defer func() { ierr = s.castInternalError(err) }()
+	lock := locks.StorageLockTab.AcquireOnName(ctx, s.G(), convID.String())
+	defer lock.Release(ctx)
+
+	entry, err := s.threads.getOrMakeEntry(ctx, convID, uid)
+	if err != nil {
+		return msgID, nil, err
+	}
+	root = entry.rootOf(msgID)
+	replies = append([]chat1.MessageID(nil), entry.Threads[root]...)
+	return root, replies, nil
+}
+
+// GetThreadUnreads returns the number of replies after readMsgID in each
+// thread of the conversation.
+func (s *Storage) GetThreadUnreads(ctx context.Context, convID chat1.ConversationID, uid gregor1.UID,
+	readMsgID chat1.MessageID,
+) (res []chat1.ThreadUnread, err Error) {
+	lock := locks.StorageLockTab.AcquireOnName(ctx, s.G(), convID.String())
+	defer lock.Release(ctx)
+
+	entry, err := s.threads.getOrMakeEntry(ctx, convID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return entry.unreads(readMsgID), nil
+}
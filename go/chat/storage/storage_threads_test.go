@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadTrackerEntry(t *testing.T) {
+	var entry threadTrackerEntry
+	require.True(t, entry.addReply(5, 2))
+	require.True(t, entry.addReply(3, 2))
+	// a reply to a reply belongs to the thread of its parent
+	require.True(t, entry.addReply(8, 5))
+	require.True(t, entry.addReply(9, 4))
+	require.False(t, entry.addReply(8, 5))
+
+	require.Equal(t, []chat1.MessageID{3, 5, 8}, entry.Threads[2])
+	require.Equal(t, chat1.MessageID(2), entry.rootOf(8))
+	require.Equal(t, chat1.MessageID(2), entry.rootOf(2))
+	require.Equal(t, chat1.MessageID(4), entry.rootOf(9))
+
+	require.Equal(t, []chat1.ThreadUnread{
+		{RootMsgID: 2, UnreadCount: 2},
+		{RootMsgID: 4, UnreadCount: 1},
+	}, entry.unreads(4))
+	require.Equal(t, []chat1.ThreadUnread{{RootMsgID: 4, UnreadCount: 1}}, entry.unreads(8))
+	require.Nil(t, entry.unreads(9))
+
+	require.True(t, entry.remove(5))
+	require.False(t, entry.remove(5))
+	require.Equal(t, []chat1.MessageID{3, 8}, entry.Threads[2])
+	require.True(t, entry.remove(9))
+	require.NotContains(t, entry.Threads, chat1.MessageID(4))
+
+	// removing the root drops the whole thread
+	require.True(t, entry.remove(2))
+	require.Empty(t, entry.Threads)
+	require.Empty(t, entry.Roots)
+}
+
+func TestThreadTrackerEntryOutOfOrder(t *testing.T) {
+	// newer pages are merged before older ones, so replies to replies come in
+	// before their parents
+	var entry threadTrackerEntry
+	require.True(t, entry.addReply(9, 6))
+	require.True(t, entry.addReply(8, 5))
+	require.True(t, entry.addReply(7, 6))
+	require.Equal(t, []chat1.MessageID{7, 9}, entry.Threads[6])
+	require.Equal(t, []chat1.MessageID{8}, entry.Threads[5])
+
+	require.True(t, entry.addReply(6, 5))
+	require.True(t, entry.addReply(5, 2))
+	require.Equal(t, map[chat1.MessageID][]chat1.MessageID{2: {5, 6, 7, 8, 9}}, entry.Threads)
+	for _, msgID := range []chat1.MessageID{5, 6, 7, 8, 9} {
+		require.Equal(t, chat1.MessageID(2), entry.rootOf(msgID))
+	}
+	require.Equal(t, []chat1.ThreadUnread{{RootMsgID: 2, UnreadCount: 3}}, entry.unreads(6))
+
+	// deleting the root still drops all of it
+	require.True(t, entry.remove(2))
+	require.Empty(t, entry.Threads)
+	require.Empty(t, entry.Roots)
+}
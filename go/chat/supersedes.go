@@ -146,6 +146,21 @@ func (t *basicSupersedesTransform) transformReaction(msg chat1.MessageUnboxed, s
 	return &newMsg
 }
 
+func (t *basicSupersedesTransform) transformPollVote(msg chat1.MessageUnboxed, superMsg chat1.MessageUnboxed) *chat1.MessageUnboxed {
+	if !msg.IsValid() {
+		return nil
+	}
+	if superMsg.Valid().MessageBody.IsNil() {
+		return &msg
+	}
+	mvalid := msg.Valid()
+	if !utils.AddPollVote(&mvalid, superMsg.Valid()) {
+		return &msg
+	}
+	newMsg := chat1.NewMessageUnboxedWithValid(mvalid)
+	return &newMsg
+}
+
 func (t *basicSupersedesTransform) transformUnfurl(msg chat1.MessageUnboxed, superMsg chat1.MessageUnboxed) *chat1.MessageUnboxed {
 	if !msg.IsValid() {
 		return nil
@@ -186,6 +201,8 @@ func (t *basicSupersedesTransform) transform(ctx context.Context, msg chat1.Mess
 			newMsg = t.transformReaction(*newMsg, superMsg)
 		case chat1.MessageType_UNFURL:
 			newMsg = t.transformUnfurl(*newMsg, superMsg)
+		case chat1.MessageType_POLLVOTE:
+			newMsg = t.transformPollVote(*newMsg, superMsg)
 		}
 
 		t.Debug(ctx, "transformed: original:%v super:%v -> %v",
@@ -0,0 +1,149 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/unfurl"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+const (
+	teamUnfurlPolicyName = "__unfurl_team_policy"
+	// teamUnfurlPolicyCacheTime bounds how long a cached policy is used, in
+	// case the push invalidating it was missed.
+	teamUnfurlPolicyCacheTime = 10 * time.Minute
+)
+
+type teamUnfurlPolicyCacheEntry struct {
+	policy   chat1.TeamUnfurlPolicy
+	loadedAt time.Time
+}
+
+// TeamUnfurlPolicySource keeps a team's unfurl policy in an admin only dev
+// conversation, the same way as the team welcome message. Policies not
+// written by an admin are ignored. Since the policy is checked for every
+// outgoing message with a URL it is cached per team, until a message or a
+// settings change in one of the team's dev conversations invalidates it.
+type TeamUnfurlPolicySource struct {
+	globals.Contextified
+	utils.DebugLabeler
+	sync.Mutex
+
+	ri    func() chat1.RemoteInterface
+	cache map[string]teamUnfurlPolicyCacheEntry
+	// gen changes with every invalidation, so a load which raced with it is
+	// not cached
+	gen int
+}
+
+var _ unfurl.TeamPolicySource = (*TeamUnfurlPolicySource)(nil)
+var _ types.TeamUnfurlPolicySource = (*TeamUnfurlPolicySource)(nil)
+
+func NewTeamUnfurlPolicySource(g *globals.Context, ri func() chat1.RemoteInterface) *TeamUnfurlPolicySource {
+	return &TeamUnfurlPolicySource{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "TeamUnfurlPolicySource", false),
+		ri:           ri,
+		cache:        make(map[string]teamUnfurlPolicyCacheEntry),
+	}
+}
+
+func (s *TeamUnfurlPolicySource) storage() *ConvDevConversationBackedStorage {
+	return NewConvDevConversationBackedStorage(s.G(), chat1.TopicType_DEV, true /* adminOnly */, s.ri)
+}
+
+func (s *TeamUnfurlPolicySource) get(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID) (res chat1.TeamUnfurlPolicy, err error) {
+	found, _, err := s.storage().Get(ctx, uid, convID, teamUnfurlPolicyName, &res, false)
+	switch err.(type) {
+	case nil:
+	case *DevStorageAdminOnlyError:
+		s.Debug(ctx, "get: ignoring policy: %s", err)
+		return chat1.TeamUnfurlPolicy{}, nil
+	default:
+		return res, err
+	}
+	if !found {
+		return chat1.TeamUnfurlPolicy{}, nil
+	}
+	return res, nil
+}
+
+func (s *TeamUnfurlPolicySource) GetForConv(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID) (res chat1.TeamUnfurlPolicy, err error) {
+	defer s.Trace(ctx, &err, "GetForConv")()
+	conv, err := utils.GetVerifiedConv(ctx, s.G(), uid, convID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	if conv.GetMembersType() != chat1.ConversationMembersType_TEAM {
+		return res, nil
+	}
+	key := conv.Info.Triple.Tlfid.String()
+	s.Lock()
+	now := s.G().GetClock().Now()
+	if entry, ok := s.cache[key]; ok && now.Sub(entry.loadedAt) < teamUnfurlPolicyCacheTime {
+		defer s.Unlock()
+		return entry.policy, nil
+	}
+	gen := s.gen
+	s.Unlock()
+
+	if res, err = s.get(ctx, uid, convID); err != nil {
+		return res, err
+	}
+	s.Lock()
+	defer s.Unlock()
+	if s.gen == gen {
+		s.cache[key] = teamUnfurlPolicyCacheEntry{policy: res, loadedAt: now}
+	}
+	return res, nil
+}
+
+// Invalidate drops the cached policy of a team, called when one of its dev
+// conversations changes.
+func (s *TeamUnfurlPolicySource) Invalidate(ctx context.Context, tlfID chat1.TLFID) {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.cache[tlfID.String()]; ok {
+		s.Debug(ctx, "Invalidate: tlfID: %s", tlfID)
+		delete(s.cache, tlfID.String())
+	}
+	s.gen++
+}
+
+func (s *TeamUnfurlPolicySource) Get(ctx context.Context, uid gregor1.UID, teamID keybase1.TeamID) (res chat1.TeamUnfurlPolicy, err error) {
+	defer s.Trace(ctx, &err, "Get")()
+	conv, err := getTeamSettingsConv(ctx, s.G(), uid, teamID)
+	if err != nil {
+		return res, err
+	}
+	return s.get(ctx, uid, conv.GetConvID())
+}
+
+func (s *TeamUnfurlPolicySource) Set(ctx context.Context, uid gregor1.UID, teamID keybase1.TeamID,
+	policy chat1.TeamUnfurlPolicy,
+) (err error) {
+	defer s.Trace(ctx, &err, "Set(%v)", policy.Mode)()
+	if _, ok := chat1.TeamUnfurlModeRevMap[policy.Mode]; !ok {
+		return fmt.Errorf("unknown team unfurl mode: %v", policy.Mode)
+	}
+	if policy.Allowlist, err = unfurl.NormalizeTeamAllowlist(policy.Allowlist); err != nil {
+		return err
+	}
+	conv, err := getTeamSettingsConv(ctx, s.G(), uid, teamID)
+	if err != nil {
+		return err
+	}
+	if err := s.storage().Put(ctx, uid, conv.GetConvID(), teamUnfurlPolicyName, policy); err != nil {
+		return err
+	}
+	s.Invalidate(ctx, chat1.TLFID(teamID.ToBytes()))
+	return nil
+}
@@ -123,6 +123,10 @@ type MessageDeliverer interface {
 	ForceDeliverLoop(ctx context.Context)
 	ActiveDeliveries(ctx context.Context) ([]chat1.OutboxRecord, error)
 	NextFailure() (chan []chat1.OutboxRecord, func())
+	Schedule(ctx context.Context, convID chat1.ConversationID, msg chat1.MessagePlaintext,
+		sendTime time.Time, identifyBehavior keybase1.TLFIdentifyBehavior) (chat1.ScheduledMessage, error)
+	ScheduledMessages(ctx context.Context, convID *chat1.ConversationID) ([]chat1.ScheduledMessage, error)
+	CancelScheduled(ctx context.Context, outboxID chat1.OutboxID) error
 }
 
 type RegexpSearcher interface {
@@ -431,7 +435,7 @@ type AttachmentUploaderResultCb interface {
 type AttachmentUploader interface {
 	Register(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 		outboxID chat1.OutboxID, title, filename string, metadata []byte,
-		callerPreview *chat1.MakePreviewRes) (AttachmentUploaderResultCb, error)
+		callerPreview *chat1.MakePreviewRes, keepMetadata bool) (AttachmentUploaderResultCb, error)
 	Status(ctx context.Context, outboxID chat1.OutboxID) (AttachmentUploaderTaskStatus, AttachmentUploadResult, error)
 	Retry(ctx context.Context, outboxID chat1.OutboxID) (AttachmentUploaderResultCb, error)
 	Cancel(ctx context.Context, outboxID chat1.OutboxID) error
@@ -586,6 +590,22 @@ type LiveLocationTracker interface {
 	StopAllTracking(ctx context.Context)
 }
 
+type WebhookDispatcher interface {
+	Resumable
+	AddHook(ctx context.Context, hook chat1.ChatWebhook) (chat1.ChatWebhook, error)
+	Hooks(ctx context.Context) ([]chat1.ChatWebhook, error)
+	RemoveHook(ctx context.Context, id string) error
+}
+
+type WebhookServer interface {
+	Resumable
+	AddHook(ctx context.Context, hook chat1.ChatIncomingWebhook) (chat1.ChatIncomingWebhook, error)
+	Hooks(ctx context.Context) ([]chat1.ChatIncomingWebhook, error)
+	RemoveHook(ctx context.Context, id string) error
+	Serve(ctx context.Context, port int) (string, error)
+	StopServing(ctx context.Context) error
+}
+
 type BotCommandManager interface {
 	Resumable
 	Advertise(ctx context.Context, alias *string, ads []chat1.AdvertiseCommandsParam) error
@@ -605,6 +625,30 @@ type ReplyFiller interface {
 		msgs []chat1.MessageUnboxed) ([]chat1.MessageUnboxed, error)
 }
 
+type ReplyThreadSource interface {
+	GetThread(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID, msgID chat1.MessageID) (chat1.MessageUnboxed, []chat1.MessageUnboxed, error)
+	ThreadUnreads(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID, readMsgID chat1.MessageID) ([]chat1.ThreadUnread, error)
+}
+
+type BookmarkManager interface {
+	Add(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID, msgID chat1.MessageID, note string) error
+	Remove(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID, msgID chat1.MessageID) error
+	List(ctx context.Context, uid gregor1.UID) ([]chat1.BookmarkedMessage, error)
+}
+
+type HighlightManager interface {
+	Get(ctx context.Context, uid gregor1.UID) (chat1.ChatHighlights, error)
+	Set(ctx context.Context, uid gregor1.UID, highlights chat1.ChatHighlights) error
+	IsHighlight(ctx context.Context, uid gregor1.UID, body chat1.MessageBody) bool
+}
+
+type TeamUnfurlPolicySource interface {
+	GetForConv(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID) (chat1.TeamUnfurlPolicy, error)
+	Get(ctx context.Context, uid gregor1.UID, teamID keybase1.TeamID) (chat1.TeamUnfurlPolicy, error)
+	Set(ctx context.Context, uid gregor1.UID, teamID keybase1.TeamID, policy chat1.TeamUnfurlPolicy) error
+	Invalidate(ctx context.Context, tlfID chat1.TLFID)
+}
+
 type UIInboxLoader interface {
 	Resumable
 	UpdateLayout(ctx context.Context, reselectMode chat1.InboxLayoutReselectMode, reason string)
@@ -792,7 +792,7 @@ var _ AttachmentUploader = (*DummyAttachmentUploader)(nil)
 
 func (d DummyAttachmentUploader) Register(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 	outboxID chat1.OutboxID, title, filename string, metadata []byte,
-	callerPreview *chat1.MakePreviewRes,
+	callerPreview *chat1.MakePreviewRes, keepMetadata bool,
 ) (AttachmentUploaderResultCb, error) {
 	return nil, nil
 }
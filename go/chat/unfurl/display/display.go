@@ -84,6 +84,32 @@ func displayUnfurlGiphy(ctx context.Context, srv types.AttachmentURLSrv, convID
 	return res, nil
 }
 
+func displayUnfurlEmbed(ctx context.Context, srv types.AttachmentURLSrv, convID chat1.ConversationID,
+	unfurl chat1.UnfurlEmbed,
+) (res chat1.UnfurlEmbedDisplay) {
+	res.EmbedType = unfurl.EmbedType
+	res.Title = unfurl.Title
+	res.Url = unfurl.Url
+	res.SiteName = unfurl.SiteName
+	res.AuthorName = unfurl.AuthorName
+	res.Description = unfurl.Description
+	res.PlayerUrl = unfurl.PlayerUrl
+	res.Width = unfurl.Width
+	res.Height = unfurl.Height
+	res.Snippet = unfurl.Snippet
+	if unfurl.Thumbnail != nil {
+		if thumb, err := assetToImageDisplay(ctx, convID, *unfurl.Thumbnail, srv); err == nil {
+			res.Thumbnail = &thumb
+		}
+	}
+	if unfurl.Favicon != nil {
+		if fav, err := assetToImageDisplay(ctx, convID, *unfurl.Favicon, srv); err == nil {
+			res.Favicon = &fav
+		}
+	}
+	return res
+}
+
 func DisplayUnfurl(ctx context.Context, srv types.AttachmentURLSrv, convID chat1.ConversationID,
 	unfurl chat1.Unfurl,
 ) (res chat1.UnfurlDisplay, err error) { // nolint
@@ -103,6 +129,8 @@ func DisplayUnfurl(ctx context.Context, srv types.AttachmentURLSrv, convID chat1
 		return chat1.NewUnfurlDisplayWithGiphy(giphy), nil
 	case chat1.UnfurlType_YOUTUBE:
 		return chat1.NewUnfurlDisplayWithYoutube(chat1.UnfurlYoutubeDisplay{}), nil
+	case chat1.UnfurlType_EMBED:
+		return chat1.NewUnfurlDisplayWithEmbed(displayUnfurlEmbed(ctx, srv, convID, unfurl.Embed())), nil
 	default:
 		return res, errors.New("unknown unfurl type")
 	}
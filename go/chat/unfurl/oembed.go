@@ -0,0 +1,209 @@
+package unfurl
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// oEmbedProvider is a site we know how to unfurl into an embed.
+type oEmbedProvider struct {
+	name     string
+	embedTyp chat1.UnfurlEmbedType
+	schemes  []*regexp.Regexp
+	// endpoint is the provider's oEmbed endpoint. Providers without one get
+	// unfurled from their pages, with a snippet of the code read from rawURL.
+	endpoint string
+	rawURL   func(u *url.URL) string
+}
+
+func (p oEmbedProvider) matches(uri string) bool {
+	for _, scheme := range p.schemes {
+		if scheme.MatchString(uri) {
+			return true
+		}
+	}
+	return false
+}
+
+type oEmbedProviders []oEmbedProvider
+
+func (p oEmbedProviders) match(uri string) *oEmbedProvider {
+	for i := range p {
+		if p[i].matches(uri) {
+			return &p[i]
+		}
+	}
+	return nil
+}
+
+func schemes(patterns ...string) (res []*regexp.Regexp) {
+	for _, pattern := range patterns {
+		res = append(res, regexp.MustCompile(pattern))
+	}
+	return res
+}
+
+var defaultOEmbedProviders = oEmbedProviders{
+	{
+		name:     "Vimeo",
+		embedTyp: chat1.UnfurlEmbedType_VIDEO,
+		schemes: schemes(`^https?://(www\.)?vimeo\.com/(channels/[\w-]+/)?\d+`,
+			`^https?://player\.vimeo\.com/video/\d+`),
+		endpoint: "https://vimeo.com/api/oembed.json",
+	},
+	{
+		name:     "SoundCloud",
+		embedTyp: chat1.UnfurlEmbedType_AUDIO,
+		schemes:  schemes(`^https?://(www\.|m\.)?soundcloud\.com/[\w-]+/[\w-]+`),
+		endpoint: "https://soundcloud.com/oembed",
+	},
+	{
+		name:     "Twitter",
+		embedTyp: chat1.UnfurlEmbedType_POST,
+		schemes:  schemes(`^https?://(www\.|mobile\.)?(twitter|x)\.com/\w+/status(es)?/\d+`),
+		endpoint: "https://publish.twitter.com/oembed",
+	},
+	{
+		name:     "Bluesky",
+		embedTyp: chat1.UnfurlEmbedType_POST,
+		schemes:  schemes(`^https?://bsky\.app/profile/[^/]+/post/\w+`),
+		endpoint: "https://embed.bsky.app/oembed",
+	},
+	{
+		name:     "CodePen",
+		embedTyp: chat1.UnfurlEmbedType_CODE,
+		schemes:  schemes(`^https?://codepen\.io/[\w-]+/pen/\w+`),
+		endpoint: "https://codepen.io/api/oembed",
+	},
+	{
+		name:     "GitHub Gist",
+		embedTyp: chat1.UnfurlEmbedType_CODE,
+		schemes:  schemes(`^https?://gist\.github\.com/[\w-]+/[0-9a-f]+/?$`),
+		rawURL: func(u *url.URL) string {
+			return "https://gist.github.com" + strings.TrimSuffix(u.Path, "/") + "/raw"
+		},
+	},
+	{
+		name:     "Pastebin",
+		embedTyp: chat1.UnfurlEmbedType_CODE,
+		schemes:  schemes(`^https?://pastebin\.com/\w+/?$`),
+		rawURL: func(u *url.URL) string {
+			return "https://pastebin.com/raw/" + strings.Trim(u.Path, "/")
+		},
+	},
+}
+
+// oEmbedURL is the request to make to endpoint for uri.
+func oEmbedURL(endpoint, uri string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("url", uri)
+	query.Set("format", "json")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// oEmbedInt is a dimension in an oEmbed response. Providers send these as
+// numbers or strings, and sometimes leave them null.
+type oEmbedInt int
+
+func (i *oEmbedInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if v, err := strconv.Atoi(s); err == nil {
+		*i = oEmbedInt(v)
+	}
+	return nil
+}
+
+type oEmbedResponse struct {
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	URL          string    `json:"url"`
+	AuthorName   string    `json:"author_name"`
+	ProviderName string    `json:"provider_name"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	HTML         string    `json:"html"`
+	Width        oEmbedInt `json:"width"`
+	Height       oEmbedInt `json:"height"`
+}
+
+func parseOEmbedResponse(dat []byte) (res oEmbedResponse, err error) {
+	if err = json.Unmarshal(dat, &res); err != nil {
+		return res, err
+	}
+	res.Type = strings.ToLower(res.Type)
+	return res, nil
+}
+
+// playerURL is the page the embed HTML frames, if it frames one.
+func (r oEmbedResponse) playerURL() string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(r.HTML))
+	if err != nil {
+		return ""
+	}
+	src, _ := doc.Find("iframe[src]").First().Attr("src")
+	if strings.HasPrefix(src, "//") {
+		src = "https:" + src
+	}
+	if !strings.HasPrefix(src, "https://") && !strings.HasPrefix(src, "http://") {
+		return ""
+	}
+	return src
+}
+
+// postText is the text of a post quoted by the embed HTML.
+func (r oEmbedResponse) postText() string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(r.HTML))
+	if err != nil {
+		return ""
+	}
+	var paragraphs []string
+	doc.Find("blockquote p").Each(func(_ int, sel *goquery.Selection) {
+		if text := strings.TrimSpace(sel.Text()); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+	return strings.Join(paragraphs, "\n")
+}
+
+// embedType is how to show a response from a provider we don't know. Only
+// players and rich embeds are worth more than the page itself.
+func (r oEmbedResponse) embedType() (chat1.UnfurlEmbedType, bool) {
+	switch r.Type {
+	case "video":
+		return chat1.UnfurlEmbedType_VIDEO, true
+	case "rich":
+		return chat1.UnfurlEmbedType_RICH, true
+	default:
+		return 0, false
+	}
+}
+
+const (
+	maxSnippetLines = 15
+	maxSnippetLen   = 1000
+)
+
+// makeSnippet keeps the first few lines of a post or some code.
+func makeSnippet(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	lines := strings.Split(text, "\n")
+	if len(lines) > maxSnippetLines {
+		lines = lines[:maxSnippetLines]
+	}
+	text = strings.Join(lines, "\n")
+	if len(text) > maxSnippetLen {
+		text = strings.ToValidUTF8(text[:maxSnippetLen], "")
+	}
+	return text
+}
@@ -0,0 +1,80 @@
+package unfurl
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOEmbedProviders(t *testing.T) {
+	testCase := func(uri, expected string) {
+		provider := defaultOEmbedProviders.match(uri)
+		if expected == "" {
+			require.Nil(t, provider, uri)
+			return
+		}
+		require.NotNil(t, provider, uri)
+		require.Equal(t, expected, provider.name)
+	}
+	testCase("https://vimeo.com/76979871", "Vimeo")
+	testCase("https://player.vimeo.com/video/76979871", "Vimeo")
+	testCase("https://soundcloud.com/forss/flickermood", "SoundCloud")
+	testCase("https://twitter.com/arstechnica/status/1057679097869094917", "Twitter")
+	testCase("https://x.com/arstechnica/status/1057679097869094917", "Twitter")
+	testCase("https://bsky.app/profile/keybase.io/post/3k4duaz5vfs2b", "Bluesky")
+	testCase("https://codepen.io/keybase/pen/abcdEF", "CodePen")
+	testCase("https://gist.github.com/keybase/0a1b2c3d4e5f", "GitHub Gist")
+	testCase("https://pastebin.com/XyZ123", "Pastebin")
+	// YouTube stays a generic unfurl
+	testCase("https://www.youtube.com/watch?v=mmJ_LT8bUj0", "")
+	require.True(t, isGenericOnly("https://www.youtube.com/watch?v=mmJ_LT8bUj0"))
+	require.True(t, isGenericOnly("https://youtu.be/mmJ_LT8bUj0"))
+	require.False(t, isGenericOnly("https://vimeo.com/76979871"))
+	testCase("https://twitter.com/arstechnica", "")
+	testCase("https://soundcloud.com/forss", "")
+	testCase("https://gist.github.com/keybase", "")
+	testCase("https://keybase.io", "")
+
+	gist, err := url.Parse("https://gist.github.com/keybase/0a1b2c3d4e5f/")
+	require.NoError(t, err)
+	require.Equal(t, "https://gist.github.com/keybase/0a1b2c3d4e5f/raw",
+		defaultOEmbedProviders.match(gist.String()).rawURL(gist))
+	paste, err := url.Parse("https://pastebin.com/XyZ123")
+	require.NoError(t, err)
+	require.Equal(t, "https://pastebin.com/raw/XyZ123", defaultOEmbedProviders.match(paste.String()).rawURL(paste))
+
+	endpoint, err := oEmbedURL("https://vimeo.com/api/oembed.json", "https://vimeo.com/76979871")
+	require.NoError(t, err)
+	require.Equal(t, "https://vimeo.com/api/oembed.json?format=json&url=https%3A%2F%2Fvimeo.com%2F76979871", endpoint)
+}
+
+func TestOEmbedResponse(t *testing.T) {
+	resp, err := parseOEmbedResponse([]byte(`{"type":"Video","width":"640","height":null,
+		"html":"<iframe src=\"//player.example/v/1\"></iframe>"}`))
+	require.NoError(t, err)
+	require.Equal(t, oEmbedInt(640), resp.Width)
+	require.Zero(t, resp.Height)
+	require.Equal(t, "https://player.example/v/1", resp.playerURL())
+	typ, ok := resp.embedType()
+	require.True(t, ok)
+	require.Equal(t, chat1.UnfurlEmbedType_VIDEO, typ)
+
+	resp, err = parseOEmbedResponse([]byte(`{"type":"link","width":"100%",
+		"html":"<blockquote><p>first</p><p> </p><p>second</p></blockquote><iframe src=\"javascript:alert(1)\"></iframe>"}`))
+	require.NoError(t, err)
+	require.Zero(t, resp.Width)
+	require.Equal(t, "first\nsecond", resp.postText())
+	require.Empty(t, resp.playerURL())
+	_, ok = resp.embedType()
+	require.False(t, ok)
+
+	_, err = parseOEmbedResponse([]byte(`<html></html>`))
+	require.Error(t, err)
+
+	long := strings.Repeat("line\r\n", 20)
+	require.Equal(t, strings.TrimSuffix(strings.Repeat("line\n", maxSnippetLines), "\n"), makeSnippet(long))
+	require.Len(t, makeSnippet(strings.Repeat("x", 2*maxSnippetLen)), maxSnippetLen)
+}
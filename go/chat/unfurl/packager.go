@@ -355,6 +355,41 @@ func (p *Packager) packageMaps(ctx context.Context, uid gregor1.UID, convID chat
 	return chat1.NewUnfurlWithGeneric(g), nil
 }
 
+func (p *Packager) packageEmbed(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	raw chat1.UnfurlRaw,
+) (res chat1.Unfurl, err error) {
+	embedRaw := raw.Embed()
+	e := chat1.UnfurlEmbed{
+		EmbedType:   embedRaw.EmbedType,
+		Title:       embedRaw.Title,
+		Url:         embedRaw.Url,
+		SiteName:    embedRaw.SiteName,
+		AuthorName:  embedRaw.AuthorName,
+		Description: embedRaw.Description,
+		PlayerUrl:   embedRaw.PlayerUrl,
+		Width:       embedRaw.Width,
+		Height:      embedRaw.Height,
+		Snippet:     embedRaw.Snippet,
+	}
+	if embedRaw.ThumbnailUrl != nil {
+		asset, err := p.assetFromURL(ctx, *embedRaw.ThumbnailUrl, uid, convID, true)
+		if err != nil {
+			p.Debug(ctx, "packageEmbed: failed to get thumbnail asset URL: %s", err)
+		} else {
+			e.Thumbnail = &asset
+		}
+	}
+	if embedRaw.FaviconUrl != nil {
+		asset, err := p.assetFromURL(ctx, *embedRaw.FaviconUrl, uid, convID, true)
+		if err != nil {
+			p.Debug(ctx, "packageEmbed: failed to get favicon asset URL: %s", err)
+		} else {
+			e.Favicon = &asset
+		}
+	}
+	return chat1.NewUnfurlWithEmbed(e), nil
+}
+
 func (p *Packager) cacheKey(uid gregor1.UID, convID chat1.ConversationID, raw chat1.UnfurlRaw) string {
 	url := raw.GetUrl()
 	if url == "" {
@@ -394,6 +429,8 @@ func (p *Packager) Package(ctx context.Context, uid gregor1.UID, convID chat1.Co
 		return p.packageGiphy(ctx, uid, convID, raw)
 	case chat1.UnfurlType_MAPS:
 		return p.packageMaps(ctx, uid, convID, raw)
+	case chat1.UnfurlType_EMBED:
+		return p.packageEmbed(ctx, uid, convID, raw)
 	default:
 		return res, errors.New("not implemented")
 	}
@@ -118,3 +118,69 @@ func TestPackager(t *testing.T) {
 	require.NoError(t, store.DownloadAsset(context.TODO(), s3params, *favicon, &buf, s3Signer, nil))
 	compareSol("nytimes_sol.ico", buf.Bytes())
 }
+
+func TestPackagerEmbed(t *testing.T) {
+	tc := libkb.SetupTest(t, "packagerEmbed", 1)
+	defer tc.Cleanup()
+	g := globals.NewContext(tc.G, &globals.ChatContext{})
+
+	store := attachments.NewStoreTesting(g, nil)
+	s3Signer := &ptsigner{}
+	ri := func() chat1.RemoteInterface { return paramsRemote{} }
+	packager := NewPackager(g, store, s3Signer, ri)
+	srv := newDummyHTTPSrv(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("typ") == "missing" {
+			w.WriteHeader(404)
+			return
+		}
+		w.WriteHeader(200)
+		dat, err := os.ReadFile(filepath.Join("testcases", "nytogimage.jpg"))
+		require.NoError(t, err)
+		_, err = w.Write(dat)
+		require.NoError(t, err)
+	})
+	addr := srv.Start()
+	defer srv.Stop()
+
+	uid := gregor1.UID([]byte{0})
+	convID := chat1.ConversationID([]byte{0})
+	thumbnailURL := fmt.Sprintf("http://%s/?typ=thumbnail", addr)
+	faviconURL := fmt.Sprintf("http://%s/?typ=missing", addr)
+	playerURL := "https://player.vimeo.com/video/76979871"
+	raw := chat1.NewUnfurlRawWithEmbed(chat1.UnfurlEmbedRaw{
+		EmbedType:    chat1.UnfurlEmbedType_VIDEO,
+		Title:        "The New Vimeo Player",
+		Url:          "https://vimeo.com/76979871",
+		SiteName:     "Vimeo",
+		ThumbnailUrl: &thumbnailURL,
+		FaviconUrl:   &faviconURL,
+		PlayerUrl:    &playerURL,
+		Width:        640,
+		Height:       360,
+	})
+	res, err := packager.Package(context.TODO(), uid, convID, raw)
+	require.NoError(t, err)
+	typ, err := res.UnfurlType()
+	require.NoError(t, err)
+	require.Equal(t, chat1.UnfurlType_EMBED, typ)
+	embed := res.Embed()
+	require.Equal(t, chat1.UnfurlEmbedType_VIDEO, embed.EmbedType)
+	require.Equal(t, "The New Vimeo Player", embed.Title)
+	require.Equal(t, &playerURL, embed.PlayerUrl)
+	require.Equal(t, 640, embed.Width)
+	require.Equal(t, 360, embed.Height)
+	// a favicon we can't get doesn't stop the unfurl
+	require.Nil(t, embed.Favicon)
+	require.NotNil(t, embed.Thumbnail)
+	require.NotZero(t, embed.Thumbnail.Metadata.Image().Height)
+	require.NotZero(t, embed.Thumbnail.Metadata.Image().Width)
+
+	var buf bytes.Buffer
+	s3params, err := ri().GetS3Params(context.TODO(), chat1.GetS3ParamsArg{
+		ConversationID: convID,
+		TempCreds:      true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.DownloadAsset(context.TODO(), s3params, *embed.Thumbnail, &buf, s3Signer, nil))
+	require.NotZero(t, buf.Len())
+}
@@ -0,0 +1,316 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+const maxEmbedFetchSize = 1 << 20
+
+// pagePlayer is a player a page links in its OpenGraph or Twitter card tags.
+type pagePlayer struct {
+	url      string
+	mimeType string
+	width    int
+	height   int
+}
+
+// pageEmbed collects what a page says about embedding it: an oEmbed endpoint
+// it advertises, and the players in its meta tags.
+type pageEmbed struct {
+	oEmbedURL     string
+	ogType        string
+	videos        []pagePlayer
+	audios        []pagePlayer
+	twitterPlayer pagePlayer
+}
+
+func setPlayerAttr(players []pagePlayer, attr, content string) []pagePlayer {
+	// OpenGraph structured properties describe the last player declared
+	switch attr {
+	case "", "url":
+		return append(players, pagePlayer{url: content})
+	}
+	if len(players) == 0 {
+		players = append(players, pagePlayer{})
+	}
+	last := &players[len(players)-1]
+	switch attr {
+	case "secure_url":
+		last.url = content
+	case "type":
+		last.mimeType = strings.ToLower(content)
+	case "width":
+		last.width, _ = strconv.Atoi(content)
+	case "height":
+		last.height, _ = strconv.Atoi(content)
+	}
+	return players
+}
+
+func (p *pageEmbed) setMeta(prop, content string) {
+	switch {
+	case prop == "og:type":
+		p.ogType = strings.ToLower(content)
+	case prop == "og:video" || strings.HasPrefix(prop, "og:video:"):
+		p.videos = setPlayerAttr(p.videos, strings.TrimPrefix(strings.TrimPrefix(prop, "og:video"), ":"), content)
+	case prop == "og:audio" || strings.HasPrefix(prop, "og:audio:"):
+		p.audios = setPlayerAttr(p.audios, strings.TrimPrefix(strings.TrimPrefix(prop, "og:audio"), ":"), content)
+	case prop == "twitter:player":
+		p.twitterPlayer.url = content
+	case prop == "twitter:player:width":
+		p.twitterPlayer.width, _ = strconv.Atoi(content)
+	case prop == "twitter:player:height":
+		p.twitterPlayer.height, _ = strconv.Atoi(content)
+	}
+}
+
+// player picks the player to embed for the page, preferring video players
+// over audio, and OpenGraph over Twitter cards.
+func (p *pageEmbed) player() (res pagePlayer, typ chat1.UnfurlEmbedType, ok bool) {
+	for _, video := range p.videos {
+		if video.url != "" && video.mimeType == "text/html" {
+			return video, chat1.UnfurlEmbedType_VIDEO, true
+		}
+	}
+	for _, audio := range p.audios {
+		if audio.url != "" {
+			return audio, chat1.UnfurlEmbedType_AUDIO, true
+		}
+	}
+	if p.twitterPlayer.url != "" {
+		if strings.HasPrefix(p.ogType, "music") {
+			return p.twitterPlayer, chat1.UnfurlEmbedType_AUDIO, true
+		}
+		return p.twitterPlayer, chat1.UnfurlEmbedType_VIDEO, true
+	}
+	return res, typ, false
+}
+
+func (s *Scraper) addEmbedScraperToCollector(ctx context.Context, c *colly.Collector, embed *pageEmbed,
+	uri string,
+) error {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	c.OnHTML("head link[rel][href][type]", func(e *colly.HTMLElement) {
+		if strings.ToLower(e.Attr("rel")) != "alternate" ||
+			strings.ToLower(e.Attr("type")) != "application/json+oembed" || embed.oEmbedURL != "" {
+			return
+		}
+		href, err := base.Parse(strings.TrimSpace(e.Attr("href")))
+		if err != nil {
+			s.Debug(ctx, "addEmbedScraperToCollector: invalid oEmbed link: %s", err)
+			return
+		}
+		embed.oEmbedURL = href.String()
+	})
+	c.OnHTML("meta[content][name]", func(e *colly.HTMLElement) {
+		embed.setMeta(strings.ToLower(e.Attr("name")), strings.TrimSpace(e.Attr("content")))
+	})
+	c.OnHTML("meta[content][property]", func(e *colly.HTMLElement) {
+		embed.setMeta(strings.ToLower(e.Attr("property")), strings.TrimSpace(e.Attr("content")))
+	})
+	return nil
+}
+
+func (s *Scraper) fetchForEmbed(ctx context.Context, uri string) ([]byte, error) {
+	client := libkb.ProxyHTTPClient(s.G().ExternalG(), s.G().Env, "UnfurlScraper")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxEmbedFetchSize))
+}
+
+func (s *Scraper) fetchOEmbed(ctx context.Context, endpointURL string) (res oEmbedResponse, err error) {
+	dat, err := s.fetchForEmbed(ctx, endpointURL)
+	if err != nil {
+		return res, err
+	}
+	return parseOEmbedResponse(dat)
+}
+
+func (s *Scraper) exportOEmbedResult(resp oEmbedResponse, typ chat1.UnfurlEmbedType, uri, siteName string,
+	faviconURL *string,
+) (res chat1.UnfurlRaw, err error) {
+	embed := chat1.UnfurlEmbedRaw{
+		EmbedType:  typ,
+		Title:      resp.Title,
+		Url:        uri,
+		SiteName:   siteName,
+		FaviconUrl: faviconURL,
+		Width:      int(resp.Width),
+		Height:     int(resp.Height),
+	}
+	if resp.ProviderName != "" {
+		embed.SiteName = resp.ProviderName
+	}
+	if resp.AuthorName != "" {
+		embed.AuthorName = &resp.AuthorName
+		if embed.Title == "" {
+			embed.Title = resp.AuthorName
+		}
+	}
+	if resp.Description != "" {
+		embed.Description = &resp.Description
+	}
+	if resp.ThumbnailURL != "" {
+		embed.ThumbnailUrl = &resp.ThumbnailURL
+	} else if resp.Type == "photo" && resp.URL != "" {
+		embed.ThumbnailUrl = &resp.URL
+	}
+	if player := resp.playerURL(); player != "" {
+		embed.PlayerUrl = &player
+	}
+	if typ == chat1.UnfurlEmbedType_POST {
+		if text := resp.postText(); text != "" {
+			snippet := makeSnippet(text)
+			embed.Snippet = &snippet
+		}
+	}
+	if embed.Title == "" && embed.Snippet == nil && embed.PlayerUrl == nil {
+		return res, newUnfurlPermanentError("not enough information to display")
+	}
+	return chat1.NewUnfurlRawWithEmbed(embed), nil
+}
+
+// scrapeProvider unfurls a link to a site in the provider registry.
+func (s *Scraper) scrapeProvider(ctx context.Context, provider *oEmbedProvider, uri, domain string) (res chat1.UnfurlRaw, err error) {
+	defer s.Trace(ctx, &err, "scrapeProvider(%s)", provider.name)()
+	if provider.endpoint != "" {
+		endpointURL, err := oEmbedURL(provider.endpoint, uri)
+		if err != nil {
+			return res, err
+		}
+		resp, err := s.fetchOEmbed(ctx, endpointURL)
+		if err != nil {
+			return res, err
+		}
+		var faviconURL *string
+		if favicon, err := GetDefaultFaviconURL(uri); err == nil {
+			faviconURL = &favicon
+		}
+		return s.exportOEmbedResult(resp, provider.embedTyp, uri, provider.name, faviconURL)
+	}
+
+	// Without an endpoint we describe the page from its meta tags, and show
+	// what it holds from the raw text.
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return res, err
+	}
+	generic := new(scoredGenericRaw)
+	c := s.makeCollector()
+	if err = s.addGenericScraperToCollector(ctx, c, generic, uri, domain); err != nil {
+		return res, err
+	}
+	if err := c.Visit(uri); err != nil {
+		return res, err
+	}
+	dat, err := s.fetchForEmbed(ctx, provider.rawURL(parsed))
+	if err != nil {
+		return res, err
+	}
+	snippet := makeSnippet(string(dat))
+	if snippet == "" {
+		return res, errors.New("nothing to show in snippet")
+	}
+	return chat1.NewUnfurlRawWithEmbed(chat1.UnfurlEmbedRaw{
+		EmbedType:   provider.embedTyp,
+		Title:       generic.Title,
+		Url:         uri,
+		SiteName:    provider.name,
+		Description: generic.Description,
+		FaviconUrl:  generic.FaviconUrl,
+		Snippet:     &snippet,
+	}), nil
+}
+
+// genericOnlyDomains are sites whose links keep their generic unfurl, even
+// though their pages describe a player.
+var genericOnlyDomains = []string{"youtube.com", "youtu.be"}
+
+func isGenericOnly(uri string) bool {
+	domain, err := GetDomain(uri)
+	if err != nil {
+		return false
+	}
+	for _, d := range genericOnlyDomains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// scrapePageEmbed turns a page into an embed from what it says about itself,
+// either an oEmbed endpoint on the same site, or a player in its meta tags.
+func (s *Scraper) scrapePageEmbed(ctx context.Context, embed *pageEmbed, generic *scoredGenericRaw,
+	uri, domain string,
+) (res chat1.UnfurlRaw, ok bool) {
+	if isGenericOnly(uri) {
+		return res, false
+	}
+	if embed.oEmbedURL != "" {
+		// Only trust endpoints run by the site itself, anyone else could
+		// describe the page however they like.
+		if endpointDomain, err := GetDomain(embed.oEmbedURL); err != nil || endpointDomain != domain {
+			s.Debug(ctx, "scrapePageEmbed: ignoring oEmbed endpoint on another site: %s", embed.oEmbedURL)
+		} else if resp, err := s.fetchOEmbed(ctx, embed.oEmbedURL); err != nil {
+			s.Debug(ctx, "scrapePageEmbed: failed to fetch oEmbed: %s", err)
+		} else if typ, ok := resp.embedType(); ok {
+			if resp.Title == "" {
+				resp.Title = generic.Title
+			}
+			if resp.ThumbnailURL == "" && generic.ImageUrl != nil {
+				resp.ThumbnailURL = *generic.ImageUrl
+			}
+			if res, err = s.exportOEmbedResult(resp, typ, generic.Url, generic.SiteName,
+				generic.FaviconUrl); err == nil {
+				return res, true
+			}
+			s.Debug(ctx, "scrapePageEmbed: unusable oEmbed: %s", err)
+		}
+	}
+	// Videos we can download are better shown by the generic unfurl.
+	if generic.Video != nil {
+		return res, false
+	}
+	player, typ, ok := embed.player()
+	if !ok || isGenericOnly(player.url) {
+		return res, false
+	}
+	return chat1.NewUnfurlRawWithEmbed(chat1.UnfurlEmbedRaw{
+		EmbedType:    typ,
+		Title:        generic.Title,
+		Url:          generic.Url,
+		SiteName:     generic.SiteName,
+		Description:  generic.Description,
+		FaviconUrl:   generic.FaviconUrl,
+		ThumbnailUrl: generic.ImageUrl,
+		PlayerUrl:    &player.url,
+		Width:        player.width,
+		Height:       player.height,
+	}), true
+}
@@ -184,12 +184,25 @@ func (s *Scraper) exportGenericResult(generic *scoredGenericRaw) (res chat1.Unfu
 }
 
 func (s *Scraper) scrapeGeneric(ctx context.Context, uri, domain string) (res chat1.UnfurlRaw, err error) {
+	// Ask the sites we know about first, and fall back to the page if they
+	// can't tell us anything.
+	if provider := s.providers.match(uri); provider != nil {
+		if res, err = s.scrapeProvider(ctx, provider, uri, domain); err == nil {
+			return res, nil
+		}
+		s.Debug(ctx, "scrapeGeneric: failed to scrape from %s: %s", provider.name, err)
+	}
+
 	// setup some defaults with score 0 and hope we can find better info.
 	generic := new(scoredGenericRaw)
+	embed := new(pageEmbed)
 	c := s.makeCollector()
 	if err = s.addGenericScraperToCollector(ctx, c, generic, uri, domain); err != nil {
 		return res, err
 	}
+	if err = s.addEmbedScraperToCollector(ctx, c, embed, uri); err != nil {
+		return res, err
+	}
 	if err := c.Visit(uri); err != nil {
 		return res, err
 	}
@@ -198,5 +211,8 @@ func (s *Scraper) scrapeGeneric(ctx context.Context, uri, domain string) (res ch
 		s.Debug(ctx, "scrapeGeneric: favicon score below Apple touch score, trying to find it")
 		s.tryAppleTouchIcon(ctx, generic, uri, domain)
 	}
+	if res, ok := s.scrapePageEmbed(ctx, embed, generic, uri, domain); ok {
+		return res, nil
+	}
 	return s.exportGenericResult(generic)
 }
@@ -17,7 +17,8 @@ const userAgent = "Mozilla/5.0 (compatible; KeybaseBot; +https://keybase.io)"
 type Scraper struct {
 	globals.Contextified
 	utils.DebugLabeler
-	cache *unfurlCache
+	cache     *unfurlCache
+	providers oEmbedProviders
 }
 
 func NewScraper(g *globals.Context) *Scraper {
@@ -25,6 +26,7 @@ func NewScraper(g *globals.Context) *Scraper {
 		Contextified: globals.NewContextified(g),
 		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "Scraper", false),
 		cache:        newUnfurlCache(),
+		providers:    defaultOEmbedProviders,
 	}
 }
 
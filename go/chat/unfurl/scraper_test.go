@@ -7,8 +7,11 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -169,6 +172,8 @@ func TestScraper(t *testing.T) {
 			require.Equal(t, e.Video.Url, r.Video.Url)
 			require.Equal(t, e.Video.Height, r.Video.Height)
 			require.Equal(t, e.Video.Width, r.Video.Width)
+		case chat1.UnfurlType_EMBED:
+			requireEmbedEqual(t, expected.Embed(), res.Embed())
 		default:
 			require.Fail(t, "unknown unfurl typ")
 		}
@@ -307,6 +312,144 @@ func TestScraper(t *testing.T) {
 	testCase("slim.html", chat1.NewUnfurlRawWithGeneric(chat1.UnfurlGenericRaw{}), false, nil, nil)
 }
 
+func requireEmbedEqual(t *testing.T, e, r chat1.UnfurlEmbedRaw) {
+	require.Equal(t, e.EmbedType, r.EmbedType)
+	require.Equal(t, e.Title, r.Title)
+	require.Equal(t, e.SiteName, r.SiteName)
+	require.Equal(t, e.AuthorName, r.AuthorName)
+	require.Equal(t, e.Description, r.Description)
+	require.Equal(t, e.FaviconUrl, r.FaviconUrl)
+	require.Equal(t, e.ThumbnailUrl, r.ThumbnailUrl)
+	require.Equal(t, e.PlayerUrl, r.PlayerUrl)
+	require.Equal(t, e.Width, r.Width)
+	require.Equal(t, e.Height, r.Height)
+	require.Equal(t, e.Snippet, r.Snippet)
+}
+
+func TestEmbedScraper(t *testing.T) {
+	tc := libkb.SetupTest(t, "embedScraper", 1)
+	defer tc.Cleanup()
+	g := globals.NewContext(tc.G, &globals.ChatContext{})
+	scraper := NewScraper(g)
+
+	srv := createTestCaseHTTPSrv(t)
+	addr := srv.Start()
+	defer srv.Stop()
+	provider := func(name string, typ chat1.UnfurlEmbedType, endpoint string) oEmbedProvider {
+		return oEmbedProvider{
+			name:     name,
+			embedTyp: typ,
+			schemes:  schemes(fmt.Sprintf(`^http://%s/\?name=%s`, regexp.QuoteMeta(addr), strings.ToLower(name))),
+			endpoint: endpoint,
+		}
+	}
+	gist := provider("Gist", chat1.UnfurlEmbedType_CODE, "")
+	gist.rawURL = func(*url.URL) string { return fmt.Sprintf("http://%s/?name=gist0.txt", addr) }
+	scraper.providers = oEmbedProviders{
+		provider("Vimeo", chat1.UnfurlEmbedType_VIDEO, fmt.Sprintf("http://%s/?name=vimeo0.json", addr)),
+		provider("SoundCloud", chat1.UnfurlEmbedType_AUDIO, fmt.Sprintf("http://%s/?name=soundcloud0.json", addr)),
+		provider("Twitter", chat1.UnfurlEmbedType_POST, fmt.Sprintf("http://%s/?name=twitter0.json", addr)),
+		// not an oEmbed response at all
+		provider("cnn0", chat1.UnfurlEmbedType_VIDEO, fmt.Sprintf("http://%s/?name=slim.html", addr)),
+		gist,
+	}
+	testCase := func(name string, expected chat1.UnfurlEmbedRaw) {
+		uri := fmt.Sprintf("http://%s/?name=%s", addr, name)
+		res, err := scraper.Scrape(context.TODO(), uri, nil)
+		require.NoError(t, err)
+		typ, err := res.UnfurlType()
+		require.NoError(t, err)
+		require.Equal(t, chat1.UnfurlType_EMBED, typ)
+		t.Logf("expected:\n%v\n\nactual:\n%v", expected, res)
+		requireEmbedEqual(t, expected, res.Embed())
+		require.Equal(t, uri, res.Embed().Url)
+	}
+
+	defaultFavicon := fmt.Sprintf("http://%s/favicon.ico", addr)
+	testCase("vimeo0", chat1.UnfurlEmbedRaw{
+		EmbedType:    chat1.UnfurlEmbedType_VIDEO,
+		Title:        "The New Vimeo Player (You Know, For Videos)",
+		SiteName:     "Vimeo",
+		AuthorName:   strPtr("Vimeo"),
+		Description:  strPtr("It may look (mostly) the same on the surface, but under the hood we totally rebuilt our player."),
+		FaviconUrl:   strPtr(defaultFavicon),
+		ThumbnailUrl: strPtr("https://i.vimeocdn.com/video/452001751-8216e0571c251a09d8e0c3b2b11b9b7a94b0a3a4e2d9f5f2e6c8a1e7c2d7b1c2-d_640"),
+		PlayerUrl:    strPtr("https://player.vimeo.com/video/76979871?h=8272103f6e&app_id=122963"),
+		Width:        640,
+		Height:       360,
+	})
+	testCase("soundcloud0", chat1.UnfurlEmbedRaw{
+		EmbedType:    chat1.UnfurlEmbedType_AUDIO,
+		Title:        "Flickermood by Forss",
+		SiteName:     "SoundCloud",
+		AuthorName:   strPtr("Forss"),
+		Description:  strPtr("From the Soulhack album,&nbsp;recently featured in this ad."),
+		FaviconUrl:   strPtr(defaultFavicon),
+		ThumbnailUrl: strPtr("https://i1.sndcdn.com/artworks-000067273316-smsiqx-t500x500.jpg"),
+		PlayerUrl:    strPtr("https://w.soundcloud.com/player/?visual=true&url=https%3A%2F%2Fapi.soundcloud.com%2Ftracks%2F293&show_artwork=true"),
+		Height:       400,
+	})
+	testCase("twitter0", chat1.UnfurlEmbedRaw{
+		EmbedType:  chat1.UnfurlEmbedType_POST,
+		Title:      "Ars Technica",
+		SiteName:   "Twitter",
+		AuthorName: strPtr("Ars Technica"),
+		FaviconUrl: strPtr(defaultFavicon),
+		Width:      550,
+		Snippet:    strPtr("Nintendo recommits to “keep the business going” for 3DS https://t.co/wTIJxmGTJH by @KyleOrl"),
+	})
+	testCase("gist0.html", chat1.UnfurlEmbedRaw{
+		EmbedType:   chat1.UnfurlEmbedType_CODE,
+		Title:       "Fibonacci in Go",
+		SiteName:    "Gist",
+		Description: strPtr("Fibonacci in Go. GitHub Gist: instantly share code, notes, and snippets."),
+		FaviconUrl:  strPtr("https://github.githubassets.com/favicons/favicon.png"),
+		Snippet: strPtr(`package main
+
+import "fmt"
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func main() {
+	for i := 0; i < 10; i++ {
+		fmt.Println(fib(i))
+	}`),
+	})
+	// an oEmbed endpoint advertised by the page itself
+	testCase("mastodon0.html", chat1.UnfurlEmbedRaw{
+		EmbedType:    chat1.UnfurlEmbedType_RICH,
+		Title:        "New status by keybase",
+		SiteName:     "mastodon.example",
+		AuthorName:   strPtr("Keybase"),
+		FaviconUrl:   strPtr(defaultFavicon),
+		ThumbnailUrl: strPtr("https://mastodon.example/system/accounts/avatars/keybase.png"),
+		PlayerUrl:    strPtr("https://mastodon.example/@keybase/110000000000000001/embed"),
+		Width:        400,
+	})
+	testCase("podcast0.html", chat1.UnfurlEmbedRaw{
+		EmbedType:    chat1.UnfurlEmbedType_AUDIO,
+		Title:        "Episode 12: Small Encrypted Things",
+		SiteName:     "Keybase Radio",
+		Description:  strPtr("We talk about keys, chats and the things in between."),
+		FaviconUrl:   strPtr(defaultFavicon),
+		ThumbnailUrl: strPtr("https://radio.example/art/12.jpg"),
+		PlayerUrl:    strPtr("https://radio.example/audio/12.mp3"),
+	})
+
+	// providers that fail fall back to the page
+	res, err := scraper.Scrape(context.TODO(), fmt.Sprintf("http://%s/?name=cnn0.html", addr), nil)
+	require.NoError(t, err)
+	typ, err := res.UnfurlType()
+	require.NoError(t, err)
+	require.Equal(t, chat1.UnfurlType_GENERIC, typ)
+	require.Equal(t, "Kanye West seeks separation from politics", res.Generic().Title)
+}
+
 func TestGiphySearchScrape(t *testing.T) {
 	tc := libkb.SetupTest(t, "giphyScraper", 1)
 	defer tc.Cleanup()
@@ -0,0 +1,70 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+// TeamPolicySource looks up the unfurl policy the admins of a team have set
+// for its conversations. Conversations outside of a team get the default
+// policy.
+type TeamPolicySource interface {
+	GetForConv(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID) (chat1.TeamUnfurlPolicy, error)
+}
+
+// TeamPolicyAllows checks if a team policy lets members unfurl uri, whatever
+// their own settings say. Maps unfurls are served by Keybase and never reach a
+// third party, so they are always allowed.
+func TeamPolicyAllows(policy chat1.TeamUnfurlPolicy, uri string) bool {
+	if policy.Mode == chat1.TeamUnfurlMode_DEFAULT {
+		return true
+	}
+	hostname, err := GetHostname(uri)
+	if err != nil {
+		return false
+	}
+	hostname = strings.ToLower(hostname)
+	if hostname == types.MapsDomain {
+		return true
+	}
+	if policy.Mode != chat1.TeamUnfurlMode_ALLOWLIST {
+		return false
+	}
+	for _, domain := range policy.Allowlist {
+		if hostname == domain || strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTeamAllowlist cleans up the domains an admin gave for an allowlist,
+// so they can be matched against hostnames. Each domain also allows its
+// subdomains.
+func NormalizeTeamAllowlist(domains []string) (res []string, err error) {
+	seen := make(map[string]bool)
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		domain = strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+		if len(domain) == 0 {
+			return nil, errors.New("empty domain in allowlist")
+		}
+		if strings.ContainsAny(domain, "/:*@ \t") {
+			return nil, fmt.Errorf("invalid domain in allowlist: %q", domain)
+		}
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		res = append(res, domain)
+	}
+	sort.Strings(res)
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package unfurl
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamPolicyAllows(t *testing.T) {
+	allowlist := chat1.TeamUnfurlPolicy{
+		Mode:      chat1.TeamUnfurlMode_ALLOWLIST,
+		Allowlist: []string{"github.com", "docs.example.com"},
+	}
+	disabled := chat1.TeamUnfurlPolicy{Mode: chat1.TeamUnfurlMode_DISABLED}
+	cases := []struct {
+		policy  chat1.TeamUnfurlPolicy
+		uri     string
+		allowed bool
+	}{
+		{policy: chat1.TeamUnfurlPolicy{}, uri: "https://www.nytimes.com/a", allowed: true},
+		{policy: disabled, uri: "https://www.nytimes.com/a", allowed: false},
+		{policy: disabled, uri: "https://keybasemaps/?lat=1&lon=2", allowed: true},
+		{policy: allowlist, uri: "https://github.com/keybase/client", allowed: true},
+		{policy: allowlist, uri: "https://GIST.GitHub.com/mike/abc", allowed: true},
+		{policy: allowlist, uri: "https://notgithub.com/keybase/client", allowed: false},
+		{policy: allowlist, uri: "https://github.com.evil.com/keybase/client", allowed: false},
+		{policy: allowlist, uri: "https://docs.example.com/a", allowed: true},
+		{policy: allowlist, uri: "https://example.com/a", allowed: false},
+		{policy: allowlist, uri: "https://keybasemaps/?lat=1&lon=2", allowed: true},
+		{policy: allowlist, uri: "::not a url", allowed: false},
+		{policy: chat1.TeamUnfurlPolicy{Mode: 100}, uri: "https://github.com", allowed: false},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.allowed, TeamPolicyAllows(c.policy, c.uri), "%v %s", c.policy.Mode, c.uri)
+	}
+}
+
+func TestNormalizeTeamAllowlist(t *testing.T) {
+	res, err := NormalizeTeamAllowlist([]string{" GitHub.com ", "*.example.com", "example.com.", "github.com"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com", "github.com"}, res)
+
+	res, err = NormalizeTeamAllowlist(nil)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	for _, domain := range []string{"", " ", "https://github.com", "github.com/keybase", "github.com:443",
+		"*.*.com", "mike@github.com"} {
+		_, err := NormalizeTeamAllowlist([]string{domain})
+		require.Error(t, err, domain)
+	}
+}
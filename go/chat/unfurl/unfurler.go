@@ -52,12 +52,13 @@ type Unfurler struct {
 	globals.Contextified
 	utils.DebugLabeler
 
-	unfurlMap map[string]bool
-	extractor *Extractor
-	scraper   *Scraper
-	packager  *Packager
-	settings  *Settings
-	sender    UnfurlMessageSender
+	unfurlMap  map[string]bool
+	extractor  *Extractor
+	scraper    *Scraper
+	packager   *Packager
+	settings   *Settings
+	teamPolicy TeamPolicySource
+	sender     UnfurlMessageSender
 
 	// testing
 	unfurlCh chan *chat1.Unfurl
@@ -67,7 +68,8 @@ type Unfurler struct {
 var _ types.Unfurler = (*Unfurler)(nil)
 
 func NewUnfurler(g *globals.Context, store attachments.Store, s3signer s3.Signer,
-	storage types.UserConversationBackedStorage, sender UnfurlMessageSender, ri func() chat1.RemoteInterface,
+	storage types.UserConversationBackedStorage, teamPolicy TeamPolicySource, sender UnfurlMessageSender,
+	ri func() chat1.RemoteInterface,
 ) *Unfurler {
 	extractor := NewExtractor(g)
 	scraper := NewScraper(g)
@@ -81,6 +83,7 @@ func NewUnfurler(g *globals.Context, store attachments.Store, s3signer s3.Signer
 		scraper:      scraper,
 		packager:     packager,
 		settings:     settings,
+		teamPolicy:   teamPolicy,
 		sender:       sender,
 	}
 }
@@ -248,6 +251,13 @@ func (u *Unfurler) UnfurlAndSend(ctx context.Context, uid gregor1.UID, convID ch
 	if len(hits) == 0 {
 		return
 	}
+	// the team's policy overrides the user's settings, so don't even prompt
+	// for domains it rules out
+	policy, err := u.teamPolicy.GetForConv(ctx, uid, convID)
+	if err != nil {
+		u.Debug(ctx, "UnfurlAndSend: failed to get team policy: %s", err)
+		return
+	}
 	// get a map for all the URLs we have already unfurled
 	prevUnfurled := make(map[string]bool)
 	for _, u := range msg.Valid().Unfurls {
@@ -260,6 +270,10 @@ func (u *Unfurler) UnfurlAndSend(ctx context.Context, uid gregor1.UID, convID ch
 			continue
 		}
 		prevUnfurled[hit.URL] = true // only one action per unique URL
+		if !TeamPolicyAllows(policy, hit.URL) {
+			u.Debug(ctx, "UnfurlAndSend: skipping URL hit, not allowed by team policy")
+			continue
+		}
 		switch hit.Typ {
 		case ExtractorHitPrompt:
 			domain, err := GetDomain(hit.URL)
@@ -367,9 +381,28 @@ func (u *Unfurler) testingSendUnfurl(unfurl *chat1.Unfurl) {
 	}
 }
 
+func (u *Unfurler) checkTeamPolicy(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
+	url string,
+) error {
+	policy, err := u.teamPolicy.GetForConv(ctx, uid, convID)
+	if err != nil {
+		return err
+	}
+	if !TeamPolicyAllows(policy, url) {
+		return newUnfurlPermanentError("unfurling not allowed by team policy")
+	}
+	return nil
+}
+
 func (u *Unfurler) scrapeAndPackage(ctx context.Context, uid gregor1.UID, convID chat1.ConversationID,
 	url string,
 ) (unfurl chat1.Unfurl, err error) {
+	// Scraping is what exposes us to the site, so check again in case the
+	// policy changed since the task was made.
+	if err := u.checkTeamPolicy(ctx, uid, convID, url); err != nil {
+		u.Debug(ctx, "unfurl: team policy check failed: %s", err)
+		return unfurl, err
+	}
 	unfurlRaw, err := u.scraper.Scrape(ctx, url, nil)
 	if err != nil {
 		u.Debug(ctx, "unfurl: failed to scrape: <error msg suppressed> (%T)", err)
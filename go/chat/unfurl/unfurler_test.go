@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -63,6 +64,25 @@ func (d *dummyActivityNotifier) PromptUnfurl(ctx context.Context, uid gregor1.UI
 	}
 }
 
+type memTeamPolicySource struct {
+	sync.Mutex
+	policy chat1.TeamUnfurlPolicy
+}
+
+func (s *memTeamPolicySource) GetForConv(ctx context.Context, uid gregor1.UID,
+	convID chat1.ConversationID,
+) (chat1.TeamUnfurlPolicy, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.policy, nil
+}
+
+func (s *memTeamPolicySource) set(policy chat1.TeamUnfurlPolicy) {
+	s.Lock()
+	defer s.Unlock()
+	s.policy = policy
+}
+
 type dummyDeliverer struct {
 	types.MessageDeliverer
 }
@@ -82,7 +102,7 @@ func TestUnfurler(t *testing.T) {
 	sender := makeDummySender()
 	ri := func() chat1.RemoteInterface { return paramsRemote{} }
 	storage := newMemConversationBackedStorage()
-	unfurler := NewUnfurler(g, store, s3signer, storage, sender, ri)
+	unfurler := NewUnfurler(g, store, s3signer, storage, &memTeamPolicySource{}, sender, ri)
 	settings := NewSettings(g, storage)
 	srv := createTestCaseHTTPSrv(t)
 	addr := srv.Start()
@@ -176,3 +196,81 @@ func TestUnfurler(t *testing.T) {
 	require.ErrorAs(t, err, new(libkb.NotFoundError))
 	require.Equal(t, types.UnfurlerTaskStatusFailed, status)
 }
+
+func TestUnfurlerTeamPolicy(t *testing.T) {
+	tc := externalstest.SetupTest(t, "unfurler", 0)
+	defer tc.Cleanup()
+	g := globals.NewContext(tc.G, &globals.ChatContext{})
+
+	store := attachments.NewStoreTesting(g, nil)
+	notifier := makeDummyActivityNotifier()
+	g.ActivityNotifier = notifier
+	g.MessageDeliverer = dummyDeliverer{}
+	sender := makeDummySender()
+	ri := func() chat1.RemoteInterface { return paramsRemote{} }
+	storage := newMemConversationBackedStorage()
+	teamPolicy := &memTeamPolicySource{}
+	unfurler := NewUnfurler(g, store, &ptsigner{}, storage, teamPolicy, sender, ri)
+	settings := NewSettings(g, storage)
+
+	uid := gregor1.UID([]byte{0, 1})
+	convID := chat1.ConversationID([]byte{0, 2})
+	makeMsg := func(msgID chat1.MessageID, body string) chat1.MessageUnboxed {
+		return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+			ClientHeader: chat1.MessageClientHeaderVerified{
+				TlfName:     "acme",
+				MessageType: chat1.MessageType_TEXT,
+			},
+			ServerHeader: chat1.MessageServerHeader{
+				MessageID: msgID,
+			},
+			MessageBody: chat1.NewMessageBodyWithText(chat1.MessageText{
+				Body: body,
+			}),
+		})
+	}
+	requireNothing := func() {
+		select {
+		case <-sender.ch:
+			require.Fail(t, "no send here")
+		case <-notifier.ch:
+			require.Fail(t, "no notification here")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	requirePrompt := func(domain string) {
+		select {
+		case <-sender.ch:
+			require.Fail(t, "no send here")
+		case n := <-notifier.ch:
+			require.Equal(t, domain, n.domain)
+		case <-time.After(20 * time.Second):
+			require.Fail(t, "no notifications")
+		}
+	}
+
+	// disabled by the team, even for domains the user always unfurls
+	require.NoError(t, settings.WhitelistAdd(context.TODO(), uid, "example.com"))
+	teamPolicy.set(chat1.TeamUnfurlPolicy{Mode: chat1.TeamUnfurlMode_DISABLED})
+	unfurler.UnfurlAndSend(context.TODO(), uid, convID, makeMsg(4, "http://www.example.com/a"))
+	requireNothing()
+	require.Zero(t, unfurler.Prefetch(context.TODO(), uid, convID, "http://www.example.com/a"))
+	_, err := unfurler.scrapeAndPackage(context.TODO(), uid, convID, "http://www.example.com/a")
+	require.Error(t, err)
+	require.True(t, unfurler.detectPermError(err))
+
+	// only allowlisted domains get through, then the user settings apply
+	teamPolicy.set(chat1.TeamUnfurlPolicy{
+		Mode:      chat1.TeamUnfurlMode_ALLOWLIST,
+		Allowlist: []string{"wsj.com"},
+	})
+	unfurler.UnfurlAndSend(context.TODO(), uid, convID, makeMsg(5, "http://www.example.com/a"))
+	requireNothing()
+	unfurler.UnfurlAndSend(context.TODO(), uid, convID, makeMsg(6, "http://www.wsj.com/a"))
+	requirePrompt("wsj.com")
+
+	// back to the user's settings
+	teamPolicy.set(chat1.TeamUnfurlPolicy{Mode: chat1.TeamUnfurlMode_DEFAULT})
+	unfurler.UnfurlAndSend(context.TODO(), uid, convID, makeMsg(7, "http://cnn.com/a"))
+	requirePrompt("cnn.com")
+}
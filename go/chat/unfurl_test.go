@@ -138,7 +138,8 @@ func TestChatSrvUnfurl(t *testing.T) {
 				func() chat1.RemoteInterface { return ri }))
 		store := attachments.NewStoreTesting(tc.Context(), nil)
 		s3signer := &ptsigner{}
-		unfurler := unfurl.NewUnfurler(tc.Context(), store, s3signer, storage, sender,
+		unfurler := unfurl.NewUnfurler(tc.Context(), store, s3signer, storage,
+			tc.ChatG.TeamUnfurlPolicySource, sender,
 			func() chat1.RemoteInterface { return ri })
 		retryCh := make(chan struct{}, 5)
 		unfurlCh := make(chan *chat1.Unfurl, 5)
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// ExportMsgContent converts a message body into the content of a message in
+// the chat JSON API.
+func ExportMsgContent(mb chat1.MessageBody) chat1.MsgContent {
+	return chat1.MsgContent{
+		TypeName:           strings.ToLower(chat1.MessageTypeRevMap[mb.MessageType__]),
+		Text:               exportTextBody(mb.Text__),
+		Attachment:         mb.Attachment__,
+		Edit:               mb.Edit__,
+		Reaction:           mb.Reaction__,
+		Delete:             mb.Delete__,
+		Metadata:           mb.Metadata__,
+		Headline:           mb.Headline__,
+		AttachmentUploaded: mb.Attachmentuploaded__,
+		System:             mb.System__,
+		SendPayment:        mb.Sendpayment__,
+		RequestPayment:     mb.Requestpayment__,
+		Unfurl:             mb.Unfurl__,
+		Flip:               exportFlipBody(mb.Flip__),
+		Poll:               exportPollBody(mb.Poll__),
+		PollVote:           exportPollVoteBody(mb.Pollvote__),
+		Interactive:        exportInteractiveBody(mb.Interactive__),
+		InteractiveAction:  exportInteractiveActionBody(mb.Interactiveaction__),
+	}
+}
+
+// ExportIncomingMessage converts the message of an incoming message
+// notification into a message of the chat JSON API. Returns nil for messages
+// that are not shown by the API.
+func ExportIncomingMessage(inMsg chat1.IncomingMessage) *chat1.Message {
+	state, err := inMsg.Message.State()
+	if err != nil {
+		errStr := err.Error()
+		return &chat1.Message{Error: &errStr}
+	}
+
+	switch state {
+	case chat1.MessageUnboxedState_ERROR:
+		errStr := inMsg.Message.Error().ErrMsg
+		return &chat1.Message{Error: &errStr}
+	case chat1.MessageUnboxedState_VALID:
+		// if we weren't able to get an inbox item here, then just return an error
+		if inMsg.Conv == nil {
+			msg := "unable to get chat channel"
+			return &chat1.Message{Error: &msg}
+		}
+		summary := ExportUIMessageToSummary(inMsg.ConvID, *inMsg.Conv, inMsg.Message.Valid())
+		return &chat1.Message{Msg: &summary}
+	default:
+		return nil
+	}
+}
+
+// ExportUIMessageToSummary converts a valid message from a chat notification
+// into a message of the chat JSON API, as printed by api-listen.
+func ExportUIMessageToSummary(convID chat1.ConversationID, conv chat1.InboxUIItem,
+	mv chat1.UIMessageValid,
+) chat1.MsgSummary {
+	summary := chat1.MsgSummary{
+		Id:     mv.MessageID,
+		ConvID: convID.ConvIDStr(),
+		Channel: chat1.ChatChannel{
+			Name:        conv.Name,
+			MembersType: strings.ToLower(conv.MembersType.String()),
+			TopicType:   strings.ToLower(conv.TopicType.String()),
+			TopicName:   conv.Channel,
+			Public:      conv.Visibility == keybase1.TLFVisibility_PUBLIC,
+		},
+		Sender: chat1.MsgSender{
+			Uid:        keybase1.UID(mv.SenderUID.String()),
+			DeviceID:   keybase1.DeviceID(mv.SenderDeviceID.String()),
+			Username:   mv.SenderUsername,
+			DeviceName: mv.SenderDeviceName,
+		},
+		SentAt:              mv.Ctime.UnixSeconds(),
+		SentAtMs:            mv.Ctime.UnixMilliseconds(),
+		RevokedDevice:       mv.SenderDeviceRevokedAt != nil,
+		IsEphemeral:         mv.IsEphemeral,
+		IsEphemeralExpired:  mv.IsEphemeralExpired,
+		ETime:               mv.Etime,
+		HasPairwiseMacs:     mv.HasPairwiseMacs,
+		Content:             ExportMsgContent(mv.MessageBody),
+		AtMentionUsernames:  mv.AtMentions,
+		ChannelMention:      strings.ToLower(mv.ChannelMention.String()),
+		ChannelNameMentions: mv.ChannelNameMentions,
+	}
+	if mv.Reactions.Reactions != nil {
+		summary.Reactions = &mv.Reactions
+	}
+	return summary
+}
+
+func exportFlipBody(flip *chat1.MessageFlip) (res *chat1.MsgFlipContent) {
+	if flip == nil {
+		return res
+	}
+	res = new(chat1.MsgFlipContent)
+	res.GameID = flip.GameID.FlipGameIDStr()
+	res.FlipConvID = flip.FlipConvID.ConvIDStr()
+	res.TeamMentions = flip.TeamMentions
+	res.UserMentions = flip.UserMentions
+	res.Text = flip.Text
+	return res
+}
+
+// exportPollBody shows a poll without any votes, use PresentPoll for the
+// tallied results.
+func exportPollBody(poll *chat1.MessagePoll) (res *chat1.MsgPollContent) {
+	if poll == nil {
+		return res
+	}
+	res = new(chat1.MsgPollContent)
+	*res = PresentPoll(chat1.MessageUnboxedValid{
+		MessageBody: chat1.NewMessageBodyWithPoll(*poll),
+	}, time.Now())
+	return res
+}
+
+func exportPollVoteBody(vote *chat1.MessagePollVote) (res *chat1.MsgPollVoteContent) {
+	if vote == nil {
+		return res
+	}
+	return &chat1.MsgPollVoteContent{
+		MessageID: vote.MessageID,
+		Choices:   vote.Choices,
+	}
+}
+
+func exportTextBody(text *chat1.MessageText) (res *chat1.MsgTextContent) {
+	if text == nil {
+		return res
+	}
+	res = new(chat1.MsgTextContent)
+	res.Body = text.Body
+	res.Payments = text.Payments
+	res.ReplyTo = text.ReplyTo
+	res.UserMentions = text.UserMentions
+	res.TeamMentions = text.TeamMentions
+	res.LiveLocation = text.LiveLocation
+	res.BotCommand = text.BotCommand
+	for _, emoji := range text.Emojis {
+		var convIDStr *chat1.ConvIDStr
+		var msgID *chat1.MessageID
+		if emoji.Source.IsMessage() {
+			convIDStr = new(chat1.ConvIDStr)
+			msgID = new(chat1.MessageID)
+			*convIDStr = emoji.Source.Message().ConvID.ConvIDStr()
+			*msgID = emoji.Source.Message().MsgID
+		}
+		res.Emojis = append(res.Emojis, chat1.EmojiContent{
+			Alias:       emoji.Alias,
+			IsCrossTeam: emoji.IsCrossTeam,
+			ConvID:      convIDStr,
+			MessageID:   msgID,
+		})
+	}
+	return res
+}
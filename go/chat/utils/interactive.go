@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// ImportInteractive converts an interactive message of the chat JSON API into
+// a message body, element types are "button" or "select".
+func ImportInteractive(content chat1.MsgInteractiveContent) (res chat1.MessageInteractive, err error) {
+	res.Body = content.Body
+	for _, elem := range content.Elements {
+		typ, ok := chat1.InteractiveElementTypeMap[strings.ToUpper(elem.Typ)]
+		if !ok {
+			return res, fmt.Errorf("unknown interactive element type: %q", elem.Typ)
+		}
+		var opts []chat1.InteractiveOption
+		for _, opt := range elem.Options {
+			opts = append(opts, chat1.InteractiveOption{
+				Label:   opt.Label,
+				Payload: opt.Payload,
+			})
+		}
+		res.Elements = append(res.Elements, chat1.InteractiveElement{
+			Typ:     typ,
+			Id:      elem.Id,
+			Label:   elem.Label,
+			Payload: elem.Payload,
+			Options: opts,
+		})
+	}
+	return res, nil
+}
+
+// NewInteractiveAction builds the action a user sends to the bot of the
+// interactive message msg when picking the choice with elementID and payload.
+// The payload can be left empty for buttons.
+func NewInteractiveAction(msg chat1.MessageUnboxedValid, elementID, payload string) (res chat1.MessageInteractiveAction, err error) {
+	if !msg.MessageBody.IsType(chat1.MessageType_INTERACTIVE) {
+		return res, fmt.Errorf("message %d is not an interactive message", msg.ServerHeader.MessageID)
+	}
+	choice, ok := msg.MessageBody.Interactive().FindChoice(elementID, payload)
+	if !ok {
+		return res, fmt.Errorf("no such choice in message %d: %s", msg.ServerHeader.MessageID, elementID)
+	}
+	return chat1.MessageInteractiveAction{
+		MessageID: msg.ServerHeader.MessageID,
+		BotUID:    msg.ClientHeader.Sender,
+		ElementID: choice.ElementID,
+		Payload:   choice.Payload,
+	}, nil
+}
+
+// IsInteractiveActionFor returns whether body is an interactive action for the
+// bot uid.
+func IsInteractiveActionFor(body chat1.MessageBody, uid gregor1.UID) bool {
+	return body.IsType(chat1.MessageType_INTERACTIVEACTION) && body.Interactiveaction().BotUID.Eq(uid)
+}
+
+func exportInteractiveBody(msg *chat1.MessageInteractive) (res *chat1.MsgInteractiveContent) {
+	if msg == nil {
+		return res
+	}
+	res = &chat1.MsgInteractiveContent{Body: msg.Body}
+	for _, elem := range msg.Elements {
+		var opts []chat1.MsgInteractiveOption
+		for _, opt := range elem.Options {
+			opts = append(opts, chat1.MsgInteractiveOption{
+				Label:   opt.Label,
+				Payload: opt.Payload,
+			})
+		}
+		res.Elements = append(res.Elements, chat1.MsgInteractiveElement{
+			Typ:     strings.ToLower(elem.Typ.String()),
+			Id:      elem.Id,
+			Label:   elem.Label,
+			Payload: elem.Payload,
+			Options: opts,
+		})
+	}
+	return res
+}
+
+func exportInteractiveActionBody(action *chat1.MessageInteractiveAction) (res *chat1.MsgInteractiveActionContent) {
+	if action == nil {
+		return res
+	}
+	return &chat1.MsgInteractiveActionContent{
+		MessageID: action.MessageID,
+		BotUID:    keybase1.UID(action.BotUID.String()),
+		ElementID: action.ElementID,
+		Payload:   action.Payload,
+	}
+}
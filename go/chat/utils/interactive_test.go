@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/chat/msgchecker"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteractive(t *testing.T) {
+	content := chat1.MsgInteractiveContent{
+		Body: "*deploy*?",
+		Elements: []chat1.MsgInteractiveElement{
+			{Typ: "button", Id: "yes", Label: "Deploy", Payload: "deploy:prod"},
+			{Typ: "select", Id: "when", Label: "When", Options: []chat1.MsgInteractiveOption{
+				{Label: "Now", Payload: "now"},
+				{Label: "Tonight", Payload: "tonight"},
+			}},
+			{Typ: "BUTTON", Id: "no", Label: "Cancel", Payload: "cancel"},
+		},
+	}
+	msg, err := ImportInteractive(content)
+	require.NoError(t, err)
+	body := chat1.NewMessageBodyWithInteractive(msg)
+	require.NoError(t, msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: body}))
+
+	// exporting gives back what the API sent, with lowercase types
+	exported := ExportMsgContent(body).Interactive
+	require.NotNil(t, exported)
+	content.Elements[2].Typ = "button"
+	require.Equal(t, content, *exported)
+
+	choices := msg.Choices()
+	require.Len(t, choices, 4)
+	require.Equal(t, []string{"Deploy", "Now", "Tonight", "Cancel"},
+		[]string{choices[0].Label, choices[1].Label, choices[2].Label, choices[3].Label})
+	require.Equal(t, "When", choices[2].ElementLabel)
+
+	_, err = ImportInteractive(chat1.MsgInteractiveContent{
+		Elements: []chat1.MsgInteractiveElement{{Typ: "slider", Id: "x"}},
+	})
+	require.Error(t, err)
+
+	botUID := gregor1.UID("bot")
+	mvalid := chat1.MessageUnboxedValid{
+		ClientHeader: chat1.MessageClientHeaderVerified{Sender: botUID},
+		ServerHeader: chat1.MessageServerHeader{MessageID: 10},
+		MessageBody:  body,
+	}
+	action, err := NewInteractiveAction(mvalid, "yes", "")
+	require.NoError(t, err)
+	require.Equal(t, chat1.MessageInteractiveAction{
+		MessageID: 10,
+		BotUID:    botUID,
+		ElementID: "yes",
+		Payload:   "deploy:prod",
+	}, action)
+	actionBody := chat1.NewMessageBodyWithInteractiveaction(action)
+	require.NoError(t, msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: actionBody}))
+	require.True(t, IsInteractiveActionFor(actionBody, botUID))
+	require.False(t, IsInteractiveActionFor(actionBody, gregor1.UID("user")))
+	require.False(t, IsInteractiveActionFor(body, botUID))
+
+	action, err = NewInteractiveAction(mvalid, "when", "tonight")
+	require.NoError(t, err)
+	require.Equal(t, "tonight", action.Payload)
+	exportedAction := ExportMsgContent(chat1.NewMessageBodyWithInteractiveaction(action)).InteractiveAction
+	require.NotNil(t, exportedAction)
+	require.Equal(t, botUID.String(), exportedAction.BotUID.String())
+
+	// select options have to be picked, and payloads have to exist
+	_, err = NewInteractiveAction(mvalid, "when", "")
+	require.Error(t, err)
+	_, err = NewInteractiveAction(mvalid, "when", "tomorrow")
+	require.Error(t, err)
+	_, err = NewInteractiveAction(mvalid, "maybe", "")
+	require.Error(t, err)
+	mvalid.MessageBody = chat1.NewMessageBodyWithText(chat1.MessageText{Body: "hi"})
+	_, err = NewInteractiveAction(mvalid, "yes", "")
+	require.Error(t, err)
+}
+
+func TestCheckInteractive(t *testing.T) {
+	check := func(msg chat1.MessageInteractive) error {
+		return msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{
+			MessageBody: chat1.NewMessageBodyWithInteractive(msg),
+		})
+	}
+	button := func(id string) chat1.InteractiveElement {
+		return chat1.InteractiveElement{
+			Typ:     chat1.InteractiveElementType_BUTTON,
+			Id:      id,
+			Label:   "label " + id,
+			Payload: "payload " + id,
+		}
+	}
+	require.NoError(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{button("a")}}))
+	require.Error(t, check(chat1.MessageInteractive{Body: "no elements"}))
+	require.Error(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{button("a"), button("a")}}))
+	require.Error(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{button("")}}))
+
+	noPayload := button("a")
+	noPayload.Payload = ""
+	require.Error(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{noPayload}}))
+
+	emptySelect := chat1.InteractiveElement{Typ: chat1.InteractiveElementType_SELECT, Id: "s"}
+	require.Error(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{emptySelect}}))
+
+	bigSelect := emptySelect
+	for range msgchecker.InteractiveMaxChoices {
+		bigSelect.Options = append(bigSelect.Options, chat1.InteractiveOption{Label: "l", Payload: "p"})
+	}
+	require.NoError(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{bigSelect}}))
+	require.Error(t, check(chat1.MessageInteractive{Elements: []chat1.InteractiveElement{bigSelect, button("a")}}))
+}
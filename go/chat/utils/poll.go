@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"sort"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// AddPollVote records vote as the latest vote of its sender on the poll in
+// mvalid. Votes sent after the poll closed, or that do not fit its options,
+// are ignored. Returns whether the votes on the poll changed.
+func AddPollVote(mvalid *chat1.MessageUnboxedValid, vote chat1.MessageUnboxedValid) bool {
+	if !mvalid.MessageBody.IsType(chat1.MessageType_POLL) ||
+		!vote.MessageBody.IsType(chat1.MessageType_POLLVOTE) {
+		return false
+	}
+	poll := mvalid.MessageBody.Poll()
+	body := vote.MessageBody.Pollvote()
+	if body.MessageID != mvalid.ServerHeader.MessageID {
+		return false
+	}
+	if poll.CloseTime != nil && vote.ServerHeader.Ctime > *poll.CloseTime {
+		return false
+	}
+	if !poll.MultiChoice && len(body.Choices) > 1 {
+		return false
+	}
+	for _, choice := range body.Choices {
+		if choice < 0 || choice >= len(poll.Options) {
+			return false
+		}
+	}
+	if mvalid.PollVotes.Votes == nil {
+		mvalid.PollVotes.Votes = make(map[string]chat1.PollVote)
+	}
+	prev, ok := mvalid.PollVotes.Votes[vote.SenderUsername]
+	if ok && prev.VoteMsgID >= vote.ServerHeader.MessageID {
+		return false
+	}
+	mvalid.PollVotes.Votes[vote.SenderUsername] = chat1.PollVote{
+		Ctime:     vote.ServerHeader.Ctime,
+		VoteMsgID: vote.ServerHeader.MessageID,
+		Choices:   body.Choices,
+	}
+	return true
+}
+
+// PresentPoll tallies the votes on a poll message. Voters are left out of
+// the results of anonymous polls.
+func PresentPoll(mvalid chat1.MessageUnboxedValid, now time.Time) (res chat1.MsgPollContent) {
+	if !mvalid.MessageBody.IsType(chat1.MessageType_POLL) {
+		return res
+	}
+	poll := mvalid.MessageBody.Poll()
+	res = chat1.MsgPollContent{
+		Question:    poll.Question,
+		MultiChoice: poll.MultiChoice,
+		Anonymous:   poll.Anonymous,
+		Options:     make([]chat1.PollOptionResult, len(poll.Options)),
+	}
+	for i, option := range poll.Options {
+		res.Options[i].Option = option
+	}
+	if poll.CloseTime != nil {
+		closeTime := poll.CloseTime.UnixSeconds()
+		res.CloseTime = &closeTime
+		res.Closed = !now.Before(poll.CloseTime.Time())
+	}
+	usernames := make([]string, 0, len(mvalid.PollVotes.Votes))
+	for username := range mvalid.PollVotes.Votes {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		vote := mvalid.PollVotes.Votes[username]
+		if len(vote.Choices) == 0 {
+			continue
+		}
+		res.TotalVoters++
+		for _, choice := range vote.Choices {
+			if choice < 0 || choice >= len(res.Options) {
+				continue
+			}
+			res.Options[choice].Votes++
+			if !poll.Anonymous {
+				res.Options[choice].Voters = append(res.Options[choice].Voters, username)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollVotes(t *testing.T) {
+	now := time.Now().Round(time.Millisecond)
+	closeTime := gregor1.ToTime(now.Add(time.Hour))
+	newPoll := func(multi, anon bool) chat1.MessageUnboxedValid {
+		return chat1.MessageUnboxedValid{
+			ServerHeader: chat1.MessageServerHeader{MessageID: 10},
+			MessageBody: chat1.NewMessageBodyWithPoll(chat1.MessagePoll{
+				Question:    "lunch?",
+				Options:     []string{"tacos", "pizza", "salad"},
+				MultiChoice: multi,
+				Anonymous:   anon,
+				CloseTime:   &closeTime,
+			}),
+		}
+	}
+	vote := func(msgID chat1.MessageID, username string, ctime time.Time, choices ...int) chat1.MessageUnboxedValid {
+		return chat1.MessageUnboxedValid{
+			ServerHeader: chat1.MessageServerHeader{
+				MessageID: msgID,
+				Ctime:     gregor1.ToTime(ctime),
+			},
+			SenderUsername: username,
+			MessageBody: chat1.NewMessageBodyWithPollvote(chat1.MessagePollVote{
+				MessageID: 10,
+				Choices:   choices,
+			}),
+		}
+	}
+
+	poll := newPoll(false, false)
+	require.True(t, AddPollVote(&poll, vote(11, "alice", now, 0)))
+	require.True(t, AddPollVote(&poll, vote(12, "bob", now, 1)))
+	require.True(t, AddPollVote(&poll, vote(13, "carol", now, 0)))
+	// single choice polls take one choice, and choices must exist
+	require.False(t, AddPollVote(&poll, vote(14, "dave", now, 0, 1)))
+	require.False(t, AddPollVote(&poll, vote(15, "dave", now, 3)))
+	// votes after the poll closed don't count
+	require.False(t, AddPollVote(&poll, vote(16, "dave", now.Add(2*time.Hour), 2)))
+	// the latest vote of a user wins, and an empty vote retracts
+	require.True(t, AddPollVote(&poll, vote(17, "bob", now, 0)))
+	require.False(t, AddPollVote(&poll, vote(12, "bob", now, 1)))
+	require.True(t, AddPollVote(&poll, vote(18, "carol", now)))
+
+	res := PresentPoll(poll, now)
+	require.False(t, res.Closed)
+	require.Equal(t, 2, res.TotalVoters)
+	require.Equal(t, []chat1.PollOptionResult{
+		{Option: "tacos", Votes: 2, Voters: []string{"alice", "bob"}},
+		{Option: "pizza"},
+		{Option: "salad"},
+	}, res.Options)
+	require.True(t, PresentPoll(poll, now.Add(time.Hour)).Closed)
+
+	poll = newPoll(true, true)
+	require.True(t, AddPollVote(&poll, vote(11, "alice", now, 0, 2)))
+	require.True(t, AddPollVote(&poll, vote(12, "bob", now, 2)))
+	res = PresentPoll(poll, now)
+	require.Equal(t, 2, res.TotalVoters)
+	require.Equal(t, []chat1.PollOptionResult{
+		{Option: "tacos", Votes: 1},
+		{Option: "pizza"},
+		{Option: "salad", Votes: 2},
+	}, res.Options)
+}
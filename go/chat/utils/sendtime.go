@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/araddon/dateparse"
+)
+
+var errNoSendTime = errors.New(`unrecognized time, try something like "in 20 minutes", "tomorrow at 9am" or "friday 17:30"`)
+
+// Hour of the day a message is sent when only a day is given, e.g. "tomorrow".
+const (
+	defaultSendHour = 9
+	tonightSendHour = 20
+)
+
+var sendTimeUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var sendTimeWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseSendTime reads the time expression at the start of text and returns the
+// time it refers to, relative to now, along with the rest of the text. It
+// understands relative times ("in 20 minutes", "in 1h30m"), days ("today",
+// "tonight", "tomorrow", "friday", "next monday", "2026-05-01") optionally
+// followed by a time of day ("at 9am", "17:30", "noon"), and a bare time of
+// day ("at 5pm"), which is today or tomorrow, whichever comes first.
+func ParseSendTime(text string, now time.Time) (sendTime time.Time, rest string, err error) {
+	words := strings.Fields(strings.ToLower(text))
+	sendTime, n, err := parseSendTimeWords(words, now)
+	if err != nil {
+		return sendTime, text, err
+	}
+	if !sendTime.After(now) {
+		return sendTime, text, fmt.Errorf("%s is in the past", sendTime.Format(time.RFC1123))
+	}
+	return sendTime, skipFields(text, n), nil
+}
+
+// ParseSendTimeExact is like ParseSendTime for a string that holds only a
+// time. It also accepts absolute dates in any format dateparse understands.
+func ParseSendTimeExact(s string, now time.Time) (time.Time, error) {
+	sendTime, rest, err := ParseSendTime(s, now)
+	if err == nil && rest == "" {
+		return sendTime, nil
+	}
+	if t, perr := dateparse.ParseIn(strings.TrimSpace(s), now.Location()); perr == nil {
+		if !t.After(now) {
+			return t, fmt.Errorf("%s is in the past", t.Format(time.RFC1123))
+		}
+		return t, nil
+	}
+	if err == nil {
+		err = errNoSendTime
+	}
+	return sendTime, err
+}
+
+func parseSendTimeWords(words []string, now time.Time) (res time.Time, n int, err error) {
+	if len(words) == 0 {
+		return res, 0, errNoSendTime
+	}
+	if words[0] == "in" {
+		d, n, err := parseSendTimeDuration(words[1:])
+		if err != nil {
+			return res, 0, err
+		}
+		return now.Add(d), n + 1, nil
+	}
+
+	day, hour, n, ok := parseSendTimeDay(words, now)
+	if ok {
+		clock, m, ok := parseSendTimeClock(words[n:])
+		if !ok {
+			return atClock(day, time.Duration(hour)*time.Hour), n, nil
+		}
+		return atClock(day, clock), n + m, nil
+	}
+
+	// a time of day on its own
+	clock, n, ok := parseSendTimeClock(words)
+	if !ok {
+		return res, 0, errNoSendTime
+	}
+	res = atClock(now, clock)
+	if !res.After(now) {
+		res = atClock(now.AddDate(0, 0, 1), clock)
+	}
+	return res, n, nil
+}
+
+// parseSendTimeDuration parses "20 minutes", "an hour", "1h30m" or "2 hours 15 minutes".
+func parseSendTimeDuration(words []string) (res time.Duration, n int, err error) {
+	for n < len(words) {
+		if d, perr := time.ParseDuration(words[n]); perr == nil && d > 0 {
+			res += d
+			n++
+			continue
+		}
+		if n+1 >= len(words) {
+			break
+		}
+		var count int
+		switch words[n] {
+		case "a", "an":
+			count = 1
+		default:
+			if count, err = strconv.Atoi(words[n]); err != nil || count <= 0 {
+				break
+			}
+		}
+		unit, ok := sendTimeUnits[words[n+1]]
+		if count <= 0 || !ok {
+			break
+		}
+		res += time.Duration(count) * unit
+		n += 2
+		if n < len(words) && words[n] == "and" && n+1 < len(words) {
+			if _, _, err := parseSendTimeDuration(words[n+1:]); err == nil {
+				n++
+			}
+		}
+	}
+	if res == 0 {
+		return 0, 0, errNoSendTime
+	}
+	return res, n, nil
+}
+
+// parseSendTimeDay parses a day and returns its start, along with the hour to
+// use if no time of day follows.
+func parseSendTimeDay(words []string, now time.Time) (day time.Time, hour, n int, ok bool) {
+	today := startOfDay(now)
+	hour = defaultSendHour
+	first := words[0]
+	if first == "on" || first == "next" {
+		if len(words) < 2 {
+			return day, 0, 0, false
+		}
+		n = 1
+	}
+	switch w := words[n]; w {
+	case "today":
+		return today, hour, n + 1, first != "next"
+	case "tonight":
+		return today, tonightSendHour, n + 1, first != "next"
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), hour, n + 1, first != "next"
+	default:
+		if wd, found := sendTimeWeekdays[w]; found {
+			days := (int(wd) - int(now.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), hour, n + 1, true
+		}
+		if t, err := time.ParseInLocation("2006-01-02", w, now.Location()); err == nil && first != "next" {
+			return t, hour, n + 1, true
+		}
+	}
+	return day, 0, 0, false
+}
+
+// parseSendTimeClock parses a time of day like "at 9am", "9:30 pm", "17:00"
+// or "noon", and returns it as an offset from midnight.
+func parseSendTimeClock(words []string) (res time.Duration, n int, ok bool) {
+	if len(words) == 0 {
+		return 0, 0, false
+	}
+	at := words[0] == "at"
+	if at {
+		n = 1
+		if len(words) < 2 {
+			return 0, 0, false
+		}
+	}
+	w := words[n]
+	switch w {
+	case "noon":
+		return 12 * time.Hour, n + 1, true
+	case "midnight":
+		return 0, n + 1, true
+	}
+	suffix := ""
+	switch {
+	case strings.HasSuffix(w, "am"), strings.HasSuffix(w, "pm"):
+		suffix = w[len(w)-2:]
+		w = w[:len(w)-2]
+	case n+1 < len(words) && (words[n+1] == "am" || words[n+1] == "pm"):
+		suffix = words[n+1]
+		n++
+	}
+	hourStr, minStr, hasMin := strings.Cut(w, ":")
+	if hourStr == "" || !isDigits(hourStr) || (hasMin && (len(minStr) != 2 || !isDigits(minStr))) {
+		return 0, 0, false
+	}
+	// a bare number is only a time of day after "at" or with am/pm
+	if !hasMin && suffix == "" && !at {
+		return 0, 0, false
+	}
+	hour, _ := strconv.Atoi(hourStr)
+	minute := 0
+	if hasMin {
+		minute, _ = strconv.Atoi(minStr)
+	}
+	if minute > 59 {
+		return 0, 0, false
+	}
+	switch suffix {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, false
+		}
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, n + 1, true
+}
+
+// atClock is the wall clock time clock after midnight on the day of t, which
+// is not the same as adding clock to midnight on days the clocks change.
+func atClock(t time.Time, clock time.Duration) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, t.Location())
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// skipFields returns text after its first n whitespace separated fields, with
+// the whitespace in the rest of the text preserved.
+func skipFields(text string, n int) string {
+	rest := strings.TrimLeftFunc(text, unicode.IsSpace)
+	for range n {
+		i := strings.IndexFunc(rest, unicode.IsSpace)
+		if i < 0 {
+			return ""
+		}
+		rest = strings.TrimLeftFunc(rest[i:], unicode.IsSpace)
+	}
+	return rest
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSendTime(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2026, 4, 15, 14, 20, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 4, day, hour, minute, 0, 0, time.UTC)
+	}
+	for _, tc := range []struct {
+		text     string
+		sendTime time.Time
+		rest     string
+	}{
+		{"in 20 minutes stand up", now.Add(20 * time.Minute), "stand up"},
+		{"in an hour  call\nmom", now.Add(time.Hour), "call\nmom"},
+		{"in 1h30m", now.Add(90 * time.Minute), ""},
+		{"in 2 hours and 15 mins lunch", now.Add(135 * time.Minute), "lunch"},
+		{"in 3 days 10 things", now.AddDate(0, 0, 3), "10 things"},
+		{"tomorrow pay rent", at(16, 9, 0), "pay rent"},
+		{"tomorrow at 7:45pm pay rent", at(16, 19, 45), "pay rent"},
+		{"Tonight game", at(15, 20, 0), "game"},
+		{"today at 5 pm go home", at(15, 17, 0), "go home"},
+		{"at 9 standup", at(16, 9, 0), "standup"},
+		{"at 15:00 standup", at(15, 15, 0), "standup"},
+		{"noon lunch", at(16, 12, 0), "lunch"},
+		{"friday 17:30 drinks", at(17, 17, 30), "drinks"},
+		{"on wed review", at(22, 9, 0), "review"},
+		{"next monday at 10am plan", at(20, 10, 0), "plan"},
+		{"2026-05-01 at 8am may day", time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC), "may day"},
+	} {
+		sendTime, rest, err := ParseSendTime(tc.text, now)
+		require.NoError(t, err, tc.text)
+		require.Equal(t, tc.sendTime, sendTime, tc.text)
+		require.Equal(t, tc.rest, rest, tc.text)
+	}
+
+	for _, text := range []string{"", "soon", "in", "in a while", "at 25:00", "today at 8am", "2026-01-01 at noon", "at 13pm"} {
+		_, rest, err := ParseSendTime(text, now)
+		require.Error(t, err, text)
+		require.Equal(t, text, rest)
+	}
+}
+
+func TestParseSendTimeDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// clocks go forward an hour early on Sunday
+	now := time.Date(2026, 3, 7, 14, 0, 0, 0, loc)
+	sendTime, _, err := ParseSendTime("tomorrow at 9am", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 8, 9, 0, 0, 0, loc), sendTime)
+	sendTime, _, err = ParseSendTime("tomorrow", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 8, 9, 0, 0, 0, loc), sendTime)
+	sendTime, _, err = ParseSendTime("at 8am", time.Date(2026, 3, 7, 20, 0, 0, 0, loc))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 8, 8, 0, 0, 0, loc), sendTime)
+}
+
+func TestParseSendTimeExact(t *testing.T) {
+	now := time.Date(2026, 4, 15, 14, 20, 0, 0, time.UTC)
+	sendTime, err := ParseSendTimeExact("in 5m", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(5*time.Minute), sendTime)
+	sendTime, err = ParseSendTimeExact("2026-04-20T08:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 4, 20, 8, 0, 0, 0, time.UTC), sendTime.UTC())
+	_, err = ParseSendTimeExact("tomorrow and more", now)
+	require.Error(t, err)
+	_, err = ParseSendTimeExact("2020-01-01", now)
+	require.Error(t, err)
+}
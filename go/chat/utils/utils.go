@@ -651,6 +651,8 @@ func GetSupersedes(msg chat1.MessageUnboxed) ([]chat1.MessageID, error) {
 		return []chat1.MessageID{msg.Valid().MessageBody.Attachmentuploaded().MessageID}, nil
 	case chat1.MessageType_UNFURL:
 		return []chat1.MessageID{msg.Valid().MessageBody.Unfurl().MessageID}, nil
+	case chat1.MessageType_POLLVOTE:
+		return []chat1.MessageID{msg.Valid().MessageBody.Pollvote().MessageID}, nil
 	default:
 		return nil, nil
 	}
@@ -1176,6 +1178,13 @@ func GetMsgSnippetBody(ctx context.Context, g *globals.Context, uid gregor1.UID,
 		return msgBody.Flip().Text, ""
 	case chat1.MessageType_PIN:
 		return "Pinned message", ""
+	case chat1.MessageType_POLL:
+		return fmt.Sprintf("Poll: %s", msgBody.Poll().Question), ""
+	case chat1.MessageType_INTERACTIVE:
+		if len(msgBody.Interactive().Body) == 0 {
+			return "Interactive message", ""
+		}
+		return msgBody.Interactive().Body, ""
 	case chat1.MessageType_ATTACHMENT:
 		obj := msgBody.Attachment().Object
 		title := obj.Title
@@ -1603,6 +1612,32 @@ func PresentConversationLocals(ctx context.Context, g *globals.Context, uid greg
 	return res
 }
 
+// AttachThreadUnreads fills in the reply threads with unread messages of
+// presented conversations. Each takes a read of local storage, so they are
+// only attached for the callers that list them, not on every inbox load.
+func AttachThreadUnreads(ctx context.Context, g *globals.Context, uid gregor1.UID,
+	convs []chat1.InboxUIItem,
+) {
+	if g.ReplyThreadSource == nil {
+		return
+	}
+	for i, conv := range convs {
+		if conv.ReadMsgID >= conv.MaxVisibleMsgID {
+			continue
+		}
+		convID, err := chat1.MakeConvID(conv.ConvID.String())
+		if err != nil {
+			continue
+		}
+		threadUnreads, err := g.ReplyThreadSource.ThreadUnreads(ctx, uid, convID, conv.ReadMsgID)
+		if err != nil {
+			g.GetLog().CDebugf(ctx, "AttachThreadUnreads: failed to get thread unreads: %s", err)
+			continue
+		}
+		convs[i].ThreadUnreads = threadUnreads
+	}
+}
+
 func PresentThreadView(ctx context.Context, g *globals.Context, uid gregor1.UID, tv chat1.ThreadView,
 	convID chat1.ConversationID,
 ) (res chat1.UIMessages) {
@@ -2592,29 +2627,14 @@ func IsPermanentErr(err error) bool {
 }
 
 func EphemeralLifetimeFromConv(ctx context.Context, g *globals.Context, conv chat1.ConversationLocal) (res *gregor1.DurationSec, err error) {
-	// Check to see if the conversation has an exploding policy
+	// Check to see if the conversation has an exploding policy. A policy which
+	// allows non-exploding messages only caps the lifetime, so it doesn't force
+	// one here.
 	var retentionRes *gregor1.DurationSec
 	var gregorRes *gregor1.DurationSec
-	var rentTyp chat1.RetentionPolicyType
-	var convSet bool
-	if conv.ConvRetention != nil {
-		if rentTyp, err = conv.ConvRetention.Typ(); err != nil {
-			return res, err
-		}
-		if rentTyp == chat1.RetentionPolicyType_EPHEMERAL {
-			e := conv.ConvRetention.Ephemeral()
-			retentionRes = &e.Age
-		}
-		convSet = rentTyp != chat1.RetentionPolicyType_INHERIT
-	}
-	if !convSet && conv.TeamRetention != nil {
-		if rentTyp, err = conv.TeamRetention.Typ(); err != nil {
-			return res, err
-		}
-		if rentTyp == chat1.RetentionPolicyType_EPHEMERAL {
-			e := conv.TeamRetention.Ephemeral()
-			retentionRes = &e.Age
-		}
+	policy := conv.GetEphemeralPolicy()
+	if policy != nil && !policy.AllowNonExploding {
+		retentionRes = &policy.Age
 	}
 
 	// See if there is anything in Gregor
@@ -2640,6 +2660,9 @@ func EphemeralLifetimeFromConv(ctx context.Context, g *globals.Context, conv cha
 			return res, nil
 		}
 		gsec := gregor1.DurationSec(sec)
+		if policy != nil && gsec > policy.Age {
+			gsec = policy.Age
+		}
 		gregorRes = &gsec
 	}
 	if retentionRes != nil && gregorRes != nil {
@@ -3004,6 +3027,12 @@ func ExportToSummary(i chat1.InboxUIItem) (s chat1.ConvSummary) {
 	s.Id = i.ConvID
 	s.IsDefaultConv = i.IsDefaultConv
 	s.Unread = i.ReadMsgID < i.MaxVisibleMsgID
+	for _, thread := range i.ThreadUnreads {
+		s.UnreadThreads = append(s.UnreadThreads, chat1.ThreadUnreadSummary{
+			RootID:      thread.RootMsgID,
+			UnreadCount: thread.UnreadCount,
+		})
+	}
 	s.ActiveAt = i.Time.UnixSeconds()
 	s.ActiveAtMs = i.Time.UnixMilliseconds()
 	s.FinalizeInfo = i.FinalizeInfo
@@ -3205,6 +3234,8 @@ func IsDeletedConvError(err error) bool {
 const (
 	DisablePlaintextDesktopGregorKey = "disableplaintextdesktop"
 	ConvertHEICGregorKey             = "convertheic"
+	StripMetadataGregorKey           = "stripattachmentmetadata"
+	DNDScheduleGregorKey             = "chatdndschedule"
 )
 
 func SetGregorBool(ctx context.Context, g *globals.Context, key string, disabled bool) error {
@@ -3238,6 +3269,23 @@ func GetGregorBoolFromState(st gregor.State, key string, defaultVal bool) (bool,
 	return defaultVal, nil
 }
 
+// GetGregorBodyFromState returns the body of the item of a category that
+// UpdateCategory sets, or nil if it was never set.
+func GetGregorBodyFromState(st gregor.State, key string) ([]byte, error) {
+	cat, err := gregor1.ObjFactory{}.MakeCategory(key)
+	if err != nil {
+		return nil, err
+	}
+	items, err := st.ItemsWithCategoryPrefix(cat)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0].Body().Bytes(), nil
+}
+
 type bgOperationKey int
 
 var bgOpKey bgOperationKey
@@ -0,0 +1,214 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/clockwork"
+)
+
+const (
+	HeaderHookID    = "X-Keybase-Webhook-Id"
+	HeaderDelivery  = "X-Keybase-Delivery"
+	HeaderEvent     = "X-Keybase-Event"
+	HeaderTimestamp = "X-Keybase-Timestamp"
+	HeaderSignature = "X-Keybase-Signature"
+)
+
+// Sign computes the signature sent along with a payload. It covers the
+// timestamp of the delivery as well, so receivers can reject replayed
+// deliveries.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", timestamp)
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type delivery struct {
+	id      string
+	event   string
+	payload []byte
+	// convID and msgID point at what the payload was about, for the
+	// dead-letter log
+	convID chat1.ConversationID
+	msgID  chat1.MessageID
+}
+
+// deadLetter is a line of the dead-letter log, a delivery that did not make
+// it to the endpoint of its hook. The payload is left out, it holds decrypted
+// messages and the log is sent along with the other logs.
+type deadLetter struct {
+	HookID     string          `json:"hook_id"`
+	URL        string          `json:"url"`
+	DeliveryID string          `json:"delivery_id"`
+	Event      string          `json:"event"`
+	ConvID     string          `json:"conv_id,omitempty"`
+	MsgID      chat1.MessageID `json:"msg_id,omitempty"`
+	Attempts   int             `json:"attempts"`
+	Error      string          `json:"error"`
+	FailedAt   int64           `json:"failed_at"`
+}
+
+// permanentError is a failure of a delivery that retrying won't fix.
+type permanentError struct {
+	error
+}
+
+// hookQueue delivers the payloads of one hook, in order. Failed deliveries are
+// retried with increasing backoff before they are sent to the dead-letter
+// log.
+type hookQueue struct {
+	hook        chat1.ChatWebhook
+	client      *http.Client
+	clock       clockwork.Clock
+	retryDelays []time.Duration
+	deadLetter  func(deadLetter)
+	debug       func(format string, args ...any)
+
+	ch     chan delivery
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+const hookQueueSize = 500
+
+var defaultRetryDelays = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+}
+
+func newHookQueue(hook chat1.ChatWebhook, client *http.Client, clock clockwork.Clock,
+	deadLetterFn func(deadLetter), debug func(string, ...any),
+) *hookQueue {
+	return &hookQueue{
+		hook:        hook,
+		client:      client,
+		clock:       clock,
+		retryDelays: defaultRetryDelays,
+		deadLetter:  deadLetterFn,
+		debug:       debug,
+		ch:          make(chan delivery, hookQueueSize),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// enqueue never blocks, the notification router waits on its listeners.
+func (q *hookQueue) enqueue(d delivery) {
+	select {
+	case q.ch <- d:
+	default:
+		q.fail(d, 0, fmt.Errorf("queue full"))
+	}
+}
+
+func (q *hookQueue) start() {
+	go q.run()
+}
+
+// stop ends delivery, and returns a channel that closes once the queue is done.
+// Pending deliveries are sent to the dead-letter log.
+func (q *hookQueue) stop() chan struct{} {
+	close(q.stopCh)
+	return q.doneCh
+}
+
+func (q *hookQueue) run() {
+	defer close(q.doneCh)
+	for {
+		select {
+		case d := <-q.ch:
+			q.deliver(d)
+		case <-q.stopCh:
+			for {
+				select {
+				case d := <-q.ch:
+					q.fail(d, 0, fmt.Errorf("webhooks stopped"))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *hookQueue) deliver(d delivery) {
+	attempts := 0
+	for {
+		err := q.post(d)
+		attempts++
+		if err == nil {
+			return
+		}
+		q.debug("deliver: hook: %s delivery: %s attempt: %d failed: %s", q.hook.Id, d.id, attempts, err)
+		if _, ok := err.(permanentError); ok || attempts > len(q.retryDelays) {
+			q.fail(d, attempts, err)
+			return
+		}
+		select {
+		case <-q.clock.After(q.retryDelays[attempts-1]):
+		case <-q.stopCh:
+			q.fail(d, attempts, err)
+			return
+		}
+	}
+}
+
+func (q *hookQueue) post(d delivery) error {
+	timestamp := q.clock.Now().Unix()
+	req, err := http.NewRequest(http.MethodPost, q.hook.Url, bytes.NewReader(d.payload))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", libkb.UserAgent)
+	req.Header.Set(HeaderHookID, q.hook.Id)
+	req.Header.Set(HeaderDelivery, d.id)
+	req.Header.Set(HeaderEvent, d.event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(q.hook.Secret, timestamp, d.payload))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := q.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("status %s", resp.Status)
+	default:
+		return permanentError{fmt.Errorf("status %s", resp.Status)}
+	}
+}
+
+func (q *hookQueue) fail(d delivery, attempts int, err error) {
+	q.deadLetter(deadLetter{
+		HookID:     q.hook.Id,
+		URL:        q.hook.Url,
+		DeliveryID: d.id,
+		Event:      d.event,
+		Attempts:   attempts,
+		Error:      err.Error(),
+		FailedAt:   q.clock.Now().Unix(),
+		ConvID:     d.convID.String(),
+		MsgID:      d.msgID,
+	})
+}
@@ -0,0 +1,130 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookQueueDelivery(t *testing.T) {
+	var mu sync.Mutex
+	var statuses []int
+	var bodies []string
+	received := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, Sign("secret", timestamp, body), r.Header.Get(HeaderSignature))
+		require.Equal(t, "hook", r.Header.Get(HeaderHookID))
+		require.Equal(t, EventChat, r.Header.Get(HeaderEvent))
+		mu.Lock()
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status = statuses[0]
+			statuses = statuses[1:]
+		}
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		w.WriteHeader(status)
+		received <- struct{}{}
+	}))
+	defer srv.Close()
+
+	deadLetters := make(chan deadLetter, 10)
+	hook := chat1.ChatWebhook{Id: "hook", Url: srv.URL, Secret: "secret"}
+	q := newHookQueue(hook, srv.Client(), clockwork.NewRealClock(), func(dl deadLetter) {
+		deadLetters <- dl
+	}, func(string, ...any) {})
+	q.retryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	q.start()
+	defer func() { <-q.stop() }()
+
+	wait := func(n int) {
+		for i := 0; i < n; i++ {
+			select {
+			case <-received:
+			case <-time.After(10 * time.Second):
+				require.Fail(t, "no delivery")
+			}
+		}
+	}
+
+	// retried until the endpoint recovers
+	mu.Lock()
+	statuses = []int{http.StatusInternalServerError, http.StatusTooManyRequests}
+	mu.Unlock()
+	q.enqueue(delivery{id: "1", event: EventChat, payload: []byte(`{"n":1}`)})
+	wait(3)
+	mu.Lock()
+	require.Equal(t, []string{`{"n":1}`, `{"n":1}`, `{"n":1}`}, bodies)
+	bodies = nil
+	mu.Unlock()
+
+	// client errors are not retried
+	mu.Lock()
+	statuses = []int{http.StatusBadRequest}
+	mu.Unlock()
+	q.enqueue(delivery{id: "2", event: EventChat, payload: []byte(`{"n":2}`), convID: chat1.ConversationID{0xab},
+		msgID: 5})
+	wait(1)
+	dl := <-deadLetters
+	require.Equal(t, "2", dl.DeliveryID)
+	require.Equal(t, 1, dl.Attempts)
+	require.Equal(t, "ab", dl.ConvID)
+	require.Equal(t, chat1.MessageID(5), dl.MsgID)
+
+	// and the retries run out
+	mu.Lock()
+	statuses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+	mu.Unlock()
+	q.enqueue(delivery{id: "3", event: EventChat, payload: []byte(`{"n":3}`)})
+	wait(3)
+	dl = <-deadLetters
+	require.Equal(t, "3", dl.DeliveryID)
+	require.Equal(t, 3, dl.Attempts)
+	select {
+	case dl := <-deadLetters:
+		require.Fail(t, "unexpected dead letter", "%+v", dl)
+	default:
+	}
+}
+
+func TestCheckHookURL(t *testing.T) {
+	for _, s := range []string{
+		"https://example.com/hook",
+		"http://localhost:8080/keybase",
+		"http://127.0.0.1/hook",
+		"http://[::1]:8080/hook",
+	} {
+		require.NoError(t, checkHookURL(s), s)
+	}
+	for _, s := range []string{
+		"http://example.com",
+		"http://localhost.example.com/hook",
+		"ftp://localhost/hook",
+		"https:///hook",
+	} {
+		require.Error(t, checkHookURL(s), s)
+	}
+}
+
+func TestMatchConv(t *testing.T) {
+	convID := chat1.ConversationID([]byte{1})
+	require.True(t, matchConv(chat1.ChatWebhook{}, convID))
+	require.True(t, matchConv(chat1.ChatWebhook{
+		FilterConvIDs: []chat1.ConversationID{[]byte{2}, convID},
+	}, convID))
+	require.False(t, matchConv(chat1.ChatWebhook{
+		FilterConvIDs: []chat1.ConversationID{[]byte{2}},
+	}, convID))
+}
@@ -0,0 +1,396 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/clockwork"
+)
+
+// Event types of webhook payloads, the same as the "type" field of the
+// notifications printed by api-listen.
+const (
+	EventChat     = "chat"
+	EventChatConv = "chat_conv"
+	EventWallet   = "wallet"
+)
+
+// maxDeadLetterLogSize is the size at which the dead-letter log is rotated.
+const maxDeadLetterLogSize = 1024 * 1024
+
+type walletPayload struct {
+	Type         string `json:"type"`
+	Source       string `json:"source"`
+	Notification any    `json:"notification"`
+}
+
+type walletPaymentNotification struct {
+	AccountID stellar1.AccountID `json:"account_id"`
+	PaymentID stellar1.PaymentID `json:"payment_id"`
+}
+
+type walletRequestNotification struct {
+	RequestID stellar1.KeybaseRequestID `json:"request_id"`
+}
+
+// Dispatcher forwards the notifications api-listen gets to the webhooks of the
+// logged in user. It listens on the notification router of the service, so
+// it runs whether or not any client is connected.
+type Dispatcher struct {
+	globals.Contextified
+	utils.DebugLabeler
+	libkb.NoopNotifyListener
+	sync.Mutex
+
+	storage      *hookStorage
+	clock        clockwork.Clock
+	uid          gregor1.UID
+	started      bool
+	listenerID   libkb.NotifyListenerID
+	queues       map[string]*hookQueue
+	deadLetterMu sync.Mutex
+}
+
+var _ types.WebhookDispatcher = (*Dispatcher)(nil)
+
+func NewDispatcher(g *globals.Context) *Dispatcher {
+	return &Dispatcher{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "webhooks.Dispatcher", false),
+		storage:      newHookStorage(g),
+		clock:        clockwork.NewRealClock(),
+		queues:       make(map[string]*hookQueue),
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context, uid gregor1.UID) {
+	defer d.Trace(ctx, nil, "Start")()
+	d.Lock()
+	defer d.Unlock()
+	if d.started {
+		return
+	}
+	d.uid = uid
+	hooks, err := d.storage.Get(ctx, uid)
+	if err != nil {
+		d.Debug(ctx, "Start: failed to load hooks: %s", err)
+	}
+	for _, hook := range hooks {
+		d.startQueueLocked(hook)
+	}
+	d.listenerID = d.G().NotifyRouter.AddListener(d)
+	d.started = true
+}
+
+func (d *Dispatcher) Stop(ctx context.Context) chan struct{} {
+	defer d.Trace(ctx, nil, "Stop")()
+	d.Lock()
+	defer d.Unlock()
+	ch := make(chan struct{})
+	if !d.started {
+		close(ch)
+		return ch
+	}
+	d.G().NotifyRouter.RemoveListener(d.listenerID)
+	d.started = false
+	var doneChs []chan struct{}
+	for id, q := range d.queues {
+		doneChs = append(doneChs, q.stop())
+		delete(d.queues, id)
+	}
+	go func() {
+		for _, doneCh := range doneChs {
+			<-doneCh
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func (d *Dispatcher) startQueueLocked(hook chat1.ChatWebhook) {
+	client := libkb.ProxyHTTPClient(d.G().ExternalG(), d.G().Env, "ChatWebhooks")
+	q := newHookQueue(hook, client, d.clock, d.writeDeadLetter, func(format string, args ...any) {
+		d.Debug(context.Background(), format, args...)
+	})
+	d.queues[hook.Id] = q
+	q.start()
+}
+
+func checkHookURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if len(u.Host) == 0 {
+		return fmt.Errorf("webhook URL has no host: %s", s)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		// payloads are decrypted messages, only send them in the clear to
+		// this machine
+		if isLoopbackHost(u.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("webhook URL must be https unless it is on localhost: %s", s)
+	default:
+		return fmt.Errorf("webhook URL must be http or https: %s", s)
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// AddHook stores a new webhook and starts delivering to it. The ID and
+// signing secret of the hook are generated here.
+func (d *Dispatcher) AddHook(ctx context.Context, hook chat1.ChatWebhook) (res chat1.ChatWebhook, err error) {
+	defer d.Trace(ctx, &err, "AddHook")()
+	if err := checkHookURL(hook.Url); err != nil {
+		return res, err
+	}
+	if hook.Id, err = libkb.RandHexString("", 8); err != nil {
+		return res, err
+	}
+	if hook.Secret, err = libkb.RandHexString("", 32); err != nil {
+		return res, err
+	}
+	hook.Ctime = gregor1.ToTime(d.clock.Now())
+	d.Lock()
+	defer d.Unlock()
+	if !d.started {
+		return res, errors.New("webhooks are not running")
+	}
+	hooks, err := d.storage.Get(ctx, d.uid)
+	if err != nil {
+		return res, err
+	}
+	if err := d.storage.Put(ctx, d.uid, append(hooks, hook)); err != nil {
+		return res, err
+	}
+	d.startQueueLocked(hook)
+	return hook, nil
+}
+
+func (d *Dispatcher) Hooks(ctx context.Context) (res []chat1.ChatWebhook, err error) {
+	defer d.Trace(ctx, &err, "Hooks")()
+	d.Lock()
+	defer d.Unlock()
+	if !d.started {
+		return nil, errors.New("webhooks are not running")
+	}
+	return d.storage.Get(ctx, d.uid)
+}
+
+func (d *Dispatcher) RemoveHook(ctx context.Context, id string) (err error) {
+	defer d.Trace(ctx, &err, "RemoveHook: %s", id)()
+	d.Lock()
+	defer d.Unlock()
+	if !d.started {
+		return errors.New("webhooks are not running")
+	}
+	hooks, err := d.storage.Get(ctx, d.uid)
+	if err != nil {
+		return err
+	}
+	var keep []chat1.ChatWebhook
+	for _, hook := range hooks {
+		if hook.Id != id {
+			keep = append(keep, hook)
+		}
+	}
+	if len(keep) == len(hooks) {
+		return fmt.Errorf("no webhook with id: %s", id)
+	}
+	if err := d.storage.Put(ctx, d.uid, keep); err != nil {
+		return err
+	}
+	if q, ok := d.queues[id]; ok {
+		// don't wait on a delivery in flight
+		q.stop()
+		delete(d.queues, id)
+	}
+	return nil
+}
+
+func (d *Dispatcher) writeDeadLetter(dl deadLetter) {
+	ctx := context.Background()
+	d.Debug(ctx, "writeDeadLetter: hook: %s delivery: %s attempts: %d error: %s", dl.HookID, dl.DeliveryID,
+		dl.Attempts, dl.Error)
+	dat, err := json.Marshal(dl)
+	if err != nil {
+		d.Debug(ctx, "writeDeadLetter: failed to encode: %s", err)
+		return
+	}
+	d.deadLetterMu.Lock()
+	defer d.deadLetterMu.Unlock()
+	path := filepath.Join(d.G().Env.GetLogDir(), libkb.WebhookDeadLetterFileName)
+	// keep the log bounded, the previous one is kept next to it
+	if fi, err := os.Stat(path); err == nil && fi.Size() >= maxDeadLetterLogSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			d.Debug(ctx, "writeDeadLetter: failed to rotate log: %s", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		d.Debug(ctx, "writeDeadLetter: failed to open log: %s", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(dat, '\n')); err != nil {
+		d.Debug(ctx, "writeDeadLetter: failed to write: %s", err)
+	}
+}
+
+// dispatch queues the payload on every hook that match accepts. convID and
+// msgID are what the payload is about, if anything.
+func (d *Dispatcher) dispatch(event string, payload any, convID chat1.ConversationID, msgID chat1.MessageID,
+	match func(chat1.ChatWebhook) bool,
+) {
+	ctx := context.Background()
+	d.Lock()
+	defer d.Unlock()
+	if !d.started {
+		return
+	}
+	var dat []byte
+	for _, q := range d.queues {
+		if !match(q.hook) {
+			continue
+		}
+		if dat == nil {
+			var err error
+			if dat, err = json.Marshal(payload); err != nil {
+				d.Debug(ctx, "dispatch: failed to encode %s payload: %s", event, err)
+				return
+			}
+		}
+		id, err := libkb.RandHexString("", 16)
+		if err != nil {
+			d.Debug(ctx, "dispatch: failed to make delivery id: %s", err)
+			return
+		}
+		q.enqueue(delivery{id: id, event: event, payload: dat, convID: convID, msgID: msgID})
+	}
+}
+
+func matchConv(hook chat1.ChatWebhook, convID chat1.ConversationID) bool {
+	if len(hook.FilterConvIDs) == 0 {
+		return true
+	}
+	for _, filterConvID := range hook.FilterConvIDs {
+		if filterConvID.Eq(convID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) NewChatActivity(uid keybase1.UID, activity chat1.ChatActivity,
+	source chat1.ChatActivitySource,
+) {
+	if source == chat1.ChatActivitySource_LOCAL {
+		return
+	}
+	typ, err := activity.ActivityType()
+	if err != nil {
+		return
+	}
+	switch typ {
+	case chat1.ChatActivityType_INCOMING_MESSAGE:
+		inMsg := activity.IncomingMessage()
+		if inMsg.Conv != nil && inMsg.Conv.TopicType != chat1.TopicType_CHAT {
+			return
+		}
+		if inMsg.Message.IsValid() &&
+			inMsg.Message.Valid().MessageBody.IsType(chat1.MessageType_INTERACTIVEACTION) &&
+			!utils.IsInteractiveActionFor(inMsg.Message.Valid().MessageBody, uid.ToBytes()) {
+			// Interactive actions are only for the bot of the interactive
+			// message, like in api-listen.
+			return
+		}
+		msg := utils.ExportIncomingMessage(inMsg)
+		if msg == nil {
+			return
+		}
+		notif := chat1.MsgNotification{
+			Type:       EventChat,
+			Source:     strings.ToLower(source.String()),
+			Msg:        msg.Msg,
+			Error:      msg.Error,
+			Pagination: inMsg.Pagination,
+		}
+		d.dispatch(EventChat, notif, inMsg.ConvID, inMsg.Message.GetMessageID(), func(hook chat1.ChatWebhook) bool {
+			return matchConv(hook, inMsg.ConvID)
+		})
+	case chat1.ChatActivityType_NEW_CONVERSATION:
+		convInfo := activity.NewConversation()
+		d.dispatchConv(convInfo.ConvID, convInfo.Conv)
+	}
+}
+
+func (d *Dispatcher) ChatJoinedConversation(uid keybase1.UID, convID chat1.ConversationID,
+	conv *chat1.InboxUIItem,
+) {
+	d.dispatchConv(convID, conv)
+}
+
+func (d *Dispatcher) dispatchConv(convID chat1.ConversationID, conv *chat1.InboxUIItem) {
+	if conv != nil && conv.TopicType != chat1.TopicType_CHAT {
+		return
+	}
+	notif := chat1.ConvNotification{Type: EventChatConv}
+	if conv == nil {
+		err := fmt.Sprintf("No conversation info found: %v", convID.String())
+		notif.Error = &err
+	} else {
+		summary := utils.ExportToSummary(*conv)
+		notif.Conv = &summary
+	}
+	d.dispatch(EventChatConv, notif, convID, 0, func(hook chat1.ChatWebhook) bool {
+		return hook.Convs
+	})
+}
+
+func (d *Dispatcher) dispatchWallet(source string, notification any) {
+	d.dispatch(EventWallet, walletPayload{
+		Type:         EventWallet,
+		Source:       source,
+		Notification: notification,
+	}, nil, 0, func(hook chat1.ChatWebhook) bool {
+		return hook.Wallet
+	})
+}
+
+func (d *Dispatcher) WalletPaymentNotification(accountID stellar1.AccountID, paymentID stellar1.PaymentID) {
+	d.dispatchWallet("payment", walletPaymentNotification{AccountID: accountID, PaymentID: paymentID})
+}
+
+func (d *Dispatcher) WalletPaymentStatusNotification(accountID stellar1.AccountID, paymentID stellar1.PaymentID) {
+	d.dispatchWallet("payment_status", walletPaymentNotification{AccountID: accountID, PaymentID: paymentID})
+}
+
+func (d *Dispatcher) WalletRequestStatusNotification(reqID stellar1.KeybaseRequestID) {
+	d.dispatchWallet("request", walletRequestNotification{RequestID: reqID})
+}
@@ -0,0 +1,263 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/keybase/client/go/chat/msgchecker"
+)
+
+const (
+	// HeaderToken carries the secret of an incoming webhook, as an
+	// alternative to a bearer token or a signature.
+	HeaderToken = "X-Keybase-Webhook-Token"
+
+	maxIncomingBodySize   = 1024 * 1024
+	maxIncomingUploadSize = 32 * 1024 * 1024
+	maxSignatureSkew      = 5 * time.Minute
+)
+
+var errMessageTooLong = fmt.Errorf("message is longer than %d bytes", msgchecker.TextMessageMaxLength)
+
+// requestError is a failure of a webhook request that is the fault of the
+// caller, along with the status to answer it with.
+type requestError struct {
+	status int
+	msg    string
+}
+
+func (e requestError) Error() string {
+	return e.msg
+}
+
+func newRequestError(status int, format string, args ...any) requestError {
+	return requestError{status: status, msg: fmt.Sprintf(format, args...)}
+}
+
+// checkIncomingHeaders authenticates a request from its headers, before its
+// body is read. A request carrying the secret of the hook is done here. A
+// request signed with it the way outgoing webhooks sign their deliveries only
+// has its timestamp checked, and signed is set so that the signature is
+// checked with checkIncomingSignature once the body is read.
+func checkIncomingHeaders(r *http.Request, secret string, now time.Time) (signed bool, err error) {
+	if len(r.Header.Get(HeaderSignature)) > 0 {
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil {
+			return true, fmt.Errorf("invalid %s header", HeaderTimestamp)
+		}
+		if math.Abs(float64(now.Unix()-timestamp)) > maxSignatureSkew.Seconds() {
+			return true, fmt.Errorf("%s is too far from the current time", HeaderTimestamp)
+		}
+		return true, nil
+	}
+	token := r.Header.Get(HeaderToken)
+	if len(token) == 0 {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if len(token) == 0 {
+		return false, errors.New("no webhook secret given")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return false, errors.New("invalid webhook secret")
+	}
+	return false, nil
+}
+
+// checkIncomingSignature checks the signature of a request which passed
+// checkIncomingHeaders.
+func checkIncomingSignature(r *http.Request, body []byte, secret string) error {
+	timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", HeaderTimestamp)
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(r.Header.Get(HeaderSignature))) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		dat, err := json.Marshal(v)
+		return string(dat), err
+	},
+	"default": func(def, v any) any {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	if len(text) == 0 {
+		return nil, nil
+	}
+	return template.New("webhook").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// limitedBuffer stops templates that render more than a message can hold.
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > msgchecker.TextMessageMaxLength {
+		return 0, errMessageTooLong
+	}
+	return b.Buffer.Write(p)
+}
+
+type incomingMessage struct {
+	text       string
+	filename   string
+	attachment []byte
+}
+
+// parseIncoming makes the message to post out of the body of a request. JSON
+// bodies are given to the template of the hook, or their "text" field is
+// posted. Form posts work the same with their fields, or with the JSON of
+// their "payload" field, and multipart forms can carry an "attachment" file.
+func parseIncoming(r *http.Request, body []byte, tmpl *template.Template) (res incomingMessage, err error) {
+	contentType := r.Header.Get("Content-Type")
+	if len(contentType) == 0 {
+		contentType = "application/json"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return res, newRequestError(http.StatusUnsupportedMediaType, "invalid Content-Type: %s", err)
+	}
+	var data any
+	switch mediaType {
+	case "application/json":
+		if data, err = decodeJSON(body); err != nil {
+			return res, err
+		}
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return res, newRequestError(http.StatusBadRequest, "invalid form: %s", err)
+		}
+		if data, err = formData(values); err != nil {
+			return res, err
+		}
+	case "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(maxIncomingUploadSize)
+		if err != nil {
+			return res, newRequestError(http.StatusBadRequest, "invalid form: %s", err)
+		}
+		defer func() { _ = form.RemoveAll() }()
+		if data, err = formData(form.Value); err != nil {
+			return res, err
+		}
+		if files := form.File["attachment"]; len(files) > 0 {
+			if res.attachment, err = readFormFile(files[0]); err != nil {
+				return res, err
+			}
+			res.filename = filepath.Base(files[0].Filename)
+			if res.filename == "." || res.filename == string(filepath.Separator) {
+				res.filename = "attachment"
+			}
+		}
+	default:
+		return res, newRequestError(http.StatusUnsupportedMediaType, "unsupported Content-Type: %s", mediaType)
+	}
+
+	if tmpl != nil {
+		var buf limitedBuffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			if errors.Is(err, errMessageTooLong) {
+				return res, newRequestError(http.StatusBadRequest, "%s", errMessageTooLong)
+			}
+			return res, newRequestError(http.StatusBadRequest, "failed to render template: %s", err)
+		}
+		res.text = buf.String()
+	} else if fields, ok := data.(map[string]any); ok {
+		res.text, _ = fields["text"].(string)
+	}
+	res.text = strings.TrimSpace(res.text)
+	if len(res.text) > msgchecker.TextMessageMaxLength {
+		return res, newRequestError(http.StatusBadRequest, "%s", errMessageTooLong)
+	}
+	if len(res.text) == 0 && res.attachment == nil {
+		return res, newRequestError(http.StatusBadRequest, "nothing to post: no text and no attachment")
+	}
+	return res, nil
+}
+
+func decodeJSON(body []byte) (res any, err error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return nil, newRequestError(http.StatusBadRequest, "invalid JSON: %s", err)
+	}
+	return res, nil
+}
+
+func formData(values map[string][]string) (any, error) {
+	if payload, ok := values["payload"]; ok && len(payload) > 0 {
+		return decodeJSON([]byte(payload[0]))
+	}
+	res := make(map[string]any, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			res[k] = v[0]
+		} else {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func readFormFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// rateLimiter is a token bucket that refills limit tokens a minute.
+type rateLimiter struct {
+	limit  int
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit int, now time.Time) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		tokens: float64(limit),
+		last:   now,
+	}
+}
+
+// take takes a token if there is one, or else returns how long until there is.
+func (l *rateLimiter) take(now time.Time) (bool, time.Duration) {
+	perNanosecond := float64(l.limit) / float64(time.Minute)
+	if now.After(l.last) {
+		l.tokens = math.Min(float64(l.limit), l.tokens+float64(now.Sub(l.last))*perNanosecond)
+		l.last = now
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - l.tokens) / perNanosecond)
+}
@@ -0,0 +1,144 @@
+package webhooks
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/chat/msgchecker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIncomingAuth(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"text":"hi"}`)
+	check := func(target string, headers map[string]string) error {
+		r := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		signed, err := checkIncomingHeaders(r, "secret", now)
+		if err != nil || !signed {
+			return err
+		}
+		return checkIncomingSignature(r, body, "secret")
+	}
+	require.NoError(t, check("/hooks/1", map[string]string{HeaderToken: "secret"}))
+	require.NoError(t, check("/hooks/1", map[string]string{"Authorization": "Bearer secret"}))
+	require.Error(t, check("/hooks/1", nil))
+	require.Error(t, check("/hooks/1", map[string]string{HeaderToken: "wrong"}))
+	// the secret would end up in logs and shell history
+	require.Error(t, check("/hooks/1?token=secret", nil))
+
+	signed := func(ts time.Time, secret string) map[string]string {
+		return map[string]string{
+			HeaderTimestamp: strconv.FormatInt(ts.Unix(), 10),
+			HeaderSignature: Sign(secret, ts.Unix(), body),
+		}
+	}
+	require.NoError(t, check("/hooks/1", signed(now, "secret")))
+	require.Error(t, check("/hooks/1", signed(now, "wrong")))
+	require.Error(t, check("/hooks/1", signed(now.Add(-time.Hour), "secret")))
+	// a bad signature is not rescued by a token
+	headers := signed(now, "wrong")
+	headers[HeaderToken] = "secret"
+	require.Error(t, check("/hooks/1", headers))
+}
+
+func TestParseIncoming(t *testing.T) {
+	parse := func(contentType, body, tmplText string) (incomingMessage, error) {
+		r := httptest.NewRequest(http.MethodPost, "/hooks/1", strings.NewReader(body))
+		if len(contentType) > 0 {
+			r.Header.Set("Content-Type", contentType)
+		}
+		tmpl, err := parseTemplate(tmplText)
+		require.NoError(t, err)
+		return parseIncoming(r, []byte(body), tmpl)
+	}
+
+	msg, err := parse("application/json", `{"text":" deployed "}`, "")
+	require.NoError(t, err)
+	require.Equal(t, "deployed", msg.text)
+	require.Nil(t, msg.attachment)
+
+	msg, err = parse("", `{"text":"no content type"}`, "")
+	require.NoError(t, err)
+	require.Equal(t, "no content type", msg.text)
+
+	msg, err = parse("application/json",
+		`{"build":{"status":"failed","number":12},"repo":"client"}`,
+		`{{.repo}} #{{.build.number}}: {{.build.status}}{{if .missing}}!{{end}} {{default "main" .branch}}`)
+	require.NoError(t, err)
+	require.Equal(t, "client #12: failed main", msg.text)
+
+	msg, err = parse("application/x-www-form-urlencoded", "text=hello+there", "")
+	require.NoError(t, err)
+	require.Equal(t, "hello there", msg.text)
+
+	msg, err = parse("application/x-www-form-urlencoded", `payload={"status":"ok"}`, "status {{.status}}")
+	require.NoError(t, err)
+	require.Equal(t, "status ok", msg.text)
+
+	_, err = parse("application/json", `{"message":"no text field"}`, "")
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(requestError).status)
+	_, err = parse("application/json", `{"text":`, "")
+	require.Error(t, err)
+	_, err = parse("text/plain", "hi", "")
+	require.Error(t, err)
+	require.Equal(t, http.StatusUnsupportedMediaType, err.(requestError).status)
+	long := strings.Repeat("x", msgchecker.TextMessageMaxLength/2+1)
+	_, err = parse("application/json", `{"s":"`+long+`"}`, "{{.s}}{{.s}}")
+	require.Error(t, err)
+	_, err = parse("application/json", `{"text":"`+long+long+`"}`, "")
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	require.NoError(t, mw.WriteField("text", "report"))
+	fw, err := mw.CreateFormFile("attachment", "../report.txt")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("all passed"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	msg, err = parse(mw.FormDataContentType(), buf.String(), "")
+	require.NoError(t, err)
+	require.Equal(t, "report", msg.text)
+	require.Equal(t, "report.txt", msg.filename)
+	require.Equal(t, "all passed", string(msg.attachment))
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(2, now)
+	ok, _ := l.take(now)
+	require.True(t, ok)
+	ok, _ = l.take(now)
+	require.True(t, ok)
+	ok, wait := l.take(now)
+	require.False(t, ok)
+	require.Equal(t, 30*time.Second, wait)
+
+	now = now.Add(15 * time.Second)
+	ok, wait = l.take(now)
+	require.False(t, ok)
+	require.Equal(t, 15*time.Second, wait)
+
+	now = now.Add(15 * time.Second)
+	ok, _ = l.take(now)
+	require.True(t, ok)
+
+	// the bucket never holds more than the limit
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		ok, _ = l.take(now)
+		require.True(t, ok)
+	}
+	ok, _ = l.take(now)
+	require.False(t, ok)
+}
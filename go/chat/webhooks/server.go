@@ -0,0 +1,450 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/keybase/client/go/chat/attachments"
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/types"
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/kbhttp"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/clockwork"
+)
+
+const (
+	// IncomingPathPrefix is where the webhook server takes the requests of
+	// a hook, followed by the id of the hook.
+	IncomingPathPrefix = "/hooks/"
+
+	// DefaultIncomingRateLimit is the rate limit of hooks that don't set one,
+	// in messages per minute.
+	DefaultIncomingRateLimit = 30
+)
+
+// Server runs the HTTP server that incoming webhooks post to, and posts what
+// they get into their conversations. The server only listens on localhost.
+type Server struct {
+	globals.Contextified
+	utils.DebugLabeler
+	sync.Mutex
+
+	sender   types.Sender
+	storage  *serverStorage
+	clock    clockwork.Clock
+	uid      gregor1.UID
+	started  bool
+	srv      *kbhttp.Srv
+	hooks    map[string]chat1.ChatIncomingWebhook
+	limiters map[string]*rateLimiter
+}
+
+var _ types.WebhookServer = (*Server)(nil)
+
+func NewServer(g *globals.Context, sender types.Sender) *Server {
+	return &Server{
+		Contextified: globals.NewContextified(g),
+		DebugLabeler: utils.NewDebugLabeler(g.ExternalG(), "webhooks.Server", false),
+		sender:       sender,
+		storage:      newServerStorage(g),
+		clock:        clockwork.NewRealClock(),
+		hooks:        make(map[string]chat1.ChatIncomingWebhook),
+		limiters:     make(map[string]*rateLimiter),
+	}
+}
+
+func (s *Server) Start(ctx context.Context, uid gregor1.UID) {
+	defer s.Trace(ctx, nil, "Start")()
+	s.Lock()
+	defer s.Unlock()
+	if s.started {
+		return
+	}
+	s.uid = uid
+	s.started = true
+	dat, err := s.storage.Get(ctx, uid)
+	if err != nil {
+		s.Debug(ctx, "Start: failed to load hooks: %s", err)
+		return
+	}
+	for _, hook := range dat.Hooks {
+		s.hooks[hook.Id] = hook
+	}
+	if dat.Serving {
+		if _, err := s.startServerLocked(ctx, dat.Port); err != nil {
+			s.Debug(ctx, "Start: failed to start server on port %d: %s", dat.Port, err)
+		}
+	}
+}
+
+func (s *Server) Stop(ctx context.Context) chan struct{} {
+	defer s.Trace(ctx, nil, "Stop")()
+	s.Lock()
+	defer s.Unlock()
+	ch := make(chan struct{})
+	s.started = false
+	s.hooks = make(map[string]chat1.ChatIncomingWebhook)
+	s.limiters = make(map[string]*rateLimiter)
+	if s.srv == nil {
+		close(ch)
+		return ch
+	}
+	doneCh := s.srv.Stop()
+	s.srv = nil
+	go func() {
+		<-doneCh
+		close(ch)
+	}()
+	return ch
+}
+
+func (s *Server) startServerLocked(ctx context.Context, port int) (string, error) {
+	var source kbhttp.ListenerSource = kbhttp.NewFixedPortListenerSource(port)
+	if port == 0 {
+		source = kbhttp.NewAutoPortListenerSource()
+	}
+	srv := kbhttp.NewSrv(s.G().GetLog(), source)
+	if err := srv.Start(); err != nil {
+		return "", err
+	}
+	srv.HandleFunc(IncomingPathPrefix, s.serveHTTP)
+	s.srv = srv
+	return srv.Addr()
+}
+
+// Serve starts the server, or moves it to another port, and keeps it running
+// across restarts of the service. Port 0 keeps the current port, or picks a
+// free one.
+func (s *Server) Serve(ctx context.Context, port int) (res string, err error) {
+	defer s.Trace(ctx, &err, "Serve: %d", port)()
+	s.Lock()
+	defer s.Unlock()
+	if !s.started {
+		return res, errors.New("webhooks are not running")
+	}
+	if s.srv != nil {
+		if res, err = s.srv.Addr(); err != nil {
+			return res, err
+		}
+		if port == 0 || strings.HasSuffix(res, fmt.Sprintf(":%d", port)) {
+			return res, nil
+		}
+		<-s.srv.Stop()
+		s.srv = nil
+	}
+	if res, err = s.startServerLocked(ctx, port); err != nil {
+		return res, err
+	}
+	// remember the port picked for us, so the URLs of the hooks stay the same
+	if _, port, err = splitPort(res); err != nil {
+		return res, err
+	}
+	dat, err := s.storage.Get(ctx, s.uid)
+	if err != nil {
+		return res, err
+	}
+	dat.Serving = true
+	dat.Port = port
+	return res, s.storage.Put(ctx, s.uid, dat)
+}
+
+func splitPort(addr string) (string, int, error) {
+	i := strings.LastIndex(addr, ":")
+	if i < 0 {
+		return "", 0, fmt.Errorf("no port in address: %s", addr)
+	}
+	port, err := strconv.Atoi(addr[i+1:])
+	return addr[:i], port, err
+}
+
+func (s *Server) StopServing(ctx context.Context) (err error) {
+	defer s.Trace(ctx, &err, "StopServing")()
+	s.Lock()
+	defer s.Unlock()
+	if !s.started {
+		return errors.New("webhooks are not running")
+	}
+	if s.srv != nil {
+		<-s.srv.Stop()
+		s.srv = nil
+	}
+	dat, err := s.storage.Get(ctx, s.uid)
+	if err != nil {
+		return err
+	}
+	dat.Serving = false
+	return s.storage.Put(ctx, s.uid, dat)
+}
+
+// AddHook stores a new incoming webhook for a conversation. The ID and secret
+// of the hook are generated here.
+func (s *Server) AddHook(ctx context.Context, hook chat1.ChatIncomingWebhook) (res chat1.ChatIncomingWebhook, err error) {
+	defer s.Trace(ctx, &err, "AddHook")()
+	if _, err := parseTemplate(hook.Template); err != nil {
+		return res, err
+	}
+	if hook.RateLimit <= 0 {
+		hook.RateLimit = DefaultIncomingRateLimit
+	}
+	uid, err := utils.AssertLoggedInUID(ctx, s.G())
+	if err != nil {
+		return res, err
+	}
+	conv, err := utils.GetVerifiedConv(ctx, s.G(), uid, hook.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	if conv.GetTopicType() != chat1.TopicType_CHAT {
+		return res, errors.New("webhooks can only post into chat conversations")
+	}
+	hook.ConvName = utils.FormatConversationName(conv.Info, s.G().Env.GetUsername().String())
+	s.Lock()
+	defer s.Unlock()
+	if !s.started {
+		return res, errors.New("webhooks are not running")
+	}
+	if hook.Id, err = libkb.RandHexString("", 8); err != nil {
+		return res, err
+	}
+	if hook.Secret, err = libkb.RandHexString("", 32); err != nil {
+		return res, err
+	}
+	hook.Ctime = gregor1.ToTime(s.clock.Now())
+	dat, err := s.storage.Get(ctx, s.uid)
+	if err != nil {
+		return res, err
+	}
+	dat.Hooks = append(dat.Hooks, hook)
+	if err := s.storage.Put(ctx, s.uid, dat); err != nil {
+		return res, err
+	}
+	s.hooks[hook.Id] = hook
+	return hook, nil
+}
+
+func (s *Server) Hooks(ctx context.Context) (res []chat1.ChatIncomingWebhook, err error) {
+	defer s.Trace(ctx, &err, "Hooks")()
+	s.Lock()
+	defer s.Unlock()
+	if !s.started {
+		return nil, errors.New("webhooks are not running")
+	}
+	dat, err := s.storage.Get(ctx, s.uid)
+	if err != nil {
+		return nil, err
+	}
+	return dat.Hooks, nil
+}
+
+func (s *Server) RemoveHook(ctx context.Context, id string) (err error) {
+	defer s.Trace(ctx, &err, "RemoveHook: %s", id)()
+	s.Lock()
+	defer s.Unlock()
+	if !s.started {
+		return errors.New("webhooks are not running")
+	}
+	dat, err := s.storage.Get(ctx, s.uid)
+	if err != nil {
+		return err
+	}
+	var keep []chat1.ChatIncomingWebhook
+	for _, hook := range dat.Hooks {
+		if hook.Id != id {
+			keep = append(keep, hook)
+		}
+	}
+	if len(keep) == len(dat.Hooks) {
+		return fmt.Errorf("no incoming webhook with id: %s", id)
+	}
+	dat.Hooks = keep
+	if err := s.storage.Put(ctx, s.uid, dat); err != nil {
+		return err
+	}
+	delete(s.hooks, id)
+	delete(s.limiters, id)
+	return nil
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, msg string) {
+	writeJSONResponse(w, status, map[string]string{"error": msg})
+}
+
+// takeToken finds a hook, and checks it against its rate limit.
+func (s *Server) takeToken(id string) (hook chat1.ChatIncomingWebhook, uid gregor1.UID, ok bool, retryAfter int) {
+	s.Lock()
+	defer s.Unlock()
+	if hook, ok = s.hooks[id]; !ok {
+		return hook, nil, false, 0
+	}
+	now := s.clock.Now()
+	limiter, exists := s.limiters[id]
+	if !exists {
+		limiter = newRateLimiter(hook.RateLimit, now)
+		s.limiters[id] = limiter
+	}
+	if allowed, wait := limiter.take(now); !allowed {
+		return hook, s.uid, true, int(math.Ceil(wait.Seconds()))
+	}
+	return hook, s.uid, true, 0
+}
+
+func (s *Server) lookupHook(id string) (chat1.ChatIncomingWebhook, bool) {
+	s.Lock()
+	defer s.Unlock()
+	hook, ok := s.hooks[id]
+	return hook, ok
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := globals.ChatCtx(context.Background(), s.G(), keybase1.TLFIdentifyBehavior_CHAT_CLI, nil, nil)
+	id := strings.TrimPrefix(r.URL.Path, IncomingPathPrefix)
+	s.Debug(ctx, "serveHTTP: %s request for hook: %s", r.Method, id)
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "webhooks only take POST requests")
+		return
+	}
+	hook, ok := s.lookupHook(id)
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "no such webhook")
+		return
+	}
+	signed, err := checkIncomingHeaders(r, hook.Secret, s.clock.Now())
+	if err != nil {
+		s.Debug(ctx, "serveHTTP: unauthorized request for hook: %s: %s", id, err)
+		writeErrorResponse(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	// A signature can only be checked once the body is read, so signed
+	// requests can't upload attachments.
+	maxSize := int64(maxIncomingUploadSize)
+	if signed {
+		maxSize = maxIncomingBodySize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if signed {
+		if err := checkIncomingSignature(r, body, hook.Secret); err != nil {
+			s.Debug(ctx, "serveHTTP: unauthorized request for hook: %s: %s", id, err)
+			writeErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+	}
+	if len(body) > maxIncomingBodySize && !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	hook, uid, ok, retryAfter := s.takeToken(id)
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "no such webhook")
+		return
+	}
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeErrorResponse(w, http.StatusTooManyRequests,
+			fmt.Sprintf("rate limit of %d messages a minute exceeded", hook.RateLimit))
+		return
+	}
+	tmpl, err := parseTemplate(hook.Template)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	msg, err := parseIncoming(r, body, tmpl)
+	if err != nil {
+		status := http.StatusBadRequest
+		if rerr, ok := err.(requestError); ok {
+			status = rerr.status
+		}
+		writeErrorResponse(w, status, err.Error())
+		return
+	}
+	msgID, err := s.post(ctx, uid, hook, msg)
+	if err != nil {
+		s.Debug(ctx, "serveHTTP: failed to post for hook: %s: %s", id, err)
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]chat1.MessageID{"message_id": msgID})
+}
+
+func (s *Server) post(ctx context.Context, uid gregor1.UID, hook chat1.ChatIncomingWebhook,
+	msg incomingMessage,
+) (res chat1.MessageID, err error) {
+	defer s.Trace(ctx, &err, "post: %s", hook.Id)()
+	conv, err := utils.GetVerifiedConv(ctx, s.G(), uid, hook.ConvID, types.InboxSourceDataSourceAll)
+	if err != nil {
+		return res, err
+	}
+	if msg.attachment != nil {
+		return s.postAttachment(ctx, uid, conv, msg)
+	}
+	plaintext := chat1.MessagePlaintext{
+		ClientHeader: chat1.MessageClientHeader{
+			Conv:        conv.Info.Triple,
+			TlfName:     conv.Info.TlfName,
+			TlfPublic:   conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+			MessageType: chat1.MessageType_TEXT,
+		},
+		MessageBody: chat1.NewMessageBodyWithText(chat1.MessageText{
+			Body: msg.text,
+		}),
+	}
+	_, boxed, err := s.sender.Send(ctx, hook.ConvID, plaintext, 0, nil, nil, nil)
+	if err != nil {
+		return res, err
+	}
+	if boxed == nil {
+		return res, errors.New("no message returned from send")
+	}
+	return boxed.GetMessageID(), nil
+}
+
+// postAttachment uploads the attachment of a request, with its text as the
+// title. The uploader wants a file, so the attachment is written to the cache
+// dir until it is sent.
+func (s *Server) postAttachment(ctx context.Context, uid gregor1.UID, conv chat1.ConversationLocal,
+	msg incomingMessage,
+) (res chat1.MessageID, err error) {
+	dir, err := os.MkdirTemp(s.G().GetCacheDir(), "webhook")
+	if err != nil {
+		return res, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	filename := filepath.Join(dir, msg.filename)
+	if err := os.WriteFile(filename, msg.attachment, 0600); err != nil {
+		return res, err
+	}
+	_, msgID, err := attachments.NewSender(s.G()).PostFileAttachment(ctx, s.sender, uid, conv.GetConvID(),
+		conv.Info.TlfName, conv.Info.Visibility, nil, filename, msg.text, nil, 0, nil, nil, false)
+	if err != nil {
+		return res, err
+	}
+	if msgID == nil {
+		return res, errors.New("no message ID returned from post")
+	}
+	return *msgID, nil
+}
@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/chat/globals"
+	"github.com/keybase/client/go/chat/storage"
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+)
+
+const diskHookStorageVersion = 1
+
+type diskHookStorage struct {
+	Version int                 `codec:"V"`
+	Hooks   []chat1.ChatWebhook `codec:"H"`
+}
+
+// hookStorage keeps the webhooks of a user in the local chat db. The hooks
+// hold their signing secrets, so they are stored encrypted.
+type hookStorage struct {
+	globals.Contextified
+	encryptedDB *encrypteddb.EncryptedDB
+}
+
+func newEncryptedDB(g *globals.Context) *encrypteddb.EncryptedDB {
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return storage.GetSecretBoxKey(ctx, g.ExternalG())
+	}
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalChatDb
+	}
+	return encrypteddb.New(g.ExternalG(), dbFn, keyFn)
+}
+
+func newHookStorage(g *globals.Context) *hookStorage {
+	return &hookStorage{
+		Contextified: globals.NewContextified(g),
+		encryptedDB:  newEncryptedDB(g),
+	}
+}
+
+func (s *hookStorage) dbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatWebhooks,
+		Key: uid.String(),
+	}
+}
+
+func (s *hookStorage) Get(ctx context.Context, uid gregor1.UID) ([]chat1.ChatWebhook, error) {
+	var dat diskHookStorage
+	found, err := s.encryptedDB.Get(ctx, s.dbKey(uid), &dat)
+	if err != nil {
+		return nil, err
+	}
+	if !found || dat.Version != diskHookStorageVersion {
+		return nil, nil
+	}
+	return dat.Hooks, nil
+}
+
+func (s *hookStorage) Put(ctx context.Context, uid gregor1.UID, hooks []chat1.ChatWebhook) error {
+	return s.encryptedDB.Put(ctx, s.dbKey(uid), diskHookStorage{
+		Version: diskHookStorageVersion,
+		Hooks:   hooks,
+	})
+}
+
+const diskServerStorageVersion = 1
+
+type diskServerStorage struct {
+	Version int                         `codec:"V"`
+	Serving bool                        `codec:"S"`
+	Port    int                         `codec:"P"`
+	Hooks   []chat1.ChatIncomingWebhook `codec:"H"`
+}
+
+// serverStorage keeps the incoming webhooks of a user, and whether the webhook
+// server should be running.
+type serverStorage struct {
+	globals.Contextified
+	encryptedDB *encrypteddb.EncryptedDB
+}
+
+func newServerStorage(g *globals.Context) *serverStorage {
+	return &serverStorage{
+		Contextified: globals.NewContextified(g),
+		encryptedDB:  newEncryptedDB(g),
+	}
+}
+
+func (s *serverStorage) dbKey(uid gregor1.UID) libkb.DbKey {
+	return libkb.DbKey{
+		Typ: libkb.DBChatIncomingWebhooks,
+		Key: uid.String(),
+	}
+}
+
+func (s *serverStorage) Get(ctx context.Context, uid gregor1.UID) (res diskServerStorage, err error) {
+	found, err := s.encryptedDB.Get(ctx, s.dbKey(uid), &res)
+	if err != nil {
+		return res, err
+	}
+	if !found || res.Version != diskServerStorageVersion {
+		return diskServerStorage{Version: diskServerStorageVersion}, nil
+	}
+	return res, nil
+}
+
+func (s *serverStorage) Put(ctx context.Context, uid gregor1.UID, dat diskServerStorage) error {
+	dat.Version = diskServerStorageVersion
+	return s.encryptedDB.Put(ctx, s.dbKey(uid), dat)
+}
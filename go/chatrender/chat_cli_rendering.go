@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/flexibletable"
@@ -337,8 +338,11 @@ func (v ConversationListView) RenderToWriter(g *libkb.GlobalContext, writer io.W
 }
 
 type RenderOptions struct {
-	UseDateTime     bool
-	SkipHeadline    bool
+	UseDateTime  bool
+	SkipHeadline bool
+	// Markdown renders the markdown of text messages with terminal styles
+	// instead of showing it as it was typed.
+	Markdown        bool
 	GetWalletClient func(g *libkb.GlobalContext) (cli stellar1.LocalClient, err error)
 }
 
@@ -453,6 +457,75 @@ func (v ConversationView) RenderToWriter(g *libkb.GlobalContext, writer io.Write
 	return nil
 }
 
+// RenderLines renders the conversation oldest message first into lines of at
+// most width runes, for callers that lay out the terminal themselves. Each
+// message starts with its ID, author and time, and wraps under an indent.
+func (v ConversationView) RenderLines(g *libkb.GlobalContext, width int, showDeviceName bool) (lines []string) {
+	for i := len(v.Messages) - 1; i >= 0; i-- {
+		m := v.Messages[i]
+		mv, err := newMessageView(g, v.Opts, v.Conversation.Info.Id, m)
+		if err != nil {
+			g.Log.Error("Message render error: %s", err)
+		}
+		if !mv.Renderable {
+			continue
+		}
+		authorAndTime := mv.AuthorAndTime
+		if showDeviceName {
+			authorAndTime = mv.AuthorAndTimeWithDeviceName
+		}
+		header := fmt.Sprintf("[%d] %s:", mv.MessageID, authorAndTime)
+		var extras []string
+		for _, extra := range []string{mv.RestrictedBotInfo, mv.EphemeralInfo, strings.TrimSpace(mv.ReactionInfo)} {
+			if len(extra) > 0 {
+				extras = append(extras, extra)
+			}
+		}
+		lines = append(lines, WrapText(header+" "+mv.Body, width, "    ")...)
+		if len(extras) > 0 {
+			lines = append(lines, WrapText("    "+strings.Join(extras, " "), width, "    ")...)
+		}
+	}
+	return lines
+}
+
+// WrapText breaks text into lines of at most width runes at spaces, breaking
+// words longer than a line. Lines after the first one of every paragraph start
+// with indent.
+func WrapText(text string, width int, indent string) (lines []string) {
+	if width <= 0 {
+		return strings.Split(text, "\n")
+	}
+	if utf8.RuneCountInString(indent) >= width {
+		indent = ""
+	}
+	for _, paragraph := range strings.Split(text, "\n") {
+		paragraphStart := len(lines)
+		var line []rune
+		empty := true
+		for _, word := range strings.Fields(paragraph) {
+			w := []rune(word)
+			if !empty && len(line)+1+len(w) > width {
+				lines = append(lines, string(line))
+				line, empty = []rune(indent), true
+			}
+			if !empty {
+				line = append(line, ' ')
+			}
+			for len(line)+len(w) > width {
+				n := width - len(line)
+				lines = append(lines, string(append(line, w[:n]...)))
+				line, w = []rune(indent), w[n:]
+			}
+			line, empty = append(line, w...), false
+		}
+		if !empty || len(lines) == paragraphStart {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines
+}
+
 // Everything you need to show a message.
 // Takes into account superseding edits and deletions.
 type messageView struct {
@@ -559,6 +632,22 @@ func formatRequestPaymentMessage(g *libkb.GlobalContext, opts RenderOptions, bod
 		view = fmt.Sprintf("requested %s", details.AmountDescription)
 	}
 
+	if invoice := details.Invoice; invoice != nil {
+		for _, item := range invoice.LineItems {
+			view += "\n  " + item.String()
+		}
+		if invoice.Reference != "" {
+			view += "\n  Reference: " + invoice.Reference
+		}
+		if invoice.DueDate != 0 {
+			view += "\n  Due: " + invoice.DueDate.Time().Format("2006-01-02")
+		}
+	}
+
+	if details.Status == stellar1.RequestStatus_OK && details.AmountPaid != "0" {
+		view += fmt.Sprintf("\n  Paid so far: %s", details.AmountPaidDescription)
+	}
+
 	if len(body.Note) > 0 {
 		view += "\n> " + body.Note
 	}
@@ -574,6 +663,50 @@ func formatRequestPaymentMessage(g *libkb.GlobalContext, opts RenderOptions, bod
 	return view
 }
 
+func formatPollMessage(poll chat1.MsgPollContent, msgID chat1.MessageID) (view string) {
+	var kind []string
+	if poll.MultiChoice {
+		kind = append(kind, "multiple choice")
+	}
+	if poll.Anonymous {
+		kind = append(kind, "anonymous")
+	}
+	view = "[poll] " + poll.Question
+	if len(kind) > 0 {
+		view += fmt.Sprintf(" (%s)", strings.Join(kind, ", "))
+	}
+	for i, option := range poll.Options {
+		view += fmt.Sprintf("\n  %d. %s: %d", i+1, option.Option, option.Votes)
+		if len(option.Voters) > 0 {
+			view += fmt.Sprintf(" (%s)", strings.Join(option.Voters, ", "))
+		}
+	}
+	switch {
+	case poll.Closed:
+		view += fmt.Sprintf("\n[closed, %d voted]", poll.TotalVoters)
+	case poll.CloseTime != nil:
+		view += fmt.Sprintf("\n[%d voted, closes %s, poll ID: %d]", poll.TotalVoters,
+			time.Unix(*poll.CloseTime, 0).Format("2006-01-02 15:04"), msgID)
+	default:
+		view += fmt.Sprintf("\n[%d voted, poll ID: %d]", poll.TotalVoters, msgID)
+	}
+	return view
+}
+
+func formatInteractiveMessage(msg chat1.MessageInteractive, msgID chat1.MessageID) (view string) {
+	view = msg.Body
+	var prevElementID string
+	for i, choice := range msg.Choices() {
+		if choice.Typ == chat1.InteractiveElementType_SELECT && choice.ElementID != prevElementID {
+			view += fmt.Sprintf("\n  %s:", choice.ElementLabel)
+		}
+		prevElementID = choice.ElementID
+		view += fmt.Sprintf("\n  %d. %s", i+1, choice.Label)
+	}
+	view += fmt.Sprintf("\n[interactive ID: %d]", msgID)
+	return strings.TrimPrefix(view, "\n")
+}
+
 func newMessageViewValid(g *libkb.GlobalContext, opts RenderOptions, conversationID chat1.ConversationID, m chat1.MessageUnboxedValid) (mv messageView, err error) {
 	mv.MessageID = m.ServerHeader.MessageID
 	mv.FromRevokedDevice = m.SenderDeviceRevokedAt != nil
@@ -592,6 +725,9 @@ func newMessageViewValid(g *libkb.GlobalContext, opts RenderOptions, conversatio
 	case chat1.MessageType_TEXT:
 		mv.Renderable = true
 		mv.Body = body.Text().Body
+		if opts.Markdown {
+			mv.Body = RenderMarkdown(mv.Body)
+		}
 		if m.ServerHeader.SupersededBy > 0 {
 			mv.Body += " (edited)"
 		}
@@ -651,6 +787,16 @@ func newMessageViewValid(g *libkb.GlobalContext, opts RenderOptions, conversatio
 		mv.Body = m.MessageBody.Flip().Text
 	case chat1.MessageType_PIN:
 		mv.Renderable = false
+	case chat1.MessageType_POLL:
+		mv.Renderable = true
+		mv.Body = formatPollMessage(utils.PresentPoll(m, time.Now()), m.ServerHeader.MessageID)
+	case chat1.MessageType_POLLVOTE:
+		mv.Renderable = false
+	case chat1.MessageType_INTERACTIVE:
+		mv.Renderable = true
+		mv.Body = formatInteractiveMessage(m.MessageBody.Interactive(), m.ServerHeader.MessageID)
+	case chat1.MessageType_INTERACTIVEACTION:
+		mv.Renderable = false
 	default:
 		return mv, fmt.Errorf("unsupported MessageType: %s", typ.String())
 	}
@@ -0,0 +1,355 @@
+package chatrender
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/keybase/client/go/terminalescaper"
+)
+
+// textStyle turns a terminal text style on and off with SGR escape sequences
+// that terminalescaper lets through.
+type textStyle struct {
+	on, off string
+}
+
+func (s textStyle) apply(text string) string {
+	if len(text) == 0 {
+		return ""
+	}
+	return s.on + text + s.off
+}
+
+var (
+	styleBold    = textStyle{"\x1b[1m", "\x1b[22m"}
+	styleItalic  = textStyle{"\x1b[3m", "\x1b[23m"}
+	styleStrike  = textStyle{"\x1b[9m", "\x1b[29m"}
+	styleCode    = textStyle{"\x1b[36m", "\x1b[39m"}
+	styleMention = textStyle{"\x1b[33m", "\x1b[39m"}
+	styleChannel = textStyle{"\x1b[34m", "\x1b[39m"}
+	styleQuote   = textStyle{"\x1b[90m", "\x1b[39m"}
+
+	markerStyles = map[byte]textStyle{'*': styleBold, '_': styleItalic, '~': styleStrike}
+
+	styleKeyword = textStyle{"\x1b[35m", "\x1b[39m"}
+	styleString  = textStyle{"\x1b[32m", "\x1b[39m"}
+	styleComment = textStyle{"\x1b[90m", "\x1b[39m"}
+	styleNumber  = textStyle{"\x1b[33m", "\x1b[39m"}
+)
+
+const (
+	codeFence  = "```"
+	codeIndent = "  "
+	quoteMark  = "> "
+	// mentionPrefixes are the characters after which an @-mention or #channel
+	// starts, like utils.ServiceDecorationPrefix
+	mentionPrefixes = " \t([/{:;.,!?\"'"
+)
+
+var (
+	atMentionRegexp      = regexp.MustCompile(`^@[a-zA-Z0-9][a-zA-Z0-9._]*[a-zA-Z0-9_]+(?:#[a-z0-9A-Z_-]+)?`)
+	channelMentionRegexp = regexp.MustCompile(`^#[0-9a-zA-Z_-]+`)
+	quoteLineRegexp      = regexp.MustCompile(`^ *> ?`)
+	codeLanguageRegexp   = regexp.MustCompile(`^[a-zA-Z0-9+#_-]+$`)
+)
+
+// RenderMarkdown renders Keybase chat markdown for a terminal: *bold*,
+// _italic_, ~strike~, `code`, ```fenced code``` highlighted by the language
+// named on its first line, > quotes, @-mentions and #channels. Text is cleaned
+// with terminalescaper, and no style goes on past the end of a line, so the
+// lines can be laid out on their own.
+func RenderMarkdown(body string) string {
+	var lines []string
+	for len(body) > 0 {
+		start := strings.Index(body, codeFence)
+		if start < 0 {
+			break
+		}
+		length := strings.Index(body[start+len(codeFence):], codeFence)
+		if length < 0 {
+			break
+		}
+		end := start + len(codeFence) + length
+		if text := strings.TrimSuffix(body[:start], "\n"); len(text) > 0 {
+			lines = append(lines, renderMarkdownLines(text)...)
+		}
+		lines = append(lines, renderCodeBlock(body[start+len(codeFence):end])...)
+		body = strings.TrimPrefix(body[end+len(codeFence):], "\n")
+	}
+	if len(body) > 0 {
+		lines = append(lines, renderMarkdownLines(body)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderMarkdownLines(text string) (lines []string) {
+	for line := range strings.SplitSeq(text, "\n") {
+		if quote := quoteLineRegexp.FindString(line); len(quote) > 0 {
+			lines = append(lines, styleQuote.apply(quoteMark)+renderInline(line[len(quote):]))
+		} else {
+			lines = append(lines, renderInline(line))
+		}
+	}
+	return lines
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// renderInline renders the spans of a line of markdown.
+func renderInline(text string) string {
+	var b strings.Builder
+	plain := 0
+	flush := func(end int) {
+		b.WriteString(terminalescaper.Clean(text[plain:end]))
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\*_~`>@#", text[i+1]) >= 0:
+			// drop the backslash and keep what it escapes as it is
+			flush(i)
+			plain = i + 1
+			i += 2
+			continue
+		case c == '`':
+			if n := strings.IndexByte(text[i+1:], '`'); n > 0 {
+				flush(i)
+				b.WriteString(styleCode.apply(terminalescaper.Clean(text[i+1 : i+1+n])))
+				i += n + 2
+				plain = i
+				continue
+			}
+		case (c == '*' || c == '_' || c == '~') && !isWordChar(prev):
+			if end := closingMarker(text, i); end > 0 {
+				flush(i)
+				b.WriteString(markerStyles[c].apply(renderInline(text[i+1 : end])))
+				i = end + 1
+				plain = i
+				continue
+			}
+		case (c == '@' || c == '#') && (i == 0 || strings.ContainsRune(mentionPrefixes, prev)):
+			re, style := atMentionRegexp, styleMention
+			if c == '#' {
+				re, style = channelMentionRegexp, styleChannel
+			}
+			if match := re.FindString(text[i:]); len(match) > 0 {
+				flush(i)
+				b.WriteString(style.apply(match))
+				i += len(match)
+				plain = i
+				continue
+			}
+		}
+		i++
+	}
+	flush(len(text))
+	return b.String()
+}
+
+// closingMarker finds the marker closing the one at start, which has to end a
+// non-empty span and can't be doubled, or returns -1.
+func closingMarker(text string, start int) int {
+	marker := text[start]
+	for i := start + 2; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case marker:
+			if i+1 < len(text) && text[i+1] == marker {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func renderCodeBlock(code string) (lines []string) {
+	var lang *codeLanguage
+	if first, rest, ok := strings.Cut(code, "\n"); ok && codeLanguageRegexp.MatchString(first) {
+		if lang = codeLanguages[strings.ToLower(first)]; lang != nil {
+			code = rest
+		}
+	}
+	code = strings.Trim(code, "\n")
+	code = strings.ReplaceAll(code, "\t", "    ")
+	inComment := false
+	for line := range strings.SplitSeq(code, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if lang == nil {
+			lines = append(lines, codeIndent+styleCode.apply(terminalescaper.Clean(line)))
+			continue
+		}
+		var highlighted string
+		highlighted, inComment = lang.highlight(line, inComment)
+		lines = append(lines, codeIndent+highlighted)
+	}
+	return lines
+}
+
+// codeLanguage is what syntax highlighting needs to know about a language.
+type codeLanguage struct {
+	keywords     map[string]bool
+	ignoreCase   bool
+	lineComments []string
+	// blockComment starts and ends a comment that can span lines
+	blockComment [2]string
+	quotes       string
+}
+
+func newCodeLanguage(keywords string, lineComments []string, blockComment [2]string, quotes string) *codeLanguage {
+	lang := &codeLanguage{
+		keywords:     make(map[string]bool),
+		lineComments: lineComments,
+		blockComment: blockComment,
+		quotes:       quotes,
+	}
+	for keyword := range strings.FieldsSeq(keywords) {
+		lang.keywords[keyword] = true
+	}
+	return lang
+}
+
+// caseInsensitive makes l match keywords, which have to be lowercase, in any
+// case.
+func (l *codeLanguage) caseInsensitive() *codeLanguage {
+	l.ignoreCase = true
+	return l
+}
+
+var (
+	cComment = [2]string{"/*", "*/"}
+
+	langGo = newCodeLanguage(`break case chan const continue default defer else fallthrough for func go
+		goto if import interface map package range return select struct switch type var true false nil`,
+		[]string{"//"}, cComment, "\"'`")
+	langJS = newCodeLanguage(`async await break case catch class const continue debugger default delete do
+		else enum export extends false finally for function if implements import in instanceof interface let
+		new null of return super switch this throw true try type typeof undefined var void while yield`,
+		[]string{"//"}, cComment, "\"'`")
+	langPython = newCodeLanguage(`and as assert async await break class continue def del elif else except
+		False finally for from global if import in is lambda None nonlocal not or pass raise return True try
+		while with yield`,
+		[]string{"#"}, [2]string{}, "\"'")
+	langShell = newCodeLanguage(`case do done elif else esac exit export fi for function if in local return
+		then until while`,
+		[]string{"#"}, [2]string{}, "\"'")
+	langRust = newCodeLanguage(`as async await break const continue crate else enum extern false fn for if
+		impl in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe
+		use where while`,
+		[]string{"//"}, cComment, "\"")
+	langC = newCodeLanguage(`auto bool break case catch char class const continue default delete do double
+		else enum extern false final float for goto if import int long namespace new nullptr null package
+		private protected public return short signed sizeof static struct switch template this throw true try
+		typedef union unsigned using virtual void volatile while`,
+		[]string{"//"}, cComment, "\"'")
+	langSQL = newCodeLanguage(`alter and as asc by create delete desc distinct drop from group having index
+		inner insert into is join key left limit not null on or order outer primary right select set table
+		union update values where`,
+		[]string{"--"}, cComment, "'\"").caseInsensitive()
+	langJSON = newCodeLanguage(`true false null`, nil, [2]string{}, "\"")
+	langYAML = newCodeLanguage(`true false null yes no`, []string{"#"}, [2]string{}, "\"'")
+
+	// codeLanguages are the languages fenced code can be highlighted in, by the
+	// names people give them on the first line of the block
+	codeLanguages = map[string]*codeLanguage{
+		"go": langGo, "golang": langGo,
+		"js": langJS, "javascript": langJS, "jsx": langJS, "ts": langJS, "typescript": langJS, "tsx": langJS,
+		"py": langPython, "python": langPython,
+		"sh": langShell, "bash": langShell, "shell": langShell, "zsh": langShell,
+		"rs": langRust, "rust": langRust,
+		"c": langC, "h": langC, "cpp": langC, "c++": langC, "java": langC, "cs": langC, "csharp": langC,
+		"sql":  langSQL,
+		"json": langJSON,
+		"yaml": langYAML, "yml": langYAML,
+	}
+)
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// highlight styles a line of code. inComment says whether the line starts
+// inside a block comment, and the returned one whether the next one does.
+func (l *codeLanguage) highlight(line string, inComment bool) (string, bool) {
+	var b strings.Builder
+	plain := 0
+	emit := func(start, end int, style textStyle) {
+		b.WriteString(terminalescaper.Clean(line[plain:start]))
+		b.WriteString(style.apply(terminalescaper.Clean(line[start:end])))
+		plain = end
+	}
+	i := 0
+	if inComment {
+		end := strings.Index(line, l.blockComment[1])
+		if end < 0 {
+			return styleComment.apply(terminalescaper.Clean(line)), true
+		}
+		i = end + len(l.blockComment[1])
+		emit(0, i, styleComment)
+	}
+lineLoop:
+	for i < len(line) {
+		c := line[i]
+		if len(l.blockComment[0]) > 0 && strings.HasPrefix(line[i:], l.blockComment[0]) {
+			end := strings.Index(line[i+len(l.blockComment[0]):], l.blockComment[1])
+			if end < 0 {
+				emit(i, len(line), styleComment)
+				return b.String(), true
+			}
+			end += i + len(l.blockComment[0]) + len(l.blockComment[1])
+			emit(i, end, styleComment)
+			i = end
+			continue
+		}
+		for _, comment := range l.lineComments {
+			if strings.HasPrefix(line[i:], comment) {
+				emit(i, len(line), styleComment)
+				break lineLoop
+			}
+		}
+		switch {
+		case strings.IndexByte(l.quotes, c) >= 0:
+			end := i + 1
+			for end < len(line) && line[end] != c {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(line))
+			emit(i, end, styleString)
+			i = end
+		case c >= '0' && c <= '9' && (i == 0 || !isIdentChar(line[i-1])):
+			end := i + 1
+			for end < len(line) && (isIdentChar(line[end]) || line[end] == '.') {
+				end++
+			}
+			emit(i, end, styleNumber)
+			i = end
+		case isIdentChar(c):
+			end := i + 1
+			for end < len(line) && isIdentChar(line[end]) {
+				end++
+			}
+			word := line[i:end]
+			if l.ignoreCase {
+				word = strings.ToLower(word)
+			}
+			if l.keywords[word] {
+				emit(i, end, styleKeyword)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	b.WriteString(terminalescaper.Clean(line[plain:]))
+	return b.String(), false
+}
@@ -0,0 +1,42 @@
+package chatrender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdownInline(t *testing.T) {
+	cases := map[string]string{
+		"plain text": "plain text",
+		"*bold* _italic_ ~strike~ `co*de*`": "\x1b[1mbold\x1b[22m \x1b[3mitalic\x1b[23m \x1b[9mstrike\x1b[29m " +
+			"\x1b[36mco*de*\x1b[39m",
+		"*bold _and italic_*": "\x1b[1mbold \x1b[3mand italic\x1b[23m\x1b[22m",
+		// markers inside words, unclosed and escaped ones stay
+		"snake_case_name 2*3 *open \\*not\\*": "snake_case_name 2*3 *open *not*",
+		"**":                                  "**",
+		"hi @alice, see #general (@team#chan) mail bob@keybase.io": "hi \x1b[33m@alice\x1b[39m, see " +
+			"\x1b[34m#general\x1b[39m (\x1b[33m@team#chan\x1b[39m) mail bob@keybase.io",
+		"> quoted *bold*\nnot > quoted": "\x1b[90m> \x1b[39mquoted \x1b[1mbold\x1b[22m\nnot > quoted",
+		// escapes other than styles are cleaned
+		"clear \x1b[2J *\x1b[2Jx*": "clear ^[[2J \x1b[1m^[[2Jx\x1b[22m",
+	}
+	for in, out := range cases {
+		require.Equal(t, out, RenderMarkdown(in), "%q", in)
+	}
+}
+
+func TestRenderMarkdownCode(t *testing.T) {
+	// without a known language, code is shown in one color
+	require.Equal(t, "before\n  \x1b[36mhello *world*\x1b[39m\n  \n  \x1b[36mnotalang\x1b[39m\nafter",
+		RenderMarkdown("before\n```\nhello *world*\n\nnotalang```after"))
+	require.Equal(t, "  \x1b[36mgo\x1b[39m", RenderMarkdown("```go```"))
+
+	require.Equal(t, "code:\n"+
+		"  \x1b[35mfunc\x1b[39m main() { \x1b[90m// hi\x1b[39m\n"+
+		"      \x1b[35mreturn\x1b[39m \x1b[32m\"a \\\" b\"\x1b[39m + \x1b[33m1.5\x1b[39m + x2 \x1b[90m/* one\x1b[39m\n"+
+		"  \x1b[90mtwo */\x1b[39m}",
+		RenderMarkdown("code:\n```Go\nfunc main() { // hi\n\treturn \"a \\\" b\" + 1.5 + x2 /* one\ntwo */}\n```\n"))
+	require.Equal(t, "  \x1b[35mSELECT\x1b[39m * \x1b[35mfrom\x1b[39m t \x1b[90m-- all\x1b[39m",
+		RenderMarkdown("```sql\nSELECT * from t -- all```"))
+}
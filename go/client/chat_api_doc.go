@@ -25,6 +25,9 @@ If you're on the nth page and want to go back, set the previous field instead.
 Get specific messages:
     {"method": "get", "params": {"options": {"channel": {"name": "you,them"}, "message_ids": [314, 315, 342]}}}
 
+Get a reply thread, the root message followed by its replies oldest first, given the ID of any message in it:
+    {"method": "getthread", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 314}}}
+
 Send a message:
     {"method": "send", "params": {"options": {"channel": {"name": "you,them"}, "message": {"body": "is it cold today?"}}}}
 
@@ -43,6 +46,9 @@ React to a message:
 Upload an attachment:
     {"method": "attach", "params": {"options": {"channel": {"name": "you,them"}, "filename": "photo.jpg", "title": "Sunset last night"}}}
 
+Upload an attachment without stripping its location and other metadata:
+    {"method": "attach", "params": {"options": {"channel": {"name": "you,them"}, "filename": "photo.jpg", "keep_metadata": true}}}
+
 Download an attachment:
     {"method": "download", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 59, "output": "/tmp/movie.mp4"}}}
 
@@ -114,6 +120,11 @@ Note the following are valid values for "type": "public", "teammembers", "teamco
     - teamconvs:  Commands are listed in all conversations of the given team. "team_name" must be specified.
     - conv: Commands are listed to the given conversation. "conv_id" must be specified.
 
+Advertise bot commands with typed arguments, which are checked before a command is sent:
+    {"method": "advertisecommands", "params": {"options":{"advertisements":[{"type": "public", "commands": [{"name": "deploy", "description": "Deploy a service", "args": [{"name": "env", "type": "enum", "values": ["prod", "staging"]}, {"name": "owner", "type": "user"}, {"name": "delay", "type": "duration", "optional": true}]}]}]}}}
+Argument types are "string", "int", "enum", "user", "channel" and "duration", and optional arguments come last. A "string" argument that comes last takes the rest of the message.
+The parsed arguments of a command reach the bot in 'keybase chat api-listen' as "botCommand" in the text content, next to the raw body.
+
 Clear bot commands:
     {"method": "clearcommands"}
 Note that there is an optional filter for this method. The valid values for "type" are "public", "teammembers", "teamconvs", "conv":
@@ -143,6 +154,53 @@ List members of a conversation from a topic name:
 List members of a conversation from a conversation id:
    {"method": "listmembers", "params": {"options": {"conversation_id": "..."}}}
 
+Schedule a message to be sent later by this device:
+   {"method": "schedule", "params": {"options": {"channel": {"name": "you,them"}, "message": {"body": "happy birthday!"}, "at": "tomorrow at 9am"}}}
+
+List scheduled messages, optionally for a single conversation:
+   {"method": "listscheduled", "params": {"options": {"channel": {"name": "you,them"}}}}
+
+Cancel a scheduled message:
+   {"method": "cancelscheduled", "params": {"options": {"id": "..."}}}
+
+Start a poll, optionally allowing several choices, hiding voters, or closing at a given time:
+   {"method": "poll", "params": {"options": {"channel": {"name": "you,them"}, "question": "lunch?", "options": ["tacos", "pizza"], "multi_choice": false, "anonymous": false, "closes": "in 2 hours"}}}
+
+Vote on a poll, choices are indexes into its options (an empty list retracts your vote):
+   {"method": "pollvote", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 72, "choices": [0]}}}
+
+Get the current results of a poll:
+   {"method": "pollresults", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 72}}}
+
+Send an interactive message, as a restricted bot of the conversation, with buttons and selects, each with a payload sent back to you when picked:
+   {"method": "interactive", "params": {"options": {"channel": {"name": "you,them"}, "body": "*Deploy* to production?", "elements": [{"type": "button", "id": "yes", "label": "Deploy", "payload": "deploy:prod"}, {"type": "select", "id": "when", "label": "When", "options": [{"label": "Now", "payload": "now"}, {"label": "Tonight", "payload": "tonight"}]}]}}}
+
+Click a button or pick a select option of an interactive message, by element and payload or by its number in 'keybase chat read':
+   {"method": "interact", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 72, "element_id": "when", "payload": "now"}}}
+   {"method": "interact", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 72, "choice": 2}}}
+The bot gets the action in 'keybase chat api-listen' as a notification of type "chat_interactive_action".
+
+Get the keywords and regexes that notify like an @-mention:
+   {"method": "gethighlights"}
+
+Set the keywords (whole words, any case) and regexes that notify like an @-mention:
+   {"method": "sethighlights", "params": {"options": {"keywords": ["outage", "keybase"], "regexes": ["proj-\\d+"]}}}
+
+Get the do-not-disturb schedule:
+   {"method": "getdnd"}
+
+Set the do-not-disturb schedule, which suppresses push and desktop notifications but keeps badges:
+   {"method": "setdnd", "params": {"options": {"enabled": true, "timezone": "Europe/Berlin", "windows": ["mon-fri 22:00-07:00", "weekends"], "allow_dms": true, "allow_channels": [{"name": "myteam", "members_type": "team", "topic_name": "incidents"}]}}}
+
+Bookmark a message privately, bookmarks are the same on all of your devices:
+   {"method": "addbookmark", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 314, "note": "read later"}}}
+
+List bookmarked messages, newest bookmarks first:
+   {"method": "listbookmarks"}
+
+Remove a bookmark:
+   {"method": "removebookmark", "params": {"options": {"channel": {"name": "you,them"}, "message_id": 314}}}
+
 Add an emoji:
     {"method": "emojiadd", "params": {"options": {"channel": {"name": "mikem"}, "alias": "mask-parrot2", "filename": "/Users/mike/Downloads/mask-parrot.gif"}}}
 
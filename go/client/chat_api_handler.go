@@ -56,6 +56,22 @@ const (
 	methodEmojiList           = "emojilist"
 	methodEmojiRemove         = "emojiremove"
 	methodEmojiAddAlias       = "emojiaddalias"
+	methodSchedule            = "schedule"
+	methodListScheduled       = "listscheduled"
+	methodCancelScheduled     = "cancelscheduled"
+	methodPoll                = "poll"
+	methodPollVote            = "pollvote"
+	methodPollResults         = "pollresults"
+	methodInteractive         = "interactive"
+	methodInteract            = "interact"
+	methodGetHighlights       = "gethighlights"
+	methodSetHighlights       = "sethighlights"
+	methodGetDND              = "getdnd"
+	methodSetDND              = "setdnd"
+	methodGetThread           = "getthread"
+	methodAddBookmark         = "addbookmark"
+	methodListBookmarks       = "listbookmarks"
+	methodRemoveBookmark      = "removebookmark"
 )
 
 // ChatAPIHandler can handle all of the chat json api methods.
@@ -95,6 +111,22 @@ type ChatAPIHandler interface {
 	EmojiAddAliasV1(context.Context, Call, io.Writer) error
 	EmojiListV1(context.Context, Call, io.Writer) error
 	EmojiRemoveV1(context.Context, Call, io.Writer) error
+	ScheduleV1(context.Context, Call, io.Writer) error
+	ListScheduledV1(context.Context, Call, io.Writer) error
+	CancelScheduledV1(context.Context, Call, io.Writer) error
+	PollV1(context.Context, Call, io.Writer) error
+	PollVoteV1(context.Context, Call, io.Writer) error
+	PollResultsV1(context.Context, Call, io.Writer) error
+	InteractiveV1(context.Context, Call, io.Writer) error
+	InteractV1(context.Context, Call, io.Writer) error
+	GetHighlightsV1(context.Context, Call, io.Writer) error
+	SetHighlightsV1(context.Context, Call, io.Writer) error
+	GetDNDV1(context.Context, Call, io.Writer) error
+	SetDNDV1(context.Context, Call, io.Writer) error
+	GetThreadV1(context.Context, Call, io.Writer) error
+	AddBookmarkV1(context.Context, Call, io.Writer) error
+	ListBookmarksV1(context.Context, Call, io.Writer) error
+	RemoveBookmarkV1(context.Context, Call, io.Writer) error
 }
 
 // ChatAPI implements ChatAPIHandler and contains a ChatServiceHandler
@@ -317,6 +349,7 @@ type attachOptionsV1 struct {
 	Preview           string
 	Title             string
 	EphemeralLifetime ephemeralLifetime `json:"exploding_lifetime"`
+	KeepMetadata      bool              `json:"keep_metadata"`
 }
 
 func (a attachOptionsV1) Check() error {
@@ -1250,6 +1283,436 @@ func (a *ChatAPI) EmojiListV1(ctx context.Context, c Call, w io.Writer) error {
 	return a.encodeReply(c, a.svcHandler.EmojiListV1(ctx), w)
 }
 
+type scheduleOptionsV1 struct {
+	Channel           ChatChannel
+	ConversationID    chat1.ConvIDStr `json:"conversation_id"`
+	Message           ChatMessage
+	At                string            `json:"at"`
+	EphemeralLifetime ephemeralLifetime `json:"exploding_lifetime"`
+}
+
+func (o scheduleOptionsV1) Check() error {
+	if err := checkChannelConv(methodSchedule, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if !o.Message.Valid() {
+		return ErrInvalidOptions{version: 1, method: methodSchedule, err: errors.New("invalid message, body cannot be empty")}
+	}
+	if len(strings.TrimSpace(o.At)) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodSchedule, err: errors.New("must specify a send time in `at`")}
+	}
+	if !o.EphemeralLifetime.Valid() {
+		return ErrInvalidOptions{version: 1, method: methodSchedule, err: fmt.Errorf("invalid ephemeral lifetime: %v, must be between %v and %v",
+			o.EphemeralLifetime, libkb.MaxEphemeralContentLifetime, libkb.MinEphemeralContentLifetime)}
+	}
+	return nil
+}
+
+func (a *ChatAPI) ScheduleV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodSchedule, err: errors.New("empty options")}
+	}
+	var opts scheduleOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.ScheduleV1(ctx, opts), w)
+}
+
+// listScheduledOptionsV1 optionally limits the list to a single conversation.
+type listScheduledOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+}
+
+func (o listScheduledOptionsV1) Check() error {
+	return nil
+}
+
+func (a *ChatAPI) ListScheduledV1(ctx context.Context, c Call, w io.Writer) error {
+	var opts listScheduledOptionsV1
+	// Options are optional for listscheduled
+	if len(c.Params.Options) != 0 {
+		if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+			return err
+		}
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.ListScheduledV1(ctx, opts), w)
+}
+
+type cancelScheduledOptionsV1 struct {
+	ID string `json:"id"`
+}
+
+func (o cancelScheduledOptionsV1) Check() error {
+	if len(o.ID) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodCancelScheduled, err: errors.New("must specify the id of a scheduled message")}
+	}
+	return nil
+}
+
+func (a *ChatAPI) CancelScheduledV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodCancelScheduled, err: errors.New("empty options")}
+	}
+	var opts cancelScheduledOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.CancelScheduledV1(ctx, opts), w)
+}
+
+type pollOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	Question       string          `json:"question"`
+	Options        []string        `json:"options"`
+	MultiChoice    bool            `json:"multi_choice"`
+	Anonymous      bool            `json:"anonymous"`
+	Closes         string          `json:"closes"`
+}
+
+func (o pollOptionsV1) Check() error {
+	if err := checkChannelConv(methodPoll, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(o.Question)) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPoll, err: errors.New("invalid poll, question cannot be empty")}
+	}
+	if len(o.Options) < 2 {
+		return ErrInvalidOptions{version: 1, method: methodPoll, err: errors.New("a poll needs at least two options")}
+	}
+	return nil
+}
+
+func (a *ChatAPI) PollV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPoll, err: errors.New("empty options")}
+	}
+	var opts pollOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.PollV1(ctx, opts), w)
+}
+
+type pollVoteOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+	// Choices are indexes into the poll options, an empty list retracts a
+	// previous vote.
+	Choices []int `json:"choices"`
+}
+
+func (o pollVoteOptionsV1) Check() error {
+	if err := checkChannelConv(methodPollVote, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPollVote, err: fmt.Errorf("invalid message id '%d'", o.MessageID)}
+	}
+	return nil
+}
+
+func (a *ChatAPI) PollVoteV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPollVote, err: errors.New("empty options")}
+	}
+	var opts pollVoteOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.PollVoteV1(ctx, opts), w)
+}
+
+type pollResultsOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+}
+
+func (o pollResultsOptionsV1) Check() error {
+	if err := checkChannelConv(methodPollResults, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPollResults, err: fmt.Errorf("invalid message id '%d'", o.MessageID)}
+	}
+	return nil
+}
+
+func (a *ChatAPI) PollResultsV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodPollResults, err: errors.New("empty options")}
+	}
+	var opts pollResultsOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.PollResultsV1(ctx, opts), w)
+}
+
+type interactiveOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	// Markdown shown above the buttons and selects
+	Body     string                        `json:"body"`
+	Elements []chat1.MsgInteractiveElement `json:"elements"`
+}
+
+func (o interactiveOptionsV1) Check() error {
+	if err := checkChannelConv(methodInteractive, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if len(o.Elements) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodInteractive, err: errors.New("an interactive message needs at least one button or select")}
+	}
+	return nil
+}
+
+func (a *ChatAPI) InteractiveV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodInteractive, err: errors.New("empty options")}
+	}
+	var opts interactiveOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.InteractiveV1(ctx, opts), w)
+}
+
+type interactOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+	// Choice is the number of a button or select option as shown by `keybase
+	// chat read`, it can be given instead of the element ID and payload.
+	Choice    int    `json:"choice"`
+	ElementID string `json:"element_id"`
+	// Payload picks the option of a select, it can be left out for buttons.
+	Payload string `json:"payload"`
+}
+
+func (o interactOptionsV1) Check() error {
+	if err := checkChannelConv(methodInteract, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodInteract, err: fmt.Errorf("invalid message id '%d'", o.MessageID)}
+	}
+	if (o.Choice > 0) == (len(o.ElementID) > 0) {
+		return ErrInvalidOptions{version: 1, method: methodInteract, err: errors.New("exactly one of choice or element_id is required")}
+	}
+	if o.Choice < 0 {
+		return ErrInvalidOptions{version: 1, method: methodInteract, err: fmt.Errorf("invalid choice '%d'", o.Choice)}
+	}
+	return nil
+}
+
+func (a *ChatAPI) InteractV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodInteract, err: errors.New("empty options")}
+	}
+	var opts interactOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.InteractV1(ctx, opts), w)
+}
+
+type setHighlightsOptionsV1 struct {
+	Keywords []string
+	Regexes  []string
+}
+
+func (o setHighlightsOptionsV1) Check() error {
+	for _, expr := range o.Regexes {
+		if _, err := regexp.Compile(expr); err != nil {
+			return ErrInvalidOptions{version: 1, method: methodSetHighlights, err: fmt.Errorf("invalid regex %q: %s", expr, err)}
+		}
+	}
+	return nil
+}
+
+func (a *ChatAPI) GetHighlightsV1(ctx context.Context, c Call, w io.Writer) error {
+	return a.encodeReply(c, a.svcHandler.GetHighlightsV1(ctx), w)
+}
+
+func (a *ChatAPI) SetHighlightsV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodSetHighlights, err: errors.New("empty options")}
+	}
+	var opts setHighlightsOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.SetHighlightsV1(ctx, opts), w)
+}
+
+type setDNDOptionsV1 struct {
+	Enabled       bool
+	Timezone      string
+	Windows       []string
+	AllowDMs      bool          `json:"allow_dms"`
+	AllowChannels []ChatChannel `json:"allow_channels"`
+}
+
+func (o setDNDOptionsV1) Check() error {
+	for _, window := range o.Windows {
+		if _, err := parseDNDWindow(window); err != nil {
+			return ErrInvalidOptions{version: 1, method: methodSetDND, err: err}
+		}
+	}
+	for _, channel := range o.AllowChannels {
+		if !channel.Valid() {
+			return ErrInvalidOptions{version: 1, method: methodSetDND, err: errors.New("invalid allowed channel")}
+		}
+	}
+	return nil
+}
+
+func (a *ChatAPI) GetDNDV1(ctx context.Context, c Call, w io.Writer) error {
+	return a.encodeReply(c, a.svcHandler.GetDNDV1(ctx), w)
+}
+
+func (a *ChatAPI) SetDNDV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodSetDND, err: errors.New("empty options")}
+	}
+	var opts setDNDOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.SetDNDV1(ctx, opts), w)
+}
+
+type getThreadOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+	FailOffline    bool            `json:"fail_offline"`
+}
+
+func (o getThreadOptionsV1) Check() error {
+	if err := checkChannelConv(methodGetThread, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodGetThread, err: errors.New("invalid message id")}
+	}
+	return nil
+}
+
+func (a *ChatAPI) GetThreadV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodGetThread, err: errors.New("empty options")}
+	}
+	var opts getThreadOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.GetThreadV1(ctx, opts), w)
+}
+
+type addBookmarkOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+	Note           string
+}
+
+func (o addBookmarkOptionsV1) Check() error {
+	if err := checkChannelConv(methodAddBookmark, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodAddBookmark, err: errors.New("invalid message id")}
+	}
+	return nil
+}
+
+type removeBookmarkOptionsV1 struct {
+	Channel        ChatChannel
+	ConversationID chat1.ConvIDStr `json:"conversation_id"`
+	MessageID      chat1.MessageID `json:"message_id"`
+}
+
+func (o removeBookmarkOptionsV1) Check() error {
+	if err := checkChannelConv(methodRemoveBookmark, o.Channel, o.ConversationID); err != nil {
+		return err
+	}
+	if o.MessageID == 0 {
+		return ErrInvalidOptions{version: 1, method: methodRemoveBookmark, err: errors.New("invalid message id")}
+	}
+	return nil
+}
+
+func (a *ChatAPI) AddBookmarkV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodAddBookmark, err: errors.New("empty options")}
+	}
+	var opts addBookmarkOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.AddBookmarkV1(ctx, opts), w)
+}
+
+func (a *ChatAPI) ListBookmarksV1(ctx context.Context, c Call, w io.Writer) error {
+	return a.encodeReply(c, a.svcHandler.ListBookmarksV1(ctx), w)
+}
+
+func (a *ChatAPI) RemoveBookmarkV1(ctx context.Context, c Call, w io.Writer) error {
+	if len(c.Params.Options) == 0 {
+		return ErrInvalidOptions{version: 1, method: methodRemoveBookmark, err: errors.New("empty options")}
+	}
+	var opts removeBookmarkOptionsV1
+	if err := json.Unmarshal(c.Params.Options, &opts); err != nil {
+		return err
+	}
+	if err := opts.Check(); err != nil {
+		return err
+	}
+	return a.encodeReply(c, a.svcHandler.RemoveBookmarkV1(ctx, opts), w)
+}
+
 func (a *ChatAPI) encodeReply(call Call, reply Reply, w io.Writer) error {
 	return encodeReply(call, reply, w, a.indent)
 }
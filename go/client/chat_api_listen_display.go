@@ -42,6 +42,10 @@ func newMsgNotification(source string) *chat1.MsgNotification {
 	}
 }
 
+// notifTypeChatInteractiveAction is the type of notifications of a user
+// clicking a button or picking an option in an interactive message of the bot.
+const notifTypeChatInteractiveAction = "chat_interactive_action"
+
 const notifTypeChatConv = "chat_conv"
 
 func newConvNotification() *chat1.ConvNotification {
@@ -50,86 +54,45 @@ func newConvNotification() *chat1.ConvNotification {
 	}
 }
 
-func (d *chatNotificationDisplay) setupFilters(ctx context.Context, channelFilters []ChatChannel) error {
+func (d *chatNotificationDisplay) setupFilters(ctx context.Context, channelFilters []ChatChannel) (err error) {
+	d.filtersNormalized, err = resolveChannelFilters(ctx, d.G(), d.svc, channelFilters)
+	return err
+}
+
+// resolveChannelFilters finds the conversations of channel filters. A team
+// channel filter without a topic name matches all team conversations the
+// user is in.
+func resolveChannelFilters(ctx context.Context, g *libkb.GlobalContext, svc *chatServiceHandler,
+	channelFilters []ChatChannel,
+) (res []chat1.ConversationID, err error) {
 	for _, v := range channelFilters {
-		if MembersTypeFromStrDefault(v.MembersType, d.G().GetEnv()) == chat1.ConversationMembersType_TEAM &&
+		if MembersTypeFromStrDefault(v.MembersType, g.GetEnv()) == chat1.ConversationMembersType_TEAM &&
 			len(v.TopicName) == 0 {
 			// treat this formulation of a channel as listing all team convs the users is in
 			topicType, err := TopicTypeFromStrDefault(v.TopicType)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			convs, _, err := d.svc.getAllTeamConvs(ctx, v.Name, &topicType)
+			convs, _, err := svc.getAllTeamConvs(ctx, v.Name, &topicType)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			for _, conv := range convs {
-				d.filtersNormalized = append(d.filtersNormalized, conv.GetConvID())
+				res = append(res, conv.GetConvID())
 			}
 		} else {
-			conv, _, err := d.svc.findConversation(ctx, "", v)
+			conv, _, err := svc.findConversation(ctx, "", v)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			d.filtersNormalized = append(d.filtersNormalized, conv.GetConvID())
+			res = append(res, conv.GetConvID())
 		}
 	}
-	return nil
+	return res, nil
 }
 
 func (d *chatNotificationDisplay) formatMessage(inMsg chat1.IncomingMessage) *chat1.Message {
-	state, err := inMsg.Message.State()
-	if err != nil {
-		errStr := err.Error()
-		return &chat1.Message{Error: &errStr}
-	}
-
-	switch state {
-	case chat1.MessageUnboxedState_ERROR:
-		errStr := inMsg.Message.Error().ErrMsg
-		return &chat1.Message{Error: &errStr}
-	case chat1.MessageUnboxedState_VALID:
-		// if we weren't able to get an inbox item here, then just return an error
-		if inMsg.Conv == nil {
-			msg := "unable to get chat channel"
-			return &chat1.Message{Error: &msg}
-		}
-		mv := inMsg.Message.Valid()
-		summary := &chat1.MsgSummary{
-			Id:     mv.MessageID,
-			ConvID: inMsg.ConvID.ConvIDStr(),
-			Channel: chat1.ChatChannel{
-				Name:        inMsg.Conv.Name,
-				MembersType: strings.ToLower(inMsg.Conv.MembersType.String()),
-				TopicType:   strings.ToLower(inMsg.Conv.TopicType.String()),
-				TopicName:   inMsg.Conv.Channel,
-				Public:      inMsg.Conv.Visibility == keybase1.TLFVisibility_PUBLIC,
-			},
-			Sender: chat1.MsgSender{
-				Uid:        keybase1.UID(mv.SenderUID.String()),
-				DeviceID:   keybase1.DeviceID(mv.SenderDeviceID.String()),
-				Username:   mv.SenderUsername,
-				DeviceName: mv.SenderDeviceName,
-			},
-			SentAt:              mv.Ctime.UnixSeconds(),
-			SentAtMs:            mv.Ctime.UnixMilliseconds(),
-			RevokedDevice:       mv.SenderDeviceRevokedAt != nil,
-			IsEphemeral:         mv.IsEphemeral,
-			IsEphemeralExpired:  mv.IsEphemeralExpired,
-			ETime:               mv.Etime,
-			HasPairwiseMacs:     mv.HasPairwiseMacs,
-			Content:             d.svc.convertMsgBody(mv.MessageBody),
-			AtMentionUsernames:  mv.AtMentions,
-			ChannelMention:      strings.ToLower(mv.ChannelMention.String()),
-			ChannelNameMentions: mv.ChannelNameMentions,
-		}
-		if mv.Reactions.Reactions != nil {
-			summary.Reactions = &mv.Reactions
-		}
-		return &chat1.Message{Msg: summary}
-	default:
-		return nil
-	}
+	return utils.ExportIncomingMessage(inMsg)
 }
 
 func (d *chatNotificationDisplay) matchFilters(convID chat1.ConversationID) bool {
@@ -161,12 +124,24 @@ func (d *chatNotificationDisplay) NewChatActivity(ctx context.Context, arg chat1
 			// Skip filtered out message.
 			return nil
 		}
+		isAction := false
+		if inMsg.Message.IsValid() &&
+			inMsg.Message.Valid().MessageBody.IsType(chat1.MessageType_INTERACTIVEACTION) {
+			// Interactive actions are only for the bot of the interactive message.
+			if !utils.IsInteractiveActionFor(inMsg.Message.Valid().MessageBody, arg.Uid.ToBytes()) {
+				return nil
+			}
+			isAction = true
+		}
 		msg := d.formatMessage(inMsg)
 		if msg == nil {
 			return nil
 		}
 		source := strings.ToLower(arg.Source.String())
 		notif := newMsgNotification(source)
+		if isAction {
+			notif.Type = notifTypeChatInteractiveAction
+		}
 		notif.Msg = msg.Msg
 		notif.Error = msg.Error
 		notif.Pagination = inMsg.Pagination
@@ -51,6 +51,22 @@ type handlerTracker struct {
 	emojiAddAliasV1     int
 	emojiListV1         int
 	emojiRemoveV1       int
+	scheduleV1          int
+	listScheduledV1     int
+	cancelScheduledV1   int
+	pollV1              int
+	pollVoteV1          int
+	pollResultsV1       int
+	interactiveV1       int
+	interactV1          int
+	getHighlightsV1     int
+	setHighlightsV1     int
+	getDNDV1            int
+	setDNDV1            int
+	getThreadV1         int
+	addBookmarkV1       int
+	listBookmarksV1     int
+	removeBookmarkV1    int
 }
 
 func (h *handlerTracker) ListV1(context.Context, Call, io.Writer) error {
@@ -226,6 +242,86 @@ func (h *handlerTracker) EmojiRemoveV1(context.Context, Call, io.Writer) error {
 	return nil
 }
 
+func (h *handlerTracker) ScheduleV1(context.Context, Call, io.Writer) error {
+	h.scheduleV1++
+	return nil
+}
+
+func (h *handlerTracker) ListScheduledV1(context.Context, Call, io.Writer) error {
+	h.listScheduledV1++
+	return nil
+}
+
+func (h *handlerTracker) CancelScheduledV1(context.Context, Call, io.Writer) error {
+	h.cancelScheduledV1++
+	return nil
+}
+
+func (h *handlerTracker) PollV1(context.Context, Call, io.Writer) error {
+	h.pollV1++
+	return nil
+}
+
+func (h *handlerTracker) PollVoteV1(context.Context, Call, io.Writer) error {
+	h.pollVoteV1++
+	return nil
+}
+
+func (h *handlerTracker) PollResultsV1(context.Context, Call, io.Writer) error {
+	h.pollResultsV1++
+	return nil
+}
+
+func (h *handlerTracker) InteractiveV1(context.Context, Call, io.Writer) error {
+	h.interactiveV1++
+	return nil
+}
+
+func (h *handlerTracker) InteractV1(context.Context, Call, io.Writer) error {
+	h.interactV1++
+	return nil
+}
+
+func (h *handlerTracker) GetHighlightsV1(context.Context, Call, io.Writer) error {
+	h.getHighlightsV1++
+	return nil
+}
+
+func (h *handlerTracker) SetHighlightsV1(context.Context, Call, io.Writer) error {
+	h.setHighlightsV1++
+	return nil
+}
+
+func (h *handlerTracker) GetDNDV1(context.Context, Call, io.Writer) error {
+	h.getDNDV1++
+	return nil
+}
+
+func (h *handlerTracker) SetDNDV1(context.Context, Call, io.Writer) error {
+	h.setDNDV1++
+	return nil
+}
+
+func (h *handlerTracker) GetThreadV1(context.Context, Call, io.Writer) error {
+	h.getThreadV1++
+	return nil
+}
+
+func (h *handlerTracker) AddBookmarkV1(context.Context, Call, io.Writer) error {
+	h.addBookmarkV1++
+	return nil
+}
+
+func (h *handlerTracker) ListBookmarksV1(context.Context, Call, io.Writer) error {
+	h.listBookmarksV1++
+	return nil
+}
+
+func (h *handlerTracker) RemoveBookmarkV1(context.Context, Call, io.Writer) error {
+	h.removeBookmarkV1++
+	return nil
+}
+
 type echoResult struct {
 	Status string `json:"status"`
 }
@@ -378,6 +474,70 @@ func (c *chatEcho) EmojiRemoveV1(context.Context, emojiRemoveOptionsV1) Reply {
 	return Reply{Result: echoOK}
 }
 
+func (c *chatEcho) ScheduleV1(context.Context, scheduleOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) ListScheduledV1(context.Context, listScheduledOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) CancelScheduledV1(context.Context, cancelScheduledOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) PollV1(context.Context, pollOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) PollVoteV1(context.Context, pollVoteOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) PollResultsV1(context.Context, pollResultsOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) InteractiveV1(context.Context, interactiveOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) InteractV1(context.Context, interactOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) GetHighlightsV1(context.Context) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) SetHighlightsV1(context.Context, setHighlightsOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) GetDNDV1(context.Context) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) SetDNDV1(context.Context, setDNDOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) GetThreadV1(context.Context, getThreadOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) AddBookmarkV1(context.Context, addBookmarkOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) ListBookmarksV1(context.Context) Reply {
+	return Reply{Result: echoOK}
+}
+
+func (c *chatEcho) RemoveBookmarkV1(context.Context, removeBookmarkOptionsV1) Reply {
+	return Reply{Result: echoOK}
+}
+
 type topTest struct {
 	input               string
 	output              string
@@ -761,6 +921,30 @@ var optTests = []optTest{
 		input:  `{"method": "unpin", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}}}}`,
 		output: `{"result":{"status":"ok"}}`,
 	},
+	{
+		input:  `{"method": "interactive", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "body": "deploy?"}}}`,
+		output: `{"error":{"code":0,"message":"invalid interactive v1 options: an interactive message needs at least one button or select"}}`,
+	},
+	{
+		input:  `{"method": "interactive", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "body": "deploy?", "elements": [{"type": "button", "id": "yes", "label": "Yes", "payload": "deploy"}]}}}`,
+		output: `{"result":{"status":"ok"}}`,
+	},
+	{
+		input:  `{"method": "interact", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "choice": 1}}}`,
+		output: `{"error":{"code":0,"message":"invalid interact v1 options: invalid message id '0'"}}`,
+	},
+	{
+		input:  `{"method": "interact", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "message_id": 3}}}`,
+		output: `{"error":{"code":0,"message":"invalid interact v1 options: exactly one of choice or element_id is required"}}`,
+	},
+	{
+		input:  `{"method": "interact", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "message_id": 3, "choice": 2, "element_id": "size"}}}`,
+		output: `{"error":{"code":0,"message":"invalid interact v1 options: exactly one of choice or element_id is required"}}`,
+	},
+	{
+		input:  `{"method": "interact", "params":{"version": 1, "options": {"channel": {"name": "alice,bob"}, "message_id": 3, "element_id": "size", "payload": "large"}}}`,
+		output: `{"result":{"status":"ok"}}`,
+	},
 }
 
 // TestChatAPIVersionHandlerOptions tests the option decoding.
@@ -99,6 +99,38 @@ func (d *ChatAPIVersionHandler) handleV1(ctx context.Context, c Call, w io.Write
 		return d.handler.EmojiListV1(ctx, c, w)
 	case methodEmojiRemove:
 		return d.handler.EmojiRemoveV1(ctx, c, w)
+	case methodSchedule:
+		return d.handler.ScheduleV1(ctx, c, w)
+	case methodListScheduled:
+		return d.handler.ListScheduledV1(ctx, c, w)
+	case methodCancelScheduled:
+		return d.handler.CancelScheduledV1(ctx, c, w)
+	case methodPoll:
+		return d.handler.PollV1(ctx, c, w)
+	case methodPollVote:
+		return d.handler.PollVoteV1(ctx, c, w)
+	case methodPollResults:
+		return d.handler.PollResultsV1(ctx, c, w)
+	case methodInteractive:
+		return d.handler.InteractiveV1(ctx, c, w)
+	case methodInteract:
+		return d.handler.InteractV1(ctx, c, w)
+	case methodGetHighlights:
+		return d.handler.GetHighlightsV1(ctx, c, w)
+	case methodSetHighlights:
+		return d.handler.SetHighlightsV1(ctx, c, w)
+	case methodGetDND:
+		return d.handler.GetDNDV1(ctx, c, w)
+	case methodSetDND:
+		return d.handler.SetDNDV1(ctx, c, w)
+	case methodGetThread:
+		return d.handler.GetThreadV1(ctx, c, w)
+	case methodAddBookmark:
+		return d.handler.AddBookmarkV1(ctx, c, w)
+	case methodListBookmarks:
+		return d.handler.ListBookmarksV1(ctx, c, w)
+	case methodRemoveBookmark:
+		return d.handler.RemoveBookmarkV1(ctx, c, w)
 	default:
 		return ErrInvalidMethod{name: c.Method, version: 1}
 	}
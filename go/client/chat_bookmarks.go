@@ -0,0 +1,49 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// loadBookmarkConvs loads the conversations that bookmarks point into, keyed
+// by conversation ID. Conversations we can't load anymore are left out.
+func loadBookmarkConvs(ctx context.Context, chatClient chat1.LocalClient,
+	bookmarks []chat1.BookmarkedMessage,
+) (map[chat1.ConvIDStr]chat1.ConversationLocal, error) {
+	res := make(map[chat1.ConvIDStr]chat1.ConversationLocal)
+	seen := make(map[chat1.ConvIDStr]bool)
+	var convIDs []chat1.ConversationID
+	for _, b := range bookmarks {
+		if !seen[b.Bookmark.ConvID.ConvIDStr()] {
+			seen[b.Bookmark.ConvID.ConvIDStr()] = true
+			convIDs = append(convIDs, b.Bookmark.ConvID)
+		}
+	}
+	if len(convIDs) == 0 {
+		return res, nil
+	}
+	ib, err := chatClient.GetInboxAndUnboxLocal(ctx, chat1.GetInboxAndUnboxLocalArg{
+		Query:            &chat1.GetInboxLocalQuery{ConvIDs: convIDs},
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, conv := range ib.Conversations {
+		res[conv.GetConvID().ConvIDStr()] = conv
+	}
+	return res, nil
+}
+
+// chatChannelDisplayName is the name of a channel as the CLI prints it.
+func chatChannelDisplayName(channel chat1.ChatChannel) string {
+	if len(channel.TopicName) > 0 && channel.MembersType == "team" {
+		return channel.Name + "#" + channel.TopicName
+	}
+	return channel.Name
+}
@@ -17,6 +17,9 @@ import (
 type chatCLIConvFetcher struct {
 	query            chat1.GetConversationForCLILocalQuery
 	resolvingRequest chatConversationResolvingRequest
+	// threadMsgID, when set, fetches the reply thread containing this message
+	// instead of the latest messages
+	threadMsgID chat1.MessageID
 }
 
 func (f chatCLIConvFetcher) fetch(ctx context.Context, g *libkb.GlobalContext) (chat1.ConversationLocal, []chat1.MessageUnboxed, error) {
@@ -46,6 +49,10 @@ func (f chatCLIConvFetcher) fetch(ctx context.Context, g *libkb.GlobalContext) (
 		return chat1.ConversationLocal{}, nil, fmt.Errorf("empty conversationInfo.Id: %+v", conversation.Info)
 	}
 
+	if f.threadMsgID > 0 {
+		return f.fetchThread(ctx, g, resolver.ChatClient, *conversation)
+	}
+
 	gcfclres, err := resolver.ChatClient.GetConversationForCLILocal(ctx, f.query)
 	if err != nil {
 		return chat1.ConversationLocal{}, nil, fmt.Errorf("GetConversationForCLILocal error: %s", err)
@@ -58,6 +65,31 @@ func (f chatCLIConvFetcher) fetch(ctx context.Context, g *libkb.GlobalContext) (
 	return gcfclres.Conversation, gcfclres.Messages, nil
 }
 
+func (f chatCLIConvFetcher) fetchThread(ctx context.Context, g *libkb.GlobalContext,
+	chatClient chat1.LocalInterface, conversation chat1.ConversationLocal,
+) (chat1.ConversationLocal, []chat1.MessageUnboxed, error) {
+	res, err := chatClient.GetReplyThreadLocal(ctx, chat1.GetReplyThreadLocalArg{
+		ConvID:           conversation.GetConvID(),
+		MsgID:            f.threadMsgID,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return chat1.ConversationLocal{}, nil, fmt.Errorf("GetReplyThreadLocal error: %s", err)
+	}
+
+	if res.Offline {
+		_, _ = g.UI.GetTerminalUI().PrintfUnescaped(ColorString(g, "yellow", "WARNING: thread results obtained in OFFLINE mode\n"))
+	}
+
+	// The thread comes oldest first, but conversations are rendered from
+	// newest first like the rest of the CLI.
+	messages := make([]chat1.MessageUnboxed, 0, len(res.Messages))
+	for i := len(res.Messages) - 1; i >= 0; i-- {
+		messages = append(messages, res.Messages[i])
+	}
+	return conversation, messages, nil
+}
+
 type chatCLIInboxFetcher struct {
 	query chat1.GetInboxSummaryForCLILocalQuery
 }
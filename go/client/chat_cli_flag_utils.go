@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/keybase/cli"
@@ -16,6 +17,7 @@ import (
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/chat1"
 	"github.com/keybase/client/go/protocol/keybase1"
+	isatty "github.com/mattn/go-isatty"
 )
 
 var chatFlags = map[string]cli.Flag{
@@ -75,6 +77,10 @@ var chatFlags = map[string]cli.Flag{
 		Name:  "show-device-name",
 		Usage: `Show device name next to author username`,
 	},
+	"no-markdown": cli.BoolFlag{
+		Name:  "no-markdown",
+		Usage: `Show messages as they were typed instead of rendering their markdown`,
+	},
 	"nonblock": cli.BoolFlag{
 		Name:  "nonblock",
 		Usage: `Send message without success confirmation`,
@@ -166,7 +172,14 @@ func getConversationResolverFlags() []cli.Flag {
 }
 
 func getMessageFetcherFlags() []cli.Flag {
-	return append(mustGetChatFlags("at-least", "at-most", "since", "show-device-name"), getConversationResolverFlags()...)
+	return append(mustGetChatFlags("at-least", "at-most", "since", "show-device-name", "no-markdown"),
+		getConversationResolverFlags()...)
+}
+
+// useChatMarkdown says whether to render the markdown of messages, which
+// needs a terminal that shows colors.
+func useChatMarkdown(g *libkb.GlobalContext, ctx *cli.Context) bool {
+	return !ctx.Bool("no-markdown") && isatty.IsTerminal(os.Stdout.Fd()) && HasColor(g)
 }
 
 func getInboxFetcherUnreadFirstFlags() []cli.Flag {
@@ -0,0 +1,162 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+var dndDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseDNDDay(s string) (int, error) {
+	s = strings.ToLower(s)
+	for i, name := range dndDayNames {
+		if s == name || s == strings.ToLower(time.Weekday(i).String()) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day: %q", s)
+}
+
+func parseDNDDays(s string) (res []int, err error) {
+	switch strings.ToLower(s) {
+	case "daily", "everyday":
+		return []int{0, 1, 2, 3, 4, 5, 6}, nil
+	case "weekdays":
+		return []int{1, 2, 3, 4, 5}, nil
+	case "weekends":
+		return []int{0, 6}, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := parseDNDDay(from)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseDNDDay(to); err != nil {
+				return nil, err
+			}
+		}
+		for day := start; ; day = (day + 1) % len(dndDayNames) {
+			res = append(res, day)
+			if day == end {
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+func parseDNDTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseDNDWindow parses a do-not-disturb window such as "mon-fri 22:00-07:00",
+// "weekends" or "sat,sun 09:00-12:00". A window without times lasts the whole
+// day, and one that ends before it starts runs past midnight.
+func parseDNDWindow(s string) (res chat1.ChatDNDWindow, err error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return res, fmt.Errorf("invalid window %q, expected <days> [HH:MM-HH:MM]", s)
+	}
+	if res.Weekdays, err = parseDNDDays(fields[0]); err != nil {
+		return res, err
+	}
+	if len(fields) == 1 {
+		return res, nil
+	}
+	start, end, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return res, fmt.Errorf("invalid window %q, expected <days> [HH:MM-HH:MM]", s)
+	}
+	if res.StartMinute, err = parseDNDTime(start); err != nil {
+		return res, err
+	}
+	if res.EndMinute, err = parseDNDTime(end); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func formatDNDDays(weekdays []int) string {
+	var days [7]bool
+	for _, day := range weekdays {
+		if day >= 0 && day < len(days) {
+			days[day] = true
+		}
+	}
+	var parts []string
+	for day := 0; day < len(days); day++ {
+		if !days[day] {
+			continue
+		}
+		end := day
+		for end+1 < len(days) && days[end+1] {
+			end++
+		}
+		switch {
+		case day == 0 && end == len(days)-1:
+			return "daily"
+		case end-day >= 2:
+			parts = append(parts, dndDayNames[day]+"-"+dndDayNames[end])
+			day = end
+		default:
+			parts = append(parts, dndDayNames[day])
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatDNDWindow(window chat1.ChatDNDWindow) string {
+	days := formatDNDDays(window.Weekdays)
+	if window.StartMinute == window.EndMinute {
+		return days
+	}
+	return fmt.Sprintf("%s %02d:%02d-%02d:%02d", days, window.StartMinute/60, window.StartMinute%60,
+		window.EndMinute/60, window.EndMinute%60)
+}
+
+// dndScheduleSummary describes a do-not-disturb schedule, with the names of
+// the conversations it allows.
+func dndScheduleSummary(ctx context.Context, chatClient chat1.LocalClient,
+	res chat1.GetChatDNDScheduleLocalRes,
+) (summary chat1.DNDScheduleSummary, err error) {
+	summary = chat1.DNDScheduleSummary{
+		Enabled:       res.Schedule.Enabled,
+		Active:        res.Active,
+		Timezone:      res.Schedule.Timezone,
+		Windows:       []string{},
+		AllowDMs:      res.Schedule.AllowDMs,
+		AllowChannels: []chat1.ChatChannel{},
+	}
+	for _, window := range res.Schedule.Windows {
+		summary.Windows = append(summary.Windows, formatDNDWindow(window))
+	}
+	if len(res.Schedule.AllowConvIDs) == 0 {
+		return summary, nil
+	}
+	ib, err := chatClient.GetInboxAndUnboxLocal(ctx, chat1.GetInboxAndUnboxLocalArg{
+		Query:            &chat1.GetInboxLocalQuery{ConvIDs: res.Schedule.AllowConvIDs},
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return summary, err
+	}
+	for _, conv := range ib.Conversations {
+		summary.AllowChannels = append(summary.AllowChannels, chatChannelFromConv(conv))
+	}
+	return summary, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDNDWindow(t *testing.T) {
+	cases := []struct {
+		input     string
+		expected  chat1.ChatDNDWindow
+		formatted string
+	}{
+		{"mon-fri 22:00-07:00", chat1.ChatDNDWindow{Weekdays: []int{1, 2, 3, 4, 5}, StartMinute: 22 * 60, EndMinute: 7 * 60}, "mon-fri 22:00-07:00"},
+		{"weekends", chat1.ChatDNDWindow{Weekdays: []int{0, 6}}, "sun,sat"},
+		{"Saturday,sun 09:30-12:00", chat1.ChatDNDWindow{Weekdays: []int{6, 0}, StartMinute: 9*60 + 30, EndMinute: 12 * 60}, "sun,sat 09:30-12:00"},
+		{"fri-mon", chat1.ChatDNDWindow{Weekdays: []int{5, 6, 0, 1}}, "sun,mon,fri,sat"},
+		{"daily 00:00-08:00", chat1.ChatDNDWindow{Weekdays: []int{0, 1, 2, 3, 4, 5, 6}, EndMinute: 8 * 60}, "daily 00:00-08:00"},
+	}
+	for _, c := range cases {
+		window, err := parseDNDWindow(c.input)
+		require.NoError(t, err, c.input)
+		require.Equal(t, c.expected, window, c.input)
+		require.Equal(t, c.formatted, formatDNDWindow(window), c.input)
+	}
+
+	for _, input := range []string{"", "someday", "mon 22:00", "mon 25:00-07:00", "mon-fri 22:00-07:00 extra"} {
+		_, err := parseDNDWindow(input)
+		require.Error(t, err, input)
+	}
+}
@@ -24,6 +24,8 @@ type ChatSendArg struct {
 	clearHeadline     bool
 	deleteHistory     *chat1.MessageDeleteHistory
 	ephemeralLifetime time.Duration
+	// sendAt schedules the message instead of sending it right away
+	sendAt *time.Time
 
 	hasTTY       bool
 	nonBlock     bool
@@ -114,6 +116,21 @@ func chatSend(ctx context.Context, g *libkb.GlobalContext, c ChatSendArg) error
 
 	arg.Msg = msg
 
+	if c.sendAt != nil {
+		res, err := resolver.ChatClient.ScheduleMessageLocal(ctx, chat1.ScheduleMessageLocalArg{
+			ConversationID:   arg.ConversationID,
+			Msg:              arg.Msg,
+			SendTime:         gregor1.ToTime(*c.sendAt),
+			IdentifyBehavior: arg.IdentifyBehavior,
+		})
+		if err != nil {
+			return err
+		}
+		g.UI.GetTerminalUI().Printf("Message scheduled for %s (ID: %s)\n",
+			res.SendTime.Time().Format(time.RFC1123), res.OutboxID)
+		return nil
+	}
+
 	if c.nonBlock {
 		var nbarg chat1.PostLocalNonblockArg
 		nbarg.ConversationID = arg.ConversationID
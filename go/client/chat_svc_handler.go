@@ -11,6 +11,7 @@ import (
 	"github.com/araddon/dateparse"
 	"github.com/keybase/client/go/chat"
 	"github.com/keybase/client/go/chat/attachments"
+	"github.com/keybase/client/go/chat/msgchecker"
 	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/chat1"
@@ -56,6 +57,22 @@ type ChatServiceHandler interface {
 	EmojiAddAliasV1(context.Context, emojiAddAliasOptionsV1) Reply
 	EmojiRemoveV1(context.Context, emojiRemoveOptionsV1) Reply
 	EmojiListV1(context.Context) Reply
+	ScheduleV1(context.Context, scheduleOptionsV1) Reply
+	ListScheduledV1(context.Context, listScheduledOptionsV1) Reply
+	CancelScheduledV1(context.Context, cancelScheduledOptionsV1) Reply
+	PollV1(context.Context, pollOptionsV1) Reply
+	PollVoteV1(context.Context, pollVoteOptionsV1) Reply
+	PollResultsV1(context.Context, pollResultsOptionsV1) Reply
+	InteractiveV1(context.Context, interactiveOptionsV1) Reply
+	InteractV1(context.Context, interactOptionsV1) Reply
+	GetHighlightsV1(context.Context) Reply
+	SetHighlightsV1(context.Context, setHighlightsOptionsV1) Reply
+	GetDNDV1(context.Context) Reply
+	SetDNDV1(context.Context, setDNDOptionsV1) Reply
+	GetThreadV1(context.Context, getThreadOptionsV1) Reply
+	AddBookmarkV1(context.Context, addBookmarkOptionsV1) Reply
+	ListBookmarksV1(context.Context) Reply
+	RemoveBookmarkV1(context.Context, removeBookmarkOptionsV1) Reply
 }
 
 // chatServiceHandler implements ChatServiceHandler.
@@ -544,6 +561,13 @@ func (c *chatServiceHandler) formatMessages(ctx context.Context, messages []chat
 			continue
 		}
 
+		if mv.MessageBody.IsType(chat1.MessageType_INTERACTIVEACTION) && !selfUID.IsNil() &&
+			mv.ClientHeader.Sender.String() != selfUID.String() &&
+			!utils.IsInteractiveActionFor(mv.MessageBody, selfUID.ToBytes()) {
+			// interactive actions are only for the bot and the user who clicked
+			continue
+		}
+
 		unread := mv.ServerHeader.MessageID > readMsgID
 		if unreadOnly && !unread {
 			continue
@@ -604,6 +628,9 @@ func (c *chatServiceHandler) formatMessages(ctx context.Context, messages []chat
 			uireact := c.reactionMapToUI(mv.Reactions)
 			msg.Reactions = &uireact
 		}
+		if mv.MessageBody.IsType(chat1.MessageType_POLL) {
+			msg.Content.Poll = c.pollWithVotes(mv)
+		}
 
 		ret = append(ret, chat1.Message{
 			Msg: &msg,
@@ -838,6 +865,7 @@ func (c *chatServiceHandler) AttachV1(ctx context.Context, opts attachOptionsV1,
 		Filename:          opts.Filename,
 		Title:             opts.Title,
 		EphemeralLifetime: ephemeralLifetime,
+		KeepMetadata:      opts.KeepMetadata,
 	}
 	// check for preview
 	if len(opts.Preview) > 0 {
@@ -1462,6 +1490,550 @@ func (c *chatServiceHandler) EmojiListV1(ctx context.Context) Reply {
 	return Reply{Result: res}
 }
 
+func (c *chatServiceHandler) ScheduleV1(ctx context.Context, opts scheduleOptionsV1) Reply {
+	sendTime, err := utils.ParseSendTimeExact(opts.At, c.G().Clock().Now())
+	if err != nil {
+		return c.errReply(err)
+	}
+	conv, _, err := c.findConversation(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	header, err := c.makePostHeader(ctx, sendArgV1{
+		channel:           opts.Channel,
+		mtype:             chat1.MessageType_TEXT,
+		ephemeralLifetime: opts.EphemeralLifetime,
+	}, []chat1.ConversationLocal{conv})
+	if err != nil {
+		return c.errReply(err)
+	}
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	res, err := chatClient.ScheduleMessageLocal(ctx, chat1.ScheduleMessageLocalArg{
+		ConversationID: header.conversationID,
+		Msg: chat1.MessagePlaintext{
+			ClientHeader: header.clientHeader,
+			MessageBody:  chat1.NewMessageBodyWithText(chat1.MessageText{Body: opts.Message.Body}),
+		},
+		SendTime:         gregor1.ToTime(sendTime),
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: c.scheduledMsgSummary(res, chatChannelFromConv(conv))}
+}
+
+func (c *chatServiceHandler) ListScheduledV1(ctx context.Context, opts listScheduledOptionsV1) Reply {
+	var convID *chat1.ConversationID
+	if !opts.Channel.IsNil() || len(opts.ConversationID) > 0 {
+		id, _, err := c.resolveAPIConvID(ctx, opts.ConversationID, opts.Channel)
+		if err != nil {
+			return c.errReply(err)
+		}
+		convID = &id
+	}
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	scheduled, err := chatClient.GetScheduledMessagesLocal(ctx, convID)
+	if err != nil {
+		return c.errReply(err)
+	}
+	res := chat1.ListScheduledRes{Scheduled: []chat1.ScheduledMsgSummary{}}
+	if len(scheduled) == 0 {
+		return Reply{Result: res}
+	}
+	seen := make(map[chat1.ConvIDStr]bool)
+	var convIDs []chat1.ConversationID
+	for _, s := range scheduled {
+		if !seen[s.ConvID.ConvIDStr()] {
+			seen[s.ConvID.ConvIDStr()] = true
+			convIDs = append(convIDs, s.ConvID)
+		}
+	}
+	channels := make(map[chat1.ConvIDStr]chat1.ChatChannel)
+	ib, err := chatClient.GetInboxAndUnboxLocal(ctx, chat1.GetInboxAndUnboxLocalArg{
+		Query:            &chat1.GetInboxLocalQuery{ConvIDs: convIDs},
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		c.G().Log.CDebugf(ctx, "ListScheduledV1: failed to load conversations: %s", err)
+	} else {
+		for _, conv := range ib.Conversations {
+			channels[conv.GetConvID().ConvIDStr()] = chatChannelFromConv(conv)
+		}
+	}
+	for _, s := range scheduled {
+		channel, ok := channels[s.ConvID.ConvIDStr()]
+		if !ok {
+			channel = chat1.ChatChannel{
+				Name:   s.Msg.ClientHeader.TlfName,
+				Public: s.Msg.ClientHeader.TlfPublic,
+			}
+		}
+		res.Scheduled = append(res.Scheduled, c.scheduledMsgSummary(s, channel))
+	}
+	return Reply{Result: res}
+}
+
+func (c *chatServiceHandler) CancelScheduledV1(ctx context.Context, opts cancelScheduledOptionsV1) Reply {
+	outboxID, err := chat1.MakeOutboxID(opts.ID)
+	if err != nil {
+		return c.errReply(fmt.Errorf("invalid scheduled message ID: %s", opts.ID))
+	}
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	if err := chatClient.CancelScheduledMessageLocal(ctx, outboxID); err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: chat1.EmptyRes{}}
+}
+
+// PollV1 implements ChatServiceHandler.PollV1.
+func (c *chatServiceHandler) PollV1(ctx context.Context, opts pollOptionsV1) Reply {
+	convID, err := chat1.MakeConvID(opts.ConversationID.String())
+	if err != nil {
+		return c.errReply(fmt.Errorf("invalid conv ID: %s", opts.ConversationID))
+	}
+	poll := chat1.MessagePoll{
+		Question:    strings.TrimSpace(opts.Question),
+		Options:     opts.Options,
+		MultiChoice: opts.MultiChoice,
+		Anonymous:   opts.Anonymous,
+	}
+	if len(opts.Closes) > 0 {
+		closeTime, err := utils.ParseSendTimeExact(opts.Closes, time.Now())
+		if err != nil {
+			return c.errReply(err)
+		}
+		gtime := gregor1.ToTime(closeTime)
+		poll.CloseTime = &gtime
+	}
+	body := chat1.NewMessageBodyWithPoll(poll)
+	if err := msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: body}); err != nil {
+		return c.errReply(err)
+	}
+	arg := sendArgV1{
+		conversationID: convID,
+		channel:        opts.Channel,
+		body:           body,
+		mtype:          chat1.MessageType_POLL,
+		response:       "poll started",
+	}
+	return c.sendV1(ctx, arg, utils.DummyChatUI{})
+}
+
+// PollVoteV1 implements ChatServiceHandler.PollVoteV1.
+func (c *chatServiceHandler) PollVoteV1(ctx context.Context, opts pollVoteOptionsV1) Reply {
+	conv, _, err := c.findConversation(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	poll, err := c.getPollMessage(ctx, conv, opts.MessageID)
+	if err != nil {
+		return c.errReply(err)
+	}
+	if res := utils.PresentPoll(poll, time.Now()); res.Closed {
+		return c.errReply(errors.New("poll is closed"))
+	}
+	body := chat1.NewMessageBodyWithPollvote(chat1.MessagePollVote{
+		MessageID: opts.MessageID,
+		Choices:   opts.Choices,
+	})
+	if err := msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: body}); err != nil {
+		return c.errReply(err)
+	}
+	if !poll.MessageBody.Poll().MultiChoice && len(opts.Choices) > 1 {
+		return c.errReply(errors.New("poll only allows a single choice"))
+	}
+	for _, choice := range opts.Choices {
+		if choice >= len(poll.MessageBody.Poll().Options) {
+			return c.errReply(fmt.Errorf("invalid choice: %d", choice))
+		}
+	}
+	arg := sendArgV1{
+		conversationID: conv.Info.Id,
+		channel:        opts.Channel,
+		body:           body,
+		mtype:          chat1.MessageType_POLLVOTE,
+		supersedes:     opts.MessageID,
+		response:       "vote recorded",
+	}
+	return c.sendV1(ctx, arg, utils.DummyChatUI{})
+}
+
+// PollResultsV1 implements ChatServiceHandler.PollResultsV1.
+func (c *chatServiceHandler) PollResultsV1(ctx context.Context, opts pollResultsOptionsV1) Reply {
+	conv, rlimits, err := c.findConversation(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	poll, err := c.getPollMessage(ctx, conv, opts.MessageID)
+	if err != nil {
+		return c.errReply(err)
+	}
+	res := chat1.PollResultsRes{
+		MessageID:  opts.MessageID,
+		Poll:       utils.PresentPoll(poll, time.Now()),
+		RateLimits: c.aggRateLimits(rlimits),
+	}
+	return Reply{Result: res}
+}
+
+// InteractiveV1 implements ChatServiceHandler.InteractiveV1.
+func (c *chatServiceHandler) InteractiveV1(ctx context.Context, opts interactiveOptionsV1) Reply {
+	msg, err := utils.ImportInteractive(chat1.MsgInteractiveContent{
+		Body:     opts.Body,
+		Elements: opts.Elements,
+	})
+	if err != nil {
+		return c.errReply(err)
+	}
+	body := chat1.NewMessageBodyWithInteractive(msg)
+	if err := msgchecker.CheckMessagePlaintext(chat1.MessagePlaintext{MessageBody: body}); err != nil {
+		return c.errReply(err)
+	}
+	convID, err := chat1.MakeConvID(opts.ConversationID.String())
+	if err != nil {
+		return c.errReply(fmt.Errorf("invalid conv ID: %s", opts.ConversationID))
+	}
+	arg := sendArgV1{
+		conversationID: convID,
+		channel:        opts.Channel,
+		body:           body,
+		mtype:          chat1.MessageType_INTERACTIVE,
+		response:       "message sent",
+	}
+	return c.sendV1(ctx, arg, utils.DummyChatUI{})
+}
+
+// InteractV1 implements ChatServiceHandler.InteractV1.
+func (c *chatServiceHandler) InteractV1(ctx context.Context, opts interactOptionsV1) Reply {
+	conv, _, err := c.findConversation(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	msg, err := c.getValidMessage(ctx, conv, opts.MessageID)
+	if err != nil {
+		return c.errReply(err)
+	}
+	if !msg.MessageBody.IsType(chat1.MessageType_INTERACTIVE) {
+		return c.errReply(fmt.Errorf("message %d is not an interactive message", opts.MessageID))
+	}
+	elementID, payload := opts.ElementID, opts.Payload
+	if opts.Choice > 0 {
+		choices := msg.MessageBody.Interactive().Choices()
+		if opts.Choice > len(choices) {
+			return c.errReply(fmt.Errorf("invalid choice: %d", opts.Choice))
+		}
+		elementID, payload = choices[opts.Choice-1].ElementID, choices[opts.Choice-1].Payload
+	}
+	action, err := utils.NewInteractiveAction(msg, elementID, payload)
+	if err != nil {
+		return c.errReply(err)
+	}
+	arg := sendArgV1{
+		conversationID: conv.Info.Id,
+		channel:        opts.Channel,
+		body:           chat1.NewMessageBodyWithInteractiveaction(action),
+		mtype:          chat1.MessageType_INTERACTIVEACTION,
+		response:       "action sent",
+	}
+	return c.sendV1(ctx, arg, utils.DummyChatUI{})
+}
+
+// GetHighlightsV1 implements ChatServiceHandler.GetHighlightsV1.
+func (c *chatServiceHandler) GetHighlightsV1(ctx context.Context) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	res, err := client.GetChatHighlightsLocal(ctx)
+	if err != nil {
+		return c.errReply(err)
+	}
+	if res.Keywords == nil {
+		res.Keywords = []string{}
+	}
+	if res.Regexes == nil {
+		res.Regexes = []string{}
+	}
+	return Reply{Result: res}
+}
+
+// SetHighlightsV1 implements ChatServiceHandler.SetHighlightsV1.
+func (c *chatServiceHandler) SetHighlightsV1(ctx context.Context, opts setHighlightsOptionsV1) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	if err := client.SetChatHighlightsLocal(ctx, chat1.ChatHighlights{
+		Keywords: opts.Keywords,
+		Regexes:  opts.Regexes,
+	}); err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: true}
+}
+
+// GetDNDV1 implements ChatServiceHandler.GetDNDV1.
+func (c *chatServiceHandler) GetDNDV1(ctx context.Context) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	res, err := client.GetChatDNDScheduleLocal(ctx)
+	if err != nil {
+		return c.errReply(err)
+	}
+	summary, err := dndScheduleSummary(ctx, client, res)
+	if err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: summary}
+}
+
+// SetDNDV1 implements ChatServiceHandler.SetDNDV1.
+func (c *chatServiceHandler) SetDNDV1(ctx context.Context, opts setDNDOptionsV1) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	schedule := chat1.ChatDNDSchedule{
+		Enabled:  opts.Enabled,
+		Timezone: opts.Timezone,
+		AllowDMs: opts.AllowDMs,
+	}
+	for _, window := range opts.Windows {
+		parsed, err := parseDNDWindow(window)
+		if err != nil {
+			return c.errReply(err)
+		}
+		schedule.Windows = append(schedule.Windows, parsed)
+	}
+	for _, channel := range opts.AllowChannels {
+		convID, _, err := c.resolveAPIConvID(ctx, "", channel)
+		if err != nil {
+			return c.errReply(err)
+		}
+		schedule.AllowConvIDs = append(schedule.AllowConvIDs, convID)
+	}
+	if err := client.SetChatDNDScheduleLocal(ctx, schedule); err != nil {
+		return c.errReply(err)
+	}
+	return c.GetDNDV1(ctx)
+}
+
+// GetThreadV1 implements ChatServiceHandler.GetThreadV1.
+func (c *chatServiceHandler) GetThreadV1(ctx context.Context, opts getThreadOptionsV1) Reply {
+	var rlimits []chat1.RateLimit
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+
+	conv, rlimits, err := c.findConversation(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+
+	res, err := client.GetReplyThreadLocal(ctx, chat1.GetReplyThreadLocalArg{
+		ConvID:           conv.Info.Id,
+		MsgID:            opts.MessageID,
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return c.errReply(err)
+	}
+	rlimits = append(rlimits, res.RateLimits...)
+
+	// Check to see if this was fetched offline and we should fail
+	if opts.FailOffline && res.Offline {
+		return c.errReply(chat.OfflineError{})
+	}
+
+	selfUID := c.G().Env.GetUID()
+	if selfUID.IsNil() {
+		c.G().Log.Warning("Could not get self UID for api")
+	}
+
+	// The root comes first, followed by the replies oldest first.
+	messages, err := c.formatMessages(ctx, res.Messages, conv, selfUID, conv.ReaderInfo.ReadMsgid, false /* unreadOnly */)
+	if err != nil {
+		return c.errReply(err)
+	}
+
+	thread := chat1.Thread{
+		Offline:          res.Offline,
+		IdentifyFailures: res.IdentifyFailures,
+		Messages:         messages,
+	}
+	thread.RateLimits = c.aggRateLimits(rlimits)
+	return Reply{Result: thread}
+}
+
+// AddBookmarkV1 implements ChatServiceHandler.AddBookmarkV1.
+func (c *chatServiceHandler) AddBookmarkV1(ctx context.Context, opts addBookmarkOptionsV1) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	convID, rlimits, err := c.resolveAPIConvID(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	if err := client.AddChatBookmarkLocal(ctx, chat1.AddChatBookmarkLocalArg{
+		ConvID: convID,
+		MsgID:  opts.MessageID,
+		Note:   opts.Note,
+	}); err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: chat1.EmptyRes{RateLimits: c.aggRateLimits(rlimits)}}
+}
+
+// ListBookmarksV1 implements ChatServiceHandler.ListBookmarksV1.
+func (c *chatServiceHandler) ListBookmarksV1(ctx context.Context) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	res, err := client.GetChatBookmarksLocal(ctx, keybase1.TLFIdentifyBehavior_CHAT_CLI)
+	if err != nil {
+		return c.errReply(err)
+	}
+	convs, err := loadBookmarkConvs(ctx, client, res.Bookmarks)
+	if err != nil {
+		return c.errReply(err)
+	}
+	selfUID := c.G().Env.GetUID()
+	if selfUID.IsNil() {
+		c.G().Log.Warning("Could not get self UID for api")
+	}
+
+	list := chat1.BookmarkList{
+		Bookmarks:        []chat1.BookmarkSummary{},
+		Offline:          res.Offline,
+		IdentifyFailures: res.IdentifyFailures,
+	}
+	for _, b := range res.Bookmarks {
+		summary := chat1.BookmarkSummary{
+			ConvID:         b.Bookmark.ConvID.ConvIDStr(),
+			MsgID:          b.Bookmark.MsgID,
+			Note:           b.Bookmark.Note,
+			BookmarkedAtMs: b.Bookmark.Ctime.UnixMilliseconds(),
+		}
+		conv, ok := convs[summary.ConvID]
+		if ok {
+			summary.Channel = chatChannelFromConv(conv)
+		}
+		if ok && b.Message != nil {
+			messages, err := c.formatMessages(ctx, []chat1.MessageUnboxed{*b.Message}, conv, selfUID,
+				conv.ReaderInfo.ReadMsgid, false /* unreadOnly */)
+			if err != nil {
+				return c.errReply(err)
+			}
+			if len(messages) > 0 {
+				summary.Msg = messages[0].Msg
+				summary.Error = messages[0].Error
+			}
+		}
+		if summary.Msg == nil && summary.Error == nil {
+			errMsg := "message unavailable"
+			summary.Error = &errMsg
+		}
+		list.Bookmarks = append(list.Bookmarks, summary)
+	}
+	list.RateLimits = c.aggRateLimits(res.RateLimits)
+	return Reply{Result: list}
+}
+
+// RemoveBookmarkV1 implements ChatServiceHandler.RemoveBookmarkV1.
+func (c *chatServiceHandler) RemoveBookmarkV1(ctx context.Context, opts removeBookmarkOptionsV1) Reply {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return c.errReply(err)
+	}
+	convID, rlimits, err := c.resolveAPIConvID(ctx, opts.ConversationID, opts.Channel)
+	if err != nil {
+		return c.errReply(err)
+	}
+	if err := client.RemoveChatBookmarkLocal(ctx, chat1.RemoveChatBookmarkLocalArg{
+		ConvID: convID,
+		MsgID:  opts.MessageID,
+	}); err != nil {
+		return c.errReply(err)
+	}
+	return Reply{Result: chat1.EmptyRes{RateLimits: c.aggRateLimits(rlimits)}}
+}
+
+func (c *chatServiceHandler) getPollMessage(ctx context.Context, conv chat1.ConversationLocal,
+	msgID chat1.MessageID,
+) (res chat1.MessageUnboxedValid, err error) {
+	if res, err = c.getValidMessage(ctx, conv, msgID); err != nil {
+		return res, err
+	}
+	if !res.MessageBody.IsType(chat1.MessageType_POLL) {
+		return res, fmt.Errorf("message %d is not a poll", msgID)
+	}
+	return res, nil
+}
+
+func (c *chatServiceHandler) getValidMessage(ctx context.Context, conv chat1.ConversationLocal,
+	msgID chat1.MessageID,
+) (res chat1.MessageUnboxedValid, err error) {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return res, err
+	}
+	msgs, err := client.GetMessagesLocal(ctx, chat1.GetMessagesLocalArg{
+		ConversationID:   conv.Info.Id,
+		MessageIDs:       []chat1.MessageID{msgID},
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return res, err
+	}
+	if len(msgs.Messages) != 1 || !msgs.Messages[0].IsValid() {
+		return res, fmt.Errorf("message %d not found", msgID)
+	}
+	return msgs.Messages[0].Valid(), nil
+}
+
+func (c *chatServiceHandler) scheduledMsgSummary(s chat1.ScheduledMessage, channel chat1.ChatChannel) chat1.ScheduledMsgSummary {
+	res := chat1.ScheduledMsgSummary{
+		Id:       s.OutboxID.String(),
+		ConvID:   s.ConvID.ConvIDStr(),
+		Channel:  channel,
+		SendAt:   int64(s.SendTime.UnixSeconds()),
+		SendAtMs: int64(s.SendTime),
+	}
+	if s.Msg.MessageBody.IsType(chat1.MessageType_TEXT) {
+		res.Body = s.Msg.MessageBody.Text().Body
+	}
+	if md := s.Msg.ClientHeader.EphemeralMetadata; md != nil {
+		lifetime := md.Lifetime.ToDuration().String()
+		res.ExplodingLifetime = &lifetime
+	}
+	return res
+}
+
+func chatChannelFromConv(conv chat1.ConversationLocal) chat1.ChatChannel {
+	return chat1.ChatChannel{
+		Name:        conv.Info.TlfName,
+		Public:      conv.Info.Visibility == keybase1.TLFVisibility_PUBLIC,
+		TopicType:   strings.ToLower(conv.Info.Triple.TopicType.String()),
+		MembersType: strings.ToLower(conv.GetMembersType().String()),
+		TopicName:   conv.Info.TopicName,
+	}
+}
+
 type postHeader struct {
 	conversationID chat1.ConversationID
 	clientHeader   chat1.MessageClientHeader
@@ -1609,72 +2181,14 @@ func (c *chatServiceHandler) getExistingConvs(ctx context.Context, convID chat1.
 	return findRes.Conversations, findRes.RateLimits, nil
 }
 
-func (c *chatServiceHandler) displayFlipBody(flip *chat1.MessageFlip) (res *chat1.MsgFlipContent) {
-	if flip == nil {
-		return res
-	}
-	res = new(chat1.MsgFlipContent)
-	res.GameID = flip.GameID.FlipGameIDStr()
-	res.FlipConvID = flip.FlipConvID.ConvIDStr()
-	res.TeamMentions = flip.TeamMentions
-	res.UserMentions = flip.UserMentions
-	res.Text = flip.Text
-	return res
-}
-
-func (*chatServiceHandler) displayTextBody(text *chat1.MessageText) (res *chat1.MsgTextContent) {
-	if text == nil {
-		return res
-	}
-	res = new(chat1.MsgTextContent)
-	res.Body = text.Body
-	res.Payments = text.Payments
-	res.ReplyTo = text.ReplyTo
-	var strReplyToUID *string
-	if text.ReplyToUID != nil {
-		strReplyToUID = new(string)
-		*strReplyToUID = text.ReplyToUID.String()
-	}
-	res.UserMentions = text.UserMentions
-	res.TeamMentions = text.TeamMentions
-	res.LiveLocation = text.LiveLocation
-	for _, emoji := range text.Emojis {
-		var convIDStr *chat1.ConvIDStr
-		var msgID *chat1.MessageID
-		if emoji.Source.IsMessage() {
-			convIDStr = new(chat1.ConvIDStr)
-			msgID = new(chat1.MessageID)
-			*convIDStr = emoji.Source.Message().ConvID.ConvIDStr()
-			*msgID = emoji.Source.Message().MsgID
-		}
-		res.Emojis = append(res.Emojis, chat1.EmojiContent{
-			Alias:       emoji.Alias,
-			IsCrossTeam: emoji.IsCrossTeam,
-			ConvID:      convIDStr,
-			MessageID:   msgID,
-		})
-	}
-	return res
+func (c *chatServiceHandler) pollWithVotes(mv chat1.MessageUnboxedValid) *chat1.MsgPollContent {
+	res := utils.PresentPoll(mv, time.Now())
+	return &res
 }
 
 // need this to get message type name
 func (c *chatServiceHandler) convertMsgBody(mb chat1.MessageBody) chat1.MsgContent {
-	return chat1.MsgContent{
-		TypeName:           strings.ToLower(chat1.MessageTypeRevMap[mb.MessageType__]),
-		Text:               c.displayTextBody(mb.Text__),
-		Attachment:         mb.Attachment__,
-		Edit:               mb.Edit__,
-		Reaction:           mb.Reaction__,
-		Delete:             mb.Delete__,
-		Metadata:           mb.Metadata__,
-		Headline:           mb.Headline__,
-		AttachmentUploaded: mb.Attachmentuploaded__,
-		System:             mb.System__,
-		SendPayment:        mb.Sendpayment__,
-		RequestPayment:     mb.Requestpayment__,
-		Unfurl:             mb.Unfurl__,
-		Flip:               c.displayFlipBody(mb.Flip__),
-	}
+	return utils.ExportMsgContent(mb)
 }
 
 func (c *chatServiceHandler) errReply(err error) Reply {
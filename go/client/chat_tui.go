@@ -0,0 +1,713 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/keybase/client/go/chatrender"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/terminalescaper"
+	"github.com/kyokomi/emoji"
+)
+
+// chatTUIBackend is what the terminal chat client needs from the service.
+type chatTUIBackend interface {
+	Inbox(ctx context.Context) ([]chat1.ConversationLocal, error)
+	Thread(ctx context.Context, conv chat1.ConversationLocal) (chat1.ConversationLocal, []chat1.MessageUnboxed, error)
+	Send(ctx context.Context, convID chat1.ConversationID, body string) error
+	Edit(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, body string) error
+	React(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, reaction string) error
+	Delete(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID) error
+	Upload(ctx context.Context, convID chat1.ConversationID, filename, title string) error
+	Download(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, output string) error
+}
+
+const (
+	chatTUIMaxCompletions = 8
+	chatTUIHelp           = "/react <id> <:emoji:>, /edit <id> <text>, /delete <id>, /upload <file> [title], " +
+		"/download <id> [file], /quit. Up/Down: conversation, PgUp/PgDn: scroll, Tab: complete"
+)
+
+// chatTUIScreen is a rendered frame of the terminal chat client.
+type chatTUIScreen struct {
+	lines []string
+	// inboxWidth is the width of the inbox pane on the left of each line
+	// between the title and the status line
+	inboxWidth int
+	// selectedRow is the line of the selected conversation, or -1
+	selectedRow int
+}
+
+// chatTUI is the state of the terminal chat client. It is only used from one
+// goroutine at a time, which feeds it keys and chat notifications and draws
+// the screen it renders.
+type chatTUI struct {
+	libkb.Contextified
+	backend chatTUIBackend
+	// events is where background work reports back to that goroutine
+	events   chan<- chatTUIEvent
+	username string
+	width    int
+	height   int
+
+	convs    []chat1.ConversationLocal
+	selected int
+	conv     *chat1.ConversationLocal
+	messages []chat1.MessageUnboxed
+	// scroll is how many lines the thread is scrolled up from its bottom
+	scroll int
+
+	input []rune
+	// completions cycle with Tab and replace the input from completionStart
+	completions     []string
+	completion      int
+	completionStart int
+
+	status string
+	typing map[chat1.ConvIDStr][]string
+	quit   bool
+
+	// transfers is the number of uploads and downloads still running
+	transfers int
+}
+
+func newChatTUI(g *libkb.GlobalContext, backend chatTUIBackend, events chan<- chatTUIEvent, username string,
+	width, height int,
+) *chatTUI {
+	return &chatTUI{
+		Contextified: libkb.NewContextified(g),
+		backend:      backend,
+		events:       events,
+		username:     username,
+		width:        width,
+		height:       height,
+		typing:       make(map[chat1.ConvIDStr][]string),
+	}
+}
+
+func (t *chatTUI) resize(width, height int) {
+	t.width, t.height = width, height
+}
+
+func (t *chatTUI) setError(err error) {
+	t.status = fmt.Sprintf("error: %s", err)
+}
+
+// refreshInbox reloads the inbox, keeping the selection on the same
+// conversation.
+func (t *chatTUI) refreshInbox(ctx context.Context) error {
+	convs, err := t.backend.Inbox(ctx)
+	if err != nil {
+		return err
+	}
+	t.convs = convs
+	t.selected = 0
+	if t.conv != nil {
+		for i, conv := range convs {
+			if conv.GetConvID().Eq(t.conv.GetConvID()) {
+				t.selected = i
+			}
+		}
+	}
+	return nil
+}
+
+// open shows the conversation at index of the inbox.
+func (t *chatTUI) open(ctx context.Context, index int) error {
+	if index < 0 || index >= len(t.convs) {
+		return nil
+	}
+	t.selected = index
+	conv := t.convs[index]
+	t.conv = &conv
+	t.messages = nil
+	t.scroll = 0
+	return t.refreshThread(ctx)
+}
+
+// openConv shows conv, which does not have to be in the inbox yet.
+func (t *chatTUI) openConv(ctx context.Context, conv chat1.ConversationLocal) error {
+	for i, c := range t.convs {
+		if c.GetConvID().Eq(conv.GetConvID()) {
+			return t.open(ctx, i)
+		}
+	}
+	t.convs = append([]chat1.ConversationLocal{conv}, t.convs...)
+	return t.open(ctx, 0)
+}
+
+func (t *chatTUI) refreshThread(ctx context.Context) error {
+	if t.conv == nil {
+		return nil
+	}
+	conv, messages, err := t.backend.Thread(ctx, *t.conv)
+	if err != nil {
+		return err
+	}
+	t.conv = &conv
+	t.messages = messages
+	if t.selected < len(t.convs) && t.convs[t.selected].GetConvID().Eq(conv.GetConvID()) {
+		t.convs[t.selected] = conv
+	}
+	return nil
+}
+
+// onActivity updates the screen for chat activity in convID, which is nil for
+// activity that is not about a single conversation.
+func (t *chatTUI) onActivity(ctx context.Context, convID *chat1.ConversationID) error {
+	if err := t.refreshInbox(ctx); err != nil {
+		return err
+	}
+	if convID != nil && t.conv != nil && convID.Eq(t.conv.GetConvID()) {
+		return t.refreshThread(ctx)
+	}
+	return nil
+}
+
+func (t *chatTUI) onTyping(updates []chat1.ConvTypingUpdate) {
+	for _, update := range updates {
+		var typers []string
+		for _, typer := range update.Typers {
+			if typer.Username != t.username {
+				typers = append(typers, typer.Username)
+			}
+		}
+		t.typing[update.ConvID.ConvIDStr()] = typers
+	}
+}
+
+func (t *chatTUI) onFailedMessage(info chat1.FailedMessageInfo) {
+	for _, obr := range info.OutboxRecords {
+		if t.conv == nil || !obr.ConvID.Eq(t.conv.GetConvID()) {
+			continue
+		}
+		if st, err := obr.State.State(); err == nil && st == chat1.OutboxStateType_ERROR {
+			t.status = fmt.Sprintf("message failed to send: %s", obr.State.Error().Message)
+		}
+	}
+}
+
+func (t *chatTUI) threadHeight() int {
+	return t.height - 3
+}
+
+func (t *chatTUI) handleKey(ctx context.Context, k tuiKey) {
+	if k.code != tuiKeyTab {
+		t.completions = nil
+	}
+	switch k.code {
+	case tuiKeyRune:
+		t.input = append(t.input, k.r)
+	case tuiKeyBackspace:
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+		}
+	case tuiKeyCtrlU:
+		t.input = nil
+	case tuiKeyCtrlW:
+		trimmed := strings.TrimRight(string(t.input), " ")
+		t.input = []rune(trimmed[:strings.LastIndex(trimmed, " ")+1])
+	case tuiKeyTab:
+		t.complete()
+	case tuiKeyEsc:
+		t.status = ""
+	case tuiKeyUp:
+		if err := t.open(ctx, t.selected-1); err != nil {
+			t.setError(err)
+		}
+	case tuiKeyDown:
+		if err := t.open(ctx, t.selected+1); err != nil {
+			t.setError(err)
+		}
+	case tuiKeyPgUp:
+		t.scroll += max(1, t.threadHeight()/2)
+	case tuiKeyPgDn:
+		t.scroll = max(0, t.scroll-max(1, t.threadHeight()/2))
+	case tuiKeyEnter:
+		text := strings.TrimSpace(string(t.input))
+		if len(text) == 0 {
+			return
+		}
+		t.input = nil
+		t.status = ""
+		if err := t.submit(ctx, text); err != nil {
+			t.setError(err)
+		}
+	case tuiKeyCtrlD:
+		if len(t.input) == 0 {
+			t.quit = true
+		}
+	case tuiKeyCtrlC:
+		t.quit = true
+	}
+}
+
+// submit sends text to the open conversation, or runs it if it is one of the
+// commands of the client. Text starting with "//" is sent with a single slash.
+func (t *chatTUI) submit(ctx context.Context, text string) error {
+	if strings.HasPrefix(text, "//") {
+		return t.send(ctx, text[1:])
+	}
+	if !strings.HasPrefix(text, "/") {
+		return t.send(ctx, text)
+	}
+	fields := strings.Fields(text)
+	cmd, args := fields[0], fields[1:]
+	rest := func(n int) string {
+		// the text after the command and its first n arguments
+		s := strings.TrimSpace(strings.TrimPrefix(text, cmd))
+		for i := 0; i < n; i++ {
+			s = strings.TrimSpace(strings.TrimPrefix(s, args[i]))
+		}
+		return s
+	}
+	switch cmd {
+	case "/quit", "/exit":
+		t.quit = true
+		return nil
+	case "/help":
+		t.status = chatTUIHelp
+		return nil
+	}
+	if t.conv == nil {
+		return errors.New("no conversation selected")
+	}
+	convID := t.conv.GetConvID()
+	msgID := func() (chat1.MessageID, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("usage: %s <message id>", cmd)
+		}
+		id, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil || id == 0 {
+			return 0, fmt.Errorf("invalid message ID: %s", args[0])
+		}
+		return chat1.MessageID(id), nil
+	}
+	var err error
+	switch cmd {
+	case "/react":
+		var id chat1.MessageID
+		if id, err = msgID(); err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return errors.New("usage: /react <message id> <:emoji:>")
+		}
+		err = t.backend.React(ctx, convID, id, args[1])
+	case "/edit":
+		var id chat1.MessageID
+		if id, err = msgID(); err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			return errors.New("usage: /edit <message id> <text>")
+		}
+		err = t.backend.Edit(ctx, convID, id, rest(1))
+	case "/delete":
+		var id chat1.MessageID
+		if id, err = msgID(); err != nil {
+			return err
+		}
+		err = t.backend.Delete(ctx, convID, id)
+	case "/upload":
+		if len(args) == 0 {
+			return errors.New("usage: /upload <file> [title]")
+		}
+		filename, title := args[0], rest(1)
+		t.transfer(ctx, fmt.Sprintf("uploading %s...", filename), fmt.Sprintf("uploaded %s", filename),
+			func() error { return t.backend.Upload(ctx, convID, filename, title) })
+		return nil
+	case "/download":
+		var id chat1.MessageID
+		if id, err = msgID(); err != nil {
+			return err
+		}
+		output := rest(1)
+		if len(output) == 0 {
+			if output, err = t.attachmentFilename(id); err != nil {
+				return err
+			}
+		}
+		t.transfer(ctx, fmt.Sprintf("downloading to %s...", output), fmt.Sprintf("downloaded to %s", output),
+			func() error { return t.backend.Download(ctx, convID, id, output) })
+		return nil
+	default:
+		return fmt.Errorf("unknown command %s, try /help (start with // to send a message starting with /)", cmd)
+	}
+	if err != nil {
+		return err
+	}
+	return t.refreshThread(ctx)
+}
+
+// transfer runs an upload or download in the background, so keys and
+// notifications keep being handled while it goes, and reports back with an
+// event once it is done. run must not touch the state of t, and should stop
+// when ctx is canceled.
+func (t *chatTUI) transfer(ctx context.Context, running, done string, run func() error) {
+	t.status = running
+	t.transfers++
+	go func() {
+		err := run()
+		ev := func(ctx context.Context, t *chatTUI) error {
+			t.transfers--
+			if err != nil {
+				return err
+			}
+			t.status = done
+			return t.refreshThread(ctx)
+		}
+		select {
+		case t.events <- ev:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func (t *chatTUI) send(ctx context.Context, text string) error {
+	if t.conv == nil {
+		return errors.New("no conversation selected")
+	}
+	if err := t.backend.Send(ctx, t.conv.GetConvID(), text); err != nil {
+		return err
+	}
+	t.scroll = 0
+	return t.refreshThread(ctx)
+}
+
+// attachmentFilename is the name to download the attachment in message msgID
+// of the open conversation to, in the current directory.
+func (t *chatTUI) attachmentFilename(msgID chat1.MessageID) (string, error) {
+	for _, msg := range t.messages {
+		if !msg.IsValid() || msg.GetMessageID() != msgID {
+			continue
+		}
+		body := msg.Valid().MessageBody
+		if !body.IsType(chat1.MessageType_ATTACHMENT) {
+			break
+		}
+		name := filepath.Base(body.Attachment().Object.Filename)
+		if name == "." || name == "/" || name == ".." {
+			break
+		}
+		return name, nil
+	}
+	return "", fmt.Errorf("message %d is not a loaded attachment, give a file to download to", msgID)
+}
+
+// completionCandidates returns the completions of the word being typed, which
+// are usernames for @-mentions and emoji aliases for words starting with a
+// colon.
+func (t *chatTUI) completionCandidates(word string) (res []string) {
+	switch {
+	case strings.HasPrefix(word, "@"):
+		prefix := strings.ToLower(word[1:])
+		var names []string
+		if t.conv != nil {
+			for _, p := range t.conv.Info.Participants {
+				names = append(names, p.Username)
+			}
+			if t.conv.GetMembersType() == chat1.ConversationMembersType_TEAM {
+				names = append(names, "here", "channel")
+			}
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				res = append(res, "@"+name)
+			}
+		}
+	case strings.HasPrefix(word, ":") && len(word) >= 3:
+		for alias := range emoji.CodeMap() {
+			if strings.HasPrefix(alias, word) {
+				res = append(res, alias)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (t *chatTUI) complete() {
+	if len(t.completions) > 0 {
+		t.completion = (t.completion + 1) % len(t.completions)
+	} else {
+		text := string(t.input)
+		t.completionStart = strings.LastIndex(text, " ") + 1
+		t.completions = t.completionCandidates(text[t.completionStart:])
+		t.completion = 0
+		if len(t.completions) == 0 {
+			return
+		}
+	}
+	choice := t.completions[t.completion]
+	if len(t.completions) == 1 {
+		choice += " "
+	}
+	t.input = append([]rune(string(t.input)[:t.completionStart]), []rune(choice)...)
+}
+
+func (t *chatTUI) convName(conv chat1.ConversationLocal) string {
+	if conv.Error != nil {
+		return "(unverified) " + conv.Error.UnverifiedTLFName
+	}
+	return chatrender.ConvName(t.G(), conv, t.username)
+}
+
+// fitLine cuts s to width runes and pads it with spaces to width.
+func fitLine(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width])
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+func (t *chatTUI) statusLine() string {
+	switch {
+	case len(t.completions) > 1:
+		shown := t.completions
+		if len(shown) > chatTUIMaxCompletions {
+			shown = shown[:chatTUIMaxCompletions]
+		}
+		line := "Tab: " + strings.Join(shown, " ")
+		if len(t.completions) > len(shown) {
+			line += fmt.Sprintf(" (+%d)", len(t.completions)-len(shown))
+		}
+		return line
+	case len(t.status) > 0:
+		return t.status
+	case t.conv != nil && len(t.typing[t.conv.GetConvID().ConvIDStr()]) > 0:
+		return strings.Join(t.typing[t.conv.GetConvID().ConvIDStr()], ", ") + " typing..."
+	}
+	return "/help for commands, Ctrl-C to quit"
+}
+
+func (t *chatTUI) inboxLines(width, height int) (lines []string, selectedRow int) {
+	// keep the selected conversation in view
+	offset := 0
+	if t.selected >= height {
+		offset = t.selected - height + 1
+	}
+	selectedRow = -1
+	for i := offset; i < len(t.convs) && len(lines) < height; i++ {
+		conv := t.convs[i]
+		unread := " "
+		if conv.ReaderInfo.ReadMsgid < conv.ReaderInfo.MaxMsgid {
+			unread = "*"
+		}
+		if i == t.selected {
+			selectedRow = len(lines)
+		}
+		lines = append(lines, unread+terminalescaper.Clean(t.convName(conv)))
+	}
+	return lines, selectedRow
+}
+
+func (t *chatTUI) threadLines(width, height int) []string {
+	if t.conv == nil {
+		return []string{"No conversation open, pick one with Up/Down"}
+	}
+	view := chatrender.ConversationView{Conversation: *t.conv, Messages: t.messages}
+	lines := view.RenderLines(t.G(), width, false)
+	if len(lines) == 0 {
+		return []string{"No messages yet"}
+	}
+	for i, line := range lines {
+		lines[i] = terminalescaper.Clean(line)
+	}
+	maxScroll := max(0, len(lines)-height)
+	t.scroll = min(t.scroll, maxScroll)
+	end := len(lines) - t.scroll
+	return lines[max(0, end-height):end]
+}
+
+// render draws the client into lines of exactly width runes: a title, the
+// inbox and thread panes, a status line and the composer.
+func (t *chatTUI) render() (screen chatTUIScreen) {
+	width, height := max(t.width, 20), max(t.height, 5)
+	screen.selectedRow = -1
+
+	title := " keybase chat"
+	if t.conv != nil {
+		title += ": " + terminalescaper.Clean(t.convName(*t.conv))
+		if headline := t.conv.Info.Headline; len(headline) > 0 {
+			title += " - " + terminalescaper.Clean(headline)
+		}
+	}
+	screen.lines = append(screen.lines, fitLine(title, width))
+
+	paneHeight := height - 3
+	if width >= 60 {
+		screen.inboxWidth = min(32, width/4)
+	}
+	threadWidth := width
+	var inbox []string
+	var selectedRow int
+	if screen.inboxWidth > 0 {
+		threadWidth = width - screen.inboxWidth - 1
+		inbox, selectedRow = t.inboxLines(screen.inboxWidth, paneHeight)
+		if selectedRow >= 0 {
+			screen.selectedRow = selectedRow + 1
+		}
+	}
+	thread := t.threadLines(threadWidth-1, paneHeight)
+	// bottom align the thread like chat clients do
+	thread = append(make([]string, max(0, paneHeight-len(thread))), thread...)
+	for i := 0; i < paneHeight; i++ {
+		var line string
+		if screen.inboxWidth > 0 {
+			var item string
+			if i < len(inbox) {
+				item = inbox[i]
+			}
+			line = fitLine(item, screen.inboxWidth) + "|"
+		}
+		screen.lines = append(screen.lines, line+fitLine(" "+thread[i], threadWidth))
+	}
+
+	screen.lines = append(screen.lines, fitLine(terminalescaper.Clean(t.statusLine()), width))
+	// keep the end of long input in view
+	input := []rune(terminalescaper.Clean(string(t.input)))
+	if len(input) > width-3 {
+		input = input[len(input)-(width-3):]
+	}
+	screen.lines = append(screen.lines, fitLine("> "+string(input), width))
+	return screen
+}
+
+type tuiKeyCode int
+
+const (
+	tuiKeyRune tuiKeyCode = iota
+	tuiKeyEnter
+	tuiKeyBackspace
+	tuiKeyTab
+	tuiKeyEsc
+	tuiKeyUp
+	tuiKeyDown
+	tuiKeyPgUp
+	tuiKeyPgDn
+	tuiKeyCtrlC
+	tuiKeyCtrlD
+	tuiKeyCtrlU
+	tuiKeyCtrlW
+)
+
+type tuiKey struct {
+	code tuiKeyCode
+	r    rune
+}
+
+// tuiKeyDecoder turns terminal input into keys. Escape sequences and UTF-8
+// characters split across reads are kept until the rest arrives.
+type tuiKeyDecoder struct {
+	pending []byte
+}
+
+func (d *tuiKeyDecoder) feed(input []byte) (keys []tuiKey) {
+	b := append(d.pending, input...)
+	d.pending = nil
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			n, key, ok := decodeEscape(b)
+			if n == 0 {
+				d.pending = b
+				return keys
+			}
+			if ok {
+				keys = append(keys, key)
+			}
+			b = b[n:]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, tuiKey{code: tuiKeyEnter})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, tuiKey{code: tuiKeyBackspace})
+		case c == '\t':
+			keys = append(keys, tuiKey{code: tuiKeyTab})
+		case c == 0x03:
+			keys = append(keys, tuiKey{code: tuiKeyCtrlC})
+		case c == 0x04:
+			keys = append(keys, tuiKey{code: tuiKeyCtrlD})
+		case c == 0x15:
+			keys = append(keys, tuiKey{code: tuiKeyCtrlU})
+		case c == 0x17:
+			keys = append(keys, tuiKey{code: tuiKeyCtrlW})
+		case c == 0x10:
+			keys = append(keys, tuiKey{code: tuiKeyUp})
+		case c == 0x0e:
+			keys = append(keys, tuiKey{code: tuiKeyDown})
+		case c < 0x20:
+			// other control characters do nothing
+		default:
+			if !utf8.FullRune(b) {
+				d.pending = b
+				return keys
+			}
+			r, n := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				keys = append(keys, tuiKey{code: tuiKeyRune, r: r})
+			}
+			b = b[n:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// decodeEscape decodes the escape sequence at the start of b, returning how
+// many bytes it takes, or 0 if it is incomplete. Unknown sequences are
+// skipped. An escape that does not start a sequence is the Esc key.
+func decodeEscape(b []byte) (n int, key tuiKey, ok bool) {
+	if len(b) == 1 {
+		return 1, tuiKey{code: tuiKeyEsc}, true
+	}
+	switch b[1] {
+	case 'O':
+		// arrows in application cursor mode
+		if len(b) < 3 {
+			return 0, key, false
+		}
+		switch b[2] {
+		case 'A':
+			return 3, tuiKey{code: tuiKeyUp}, true
+		case 'B':
+			return 3, tuiKey{code: tuiKeyDown}, true
+		}
+		return 3, key, false
+	case '[':
+		for i := 2; i < len(b); i++ {
+			if b[i] < 0x40 || b[i] > 0x7e {
+				continue
+			}
+			switch string(b[2 : i+1]) {
+			case "A":
+				return i + 1, tuiKey{code: tuiKeyUp}, true
+			case "B":
+				return i + 1, tuiKey{code: tuiKeyDown}, true
+			case "5~":
+				return i + 1, tuiKey{code: tuiKeyPgUp}, true
+			case "6~":
+				return i + 1, tuiKey{code: tuiKeyPgDn}, true
+			}
+			return i + 1, key, false
+		}
+		return 0, key, false
+	}
+	return 1, tuiKey{code: tuiKeyEsc}, true
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/keybase/client/go/chat/utils"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+)
+
+const (
+	chatTUIInboxLimit  = 100
+	chatTUIThreadLimit = 200
+)
+
+// chatTUIRPCBackend talks to the service for the terminal chat client, with
+// the same RPCs as the one-shot chat commands.
+type chatTUIRPCBackend struct {
+	libkb.Contextified
+	chatClient chat1.LocalInterface
+	svc        *chatServiceHandler
+	// ui and notifications are quiet, since output would mess up the screen
+	ui            *ChatCLIUI
+	notifications *ChatCLINotifications
+}
+
+var _ chatTUIBackend = (*chatTUIRPCBackend)(nil)
+
+func newChatTUIRPCBackend(g *libkb.GlobalContext) (*chatTUIRPCBackend, error) {
+	chatClient, err := GetChatLocalClient(g)
+	if err != nil {
+		return nil, fmt.Errorf("Getting chat service client error: %s", err)
+	}
+	ui := NewChatCLIUI(g)
+	ui.noOutput = true
+	notifications := NewChatCLINotifications(g)
+	notifications.noOutput = true
+	return &chatTUIRPCBackend{
+		Contextified:  libkb.NewContextified(g),
+		chatClient:    chatClient,
+		svc:           newChatServiceHandler(g),
+		ui:            ui,
+		notifications: notifications,
+	}, nil
+}
+
+func (b *chatTUIRPCBackend) protocols() []rpc.Protocol {
+	return []rpc.Protocol{chat1.ChatUiProtocol(b.ui)}
+}
+
+func (b *chatTUIRPCBackend) Inbox(ctx context.Context) ([]chat1.ConversationLocal, error) {
+	res, err := b.chatClient.GetInboxSummaryForCLILocal(ctx, chat1.GetInboxSummaryForCLILocalQuery{
+		TopicType:           chat1.TopicType_CHAT,
+		Status:              utils.VisibleChatConversationStatuses(),
+		Visibility:          keybase1.TLFVisibility_ANY,
+		ActivitySortedLimit: chatTUIInboxLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Conversations, nil
+}
+
+func (b *chatTUIRPCBackend) Thread(ctx context.Context, conv chat1.ConversationLocal) (chat1.ConversationLocal, []chat1.MessageUnboxed, error) {
+	res, err := b.chatClient.GetConversationForCLILocal(ctx, chat1.GetConversationForCLILocalQuery{
+		Conv: conv,
+		MessageTypes: []chat1.MessageType{
+			chat1.MessageType_TEXT,
+			chat1.MessageType_ATTACHMENT,
+			chat1.MessageType_JOIN,
+			chat1.MessageType_LEAVE,
+			chat1.MessageType_SYSTEM,
+			chat1.MessageType_HEADLINE,
+			chat1.MessageType_SENDPAYMENT,
+			chat1.MessageType_REQUESTPAYMENT,
+		},
+		Limit: chat1.UnreadFirstNumLimit{
+			NumRead: chatTUIThreadLimit,
+			AtLeast: chatTUIThreadLimit,
+			AtMost:  chatTUIThreadLimit,
+		},
+		MarkAsRead: true,
+	})
+	if err != nil {
+		return conv, nil, err
+	}
+	return res.Conversation, res.Messages, nil
+}
+
+func replyError(reply Reply) error {
+	if reply.Error != nil {
+		return errors.New(reply.Error.Message)
+	}
+	return nil
+}
+
+func (b *chatTUIRPCBackend) Send(ctx context.Context, convID chat1.ConversationID, body string) error {
+	return replyError(b.svc.SendV1(ctx, sendOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		Message:        ChatMessage{Body: body},
+	}, b.ui))
+}
+
+func (b *chatTUIRPCBackend) Edit(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, body string) error {
+	return replyError(b.svc.EditV1(ctx, editOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		MessageID:      msgID,
+		Message:        ChatMessage{Body: body},
+	}))
+}
+
+func (b *chatTUIRPCBackend) React(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, reaction string) error {
+	return replyError(b.svc.ReactionV1(ctx, reactionOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		MessageID:      msgID,
+		Message:        ChatMessage{Body: reaction},
+	}))
+}
+
+func (b *chatTUIRPCBackend) Delete(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID) error {
+	return replyError(b.svc.DeleteV1(ctx, deleteOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		MessageID:      msgID,
+	}))
+}
+
+func (b *chatTUIRPCBackend) Upload(ctx context.Context, convID chat1.ConversationID, filename, title string) error {
+	return replyError(b.svc.AttachV1(ctx, attachOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		Filename:       filename,
+		Title:          title,
+	}, b.ui, b.notifications))
+}
+
+func (b *chatTUIRPCBackend) Download(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, output string) error {
+	return replyError(b.svc.DownloadV1(ctx, downloadOptionsV1{
+		ConversationID: convID.ConvIDStr(),
+		MessageID:      msgID,
+		Output:         output,
+	}, b.ui, b.notifications))
+}
+
+// chatTUIEvent is a change to apply to the terminal chat client from its
+// event loop.
+type chatTUIEvent func(ctx context.Context, t *chatTUI) error
+
+// chatTUINotifications turns chat notifications into events for the terminal
+// chat client. Notifications it does not need are ignored like in api-listen.
+type chatTUINotifications struct {
+	*chatNotificationDisplay
+	events chan<- chatTUIEvent
+}
+
+func newChatTUINotifications(g *libkb.GlobalContext, events chan<- chatTUIEvent) *chatTUINotifications {
+	return &chatTUINotifications{
+		chatNotificationDisplay: newChatNotificationDisplay(g, chatNotificationConfig{}),
+		events:                  events,
+	}
+}
+
+// chatActivityConvID is the conversation chat activity is about, or nil when
+// there is not a single one.
+func chatActivityConvID(activity chat1.ChatActivity) *chat1.ConversationID {
+	typ, err := activity.ActivityType()
+	if err != nil {
+		return nil
+	}
+	var convID chat1.ConversationID
+	switch typ {
+	case chat1.ChatActivityType_INCOMING_MESSAGE:
+		convID = activity.IncomingMessage().ConvID
+	case chat1.ChatActivityType_REACTION_UPDATE:
+		convID = activity.ReactionUpdate().ConvID
+	case chat1.ChatActivityType_MESSAGES_UPDATED:
+		convID = activity.MessagesUpdated().ConvID
+	case chat1.ChatActivityType_EXPUNGE:
+		convID = activity.Expunge().ConvID
+	case chat1.ChatActivityType_EPHEMERAL_PURGE:
+		convID = activity.EphemeralPurge().ConvID
+	default:
+		// Read markers and status changes only show in the inbox. Refreshing
+		// the thread for them would mark it as read again.
+		return nil
+	}
+	return &convID
+}
+
+func (n *chatTUINotifications) NewChatActivity(ctx context.Context, arg chat1.NewChatActivityArg) error {
+	if typ, err := arg.Activity.ActivityType(); err == nil && typ == chat1.ChatActivityType_FAILED_MESSAGE {
+		info := arg.Activity.FailedMessage()
+		n.events <- func(ctx context.Context, t *chatTUI) error {
+			t.onFailedMessage(info)
+			return nil
+		}
+		return nil
+	}
+	convID := chatActivityConvID(arg.Activity)
+	n.events <- func(ctx context.Context, t *chatTUI) error {
+		return t.onActivity(ctx, convID)
+	}
+	return nil
+}
+
+func (n *chatTUINotifications) ChatTypingUpdate(ctx context.Context, updates []chat1.ConvTypingUpdate) error {
+	n.events <- func(ctx context.Context, t *chatTUI) error {
+		t.onTyping(updates)
+		return nil
+	}
+	return nil
+}
+
+func (n *chatTUINotifications) ChatJoinedConversation(ctx context.Context, arg chat1.ChatJoinedConversationArg) error {
+	n.events <- func(ctx context.Context, t *chatTUI) error {
+		return t.refreshInbox(ctx)
+	}
+	return nil
+}
+
+func (n *chatTUINotifications) ChatLeftConversation(ctx context.Context, arg chat1.ChatLeftConversationArg) error {
+	n.events <- func(ctx context.Context, t *chatTUI) error {
+		return t.refreshInbox(ctx)
+	}
+	return nil
+}
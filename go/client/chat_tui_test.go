@@ -0,0 +1,279 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+type chatTUITestBackend struct {
+	convs    []chat1.ConversationLocal
+	messages map[chat1.ConvIDStr][]chat1.MessageUnboxed
+	calls    []string
+
+	// uploads, if set, holds uploads until it is closed or they are canceled,
+	// which closes canceled
+	uploads  chan struct{}
+	canceled chan struct{}
+}
+
+var _ chatTUIBackend = (*chatTUITestBackend)(nil)
+
+func chatTUITestConv(id byte, names ...string) chat1.ConversationLocal {
+	conv := chat1.ConversationLocal{
+		Info: chat1.ConversationInfoLocal{
+			Id:          chat1.ConversationID{id},
+			TlfName:     strings.Join(names, ","),
+			MembersType: chat1.ConversationMembersType_IMPTEAMNATIVE,
+		},
+	}
+	for _, name := range names {
+		conv.Info.Participants = append(conv.Info.Participants,
+			chat1.ConversationLocalParticipant{Username: name, InConvName: true})
+	}
+	return conv
+}
+
+func chatTUITestMessage(id chat1.MessageID, sender, body string) chat1.MessageUnboxed {
+	return chat1.NewMessageUnboxedWithValid(chat1.MessageUnboxedValid{
+		ServerHeader: chat1.MessageServerHeader{
+			MessageID: id,
+			Ctime:     gregor1.ToTime(time.Now()),
+		},
+		MessageBody:    chat1.NewMessageBodyWithText(chat1.MessageText{Body: body}),
+		SenderUsername: sender,
+	})
+}
+
+func (b *chatTUITestBackend) Inbox(ctx context.Context) ([]chat1.ConversationLocal, error) {
+	return append([]chat1.ConversationLocal(nil), b.convs...), nil
+}
+
+func (b *chatTUITestBackend) Thread(ctx context.Context, conv chat1.ConversationLocal) (chat1.ConversationLocal, []chat1.MessageUnboxed, error) {
+	return conv, b.messages[conv.GetConvID().ConvIDStr()], nil
+}
+
+func (b *chatTUITestBackend) Send(ctx context.Context, convID chat1.ConversationID, body string) error {
+	msgs := b.messages[convID.ConvIDStr()]
+	id := chat1.MessageID(len(msgs) + 1)
+	// newest first, like the service returns them
+	b.messages[convID.ConvIDStr()] = append([]chat1.MessageUnboxed{chatTUITestMessage(id, "me", body)}, msgs...)
+	b.calls = append(b.calls, "send "+body)
+	return nil
+}
+
+func (b *chatTUITestBackend) Edit(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, body string) error {
+	b.calls = append(b.calls, fmt.Sprintf("edit %d %s", msgID, body))
+	return nil
+}
+
+func (b *chatTUITestBackend) React(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, reaction string) error {
+	b.calls = append(b.calls, fmt.Sprintf("react %d %s", msgID, reaction))
+	return nil
+}
+
+func (b *chatTUITestBackend) Delete(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID) error {
+	b.calls = append(b.calls, fmt.Sprintf("delete %d", msgID))
+	return nil
+}
+
+func (b *chatTUITestBackend) Upload(ctx context.Context, convID chat1.ConversationID, filename, title string) error {
+	b.calls = append(b.calls, fmt.Sprintf("upload %s %s", filename, title))
+	if b.uploads == nil {
+		return nil
+	}
+	select {
+	case <-b.uploads:
+		return nil
+	case <-ctx.Done():
+		close(b.canceled)
+		return ctx.Err()
+	}
+}
+
+func (b *chatTUITestBackend) Download(ctx context.Context, convID chat1.ConversationID, msgID chat1.MessageID, output string) error {
+	b.calls = append(b.calls, fmt.Sprintf("download %d %s", msgID, output))
+	return nil
+}
+
+func TestChatTUIKeyDecoder(t *testing.T) {
+	var d tuiKeyDecoder
+	require.Equal(t, []tuiKey{{code: tuiKeyRune, r: 'h'}, {code: tuiKeyRune, r: 'i'}, {code: tuiKeyEnter}},
+		d.feed([]byte("hi\r")))
+	// sequences and characters split across reads
+	require.Empty(t, d.feed([]byte("\x1b[")))
+	require.Equal(t, []tuiKey{{code: tuiKeyUp}}, d.feed([]byte("A")))
+	require.Empty(t, d.feed([]byte{0xc3}))
+	require.Equal(t, []tuiKey{{code: tuiKeyRune, r: 'é'}}, d.feed([]byte{0xa9}))
+	require.Equal(t, []tuiKey{{code: tuiKeyPgUp}, {code: tuiKeyDown}, {code: tuiKeyBackspace}, {code: tuiKeyEsc}},
+		d.feed([]byte("\x1b[5~\x1bOB\x7f\x1b")))
+	// unknown sequences and control characters are dropped
+	require.Equal(t, []tuiKey{{code: tuiKeyTab}}, d.feed([]byte("\x1b[1;5C\x01\t")))
+}
+
+func TestChatTUIHeadless(t *testing.T) {
+	tc := libkb.SetupTest(t, "chat_tui", 0)
+	defer tc.Cleanup()
+
+	general := chatTUITestConv(1, "me", "alice", "bob")
+	other := chatTUITestConv(2, "me", "carol")
+	backend := &chatTUITestBackend{
+		convs: []chat1.ConversationLocal{general, other},
+		messages: map[chat1.ConvIDStr][]chat1.MessageUnboxed{
+			general.GetConvID().ConvIDStr(): {chatTUITestMessage(1, "alice", "hello there")},
+		},
+	}
+	ctx := context.Background()
+	events := make(chan chatTUIEvent)
+	tui := newChatTUI(tc.G, backend, events, "me", 80, 12)
+	require.NoError(t, tui.refreshInbox(ctx))
+	require.NoError(t, tui.open(ctx, 0))
+
+	input := make(chan []byte)
+	frames := make(chan string)
+	done := make(chan error)
+	go func() {
+		done <- runChatTUI(ctx, tui, input, events, nil, true, func(screen chatTUIScreen) error {
+			var b strings.Builder
+			require.NoError(t, writeChatTUIHeadless(&b, screen))
+			frames <- b.String()
+			return nil
+		})
+	}()
+	frame := <-frames
+	require.Contains(t, frame, "keybase chat: alice,bob")
+	require.Contains(t, frame, "[1] alice")
+	require.Contains(t, frame, "hello there")
+	require.Contains(t, frame, "carol")
+	lines := strings.Split(frame, "\n")
+	require.Len(t, lines, 12+2)
+	require.Equal(t, strings.Repeat("=", 80), lines[12])
+
+	typeLine := func(s string) string {
+		input <- []byte(s)
+		return <-frames
+	}
+
+	frame = typeLine("hi @al\t")
+	require.Contains(t, frame, "> hi @alice")
+	frame = typeLine("\n")
+	require.Contains(t, frame, "[2] me")
+	require.Equal(t, []string{"send hi @alice"}, backend.calls)
+
+	// several candidates cycle and show in the status line
+	frame = typeLine(":smil\t")
+	require.Contains(t, frame, "Tab: :smile:")
+	frame = typeLine("\t\x15")
+	require.Contains(t, frame, "\n>\n")
+
+	typeLine("/react 1 :+1:\n")
+	typeLine("/edit 2 hi @alice!\n")
+	typeLine("/delete 2\n")
+	// transfers report back with an event once they are done
+	require.Contains(t, typeLine("/upload /tmp/cat.png my cat\n"), "uploading /tmp/cat.png...")
+	require.Contains(t, <-frames, "uploaded /tmp/cat.png")
+	require.Contains(t, typeLine("/download 5 /tmp/out.png\n"), "downloading to /tmp/out.png...")
+	require.Contains(t, <-frames, "downloaded to /tmp/out.png")
+	typeLine("//shrug\n")
+	require.Equal(t, []string{
+		"send hi @alice",
+		"react 1 :+1:",
+		"edit 2 hi @alice!",
+		"delete 2",
+		"upload /tmp/cat.png my cat",
+		"download 5 /tmp/out.png",
+		"send /shrug",
+	}, backend.calls)
+	frame = typeLine("/frob\n")
+	require.Contains(t, frame, "error: unknown command /frob")
+	frame = typeLine("/download 1\n")
+	require.Contains(t, frame, "error: message 1 is not a loaded attachment")
+
+	// messages from notifications show up
+	backend.messages[general.GetConvID().ConvIDStr()] = append([]chat1.MessageUnboxed{
+		chatTUITestMessage(9, "bob", "live update"),
+	}, backend.messages[general.GetConvID().ConvIDStr()]...)
+	convID := general.GetConvID()
+	events <- func(ctx context.Context, t *chatTUI) error {
+		return t.onActivity(ctx, &convID)
+	}
+	require.Contains(t, <-frames, "live update")
+	// Esc clears the error, which would hide who is typing
+	require.NotContains(t, typeLine("\x1b"), "error:")
+	events <- func(ctx context.Context, t *chatTUI) error {
+		t.onTyping([]chat1.ConvTypingUpdate{{ConvID: convID, Typers: []chat1.TyperInfo{{Username: "bob"}}}})
+		return nil
+	}
+	require.Contains(t, <-frames, "bob typing...")
+
+	// switch conversations
+	frame = typeLine("\x1b[B")
+	require.Contains(t, frame, "keybase chat: carol")
+	require.Contains(t, frame, "No messages yet")
+
+	input <- []byte("/quit\n")
+	<-frames
+	require.NoError(t, <-done)
+}
+
+func TestChatTUITransferAtExit(t *testing.T) {
+	tc := libkb.SetupTest(t, "chat_tui", 0)
+	defer tc.Cleanup()
+
+	run := func(ctx context.Context, waitTransfers bool) (*chatTUITestBackend, chan<- []byte, <-chan string, <-chan error) {
+		backend := &chatTUITestBackend{
+			convs:    []chat1.ConversationLocal{chatTUITestConv(1, "me", "alice")},
+			uploads:  make(chan struct{}),
+			canceled: make(chan struct{}),
+		}
+		events := make(chan chatTUIEvent)
+		tui := newChatTUI(tc.G, backend, events, "me", 80, 12)
+		require.NoError(t, tui.refreshInbox(ctx))
+		require.NoError(t, tui.open(ctx, 0))
+		input := make(chan []byte)
+		frames := make(chan string, 10)
+		done := make(chan error)
+		go func() {
+			done <- runChatTUI(ctx, tui, input, events, nil, waitTransfers, func(screen chatTUIScreen) error {
+				var b strings.Builder
+				require.NoError(t, writeChatTUIHeadless(&b, screen))
+				frames <- b.String()
+				return nil
+			})
+		}()
+		<-frames
+		input <- []byte("/upload /tmp/cat.png\n")
+		require.Contains(t, <-frames, "uploading /tmp/cat.png...")
+		return backend, input, frames, done
+	}
+
+	// headless input ending waits for the upload to report back
+	backend, input, frames, done := run(context.Background(), true)
+	close(input)
+	select {
+	case <-done:
+		require.Fail(t, "returned with an upload running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(backend.uploads)
+	require.Contains(t, <-frames, "uploaded /tmp/cat.png")
+	require.NoError(t, <-done)
+
+	// otherwise quitting doesn't wait, and canceling stops the upload
+	ctx, cancel := context.WithCancel(context.Background())
+	backend, input, _, done = run(ctx, false)
+	input <- []byte("/quit\n")
+	require.NoError(t, <-done)
+	cancel()
+	<-backend.canceled
+}
@@ -22,9 +22,11 @@ func NewCmdChat(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdChatArchiveList(cl, g),
 		newCmdChatArchivePause(cl, g),
 		newCmdChatArchiveResume(cl, g),
+		newCmdChatBookmark(cl, g),
 		newCmdChatDefaultChannels(cl, g),
 		newCmdChatDeleteChannel(cl, g),
 		newCmdChatDeleteHistory(cl, g),
+		newCmdChatDND(cl, g),
 		newCmdChatDownload(cl, g),
 		newCmdChatHide(cl, g),
 		newCmdChatJoinChannel(cl, g),
@@ -45,7 +47,9 @@ func NewCmdChat(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command
 		newCmdChatSearchInbox(cl, g),
 		newCmdChatSearchRegexp(cl, g),
 		newCmdChatSend(cl, g),
+		newCmdChatTUI(cl, g),
 		newCmdChatUpload(cl, g),
+		newCmdChatWebhook(cl, g),
 		newCmdChatAddBotMember(cl, g),
 		newCmdChatRemoveBotMember(cl, g),
 		newCmdChatEditBotMember(cl, g),
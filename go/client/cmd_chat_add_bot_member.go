@@ -20,6 +20,7 @@ type CmdChatAddBotMember struct {
 	username         string
 	role             keybase1.TeamRole
 	botSettings      *keybase1.TeamBotSettings
+	botSenders       []string
 	hasTTY           bool
 }
 
@@ -108,7 +109,7 @@ func (c *CmdChatAddBotMember) Run() (err error) {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), conversationInfo.TlfName, conversationInfo.MembersType, c.botSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), conversationInfo.TlfName, conversationInfo.MembersType, c.botSettings, c.botSenders); err != nil {
 		return err
 	}
 
@@ -134,7 +135,7 @@ func (c *CmdChatAddBotMember) ParseArgv(ctx *cli.Context) (err error) {
 	}
 
 	if c.role.IsRestrictedBot() {
-		c.botSettings = ParseBotSettings(ctx)
+		c.botSettings, c.botSenders = ParseBotSettings(ctx)
 	}
 	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
 
@@ -25,6 +25,8 @@ type CmdChatAPIListen struct {
 	subscribeDev    bool
 	subscribeWallet bool
 	channelFilters  []ChatChannel
+	walletFilter    walletNotificationFilter
+	walletCursor    *walletPaymentCursor
 }
 
 func newCmdChatAPIListen(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -67,6 +69,14 @@ func newCmdChatAPIListen(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli
 				Name:  "filter-channels",
 				Usage: "Only show notifications for specified list of channels.",
 			},
+			cli.StringFlag{
+				Name:  "filter-wallet",
+				Usage: "Only show payment notifications that match the JSON filter (implies --wallet).",
+			},
+			cli.StringFlag{
+				Name:  "wallet-cursor",
+				Usage: "Replay payments made after this cursor before live notifications (implies --wallet).",
+			},
 		},
 		Description: `"keybase chat api-listen" is a command that will print incoming chat messages, conversation, or
    wallet notifications until it's exited. Messages are printed to standard output in
@@ -85,6 +95,17 @@ func newCmdChatAPIListen(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli
    Only show messages from "alice,bob" user conversation:
 
       keybase chat api-listen --filter-channel '{"name":"alice,bob"}'
+
+   Wallet payment notifications carry a "cursor" field. A client that gets
+   disconnected can pass the cursor of the last payment it processed to
+   --wallet-cursor, and the payments it missed are printed, oldest first and
+   with "replayed": true, before live notifications resume. Payments can be
+   filtered by account, asset code ("XLM" or "native" for lumens) and direction
+   ("sent" or "received") with --filter-wallet.
+
+   Only show lumens received by one account, starting after a known payment:
+
+      keybase chat api-listen --wallet-cursor 1555000000000-d9ea0e1f... --filter-wallet '{"accounts":["GDD..."],"assets":["XLM"],"direction":"received"}'
 `,
 	}
 }
@@ -101,33 +122,64 @@ func (c *CmdChatAPIListen) ParseArgv(ctx *cli.Context) error {
 	}
 	c.subscribeDev = ctx.Bool("dev")
 	c.subscribeWallet = ctx.Bool("wallet")
+	if err := c.parseWalletArgs(ctx); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (c *CmdChatAPIListen) parseFilterChannelArgs(ctx *cli.Context) error {
-	if chs := ctx.String("filter-channels"); chs != "" {
-		if err := json.Unmarshal([]byte(chs), &c.channelFilters); err != nil {
+func (c *CmdChatAPIListen) parseWalletArgs(ctx *cli.Context) error {
+	if f := ctx.String("filter-wallet"); f != "" {
+		if err := json.Unmarshal([]byte(f), &c.walletFilter); err != nil {
+			return err
+		}
+		if err := c.walletFilter.Check(); err != nil {
+			return err
+		}
+		c.subscribeWallet = true
+	}
+	if s := ctx.String("wallet-cursor"); s != "" {
+		cursor, err := parseWalletPaymentCursor(s)
+		if err != nil {
 			return err
 		}
+		c.walletCursor = &cursor
+		c.subscribeWallet = true
+	}
+	return nil
+}
+
+func (c *CmdChatAPIListen) parseFilterChannelArgs(ctx *cli.Context) (err error) {
+	c.channelFilters, err = parseChannelFilterFlags(ctx)
+	return err
+}
+
+// parseChannelFilterFlags reads the --filter-channel and --filter-channels
+// flags of api-listen and webhook add.
+func parseChannelFilterFlags(ctx *cli.Context) (res []ChatChannel, err error) {
+	if chs := ctx.String("filter-channels"); chs != "" {
+		if err := json.Unmarshal([]byte(chs), &res); err != nil {
+			return nil, err
+		}
 	}
 
 	if ch := ctx.String("filter-channel"); ch != "" {
 		var channel ChatChannel
 		if err := json.Unmarshal([]byte(ch), &channel); err != nil {
-			return err
+			return nil, err
 		}
-		c.channelFilters = append(c.channelFilters, channel)
+		res = append(res, channel)
 	}
 
-	for _, v := range c.channelFilters {
+	for _, v := range res {
 		if !v.Valid() {
 			str, _ := json.Marshal(v)
-			return fmt.Errorf("Channel filter not valid: %s", str)
+			return nil, fmt.Errorf("Channel filter not valid: %s", str)
 		}
 	}
 
-	return nil
+	return res, nil
 }
 
 func NewCmdChatAPIListenRunner(g *libkb.GlobalContext) *CmdChatAPIListen {
@@ -167,8 +219,9 @@ func (c *CmdChatAPIListen) Run() error {
 	protocols := []rpc.Protocol{
 		chat1.NotifyChatProtocol(chatDisplay),
 	}
+	var stellarDisplay *walletNotificationDisplay
 	if c.subscribeWallet {
-		stellarDisplay := newWalletNotificationDisplay(c.G())
+		stellarDisplay = newWalletNotificationDisplay(c.G(), c.walletFilter)
 		protocols = append(protocols, stellar1.NotifyProtocol(stellarDisplay))
 	}
 
@@ -199,6 +252,14 @@ func (c *CmdChatAPIListen) Run() error {
 			return err
 		}
 	}
+	if c.walletCursor != nil {
+		// Subscribed already, so payments that arrive during the replay wait
+		// for it and are deduped against it.
+		c.ErrWriteLn("Replaying wallet payments after %s", c.walletCursor)
+		if err := stellarDisplay.replay(context.TODO(), *c.walletCursor); err != nil {
+			return err
+		}
+	}
 
 	for {
 		if err := sendPing(sessionClient); err != nil {
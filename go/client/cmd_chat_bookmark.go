@@ -0,0 +1,48 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+func newCmdChatBookmark(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	subcommands := []cli.Command{
+		newCmdChatBookmarkAdd(cl, g),
+		newCmdChatBookmarkList(cl, g),
+		newCmdChatBookmarkRemove(cl, g),
+	}
+	sort.Sort(cli.ByName(subcommands))
+	return cli.Command{
+		Name:         "bookmark",
+		Usage:        "Manage private bookmarks of messages",
+		ArgumentHelp: "[arguments...]",
+		Subcommands:  subcommands,
+	}
+}
+
+// parseBookmarkArgs parses the <conversation> <message id> arguments of the
+// bookmark commands.
+func parseBookmarkArgs(ctx *cli.Context, name string) (req chatConversationResolvingRequest,
+	msgID chat1.MessageID, err error,
+) {
+	if len(ctx.Args()) != 2 {
+		return req, 0, errors.New("bookmark " + name + " takes a conversation and a message ID")
+	}
+	id, err := strconv.ParseUint(ctx.Args().Get(1), 10, 32)
+	if err != nil || id == 0 {
+		return req, 0, errors.New("invalid message ID: " + ctx.Args().Get(1))
+	}
+	if req, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+		return req, 0, err
+	}
+	return req, chat1.MessageID(id), nil
+}
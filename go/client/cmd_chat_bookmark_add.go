@@ -0,0 +1,90 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdChatBookmarkAdd struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	msgID            chat1.MessageID
+	note             string
+}
+
+func newCmdChatBookmarkAdd(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "add",
+		Usage:        "Bookmark a message",
+		ArgumentHelp: "<conversation> <message id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatBookmarkAdd{
+				Contextified: libkb.NewContextified(g),
+			}, "add", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(),
+			cli.StringFlag{
+				Name:  "note",
+				Usage: "A note to keep with the bookmark",
+			},
+		),
+		Description: `"keybase chat bookmark add" saves a private bookmark of a message. Only you
+   can see your bookmarks, and they are the same on all of your devices.
+   Bookmarking a message again updates its note.
+
+   Example:
+
+      keybase chat bookmark add --channel general --note "release checklist" myteam 314
+`,
+	}
+}
+
+func (c *CmdChatBookmarkAdd) ParseArgv(ctx *cli.Context) (err error) {
+	if c.resolvingRequest, c.msgID, err = parseBookmarkArgs(ctx, "add"); err != nil {
+		return err
+	}
+	c.note = ctx.String("note")
+	return nil
+}
+
+func (c *CmdChatBookmarkAdd) Run() error {
+	ctx := context.TODO()
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resolver.ChatClient.AddChatBookmarkLocal(ctx, chat1.AddChatBookmarkLocalArg{
+		ConvID: conv.GetConvID(),
+		MsgID:  c.msgID,
+		Note:   c.note,
+	}); err != nil {
+		return err
+	}
+	c.G().UI.GetTerminalUI().Printf("Bookmarked message %d\n", c.msgID)
+	return nil
+}
+
+func (c *CmdChatBookmarkAdd) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
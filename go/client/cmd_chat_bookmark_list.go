@@ -0,0 +1,100 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chatrender"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdChatBookmarkList struct {
+	libkb.Contextified
+	showDeviceName bool
+}
+
+func newCmdChatBookmarkList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "List bookmarked messages, newest bookmarks first",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatBookmarkList{
+				Contextified: libkb.NewContextified(g),
+			}, "list", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: mustGetChatFlags("show-device-name"),
+	}
+}
+
+func (c *CmdChatBookmarkList) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return fmt.Errorf("no arguments required")
+	}
+	c.showDeviceName = ctx.Bool("show-device-name")
+	return nil
+}
+
+func (c *CmdChatBookmarkList) Run() error {
+	ctx := context.TODO()
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := client.GetChatBookmarksLocal(ctx, keybase1.TLFIdentifyBehavior_CHAT_CLI)
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	if res.Offline {
+		_, _ = ui.PrintfUnescaped(ColorString(c.G(), "yellow", "WARNING: bookmarks obtained in OFFLINE mode\n"))
+	}
+	if len(res.Bookmarks) == 0 {
+		ui.Printf("No bookmarks\n")
+		return nil
+	}
+	convs, err := loadBookmarkConvs(ctx, client, res.Bookmarks)
+	if err != nil {
+		return err
+	}
+	for i, b := range res.Bookmarks {
+		conv, ok := convs[b.Bookmark.ConvID.ConvIDStr()]
+		name := b.Bookmark.ConvID.String()
+		if ok {
+			name = chatChannelDisplayName(chatChannelFromConv(conv))
+		}
+		ui.Printf("[%d] %s, message %d, bookmarked %s\n", i+1, name, b.Bookmark.MsgID,
+			chatrender.FmtTime(gregor1.FromTime(b.Bookmark.Ctime), chatrender.RenderOptions{UseDateTime: true}))
+		if len(b.Bookmark.Note) > 0 {
+			ui.Printf("    note: %s\n", b.Bookmark.Note)
+		}
+		if !ok || b.Message == nil {
+			ui.Printf("    (message unavailable)\n\n")
+			continue
+		}
+		if err := (chatrender.ConversationView{
+			Conversation: conv,
+			Messages:     []chat1.MessageUnboxed{*b.Message},
+			Opts:         chatrender.RenderOptions{SkipHeadline: true},
+		}).Show(c.G(), c.showDeviceName); err != nil {
+			return err
+		}
+		ui.Printf("\n")
+	}
+	return nil
+}
+
+func (c *CmdChatBookmarkList) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
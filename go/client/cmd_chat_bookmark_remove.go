@@ -0,0 +1,72 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdChatBookmarkRemove struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	msgID            chat1.MessageID
+}
+
+func newCmdChatBookmarkRemove(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "remove",
+		Usage:        "Remove the bookmark of a message",
+		ArgumentHelp: "<conversation> <message id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatBookmarkRemove{
+				Contextified: libkb.NewContextified(g),
+			}, "remove", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: getConversationResolverFlags(),
+	}
+}
+
+func (c *CmdChatBookmarkRemove) ParseArgv(ctx *cli.Context) (err error) {
+	c.resolvingRequest, c.msgID, err = parseBookmarkArgs(ctx, "remove")
+	return err
+}
+
+func (c *CmdChatBookmarkRemove) Run() error {
+	ctx := context.TODO()
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	if err := resolver.ChatClient.RemoveChatBookmarkLocal(ctx, chat1.RemoveChatBookmarkLocalArg{
+		ConvID: conv.GetConvID(),
+		MsgID:  c.msgID,
+	}); err != nil {
+		return err
+	}
+	c.G().UI.GetTerminalUI().Printf("Removed the bookmark of message %d\n", c.msgID)
+	return nil
+}
+
+func (c *CmdChatBookmarkRemove) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -17,6 +17,7 @@ type CmdChatBotMemberSettings struct {
 	resolvingRequest chatConversationResolvingRequest
 	username         string
 	botSettings      *keybase1.TeamBotSettings
+	botSenders       []string
 	hasTTY           bool
 }
 
@@ -51,8 +52,8 @@ func (c *CmdChatBotMemberSettings) Run() (err error) {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), conversationInfo.TlfName,
-		conversationInfo.MembersType, c.botSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), conversationInfo.TlfName,
+		conversationInfo.MembersType, c.botSettings, c.botSenders); err != nil {
 		return err
 	}
 
@@ -88,7 +89,7 @@ func (c *CmdChatBotMemberSettings) ParseArgv(ctx *cli.Context) (err error) {
 		return err
 	}
 
-	c.botSettings = ParseBotSettings(ctx)
+	c.botSettings, c.botSenders = ParseBotSettings(ctx)
 	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
 
 	var tlfName string
@@ -101,6 +101,16 @@ func (c *CmdChatConvInfo) Run() error {
 	}
 	_, err = dui.Printf("ConversationName: %s%s\nConversationID: %v\nConvIDShort: %v\n",
 		utils.FormatConversationName(info, c.G().Env.GetUsername().String()), topicType, info.Id, info.Id.DbShortFormString())
+	if err != nil {
+		return err
+	}
+	if policy := conv.GetEphemeralPolicy(); policy != nil {
+		requirement := "required"
+		if policy.AllowNonExploding {
+			requirement = "optional"
+		}
+		_, err = dui.Printf("ExplodingPolicy: %s, maximum lifetime %v\n", requirement, policy.Age.ToDuration())
+	}
 	return err
 }
 
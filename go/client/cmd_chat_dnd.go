@@ -0,0 +1,254 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdChatDND struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+
+	enabled      *bool
+	timezone     *string
+	windows      []chat1.ChatDNDWindow
+	clearWindows bool
+	allowDMs     *bool
+	allow        bool
+	disallow     bool
+}
+
+func newCmdChatDND(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "dnd",
+		Usage:        "Manage the do-not-disturb schedule",
+		ArgumentHelp: "[conversation]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatDND{Contextified: libkb.NewContextified(g)}, "dnd", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(),
+			cli.BoolFlag{
+				Name:  "on",
+				Usage: "Turn the schedule on",
+			},
+			cli.BoolFlag{
+				Name:  "off",
+				Usage: "Turn the schedule off",
+			},
+			cli.StringSliceFlag{
+				Name: "window",
+				Usage: `Quiet hours, as <days> [HH:MM-HH:MM], e.g. "mon-fri 22:00-07:00" or
+	"weekends". Replaces the current windows. Can be specified multiple times.`,
+			},
+			cli.BoolFlag{
+				Name:  "clear-windows",
+				Usage: "Remove all windows",
+			},
+			cli.StringFlag{
+				Name:  "timezone",
+				Usage: `Timezone of the windows, e.g. "Europe/Berlin", or "local" for the local time of each device`,
+			},
+			cli.BoolFlag{
+				Name:  "allow-dms",
+				Usage: "Keep notifying for DMs and group chats outside of teams (--allow-dms=0 to undo)",
+			},
+			cli.BoolFlag{
+				Name:  "allow",
+				Usage: "Keep notifying for the given conversation",
+			},
+			cli.BoolFlag{
+				Name:  "disallow",
+				Usage: "Stop making an exception for the given conversation",
+			},
+		),
+		Description: `"keybase chat dnd" suppresses push and desktop notifications during quiet
+   hours. Badges are still updated. The schedule is kept with the rest of the
+   notification settings, so it is the same on all devices.
+
+   Examples:
+
+      keybase chat dnd --on --window "mon-fri 22:00-07:00" --window weekends
+      keybase chat dnd --timezone America/New_York --allow-dms
+      keybase chat dnd --allow --channel incidents myteam
+      keybase chat dnd --off
+`,
+	}
+}
+
+func (c *CmdChatDND) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) > 1 {
+		return errors.New("dnd takes at most one conversation")
+	}
+	if ctx.Bool("on") && ctx.Bool("off") {
+		return errors.New("only one of --on and --off can be given")
+	}
+	if ctx.Bool("on") || ctx.Bool("off") {
+		enabled := ctx.Bool("on")
+		c.enabled = &enabled
+	}
+	if ctx.IsSet("timezone") {
+		timezone := ctx.String("timezone")
+		if strings.EqualFold(timezone, "local") {
+			timezone = ""
+		}
+		c.timezone = &timezone
+	}
+	for _, window := range ctx.StringSlice("window") {
+		parsed, err := parseDNDWindow(window)
+		if err != nil {
+			return err
+		}
+		c.windows = append(c.windows, parsed)
+	}
+	c.clearWindows = ctx.Bool("clear-windows")
+	if c.clearWindows && len(c.windows) > 0 {
+		return errors.New("only one of --window and --clear-windows can be given")
+	}
+	if ctx.IsSet("allow-dms") {
+		allowDMs := ctx.Bool("allow-dms")
+		c.allowDMs = &allowDMs
+	}
+	c.allow = ctx.Bool("allow")
+	c.disallow = ctx.Bool("disallow")
+	if c.allow && c.disallow {
+		return errors.New("only one of --allow and --disallow can be given")
+	}
+	if c.allow || c.disallow {
+		if len(ctx.Args()) == 0 {
+			return errors.New("--allow and --disallow need a conversation")
+		}
+		if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args().Get(0)); err != nil {
+			return err
+		}
+	} else if len(ctx.Args()) > 0 {
+		return errors.New("a conversation can only be given with --allow or --disallow")
+	}
+	return nil
+}
+
+func (c *CmdChatDND) changed() bool {
+	return c.enabled != nil || c.timezone != nil || len(c.windows) > 0 || c.clearWindows ||
+		c.allowDMs != nil || c.allow || c.disallow
+}
+
+func (c *CmdChatDND) update(ctx context.Context, schedule *chat1.ChatDNDSchedule) error {
+	if c.enabled != nil {
+		schedule.Enabled = *c.enabled
+	}
+	if c.timezone != nil {
+		schedule.Timezone = *c.timezone
+	}
+	if len(c.windows) > 0 || c.clearWindows {
+		schedule.Windows = c.windows
+	}
+	if c.allowDMs != nil {
+		schedule.AllowDMs = *c.allowDMs
+	}
+	if !c.allow && !c.disallow {
+		return nil
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return err
+	}
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		IdentifyBehavior: keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return err
+	}
+	convIDs := schedule.AllowConvIDs[:0]
+	for _, convID := range schedule.AllowConvIDs {
+		if !convID.Eq(conv.GetConvID()) {
+			convIDs = append(convIDs, convID)
+		}
+	}
+	if c.allow {
+		convIDs = append(convIDs, conv.GetConvID())
+	}
+	schedule.AllowConvIDs = convIDs
+	return nil
+}
+
+func (c *CmdChatDND) Run() error {
+	ctx := context.TODO()
+	chatClient, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := chatClient.GetChatDNDScheduleLocal(ctx)
+	if err != nil {
+		return err
+	}
+	if c.changed() {
+		if err := c.update(ctx, &res.Schedule); err != nil {
+			return err
+		}
+		if err := chatClient.SetChatDNDScheduleLocal(ctx, res.Schedule); err != nil {
+			return err
+		}
+		if res, err = chatClient.GetChatDNDScheduleLocal(ctx); err != nil {
+			return err
+		}
+	}
+	summary, err := dndScheduleSummary(ctx, chatClient, res)
+	if err != nil {
+		return err
+	}
+
+	ui := c.G().UI.GetTerminalUI()
+	switch {
+	case summary.Active:
+		ui.Printf("Do not disturb: on, notifications are suppressed now\n")
+	case summary.Enabled:
+		ui.Printf("Do not disturb: on\n")
+	default:
+		ui.Printf("Do not disturb: off\n")
+	}
+	timezone := summary.Timezone
+	if len(timezone) == 0 {
+		timezone = "local"
+	}
+	ui.Printf("Timezone: %s\n", timezone)
+	if len(summary.Windows) == 0 {
+		ui.Printf("Windows: none\n")
+	} else {
+		ui.Printf("Windows:\n")
+		for _, window := range summary.Windows {
+			ui.Printf("  %s\n", window)
+		}
+	}
+	var allowed []string
+	if summary.AllowDMs {
+		allowed = append(allowed, "DMs")
+	}
+	for _, channel := range summary.AllowChannels {
+		allowed = append(allowed, chatChannelDisplayName(channel))
+	}
+	if len(allowed) == 0 {
+		allowed = append(allowed, "none")
+	}
+	ui.Printf("Allowed: %s\n", strings.Join(allowed, ", "))
+	return nil
+}
+
+func (c *CmdChatDND) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -18,6 +18,7 @@ type CmdChatEditBotMember struct {
 	username         string
 	role             keybase1.TeamRole
 	botSettings      *keybase1.TeamBotSettings
+	botSenders       []string
 	hasTTY           bool
 }
 
@@ -55,7 +56,7 @@ func (c *CmdChatEditBotMember) Run() (err error) {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), conversationInfo.TlfName, conversationInfo.MembersType, c.botSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), conversationInfo.TlfName, conversationInfo.MembersType, c.botSettings, c.botSenders); err != nil {
 		return err
 	}
 
@@ -81,7 +82,7 @@ func (c *CmdChatEditBotMember) ParseArgv(ctx *cli.Context) (err error) {
 	}
 
 	if c.role.IsRestrictedBot() {
-		c.botSettings = ParseBotSettings(ctx)
+		c.botSettings, c.botSenders = ParseBotSettings(ctx)
 	}
 	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
 
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
@@ -13,6 +15,11 @@ import (
 type CmdChatSetNotificationSettings struct {
 	libkb.Contextified
 	settings chat1.GlobalAppNotificationSettings
+
+	addKeywords     []string
+	addRegexes      []string
+	removeHighlight []string
+	clearHighlights bool
 }
 
 func NewCmdChatSetNotificationSettingsRunner(g *libkb.GlobalContext) *CmdChatSetNotificationSettings {
@@ -29,6 +36,26 @@ func newCmdChatSetNotificationSettings(cl *libcmdline.CommandLine, g *libkb.Glob
 			Usage: setting.Usage(),
 		})
 	}
+	flags = append(flags,
+		cli.StringSliceFlag{
+			Name: "highlight",
+			Usage: `Get desktop notifications like for an @-mention when a message contains a keyword,
+	as a whole word in any case. Can be specified multiple times.`,
+		},
+		cli.StringSliceFlag{
+			Name: "highlight-regex",
+			Usage: `Get desktop notifications like for an @-mention when a message matches a regex.
+	Can be specified multiple times.`,
+		},
+		cli.StringSliceFlag{
+			Name:  "unhighlight",
+			Usage: "Remove a highlight keyword or regex. Can be specified multiple times.",
+		},
+		cli.BoolFlag{
+			Name:  "clear-highlights",
+			Usage: "Remove all highlights",
+		},
+	)
 	return cli.Command{
 		Name:  "notification-settings",
 		Usage: "Manage personal notification settings",
@@ -41,6 +68,9 @@ Enable plaintext notifications:
 
 Disable plaintext notifications:
     keybase chat notification-settings --plaintext-mobile=0
+
+Get notified of messages about an outage or a project:
+    keybase chat notification-settings --highlight outage --highlight-regex 'proj-\d+'
 `,
 		ArgumentHelp: "[options]",
 		Action: func(c *cli.Context) {
@@ -56,7 +86,15 @@ func (c *CmdChatSetNotificationSettings) Run() error {
 			return err
 		}
 	}
-	return c.getGlobalAppNotificationSettings(context.TODO())
+	if c.clearHighlights || len(c.addKeywords) > 0 || len(c.addRegexes) > 0 || len(c.removeHighlight) > 0 {
+		if err := c.setHighlights(context.TODO()); err != nil {
+			return err
+		}
+	}
+	if err := c.getGlobalAppNotificationSettings(context.TODO()); err != nil {
+		return err
+	}
+	return c.getHighlights(context.TODO())
 }
 
 func (c *CmdChatSetNotificationSettings) ParseArgv(ctx *cli.Context) (err error) {
@@ -70,6 +108,10 @@ func (c *CmdChatSetNotificationSettings) ParseArgv(ctx *cli.Context) (err error)
 			c.settings.Settings[setting] = ctx.Bool(flagName)
 		}
 	}
+	c.addKeywords = ctx.StringSlice("highlight")
+	c.addRegexes = ctx.StringSlice("highlight-regex")
+	c.removeHighlight = ctx.StringSlice("unhighlight")
+	c.clearHighlights = ctx.Bool("clear-highlights")
 	return nil
 }
 
@@ -112,3 +154,57 @@ func (c *CmdChatSetNotificationSettings) getGlobalAppNotificationSettings(ctx co
 	}
 	return nil
 }
+
+func (c *CmdChatSetNotificationSettings) setHighlights(ctx context.Context) error {
+	lcli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	var highlights chat1.ChatHighlights
+	if !c.clearHighlights {
+		if highlights, err = lcli.GetChatHighlightsLocal(ctx); err != nil {
+			return err
+		}
+	}
+	remove := func(items []string) (res []string) {
+		for _, item := range items {
+			keep := true
+			for _, r := range c.removeHighlight {
+				if strings.EqualFold(item, r) {
+					keep = false
+					break
+				}
+			}
+			if keep {
+				res = append(res, item)
+			}
+		}
+		return res
+	}
+	highlights.Keywords = append(remove(highlights.Keywords), c.addKeywords...)
+	highlights.Regexes = append(remove(highlights.Regexes), c.addRegexes...)
+	return lcli.SetChatHighlightsLocal(ctx, highlights)
+}
+
+func (c *CmdChatSetNotificationSettings) getHighlights(ctx context.Context) error {
+	lcli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	highlights, err := lcli.GetChatHighlightsLocal(ctx)
+	if err != nil {
+		return err
+	}
+	var items []string
+	items = append(items, highlights.Keywords...)
+	for _, re := range highlights.Regexes {
+		items = append(items, fmt.Sprintf("/%s/", re))
+	}
+	highlightsStr := "none"
+	if len(items) > 0 {
+		highlightsStr = strings.Join(items, ", ")
+	}
+	c.G().UI.GetDumbOutputUI().Printf("highlights (%s)\n\tNotify like an @-mention when a message contains one of these\n",
+		highlightsStr)
+	return nil
+}
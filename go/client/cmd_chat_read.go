@@ -5,6 +5,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/chat/globals"
@@ -19,6 +20,7 @@ type CmdChatRead struct {
 	libkb.Contextified
 	fetcher        chatCLIConvFetcher
 	showDeviceName bool
+	markdown       bool
 }
 
 func NewCmdChatReadRunner(g *libkb.GlobalContext) *CmdChatRead {
@@ -36,7 +38,10 @@ func newCmdChatRead(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comm
 			cl.ChooseCommand(NewCmdChatReadRunner(g), "read", c)
 			cl.SetLogForward(libcmdline.LogForwardNone)
 		},
-		Flags: getMessageFetcherFlags(),
+		Flags: append(getMessageFetcherFlags(), cli.IntFlag{
+			Name:  "thread",
+			Usage: "Show the reply thread containing this message ID, starting from its root",
+		}),
 	}
 }
 
@@ -88,6 +93,7 @@ func (c *CmdChatRead) Run() error {
 	if err = (chatrender.ConversationView{
 		Conversation: convLocal,
 		Messages:     messages,
+		Opts:         chatrender.RenderOptions{Markdown: c.markdown},
 	}).Show(c.G(), c.showDeviceName); err != nil {
 		return err
 	}
@@ -101,10 +107,17 @@ func (c *CmdChatRead) ParseArgv(ctx *cli.Context) (err error) {
 	if len(ctx.Args()) >= 1 {
 		tlfName = ctx.Args().Get(0)
 	}
-	if c.fetcher, err = makeChatCLIConversationFetcher(ctx, tlfName, true); err != nil {
+	threadMsgID := ctx.Int("thread")
+	if threadMsgID < 0 {
+		return fmt.Errorf("invalid message ID for --thread: %d", threadMsgID)
+	}
+	// Reading a thread doesn't move the read marker of the conversation.
+	if c.fetcher, err = makeChatCLIConversationFetcher(ctx, tlfName, threadMsgID == 0); err != nil {
 		return err
 	}
+	c.fetcher.threadMsgID = chat1.MessageID(threadMsgID)
 	c.showDeviceName = ctx.Bool("show-device-name")
+	c.markdown = useChatMarkdown(c.G(), ctx)
 	return nil
 }
 
@@ -50,6 +50,9 @@ Keep messages for a week:
 Require messages to be exploding and have a maxmimum lifetime of a week:
     keybase chat retention-policy patrick --explode 1w
 
+Allow non-exploding messages, but limit exploding ones to a day:
+    keybase chat retention-policy ateam --explode 1d --allow-non-exploding
+
 Change the team-wide policy:
     keybase chat retention-policy ateam --expire 1y
 
@@ -74,6 +77,10 @@ Use the team policy for this channel:
 				Name:  "explode",
 				Usage: `Require all messages to be exploding with a maximum lifetime of one of [30s, 5m, 1h, 6h, 1d, 3d, 1w]`,
 			},
+			cli.BoolFlag{
+				Name:  "allow-non-exploding",
+				Usage: `With --explode, only limit the lifetime of exploding messages instead of requiring them`,
+			},
 			cli.BoolFlag{
 				Name:  "inherit",
 				Usage: `Use the team's policy for a channel`,
@@ -161,6 +168,10 @@ func (c *CmdChatSetRetention) ParseArgv(ctx *cli.Context) (err error) {
 		}
 		exclusiveChoices = append(exclusiveChoices, "explode")
 	}
+	allowNonExploding := ctx.Bool("allow-non-exploding")
+	if allowNonExploding && len(ctx.String("explode")) == 0 {
+		return fmt.Errorf("--allow-non-exploding requires --explode")
+	}
 	keep = ctx.Bool("keep")
 	if keep {
 		exclusiveChoices = append(exclusiveChoices, "keep")
@@ -185,7 +196,8 @@ func (c *CmdChatSetRetention) ParseArgv(ctx *cli.Context) (err error) {
 			})
 		case "explode":
 			p = chat1.NewRetentionPolicyWithEphemeral(chat1.RpEphemeral{
-				Age: age,
+				Age:               age,
+				AllowNonExploding: allowNonExploding,
 			})
 		}
 		c.setPolicy = &p
@@ -13,6 +13,7 @@ import (
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/chat/globals"
 	"github.com/keybase/client/go/chat/msgchecker"
+	"github.com/keybase/client/go/chat/utils"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/chat1"
@@ -28,6 +29,7 @@ type CmdChatSend struct {
 	message           string
 	setHeadline       string
 	ephemeralLifetime time.Duration
+	sendAt            *time.Time
 	clearHeadline     bool
 	hasTTY            bool
 	nonBlock          bool
@@ -59,6 +61,12 @@ func newCmdChatSend(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comm
 	flags := append(getConversationResolverFlags(),
 		mustGetChatFlags("set-headline", "clear-headline", "nonblock", "exploding-lifetime")...,
 	)
+	flags = append(flags, cli.StringFlag{
+		Name: "at",
+		Usage: `Schedule the message instead of sending it now, e.g. "in 20 minutes",
+	"tomorrow at 9am", "friday 17:30" or "2026-05-01T10:00:00Z". Scheduled messages are
+	sent by this device's service, so it needs to be running at that time.`,
+	})
 	return cli.Command{
 		Name:         "send",
 		Usage:        "Send a message to a conversation",
@@ -126,6 +134,7 @@ func (c *CmdChatSend) Run() (err error) {
 		team:              c.team,
 		setTopicName:      "",
 		ephemeralLifetime: c.ephemeralLifetime,
+		sendAt:            c.sendAt,
 	})
 }
 
@@ -135,6 +144,13 @@ func (c *CmdChatSend) ParseArgv(ctx *cli.Context) (err error) {
 	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
 	c.nonBlock = ctx.Bool("nonblock")
 	c.ephemeralLifetime = ctx.Duration("exploding-lifetime")
+	if at := ctx.String("at"); at != "" {
+		sendAt, err := utils.ParseSendTimeExact(at, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --at: %v", err)
+		}
+		c.sendAt = &sendAt
+	}
 
 	var tlfName string
 	// Get the TLF name from the first position arg
@@ -208,6 +224,9 @@ func (c *CmdChatSend) ParseArgv(ctx *cli.Context) (err error) {
 	if nActions > 1 {
 		return fmt.Errorf("only one of message, --set-headline, --clear-headline allowed")
 	}
+	if c.sendAt != nil && (c.setHeadline != "" || c.clearHeadline) {
+		return fmt.Errorf("--at can only be used to send a message")
+	}
 
 	return nil
 }
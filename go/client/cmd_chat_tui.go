@@ -0,0 +1,356 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-crypto/ssh/terminal"
+	isatty "github.com/mattn/go-isatty"
+)
+
+const (
+	chatTUIDefaultWidth  = 80
+	chatTUIDefaultHeight = 24
+)
+
+type CmdChatTUI struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	headless         bool
+	width            int
+	height           int
+}
+
+func NewCmdChatTUIRunner(g *libkb.GlobalContext) *CmdChatTUI {
+	return &CmdChatTUI{
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func newCmdChatTUI(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "tui",
+		Usage:        "Chat interactively in the terminal",
+		ArgumentHelp: "[conversation]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(NewCmdChatTUIRunner(g), "tui", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(),
+			cli.BoolFlag{
+				Name:  "headless",
+				Usage: "Read keys from standard input and print a plain frame after every line, for scripts and tests",
+			},
+			cli.IntFlag{
+				Name:  "width",
+				Usage: fmt.Sprintf("Width of headless frames (default %d)", chatTUIDefaultWidth),
+			},
+			cli.IntFlag{
+				Name:  "height",
+				Usage: fmt.Sprintf("Height of headless frames (default %d)", chatTUIDefaultHeight),
+			},
+		),
+		Description: `"keybase chat tui" shows your inbox, the messages of a conversation and a
+   composer in the terminal, and updates as messages come in. It opens the
+   given conversation, or the most recent one.
+
+   Up/Down (or Ctrl-P/Ctrl-N) switch conversations, PgUp/PgDn scroll, and Tab
+   completes @-mentions and :emoji:. Besides messages, the composer takes:
+
+      /react <id> <:emoji:>     react to message <id>
+      /edit <id> <text>         edit your message <id>
+      /delete <id>              delete your message <id>
+      /upload <file> [title]    attach a file
+      /download <id> [file]     save an attachment
+      /quit                     exit (also Ctrl-C)
+
+   Start a message with "//" to send it starting with a single "/".
+
+   With --headless, keys are read from standard input, which does not have to be
+   a terminal, and a plain frame of the screen is printed after every line.
+`,
+	}
+}
+
+func (c *CmdChatTUI) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) > 1 {
+		return errors.New("usage: keybase chat tui [conversation]")
+	}
+	if len(ctx.Args()) == 1 {
+		if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, ctx.Args()[0]); err != nil {
+			return err
+		}
+	}
+	c.headless = ctx.Bool("headless")
+	c.width, c.height = ctx.Int("width"), ctx.Int("height")
+	if !c.headless && (c.width != 0 || c.height != 0) {
+		return errors.New("--width and --height only apply to --headless")
+	}
+	if c.width < 0 || c.height < 0 {
+		return errors.New("--width and --height have to be positive")
+	}
+	if c.width == 0 {
+		c.width = chatTUIDefaultWidth
+	}
+	if c.height == 0 {
+		c.height = chatTUIDefaultHeight
+	}
+	return nil
+}
+
+func (c *CmdChatTUI) Run() (err error) {
+	// canceled on the way out, which stops transfers still running
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fd := int(os.Stdin.Fd())
+	if !c.headless && (!isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd())) {
+		return errors.New("chat tui needs a terminal, use --headless otherwise")
+	}
+
+	backend, err := newChatTUIRPCBackend(c.G())
+	if err != nil {
+		return err
+	}
+	events := make(chan chatTUIEvent, 100)
+	notifications := newChatTUINotifications(c.G(), events)
+	protocols := append(backend.protocols(), chat1.NotifyChatProtocol(notifications))
+	if err := RegisterProtocolsWithContext(protocols, c.G()); err != nil {
+		return err
+	}
+	notifyCli, err := GetNotifyCtlClient(c.G())
+	if err != nil {
+		return err
+	}
+	if err := notifyCli.SetNotifications(ctx, keybase1.NotificationChannels{Chat: true}); err != nil {
+		return err
+	}
+
+	// Resolve before taking over the screen, since it can ask questions.
+	var conv *chat1.ConversationLocal
+	if len(c.resolvingRequest.TlfName) > 0 {
+		if conv, err = c.resolve(ctx); err != nil {
+			return err
+		}
+	}
+
+	width, height := c.width, c.height
+	if !c.headless {
+		if width, height, err = terminal.GetSize(fd); err != nil {
+			return err
+		}
+	}
+	t := newChatTUI(c.G(), backend, events, c.G().Env.GetUsername().String(), width, height)
+	if err := t.refreshInbox(ctx); err != nil {
+		return err
+	}
+	if conv != nil {
+		err = t.openConv(ctx, *conv)
+	} else {
+		err = t.open(ctx, 0)
+	}
+	if err != nil {
+		t.setError(err)
+	}
+
+	if c.headless {
+		out := c.G().UI.GetTerminalUI().OutputWriter()
+		return runChatTUI(ctx, t, readChatTUILines(os.Stdin), events, nil, true, func(screen chatTUIScreen) error {
+			return writeChatTUIHeadless(out, screen)
+		})
+	}
+
+	oldState, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	out := c.G().UI.GetTerminalUI().UnescapedOutputWriter()
+	// use the alternate screen, so the shell comes back as it was
+	_, _ = io.WriteString(out, "\x1b[?1049h")
+	defer func() {
+		_, _ = io.WriteString(out, "\x1b[?1049l")
+		_ = terminal.Restore(fd, oldState)
+	}()
+	resize := func() bool {
+		w, h, err := terminal.GetSize(fd)
+		if err != nil || (w == t.width && h == t.height) {
+			return false
+		}
+		t.resize(w, h)
+		return true
+	}
+	return runChatTUI(ctx, t, readChatTUIChunks(os.Stdin), events, resize, false, func(screen chatTUIScreen) error {
+		return writeChatTUITerminal(out, screen, t.input)
+	})
+}
+
+func (c *CmdChatTUI) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
+
+func (c *CmdChatTUI) resolve(ctx context.Context) (*chat1.ConversationLocal, error) {
+	if err := annotateResolvingRequest(c.G(), &c.resolvingRequest); err != nil {
+		return nil, err
+	}
+	resolver, err := newChatConversationResolver(c.G())
+	if err != nil {
+		return nil, err
+	}
+	conv, _, err := resolver.Resolve(ctx, c.resolvingRequest, chatConversationResolvingBehavior{
+		CreateIfNotExists: false,
+		MustNotExist:      false,
+		Interactive:       !c.headless,
+		IdentifyBehavior:  keybase1.TLFIdentifyBehavior_CHAT_CLI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conv == nil {
+		return nil, fmt.Errorf("no conversation found for %s", c.resolvingRequest.TlfName)
+	}
+	return conv, nil
+}
+
+// runChatTUI feeds input and events to t until it quits or input ends, drawing
+// it after every chunk of input and batch of events. resize, if set, is
+// polled for changes of the terminal size. With waitTransfers it then keeps
+// handling events until the running uploads and downloads are done, so that
+// scripts get to see how they went.
+func runChatTUI(ctx context.Context, t *chatTUI, input <-chan []byte, events <-chan chatTUIEvent,
+	resize func() bool, waitTransfers bool, draw func(chatTUIScreen) error,
+) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var decoder tuiKeyDecoder
+	inputDone := false
+	redraw := true
+	for {
+		if redraw {
+			if err := draw(t.render()); err != nil {
+				return err
+			}
+		}
+		if t.quit || inputDone {
+			if !waitTransfers || t.transfers == 0 {
+				return nil
+			}
+			// a nil channel is never ready, so no more keys are taken
+			input = nil
+		}
+		redraw = true
+		select {
+		case chunk, ok := <-input:
+			if !ok {
+				inputDone = true
+				redraw = false
+				continue
+			}
+			for _, key := range decoder.feed(chunk) {
+				t.handleKey(ctx, key)
+			}
+		case ev := <-events:
+			// apply everything that queued up before drawing again
+			for ev != nil {
+				if err := ev(ctx, t); err != nil {
+					t.setError(err)
+				}
+				select {
+				case ev = <-events:
+				default:
+					ev = nil
+				}
+			}
+		case <-ticker.C:
+			redraw = resize != nil && resize()
+		}
+	}
+}
+
+func readChatTUIChunks(r io.Reader) <-chan []byte {
+	ch := make(chan []byte)
+	go func() {
+		defer close(ch)
+		buf := make([]byte, 1024)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				ch <- append([]byte(nil), buf[:n]...)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func readChatTUILines(r io.Reader) <-chan []byte {
+	ch := make(chan []byte)
+	go func() {
+		defer close(ch)
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadBytes('\n')
+			if len(line) > 0 {
+				ch <- line
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func writeChatTUIHeadless(w io.Writer, screen chatTUIScreen) error {
+	var b strings.Builder
+	for _, line := range screen.lines {
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteString("\n")
+	}
+	if len(screen.lines) > 0 {
+		b.WriteString(strings.Repeat("=", len([]rune(screen.lines[0]))))
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeChatTUITerminal(w io.Writer, screen chatTUIScreen, input []rune) error {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range screen.lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		if i == screen.selectedRow {
+			r := []rune(line)
+			b.WriteString("\x1b[7m" + string(r[:screen.inboxWidth]) + "\x1b[0m" + string(r[screen.inboxWidth:]))
+		} else {
+			b.WriteString(line)
+		}
+	}
+	if len(screen.lines) > 0 {
+		width := len([]rune(screen.lines[0]))
+		b.WriteString(fmt.Sprintf("\x1b[%d;%dH", len(screen.lines), min(width, len(input)+3)))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -24,6 +24,7 @@ type CmdChatUpload struct {
 	filename          string
 	title             string
 	ephemeralLifetime ephemeralLifetime
+	keepMetadata      bool
 	cancel            func()
 	done              chan bool
 }
@@ -34,6 +35,10 @@ func newCmdChatUpload(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 			Name:  "title",
 			Usage: "Title of attachment (defaults to filename)",
 		},
+		cli.BoolFlag{
+			Name:  "keep-metadata",
+			Usage: "Upload the file as is, without stripping its location and other metadata",
+		},
 	}
 	flags = append(flags, getConversationResolverFlags()...)
 	flags = append(flags, mustGetChatFlags("exploding-lifetime")...)
@@ -68,6 +73,7 @@ func (c *CmdChatUpload) ParseArgv(ctx *cli.Context) (err error) {
 	}
 	c.title = ctx.String("title")
 	c.ephemeralLifetime = ephemeralLifetime{ctx.Duration("exploding-lifetime")}
+	c.keepMetadata = ctx.Bool("keep-metadata")
 	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
 	return nil
 }
@@ -129,6 +135,7 @@ func (c *CmdChatUpload) Run() (err error) {
 		Filename:          c.filename,
 		Title:             c.title,
 		EphemeralLifetime: c.ephemeralLifetime,
+		KeepMetadata:      c.keepMetadata,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,29 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"sort"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+)
+
+func newCmdChatWebhook(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	subcommands := []cli.Command{
+		newCmdChatWebhookAdd(cl, g),
+		newCmdChatWebhookIncoming(cl, g),
+		newCmdChatWebhookList(cl, g),
+		newCmdChatWebhookRemove(cl, g),
+		newCmdChatWebhookServe(cl, g),
+	}
+	sort.Sort(cli.ByName(subcommands))
+	return cli.Command{
+		Name:         "webhook",
+		Usage:        "Forward chat notifications to HTTP endpoints, and post HTTP requests into chat",
+		ArgumentHelp: "[arguments...]",
+		Subcommands:  subcommands,
+	}
+}
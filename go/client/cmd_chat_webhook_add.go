@@ -0,0 +1,125 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+type CmdChatWebhookAdd struct {
+	libkb.Contextified
+	url            string
+	channelFilters []ChatChannel
+	convs          bool
+	wallet         bool
+}
+
+func newCmdChatWebhookAdd(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "add",
+		Usage:        "Forward chat notifications to an HTTP endpoint",
+		ArgumentHelp: "<url>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookAdd{
+				Contextified: libkb.NewContextified(g),
+			}, "add", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "convs",
+				Usage: "Forward notifications of new conversations",
+			},
+			cli.BoolFlag{
+				Name:  "wallet",
+				Usage: "Forward notifications for wallet events",
+			},
+			cli.StringFlag{
+				Name:  "filter-channel",
+				Usage: "Only forward messages of the specified (one) channel.",
+			},
+			cli.StringFlag{
+				Name:  "filter-channels",
+				Usage: "Only forward messages of the specified list of channels.",
+			},
+		},
+		Description: `"keybase chat webhook add" makes the service POST the notifications that
+   "keybase chat api-listen" prints to a URL, whether or not any client is
+   running. The filters are the same as the ones of api-listen. The URL must
+   be https, unless it points at localhost.
+
+   Every request carries the headers:
+
+      X-Keybase-Webhook-Id: the id of the webhook
+      X-Keybase-Delivery:   a unique id of the delivery
+      X-Keybase-Event:      "chat", "chat_conv" or "wallet"
+      X-Keybase-Timestamp:  unix time the request was sent at
+      X-Keybase-Signature:  "sha256=" and the hex HMAC-SHA256 of
+                            "<timestamp>.<body>", keyed with the webhook secret
+
+   The secret is printed when the webhook is added. Deliveries that fail with
+   a network error, a 429 or a 5xx status are retried with backoff for over
+   10 minutes. The ids of deliveries that keep failing are logged, without
+   their payload, to keybase.webhooks.deadletter.log in the log directory.
+
+   Example:
+
+      keybase chat webhook add --filter-channel '{"name":"alice,bob"}' http://localhost:8080/keybase
+`,
+	}
+}
+
+func (c *CmdChatWebhookAdd) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return errors.New("webhook add takes a URL")
+	}
+	c.url = ctx.Args()[0]
+	c.convs = ctx.Bool("convs")
+	c.wallet = ctx.Bool("wallet")
+	c.channelFilters, err = parseChannelFilterFlags(ctx)
+	return err
+}
+
+func (c *CmdChatWebhookAdd) Run() error {
+	ctx := context.Background()
+	convIDs, err := resolveChannelFilters(ctx, c.G(), newChatServiceHandler(c.G()), c.channelFilters)
+	if err != nil {
+		return err
+	}
+	channels := make([]chat1.ChatChannel, 0, len(c.channelFilters))
+	for _, channel := range c.channelFilters {
+		channels = append(channels, chat1.ChatChannel(channel))
+	}
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	hook, err := client.AddChatWebhookLocal(ctx, chat1.AddChatWebhookLocalArg{
+		Url:            c.url,
+		FilterChannels: channels,
+		FilterConvIDs:  convIDs,
+		Convs:          c.convs,
+		Wallet:         c.wallet,
+	})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	ui.Printf("Added webhook %s\n", hook.Id)
+	ui.Printf("Secret: %s\n", hook.Secret)
+	return nil
+}
+
+func (c *CmdChatWebhookAdd) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
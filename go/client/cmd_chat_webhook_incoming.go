@@ -0,0 +1,27 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"sort"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+)
+
+func newCmdChatWebhookIncoming(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	subcommands := []cli.Command{
+		newCmdChatWebhookIncomingAdd(cl, g),
+		newCmdChatWebhookIncomingList(cl, g),
+		newCmdChatWebhookIncomingRemove(cl, g),
+	}
+	sort.Sort(cli.ByName(subcommands))
+	return cli.Command{
+		Name:         "incoming",
+		Usage:        "Post HTTP requests into conversations",
+		ArgumentHelp: "[arguments...]",
+		Subcommands:  subcommands,
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/webhooks"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	isatty "github.com/mattn/go-isatty"
+)
+
+type CmdChatWebhookIncomingAdd struct {
+	libkb.Contextified
+	resolvingRequest chatConversationResolvingRequest
+	hasTTY           bool
+	template         string
+	rateLimit        int
+}
+
+func newCmdChatWebhookIncomingAdd(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "add",
+		Usage:        "Add a webhook that posts into a conversation",
+		ArgumentHelp: "[conversation]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookIncomingAdd{
+				Contextified: libkb.NewContextified(g),
+			}, "add", c)
+			cl.SetNoStandalone()
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: append(getConversationResolverFlags(),
+			cli.StringFlag{
+				Name:  "template",
+				Usage: "Go text/template that renders the message from the request",
+			},
+			cli.StringFlag{
+				Name:  "template-file",
+				Usage: "Read the template from a file",
+			},
+			cli.IntFlag{
+				Name:  "rate-limit",
+				Value: webhooks.DefaultIncomingRateLimit,
+				Usage: "Maximum number of messages a minute",
+			},
+		),
+		Description: `"keybase chat webhook incoming add" adds a webhook that posts the requests
+   it gets into a conversation. Requests go to the webhook server of the
+   service, which is started with "keybase chat webhook serve", at
+   /hooks/<id>.
+
+   Requests are authenticated with the secret of the webhook, printed when it
+   is added, in either of:
+
+      X-Keybase-Webhook-Token: <secret>
+      Authorization: Bearer <secret>
+
+   or with an X-Keybase-Signature and X-Keybase-Timestamp header, made the
+   same way as the ones of "keybase chat webhook add". Signed requests can't
+   carry attachments.
+
+   The body can be JSON, a form, or a multipart form. The "text" field is
+   posted, unless the webhook has a template, which is given the JSON body
+   or the form fields. A form can also carry its JSON in a "payload" field.
+   A multipart form can attach a file in its "attachment" field, the text is
+   then used as its title. Requests over the rate limit get a 429 with a
+   Retry-After header.
+
+   Examples:
+
+      keybase chat webhook incoming add --channel builds myteam
+      keybase chat webhook incoming add --template \
+          '{{.repository.name}}: build {{.build.status}} ({{.build.url}})' alice,bob
+
+      curl -H "X-Keybase-Webhook-Token: $SECRET" -d '{"text":"deployed"}' \
+          http://127.0.0.1:$PORT/hooks/$ID
+      curl -H "X-Keybase-Webhook-Token: $SECRET" -F text="test report" \
+          -F attachment=@report.html http://127.0.0.1:$PORT/hooks/$ID
+`,
+	}
+}
+
+func (c *CmdChatWebhookIncomingAdd) ParseArgv(ctx *cli.Context) (err error) {
+	c.hasTTY = isatty.IsTerminal(os.Stdin.Fd())
+	var tlfName string
+	if len(ctx.Args()) > 1 {
+		return errors.New("webhook incoming add takes at most one conversation")
+	}
+	if len(ctx.Args()) == 1 {
+		tlfName = ctx.Args().Get(0)
+	}
+	if c.resolvingRequest, err = parseConversationResolvingRequest(ctx, tlfName); err != nil {
+		return err
+	}
+	c.template = ctx.String("template")
+	if path := ctx.String("template-file"); len(path) > 0 {
+		if len(c.template) > 0 {
+			return errors.New("only one of --template and --template-file can be given")
+		}
+		dat, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		c.template = string(dat)
+	}
+	c.rateLimit = ctx.Int("rate-limit")
+	if c.rateLimit <= 0 {
+		return errors.New("--rate-limit must be positive")
+	}
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingAdd) Run() error {
+	resolver, conv, err := resolveToConversation(c.G(), c.resolvingRequest, c.hasTTY)
+	if err != nil {
+		return err
+	}
+	hook, err := resolver.ChatClient.AddChatIncomingWebhookLocal(context.Background(),
+		chat1.AddChatIncomingWebhookLocalArg{
+			ConvID:    conv.GetConvID(),
+			Template:  c.template,
+			RateLimit: c.rateLimit,
+		})
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	ui.Printf("Added incoming webhook %s for %s\n", hook.Id, hook.ConvName)
+	ui.Printf("Path: %s%s\n", webhooks.IncomingPathPrefix, hook.Id)
+	ui.Printf("Secret: %s\n", hook.Secret)
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingAdd) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
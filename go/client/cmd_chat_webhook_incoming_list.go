@@ -0,0 +1,84 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/webhooks"
+	"github.com/keybase/client/go/chatrender"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	gregor1 "github.com/keybase/client/go/protocol/gregor1"
+)
+
+type CmdChatWebhookIncomingList struct {
+	libkb.Contextified
+	showSecrets bool
+}
+
+func newCmdChatWebhookIncomingList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "List incoming webhooks",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookIncomingList{
+				Contextified: libkb.NewContextified(g),
+			}, "list", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "show-secrets",
+				Usage: "Show the secrets of the webhooks",
+			},
+		},
+	}
+}
+
+func (c *CmdChatWebhookIncomingList) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return fmt.Errorf("no arguments required")
+	}
+	c.showSecrets = ctx.Bool("show-secrets")
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingList) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	hooks, err := client.ListChatIncomingWebhooksLocal(context.TODO())
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	if len(hooks) == 0 {
+		ui.Printf("No incoming webhooks\n")
+		return nil
+	}
+	for _, hook := range hooks {
+		ui.Printf("ID: %s\nPath: %s%s\nConversation: %s\nRate limit: %d messages a minute\nAdded: %s\n",
+			hook.Id, webhooks.IncomingPathPrefix, hook.Id, hook.ConvName, hook.RateLimit,
+			chatrender.FmtTime(gregor1.FromTime(hook.Ctime), chatrender.RenderOptions{UseDateTime: true}))
+		if len(hook.Template) > 0 {
+			ui.Printf("Template: %s\n", hook.Template)
+		}
+		if c.showSecrets {
+			ui.Printf("Secret: %s\n", hook.Secret)
+		}
+		ui.Printf("\n")
+	}
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingList) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+)
+
+type CmdChatWebhookIncomingRemove struct {
+	libkb.Contextified
+	id string
+}
+
+func newCmdChatWebhookIncomingRemove(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "remove",
+		Usage:        "Remove an incoming webhook",
+		ArgumentHelp: "<id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookIncomingRemove{
+				Contextified: libkb.NewContextified(g),
+			}, "remove", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+	}
+}
+
+func (c *CmdChatWebhookIncomingRemove) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("webhook incoming remove takes the id of a webhook")
+	}
+	c.id = ctx.Args()[0]
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingRemove) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	if err := client.RemoveChatIncomingWebhookLocal(context.TODO(), c.id); err != nil {
+		return err
+	}
+	c.G().UI.GetTerminalUI().Printf("Removed incoming webhook %s\n", c.id)
+	return nil
+}
+
+func (c *CmdChatWebhookIncomingRemove) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chatrender"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	gregor1 "github.com/keybase/client/go/protocol/gregor1"
+)
+
+type CmdChatWebhookList struct {
+	libkb.Contextified
+	showSecrets bool
+}
+
+func newCmdChatWebhookList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "List chat webhooks",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookList{
+				Contextified: libkb.NewContextified(g),
+			}, "list", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "show-secrets",
+				Usage: "Show the signing secrets of the webhooks",
+			},
+		},
+	}
+}
+
+func (c *CmdChatWebhookList) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return fmt.Errorf("no arguments required")
+	}
+	c.showSecrets = ctx.Bool("show-secrets")
+	return nil
+}
+
+func (c *CmdChatWebhookList) Run() error {
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	hooks, err := client.ListChatWebhooksLocal(context.TODO())
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	if len(hooks) == 0 {
+		ui.Printf("No webhooks\n")
+		return nil
+	}
+	for _, hook := range hooks {
+		var events []string
+		if len(hook.FilterChannels) == 0 {
+			events = append(events, "all messages")
+		} else {
+			for _, channel := range hook.FilterChannels {
+				dat, _ := json.Marshal(channel)
+				events = append(events, string(dat))
+			}
+		}
+		if hook.Convs {
+			events = append(events, "new conversations")
+		}
+		if hook.Wallet {
+			events = append(events, "wallet")
+		}
+		ui.Printf("ID: %s\nURL: %s\nEvents: %s\nAdded: %s\n", hook.Id, hook.Url, strings.Join(events, ", "),
+			chatrender.FmtTime(gregor1.FromTime(hook.Ctime), chatrender.RenderOptions{UseDateTime: true}))
+		if c.showSecrets {
+			ui.Printf("Secret: %s\n", hook.Secret)
+		}
+		ui.Printf("\n")
+	}
+	return nil
+}
+
+func (c *CmdChatWebhookList) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/chat/webhooks"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+)
+
+type CmdChatWebhookServe struct {
+	libkb.Contextified
+	port int
+	stop bool
+}
+
+func newCmdChatWebhookServe(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:  "serve",
+		Usage: "Start the server of incoming webhooks",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdChatWebhookServe{
+				Contextified: libkb.NewContextified(g),
+			}, "serve", c)
+			cl.SetLogForward(libcmdline.LogForwardNone)
+		},
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "port",
+				Usage: "Port to listen on. Defaults to the current port, or a free one.",
+			},
+			cli.BoolFlag{
+				Name:  "stop",
+				Usage: "Stop the server",
+			},
+		},
+		Description: `"keybase chat webhook serve" starts the HTTP server that incoming webhooks
+   post to, on localhost. The service keeps running the server, on the same
+   port, until "keybase chat webhook serve --stop".
+`,
+	}
+}
+
+func (c *CmdChatWebhookServe) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return errors.New("no arguments required")
+	}
+	c.port = ctx.Int("port")
+	c.stop = ctx.Bool("stop")
+	if c.stop && c.port != 0 {
+		return errors.New("--port can't be given with --stop")
+	}
+	if c.port < 0 || c.port > 65535 {
+		return errors.New("invalid --port")
+	}
+	return nil
+}
+
+func (c *CmdChatWebhookServe) Run() error {
+	ctx := context.Background()
+	client, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	ui := c.G().UI.GetTerminalUI()
+	if c.stop {
+		if err := client.StopChatWebhookServerLocal(ctx); err != nil {
+			return err
+		}
+		ui.Printf("Stopped the webhook server\n")
+		return nil
+	}
+	addr, err := client.StartChatWebhookServerLocal(ctx, c.port)
+	if err != nil {
+		return err
+	}
+	ui.Printf("Serving webhooks on http://%s\n", addr)
+	hooks, err := client.ListChatIncomingWebhooksLocal(ctx)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		ui.Printf("  %s: http://%s%s%s\n", hook.ConvName, addr, webhooks.IncomingPathPrefix, hook.Id)
+	}
+	return nil
+}
+
+func (c *CmdChatWebhookServe) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
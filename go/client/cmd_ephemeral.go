@@ -0,0 +1,213 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+func newCmdEphemeral(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "ephemeral",
+		Usage:        "Inspect and repair ephemeral (exploding message) keys",
+		ArgumentHelp: "[arguments...]",
+		Subcommands: []cli.Command{
+			newCmdEphemeralStatus(cl, g),
+			newCmdEphemeralRepair(cl, g),
+		},
+	}
+}
+
+type CmdEphemeralStatus struct {
+	libkb.Contextified
+	json bool
+}
+
+func newCmdEphemeralStatus(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &CmdEphemeralStatus{Contextified: libkb.NewContextified(g)}
+	return cli.Command{
+		Name:  "status",
+		Usage: "List the locally stored ephemeral keys of every layer",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "Output status as JSON",
+			},
+		},
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "status", c)
+		},
+	}
+}
+
+func (c *CmdEphemeralStatus) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return UnexpectedArgsError("ephemeral status")
+	}
+	c.json = ctx.Bool("json")
+	return nil
+}
+
+func (c *CmdEphemeralStatus) Run() error {
+	cli, err := GetEphemeralClient(c.G())
+	if err != nil {
+		return err
+	}
+	storages, err := cli.GetEkStatus(context.Background(), 0)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return outputEphemeralJSON(c.G(), storages)
+	}
+	renderEphemeralStatus(c.G(), storages)
+	return nil
+}
+
+func (c *CmdEphemeralStatus) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
+
+type CmdEphemeralRepair struct {
+	libkb.Contextified
+	json  bool
+	force bool
+}
+
+func newCmdEphemeralRepair(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &CmdEphemeralRepair{Contextified: libkb.NewContextified(g)}
+	return cli.Command{
+		Name: "repair",
+		Usage: `List unreadable ephemeral keys to drop and missing boxes to re-fetch
+	from the server; with --force, repair them`,
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "f, force",
+				Usage: "Delete and re-fetch keys and generate new device and user keys if needed",
+			},
+			cli.BoolFlag{
+				Name:  "j, json",
+				Usage: "Output result as JSON",
+			},
+		},
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "repair", c)
+		},
+	}
+}
+
+func (c *CmdEphemeralRepair) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) > 0 {
+		return UnexpectedArgsError("ephemeral repair")
+	}
+	c.json = ctx.Bool("json")
+	c.force = ctx.Bool("force")
+	return nil
+}
+
+func (c *CmdEphemeralRepair) Run() error {
+	cli, err := GetEphemeralClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.RepairEks(context.Background(), keybase1.RepairEksArg{Force: c.force})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return outputEphemeralJSON(c.G(), res)
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if res.DryRun {
+		if len(res.Actions) == 0 {
+			dui.Printf("Nothing to repair.\n")
+			return nil
+		}
+		dui.Printf("A repair would:\n")
+		for _, action := range res.Actions {
+			dui.Printf("    %s\n", action)
+		}
+		dui.Printf("\nRun `keybase ephemeral repair --force` to do so.\n")
+		return nil
+	}
+	dui.Printf("Deleted %d and re-fetched %d ephemeral keys.\n", res.Deleted, res.Refetched)
+	for _, msg := range res.Errors {
+		dui.PrintfStderr("%s %s\n", ColorString(c.G(), "yellow", "Warning:"), msg)
+	}
+	dui.Printf("\n")
+	renderEphemeralStatus(c.G(), res.Storages)
+	return nil
+}
+
+func (c *CmdEphemeralRepair) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config: true,
+		API:    true,
+	}
+}
+
+func outputEphemeralJSON(g *libkb.GlobalContext, obj any) error {
+	b, err := json.MarshalIndent(obj, "", "    ")
+	if err != nil {
+		return err
+	}
+	g.UI.GetDumbOutputUI().Printf("%s\n", b)
+	return nil
+}
+
+func ephemeralStorageName(storage keybase1.EkStorageStatus) string {
+	var name string
+	switch storage.Layer {
+	case keybase1.EkStorageLayer_DEVICE:
+		return "Device EKs"
+	case keybase1.EkStorageLayer_USER:
+		return "User EKs"
+	case keybase1.EkStorageLayer_TEAM:
+		name = "Team EKs"
+	case keybase1.EkStorageLayer_TEAMBOT:
+		name = "Teambot EKs"
+	default:
+		return storage.Layer.String()
+	}
+	if storage.TeamName != "" {
+		return fmt.Sprintf("%s for %s (%s)", name, storage.TeamName, storage.TeamID)
+	}
+	return fmt.Sprintf("%s for %s", name, storage.TeamID)
+}
+
+func renderEphemeralStatus(g *libkb.GlobalContext, storages []keybase1.EkStorageStatus) {
+	dui := g.UI.GetDumbOutputUI()
+	for _, storage := range storages {
+		dui.Printf("%s:\n", ephemeralStorageName(storage))
+		if len(storage.Generations) == 0 {
+			dui.Printf("    none stored\n")
+		}
+		for _, gen := range storage.Generations {
+			if gen.Error != "" {
+				dui.Printf("    %4d  %s %s\n", gen.Generation, ColorString(g, "red", "error:"), gen.Error)
+				continue
+			}
+			dui.Printf("    %4d  created %s\n", gen.Generation, humanize.Time(gen.Ctime.Time()))
+		}
+		if len(storage.Gaps) > 0 {
+			gaps := make([]string, 0, len(storage.Gaps))
+			for _, gap := range storage.Gaps {
+				gaps = append(gaps, fmt.Sprintf("%d", gap))
+			}
+			dui.Printf("    %s %s\n", ColorString(g, "yellow", "missing generations:"), strings.Join(gaps, ", "))
+		}
+	}
+}
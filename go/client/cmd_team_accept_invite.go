@@ -3,6 +3,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
@@ -13,13 +16,14 @@ import (
 
 type CmdTeamAcceptInvite struct {
 	libkb.Contextified
-	Token string
+	Token   string
+	Payload teamInviteQRPayload
 }
 
 func newCmdTeamAcceptInvite(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
 	return cli.Command{
 		Name:         "accept-invite",
-		ArgumentHelp: "--token=<invite token>",
+		ArgumentHelp: "--token=<invite token> | --from-image=<png file>",
 		Usage:        "Accept a team email invitation.",
 		Action: func(c *cli.Context) {
 			cmd := NewCmdTeamAcceptInviteRunner(g)
@@ -30,6 +34,10 @@ func newCmdTeamAcceptInvite(cl *libcmdline.CommandLine, g *libkb.GlobalContext)
 				Name:  "token",
 				Usage: "token",
 			},
+			cli.StringFlag{
+				Name:  "from-image",
+				Usage: "read the invite token from a QR code image",
+			},
 		},
 	}
 }
@@ -39,10 +47,26 @@ func NewCmdTeamAcceptInviteRunner(g *libkb.GlobalContext) *CmdTeamAcceptInvite {
 }
 
 func (c *CmdTeamAcceptInvite) ParseArgv(ctx *cli.Context) error {
-	c.Token = ctx.String("token")
-	if len(c.Token) == 0 {
+	token := ctx.String("token")
+	image := ctx.String("from-image")
+	var err error
+	switch {
+	case len(token) > 0 && len(image) > 0:
+		return errors.New("only one of --token and --from-image can be specified")
+	case len(image) > 0:
+		c.Payload, err = readTeamInviteQRImage(image)
+	case len(token) > 0:
+		c.Payload, err = parseTeamInviteQRPayload(token)
+	default:
 		return errors.New("please specify an invite token with the --token flag")
 	}
+	if err != nil {
+		return err
+	}
+	if c.Payload.isExpired(time.Now()) {
+		return fmt.Errorf("this invite expired on %s", c.Payload.Expires.Time().Format(time.RFC1123))
+	}
+	c.Token = c.Payload.Token
 
 	return nil
 }
@@ -59,6 +83,17 @@ func (c *CmdTeamAcceptInvite) Run() error {
 		return err
 	}
 
+	if c.Payload.Team != "" || c.Payload.Role != keybase1.TeamRole_NONE {
+		desc := "Accepting an invitation"
+		if c.Payload.Team != "" {
+			desc += " to " + c.Payload.Team
+		}
+		if c.Payload.Role != keybase1.TeamRole_NONE {
+			desc += " as " + strings.ToLower(c.Payload.Role.String())
+		}
+		c.G().UI.GetDumbOutputUI().Printf("%s...\n", desc)
+	}
+
 	arg := keybase1.TeamAcceptInviteArg{
 		Token: c.Token,
 	}
@@ -22,6 +22,7 @@ type CmdTeamAddMember struct {
 	Username             string
 	Role                 keybase1.TeamRole
 	BotSettings          *keybase1.TeamBotSettings
+	BotSenders           []string
 	SkipChatNotification bool
 	EmailInviteMessage   *string
 }
@@ -109,7 +110,7 @@ func (c *CmdTeamAddMember) ParseArgv(ctx *cli.Context) error {
 	c.SkipChatNotification = ctx.Bool("skip-chat-message")
 
 	if c.Role.IsRestrictedBot() {
-		c.BotSettings = ParseBotSettings(ctx)
+		c.BotSettings, c.BotSenders = ParseBotSettings(ctx)
 	}
 
 	return nil
@@ -121,8 +122,8 @@ func (c *CmdTeamAddMember) Run() error {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), c.Team,
-		chat1.ConversationMembersType_TEAM, c.BotSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), c.Team,
+		chat1.ConversationMembersType_TEAM, c.BotSettings, c.BotSenders); err != nil {
 		return err
 	}
 
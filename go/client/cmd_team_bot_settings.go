@@ -18,6 +18,7 @@ type CmdTeamBotSettings struct {
 	Team        string
 	Username    string
 	BotSettings *keybase1.TeamBotSettings
+	BotSenders  []string
 }
 
 func newCmdTeamBotSettings(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -54,7 +55,7 @@ func (c *CmdTeamBotSettings) ParseArgv(ctx *cli.Context) error {
 		return err
 	}
 
-	c.BotSettings = ParseBotSettings(ctx)
+	c.BotSettings, c.BotSenders = ParseBotSettings(ctx)
 	return nil
 }
 
@@ -64,8 +65,8 @@ func (c *CmdTeamBotSettings) Run() error {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), c.Team,
-		chat1.ConversationMembersType_TEAM, c.BotSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), c.Team,
+		chat1.ConversationMembersType_TEAM, c.BotSettings, c.BotSenders); err != nil {
 		return err
 	}
 
@@ -98,7 +99,7 @@ func (c *CmdTeamBotSettings) Run() error {
 
 func renderBotSettings(g *libkb.GlobalContext, username string, convID *chat1.ConversationID, botSettings keybase1.TeamBotSettings) error {
 	var output string
-	if botSettings.Cmds {
+	if botSettings.Cmds || botSettings.CmdsOnly {
 		chatClient, err := GetChatLocalClient(g)
 		if err != nil {
 			return fmt.Errorf("Getting chat service client error: %s", err)
@@ -129,16 +130,20 @@ func renderBotSettings(g *libkb.GlobalContext, username string, convID *chat1.Co
 		}
 	}
 
-	if botSettings.Mentions {
-		output += "\t- when @-mentioned\n"
-	}
+	if botSettings.CmdsOnly {
+		output += "\t- only command messages, even when @-mentioned or a trigger matches\n"
+	} else {
+		if botSettings.Mentions {
+			output += "\t- when @-mentioned\n"
+		}
 
-	if len(botSettings.Triggers) > 0 {
-		output += "\t- messages that match the following:\n\t\t"
-		for _, trigger := range botSettings.Triggers {
-			output += fmt.Sprintf("%q\n\t\t", trigger)
+		if len(botSettings.Triggers) > 0 {
+			output += "\t- messages that match the following:\n\t\t"
+			for _, trigger := range botSettings.Triggers {
+				output += fmt.Sprintf("%q\n\t\t", trigger)
+			}
+			output += "\n"
 		}
-		output += "\n"
 	}
 
 	dui := g.UI.GetDumbOutputUI()
@@ -147,6 +152,13 @@ func renderBotSettings(g *libkb.GlobalContext, username string, convID *chat1.Co
 	} else {
 		dui.Printf("%s will receive messages in the following cases:\n%s", username, output)
 	}
+	if len(botSettings.Senders) > 0 {
+		senders, err := getSenderNames(g, botSettings.Senders)
+		if err != nil {
+			return err
+		}
+		dui.Printf("%s will only receive messages sent by:\n\t%s\n", username, strings.Join(senders, "\n\t"))
+	}
 	if len(botSettings.Convs) == 0 {
 		dui.Printf("%s can send/receive into all conversations", username)
 	} else {
@@ -165,6 +177,21 @@ func renderBotSettings(g *libkb.GlobalContext, username string, convID *chat1.Co
 	return nil
 }
 
+func getSenderNames(g *libkb.GlobalContext, uids []keybase1.UID) (usernames []string, err error) {
+	cli, err := GetUserClient(g)
+	if err != nil {
+		return nil, err
+	}
+	for _, uid := range uids {
+		upak, err := cli.GetUPAKLite(context.TODO(), uid)
+		if err != nil {
+			return nil, err
+		}
+		usernames = append(usernames, upak.Current.Username)
+	}
+	return usernames, nil
+}
+
 func getConvNames(g *libkb.GlobalContext, convs []chat1.ConvIDStr) (convNames []string, err error) {
 	fetcher := chatCLIInboxFetcher{}
 	for _, convIDStr := range convs {
@@ -207,4 +234,8 @@ View current bot settings:
 Specify new bot settings:
 
     keybase team bot-settings acme -u alice --allow-mentions --triggers foo --triggers bar --allowed-convs #general
+
+Only let the bot read messages invoking its commands, sent by bob or carol:
+
+    keybase team bot-settings acme -u alice --allow-commands-only --allow-sender bob --allow-sender carol
 `
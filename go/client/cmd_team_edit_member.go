@@ -19,6 +19,7 @@ type CmdTeamEditMember struct {
 	Username    string
 	Role        keybase1.TeamRole
 	BotSettings *keybase1.TeamBotSettings
+	BotSenders  []string
 }
 
 func newCmdTeamEditMember(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -63,7 +64,7 @@ func (c *CmdTeamEditMember) ParseArgv(ctx *cli.Context) error {
 	}
 
 	if c.Role.IsRestrictedBot() {
-		c.BotSettings = ParseBotSettings(ctx)
+		c.BotSettings, c.BotSenders = ParseBotSettings(ctx)
 	}
 
 	return nil
@@ -75,8 +76,8 @@ func (c *CmdTeamEditMember) Run() error {
 		return err
 	}
 
-	if err := ValidateBotSettingsConvs(c.G(), c.Team,
-		chat1.ConversationMembersType_TEAM, c.BotSettings); err != nil {
+	if err := ValidateBotSettings(c.G(), c.Team,
+		chat1.ConversationMembersType_TEAM, c.BotSettings, c.BotSenders); err != nil {
 		return err
 	}
 
@@ -6,11 +6,16 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/keybase/cli"
+	"github.com/keybase/client/go/kbtime"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/qrcode"
 )
 
 type CmdTeamGenerateSeitan struct {
@@ -19,6 +24,11 @@ type CmdTeamGenerateSeitan struct {
 	Role     keybase1.TeamRole
 	FullName string
 	Number   string
+
+	QR        bool
+	QRPNG     string
+	QRDetails bool
+	QRExpires *keybase1.UnixTime
 }
 
 func newCmdTeamGenerateSeitan(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -43,6 +53,22 @@ func newCmdTeamGenerateSeitan(cl *libcmdline.CommandLine, g *libkb.GlobalContext
 				Name:  "number",
 				Usage: "invitee's phone number",
 			},
+			cli.BoolFlag{
+				Name:  "qr",
+				Usage: "also display the token as a QR code",
+			},
+			cli.StringFlag{
+				Name:  "qr-png",
+				Usage: "write the QR code as a PNG image to this file",
+			},
+			cli.BoolFlag{
+				Name:  "qr-details",
+				Usage: "include the team name and role in the QR code",
+			},
+			cli.StringFlag{
+				Name:  "qr-duration",
+				Usage: "include an expiry in the QR code, after which it is refused (1D, 3M, 5Y, etc.)",
+			},
 		},
 		Description: teamGenerateSeitanDoc,
 	}
@@ -70,6 +96,21 @@ func (c *CmdTeamGenerateSeitan) ParseArgv(ctx *cli.Context) error {
 	c.FullName = ctx.String("fullname")
 	c.Number = ctx.String("number")
 
+	c.QRPNG = ctx.String("qr-png")
+	c.QR = ctx.Bool("qr") || c.QRPNG != ""
+	c.QRDetails = ctx.Bool("qr-details")
+	if ctx.IsSet("qr-duration") {
+		then, err := kbtime.AddLongDuration(time.Now(), ctx.String("qr-duration"))
+		if err != nil {
+			return fmt.Errorf("failed to compute expiration date: %w", err)
+		}
+		t := keybase1.ToUnixTime(then)
+		c.QRExpires = &t
+	}
+	if !c.QR && (c.QRDetails || c.QRExpires != nil) {
+		return errors.New("--qr-details and --qr-duration require --qr or --qr-png")
+	}
+
 	return nil
 }
 
@@ -97,6 +138,35 @@ func (c *CmdTeamGenerateSeitan) Run() error {
 	dui := c.G().UI.GetDumbOutputUI()
 	dui.Printf("Generated token: %q.\nAnother Keybase user can join the team using the following command:\n\nkeybase team accept-invite --token %s\n", res, res)
 
+	if c.QR {
+		return c.outputQR(string(res))
+	}
+	return nil
+}
+
+func (c *CmdTeamGenerateSeitan) outputQR(token string) error {
+	payload := teamInviteQRPayload{
+		Token:   token,
+		Expires: c.QRExpires,
+	}
+	if c.QRDetails {
+		payload.Team = c.Team
+		payload.Role = c.Role
+	}
+	encodings, err := qrcode.Encode([]byte(payload.String()))
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	dui.Printf("\nOr they can scan this QR code:\n\n")
+	_, _ = dui.PrintfUnescaped("%s", encodings.Terminal)
+	if c.QRPNG != "" {
+		if err := os.WriteFile(c.QRPNG, encodings.PNG, 0644); err != nil {
+			return err
+		}
+		dui.Printf("\nThe QR code was saved to %s. It can be accepted with:\n\nkeybase team accept-invite --from-image %s\n", c.QRPNG, c.QRPNG)
+	}
 	return nil
 }
 
@@ -115,4 +185,12 @@ Optionally, full name and phone number can be provided (using
 --fullname and --number flags) to label created token to make them
 easier to distinguish. Label data is encrypted and visible only to
 admins.
+
+With --qr the token is also displayed as a QR code, and with --qr-png
+the QR code is saved as a PNG image that can be accepted with
+"keybase team accept-invite --from-image". --qr-details adds the team
+name and role to the QR code and --qr-duration adds an expiry after
+which "keybase team accept-invite" refuses it. These details are shown
+to the invitee but, unlike the token itself, are not verified by the
+server.
 `
@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/keybase/cli"
+	"github.com/keybase/client/go/kbtime"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
 	keybase1 "github.com/keybase/client/go/protocol/keybase1"
@@ -23,14 +25,17 @@ const (
 type CmdTeamSearch struct {
 	libkb.Contextified
 
-	query string
-	limit int
+	query        string
+	limit        int
+	filter       keybase1.TeamSearchFilter
+	sortOrder    keybase1.TeamSearchSortOrder
+	forceRefresh bool
 }
 
 func newCmdTeamSearch(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
 	return cli.Command{
 		Name:         "search",
-		ArgumentHelp: "<query>",
+		ArgumentHelp: "[<query>]",
 		Usage:        "Search for open teams on Keybase.",
 		Action: func(c *cli.Context) {
 			cmd := NewCmdTeamSearchRunner(g)
@@ -44,6 +49,27 @@ func newCmdTeamSearch(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 					"How many teams to return at most (default %d)",
 					defaultTeamSearchLimit),
 			},
+			cli.IntFlag{
+				Name:  "min-members",
+				Usage: "Only show teams with at least this many members",
+			},
+			cli.IntFlag{
+				Name:  "max-members",
+				Usage: "Only show teams with at most this many members",
+			},
+			cli.StringFlag{
+				Name:  "active-within",
+				Usage: "Only show teams active within this duration (1h, 7D, 3M, etc.)",
+			},
+			cli.StringFlag{
+				Name:  "sort",
+				Value: "relevance",
+				Usage: "Sort by relevance, members, activity or name",
+			},
+			cli.BoolFlag{
+				Name:  "refresh",
+				Usage: "Refresh the local team directory before searching",
+			},
 		},
 	}
 }
@@ -52,18 +78,66 @@ func NewCmdTeamSearchRunner(g *libkb.GlobalContext) *CmdTeamSearch {
 	return &CmdTeamSearch{Contextified: libkb.NewContextified(g)}
 }
 
+func parseTeamSearchSortOrder(s string) (keybase1.TeamSearchSortOrder, error) {
+	switch strings.ToLower(s) {
+	case "", "relevance":
+		return keybase1.TeamSearchSortOrder_RELEVANCE, nil
+	case "members":
+		return keybase1.TeamSearchSortOrder_MEMBER_COUNT, nil
+	case "activity":
+		return keybase1.TeamSearchSortOrder_LAST_ACTIVE, nil
+	case "name":
+		return keybase1.TeamSearchSortOrder_NAME, nil
+	default:
+		return 0, fmt.Errorf("invalid sort order %q, please use relevance, members, activity or name", s)
+	}
+}
+
+// parseTeamSearchActiveWithin converts a duration such as "7D" into the
+// earliest last activity time a team may have to be included in results.
+func parseTeamSearchActiveWithin(s string) (*keybase1.Time, error) {
+	now := time.Now()
+	then, err := kbtime.AddLongDuration(now, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid activity duration: %w", err)
+	}
+	since := keybase1.ToTime(now.Add(-then.Sub(now)))
+	return &since, nil
+}
+
 func (c *CmdTeamSearch) ParseArgv(ctx *cli.Context) (err error) {
-	if len(ctx.Args()) != 1 {
-		return errors.New("usage: keybase team search <query>")
+	switch len(ctx.Args()) {
+	case 0:
+	case 1:
+		c.query = ctx.Args().Get(0)
+	default:
+		return errors.New("usage: keybase team search [<query>]")
 	}
-	c.query = ctx.Args().Get(0)
 	c.limit = ctx.Int("limit")
+	c.filter.MinMemberCount = ctx.Int("min-members")
+	c.filter.MaxMemberCount = ctx.Int("max-members")
+	if c.filter.MinMemberCount < 0 || c.filter.MaxMemberCount < 0 {
+		return errors.New("member counts must not be negative")
+	}
+	if c.filter.MaxMemberCount > 0 && c.filter.MinMemberCount > c.filter.MaxMemberCount {
+		return errors.New("--min-members must not be greater than --max-members")
+	}
+	if ctx.IsSet("active-within") {
+		if c.filter.ActiveSince, err = parseTeamSearchActiveWithin(ctx.String("active-within")); err != nil {
+			return err
+		}
+	}
+	if c.sortOrder, err = parseTeamSearchSortOrder(ctx.String("sort")); err != nil {
+		return err
+	}
+	c.forceRefresh = ctx.Bool("refresh")
 	return nil
 }
 
 func renderTeamSearchItem(item keybase1.TeamSearchItem) (res string) {
-	res = fmt.Sprintf("%s (%d members) (last active %s) \n",
+	res = fmt.Sprintf("%s (%d members) (last active %s)",
 		item.Name, item.MemberCount, humanize.Time(item.LastActive.Time()))
+	res += " \n"
 	if item.Description != nil {
 		res += fmt.Sprintf(
 			"\t%s\n", strings.ReplaceAll(*item.Description, "\n", "\n\t"))
@@ -84,9 +158,12 @@ func (c *CmdTeamSearch) Run() error {
 		return err
 	}
 
-	res, err := cli.TeamSearch(ctx, keybase1.TeamSearchArg{
-		Query: c.query,
-		Limit: c.limit,
+	res, err := cli.TeamSearchDirectory(ctx, keybase1.TeamSearchDirectoryArg{
+		Query:        c.query,
+		Limit:        c.limit,
+		Filter:       c.filter,
+		SortOrder:    c.sortOrder,
+		ForceRefresh: c.forceRefresh,
 	})
 	if err != nil {
 		return err
@@ -27,6 +27,8 @@ type CmdTeamSettings struct {
 	AllowProfilePromote   *bool
 	Showcase              *bool
 	DisableAccessRequests *bool
+	UnfurlMode            *chat1.TeamUnfurlMode
+	UnfurlAllowlist       []string
 }
 
 func newCmdTeamSettings(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -47,6 +49,10 @@ Set a description for the team to show if promoted:
     keybase team settings acme --description="Rocket-Powered Products"
 Clear the team description:
     keybase team settings acme --description=""
+Only unfurl links to some domains in team conversations:
+    keybase team settings acme --unfurl-allowlist=github.com,keybase.io
+Never unfurl links in team conversations:
+    keybase team settings acme --unfurl-policy=disabled
 `,
 		Action: func(c *cli.Context) {
 			cmd := NewCmdTeamSettingsRunner(g)
@@ -84,6 +90,15 @@ Clear the team description:
 				Name:  "disable-access-requests",
 				Usage: "[yes|no] Set whether it should be possible to access request to this team",
 			},
+			cli.StringFlag{
+				Name: "unfurl-policy",
+				Usage: "[default|disabled|allowlist] Restrict link unfurling in team conversations for all members, " +
+					"overriding their own settings",
+			},
+			cli.StringFlag{
+				Name:  "unfurl-allowlist",
+				Usage: "Set the comma-separated domains members may unfurl under the allowlist policy",
+			},
 			// cli.StringFlag{
 			// 	Name:  "welcome-message",
 			// 	Usage: "Set a welcome message for new team members. Empty string for no welcome message.",
@@ -169,6 +184,27 @@ func (c *CmdTeamSettings) ParseArgv(ctx *cli.Context) (err error) {
 		c.DisableAccessRequests = &val
 	}
 
+	if ctx.IsSet("unfurl-policy") || ctx.IsSet("unfurl-allowlist") {
+		exclusiveActions = append(exclusiveActions, "unfurl-policy")
+		mode := chat1.TeamUnfurlMode_ALLOWLIST
+		if ctx.IsSet("unfurl-policy") {
+			var ok bool
+			mode, ok = chat1.TeamUnfurlModeMap[strings.ToUpper(ctx.String("unfurl-policy"))]
+			if !ok {
+				return fmt.Errorf("unfurl-policy must be one of [default|disabled|allowlist]")
+			}
+		}
+		c.UnfurlMode = &mode
+		if ctx.IsSet("unfurl-allowlist") {
+			c.UnfurlAllowlist = []string{}
+			for _, domain := range strings.Split(ctx.String("unfurl-allowlist"), ",") {
+				if domain = strings.TrimSpace(domain); len(domain) > 0 {
+					c.UnfurlAllowlist = append(c.UnfurlAllowlist, domain)
+				}
+			}
+		}
+	}
+
 	if ctx.IsSet("welcome-message") {
 		exclusiveActions = append(exclusiveActions, "welcome-message")
 		welcomeMessage := ctx.String("welcome-message")
@@ -249,6 +285,13 @@ func (c *CmdTeamSettings) Run() error {
 		}
 	}
 
+	if c.UnfurlMode != nil {
+		err = c.setUnfurlPolicy(ctx, *c.UnfurlMode, c.UnfurlAllowlist)
+		if err != nil {
+			return err
+		}
+	}
+
 	if c.WelcomeMessage != nil {
 		err = c.setWelcomeMessage(ctx, *c.WelcomeMessage)
 		if err != nil {
@@ -385,6 +428,29 @@ func (c *CmdTeamSettings) resetWelcomeMessage(ctx context.Context) error {
 	})
 }
 
+func (c *CmdTeamSettings) setUnfurlPolicy(ctx context.Context, mode chat1.TeamUnfurlMode, allowlist []string) error {
+	if err := CheckAndStartStandaloneChat(c.G(), chat1.ConversationMembersType_TEAM); err != nil {
+		return err
+	}
+	cli, err := GetChatLocalClient(c.G())
+	if err != nil {
+		return err
+	}
+	// keep the current allowlist unless we were given a new one
+	policy, err := cli.GetTeamUnfurlPolicy(ctx, c.teamID)
+	if err != nil {
+		return err
+	}
+	policy.Mode = mode
+	if allowlist != nil {
+		policy.Allowlist = allowlist
+	}
+	return cli.SetTeamUnfurlPolicy(ctx, chat1.SetTeamUnfurlPolicyArg{
+		TeamID: c.teamID,
+		Policy: policy,
+	})
+}
+
 func (c *CmdTeamSettings) printCurrentSettings(ctx context.Context, cli keybase1.TeamsClient) error {
 	res, err := cli.GetAnnotatedTeamByName(ctx, c.Team.String())
 	if err != nil {
@@ -451,6 +517,19 @@ func (c *CmdTeamSettings) printCurrentSettings(ctx context.Context, cli keybase1
 				dui.Printf("  Welcome message:          unset (default)\n")
 			}
 		}
+		policy, err := chatCli.GetTeamUnfurlPolicy(ctx, c.teamID)
+		if err != nil {
+			c.G().Log.CWarningf(ctx, "failed to get unfurl policy: %v", err)
+		} else {
+			switch policy.Mode {
+			case chat1.TeamUnfurlMode_DISABLED:
+				dui.Printf("  Unfurl policy:            disabled\n")
+			case chat1.TeamUnfurlMode_ALLOWLIST:
+				dui.Printf("  Unfurl policy:            allowlist (%s)\n", strings.Join(policy.Allowlist, ", "))
+			default:
+				dui.Printf("  Unfurl policy:            default (members' own settings)\n")
+			}
+		}
 	}
 
 	return nil
@@ -19,9 +19,11 @@ func newCmdWallet(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 		// newCmdWalletCancelAll(cl, g),
 		// newCmdWalletCancelRequest(cl, g),
 		// newCmdWalletChangeTrustlineLimit(cl, g),
+		newCmdWalletCosign(cl, g),
 		// newCmdWalletDeleteTrustline(cl, g),
 		newCmdWalletDetail(cl, g),
 		newCmdWalletExport(cl, g),
+		newCmdWalletExportHistory(cl, g),
 		// newCmdWalletGetInflation(cl, g),
 		// newCmdWalletGetStarted(cl, g),
 		// newCmdWalletHistory(cl, g),
@@ -30,7 +32,9 @@ func newCmdWallet(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comman
 		// newCmdWalletMerge(cl, g),
 		// newCmdWalletPopularAssets(cl, g),
 		// newCmdWalletRename(cl, g),
-		// newCmdWalletRequest(cl, g),
+		newCmdWalletRequest(cl, g),
+		newCmdWalletRequests(cl, g),
+		newCmdWalletSchedule(cl, g),
 		// newCmdWalletSend(cl, g),
 		// newCmdWalletSendPathPayment(cl, g),
 		// newCmdWalletSetCurrency(cl, g),
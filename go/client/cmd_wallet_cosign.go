@@ -0,0 +1,392 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+)
+
+func newCmdWalletCosign(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "cosign",
+		Usage:        "Manage and approve payments from multisig accounts",
+		ArgumentHelp: "[arguments...]",
+		Subcommands: []cli.Command{
+			newCmdWalletCosignRequest(cl, g),
+			newCmdWalletCosignSetup(cl, g),
+			newCmdWalletCosignSign(cl, g),
+			newCmdWalletCosignStatus(cl, g),
+		},
+	}
+}
+
+const cosignChannelUsage = `Chat conversation to send the transaction to for the other signers to
+	approve: either "team#channel" or a comma-separated list of users.`
+
+type cmdWalletCosignSetup struct {
+	libkb.Contextified
+	arg stellar1.CosignSetupLocalArg
+}
+
+func newCmdWalletCosignSetup(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletCosignSetup{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "setup",
+		Usage:        "Set the signers and thresholds of an account",
+		ArgumentHelp: "<account id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "setup", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name: "signer",
+				Usage: `Account ID of a signer, optionally followed by ":<weight>" (default 1).
+	Can be specified multiple times. Signers that are not listed are removed.`,
+			},
+			cli.IntFlag{
+				Name:  "master-weight",
+				Value: 1,
+				Usage: "Weight of the account's own key.",
+			},
+			cli.IntFlag{
+				Name:  "threshold",
+				Usage: "Shortcut to set the low, medium and high thresholds at once.",
+			},
+			cli.IntFlag{
+				Name:  "low",
+				Usage: "Weight needed for low threshold operations (allow trust, bump sequence).",
+			},
+			cli.IntFlag{
+				Name:  "medium",
+				Usage: "Weight needed for medium threshold operations (payments and most others).",
+			},
+			cli.IntFlag{
+				Name:  "high",
+				Usage: "Weight needed for high threshold operations (changing signers, merging the account).",
+			},
+			cli.StringFlag{
+				Name:  "channel",
+				Usage: cosignChannelUsage,
+			},
+		},
+		Description: `Turns an account into a multisig account. Every payment from it then
+   needs signatures whose weights add up to the medium threshold, for example:
+
+     keybase wallet cosign setup GA... --signer GB... --signer GC... --threshold 2
+
+   makes payments need two out of the three keys. Once an account has other
+   signers, changing them needs their approval too.`,
+	}
+}
+
+func (c *cmdWalletCosignSetup) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 1 {
+		return errors.New("setup requires an account ID")
+	}
+	c.arg.AccountID, err = libkb.ParseStellarAccountID(ctx.Args()[0])
+	if err != nil {
+		return err
+	}
+	for _, s := range ctx.StringSlice("signer") {
+		signer, err := parseCosignSigner(s)
+		if err != nil {
+			return err
+		}
+		c.arg.Signers = append(c.arg.Signers, signer)
+	}
+	c.arg.MasterWeight = ctx.Int("master-weight")
+	if threshold := ctx.Int("threshold"); threshold != 0 {
+		if ctx.IsSet("low") || ctx.IsSet("medium") || ctx.IsSet("high") {
+			return errors.New("--threshold cannot be combined with --low, --medium or --high")
+		}
+		c.arg.LowThreshold, c.arg.MediumThreshold, c.arg.HighThreshold = threshold, threshold, threshold
+	} else {
+		c.arg.LowThreshold = ctx.Int("low")
+		c.arg.MediumThreshold = ctx.Int("medium")
+		c.arg.HighThreshold = ctx.Int("high")
+	}
+	c.arg.Channel = ctx.String("channel")
+	return nil
+}
+
+func parseCosignSigner(s string) (res stellar1.CosignSigner, err error) {
+	idStr, weightStr, hasWeight := strings.Cut(s, ":")
+	res.AccountID, err = libkb.ParseStellarAccountID(idStr)
+	if err != nil {
+		return res, err
+	}
+	res.Weight = 1
+	if hasWeight {
+		res.Weight, err = strconv.Atoi(weightStr)
+		if err != nil {
+			return res, fmt.Errorf("invalid weight for signer %v: %q", res.AccountID, weightStr)
+		}
+	}
+	return res, nil
+}
+
+func (c *cmdWalletCosignSetup) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.CosignSetupLocal(context.Background(), c.arg)
+	if err != nil {
+		return err
+	}
+	printCosignResult(c.G(), res, c.arg.Channel)
+	return nil
+}
+
+func (c *cmdWalletCosignSetup) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type cmdWalletCosignRequest struct {
+	libkb.Contextified
+	arg stellar1.CosignRequestLocalArg
+}
+
+func newCmdWalletCosignRequest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletCosignRequest{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "request",
+		Usage:        "Start an XLM payment from a multisig account",
+		ArgumentHelp: "<recipient account id> <amount>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "request", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Multisig account to pay from.",
+			},
+			cli.StringFlag{
+				Name:  "memo",
+				Usage: "Public memo text, up to 28 bytes.",
+			},
+			cli.StringFlag{
+				Name:  "channel",
+				Usage: cosignChannelUsage,
+			},
+		},
+	}
+}
+
+func (c *cmdWalletCosignRequest) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 2 {
+		return errors.New("request requires a recipient account ID and an amount")
+	}
+	c.arg.From, err = libkb.ParseStellarAccountID(ctx.String("from"))
+	if err != nil {
+		return fmt.Errorf("--from: %v", err)
+	}
+	c.arg.To, err = libkb.ParseStellarAccountID(ctx.Args()[0])
+	if err != nil {
+		return err
+	}
+	c.arg.Amount = ctx.Args()[1]
+	c.arg.PublicMemo = ctx.String("memo")
+	c.arg.Channel = ctx.String("channel")
+	return nil
+}
+
+func (c *cmdWalletCosignRequest) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.CosignRequestLocal(context.Background(), c.arg)
+	if err != nil {
+		return err
+	}
+	printCosignResult(c.G(), res, c.arg.Channel)
+	return nil
+}
+
+func (c *cmdWalletCosignRequest) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type cmdWalletCosignSign struct {
+	libkb.Contextified
+	xdr     string
+	channel string
+}
+
+func newCmdWalletCosignSign(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletCosignSign{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "sign",
+		Usage:        "Approve a transaction from a multisig account",
+		ArgumentHelp: "[envelope xdr]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "sign", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "channel",
+				Usage: cosignChannelUsage,
+			},
+		},
+		Description: `Signs the transaction with any of your accounts that are signers of its
+   source account, and submits it to the network once it has enough
+   signatures. The envelope is read from stdin if not given.`,
+	}
+}
+
+func (c *cmdWalletCosignSign) ParseArgv(ctx *cli.Context) (err error) {
+	c.xdr, err = cosignEnvelopeArg(ctx)
+	c.channel = ctx.String("channel")
+	return err
+}
+
+func cosignEnvelopeArg(ctx *cli.Context) (string, error) {
+	switch len(ctx.Args()) {
+	case 0:
+		bytes, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(bytes)), nil
+	case 1:
+		return strings.TrimSpace(ctx.Args()[0]), nil
+	default:
+		return "", errors.New("expecting at most one transaction envelope")
+	}
+}
+
+func (c *cmdWalletCosignSign) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.CosignSignLocal(context.Background(), stellar1.CosignSignLocalArg{
+		EnvelopeXdr: c.xdr,
+		Channel:     c.channel,
+	})
+	if err != nil {
+		return err
+	}
+	printCosignResult(c.G(), res, c.channel)
+	return nil
+}
+
+func (c *cmdWalletCosignSign) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type cmdWalletCosignStatus struct {
+	libkb.Contextified
+	xdr string
+}
+
+func newCmdWalletCosignStatus(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletCosignStatus{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "status",
+		Usage:        "Show what a transaction does and who has signed it",
+		ArgumentHelp: "[envelope xdr]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "status", c)
+		},
+	}
+}
+
+func (c *cmdWalletCosignStatus) ParseArgv(ctx *cli.Context) (err error) {
+	c.xdr, err = cosignEnvelopeArg(ctx)
+	return err
+}
+
+func (c *cmdWalletCosignStatus) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	status, err := cli.CosignStatusLocal(context.Background(), c.xdr)
+	if err != nil {
+		return err
+	}
+	printCosignStatus(c.G(), status)
+	return nil
+}
+
+func (c *cmdWalletCosignStatus) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+func printCosignStatus(g *libkb.GlobalContext, status stellar1.CosignStatusLocal) {
+	dui := g.UI.GetDumbOutputUI()
+	signed := make(map[stellar1.AccountID]bool)
+	for _, accountID := range status.Signed {
+		signed[accountID] = true
+	}
+	dui.Printf("Transaction: %s\n", status.TxID)
+	dui.Printf("Account:     %s\n", status.AccountID)
+	for _, op := range status.Operations {
+		dui.Printf("  %s\n", op)
+	}
+	dui.Printf("Signatures:  weight %d of %d\n", status.CollectedWeight, status.Threshold)
+	for _, signer := range status.Signers {
+		mark := " "
+		if signed[signer.AccountID] {
+			mark = "x"
+		}
+		dui.Printf("  [%s] %s (weight %d)\n", mark, signer.AccountID, signer.Weight)
+	}
+}
+
+func printCosignResult(g *libkb.GlobalContext, res stellar1.CosignResultLocal, channel string) {
+	dui := g.UI.GetDumbOutputUI()
+	printCosignStatus(g, res.Status)
+	if res.SubmitTxID != nil {
+		dui.PrintfStderr(ColorString(g, "green", "Transaction submitted to the network.\n"))
+		return
+	}
+	if channel != "" {
+		dui.PrintfStderr(ColorString(g, "green", "Sent to %s for the other signers to approve.\n", channel))
+	} else {
+		dui.PrintfStderr("Share this envelope with the other signers, they can approve it with `keybase wallet cosign sign`:\n")
+	}
+	dui.Printf("%s\n", res.EnvelopeXdr)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
 )
 
 type cmdWalletDetail struct {
@@ -24,8 +25,8 @@ func newCmdWalletDetail(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.
 	return cli.Command{
 		Name:         "detail",
 		Aliases:      []string{"details"},
-		Usage:        "Show payment details",
-		ArgumentHelp: "<transaction ID>",
+		Usage:        "Show payment or payment request details",
+		ArgumentHelp: "<transaction ID | request ID>",
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(cmd, "detail", c)
 		},
@@ -34,7 +35,7 @@ func newCmdWalletDetail(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.
 
 func (c *cmdWalletDetail) ParseArgv(ctx *cli.Context) (err error) {
 	if len(ctx.Args()) == 0 {
-		return errors.New("expected a tx ID (run 'keybase wallet history -v' to find one)")
+		return errors.New("expected a tx ID (run 'keybase wallet history -v' to find one) or a request ID")
 	}
 	if len(ctx.Args()) != 1 {
 		return errors.New("expected one argument")
@@ -49,6 +50,9 @@ func (c *cmdWalletDetail) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	if requestID, err := stellar1.KeybaseRequestIDFromString(c.TxID); err == nil {
+		return c.runRequest(cli, requestID)
+	}
 	detail, err := cli.PaymentDetailCLILocal(context.TODO(), c.TxID)
 	if err != nil {
 		return err
@@ -58,6 +62,17 @@ func (c *cmdWalletDetail) Run() (err error) {
 	return nil
 }
 
+func (c *cmdWalletDetail) runRequest(cli stellar1.LocalClient, requestID stellar1.KeybaseRequestID) error {
+	details, err := cli.GetRequestDetailsLocal(context.TODO(), stellar1.GetRequestDetailsLocalArg{
+		ReqID: requestID,
+	})
+	if err != nil {
+		return err
+	}
+	printRequest(c.G(), details, true /* verbose */)
+	return nil
+}
+
 func (c *cmdWalletDetail) GetUsage() libkb.Usage {
 	return libkb.Usage{
 		Config:    true,
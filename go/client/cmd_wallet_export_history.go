@@ -0,0 +1,401 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+)
+
+type cmdWalletExportHistory struct {
+	libkb.Contextified
+	accountID *stellar1.AccountID
+	format    string
+	from      *time.Time
+	to        *time.Time
+	currency  string
+	fees      bool
+	outfile   string
+}
+
+func newCmdWalletExportHistory(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletExportHistory{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "export-history",
+		Usage:        "Export the full payment history of a stellar account",
+		ArgumentHelp: "[--account G...] [--format csv|ofx|json] [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--fees]",
+		Description:  walletExportHistoryDoc,
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "export-history", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "account",
+				Usage: "account to export, defaults to your primary account",
+			},
+			cli.StringFlag{
+				Name:  "format",
+				Usage: "output format: csv, ofx or json",
+				Value: "csv",
+			},
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "only export payments made on or after this date",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "only export payments made on or before this date",
+			},
+			cli.StringFlag{
+				Name:  "currency",
+				Usage: "currency to value payments in, defaults to the account's display currency",
+			},
+			cli.BoolFlag{
+				Name:  "fees",
+				Usage: "look up the fee of every sent payment, which is slow for long histories",
+			},
+			cli.StringFlag{
+				Name:  "o, outfile",
+				Usage: "write to this file instead of standard output",
+			},
+		},
+	}
+}
+
+// parseExportHistoryDate accepts a date, interpreted in local time, or an
+// RFC3339 timestamp. A date used as the end of the range includes that
+// entire day.
+func parseExportHistoryDate(s string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return t, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Millisecond)
+	}
+	return t, nil
+}
+
+func (c *cmdWalletExportHistory) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) != 0 {
+		return errors.New("expected no arguments")
+	}
+	if s := ctx.String("account"); len(s) > 0 {
+		accountID, err := libkb.ParseStellarAccountID(s)
+		if err != nil {
+			return err
+		}
+		c.accountID = &accountID
+	}
+	c.format = strings.ToLower(ctx.String("format"))
+	switch c.format {
+	case "csv", "ofx", "json":
+	default:
+		return fmt.Errorf("unknown format %q, expected csv, ofx or json", c.format)
+	}
+	if s := ctx.String("from"); len(s) > 0 {
+		from, err := parseExportHistoryDate(s, false)
+		if err != nil {
+			return err
+		}
+		c.from = &from
+	}
+	if s := ctx.String("to"); len(s) > 0 {
+		to, err := parseExportHistoryDate(s, true)
+		if err != nil {
+			return err
+		}
+		c.to = &to
+	}
+	if c.from != nil && c.to != nil && c.to.Before(*c.from) {
+		return errors.New("--to must not be before --from")
+	}
+	c.currency = strings.ToUpper(ctx.String("currency"))
+	c.fees = ctx.Bool("fees")
+	c.outfile = ctx.String("outfile")
+	return nil
+}
+
+func (c *cmdWalletExportHistory) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	arg := stellar1.ExportHistoryCLILocalArg{
+		AccountID:   c.accountID,
+		Currency:    c.currency,
+		IncludeFees: c.fees,
+	}
+	if c.from != nil {
+		from := stellar1.ToTimeMs(*c.from)
+		arg.From = &from
+	}
+	if c.to != nil {
+		to := stellar1.ToTimeMs(*c.to)
+		arg.To = &to
+	}
+	rows, err := cli.ExportHistoryCLILocal(context.Background(), arg)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch c.format {
+	case "csv":
+		err = renderExportHistoryCSV(&buf, rows)
+	case "ofx":
+		var accountID stellar1.AccountID
+		if c.accountID != nil {
+			accountID = *c.accountID
+		} else if len(rows) > 0 {
+			// Every row is to or from the exported account.
+			accountID = rows[0].Payment.FromStellar
+			if !rows[0].Sent && rows[0].Payment.ToStellar != nil {
+				accountID = *rows[0].Payment.ToStellar
+			}
+		}
+		err = renderExportHistoryOFX(&buf, accountID, rows, time.Now())
+	case "json":
+		err = renderExportHistoryJSON(&buf, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(c.outfile) > 0 {
+		if err := os.WriteFile(c.outfile, buf.Bytes(), 0600); err != nil {
+			return err
+		}
+		dui.PrintfStderr("Exported %d payments to %s\n", len(rows), c.outfile)
+		return nil
+	}
+	dui.Printf("%s", buf.String())
+	return nil
+}
+
+func (c *cmdWalletExportHistory) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+func exportAssetCode(asset stellar1.Asset) string {
+	if asset.IsNativeXLM() {
+		return "XLM"
+	}
+	return asset.Code
+}
+
+func exportDirection(row stellar1.PaymentExportCLILocal) string {
+	if row.Sent {
+		return "sent"
+	}
+	return "received"
+}
+
+func renderExportHistoryCSV(w io.Writer, rows []stellar1.PaymentExportCLILocal) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{
+		"time", "transaction_id", "status", "direction", "counterparty",
+		"asset", "asset_issuer", "amount", "source_asset", "source_amount",
+		"fee_xlm", "worth", "worth_currency",
+		"note", "public_note", "description",
+	})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		p := row.Payment
+		var sourceAsset string
+		if !p.SourceAsset.IsEmpty() {
+			sourceAsset = exportAssetCode(p.SourceAsset)
+		}
+		var asset string
+		if !p.Asset.IsEmpty() {
+			asset = exportAssetCode(p.Asset)
+		}
+		err := cw.Write([]string{
+			p.Time.Time().UTC().Format(time.RFC3339),
+			p.TxID.String(),
+			p.Status,
+			exportDirection(row),
+			row.Counterparty,
+			asset,
+			p.Asset.Issuer,
+			p.Amount,
+			sourceAsset,
+			p.SourceAmountActual,
+			row.FeeCharged,
+			row.Worth,
+			row.WorthCurrency,
+			p.Note,
+			p.PublicNote,
+			p.SummaryAdvanced,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func renderExportHistoryJSON(w io.Writer, rows []stellar1.PaymentExportCLILocal) error {
+	if rows == nil {
+		rows = []stellar1.PaymentExportCLILocal{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(rows)
+}
+
+type ofxTransaction struct {
+	typ    string
+	time   time.Time
+	amount string
+	fitID  string
+	name   string
+	memo   string
+}
+
+// ofxEscape escapes the characters which are not allowed in OFX SGML element
+// values and truncates the value to maxLen characters.
+func ofxEscape(s string, maxLen int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxLen {
+		s = string(r[:maxLen])
+	}
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
+
+// renderExportHistoryOFX writes an OFX 1.0.2 bank statement per asset, since
+// an OFX statement has a single currency. Fees are separate transactions in
+// the XLM statement. Failed and canceled payments as well as non-payment
+// transactions are left out since they did not move any funds.
+func renderExportHistoryOFX(w io.Writer, accountID stellar1.AccountID, rows []stellar1.PaymentExportCLILocal, now time.Time) error {
+	statements := make(map[string][]ofxTransaction)
+	addTx := func(asset string, tx ofxTransaction) {
+		statements[asset] = append(statements[asset], tx)
+	}
+	for _, row := range rows {
+		p := row.Payment
+		status := strings.ToLower(p.Status)
+		if p.IsAdvanced || strings.HasPrefix(status, "error") || status == "canceled" {
+			continue
+		}
+		memo := p.Note
+		if row.Worth != "" {
+			memo = strings.TrimSpace(fmt.Sprintf("%s (%s %s)", memo, row.Worth, row.WorthCurrency))
+		}
+		tx := ofxTransaction{
+			time:  p.Time.Time(),
+			fitID: p.TxID.String(),
+			name:  row.Counterparty,
+			memo:  memo,
+		}
+		asset, amount := exportAssetCode(p.Asset), p.Amount
+		if row.Sent {
+			if !p.SourceAsset.IsEmpty() && p.SourceAmountActual != "" {
+				asset, amount = exportAssetCode(p.SourceAsset), p.SourceAmountActual
+			}
+			tx.typ, tx.amount = "DEBIT", "-"+amount
+		} else {
+			tx.typ, tx.amount = "CREDIT", amount
+		}
+		addTx(asset, tx)
+		if row.FeeCharged != "" {
+			addTx("XLM", ofxTransaction{
+				typ:    "FEE",
+				time:   tx.time,
+				amount: "-" + row.FeeCharged,
+				fitID:  tx.fitID + "-fee",
+				name:   "Stellar network fee",
+			})
+		}
+	}
+	assets := make([]string, 0, len(statements))
+	for asset := range statements {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	var b strings.Builder
+	// Notes can be in any language, UNICODE with no charset is how OFX 1.0.2
+	// declares UTF-8.
+	b.WriteString("OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nSECURITY:NONE\nENCODING:UNICODE\n" +
+		"CHARSET:NONE\nCOMPRESSION:NONE\nOLDFILEUID:NONE\nNEWFILEUID:NONE\n\n")
+	b.WriteString("<OFX>\n<SIGNONMSGSRSV1>\n<SONRS>\n<STATUS>\n<CODE>0\n<SEVERITY>INFO\n</STATUS>\n")
+	fmt.Fprintf(&b, "<DTSERVER>%s\n<LANGUAGE>ENG\n</SONRS>\n</SIGNONMSGSRSV1>\n", ofxTime(now))
+	b.WriteString("<BANKMSGSRSV1>\n")
+	for i, asset := range assets {
+		txs := statements[asset]
+		fmt.Fprintf(&b, "<STMTTRNRS>\n<TRNUID>%d\n<STATUS>\n<CODE>0\n<SEVERITY>INFO\n</STATUS>\n", i+1)
+		fmt.Fprintf(&b, "<STMTRS>\n<CURDEF>%s\n", ofxEscape(asset, 12))
+		fmt.Fprintf(&b, "<BANKACCTFROM>\n<BANKID>STELLAR\n<ACCTID>%s\n<ACCTTYPE>CHECKING\n</BANKACCTFROM>\n", accountID)
+		fmt.Fprintf(&b, "<BANKTRANLIST>\n<DTSTART>%s\n<DTEND>%s\n", ofxTime(txs[0].time), ofxTime(txs[len(txs)-1].time))
+		for _, tx := range txs {
+			fmt.Fprintf(&b, "<STMTTRN>\n<TRNTYPE>%s\n<DTPOSTED>%s\n<TRNAMT>%s\n<FITID>%s\n",
+				tx.typ, ofxTime(tx.time), tx.amount, ofxEscape(tx.fitID, 255))
+			if tx.name != "" {
+				fmt.Fprintf(&b, "<NAME>%s\n", ofxEscape(tx.name, 32))
+			}
+			if tx.memo != "" {
+				fmt.Fprintf(&b, "<MEMO>%s\n", ofxEscape(tx.memo, 255))
+			}
+			b.WriteString("</STMTTRN>\n")
+		}
+		b.WriteString("</BANKTRANLIST>\n</STMTRS>\n</STMTTRNRS>\n")
+	}
+	b.WriteString("</BANKMSGSRSV1>\n</OFX>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+const walletExportHistoryDoc = `"keybase wallet export-history" exports every payment, path payment
+and relay payment of a stellar account for bookkeeping, oldest first.
+
+Each payment includes the asset and amount, the other party, the
+encrypted Keybase note and its worth in an outside currency at the time of
+the payment. The worth is left empty for payments that were not valued in
+that currency when they were made.
+
+The fee you paid for each payment is only included with --fees, which
+looks up every sent payment separately.
+
+Formats:
+    csv   one row per payment
+    ofx   an OFX bank statement per asset, for import into accounting software
+    json  the full payment details
+
+Examples:
+    keybase wallet export-history --from 2026-01-01 --to 2026-03-31 -o q1.csv
+    keybase wallet export-history --format ofx --currency EUR > payments.ofx
+`
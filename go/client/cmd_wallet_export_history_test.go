@@ -0,0 +1,99 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func exportHistoryTestRows() []stellar1.PaymentExportCLILocal {
+	to := stellar1.AccountID("GBB")
+	ctime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []stellar1.PaymentExportCLILocal{
+		{
+			Payment: stellar1.PaymentCLILocal{
+				TxID:        "tx1",
+				Time:        stellar1.ToTimeMs(ctime),
+				Status:      "Completed",
+				Amount:      "10.0000000",
+				Asset:       stellar1.AssetNative(),
+				FromStellar: "GAA",
+				ToStellar:   &to,
+				Note:        "rent, <märz>",
+			},
+			Sent:          true,
+			Counterparty:  "alice",
+			FeeCharged:    "0.0000100",
+			Worth:         "3.18",
+			WorthCurrency: "USD",
+		},
+		{
+			Payment: stellar1.PaymentCLILocal{
+				TxID:        "tx2",
+				Time:        stellar1.ToTimeMs(ctime.Add(time.Hour)),
+				Status:      "Completed",
+				Amount:      "5.0000000",
+				Asset:       stellar1.Asset{Type: "credit_alphanum4", Code: "EUR", Issuer: "GISSUER"},
+				FromStellar: "GCC",
+				ToStellar:   &to,
+			},
+			Counterparty: "GCC",
+		},
+		{
+			Payment: stellar1.PaymentCLILocal{
+				TxID:        "tx3",
+				Time:        stellar1.ToTimeMs(ctime.Add(2 * time.Hour)),
+				Status:      "error",
+				Amount:      "1.0000000",
+				Asset:       stellar1.AssetNative(),
+				FromStellar: "GAA",
+				ToStellar:   &to,
+			},
+			Sent:         true,
+			Counterparty: "bob",
+		},
+	}
+}
+
+func TestRenderExportHistoryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderExportHistoryCSV(&buf, exportHistoryTestRows()))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, []string{
+		"2026-03-01T12:00:00Z", "tx1", "Completed", "sent", "alice",
+		"XLM", "", "10.0000000", "", "",
+		"0.0000100", "3.18", "USD",
+		"rent, <märz>", "", "",
+	}, records[1])
+	require.Equal(t, "received", records[2][3])
+	require.Equal(t, "EUR", records[2][5])
+	require.Equal(t, "GISSUER", records[2][6])
+}
+
+func TestRenderExportHistoryOFX(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, renderExportHistoryOFX(&buf, "GBB", exportHistoryTestRows(), now))
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "OFXHEADER:100\n"))
+	require.Contains(t, out, "\nENCODING:UNICODE\nCHARSET:NONE\n")
+	require.Contains(t, out, "<DTSERVER>20260401000000[0:GMT]\n")
+	// One statement per asset, sorted by code.
+	require.Equal(t, 2, strings.Count(out, "<STMTRS>"))
+	require.Less(t, strings.Index(out, "<CURDEF>EUR"), strings.Index(out, "<CURDEF>XLM"))
+	require.Contains(t, out, "<TRNTYPE>DEBIT\n<DTPOSTED>20260301120000[0:GMT]\n<TRNAMT>-10.0000000\n<FITID>tx1\n<NAME>alice\n<MEMO>rent, &lt;märz&gt; (3.18 USD)\n")
+	require.Contains(t, out, "<TRNTYPE>FEE\n<DTPOSTED>20260301120000[0:GMT]\n<TRNAMT>-0.0000100\n<FITID>tx1-fee\n")
+	require.Contains(t, out, "<TRNTYPE>CREDIT\n<DTPOSTED>20260301130000[0:GMT]\n<TRNAMT>5.0000000\n<FITID>tx2\n")
+	// The failed payment did not move any funds.
+	require.NotContains(t, out, "tx3")
+}
@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
@@ -19,6 +21,7 @@ type CmdWalletRequest struct {
 	Amount        string
 	LocalCurrency string
 	Note          string
+	Invoice       *stellar1.InvoiceContents
 }
 
 func newCmdWalletRequest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -27,6 +30,18 @@ func newCmdWalletRequest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli
 			Name:  "m, message",
 			Usage: "Include a message with the request.",
 		},
+		cli.StringSliceFlag{
+			Name:  "item",
+			Usage: `Add an invoice line item, "<description>=<amount>" or "<description>=<quantity>x<unit amount>". Can be repeated.`,
+		},
+		cli.StringFlag{
+			Name:  "due",
+			Usage: `Invoice due date, "YYYY-MM-DD".`,
+		},
+		cli.StringFlag{
+			Name:  "reference",
+			Usage: "Invoice reference, such as an invoice number.",
+		},
 	}
 	cmd := &CmdWalletRequest{
 		Contextified: libkb.NewContextified(g),
@@ -39,6 +54,10 @@ func newCmdWalletRequest(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli
 			cl.ChooseCommand(cmd, "request", c)
 		},
 		Flags: flags,
+		Description: `Line items, a due date and a reference turn the request into an
+   invoice, which is encrypted for you and the recipient. Line items must
+   add up to the requested amount. The request can be paid in several
+   payments, see "keybase wallet requests".`,
 	}
 }
 
@@ -59,9 +78,51 @@ func (c *CmdWalletRequest) ParseArgv(ctx *cli.Context) error {
 		}
 	}
 	c.Note = ctx.String("message")
+
+	var invoice stellar1.InvoiceContents
+	for _, s := range ctx.StringSlice("item") {
+		item, err := parseInvoiceLineItem(s)
+		if err != nil {
+			return err
+		}
+		invoice.LineItems = append(invoice.LineItems, item)
+	}
+	if s := ctx.String("due"); s != "" {
+		due, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", s)
+		}
+		// Due by the end of the day.
+		invoice.DueDate = stellar1.ToTimeMs(due.AddDate(0, 0, 1).Add(-time.Millisecond))
+	}
+	invoice.Reference = ctx.String("reference")
+	if len(invoice.LineItems) > 0 || invoice.DueDate != 0 || invoice.Reference != "" {
+		c.Invoice = &invoice
+	}
 	return nil
 }
 
+// parseInvoiceLineItem parses "<description>=<amount>" or
+// "<description>=<quantity>x<unit amount>".
+func parseInvoiceLineItem(s string) (item stellar1.InvoiceLineItem, err error) {
+	i := strings.LastIndex(s, "=")
+	if i < 0 {
+		return item, fmt.Errorf("invalid line item %q, expected <description>=<amount>", s)
+	}
+	item.Description = strings.TrimSpace(s[:i])
+	amount := strings.TrimSpace(s[i+1:])
+	if item.Description == "" || amount == "" {
+		return item, fmt.Errorf("invalid line item %q, expected <description>=<amount>", s)
+	}
+	if quantity, unit, ok := strings.Cut(amount, "x"); ok {
+		item.Quantity = strings.TrimSpace(quantity)
+		item.UnitAmount = strings.TrimSpace(unit)
+	} else {
+		item.Amount = amount
+	}
+	return item, nil
+}
+
 func (c *CmdWalletRequest) Run() (err error) {
 	defer transformStellarCLIError(&err)
 	cli, err := GetWalletClient(c.G())
@@ -80,6 +141,7 @@ func (c *CmdWalletRequest) Run() (err error) {
 		Recipient: c.Recipient,
 		Note:      c.Note,
 		Amount:    c.Amount,
+		Invoice:   c.Invoice,
 	}
 
 	if c.LocalCurrency != "" && c.LocalCurrency != "XLM" {
@@ -90,11 +152,11 @@ func (c *CmdWalletRequest) Run() (err error) {
 		arg.Asset = &xlm
 	}
 
-	_, err = cli.MakeRequestCLILocal(context.Background(), arg)
+	requestID, err := cli.MakeRequestCLILocal(context.Background(), arg)
 	if err != nil {
 		return err
 	}
-
+	c.G().UI.GetDumbOutputUI().PrintfStderr("Request ID: %s\n", requestID)
 	return nil
 }
 
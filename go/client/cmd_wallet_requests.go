@@ -0,0 +1,155 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+)
+
+type cmdWalletRequests struct {
+	libkb.Contextified
+	all     bool
+	verbose bool
+}
+
+func newCmdWalletRequests(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletRequests{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:  "requests",
+		Usage: "List payment requests made by or to you",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "requests", c)
+		},
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "a, all",
+				Usage: "Include paid and canceled requests.",
+			},
+			cli.BoolFlag{
+				Name:  "v, verbose",
+				Usage: "Show invoice line items and payments.",
+			},
+		},
+	}
+}
+
+func (c *cmdWalletRequests) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return errors.New("expected no arguments")
+	}
+	c.all = ctx.Bool("all")
+	c.verbose = ctx.Bool("verbose")
+	return nil
+}
+
+func (c *cmdWalletRequests) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	requests, err := cli.ListRequestsLocal(context.Background(), stellar1.ListRequestsLocalArg{
+		IncludeClosed: c.all,
+	})
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(requests) == 0 {
+		dui.PrintfStderr("No outstanding requests.\n")
+		return nil
+	}
+	for _, r := range requests {
+		printRequest(c.G(), r, c.verbose)
+	}
+	return nil
+}
+
+func (c *cmdWalletRequests) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+// requestStatusString describes where a request stands, taking partial
+// payments and the invoice due date into account.
+func requestStatusString(r stellar1.RequestDetailsLocal, now time.Time) string {
+	switch r.Status {
+	case stellar1.RequestStatus_CANCELED:
+		return "canceled"
+	case stellar1.RequestStatus_DONE:
+		return "paid"
+	}
+	if r.Invoice != nil && r.Invoice.DueDate != 0 && now.After(r.Invoice.DueDate.Time()) {
+		return "overdue"
+	}
+	if r.AmountPaid != "" && r.AmountPaid != "0" {
+		return "partially paid"
+	}
+	return "open"
+}
+
+func printRequest(g *libkb.GlobalContext, r stellar1.RequestDetailsLocal, verbose bool) {
+	dui := g.UI.GetDumbOutputUI()
+	status := requestStatusString(r, time.Now())
+	statusColor := "yellow"
+	switch status {
+	case "paid":
+		statusColor = "green"
+	case "overdue":
+		statusColor = "red"
+	case "canceled":
+		statusColor = ""
+	}
+	if statusColor != "" {
+		status = ColorString(g, statusColor, "%s", status)
+	}
+	if r.FromCurrentUser {
+		dui.Printf("%s  Requested %s from %s [%s]\n", r.Id, r.AmountDescription, r.ToAssertion, status)
+	} else {
+		dui.Printf("%s  %s requested %s [%s]\n", r.Id, r.FromAssertion, r.AmountDescription, status)
+	}
+	if r.Ctime != 0 {
+		dui.Printf("  Created: %s\n", r.Ctime.Time().Format(time.RFC1123))
+	}
+	if invoice := r.Invoice; invoice != nil {
+		if invoice.Reference != "" {
+			dui.Printf("  Reference: %s\n", invoice.Reference)
+		}
+		if invoice.DueDate != 0 {
+			dui.Printf("  Due: %s\n", invoice.DueDate.Time().Format("2006-01-02"))
+		}
+		if verbose && len(invoice.LineItems) > 0 {
+			dui.Printf("  Items:\n")
+			for _, item := range invoice.LineItems {
+				dui.Printf("    %s\n", item.String())
+			}
+		}
+	}
+	if r.AmountPaid != "" && r.AmountPaid != "0" {
+		dui.Printf("  Paid: %s of %s\n", r.AmountPaidDescription, r.AmountDescription)
+	}
+	if verbose && len(r.Payments) > 0 {
+		dui.Printf("  Payments:\n")
+		for _, p := range r.Payments {
+			line := fmt.Sprintf("    %s  %s XLM", p.KbTxID, p.Amount)
+			if p.DisplayAmount != "" && p.DisplayCurrency != "" {
+				line += fmt.Sprintf(" (%s %s)", p.DisplayAmount, p.DisplayCurrency)
+			}
+			dui.Printf("%s  %s\n", line, p.Ctime.Time().Format(time.RFC1123))
+		}
+	}
+}
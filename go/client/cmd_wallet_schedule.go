@@ -0,0 +1,304 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+)
+
+func newCmdWalletSchedule(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "schedule",
+		Usage:        "Send XLM later or on a recurring basis",
+		ArgumentHelp: "[arguments...]",
+		Subcommands: []cli.Command{
+			newCmdWalletScheduleAdd(cl, g),
+			newCmdWalletScheduleCancel(cl, g),
+			newCmdWalletScheduleList(cl, g),
+		},
+	}
+}
+
+type cmdWalletScheduleAdd struct {
+	libkb.Contextified
+	arg stellar1.SchedulePaymentLocalArg
+}
+
+func newCmdWalletScheduleAdd(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletScheduleAdd{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "add",
+		Usage:        "Schedule a payment to a keybase user or stellar address",
+		ArgumentHelp: "<recipient> <amount> [<local currency>]",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "add", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "at",
+				Usage: `Time of the (first) payment, "YYYY-MM-DD", "YYYY-MM-DD HH:MM" or RFC3339. Defaults to now.`,
+			},
+			cli.StringFlag{
+				Name:  "every",
+				Usage: `Repeat the payment every "week" or "month".`,
+			},
+			cli.StringFlag{
+				Name:  "m, message",
+				Usage: "Include an encrypted message with the payment.",
+			},
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Specify the source account for the payment.",
+			},
+			cli.StringFlag{
+				Name:  "memo",
+				Usage: "Include a public memo text in the stellar transaction.",
+			},
+		},
+		Description: `Payments are sent by the Keybase service on this device, so it has to
+   be running and online when a payment is due. A payment that is due while
+   the device is offline goes out once it is back, but missed recurring
+   payments are not made up for. A payment is skipped if the account does
+   not have enough available XLM at the time. Amounts in a local currency
+   are converted at the exchange rate of each payment.`,
+	}
+}
+
+func (c *cmdWalletScheduleAdd) ParseArgv(ctx *cli.Context) (err error) {
+	if len(ctx.Args()) > 3 {
+		return errors.New("add expects at most three arguments")
+	} else if len(ctx.Args()) < 2 {
+		return errors.New("add expects at least two arguments (recipient and amount)")
+	}
+	c.arg.To = ctx.Args()[0]
+	c.arg.Amount = ctx.Args()[1]
+	if len(ctx.Args()) == 3 {
+		currency := strings.ToUpper(ctx.Args()[2])
+		if len(currency) != 3 {
+			return errors.New("Invalid currency code")
+		}
+		if currency != "XLM" {
+			c.arg.Currency = stellar1.OutsideCurrencyCode(currency)
+		}
+	}
+	if s := ctx.String("at"); s != "" {
+		start, err := parseSchedulePaymentTime(s)
+		if err != nil {
+			return err
+		}
+		c.arg.Start = stellar1.ToTimeMs(start)
+	}
+	c.arg.Recurrence, err = parseSchedulePaymentRecurrence(ctx.String("every"))
+	if err != nil {
+		return err
+	}
+	if s := ctx.String("from"); s != "" {
+		c.arg.From, err = libkb.ParseStellarAccountID(s)
+		if err != nil {
+			return fmt.Errorf("--from: %v", err)
+		}
+	}
+	c.arg.Note = ctx.String("message")
+	c.arg.PublicMemo = ctx.String("memo")
+	return nil
+}
+
+// parseSchedulePaymentTime accepts a date or a date and time, interpreted in
+// local time, or an RFC3339 timestamp.
+func parseSchedulePaymentTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD or YYYY-MM-DD HH:MM", s)
+}
+
+func parseSchedulePaymentRecurrence(s string) (stellar1.ScheduledPaymentRecurrence, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return stellar1.ScheduledPaymentRecurrence_ONCE, nil
+	case "week", "weekly":
+		return stellar1.ScheduledPaymentRecurrence_WEEKLY, nil
+	case "month", "monthly":
+		return stellar1.ScheduledPaymentRecurrence_MONTHLY, nil
+	default:
+		return 0, fmt.Errorf("invalid recurrence %q, expected week or month", s)
+	}
+}
+
+func (c *cmdWalletScheduleAdd) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	res, err := cli.SchedulePaymentLocal(context.Background(), c.arg)
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	dui.PrintfStderr(ColorString(c.G(), "green", "Scheduled payment %s, next run at %s.\n",
+		res.Id, res.NextRun.Time().Format(time.RFC1123)))
+	return nil
+}
+
+func (c *cmdWalletScheduleAdd) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type cmdWalletScheduleList struct {
+	libkb.Contextified
+}
+
+func newCmdWalletScheduleList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletScheduleList{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:  "list",
+		Usage: "List scheduled payments",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "list", c)
+		},
+	}
+}
+
+func (c *cmdWalletScheduleList) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return errors.New("expected no arguments")
+	}
+	return nil
+}
+
+func (c *cmdWalletScheduleList) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	payments, err := cli.ListScheduledPaymentsLocal(context.Background())
+	if err != nil {
+		return err
+	}
+	dui := c.G().UI.GetDumbOutputUI()
+	if len(payments) == 0 {
+		dui.PrintfStderr("No scheduled payments.\n")
+		return nil
+	}
+	for _, p := range payments {
+		printScheduledPayment(c.G(), p)
+	}
+	return nil
+}
+
+func printScheduledPayment(g *libkb.GlobalContext, p stellar1.ScheduledPaymentLocal) {
+	dui := g.UI.GetDumbOutputUI()
+	currency := "XLM"
+	if p.Currency != "" {
+		currency = string(p.Currency)
+	}
+	dui.Printf("%s  %s %s to %s from %s\n", p.Id, p.Amount, currency, p.To, p.From)
+	switch p.Recurrence {
+	case stellar1.ScheduledPaymentRecurrence_WEEKLY:
+		dui.Printf("  Every week since %s\n", p.Start.Time().Format(time.RFC1123))
+	case stellar1.ScheduledPaymentRecurrence_MONTHLY:
+		dui.Printf("  Every month since %s\n", p.Start.Time().Format(time.RFC1123))
+	}
+	if p.NextRun != 0 {
+		dui.Printf("  Next run: %s\n", p.NextRun.Time().Format(time.RFC1123))
+	} else {
+		dui.Printf("  Done\n")
+	}
+	if p.Note != "" {
+		dui.Printf("  Message: %s\n", p.Note)
+	}
+	if p.PublicMemo != "" {
+		dui.Printf("  Memo: %s\n", p.PublicMemo)
+	}
+	if run := p.LastRun; run != nil {
+		line := fmt.Sprintf("  Last run: %s %s", run.Time.Time().Format(time.RFC1123), strings.ToLower(run.Status.String()))
+		if run.TxID != nil {
+			line += fmt.Sprintf(" (%s)", *run.TxID)
+		}
+		if run.Error != "" {
+			line += ": " + run.Error
+		}
+		dui.Printf("%s\n", line)
+	}
+}
+
+func (c *cmdWalletScheduleList) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
+
+type cmdWalletScheduleCancel struct {
+	libkb.Contextified
+	id stellar1.ScheduledPaymentID
+}
+
+func newCmdWalletScheduleCancel(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	cmd := &cmdWalletScheduleCancel{
+		Contextified: libkb.NewContextified(g),
+	}
+	return cli.Command{
+		Name:         "cancel",
+		Usage:        "Cancel a scheduled payment",
+		ArgumentHelp: "<id>",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(cmd, "cancel", c)
+		},
+	}
+}
+
+func (c *cmdWalletScheduleCancel) ParseArgv(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("cancel requires the id of a scheduled payment")
+	}
+	c.id = stellar1.ScheduledPaymentID(ctx.Args()[0])
+	return nil
+}
+
+func (c *cmdWalletScheduleCancel) Run() (err error) {
+	defer transformStellarCLIError(&err)
+	cli, err := GetWalletClient(c.G())
+	if err != nil {
+		return err
+	}
+	if err := cli.CancelScheduledPaymentLocal(context.Background(), c.id); err != nil {
+		return err
+	}
+	c.G().UI.GetDumbOutputUI().PrintfStderr("Canceled scheduled payment %s.\n", c.id)
+	return nil
+}
+
+func (c *cmdWalletScheduleCancel) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
@@ -24,6 +24,7 @@ type CmdWalletSend struct {
 	FromAccountID stellar1.AccountID
 	Memo          string
 	MemoType      stellar1.PublicNoteType
+	RequestID     *stellar1.KeybaseRequestID
 }
 
 func newCmdWalletSend(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
@@ -44,6 +45,10 @@ func newCmdWalletSend(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Co
 			Name:  "memo_type",
 			Usage: "Specify the type of memo (text, id, hash, return). hash and return should be hex-encoded.",
 		},
+		cli.StringFlag{
+			Name:  "request",
+			Usage: "Pay (part of) a payment request with the given ID.",
+		},
 	}
 	if develUsage {
 		flags = append(flags, cli.BoolFlag{
@@ -100,6 +105,13 @@ func (c *CmdWalletSend) ParseArgv(ctx *cli.Context) error {
 
 	c.ForceRelay = ctx.Bool("relay")
 	c.FromAccountID = stellar1.AccountID(ctx.String("from"))
+	if s := ctx.String("request"); s != "" {
+		requestID, err := stellar1.KeybaseRequestIDFromString(s)
+		if err != nil {
+			return err
+		}
+		c.RequestID = &requestID
+	}
 	return nil
 }
 
@@ -161,6 +173,7 @@ func (c *CmdWalletSend) Run() (err error) {
 		FromAccountID:   c.FromAccountID,
 		PublicNote:      c.Memo,
 		PublicNoteType:  c.MemoType,
+		RequestID:       c.RequestID,
 	}
 	res, err := cli.SendCLILocal(context.Background(), arg)
 	if err != nil {
@@ -36,6 +36,7 @@ func GetCommands(cl *libcmdline.CommandLine, g *libkb.GlobalContext) []cli.Comma
 		NewCmdDumpKeyfamily(cl, g),
 		NewCmdDumpPushNotifications(cl, g),
 		NewCmdEncrypt(cl, g),
+		newCmdEphemeral(cl, g),
 		NewCmdFNMR(cl, g),
 		NewCmdGit(cl, g),
 		NewCmdHome(cl, g),
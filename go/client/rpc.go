@@ -485,6 +485,14 @@ func GetAuditClient(g *libkb.GlobalContext) (cli keybase1.AuditClient, err error
 	return
 }
 
+func GetEphemeralClient(g *libkb.GlobalContext) (cli keybase1.EphemeralClient, err error) {
+	var rcli *rpc.Client
+	if rcli, _, err = GetRPCClientWithContext(g); err == nil {
+		cli = keybase1.EphemeralClient{Cli: rcli}
+	}
+	return
+}
+
 func GetFeaturedBotsClient(g *libkb.GlobalContext) (cli keybase1.FeaturedBotClient, err error) {
 	rcli, _, err := GetRPCClientWithContext(g)
 	if err != nil {
@@ -36,4 +36,7 @@ Leave a team:
 
 List requests to join a team:
     {"method": "list-requests", "params": {"options": {"team": "phoenix"}}}
+
+Search the local directory of open teams:
+    {"method": "search", "params": {"options": {"query": "crypto", "limit": 10, "min-members": 50, "active-within": "7D", "sort": "members"}}}
 `
@@ -40,6 +40,7 @@ const (
 	removeMemberMethod  = "remove-member"
 	renameSubteamMethod = "rename-subteam"
 	listRequestsMethod  = "list-requests"
+	searchMethod        = "search"
 )
 
 var validMethodsV1 = map[string]bool{
@@ -53,6 +54,7 @@ var validMethodsV1 = map[string]bool{
 	removeMemberMethod:  true,
 	renameSubteamMethod: true,
 	listRequestsMethod:  true,
+	searchMethod:        true,
 }
 
 func (t *teamAPIHandler) handleV1(ctx context.Context, c Call, w io.Writer) error {
@@ -91,6 +93,8 @@ func (t *teamAPIHandler) handleV1(ctx context.Context, c Call, w io.Writer) erro
 		return t.renameSubteam(ctx, c, w)
 	case listRequestsMethod:
 		return t.listRequests(ctx, c, w)
+	case searchMethod:
+		return t.search(ctx, c, w)
 	default:
 		return ErrInvalidMethod{name: c.Method, version: 1}
 	}
@@ -465,6 +469,67 @@ func (t *teamAPIHandler) listRequests(ctx context.Context, c Call, w io.Writer)
 	return t.encodeResult(c, reqs, w)
 }
 
+type searchOptions struct {
+	Query        string `json:"query"`
+	Limit        int    `json:"limit"`
+	MinMembers   int    `json:"min-members"`
+	MaxMembers   int    `json:"max-members"`
+	ActiveWithin string `json:"active-within"`
+	Sort         string `json:"sort"`
+	Refresh      bool   `json:"refresh"`
+}
+
+func (c *searchOptions) Check() error {
+	if c.Limit < 0 || c.MinMembers < 0 || c.MaxMembers < 0 {
+		return errors.New("search: limit and member counts must not be negative")
+	}
+	if c.MaxMembers > 0 && c.MinMembers > c.MaxMembers {
+		return errors.New("search: min-members must not be greater than max-members")
+	}
+	_, err := parseTeamSearchSortOrder(c.Sort)
+	return err
+}
+
+func (t *teamAPIHandler) search(ctx context.Context, c Call, w io.Writer) error {
+	var opts searchOptions
+	if err := t.unmarshalOptions(c, &opts); err != nil {
+		return t.encodeErr(c, err, w)
+	}
+
+	arg := keybase1.TeamSearchDirectoryArg{
+		Query: opts.Query,
+		Limit: opts.Limit,
+		Filter: keybase1.TeamSearchFilter{
+			MinMemberCount: opts.MinMembers,
+			MaxMemberCount: opts.MaxMembers,
+		},
+		ForceRefresh: opts.Refresh,
+	}
+	if arg.Limit == 0 {
+		arg.Limit = defaultTeamSearchLimit
+	}
+	var err error
+	if arg.SortOrder, err = parseTeamSearchSortOrder(opts.Sort); err != nil {
+		return t.encodeErr(c, err, w)
+	}
+	if opts.ActiveWithin != "" {
+		if arg.Filter.ActiveSince, err = parseTeamSearchActiveWithin(opts.ActiveWithin); err != nil {
+			return t.encodeErr(c, err, w)
+		}
+	}
+
+	cli, err := GetTeamSearchClient(t.G())
+	if err != nil {
+		return t.encodeErr(c, err, w)
+	}
+	res, err := cli.TeamSearchDirectory(ctx, arg)
+	if err != nil {
+		return t.encodeErr(c, err, w)
+	}
+
+	return t.encodeResult(c, res, w)
+}
+
 func (t *teamAPIHandler) requireOptionsV1(c Call) error {
 	if len(c.Params.Options) == 0 {
 		if c.Method != "list-self-memberships" {
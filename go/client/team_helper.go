@@ -94,22 +94,40 @@ var botSettingsFlags = []cli.Flag{
 		Usage: `Restricted bots will only be able to send/receive messages in the given conversations.
 	If not specified all conversations are allowed. Can be specified multiple times.`,
 	},
+	cli.BoolFlag{
+		Name: "allow-commands-only",
+		Usage: `Restricted bots will only receive messages that begin with commands they support,
+	even if they are @-mentioned or a trigger matches.`,
+	},
+	cli.StringSliceFlag{
+		Name: "allow-sender",
+		Usage: `Restricted bots will only receive messages sent by the given users.
+	If not specified messages from all users are allowed. Can be specified multiple times.`,
+	},
 }
 
-func ParseBotSettings(ctx *cli.Context) *keybase1.TeamBotSettings {
-	if !ctx.IsSet("allow-commands") && !ctx.IsSet("allow-mentions") && !ctx.IsSet("allow-trigger") && !ctx.IsSet("allow-conversation") {
-		return nil
-	}
+// ParseBotSettings returns the bot settings given on the command line along
+// with the usernames of the allowed senders, which ValidateBotSettings
+// resolves into the settings.
+func ParseBotSettings(ctx *cli.Context) (*keybase1.TeamBotSettings, []string) {
+	if !ctx.IsSet("allow-commands") && !ctx.IsSet("allow-mentions") && !ctx.IsSet("allow-trigger") &&
+		!ctx.IsSet("allow-conversation") && !ctx.IsSet("allow-commands-only") && !ctx.IsSet("allow-sender") {
+		return nil, nil
+	}
+	cmdsOnly := ctx.Bool("allow-commands-only")
 	return &keybase1.TeamBotSettings{
-		Cmds:     ctx.Bool("allow-commands"),
+		Cmds:     ctx.Bool("allow-commands") || cmdsOnly,
 		Mentions: ctx.Bool("allow-mentions"),
 		Triggers: ctx.StringSlice("allow-trigger"),
 		Convs:    ctx.StringSlice("allow-conversation"),
-	}
+		CmdsOnly: cmdsOnly,
+	}, ctx.StringSlice("allow-sender")
 }
 
-func ValidateBotSettingsConvs(g *libkb.GlobalContext, tlfName string,
-	mt chat1.ConversationMembersType, botSettings *keybase1.TeamBotSettings,
+// ValidateBotSettings resolves the conversation topic names given on the
+// command line to conversation IDs and the sender usernames to UIDs.
+func ValidateBotSettings(g *libkb.GlobalContext, tlfName string,
+	mt chat1.ConversationMembersType, botSettings *keybase1.TeamBotSettings, senders []string,
 ) error {
 	if botSettings == nil {
 		return nil
@@ -122,9 +140,28 @@ func ValidateBotSettingsConvs(g *libkb.GlobalContext, tlfName string,
 	for _, convID := range convIDs {
 		convs = append(convs, convID.String())
 	}
-
 	botSettings.Convs = convs
-	return nil
+
+	botSettings.Senders, err = lookupUIDsByUsername(g, senders)
+	return err
+}
+
+func lookupUIDsByUsername(g *libkb.GlobalContext, usernames []string) (uids []keybase1.UID, err error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	cli, err := GetUserClient(g)
+	if err != nil {
+		return nil, err
+	}
+	for _, username := range usernames {
+		user, err := cli.LoadUserByName(context.TODO(), keybase1.LoadUserByNameArg{Username: username})
+		if err != nil {
+			return nil, fmt.Errorf("unable to find user %s: %v", username, err)
+		}
+		uids = append(uids, user.Uid)
+	}
+	return uids, nil
 }
 
 func lookupConvIDsByTopicName(g *libkb.GlobalContext, tlfName string,
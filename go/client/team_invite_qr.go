@@ -0,0 +1,106 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/qrcode"
+)
+
+const teamInviteQRPrefix = "keybase://team-invite?"
+
+// teamInviteQRPayload is what we put into an invite QR code. Without any
+// details the payload is just the bare token, so any QR scanner gives
+// something that can be pasted into `keybase team accept-invite --token`.
+// The team name, role and expiry are informational: they are only checked
+// by the accepting client and are not part of the signed invite.
+type teamInviteQRPayload struct {
+	Token   string
+	Team    string
+	Role    keybase1.TeamRole
+	Expires *keybase1.UnixTime
+}
+
+func (p teamInviteQRPayload) hasDetails() bool {
+	return p.Team != "" || p.Role != keybase1.TeamRole_NONE || p.Expires != nil
+}
+
+func (p teamInviteQRPayload) String() string {
+	if !p.hasDetails() {
+		return p.Token
+	}
+	v := url.Values{}
+	v.Set("token", p.Token)
+	if p.Team != "" {
+		v.Set("team", p.Team)
+	}
+	if p.Role != keybase1.TeamRole_NONE {
+		v.Set("role", strings.ToLower(p.Role.String()))
+	}
+	if p.Expires != nil {
+		v.Set("expires", strconv.FormatInt(int64(*p.Expires), 10))
+	}
+	return teamInviteQRPrefix + v.Encode()
+}
+
+func (p teamInviteQRPayload) isExpired(now time.Time) bool {
+	return p.Expires != nil && !now.Before(p.Expires.Time())
+}
+
+func parseTeamInviteQRPayload(s string) (res teamInviteQRPayload, err error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, teamInviteQRPrefix) {
+		if s == "" {
+			return res, errors.New("empty invite token")
+		}
+		res.Token = s
+		return res, nil
+	}
+	v, err := url.ParseQuery(strings.TrimPrefix(s, teamInviteQRPrefix))
+	if err != nil {
+		return res, fmt.Errorf("invalid invite payload: %w", err)
+	}
+	res.Token = v.Get("token")
+	if res.Token == "" {
+		return res, errors.New("invalid invite payload: missing token")
+	}
+	res.Team = v.Get("team")
+	if srole := v.Get("role"); srole != "" {
+		role, ok := keybase1.TeamRoleMap[strings.ToUpper(srole)]
+		if !ok {
+			return res, fmt.Errorf("invalid invite payload: unknown role %q", srole)
+		}
+		res.Role = role
+	}
+	if sexpires := v.Get("expires"); sexpires != "" {
+		expires, err := strconv.ParseInt(sexpires, 10, 64)
+		if err != nil {
+			return res, fmt.Errorf("invalid invite payload: bad expiry %q", sexpires)
+		}
+		t := keybase1.UnixTime(expires)
+		res.Expires = &t
+	}
+	return res, nil
+}
+
+func readTeamInviteQRImage(filename string) (res teamInviteQRPayload, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return res, err
+	}
+	defer f.Close()
+	data, err := qrcode.Decode(f)
+	if err != nil {
+		return res, fmt.Errorf("unable to read a QR code from %s: %w", filename, err)
+	}
+	return parseTeamInviteQRPayload(string(data))
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/qrcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamInviteQRPayload(t *testing.T) {
+	bare := teamInviteQRPayload{Token: "aaaaaaaaaaaaaaaaaa"}
+	require.Equal(t, "aaaaaaaaaaaaaaaaaa", bare.String())
+	parsed, err := parseTeamInviteQRPayload(" aaaaaaaaaaaaaaaaaa\n")
+	require.NoError(t, err)
+	require.Equal(t, bare, parsed)
+
+	expires := keybase1.UnixTime(1790000000)
+	full := teamInviteQRPayload{
+		Token:   "aaaaaaaaaaaaaaaaaa",
+		Team:    "acme.ops",
+		Role:    keybase1.TeamRole_WRITER,
+		Expires: &expires,
+	}
+	parsed, err = parseTeamInviteQRPayload(full.String())
+	require.NoError(t, err)
+	require.Equal(t, full, parsed)
+	require.False(t, parsed.isExpired(expires.Time().Add(-time.Second)))
+	require.True(t, parsed.isExpired(expires.Time()))
+
+	for _, bad := range []string{
+		"",
+		teamInviteQRPrefix + "team=acme",
+		teamInviteQRPrefix + "token=aaaa&role=emperor",
+		teamInviteQRPrefix + "token=aaaa&expires=tomorrow",
+	} {
+		_, err = parseTeamInviteQRPayload(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestReadTeamInviteQRImage(t *testing.T) {
+	payload := teamInviteQRPayload{Token: "aaaaaaaaaaaaaaaaaa", Team: "acme"}
+	enc, err := qrcode.Encode([]byte(payload.String()))
+	require.NoError(t, err)
+	fname := filepath.Join(t.TempDir(), "invite.png")
+	require.NoError(t, os.WriteFile(fname, enc.PNG, 0600))
+
+	res, err := readTeamInviteQRImage(fname)
+	require.NoError(t, err)
+	require.Equal(t, payload, res)
+
+	_, err = readTeamInviteQRImage(filepath.Join(t.TempDir(), "missing.png"))
+	require.Error(t, err)
+}
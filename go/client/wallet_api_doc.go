@@ -35,6 +35,9 @@ Send XLM to a Keybase user (there is no confirmation so be careful):
 Send $10 USD worth of XLM to a Keybase user:
     {"method": "send", "params": {"options": {"recipient": "patrick", "amount": "10", "currency": "USD", "message": "here's the money I owe you"}}}
 
+Pay part of a payment request (see "keybase wallet requests"):
+    {"method": "send", "params": {"options": {"recipient": "patrick", "amount": "5", "currency": "USD", "request-id": "d7ab0bd8e23dc6e6a3bf4f3a7c7a8e31"}}}
+
 Find a payment path to a Keybase user between two assets:
     {"method": "find-payment-path", "params": {"options": {"recipient": "patrick", "amount": "10", "source-asset": "native", "destination-asset": "USD/GDUKMGUGDZQK6YHYA5Z6AY2G4XDSZPSZ3SW5UN3ARVMO6QSRDWP5YLEX"}}}
 
@@ -46,6 +49,27 @@ cancel the payment before the recipient claims it and the XLM will be returned
 to your account:
     {"method": "cancel", "params": {"options": {"txid": "e5334601b9dc2a24e031ffeec2fce37bb6a8b4b51fc711d16dec04d3e64976c4"}}}
 
+Send $25 USD worth of XLM to a Keybase user on the first of every month, starting in March:
+    {"method": "schedule", "params": {"options": {"recipient": "patrick", "amount": "25", "currency": "USD", "at": "2026-03-01 09:00", "every": "month", "message": "rent"}}}
+
+List scheduled payments:
+    {"method": "list-scheduled"}
+
+Cancel a scheduled payment:
+    {"method": "cancel-scheduled", "params": {"options": {"id": "4b0c6e3c8d1a2f57"}}}
+
+Make an account need two of three signatures for payments and signer changes:
+    {"method": "cosign-setup", "params": {"options": {"account-id": "GDUKZH6Q3U5WQD4PDGZXYLJE3P76BDRDWPSALN4OUFEESI2QL5UZHCK4", "signers": [{"account-id": "GD5CR6MG5R3BADYP2RUVAGC5PKCZGS4CFSAK3FYKD7WEUTRW25UH6C2J", "weight": 1}, {"account-id": "GDUKMGUGDZQK6YHYA5Z6AY2G4XDSZPSZ3SW5UN3ARVMO6QSRDWP5YLEX", "weight": 1}], "low-threshold": 1, "medium-threshold": 2, "high-threshold": 2}}}
+
+Start a payment from a multisig account and send it to a team channel for approval:
+    {"method": "cosign-request", "params": {"options": {"from-account-id": "GDUKZH6Q3U5WQD4PDGZXYLJE3P76BDRDWPSALN4OUFEESI2QL5UZHCK4", "recipient": "GD5CR6MG5R3BADYP2RUVAGC5PKCZGS4CFSAK3FYKD7WEUTRW25UH6C2J", "amount": "10", "channel": "treasury#payments"}}}
+
+See what a transaction does and who has signed it:
+    {"method": "cosign-status", "params": {"options": {"envelope-xdr": "AAAAAJHtRFG9..."}}}
+
+Approve a transaction, which submits it once it has enough signatures:
+    {"method": "cosign-sign", "params": {"options": {"envelope-xdr": "AAAAAJHtRFG9...", "channel": "treasury#payments"}}}
+
 Initialize the wallet for an account:
     {"method": "setup-wallet"}
 `
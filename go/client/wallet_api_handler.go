@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/stellar1"
@@ -45,6 +46,15 @@ var ErrInvalidSourceMax = errors.New("invalid source asset maximum amount")
 // ErrInvalidSourceMax is for when a payment path would exceed the source asset maximum
 var ErrPathMaxExceeded = errors.New("payment path could exceed source asset limit")
 
+// ErrEnvelopeMissing is for missing envelope-xdr options.
+var ErrEnvelopeMissing = errors.New("'envelope-xdr' option is required")
+
+// ErrInvalidRequestID is for invalid payment request IDs.
+var ErrInvalidRequestID = errors.New("invalid payment request ID")
+
+// ErrScheduledPaymentIDMissing is for missing id options.
+var ErrScheduledPaymentIDMissing = errors.New("'id' option is required")
+
 // ErrMemoTextTooLong is for lengthy memos.
 var ErrMemoTextTooLong = errors.New("memo text is too long (max 28 characters)")
 
@@ -76,11 +86,18 @@ const (
 	balancesMethod     = "balances"
 	batchMethod        = "batch"
 	cancelMethod       = "cancel"
+	cancelScheduled    = "cancel-scheduled"
+	cosignRequest      = "cosign-request"
+	cosignSetup        = "cosign-setup"
+	cosignSign         = "cosign-sign"
+	cosignStatus       = "cosign-status"
 	detailsMethod      = "details"
 	getInflationMethod = "get-inflation"
 	historyMethod      = "history"
 	initializeMethod   = "setup-wallet"
+	listScheduled      = "list-scheduled"
 	lookupMethod       = "lookup"
+	scheduleMethod     = "schedule"
 	sendMethod         = "send"
 	setInflationMethod = "set-inflation"
 	findPaymentPath    = "find-payment-path"
@@ -92,11 +109,18 @@ var validWalletMethodsV1 = map[string]bool{
 	balancesMethod:     true,
 	batchMethod:        true,
 	cancelMethod:       true,
+	cancelScheduled:    true,
+	cosignRequest:      true,
+	cosignSetup:        true,
+	cosignSign:         true,
+	cosignStatus:       true,
 	detailsMethod:      true,
 	getInflationMethod: true,
 	historyMethod:      true,
 	initializeMethod:   true,
+	listScheduled:      true,
 	lookupMethod:       true,
+	scheduleMethod:     true,
 	sendMethod:         true,
 	setInflationMethod: true,
 	findPaymentPath:    true,
@@ -122,6 +146,16 @@ func (w *walletAPIHandler) handleV1(ctx context.Context, c Call, wr io.Writer) e
 		return w.batch(ctx, c, wr)
 	case cancelMethod:
 		return w.cancelPayment(ctx, c, wr)
+	case cancelScheduled:
+		return w.cancelScheduledPayment(ctx, c, wr)
+	case cosignRequest:
+		return w.cosignRequest(ctx, c, wr)
+	case cosignSetup:
+		return w.cosignSetup(ctx, c, wr)
+	case cosignSign:
+		return w.cosignSign(ctx, c, wr)
+	case cosignStatus:
+		return w.cosignStatus(ctx, c, wr)
 	case detailsMethod:
 		return w.details(ctx, c, wr)
 	case getInflationMethod:
@@ -130,8 +164,12 @@ func (w *walletAPIHandler) handleV1(ctx context.Context, c Call, wr io.Writer) e
 		return w.history(ctx, c, wr)
 	case initializeMethod:
 		return w.initializeWallet(ctx, c, wr)
+	case listScheduled:
+		return w.listScheduledPayments(ctx, c, wr)
 	case lookupMethod:
 		return w.lookup(ctx, c, wr)
+	case scheduleMethod:
+		return w.schedulePayment(ctx, c, wr)
 	case sendMethod:
 		return w.send(ctx, c, wr)
 	case setInflationMethod:
@@ -300,6 +338,10 @@ func (w *walletAPIHandler) send(ctx context.Context, c Call, wr io.Writer) error
 		PublicNote:      opts.MemoText,
 		PublicNoteType:  stellar1.PublicNoteType_TEXT,
 	}
+	if opts.RequestID != "" {
+		requestID := stellar1.KeybaseRequestID(opts.RequestID)
+		arg.RequestID = &requestID
+	}
 	result, err := w.cli.SendCLILocal(ctx, arg)
 	if err != nil {
 		return w.encodeErr(c, err, wr)
@@ -341,6 +383,130 @@ func (w *walletAPIHandler) setInflation(ctx context.Context, c Call, wr io.Write
 	return w.encodeResult(c, inflation, wr)
 }
 
+// cosignSetup sets the signers and thresholds of a multisig account.
+func (w *walletAPIHandler) cosignSetup(ctx context.Context, c Call, wr io.Writer) error {
+	var opts cosignSetupOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	arg := stellar1.CosignSetupLocalArg{
+		AccountID:       stellar1.AccountID(opts.AccountID),
+		MasterWeight:    1,
+		LowThreshold:    opts.LowThreshold,
+		MediumThreshold: opts.MediumThreshold,
+		HighThreshold:   opts.HighThreshold,
+		Channel:         opts.Channel,
+	}
+	if opts.MasterWeight != nil {
+		arg.MasterWeight = *opts.MasterWeight
+	}
+	for _, signer := range opts.Signers {
+		arg.Signers = append(arg.Signers, stellar1.CosignSigner{
+			AccountID: stellar1.AccountID(signer.AccountID),
+			Weight:    signer.Weight,
+		})
+	}
+	res, err := w.cli.CosignSetupLocal(ctx, arg)
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// cosignRequest starts a payment from a multisig account.
+func (w *walletAPIHandler) cosignRequest(ctx context.Context, c Call, wr io.Writer) error {
+	var opts cosignRequestOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	res, err := w.cli.CosignRequestLocal(ctx, stellar1.CosignRequestLocalArg{
+		From:       stellar1.AccountID(opts.FromAccountID),
+		To:         stellar1.AccountID(opts.Recipient),
+		Amount:     opts.Amount,
+		PublicMemo: opts.MemoText,
+		Channel:    opts.Channel,
+	})
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// cosignSign approves a transaction from a multisig account.
+func (w *walletAPIHandler) cosignSign(ctx context.Context, c Call, wr io.Writer) error {
+	var opts cosignEnvelopeOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	res, err := w.cli.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{
+		EnvelopeXdr: opts.EnvelopeXdr,
+		Channel:     opts.Channel,
+	})
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// cosignStatus shows the signatures collected on a transaction.
+func (w *walletAPIHandler) cosignStatus(ctx context.Context, c Call, wr io.Writer) error {
+	var opts cosignEnvelopeOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	res, err := w.cli.CosignStatusLocal(ctx, opts.EnvelopeXdr)
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// schedulePayment schedules a one-off or recurring payment.
+func (w *walletAPIHandler) schedulePayment(ctx context.Context, c Call, wr io.Writer) error {
+	var opts scheduleOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	arg := stellar1.SchedulePaymentLocalArg{
+		From:       stellar1.AccountID(opts.FromAccountID),
+		To:         opts.Recipient,
+		Amount:     opts.Amount,
+		Currency:   stellar1.OutsideCurrencyCode(strings.ToUpper(opts.Currency)),
+		Note:       opts.Message,
+		PublicMemo: opts.MemoText,
+		Recurrence: opts.recurrence,
+	}
+	if !opts.start.IsZero() {
+		arg.Start = stellar1.ToTimeMs(opts.start)
+	}
+	res, err := w.cli.SchedulePaymentLocal(ctx, arg)
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// listScheduledPayments lists the scheduled payments made from this device.
+func (w *walletAPIHandler) listScheduledPayments(ctx context.Context, c Call, wr io.Writer) error {
+	res, err := w.cli.ListScheduledPaymentsLocal(ctx)
+	if err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, res, wr)
+}
+
+// cancelScheduledPayment cancels a scheduled payment.
+func (w *walletAPIHandler) cancelScheduledPayment(ctx context.Context, c Call, wr io.Writer) error {
+	var opts cancelScheduledOptions
+	if err := unmarshalOptions(c, &opts); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	if err := w.cli.CancelScheduledPaymentLocal(ctx, stellar1.ScheduledPaymentID(opts.ID)); err != nil {
+		return w.encodeErr(c, err, wr)
+	}
+	return w.encodeResult(c, nil, wr)
+}
+
 func (w *walletAPIHandler) findPaymentPath(ctx context.Context, c Call, wr io.Writer) error {
 	var opts findPaymentPathOptions
 	if err := unmarshalOptions(c, &opts); err != nil {
@@ -543,6 +709,7 @@ type sendOptions struct {
 	Message       string `json:"message"`
 	FromAccountID string `json:"from-account-id"`
 	MemoText      string `json:"memo-text"`
+	RequestID     string `json:"request-id"`
 }
 
 // Check makes sure that the send options are valid.
@@ -573,6 +740,11 @@ func (c *sendOptions) Check() error {
 	if len(c.MemoText) > libkb.MaxStellarPaymentPublicNoteLength {
 		return ErrMemoTextTooLong
 	}
+	if c.RequestID != "" {
+		if _, err := stellar1.KeybaseRequestIDFromString(c.RequestID); err != nil {
+			return ErrInvalidRequestID
+		}
+	}
 
 	return nil
 }
@@ -706,3 +878,121 @@ func (c *sendPathPaymentOptions) Check() error {
 
 	return nil
 }
+
+type cosignSignerOptions struct {
+	AccountID string `json:"account-id"`
+	Weight    int    `json:"weight"`
+}
+
+// cosignSetupOptions are the options for the cosign-setup method.
+type cosignSetupOptions struct {
+	AccountID       string                `json:"account-id"`
+	MasterWeight    *int                  `json:"master-weight"`
+	Signers         []cosignSignerOptions `json:"signers"`
+	LowThreshold    int                   `json:"low-threshold"`
+	MediumThreshold int                   `json:"medium-threshold"`
+	HighThreshold   int                   `json:"high-threshold"`
+	Channel         string                `json:"channel"`
+}
+
+// Check makes sure that the account IDs are valid.
+func (c *cosignSetupOptions) Check() error {
+	if _, err := strkey.Decode(strkey.VersionByteAccountID, c.AccountID); err != nil {
+		return ErrInvalidAccountID
+	}
+	for _, signer := range c.Signers {
+		if _, err := strkey.Decode(strkey.VersionByteAccountID, signer.AccountID); err != nil {
+			return ErrInvalidAccountID
+		}
+	}
+	return nil
+}
+
+// cosignRequestOptions are the options for the cosign-request method.
+type cosignRequestOptions struct {
+	FromAccountID string `json:"from-account-id"`
+	Recipient     string `json:"recipient"`
+	Amount        string `json:"amount"`
+	MemoText      string `json:"memo-text"`
+	Channel       string `json:"channel"`
+}
+
+// Check makes sure that the cosign request options are valid.
+func (c *cosignRequestOptions) Check() error {
+	if _, err := strkey.Decode(strkey.VersionByteAccountID, c.FromAccountID); err != nil {
+		return ErrInvalidAccountID
+	}
+	if strings.TrimSpace(c.Recipient) == "" {
+		return ErrRecipientMissing
+	}
+	if _, err := strkey.Decode(strkey.VersionByteAccountID, c.Recipient); err != nil {
+		return ErrInvalidAccountID
+	}
+	if strings.TrimSpace(c.Amount) == "" {
+		return ErrAmountMissing
+	}
+	amt, err := stellarnet.ParseStellarAmount(c.Amount)
+	if err != nil || amt <= 0 {
+		return ErrInvalidAmount
+	}
+	if len(c.MemoText) > libkb.MaxStellarPaymentPublicNoteLength {
+		return ErrMemoTextTooLong
+	}
+	return nil
+}
+
+// cosignEnvelopeOptions are the options for the cosign-sign and cosign-status
+// methods.
+type cosignEnvelopeOptions struct {
+	EnvelopeXdr string `json:"envelope-xdr"`
+	Channel     string `json:"channel"`
+}
+
+// Check makes sure that the envelope isn't empty.
+func (c *cosignEnvelopeOptions) Check() error {
+	if strings.TrimSpace(c.EnvelopeXdr) == "" {
+		return ErrEnvelopeMissing
+	}
+	return nil
+}
+
+// scheduleOptions are the options for the schedule method.
+type scheduleOptions struct {
+	sendOptions
+	At    string `json:"at"`
+	Every string `json:"every"`
+
+	start      time.Time
+	recurrence stellar1.ScheduledPaymentRecurrence
+}
+
+// Check makes sure that the schedule options are valid.
+func (c *scheduleOptions) Check() (err error) {
+	if err := c.sendOptions.Check(); err != nil {
+		return err
+	}
+	if c.RequestID != "" {
+		return errors.New("scheduled payments cannot be made toward a request")
+	}
+	if c.At != "" {
+		c.start, err = parseSchedulePaymentTime(c.At)
+		if err != nil {
+			return err
+		}
+	}
+	c.recurrence, err = parseSchedulePaymentRecurrence(c.Every)
+	return err
+}
+
+// cancelScheduledOptions are the options for the cancel-scheduled method.
+type cancelScheduledOptions struct {
+	ID string `json:"id"`
+}
+
+// Check makes sure that the id isn't empty.
+func (c *cancelScheduledOptions) Check() error {
+	if strings.TrimSpace(c.ID) == "" {
+		return ErrScheduledPaymentIDMissing
+	}
+	return nil
+}
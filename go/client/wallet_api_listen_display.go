@@ -3,16 +3,18 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/stellar1"
 )
 
 const (
-	notifTypeWallet     = "wallet"
-	sourcePayment       = "payment"
-	sourcePaymentStatus = "payment_status"
-	sourceRequest       = "request"
+	notifTypeWallet        = "wallet"
+	sourcePayment          = "payment"
+	sourcePaymentStatus    = "payment_status"
+	sourceRequest          = "request"
+	sourceScheduledPayment = "scheduled_payment"
 )
 
 type walletNotification struct {
@@ -22,6 +24,12 @@ type walletNotification struct {
 	Source       string  `json:"source"`
 	Notification any     `json:"notification,omitempty"`
 	Error        *string `json:"error,omitempty"`
+	// Cursor of a payment notification, to be passed to --wallet-cursor
+	// after a reconnect.
+	Cursor string `json:"cursor,omitempty"`
+	// Replayed is set on payments that were missed and replayed from the
+	// payment history.
+	Replayed bool `json:"replayed,omitempty"`
 }
 
 func newWalletNotification(source string) *walletNotification {
@@ -34,11 +42,13 @@ func newWalletNotification(source string) *walletNotification {
 
 type walletNotificationDisplay struct {
 	*baseNotificationDisplay
+	sync.Mutex
 	cli     stellar1.LocalClient
+	filter  walletNotificationFilter
 	deduper map[string]bool
 }
 
-func newWalletNotificationDisplay(g *libkb.GlobalContext) *walletNotificationDisplay {
+func newWalletNotificationDisplay(g *libkb.GlobalContext, filter walletNotificationFilter) *walletNotificationDisplay {
 	cli, err := GetWalletClient(g)
 	if err != nil {
 		panic(err.Error())
@@ -46,6 +56,7 @@ func newWalletNotificationDisplay(g *libkb.GlobalContext) *walletNotificationDis
 	return &walletNotificationDisplay{
 		baseNotificationDisplay: newBaseNotificationDisplay(g),
 		cli:                     cli,
+		filter:                  filter,
 		deduper:                 make(map[string]bool),
 	}
 }
@@ -57,7 +68,20 @@ func deduperKey(details stellar1.PaymentDetailsLocal) string {
 func (d *walletNotificationDisplay) displayPaymentDetails(ctx context.Context, source string,
 	accountID stellar1.AccountID, paymentID stellar1.PaymentID,
 ) error {
+	d.Lock()
+	defer d.Unlock()
+	d.displayPaymentDetailsLocked(ctx, source, accountID, paymentID, false /* replayed */)
+	return nil
+}
+
+func (d *walletNotificationDisplay) displayPaymentDetailsLocked(ctx context.Context, source string,
+	accountID stellar1.AccountID, paymentID stellar1.PaymentID, replayed bool,
+) {
+	if !d.filter.matchAccount(accountID) {
+		return
+	}
 	notif := newWalletNotification(source)
+	notif.Replayed = replayed
 	details, err := d.cli.GetPaymentDetailsLocal(ctx, stellar1.GetPaymentDetailsLocalArg{
 		AccountID: accountID,
 		Id:        paymentID,
@@ -66,16 +90,21 @@ func (d *walletNotificationDisplay) displayPaymentDetails(ctx context.Context, s
 		errStr := err.Error()
 		notif.Error = &errStr
 		d.printJSON(notif)
-		return nil
+		return
+	}
+	// Filter before deduping: a payment between two of the user's accounts
+	// is announced once for each account.
+	if !d.filter.matchPayment(accountID, details.Summary) {
+		return
 	}
 	if _, dupeMsg := d.deduper[deduperKey(details)]; dupeMsg {
-		return nil
+		return
 	}
 
 	d.deduper[deduperKey(details)] = true
 	notif.Notification = details
+	notif.Cursor = newWalletPaymentCursor(details.Summary).String()
 	d.printJSON(notif)
-	return nil
 }
 
 func (d *walletNotificationDisplay) PaymentNotification(ctx context.Context, arg stellar1.PaymentNotificationArg) error {
@@ -115,3 +144,10 @@ func (d *walletNotificationDisplay) PendingPaymentsUpdate(ctx context.Context, a
 func (d *walletNotificationDisplay) RecentPaymentsUpdate(ctx context.Context, arg stellar1.RecentPaymentsUpdateArg) error {
 	return nil
 }
+
+func (d *walletNotificationDisplay) ScheduledPaymentNotification(ctx context.Context, payment stellar1.ScheduledPaymentLocal) error {
+	notif := newWalletNotification(sourceScheduledPayment)
+	notif.Notification = payment
+	d.printJSON(notif)
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+)
+
+// walletPaymentCursor marks a position in the payment history of the
+// logged-in user. It is printed with every payment notification, and a client
+// that reconnects can pass the last one it processed to have the payments that
+// it missed replayed.
+type walletPaymentCursor struct {
+	Time stellar1.TimeMs
+	TxID stellar1.TransactionID
+}
+
+func newWalletPaymentCursor(p stellar1.PaymentLocal) walletPaymentCursor {
+	return walletPaymentCursor{Time: p.Time, TxID: p.TxID}
+}
+
+func (c walletPaymentCursor) String() string {
+	return fmt.Sprintf("%d-%s", c.Time, c.TxID)
+}
+
+func parseWalletPaymentCursor(s string) (res walletPaymentCursor, err error) {
+	ms, txID, ok := strings.Cut(s, "-")
+	if !ok {
+		return res, fmt.Errorf("invalid wallet cursor %q", s)
+	}
+	t, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil || t <= 0 {
+		return res, fmt.Errorf("invalid wallet cursor %q", s)
+	}
+	return walletPaymentCursor{Time: stellar1.TimeMs(t), TxID: stellar1.TransactionID(txID)}, nil
+}
+
+// before orders cursors by payment time, and by transaction ID for payments
+// made in the same millisecond.
+func (c walletPaymentCursor) before(o walletPaymentCursor) bool {
+	if c.Time != o.Time {
+		return c.Time < o.Time
+	}
+	return c.TxID < o.TxID
+}
+
+func (c walletPaymentCursor) after(o walletPaymentCursor) bool {
+	return o.before(c)
+}
+
+const (
+	walletDirectionSent     = "sent"
+	walletDirectionReceived = "received"
+)
+
+// walletNotificationFilter limits the payment notifications of api-listen.
+// Empty fields match all payments.
+type walletNotificationFilter struct {
+	Accounts []stellar1.AccountID `json:"accounts,omitempty"`
+	// Asset codes of the received asset, "XLM" or "native" for lumens.
+	Assets []string `json:"assets,omitempty"`
+	// "sent" or "received", relative to the account of the notification.
+	Direction string `json:"direction,omitempty"`
+}
+
+func (f walletNotificationFilter) Check() error {
+	for _, a := range f.Accounts {
+		if _, err := libkb.ParseStellarAccountID(a.String()); err != nil {
+			return fmt.Errorf("invalid account in wallet filter: %s", a)
+		}
+	}
+	switch f.Direction {
+	case "", walletDirectionSent, walletDirectionReceived:
+	default:
+		return fmt.Errorf("invalid direction in wallet filter: %q, expected %q or %q",
+			f.Direction, walletDirectionSent, walletDirectionReceived)
+	}
+	return nil
+}
+
+func (f walletNotificationFilter) matchAccount(accountID stellar1.AccountID) bool {
+	if len(f.Accounts) == 0 {
+		return true
+	}
+	for _, a := range f.Accounts {
+		if a.Eq(accountID) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPayment checks the payment as seen from one of the user's accounts.
+func (f walletNotificationFilter) matchPayment(accountID stellar1.AccountID, p stellar1.PaymentLocal) bool {
+	if !f.matchAccount(accountID) {
+		return false
+	}
+	switch f.Direction {
+	case walletDirectionSent:
+		if p.Delta != stellar1.BalanceDelta_DECREASE {
+			return false
+		}
+	case walletDirectionReceived:
+		if p.Delta != stellar1.BalanceDelta_INCREASE {
+			return false
+		}
+	}
+	if len(f.Assets) == 0 {
+		return true
+	}
+	code := p.AssetCode
+	if code == "" {
+		code = "XLM"
+	}
+	for _, asset := range f.Assets {
+		if strings.EqualFold(asset, "native") {
+			asset = "XLM"
+		}
+		if strings.EqualFold(asset, code) {
+			return true
+		}
+	}
+	return false
+}
+
+type walletReplayPayment struct {
+	accountID stellar1.AccountID
+	payment   stellar1.PaymentLocal
+}
+
+// paymentsSince pages through the payments of the user's accounts that pass
+// the filter, and returns the ones after `since`, oldest first.
+func (d *walletNotificationDisplay) paymentsSince(ctx context.Context, since walletPaymentCursor) (res []walletReplayPayment, err error) {
+	accounts, err := d.cli.GetWalletAccountsLocal(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	add := func(accountID stellar1.AccountID, p *stellar1.PaymentLocal) {
+		if p == nil || !newWalletPaymentCursor(*p).after(since) {
+			return
+		}
+		key := fmt.Sprintf("%s:%s", accountID, p.Id)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		res = append(res, walletReplayPayment{accountID: accountID, payment: *p})
+	}
+	for _, account := range accounts {
+		if !d.filter.matchAccount(account.AccountID) {
+			continue
+		}
+		pending, err := d.cli.GetPendingPaymentsLocal(ctx, stellar1.GetPendingPaymentsLocalArg{
+			AccountID: account.AccountID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pending {
+			add(account.AccountID, p.Payment)
+		}
+		var cursor *stellar1.PageCursor
+		for {
+			page, err := d.cli.GetPaymentsLocal(ctx, stellar1.GetPaymentsLocalArg{
+				AccountID: account.AccountID,
+				Cursor:    cursor,
+			})
+			if err != nil {
+				return nil, err
+			}
+			reachedSince := false
+			for _, p := range page.Payments {
+				if p.Payment != nil && !newWalletPaymentCursor(*p.Payment).after(since) {
+					reachedSince = true
+				}
+				add(account.AccountID, p.Payment)
+			}
+			if reachedSince || page.Cursor == nil || len(page.Payments) == 0 {
+				break
+			}
+			cursor = page.Cursor
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return newWalletPaymentCursor(res[i].payment).before(newWalletPaymentCursor(res[j].payment))
+	})
+	return res, nil
+}
+
+// replay prints the payments that were made after `since` before any live
+// notification is printed.
+func (d *walletNotificationDisplay) replay(ctx context.Context, since walletPaymentCursor) error {
+	d.Lock()
+	defer d.Unlock()
+	payments, err := d.paymentsSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	for _, p := range payments {
+		d.displayPaymentDetailsLocked(ctx, sourcePayment, p.accountID, p.payment.Id, true /* replayed */)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletPaymentCursor(t *testing.T) {
+	c := walletPaymentCursor{Time: 1555000000000, TxID: "a1b2"}
+	parsed, err := parseWalletPaymentCursor(c.String())
+	require.NoError(t, err)
+	require.Equal(t, c, parsed)
+
+	later := walletPaymentCursor{Time: 1555000000000, TxID: "b1b2"}
+	require.True(t, c.before(later))
+	require.True(t, later.after(c))
+	require.False(t, c.after(c))
+	require.True(t, later.before(walletPaymentCursor{Time: 1555000000001}))
+
+	for _, s := range []string{"", "1555000000000", "abc-a1b2", "-5-a1b2"} {
+		_, err := parseWalletPaymentCursor(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestWalletNotificationFilter(t *testing.T) {
+	acct1 := stellar1.AccountID("GAKBPBDMW6CTRDCXNAPSVJZ6QAN3OBNRG6CWI27FGDQT2ZJJEMDRXPKK")
+	acct2 := stellar1.AccountID("GAWZ7HVPKRGCH2KP6475XV6HA2CAF44MXWWE5RKV4LMMGB6FNNSEPNPE")
+	received := stellar1.PaymentLocal{Delta: stellar1.BalanceDelta_INCREASE, AssetCode: "XLM"}
+	sent := stellar1.PaymentLocal{Delta: stellar1.BalanceDelta_DECREASE, AssetCode: "USD"}
+
+	var all walletNotificationFilter
+	require.NoError(t, all.Check())
+	require.True(t, all.matchPayment(acct1, received))
+	require.True(t, all.matchPayment(acct2, sent))
+
+	f := walletNotificationFilter{
+		Accounts:  []stellar1.AccountID{acct1},
+		Assets:    []string{"native"},
+		Direction: walletDirectionReceived,
+	}
+	require.NoError(t, f.Check())
+	require.True(t, f.matchPayment(acct1, received))
+	require.False(t, f.matchPayment(acct2, received))
+	require.False(t, f.matchPayment(acct1, sent))
+	sent.AssetCode = "XLM"
+	require.False(t, f.matchPayment(acct1, sent))
+
+	f = walletNotificationFilter{Assets: []string{"usd"}, Direction: walletDirectionSent}
+	sent.AssetCode = "USD"
+	require.True(t, f.matchPayment(acct2, sent))
+
+	require.Error(t, walletNotificationFilter{Direction: "both"}.Check())
+	require.Error(t, walletNotificationFilter{Accounts: []stellar1.AccountID{"GABC"}}.Check())
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// WalletScheduledPaymentsBackground sends the scheduled wallet payments that are due.
+
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+)
+
+var WalletScheduledPaymentsBackgroundSettings = BackgroundTaskSettings{
+	Start:        40 * time.Second, // Wait after starting the app.
+	StartStagger: 20 * time.Second, // Wait an additional random amount.
+	WakeUp:       20 * time.Second, // Additional delay after waking from sleep.
+	Interval:     time.Minute,      // Wait between checks
+	Limit:        10 * time.Minute, // Time limit on each round
+}
+
+// WalletScheduledPaymentsBackground is an engine.
+type WalletScheduledPaymentsBackground struct {
+	libkb.Contextified
+	sync.Mutex
+
+	args *WalletScheduledPaymentsBackgroundArgs
+	task *BackgroundTask
+}
+
+type WalletScheduledPaymentsBackgroundArgs struct {
+	// Channels used for testing. Normally nil.
+	testingMetaCh     chan<- string
+	testingRoundResCh chan<- error
+}
+
+// NewWalletScheduledPaymentsBackground creates a WalletScheduledPaymentsBackground engine.
+func NewWalletScheduledPaymentsBackground(g *libkb.GlobalContext, args *WalletScheduledPaymentsBackgroundArgs) *WalletScheduledPaymentsBackground {
+	task := NewBackgroundTask(g, &BackgroundTaskArgs{
+		Name:     "WalletScheduledPaymentsBackground",
+		F:        WalletScheduledPaymentsBackgroundRound,
+		Settings: WalletScheduledPaymentsBackgroundSettings,
+
+		testingMetaCh:     args.testingMetaCh,
+		testingRoundResCh: args.testingRoundResCh,
+	})
+	return &WalletScheduledPaymentsBackground{
+		Contextified: libkb.NewContextified(g),
+		args:         args,
+		// Install the task early so that Shutdown can be called before RunEngine.
+		task: task,
+	}
+}
+
+// Name is the unique engine name.
+func (e *WalletScheduledPaymentsBackground) Name() string {
+	return "WalletScheduledPaymentsBackground"
+}
+
+// GetPrereqs returns the engine prereqs.
+func (e *WalletScheduledPaymentsBackground) Prereqs() Prereqs {
+	return Prereqs{}
+}
+
+// RequiredUIs returns the required UIs.
+func (e *WalletScheduledPaymentsBackground) RequiredUIs() []libkb.UIKind {
+	return []libkb.UIKind{}
+}
+
+// SubConsumers returns the other UI consumers for this engine.
+func (e *WalletScheduledPaymentsBackground) SubConsumers() []libkb.UIConsumer {
+	return []libkb.UIConsumer{}
+}
+
+// Run starts the engine.
+// Returns immediately, kicks off a background goroutine.
+func (e *WalletScheduledPaymentsBackground) Run(m libkb.MetaContext) (err error) {
+	return RunEngine2(m, e.task)
+}
+
+func (e *WalletScheduledPaymentsBackground) Shutdown() {
+	e.task.Shutdown()
+}
+
+func WalletScheduledPaymentsBackgroundRound(m libkb.MetaContext) error {
+	g := m.G()
+	if g.ConnectivityMonitor.IsConnected(m.Ctx()) == libkb.ConnectivityMonitorNo {
+		m.Debug("WalletScheduledPaymentsBackgroundRound giving up offline")
+		return nil
+	}
+
+	if !g.ActiveDevice.Valid() {
+		m.Debug("WalletScheduledPaymentsBackgroundRound not logged in")
+		return nil
+	}
+
+	if !g.LocalSigchainGuard().IsAvailable(m.Ctx(), "WalletScheduledPaymentsBackgroundRound") {
+		m.Debug("WalletScheduledPaymentsBackgroundRound yielding to guard")
+		return nil
+	}
+
+	return g.GetStellar().RunScheduledPayments(m)
+}
@@ -380,6 +380,32 @@ func (s *DeviceEKStorage) GetAll(mctx libkb.MetaContext) (deviceEKs DeviceEKMap,
 	return deviceEKs, nil
 }
 
+// Status lists every stored generation, including those which could not be
+// read from disk.
+func (s *DeviceEKStorage) Status(mctx libkb.MetaContext) (res []keybase1.EkGenerationStatus, err error) {
+	defer mctx.Trace("DeviceEKStorage#Status", &err)()
+
+	s.Lock()
+	defer s.Unlock()
+
+	cache, err := s.getCache(mctx)
+	if err != nil {
+		return nil, err
+	}
+	for generation, cacheItem := range cache {
+		status := keybase1.EkGenerationStatus{
+			Generation: generation,
+			Ctime:      cacheItem.DeviceEK.Metadata.Ctime,
+		}
+		if cacheItem.Err != nil {
+			status.Error = cacheItem.Err.Error()
+		}
+		res = append(res, status)
+	}
+	sortGenerationStatus(res)
+	return res, nil
+}
+
 func (s *DeviceEKStorage) GetAllActive(mctx libkb.MetaContext, merkleRoot libkb.MerkleRoot) (metadatas []keybase1.DeviceEkMetadata, err error) {
 	defer mctx.Trace("GetAllActive", &err)()
 
@@ -0,0 +1,32 @@
+package ephemeral
+
+import (
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+)
+
+// CheckMessagePolicy verifies that a message of the given type and exploding
+// metadata complies with a conversation's exploding message policy. Only
+// message types which can explode on their own are subject to the policy;
+// superseders inherit the lifetime of the message they supersede.
+func CheckMessagePolicy(policy *chat1.RpEphemeral, typ chat1.MessageType,
+	metadata *chat1.MsgEphemeralMetadata,
+) error {
+	if policy == nil || !chat1.IsEphemeralNonSupersederType(typ) {
+		return nil
+	}
+	violation := libkb.ChatEphemeralRetentionPolicyViolatedError{
+		MaxAge:            policy.Age,
+		AllowNonExploding: policy.AllowNonExploding,
+	}
+	if metadata == nil {
+		if policy.AllowNonExploding {
+			return nil
+		}
+		return violation
+	}
+	if metadata.Lifetime > policy.Age {
+		return violation
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package ephemeral
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/gregor1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMessagePolicy(t *testing.T) {
+	week := gregor1.ToDurationSec(7 * 24 * time.Hour)
+	day := &chat1.MsgEphemeralMetadata{Lifetime: gregor1.ToDurationSec(24 * time.Hour)}
+	month := &chat1.MsgEphemeralMetadata{Lifetime: gregor1.ToDurationSec(30 * 24 * time.Hour)}
+
+	mandatory := &chat1.RpEphemeral{Age: week}
+	require.NoError(t, CheckMessagePolicy(nil, chat1.MessageType_TEXT, nil))
+	require.NoError(t, CheckMessagePolicy(mandatory, chat1.MessageType_TEXT, day))
+	require.NoError(t, CheckMessagePolicy(mandatory, chat1.MessageType_REACTION, nil))
+	err := CheckMessagePolicy(mandatory, chat1.MessageType_TEXT, nil)
+	require.ErrorAs(t, err, new(libkb.ChatEphemeralRetentionPolicyViolatedError))
+	require.Error(t, CheckMessagePolicy(mandatory, chat1.MessageType_ATTACHMENT, month))
+
+	optional := &chat1.RpEphemeral{Age: week, AllowNonExploding: true}
+	require.NoError(t, CheckMessagePolicy(optional, chat1.MessageType_TEXT, nil))
+	require.NoError(t, CheckMessagePolicy(optional, chat1.MessageType_TEXT, day))
+	err = CheckMessagePolicy(optional, chat1.MessageType_TEXT, month)
+	require.Equal(t, libkb.ChatEphemeralRetentionPolicyViolatedError{MaxAge: week, AllowNonExploding: true}, err)
+}
+
+func TestEffectiveEphemeralPolicy(t *testing.T) {
+	day := gregor1.ToDurationSec(24 * time.Hour)
+	week := gregor1.ToDurationSec(7 * 24 * time.Hour)
+	retain := chat1.NewRetentionPolicyWithRetain(chat1.RpRetain{})
+	teamWeek := chat1.NewRetentionPolicyWithEphemeral(chat1.RpEphemeral{Age: week})
+	convDay := chat1.NewRetentionPolicyWithEphemeral(chat1.RpEphemeral{Age: day, AllowNonExploding: true})
+
+	require.Nil(t, chat1.EffectiveEphemeralPolicy(nil, nil))
+	require.Nil(t, chat1.EffectiveEphemeralPolicy(&retain, nil))
+	// a channel can't opt out of the team's policy
+	require.Equal(t, &chat1.RpEphemeral{Age: week}, chat1.EffectiveEphemeralPolicy(&retain, &teamWeek))
+	require.Equal(t, &chat1.RpEphemeral{Age: day}, chat1.EffectiveEphemeralPolicy(&convDay, &teamWeek))
+	require.Equal(t, &chat1.RpEphemeral{Age: day, AllowNonExploding: true},
+		chat1.EffectiveEphemeralPolicy(&convDay, &retain))
+}
@@ -0,0 +1,259 @@
+package ephemeral
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+func sortGenerationStatus(statuses []keybase1.EkGenerationStatus) {
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Generation < statuses[j].Generation })
+}
+
+// generationGaps returns the generations between the oldest and newest stored
+// generation which are missing. Older generations are deleted as they expire
+// so nothing below the oldest stored generation is reported.
+func generationGaps(statuses []keybase1.EkGenerationStatus) (gaps []keybase1.EkGeneration) {
+	if len(statuses) == 0 {
+		return nil
+	}
+	present := make(map[keybase1.EkGeneration]bool, len(statuses))
+	minGen, maxGen := statuses[0].Generation, statuses[0].Generation
+	for _, status := range statuses {
+		present[status.Generation] = true
+		if status.Generation < minGen {
+			minGen = status.Generation
+		}
+		if status.Generation > maxGen {
+			maxGen = status.Generation
+		}
+	}
+	for gen := minGen + 1; gen < maxGen; gen++ {
+		if !present[gen] {
+			gaps = append(gaps, gen)
+		}
+	}
+	return gaps
+}
+
+// erroredGenerations returns the generations which are stored with an error,
+// i.e. we were unable to read or unbox the key or the server had no box for us.
+func erroredGenerations(statuses []keybase1.EkGenerationStatus) (res []keybase1.EkGeneration) {
+	for _, status := range statuses {
+		if status.Error != "" {
+			res = append(res, status.Generation)
+		}
+	}
+	return res
+}
+
+func newStorageStatus(layer keybase1.EkStorageLayer, teamID keybase1.TeamID,
+	generations []keybase1.EkGenerationStatus,
+) keybase1.EkStorageStatus {
+	return keybase1.EkStorageStatus{
+		Layer:       layer,
+		TeamID:      teamID,
+		Generations: generations,
+		Gaps:        generationGaps(generations),
+	}
+}
+
+func teamStorageLayer(typ keybase1.TeamEphemeralKeyType) keybase1.EkStorageLayer {
+	if typ == keybase1.TeamEphemeralKeyType_TEAMBOT {
+		return keybase1.EkStorageLayer_TEAMBOT
+	}
+	return keybase1.EkStorageLayer_TEAM
+}
+
+// Status lists the contents of every local ephemeral key storage layer for the
+// current user: the device and user EKs followed by the team and teambot EKs
+// of each team we have stored keys for.
+func (e *EKLib) Status(mctx libkb.MetaContext) (res []keybase1.EkStorageStatus, err error) {
+	defer mctx.Trace("EKLib.Status", &err)()
+
+	deviceEKs, err := mctx.G().GetDeviceEKStorage().Status(mctx)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, newStorageStatus(keybase1.EkStorageLayer_DEVICE, "", deviceEKs))
+
+	userEKs, err := mctx.G().GetUserEKBoxStorage().Status(mctx)
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, newStorageStatus(keybase1.EkStorageLayer_USER, "", userEKs))
+
+	for _, typ := range []keybase1.TeamEphemeralKeyType{
+		keybase1.TeamEphemeralKeyType_TEAM,
+		keybase1.TeamEphemeralKeyType_TEAMBOT,
+	} {
+		storage, err := e.getStorageForType(mctx, typ)
+		if err != nil {
+			return nil, err
+		}
+		teamIDs, err := storage.TeamIDs(mctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, teamID := range teamIDs {
+			teamEKs, err := storage.Status(mctx, teamID)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, newStorageStatus(teamStorageLayer(typ), teamID, teamEKs))
+		}
+	}
+	return res, nil
+}
+
+// ekRepairAction is a single step of a repair. Unreadable device EKs can only
+// be deleted since they exist nowhere else, everything else is re-fetched from
+// the server.
+type ekRepairAction struct {
+	layer      keybase1.EkStorageLayer
+	teamID     keybase1.TeamID
+	generation keybase1.EkGeneration
+	delete     bool
+}
+
+func (a ekRepairAction) String() string {
+	var name string
+	switch a.layer {
+	case keybase1.EkStorageLayer_DEVICE:
+		name = "deviceEK"
+	case keybase1.EkStorageLayer_USER:
+		name = "userEK"
+	case keybase1.EkStorageLayer_TEAM:
+		name = "teamEK"
+	case keybase1.EkStorageLayer_TEAMBOT:
+		name = "teambotEK"
+	default:
+		name = a.layer.String()
+	}
+	verb := "re-fetch"
+	if a.delete {
+		verb = "delete unreadable"
+	}
+	if a.teamID.IsNil() {
+		return fmt.Sprintf("%s %s %d", verb, name, a.generation)
+	}
+	return fmt.Sprintf("%s %s %d for %v", verb, name, a.generation, a.teamID)
+}
+
+// planRepair lists what Repair does for the given storage statuses: errored
+// device EKs are deleted, errored and missing generations of the other layers
+// are re-fetched.
+func planRepair(statuses []keybase1.EkStorageStatus) (actions []ekRepairAction) {
+	for _, status := range statuses {
+		errored := erroredGenerations(status.Generations)
+		if status.Layer == keybase1.EkStorageLayer_DEVICE {
+			for _, generation := range errored {
+				actions = append(actions, ekRepairAction{layer: status.Layer, generation: generation, delete: true})
+			}
+			continue
+		}
+		for _, generation := range append(errored, status.Gaps...) {
+			actions = append(actions, ekRepairAction{layer: status.Layer, teamID: status.TeamID, generation: generation})
+		}
+	}
+	return actions
+}
+
+// Repair fixes errored and missing generations in local storage, see
+// planRepair. Unless force is set nothing is changed and the result only lists
+// the actions a forced repair would take. A forced repair re-reads device EKs
+// from disk before deleting them and finally regenerates device and user EKs
+// if needed. The returned result contains the storage status after the
+// repair.
+func (e *EKLib) Repair(mctx libkb.MetaContext, force bool) (res keybase1.EkRepairRes, err error) {
+	defer mctx.Trace(fmt.Sprintf("EKLib.Repair: force:%v", force), &err)()
+
+	// Reload everything from disk so we don't act on stale in-memory state.
+	e.ClearCaches(mctx)
+	statuses, err := e.Status(mctx)
+	if err != nil {
+		return res, err
+	}
+	actions := planRepair(statuses)
+	for _, action := range actions {
+		res.Actions = append(res.Actions, action.String())
+	}
+	if !force {
+		res.DryRun = true
+		res.Storages = statuses
+		return res, nil
+	}
+
+	if err := e.applyRepair(mctx, actions, &res); err != nil {
+		return res, err
+	}
+	if err := e.KeygenIfNeeded(mctx); err != nil {
+		mctx.Debug("EKLib.Repair: unable to generate new keys: %v", err)
+		res.Errors = append(res.Errors, fmt.Sprintf("unable to generate new keys: %v", err))
+	}
+
+	res.Storages, err = e.Status(mctx)
+	return res, err
+}
+
+// applyRepair carries out the actions of a forced repair, counting what was
+// done and collecting errors in res.
+func (e *EKLib) applyRepair(mctx libkb.MetaContext, actions []ekRepairAction, res *keybase1.EkRepairRes) error {
+	addErr := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		mctx.Debug("EKLib.Repair: %s", msg)
+		res.Errors = append(res.Errors, msg)
+	}
+	// Deleting a deviceEK can't be undone, so make sure it is still unreadable
+	// when read from disk again.
+	deviceStorage := mctx.G().GetDeviceEKStorage()
+	deviceStorage.ClearCache()
+	deviceStatus, err := deviceStorage.Status(mctx)
+	if err != nil {
+		return err
+	}
+	stillErrored := make(map[keybase1.EkGeneration]bool)
+	for _, generation := range erroredGenerations(deviceStatus) {
+		stillErrored[generation] = true
+	}
+
+	for _, action := range actions {
+		switch action.layer {
+		case keybase1.EkStorageLayer_DEVICE:
+			if !stillErrored[action.generation] {
+				mctx.Debug("EKLib.Repair: deviceEK %d is readable again, skipping", action.generation)
+				continue
+			}
+			if err := deviceStorage.Delete(mctx, action.generation, "EKLib.Repair: unreadable deviceEK"); err != nil {
+				addErr("unable to %v: %v", action, err)
+				continue
+			}
+			res.Deleted++
+		case keybase1.EkStorageLayer_USER:
+			if err := mctx.G().GetUserEKBoxStorage().Refetch(mctx, action.generation); err != nil {
+				addErr("unable to %v: %v", action, err)
+				continue
+			}
+			res.Refetched++
+		case keybase1.EkStorageLayer_TEAM, keybase1.EkStorageLayer_TEAMBOT:
+			typ := keybase1.TeamEphemeralKeyType_TEAM
+			if action.layer == keybase1.EkStorageLayer_TEAMBOT {
+				typ = keybase1.TeamEphemeralKeyType_TEAMBOT
+			}
+			storage, err := e.getStorageForType(mctx, typ)
+			if err != nil {
+				return err
+			}
+			// Make sure we look up the latest generation again.
+			e.teamEKGenCache.Remove(e.cacheKey(action.teamID, typ))
+			if err := storage.Refetch(mctx, action.teamID, action.generation); err != nil {
+				addErr("unable to %v: %v", action, err)
+				continue
+			}
+			res.Refetched++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package ephemeral
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationGaps(t *testing.T) {
+	statuses := func(gens ...keybase1.EkGeneration) (res []keybase1.EkGenerationStatus) {
+		for _, gen := range gens {
+			res = append(res, keybase1.EkGenerationStatus{Generation: gen})
+		}
+		return res
+	}
+	require.Nil(t, generationGaps(nil))
+	require.Nil(t, generationGaps(statuses(4)))
+	require.Nil(t, generationGaps(statuses(3, 4, 5)))
+	require.Equal(t, []keybase1.EkGeneration{4, 6, 7}, generationGaps(statuses(8, 3, 5)))
+
+	withErr := statuses(1, 2, 3)
+	withErr[1].Error = "missing box"
+	require.Equal(t, []keybase1.EkGeneration{2}, erroredGenerations(withErr))
+}
+
+func findStorageStatus(t *testing.T, storages []keybase1.EkStorageStatus, layer keybase1.EkStorageLayer,
+	teamID keybase1.TeamID,
+) keybase1.EkStorageStatus {
+	for _, storage := range storages {
+		if storage.Layer == layer && storage.TeamID == teamID {
+			return storage
+		}
+	}
+	require.Fail(t, "storage not found", "%v %v", layer, teamID)
+	return keybase1.EkStorageStatus{}
+}
+
+func TestEKStatusAndRepair(t *testing.T) {
+	tc, mctx, _ := ephemeralKeyTestSetup(t)
+	defer tc.Cleanup()
+
+	merkleRootPtr, err := tc.G.GetMerkleClient().FetchRootFromServer(mctx, libkb.EphemeralKeyMerkleFreshness)
+	require.NoError(t, err)
+	merkleRoot := *merkleRootPtr
+
+	ekLib := tc.G.GetEKLib().(*EKLib)
+	teamID := createTeam(tc)
+	teamEK, _, err := ekLib.GetOrCreateLatestTeamEK(mctx, teamID)
+	require.NoError(t, err)
+
+	// Publish a few more userEKs and drop one from the middle so we have a gap.
+	userEKBoxStorage := tc.G.GetUserEKBoxStorage()
+	var userEKGens []keybase1.EkGeneration
+	for range 2 {
+		userEKMetadata, err := publishNewUserEK(mctx, merkleRoot)
+		require.NoError(t, err)
+		userEKGens = append(userEKGens, userEKMetadata.Generation)
+	}
+	missingUserEKGen := userEKGens[0]
+	err = userEKBoxStorage.Delete(mctx, missingUserEKGen)
+	require.NoError(t, err)
+
+	// Cache missing box errors for the next generations.
+	erroredUserEKGen := userEKGens[1] + 1
+	_, err = userEKBoxStorage.Get(mctx, erroredUserEKGen, nil)
+	require.Error(t, err)
+	erroredTeamEKGen := teamEK.Generation() + 1
+	_, err = tc.G.GetTeamEKBoxStorage().Get(mctx, teamID, erroredTeamEKGen, nil)
+	require.Error(t, err)
+
+	teamIDs, err := tc.G.GetTeamEKBoxStorage().TeamIDs(mctx)
+	require.NoError(t, err)
+	require.Equal(t, []keybase1.TeamID{teamID}, teamIDs)
+	teambotTeamIDs, err := tc.G.GetTeambotEKBoxStorage().TeamIDs(mctx)
+	require.NoError(t, err)
+	require.Empty(t, teambotTeamIDs)
+
+	storages, err := ekLib.Status(mctx)
+	require.NoError(t, err)
+	require.Len(t, storages, 3)
+
+	deviceStatus := findStorageStatus(t, storages, keybase1.EkStorageLayer_DEVICE, "")
+	require.NotEmpty(t, deviceStatus.Generations)
+	require.Empty(t, deviceStatus.Gaps)
+	for _, gen := range deviceStatus.Generations {
+		require.Empty(t, gen.Error)
+		require.NotZero(t, gen.Ctime)
+	}
+
+	userStatus := findStorageStatus(t, storages, keybase1.EkStorageLayer_USER, "")
+	require.Equal(t, []keybase1.EkGeneration{missingUserEKGen}, userStatus.Gaps)
+	require.Equal(t, []keybase1.EkGeneration{erroredUserEKGen}, erroredGenerations(userStatus.Generations))
+
+	teamStatus := findStorageStatus(t, storages, keybase1.EkStorageLayer_TEAM, teamID)
+	require.Empty(t, teamStatus.Gaps)
+	require.Len(t, teamStatus.Generations, 2)
+	require.Equal(t, teamEK.Generation(), teamStatus.Generations[0].Generation)
+	require.Equal(t, []keybase1.EkGeneration{erroredTeamEKGen}, erroredGenerations(teamStatus.Generations))
+
+	// Without force nothing is touched.
+	res, err := ekLib.Repair(mctx, false)
+	require.NoError(t, err)
+	require.True(t, res.DryRun)
+	require.ElementsMatch(t, []string{
+		fmt.Sprintf("re-fetch userEK %d", erroredUserEKGen),
+		fmt.Sprintf("re-fetch userEK %d", missingUserEKGen),
+		fmt.Sprintf("re-fetch teamEK %d for %v", erroredTeamEKGen, teamID),
+	}, res.Actions)
+	require.Zero(t, res.Refetched)
+	userStatus = findStorageStatus(t, res.Storages, keybase1.EkStorageLayer_USER, "")
+	require.Equal(t, []keybase1.EkGeneration{missingUserEKGen}, userStatus.Gaps)
+
+	res, err = ekLib.Repair(mctx, true)
+	require.NoError(t, err)
+	require.False(t, res.DryRun)
+	// Only the gap can be re-fetched since the server has nothing at the
+	// errored generations, which keep their errors.
+	require.Zero(t, res.Deleted)
+	require.Equal(t, 1, res.Refetched)
+	require.Len(t, res.Errors, 2)
+
+	userStatus = findStorageStatus(t, res.Storages, keybase1.EkStorageLayer_USER, "")
+	require.Empty(t, userStatus.Gaps)
+	require.Equal(t, []keybase1.EkGeneration{erroredUserEKGen}, erroredGenerations(userStatus.Generations))
+	userEK, err := userEKBoxStorage.Get(mctx, missingUserEKGen, nil)
+	require.NoError(t, err)
+	require.Equal(t, missingUserEKGen, userEK.Metadata.Generation)
+}
+
+type repairTestDeviceEKStorage struct {
+	libkb.DeviceEKStorage
+	// statuses returned by consecutive reads
+	statuses [][]keybase1.EkGenerationStatus
+	deleted  []keybase1.EkGeneration
+}
+
+func (s *repairTestDeviceEKStorage) ClearCache() {}
+
+func (s *repairTestDeviceEKStorage) Status(mctx libkb.MetaContext) ([]keybase1.EkGenerationStatus, error) {
+	res := s.statuses[0]
+	if len(s.statuses) > 1 {
+		s.statuses = s.statuses[1:]
+	}
+	return res, nil
+}
+
+func (s *repairTestDeviceEKStorage) Delete(mctx libkb.MetaContext, generation keybase1.EkGeneration,
+	reason string, args ...any,
+) error {
+	s.deleted = append(s.deleted, generation)
+	return nil
+}
+
+type repairTestUserEKBoxStorage struct {
+	libkb.UserEKBoxStorage
+	status    []keybase1.EkGenerationStatus
+	refetched []keybase1.EkGeneration
+}
+
+func (s *repairTestUserEKBoxStorage) ClearCache() {}
+
+func (s *repairTestUserEKBoxStorage) Status(mctx libkb.MetaContext) ([]keybase1.EkGenerationStatus, error) {
+	return s.status, nil
+}
+
+func (s *repairTestUserEKBoxStorage) Refetch(mctx libkb.MetaContext, generation keybase1.EkGeneration) error {
+	s.refetched = append(s.refetched, generation)
+	return nil
+}
+
+type repairTestTeamEKBoxStorage struct {
+	libkb.TeamEKBoxStorage
+	status    map[keybase1.TeamID][]keybase1.EkGenerationStatus
+	refetched []string
+}
+
+func (s *repairTestTeamEKBoxStorage) ClearCache() {}
+
+func (s *repairTestTeamEKBoxStorage) TeamIDs(mctx libkb.MetaContext) (res []keybase1.TeamID, err error) {
+	for teamID := range s.status {
+		res = append(res, teamID)
+	}
+	return res, nil
+}
+
+func (s *repairTestTeamEKBoxStorage) Status(mctx libkb.MetaContext, teamID keybase1.TeamID) ([]keybase1.EkGenerationStatus, error) {
+	return s.status[teamID], nil
+}
+
+func (s *repairTestTeamEKBoxStorage) Refetch(mctx libkb.MetaContext, teamID keybase1.TeamID, generation keybase1.EkGeneration) error {
+	s.refetched = append(s.refetched, fmt.Sprintf("%v %d", teamID, generation))
+	return nil
+}
+
+// TestEKRepairOffline runs a repair against fake storages, so nothing is
+// fetched from or published to the server.
+func TestEKRepairOffline(t *testing.T) {
+	tc := libkb.SetupTest(t, "ephemeral", 2)
+	defer tc.Cleanup()
+	mctx := libkb.NewMetaContextForTest(tc)
+
+	broken := func(gen keybase1.EkGeneration) keybase1.EkGenerationStatus {
+		return keybase1.EkGenerationStatus{Generation: gen, Error: "broken"}
+	}
+	ok := func(gen keybase1.EkGeneration) keybase1.EkGenerationStatus {
+		return keybase1.EkGenerationStatus{Generation: gen}
+	}
+	teamID := keybase1.TeamID("4d4fe5a4d2c1bb3fbd4ff3f72f4c5224")
+	deviceStorage := &repairTestDeviceEKStorage{statuses: [][]keybase1.EkGenerationStatus{
+		{broken(1), broken(2), ok(3)},
+		// generation 2 can be read again by the time we would delete it
+		{broken(1), ok(2), ok(3)},
+	}}
+	userStorage := &repairTestUserEKBoxStorage{status: []keybase1.EkGenerationStatus{broken(5), ok(7)}}
+	teamStorage := &repairTestTeamEKBoxStorage{status: map[keybase1.TeamID][]keybase1.EkGenerationStatus{
+		teamID: {ok(1), broken(2)},
+	}}
+	teambotStorage := &repairTestTeamEKBoxStorage{}
+	tc.G.SetDeviceEKStorage(deviceStorage)
+	tc.G.SetUserEKBoxStorage(userStorage)
+	tc.G.SetTeamEKBoxStorage(teamStorage)
+	tc.G.SetTeambotEKBoxStorage(teambotStorage)
+	ekLib := NewEKLib(mctx)
+	defer func() { _ = ekLib.Shutdown(mctx) }()
+
+	res, err := ekLib.Repair(mctx, false)
+	require.NoError(t, err)
+	require.True(t, res.DryRun)
+	require.Equal(t, []string{
+		"delete unreadable deviceEK 1",
+		"delete unreadable deviceEK 2",
+		"re-fetch userEK 5",
+		"re-fetch userEK 6",
+		fmt.Sprintf("re-fetch teamEK 2 for %v", teamID),
+	}, res.Actions)
+	actions := planRepair(res.Storages)
+	require.Equal(t, []ekRepairAction{
+		{layer: keybase1.EkStorageLayer_DEVICE, generation: 1, delete: true},
+		{layer: keybase1.EkStorageLayer_DEVICE, generation: 2, delete: true},
+		{layer: keybase1.EkStorageLayer_USER, generation: 5},
+		{layer: keybase1.EkStorageLayer_USER, generation: 6},
+		{layer: keybase1.EkStorageLayer_TEAM, teamID: teamID, generation: 2},
+	}, actions)
+	require.Empty(t, deviceStorage.deleted)
+	require.Empty(t, userStorage.refetched)
+	require.Empty(t, teamStorage.refetched)
+
+	// Applying the repair reads device EKs once more before deleting any.
+	res = keybase1.EkRepairRes{}
+	require.NoError(t, ekLib.applyRepair(mctx, actions, &res))
+	require.Equal(t, []keybase1.EkGeneration{1}, deviceStorage.deleted)
+	require.Equal(t, []keybase1.EkGeneration{5, 6}, userStorage.refetched)
+	require.Equal(t, []string{fmt.Sprintf("%v 2", teamID)}, teamStorage.refetched)
+	require.Empty(t, teambotStorage.refetched)
+	require.Equal(t, 1, res.Deleted)
+	require.Equal(t, 3, res.Refetched)
+	require.Empty(t, res.Errors)
+}
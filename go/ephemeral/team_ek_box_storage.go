@@ -3,6 +3,8 @@ package ephemeral
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -341,6 +343,72 @@ func (s *TeamEKBoxStorage) GetAll(mctx libkb.MetaContext, teamID keybase1.TeamID
 	return teamEKs, err
 }
 
+// Refetch fetches the box for generation from the server again, ignoring
+// anything we have stored for it. Transient errors leave storage untouched.
+func (s *TeamEKBoxStorage) Refetch(mctx libkb.MetaContext, teamID keybase1.TeamID, generation keybase1.EkGeneration) (err error) {
+	defer mctx.Trace(fmt.Sprintf("TeamEKBoxStorage#Refetch: teamID:%v, generation:%v", teamID, generation), &err)()
+	_, err = s.fetchAndStore(mctx, teamID, generation, nil)
+	return err
+}
+
+// Status lists every stored generation for the team, including cached fetch
+// or unboxing errors, without unboxing anything.
+func (s *TeamEKBoxStorage) Status(mctx libkb.MetaContext, teamID keybase1.TeamID) (res []keybase1.EkGenerationStatus, err error) {
+	defer mctx.Trace(fmt.Sprintf("TeamEKBoxStorage#Status: teamID:%v", teamID), &err)()
+
+	unlock := s.lockForTeamID(mctx, teamID)
+	defer unlock()
+
+	cache, _, err := s.getCacheForTeamID(mctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	for generation, cacheItem := range cache {
+		status := keybase1.EkGenerationStatus{
+			Generation: generation,
+			Ctime:      cacheItem.TeamEKBoxed.Ctime(),
+		}
+		if cacheItem.HasError() {
+			status.Error = cacheItem.Error().Error()
+		}
+		res = append(res, status)
+	}
+	sortGenerationStatus(res)
+	return res, nil
+}
+
+// TeamIDs lists the teams for which we have anything stored for the current
+// user.
+func (s *TeamEKBoxStorage) TeamIDs(mctx libkb.MetaContext) (teamIDs []keybase1.TeamID, err error) {
+	defer mctx.Trace("TeamEKBoxStorage#TeamIDs", &err)()
+
+	keyPrefix := fmt.Sprintf("teamEphemeralKeyBox-%s-", s.keyer.Type())
+	dbKeys, err := mctx.G().GetKVStore().KeysWithPrefixes(libkb.DbKey{
+		Typ: libkb.DBTeamEKBox,
+		Key: keyPrefix,
+	}.ToBytes())
+	if err != nil {
+		return nil, err
+	}
+	for dbKey := range dbKeys {
+		if dbKey.Typ != libkb.DBTeamEKBox || !strings.HasPrefix(dbKey.Key, keyPrefix) {
+			continue
+		}
+		teamID, _, _ := strings.Cut(strings.TrimPrefix(dbKey.Key, keyPrefix), "-")
+		// Make sure the key belongs to the current user and db version.
+		expected, err := s.dbKey(mctx, keybase1.TeamID(teamID))
+		if err != nil {
+			return nil, err
+		}
+		if expected != dbKey {
+			continue
+		}
+		teamIDs = append(teamIDs, keybase1.TeamID(teamID))
+	}
+	sort.Slice(teamIDs, func(i, j int) bool { return teamIDs[i] < teamIDs[j] })
+	return teamIDs, nil
+}
+
 func (s *TeamEKBoxStorage) ClearCache() {
 	s.Lock()
 	defer s.Unlock()
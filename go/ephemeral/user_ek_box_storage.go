@@ -330,6 +330,40 @@ func (s *UserEKBoxStorage) GetAll(mctx libkb.MetaContext) (userEKs UserEKUnboxed
 	return userEKs, err
 }
 
+// Refetch fetches the box for generation from the server again, ignoring
+// anything we have stored for it. Transient errors leave storage untouched.
+func (s *UserEKBoxStorage) Refetch(mctx libkb.MetaContext, generation keybase1.EkGeneration) (err error) {
+	defer mctx.Trace(fmt.Sprintf("UserEKBoxStorage#Refetch: generation:%v", generation), &err)()
+	_, err = s.fetchAndStore(mctx, generation)
+	return err
+}
+
+// Status lists every stored generation, including cached fetch or unboxing
+// errors, without unboxing anything.
+func (s *UserEKBoxStorage) Status(mctx libkb.MetaContext) (res []keybase1.EkGenerationStatus, err error) {
+	defer mctx.Trace("UserEKBoxStorage#Status", &err)()
+
+	s.Lock()
+	defer s.Unlock()
+	cache, err := s.getCache(mctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for generation, cacheItem := range cache {
+		status := keybase1.EkGenerationStatus{
+			Generation: generation,
+			Ctime:      cacheItem.UserEKBoxed.Metadata.Ctime,
+		}
+		if cacheItem.HasError() {
+			status.Error = cacheItem.Error().Error()
+		}
+		res = append(res, status)
+	}
+	sortGenerationStatus(res)
+	return res, nil
+}
+
 func (s *UserEKBoxStorage) ClearCache() {
 	s.Lock()
 	defer s.Unlock()
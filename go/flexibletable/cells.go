@@ -56,7 +56,7 @@ func (c Cell) renderWithPadding(width int) (string, error) {
 }
 
 func (c Cell) addPadding(str string, width int) (string, error) {
-	padding := width - len(str)
+	padding := width - displayLen(str)
 	if padding < 0 {
 		return "", WidthTooSmallError{}
 	}
@@ -101,15 +101,16 @@ func (c SingleCell) full() string {
 }
 
 func (c SingleCell) render(maxWidth int) string {
-	if len(c.Item) <= maxWidth {
+	if displayLen(c.Item) <= maxWidth {
 		return c.Item
 	}
-	return c.Item[:maxWidth-3] + "..."
+	head, _ := splitDisplay(c.Item, maxWidth-3)
+	return head + "..."
 }
 
 func (c SingleCell) minWidth() int {
-	if len(c.Item) < 3 {
-		return len(c.Item)
+	if n := displayLen(c.Item); n < 3 {
+		return n
 	}
 	return 3 // "..."
 }
@@ -161,3 +162,64 @@ func (c MultiCell) minWidth() int {
 	}
 	return digestMin
 }
+
+// sgrLen returns the length of the SGR escape sequence (like "\x1b[1m") at the
+// start of s, or 0 if there is none.
+func sgrLen(s string) int {
+	if len(s) < 3 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == 'm':
+			return i + 1
+		case c != ';' && (c < '0' || c > '9'):
+			return 0
+		}
+	}
+	return 0
+}
+
+// displayLen is the length of s without SGR escape sequences, which style the
+// text but take no room on the terminal.
+func displayLen(s string) (n int) {
+	for i := 0; i < len(s); {
+		if l := sgrLen(s[i:]); l > 0 {
+			i += l
+			continue
+		}
+		i++
+		n++
+	}
+	return n
+}
+
+// splitDisplay splits s after a display length of n. Styles that are on at the
+// split are reset at the end of head and turned on again at the start of tail,
+// so that each part can go on a line of its own.
+func splitDisplay(s string, n int) (head, tail string) {
+	var styles []string
+	i := 0
+	for shown := 0; i < len(s); {
+		if l := sgrLen(s[i:]); l > 0 {
+			if s[i:i+l] == "\x1b[0m" {
+				styles = nil
+			} else {
+				styles = append(styles, s[i:i+l])
+			}
+			i += l
+			continue
+		}
+		if shown == n {
+			break
+		}
+		i++
+		shown++
+	}
+	head, tail = s[:i], s[i:]
+	if len(styles) > 0 && len(tail) > 0 {
+		head += "\x1b[0m"
+		tail = strings.Join(styles, "") + tail
+	}
+	return head, tail
+}
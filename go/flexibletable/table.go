@@ -131,8 +131,8 @@ func (t Table) renderFirstPass(cellSep string, maxWidth int, constraints []Colum
 				if err != nil {
 					return nil, err
 				}
-				if widths[i] < len(str) {
-					widths[i] = len(str)
+				if n := displayLen(str); widths[i] < n {
+					widths[i] = n
 				}
 			}
 		}
@@ -192,9 +192,10 @@ func (t Table) renderSecondPass(constraints []ColumnConstraint, widths []int) (r
 				if constraints[i] < 0 && widths[i] <= 0 && len(strs[i]) > 0 {
 					return nil, WidthTooSmallError{}
 				}
-				if widths[i] < len(strs[i]) {
-					toAppend = append(toAppend, strs[i][:widths[i]])
-					strs[i] = strs[i][widths[i]:]
+				if widths[i] < displayLen(strs[i]) {
+					var head string
+					head, strs[i] = splitDisplay(strs[i], widths[i])
+					toAppend = append(toAppend, head)
 					wrapping = true
 				} else {
 					str, err := row[i].addPadding(strs[i], widths[i])
@@ -144,3 +144,25 @@ func TestTableMultiline(t *testing.T) {
 		readable(out.String()),
 		readable(expected))
 }
+
+func TestTableStyledText(t *testing.T) {
+	table := &Table{}
+	err := table.Insert(Row{
+		Cell{Content: SingleCell{Item: "\x1b[1mbold\x1b[22m"}},
+		Cell{Content: SingleCell{Item: "one \x1b[3mtwo three\x1b[23m four"}},
+	})
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+	err = table.Render(out, " ", 14, []ColumnConstraint{4, ExpandableWrappable})
+	require.NoError(t, err)
+	// escapes take no room, and styles go on after a wrap
+	require.Equal(t, "\x1b[1mbold\x1b[22m one \x1b[3mtwo t\x1b[0m\n"+
+		"     \x1b[3mhree\x1b[23m four\n", out.String())
+
+	head, tail := splitDisplay("\x1b[36mabc\x1b[0mdef", 4)
+	require.Equal(t, "\x1b[36mabc\x1b[0md", head)
+	require.Equal(t, "ef", tail)
+	require.Equal(t, 6, displayLen("\x1b[36mabc\x1b[0mdef"))
+	// other escapes are not styles, Clean takes care of them
+	require.Equal(t, 6, displayLen("\x1b[4Pab"))
+}
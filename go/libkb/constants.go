@@ -611,6 +611,8 @@ const (
 	GitPerfLogFileName  = "keybase.git.perf.log"
 	UpdaterLogFileName  = "keybase.updater.log"
 	GUILogFileName      = "Keybase.app.log"
+	// WebhookDeadLetterFileName is where chat webhook deliveries that failed for good are logged
+	WebhookDeadLetterFileName = "keybase.webhooks.deadletter.log"
 	// StartLogFileName is where services can log to (on startup) before they handle their own logging
 	StartLogFileName = "keybase.start.log"
 )
@@ -638,6 +640,7 @@ const (
 	EncryptionReasonContactsLocalStorage    EncryptionReason = "Keybase-Contacts-Local-Storage-1"
 	EncryptionReasonContactsResolvedServer  EncryptionReason = "Keybase-Contacts-Resolved-Server-1"
 	EncryptionReasonTeambotKeyLocalStorage  EncryptionReason = "Keybase-Teambot-Key-Local-Storage-1"
+	EncryptionReasonStellarSchedule         EncryptionReason = "Keybase-Stellar-Scheduled-Payments-1"
 	EncryptionReasonKBFSFavorites           EncryptionReason = "kbfs.favorites" // legacy const for kbfs favorites
 )
 
@@ -761,6 +764,8 @@ const (
 	MaxStellarPaymentNoteLength       = 500
 	MaxStellarPaymentBoxedNoteLength  = 2000
 	MaxStellarPaymentPublicNoteLength = 28
+	MaxStellarInvoiceLineItems        = 50
+	MaxStellarBoxedInvoiceLength      = 12000
 )
 
 const ClientTriplesecVersion = 3
@@ -51,6 +51,9 @@ const (
 	DBOfflineRPC                     = 0xbe
 	DBChatCollapses                  = 0xbf
 	DBSupportsHiddenFlagStorage      = 0xc0
+	DBStellarScheduledPayments       = 0xc1
+	DBChatWebhooks                   = 0xc2
+	DBChatIncomingWebhooks           = 0xc3
 	DBMerkleAudit                    = 0xca
 	DBUnfurler                       = 0xcb
 	DBStellarDisclaimer              = 0xcc
@@ -134,6 +137,9 @@ func IsPermDbKey(typ ObjType) bool {
 		DBLegacyHasRandomPW,
 		DBChatReacji,
 		DBStellarDisclaimer,
+		DBStellarScheduledPayments,
+		DBChatWebhooks,
+		DBChatIncomingWebhooks,
 		DBChatIndex,
 		DBBoxAuditorPermanent,
 		DBSavedContacts,
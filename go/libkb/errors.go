@@ -2203,10 +2203,14 @@ func (e ChatStalePreviousStateError) Error() string {
 // =============================================================================
 
 type ChatEphemeralRetentionPolicyViolatedError struct {
-	MaxAge gregor1.DurationSec
+	MaxAge            gregor1.DurationSec
+	AllowNonExploding bool
 }
 
 func (e ChatEphemeralRetentionPolicyViolatedError) Error() string {
+	if e.AllowNonExploding {
+		return fmt.Sprintf("exploding messages in this conversation are required to have a maximum lifetime of %v", e.MaxAge.ToDuration())
+	}
 	return fmt.Sprintf("messages in this conversation are required to be exploding with a maximum lifetime of %v", e.MaxAge.ToDuration())
 }
 
@@ -837,6 +837,7 @@ type MiniChatPaymentSummary struct {
 type Stellar interface {
 	CreateWalletSoft(context.Context)
 	Upkeep(context.Context) error
+	RunScheduledPayments(MetaContext) error
 	GetServerDefinitions(context.Context) (stellar1.StellarServerDefinitions, error)
 	KickAutoClaimRunner(MetaContext, gregor.MsgID)
 	UpdateUnreadCount(ctx context.Context, accountID stellar1.AccountID, unread int) error
@@ -856,6 +857,7 @@ type DeviceEKStorage interface {
 	GetAllActive(mctx MetaContext, merkleRoot MerkleRoot) ([]keybase1.DeviceEkMetadata, error)
 	MaxGeneration(mctx MetaContext, includeErrs bool) (keybase1.EkGeneration, error)
 	DeleteExpired(mctx MetaContext, merkleRoot MerkleRoot) ([]keybase1.EkGeneration, error)
+	Delete(mctx MetaContext, generation keybase1.EkGeneration, reason string, args ...any) error
 	ClearCache()
 	// Dangerous! Only for deprovisioning or shutdown/logout when in oneshot mode.
 	ForceDeleteAll(mctx MetaContext, username NormalizedUsername) error
@@ -863,6 +865,8 @@ type DeviceEKStorage interface {
 	ListAllForUser(mctx MetaContext) ([]string, error)
 	// Called on login/logout hooks to set the logged in username in the EK log
 	SetLogPrefix(mctx MetaContext)
+	// For keybase ephemeral status
+	Status(mctx MetaContext) ([]keybase1.EkGenerationStatus, error)
 }
 
 type UserEKBoxStorage interface {
@@ -870,7 +874,10 @@ type UserEKBoxStorage interface {
 	Get(mctx MetaContext, generation keybase1.EkGeneration, contentCtime *gregor1.Time) (keybase1.UserEk, error)
 	MaxGeneration(mctx MetaContext, includeErrs bool) (keybase1.EkGeneration, error)
 	DeleteExpired(mctx MetaContext, merkleRoot MerkleRoot) ([]keybase1.EkGeneration, error)
+	Delete(mctx MetaContext, generation keybase1.EkGeneration) error
 	ClearCache()
+	Status(mctx MetaContext) ([]keybase1.EkGenerationStatus, error)
+	Refetch(mctx MetaContext, generation keybase1.EkGeneration) error
 }
 
 type TeamEKBoxStorage interface {
@@ -881,6 +888,9 @@ type TeamEKBoxStorage interface {
 	PurgeCacheForTeamID(mctx MetaContext, teamID keybase1.TeamID) error
 	Delete(mctx MetaContext, teamID keybase1.TeamID, generation keybase1.EkGeneration) error
 	ClearCache()
+	Status(mctx MetaContext, teamID keybase1.TeamID) ([]keybase1.EkGenerationStatus, error)
+	TeamIDs(mctx MetaContext) ([]keybase1.TeamID, error)
+	Refetch(mctx MetaContext, teamID keybase1.TeamID, generation keybase1.EkGeneration) error
 }
 
 type EKLib interface {
@@ -910,6 +920,9 @@ type EKLib interface {
 	BoxLatestTeamEK(mctx MetaContext, teamID keybase1.TeamID, uids []keybase1.UID) (*[]keybase1.TeamEkBoxMetadata, error)
 	PrepareNewTeamEK(mctx MetaContext, teamID keybase1.TeamID, signingKey NaclSigningKeyPair, uids []keybase1.UID) (string, *[]keybase1.TeamEkBoxMetadata, keybase1.TeamEkMetadata, *keybase1.TeamEkBoxed, error)
 	ClearCaches(mctx MetaContext)
+	// For keybase ephemeral status/repair
+	Status(mctx MetaContext) ([]keybase1.EkStorageStatus, error)
+	Repair(mctx MetaContext, force bool) (keybase1.EkRepairRes, error)
 	// For testing
 	NewTeamEKNeeded(mctx MetaContext, teamID keybase1.TeamID) (bool, error)
 }
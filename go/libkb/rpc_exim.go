@@ -477,16 +477,19 @@ func ImportStatusAsError(g *GlobalContext, s *keybase1.Status) error {
 	case SCChatStalePreviousState:
 		return ChatStalePreviousStateError{}
 	case SCChatEphemeralRetentionPolicyViolatedError:
-		var maxAge gregor1.DurationSec
+		var ret ChatEphemeralRetentionPolicyViolatedError
 		for _, field := range s.Fields {
-			if field.Key == "MaxAge" {
+			switch field.Key {
+			case "MaxAge":
 				dur, err := time.ParseDuration(field.Value)
 				if err == nil {
-					maxAge = gregor1.ToDurationSec(dur)
+					ret.MaxAge = gregor1.ToDurationSec(dur)
 				}
+			case "AllowNonExploding":
+				ret.AllowNonExploding = field.BoolValue()
 			}
 		}
-		return ChatEphemeralRetentionPolicyViolatedError{maxAge}
+		return ret
 	case SCChatConvExists:
 		var convID chat1.ConversationID
 		for _, field := range s.Fields {
@@ -2075,15 +2078,14 @@ func (e ChatStalePreviousStateError) ToStatus() keybase1.Status {
 }
 
 func (e ChatEphemeralRetentionPolicyViolatedError) ToStatus() keybase1.Status {
-	kv := keybase1.StringKVPair{
-		Key:   "MaxAge",
-		Value: e.MaxAge.ToDuration().String(),
-	}
 	return keybase1.Status{
-		Code:   SCChatEphemeralRetentionPolicyViolatedError,
-		Name:   "SC_CHAT_EPHEMERAL_RETENTION_POLICY_VIOLATED",
-		Desc:   e.Error(),
-		Fields: []keybase1.StringKVPair{kv},
+		Code: SCChatEphemeralRetentionPolicyViolatedError,
+		Name: "SC_CHAT_EPHEMERAL_RETENTION_POLICY_VIOLATED",
+		Desc: e.Error(),
+		Fields: []keybase1.StringKVPair{
+			{Key: "MaxAge", Value: e.MaxAge.ToDuration().String()},
+			{Key: "AllowNonExploding", Value: strconv.FormatBool(e.AllowNonExploding)},
+		},
 	}
 }
 
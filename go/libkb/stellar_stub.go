@@ -28,6 +28,10 @@ func (n *nullStellar) Upkeep(ctx context.Context) error {
 	return fmt.Errorf("null stellar impl")
 }
 
+func (n *nullStellar) RunScheduledPayments(MetaContext) error {
+	return errors.New("nullStellar RunScheduledPayments")
+}
+
 func (n *nullStellar) GetServerDefinitions(ctx context.Context) (ret stellar1.StellarServerDefinitions, err error) {
 	return ret, fmt.Errorf("null stellar impl")
 }
@@ -140,6 +140,168 @@ func (o MsgFlipContent) DeepCopy() MsgFlipContent {
 	}
 }
 
+type PollOptionResult struct {
+	Option string   `codec:"option" json:"option"`
+	Votes  int      `codec:"votes" json:"votes"`
+	Voters []string `codec:"voters,omitempty" json:"voters,omitempty"`
+}
+
+func (o PollOptionResult) DeepCopy() PollOptionResult {
+	return PollOptionResult{
+		Option: o.Option,
+		Votes:  o.Votes,
+		Voters: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Voters),
+	}
+}
+
+type MsgPollContent struct {
+	Question    string             `codec:"question" json:"question"`
+	MultiChoice bool               `codec:"multiChoice" json:"multi_choice"`
+	Anonymous   bool               `codec:"anonymous" json:"anonymous"`
+	CloseTime   *int64             `codec:"closeTime,omitempty" json:"close_time,omitempty"`
+	Closed      bool               `codec:"closed" json:"closed"`
+	TotalVoters int                `codec:"totalVoters" json:"total_voters"`
+	Options     []PollOptionResult `codec:"options" json:"options"`
+}
+
+func (o MsgPollContent) DeepCopy() MsgPollContent {
+	return MsgPollContent{
+		Question:    o.Question,
+		MultiChoice: o.MultiChoice,
+		Anonymous:   o.Anonymous,
+		CloseTime: (func(x *int64) *int64 {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.CloseTime),
+		Closed:      o.Closed,
+		TotalVoters: o.TotalVoters,
+		Options: (func(x []PollOptionResult) []PollOptionResult {
+			if x == nil {
+				return nil
+			}
+			ret := make([]PollOptionResult, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+	}
+}
+
+type MsgPollVoteContent struct {
+	MessageID MessageID `codec:"messageID" json:"message_id"`
+	Choices   []int     `codec:"choices" json:"choices"`
+}
+
+func (o MsgPollVoteContent) DeepCopy() MsgPollVoteContent {
+	return MsgPollVoteContent{
+		MessageID: o.MessageID.DeepCopy(),
+		Choices: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Choices),
+	}
+}
+
+type MsgInteractiveOption struct {
+	Label   string `codec:"label" json:"label"`
+	Payload string `codec:"payload" json:"payload"`
+}
+
+func (o MsgInteractiveOption) DeepCopy() MsgInteractiveOption {
+	return MsgInteractiveOption{
+		Label:   o.Label,
+		Payload: o.Payload,
+	}
+}
+
+type MsgInteractiveElement struct {
+	Typ     string                 `codec:"typ" json:"type"`
+	Id      string                 `codec:"id" json:"id"`
+	Label   string                 `codec:"label" json:"label"`
+	Payload string                 `codec:"payload,omitempty" json:"payload,omitempty"`
+	Options []MsgInteractiveOption `codec:"options,omitempty" json:"options,omitempty"`
+}
+
+func (o MsgInteractiveElement) DeepCopy() MsgInteractiveElement {
+	return MsgInteractiveElement{
+		Typ:     o.Typ,
+		Id:      o.Id,
+		Label:   o.Label,
+		Payload: o.Payload,
+		Options: (func(x []MsgInteractiveOption) []MsgInteractiveOption {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MsgInteractiveOption, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+	}
+}
+
+type MsgInteractiveContent struct {
+	Body     string                  `codec:"body" json:"body"`
+	Elements []MsgInteractiveElement `codec:"elements" json:"elements"`
+}
+
+func (o MsgInteractiveContent) DeepCopy() MsgInteractiveContent {
+	return MsgInteractiveContent{
+		Body: o.Body,
+		Elements: (func(x []MsgInteractiveElement) []MsgInteractiveElement {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MsgInteractiveElement, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Elements),
+	}
+}
+
+type MsgInteractiveActionContent struct {
+	MessageID MessageID    `codec:"messageID" json:"message_id"`
+	BotUID    keybase1.UID `codec:"botUID" json:"bot_uid"`
+	ElementID string       `codec:"elementID" json:"element_id"`
+	Payload   string       `codec:"payload" json:"payload"`
+}
+
+func (o MsgInteractiveActionContent) DeepCopy() MsgInteractiveActionContent {
+	return MsgInteractiveActionContent{
+		MessageID: o.MessageID.DeepCopy(),
+		BotUID:    o.BotUID.DeepCopy(),
+		ElementID: o.ElementID,
+		Payload:   o.Payload,
+	}
+}
+
 type EmojiContent struct {
 	Alias       string     `codec:"alias" json:"alias"`
 	IsCrossTeam bool       `codec:"isCrossTeam" json:"isCrossTeam"`
@@ -169,14 +331,15 @@ func (o EmojiContent) DeepCopy() EmojiContent {
 }
 
 type MsgTextContent struct {
-	Body         string             `codec:"body" json:"body"`
-	Payments     []TextPayment      `codec:"payments" json:"payments"`
-	ReplyTo      *MessageID         `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
-	ReplyToUID   *string            `codec:"replyToUID,omitempty" json:"replyToUID,omitempty"`
-	UserMentions []KnownUserMention `codec:"userMentions" json:"userMentions"`
-	TeamMentions []KnownTeamMention `codec:"teamMentions" json:"teamMentions"`
-	LiveLocation *LiveLocation      `codec:"liveLocation,omitempty" json:"liveLocation,omitempty"`
-	Emojis       []EmojiContent     `codec:"emojis" json:"emojis"`
+	Body         string                `codec:"body" json:"body"`
+	Payments     []TextPayment         `codec:"payments" json:"payments"`
+	ReplyTo      *MessageID            `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
+	ReplyToUID   *string               `codec:"replyToUID,omitempty" json:"replyToUID,omitempty"`
+	UserMentions []KnownUserMention    `codec:"userMentions" json:"userMentions"`
+	TeamMentions []KnownTeamMention    `codec:"teamMentions" json:"teamMentions"`
+	LiveLocation *LiveLocation         `codec:"liveLocation,omitempty" json:"liveLocation,omitempty"`
+	Emojis       []EmojiContent        `codec:"emojis" json:"emojis"`
+	BotCommand   *BotCommandInvocation `codec:"botCommand,omitempty" json:"botCommand,omitempty"`
 }
 
 func (o MsgTextContent) DeepCopy() MsgTextContent {
@@ -247,6 +410,13 @@ func (o MsgTextContent) DeepCopy() MsgTextContent {
 			}
 			return ret
 		})(o.Emojis),
+		BotCommand: (func(x *BotCommandInvocation) *BotCommandInvocation {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.BotCommand),
 	}
 }
 
@@ -265,6 +435,10 @@ type MsgContent struct {
 	RequestPayment     *MessageRequestPayment       `codec:"requestPayment,omitempty" json:"request_payment,omitempty"`
 	Unfurl             *MessageUnfurl               `codec:"unfurl,omitempty" json:"unfurl,omitempty"`
 	Flip               *MsgFlipContent              `codec:"flip,omitempty" json:"flip,omitempty"`
+	Poll               *MsgPollContent              `codec:"poll,omitempty" json:"poll,omitempty"`
+	PollVote           *MsgPollVoteContent          `codec:"pollVote,omitempty" json:"poll_vote,omitempty"`
+	Interactive        *MsgInteractiveContent       `codec:"interactive,omitempty" json:"interactive,omitempty"`
+	InteractiveAction  *MsgInteractiveActionContent `codec:"interactiveAction,omitempty" json:"interactive_action,omitempty"`
 }
 
 func (o MsgContent) DeepCopy() MsgContent {
@@ -361,6 +535,34 @@ func (o MsgContent) DeepCopy() MsgContent {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Flip),
+		Poll: (func(x *MsgPollContent) *MsgPollContent {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Poll),
+		PollVote: (func(x *MsgPollVoteContent) *MsgPollVoteContent {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.PollVote),
+		Interactive: (func(x *MsgInteractiveContent) *MsgInteractiveContent {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Interactive),
+		InteractiveAction: (func(x *MsgInteractiveActionContent) *MsgInteractiveActionContent {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.InteractiveAction),
 	}
 }
 
@@ -534,6 +736,18 @@ func (o Thread) DeepCopy() Thread {
 	}
 }
 
+type ThreadUnreadSummary struct {
+	RootID      MessageID `codec:"rootID" json:"root_id"`
+	UnreadCount int       `codec:"unreadCount" json:"unread_count"`
+}
+
+func (o ThreadUnreadSummary) DeepCopy() ThreadUnreadSummary {
+	return ThreadUnreadSummary{
+		RootID:      o.RootID.DeepCopy(),
+		UnreadCount: o.UnreadCount,
+	}
+}
+
 // A chat conversation. This is essentially a chat channel plus some additional metadata.
 type ConvSummary struct {
 	Id            ConvIDStr                     `codec:"id" json:"id"`
@@ -549,6 +763,7 @@ type ConvSummary struct {
 	SupersededBy  []string                      `codec:"supersededBy,omitempty" json:"superseded_by,omitempty"`
 	Error         string                        `codec:"error,omitempty" json:"error,omitempty"`
 	CreatorInfo   *ConversationCreatorInfoLocal `codec:"creatorInfo,omitempty" json:"creator_info,omitempty"`
+	UnreadThreads []ThreadUnreadSummary         `codec:"unreadThreads,omitempty" json:"unread_threads,omitempty"`
 }
 
 func (o ConvSummary) DeepCopy() ConvSummary {
@@ -608,6 +823,17 @@ func (o ConvSummary) DeepCopy() ConvSummary {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.CreatorInfo),
+		UnreadThreads: (func(x []ThreadUnreadSummary) []ThreadUnreadSummary {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ThreadUnreadSummary, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.UnreadThreads),
 	}
 }
 
@@ -1059,6 +1285,118 @@ func (o GetDeviceInfoRes) DeepCopy() GetDeviceInfoRes {
 	}
 }
 
+type ScheduledMsgSummary struct {
+	Id                string      `codec:"id" json:"id"`
+	ConvID            ConvIDStr   `codec:"convID" json:"conversation_id"`
+	Channel           ChatChannel `codec:"channel" json:"channel"`
+	Body              string      `codec:"body" json:"body"`
+	SendAt            int64       `codec:"sendAt" json:"send_at"`
+	SendAtMs          int64       `codec:"sendAtMs" json:"send_at_ms"`
+	ExplodingLifetime *string     `codec:"explodingLifetime,omitempty" json:"exploding_lifetime,omitempty"`
+}
+
+func (o ScheduledMsgSummary) DeepCopy() ScheduledMsgSummary {
+	return ScheduledMsgSummary{
+		Id:       o.Id,
+		ConvID:   o.ConvID.DeepCopy(),
+		Channel:  o.Channel.DeepCopy(),
+		Body:     o.Body,
+		SendAt:   o.SendAt,
+		SendAtMs: o.SendAtMs,
+		ExplodingLifetime: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.ExplodingLifetime),
+	}
+}
+
+type ListScheduledRes struct {
+	Scheduled []ScheduledMsgSummary `codec:"scheduled" json:"scheduled"`
+}
+
+func (o ListScheduledRes) DeepCopy() ListScheduledRes {
+	return ListScheduledRes{
+		Scheduled: (func(x []ScheduledMsgSummary) []ScheduledMsgSummary {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ScheduledMsgSummary, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Scheduled),
+	}
+}
+
+type PollResultsRes struct {
+	MessageID  MessageID      `codec:"messageID" json:"message_id"`
+	Poll       MsgPollContent `codec:"poll" json:"poll"`
+	RateLimits []RateLimitRes `codec:"rateLimits,omitempty" json:"ratelimits,omitempty"`
+}
+
+func (o PollResultsRes) DeepCopy() PollResultsRes {
+	return PollResultsRes{
+		MessageID: o.MessageID.DeepCopy(),
+		Poll:      o.Poll.DeepCopy(),
+		RateLimits: (func(x []RateLimitRes) []RateLimitRes {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimitRes, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+	}
+}
+
+type DNDScheduleSummary struct {
+	Enabled       bool          `codec:"enabled" json:"enabled"`
+	Active        bool          `codec:"active" json:"active"`
+	Timezone      string        `codec:"timezone" json:"timezone"`
+	Windows       []string      `codec:"windows" json:"windows"`
+	AllowDMs      bool          `codec:"allowDMs" json:"allow_dms"`
+	AllowChannels []ChatChannel `codec:"allowChannels" json:"allow_channels"`
+}
+
+func (o DNDScheduleSummary) DeepCopy() DNDScheduleSummary {
+	return DNDScheduleSummary{
+		Enabled:  o.Enabled,
+		Active:   o.Active,
+		Timezone: o.Timezone,
+		Windows: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Windows),
+		AllowDMs: o.AllowDMs,
+		AllowChannels: (func(x []ChatChannel) []ChatChannel {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChatChannel, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.AllowChannels),
+	}
+}
+
 type ApiInterface interface {
 }
 
@@ -1072,3 +1410,83 @@ func ApiProtocol(i ApiInterface) rpc.Protocol {
 type ApiClient struct {
 	Cli rpc.GenericClient
 }
+
+type BookmarkSummary struct {
+	ConvID         ConvIDStr   `codec:"convID" json:"conversation_id"`
+	Channel        ChatChannel `codec:"channel" json:"channel"`
+	MsgID          MessageID   `codec:"msgID" json:"message_id"`
+	Note           string      `codec:"note,omitempty" json:"note,omitempty"`
+	BookmarkedAtMs int64       `codec:"bookmarkedAtMs" json:"bookmarked_at_ms"`
+	Msg            *MsgSummary `codec:"msg,omitempty" json:"msg,omitempty"`
+	Error          *string     `codec:"error,omitempty" json:"error,omitempty"`
+}
+
+func (o BookmarkSummary) DeepCopy() BookmarkSummary {
+	return BookmarkSummary{
+		ConvID:         o.ConvID.DeepCopy(),
+		Channel:        o.Channel.DeepCopy(),
+		MsgID:          o.MsgID.DeepCopy(),
+		Note:           o.Note,
+		BookmarkedAtMs: o.BookmarkedAtMs,
+		Msg: (func(x *MsgSummary) *MsgSummary {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Msg),
+		Error: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Error),
+	}
+}
+
+type BookmarkList struct {
+	Bookmarks        []BookmarkSummary             `codec:"bookmarks" json:"bookmarks"`
+	Offline          bool                          `codec:"offline" json:"offline"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures,omitempty" json:"identify_failures,omitempty"`
+	RateLimits       []RateLimitRes                `codec:"rateLimits,omitempty" json:"ratelimits,omitempty"`
+}
+
+func (o BookmarkList) DeepCopy() BookmarkList {
+	return BookmarkList{
+		Bookmarks: (func(x []BookmarkSummary) []BookmarkSummary {
+			if x == nil {
+				return nil
+			}
+			ret := make([]BookmarkSummary, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Bookmarks),
+		Offline: o.Offline,
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+		RateLimits: (func(x []RateLimitRes) []RateLimitRes {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimitRes, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+	}
+}
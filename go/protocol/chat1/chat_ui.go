@@ -533,6 +533,18 @@ func (o UIPinnedMessage) DeepCopy() UIPinnedMessage {
 	}
 }
 
+type ThreadUnread struct {
+	RootMsgID   MessageID `codec:"rootMsgID" json:"rootMsgID"`
+	UnreadCount int       `codec:"unreadCount" json:"unreadCount"`
+}
+
+func (o ThreadUnread) DeepCopy() ThreadUnread {
+	return ThreadUnread{
+		RootMsgID:   o.RootMsgID.DeepCopy(),
+		UnreadCount: o.UnreadCount,
+	}
+}
+
 type InboxUIItem struct {
 	ConvID            ConvIDStr                     `codec:"convID" json:"convID"`
 	TlfID             TLFIDStr                      `codec:"tlfID" json:"tlfID"`
@@ -573,6 +585,7 @@ type InboxUIItem struct {
 	BotCommands       ConversationCommandGroups     `codec:"botCommands" json:"botCommands"`
 	BotAliases        map[string]string             `codec:"botAliases" json:"botAliases"`
 	PinnedMsg         *UIPinnedMessage              `codec:"pinnedMsg,omitempty" json:"pinnedMsg,omitempty"`
+	ThreadUnreads     []ThreadUnread                `codec:"threadUnreads" json:"threadUnreads"`
 }
 
 func (o InboxUIItem) DeepCopy() InboxUIItem {
@@ -715,6 +728,17 @@ func (o InboxUIItem) DeepCopy() InboxUIItem {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.PinnedMsg),
+		ThreadUnreads: (func(x []ThreadUnread) []ThreadUnread {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ThreadUnread, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.ThreadUnreads),
 	}
 }
 
@@ -849,12 +873,14 @@ func (o UIPaymentInfo) DeepCopy() UIPaymentInfo {
 }
 
 type UIRequestInfo struct {
-	Amount             string                        `codec:"amount" json:"amount"`
-	AmountDescription  string                        `codec:"amountDescription" json:"amountDescription"`
-	Asset              *stellar1.Asset               `codec:"asset,omitempty" json:"asset,omitempty"`
-	Currency           *stellar1.OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
-	WorthAtRequestTime string                        `codec:"worthAtRequestTime" json:"worthAtRequestTime"`
-	Status             stellar1.RequestStatus        `codec:"status" json:"status"`
+	Amount                string                        `codec:"amount" json:"amount"`
+	AmountDescription     string                        `codec:"amountDescription" json:"amountDescription"`
+	Asset                 *stellar1.Asset               `codec:"asset,omitempty" json:"asset,omitempty"`
+	Currency              *stellar1.OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
+	WorthAtRequestTime    string                        `codec:"worthAtRequestTime" json:"worthAtRequestTime"`
+	Status                stellar1.RequestStatus        `codec:"status" json:"status"`
+	AmountPaidDescription string                        `codec:"amountPaidDescription" json:"amountPaidDescription"`
+	Invoice               *stellar1.InvoiceContents     `codec:"invoice,omitempty" json:"invoice,omitempty"`
 }
 
 func (o UIRequestInfo) DeepCopy() UIRequestInfo {
@@ -875,8 +901,16 @@ func (o UIRequestInfo) DeepCopy() UIRequestInfo {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Currency),
-		WorthAtRequestTime: o.WorthAtRequestTime,
-		Status:             o.Status.DeepCopy(),
+		WorthAtRequestTime:    o.WorthAtRequestTime,
+		Status:                o.Status.DeepCopy(),
+		AmountPaidDescription: o.AmountPaidDescription,
+		Invoice: (func(x *stellar1.InvoiceContents) *stellar1.InvoiceContents {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Invoice),
 	}
 }
 
@@ -10,12 +10,41 @@ import (
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 )
 
+type UserBotCommandArg struct {
+	Name        string   `codec:"name" json:"name"`
+	Typ         string   `codec:"typ" json:"type"`
+	Description string   `codec:"description,omitempty" json:"description,omitempty"`
+	Optional    bool     `codec:"optional,omitempty" json:"optional,omitempty"`
+	Values      []string `codec:"values,omitempty" json:"values,omitempty"`
+}
+
+func (o UserBotCommandArg) DeepCopy() UserBotCommandArg {
+	return UserBotCommandArg{
+		Name:        o.Name,
+		Typ:         o.Typ,
+		Description: o.Description,
+		Optional:    o.Optional,
+		Values: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Values),
+	}
+}
+
 type ConversationCommand struct {
-	Description string  `codec:"description" json:"description"`
-	Name        string  `codec:"name" json:"name"`
-	Usage       string  `codec:"usage" json:"usage"`
-	HasHelpText bool    `codec:"hasHelpText" json:"hasHelpText"`
-	Username    *string `codec:"username,omitempty" json:"username,omitempty"`
+	Description string              `codec:"description" json:"description"`
+	Name        string              `codec:"name" json:"name"`
+	Usage       string              `codec:"usage" json:"usage"`
+	HasHelpText bool                `codec:"hasHelpText" json:"hasHelpText"`
+	Username    *string             `codec:"username,omitempty" json:"username,omitempty"`
+	Args        []UserBotCommandArg `codec:"args,omitempty" json:"args,omitempty"`
 }
 
 func (o ConversationCommand) DeepCopy() ConversationCommand {
@@ -31,6 +60,17 @@ func (o ConversationCommand) DeepCopy() ConversationCommand {
 			tmp := (*x)
 			return &tmp
 		})(o.Username),
+		Args: (func(x []UserBotCommandArg) []UserBotCommandArg {
+			if x == nil {
+				return nil
+			}
+			ret := make([]UserBotCommandArg, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Args),
 	}
 }
 
@@ -264,6 +264,10 @@ const (
 	MessageType_UNFURL             MessageType = 16
 	MessageType_FLIP               MessageType = 17
 	MessageType_PIN                MessageType = 18
+	MessageType_POLL               MessageType = 19
+	MessageType_POLLVOTE           MessageType = 20
+	MessageType_INTERACTIVE        MessageType = 21
+	MessageType_INTERACTIVEACTION  MessageType = 22
 )
 
 func (o MessageType) DeepCopy() MessageType { return o }
@@ -288,6 +292,10 @@ var MessageTypeMap = map[string]MessageType{
 	"UNFURL":             16,
 	"FLIP":               17,
 	"PIN":                18,
+	"POLL":               19,
+	"POLLVOTE":           20,
+	"INTERACTIVE":        21,
+	"INTERACTIVEACTION":  22,
 }
 
 var MessageTypeRevMap = map[MessageType]string{
@@ -310,6 +318,10 @@ var MessageTypeRevMap = map[MessageType]string{
 	16: "UNFURL",
 	17: "FLIP",
 	18: "PIN",
+	19: "POLL",
+	20: "POLLVOTE",
+	21: "INTERACTIVE",
+	22: "INTERACTIVEACTION",
 }
 
 type TopicType int
@@ -400,6 +412,7 @@ const (
 	GlobalAppNotificationSetting_DEFAULTSOUNDMOBILE GlobalAppNotificationSetting = 3
 	GlobalAppNotificationSetting_DISABLETYPING      GlobalAppNotificationSetting = 4
 	GlobalAppNotificationSetting_CONVERTHEIC        GlobalAppNotificationSetting = 5
+	GlobalAppNotificationSetting_STRIPMETADATA      GlobalAppNotificationSetting = 6
 )
 
 func (o GlobalAppNotificationSetting) DeepCopy() GlobalAppNotificationSetting { return o }
@@ -411,6 +424,7 @@ var GlobalAppNotificationSettingMap = map[string]GlobalAppNotificationSetting{
 	"DEFAULTSOUNDMOBILE": 3,
 	"DISABLETYPING":      4,
 	"CONVERTHEIC":        5,
+	"STRIPMETADATA":      6,
 }
 
 var GlobalAppNotificationSettingRevMap = map[GlobalAppNotificationSetting]string{
@@ -420,6 +434,7 @@ var GlobalAppNotificationSettingRevMap = map[GlobalAppNotificationSetting]string
 	3: "DEFAULTSOUNDMOBILE",
 	4: "DISABLETYPING",
 	5: "CONVERTHEIC",
+	6: "STRIPMETADATA",
 }
 
 func (o GlobalAppNotificationSetting) String() string {
@@ -1242,6 +1257,51 @@ func (o ReactionMap) DeepCopy() ReactionMap {
 	}
 }
 
+type PollVote struct {
+	Ctime     gregor1.Time `codec:"ctime" json:"ctime"`
+	VoteMsgID MessageID    `codec:"voteMsgID" json:"voteMsgID"`
+	Choices   []int        `codec:"choices" json:"choices"`
+}
+
+func (o PollVote) DeepCopy() PollVote {
+	return PollVote{
+		Ctime:     o.Ctime.DeepCopy(),
+		VoteMsgID: o.VoteMsgID.DeepCopy(),
+		Choices: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Choices),
+	}
+}
+
+type PollVoteMap struct {
+	Votes map[string]PollVote `codec:"votes" json:"votes"`
+}
+
+func (o PollVoteMap) DeepCopy() PollVoteMap {
+	return PollVoteMap{
+		Votes: (func(x map[string]PollVote) map[string]PollVote {
+			if x == nil {
+				return nil
+			}
+			ret := make(map[string]PollVote, len(x))
+			for k, v := range x {
+				kCopy := k
+				vCopy := v.DeepCopy()
+				ret[kCopy] = vCopy
+			}
+			return ret
+		})(o.Votes),
+	}
+}
+
 type MessageServerHeader struct {
 	MessageID    MessageID     `codec:"messageID" json:"messageID"`
 	SupersededBy MessageID     `codec:"supersededBy" json:"supersededBy"`
@@ -1991,12 +2051,14 @@ func (o RpInherit) DeepCopy() RpInherit {
 }
 
 type RpEphemeral struct {
-	Age gregor1.DurationSec `codec:"age" json:"age"`
+	Age               gregor1.DurationSec `codec:"age" json:"age"`
+	AllowNonExploding bool                `codec:"allowNonExploding" json:"allowNonExploding"`
 }
 
 func (o RpEphemeral) DeepCopy() RpEphemeral {
 	return RpEphemeral{
-		Age: o.Age.DeepCopy(),
+		Age:               o.Age.DeepCopy(),
+		AllowNonExploding: o.AllowNonExploding,
 	}
 }
 
@@ -201,6 +201,10 @@ var deletableMessageTypesByDelete = []MessageType{
 	MessageType_HEADLINE,
 	MessageType_SYSTEM,
 	MessageType_FLIP,
+	MessageType_POLL,
+	MessageType_POLLVOTE,
+	MessageType_INTERACTIVE,
+	MessageType_INTERACTIVEACTION,
 }
 
 // Messages types NOT deletable by a DELETEHISTORY message.
@@ -245,6 +249,8 @@ var visibleMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_INTERACTIVE,
 }
 
 // Visible chat messages appear visually as a message in the conv.
@@ -262,6 +268,8 @@ var badgeableMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_INTERACTIVE,
 }
 
 // Message types that cause badges.
@@ -286,6 +294,8 @@ var snippetMessageTypes = []MessageType{
 	MessageType_FLIP,
 	MessageType_HEADLINE,
 	MessageType_PIN,
+	MessageType_POLL,
+	MessageType_INTERACTIVE,
 }
 
 // Snippet chat messages can be the snippet of a conversation.
@@ -1033,6 +1043,10 @@ func (b MessageBody) SearchableText() string {
 		return b.Attachment().GetTitle()
 	case MessageType_FLIP:
 		return b.Flip().Text
+	case MessageType_POLL:
+		return strings.Join(append([]string{b.Poll().Question}, b.Poll().Options...), " ")
+	case MessageType_INTERACTIVE:
+		return b.Interactive().SearchableText()
 	case MessageType_UNFURL:
 		return b.Unfurl().SearchableText()
 	case MessageType_SYSTEM:
@@ -1533,6 +1547,12 @@ func (c ConversationLocal) GetMaxMessage(typ MessageType) (MessageSummary, error
 	return MessageSummary{}, fmt.Errorf("max message not found: %v", typ)
 }
 
+// GetEphemeralPolicy returns the exploding message policy in effect for the
+// conversation, or nil if there is none.
+func (c ConversationLocal) GetEphemeralPolicy() *RpEphemeral {
+	return EffectiveEphemeralPolicy(c.ConvRetention, c.TeamRetention)
+}
+
 func (c ConversationLocal) GetMaxDeletedUpTo() MessageID {
 	var maxExpungeID, maxDelHID MessageID
 	if expunge := c.GetExpunge(); expunge != nil {
@@ -1652,6 +1672,12 @@ func (c Conversation) Includes(uid gregor1.UID) bool {
 	return slices.ContainsFunc(c.Metadata.ActiveList, uid.Eq)
 }
 
+// GetEphemeralPolicy returns the exploding message policy in effect for the
+// conversation, or nil if there is none.
+func (c Conversation) GetEphemeralPolicy() *RpEphemeral {
+	return EffectiveEphemeralPolicy(c.ConvRetention, c.TeamRetention)
+}
+
 func (c Conversation) GetMaxDeletedUpTo() MessageID {
 	var maxExpungeID, maxDelHID MessageID
 	if expunge := c.GetExpunge(); expunge != nil {
@@ -1847,6 +1873,14 @@ func (r *GetNextAttachmentMessageLocalRes) SetOffline() {
 	r.Offline = true
 }
 
+func (r *GetReplyThreadLocalRes) SetOffline() {
+	r.Offline = true
+}
+
+func (r *GetChatBookmarksLocalRes) SetOffline() {
+	r.Offline = true
+}
+
 func (r *FindConversationsLocalRes) SetOffline() {
 	r.Offline = true
 }
@@ -2057,6 +2091,9 @@ func (p RetentionPolicy) HumanSummary() (summary string) {
 		duration := humanizeDuration(p.Ephemeral().Age.ToDuration())
 		if duration != "" {
 			summary = fmt.Sprintf("explode after %s by default", duration)
+			if p.Ephemeral().AllowNonExploding {
+				summary = fmt.Sprintf("explode after at most %s if exploding", duration)
+			}
 		}
 	}
 	if summary != "" {
@@ -2065,6 +2102,39 @@ func (p RetentionPolicy) HumanSummary() (summary string) {
 	return summary
 }
 
+// ephemeralPolicy returns the exploding message policy of p, if any.
+func (p *RetentionPolicy) ephemeralPolicy() *RpEphemeral {
+	if p == nil {
+		return nil
+	}
+	if typ, err := p.Typ(); err != nil || typ != RetentionPolicyType_EPHEMERAL {
+		return nil
+	}
+	res := p.Ephemeral()
+	return &res
+}
+
+// EffectiveEphemeralPolicy combines the exploding message policies of a
+// channel and its team. The team policy acts as a minimum which a channel can
+// only tighten: the shorter lifetime wins, and messages may only be
+// non-exploding if both policies allow it.
+func EffectiveEphemeralPolicy(convRetention, teamRetention *RetentionPolicy) *RpEphemeral {
+	conv := convRetention.ephemeralPolicy()
+	team := teamRetention.ephemeralPolicy()
+	switch {
+	case conv == nil:
+		return team
+	case team == nil:
+		return conv
+	}
+	res := *conv
+	if team.Age < res.Age {
+		res.Age = team.Age
+	}
+	res.AllowNonExploding = conv.AllowNonExploding && team.AllowNonExploding
+	return &res
+}
+
 func (p RetentionPolicy) Summary() string {
 	typ, err := p.Typ()
 	if err != nil {
@@ -2074,7 +2144,8 @@ func (p RetentionPolicy) Summary() string {
 	case RetentionPolicyType_EXPIRE:
 		return fmt.Sprintf("{%v age:%v}", typ, p.Expire().Age.ToDuration())
 	case RetentionPolicyType_EPHEMERAL:
-		return fmt.Sprintf("{%v age:%v}", typ, p.Ephemeral().Age.ToDuration())
+		return fmt.Sprintf("{%v age:%v allowNonExploding:%v}", typ, p.Ephemeral().Age.ToDuration(),
+			p.Ephemeral().AllowNonExploding)
 	default:
 		return fmt.Sprintf("{%v}", typ)
 	}
@@ -2188,6 +2259,22 @@ func (r *GetNextAttachmentMessageLocalRes) SetRateLimits(rl []RateLimit) {
 	r.RateLimits = rl
 }
 
+func (r *GetReplyThreadLocalRes) GetRateLimit() []RateLimit {
+	return r.RateLimits
+}
+
+func (r *GetReplyThreadLocalRes) SetRateLimits(rl []RateLimit) {
+	r.RateLimits = rl
+}
+
+func (r *GetChatBookmarksLocalRes) GetRateLimit() []RateLimit {
+	return r.RateLimits
+}
+
+func (r *GetChatBookmarksLocalRes) SetRateLimits(rl []RateLimit) {
+	r.RateLimits = rl
+}
+
 func (r *SetConversationStatusLocalRes) GetRateLimit() []RateLimit {
 	return r.RateLimits
 }
@@ -2798,6 +2885,66 @@ func (a MessageAttachment) GetTitle() string {
 	return title
 }
 
+func (m MessageInteractive) SearchableText() string {
+	res := []string{m.Body}
+	for _, choice := range m.Choices() {
+		res = append(res, choice.Label)
+	}
+	return strings.Join(res, " ")
+}
+
+// InteractiveChoice is a button or a select option of an interactive message.
+type InteractiveChoice struct {
+	Typ       InteractiveElementType
+	ElementID string
+	// Label of the select for select options
+	ElementLabel string
+	Label        string
+	Payload      string
+}
+
+// Choices flattens the buttons and select options of an interactive message,
+// in the order clients number them.
+func (m MessageInteractive) Choices() (res []InteractiveChoice) {
+	for _, elem := range m.Elements {
+		switch elem.Typ {
+		case InteractiveElementType_BUTTON:
+			res = append(res, InteractiveChoice{
+				Typ:       elem.Typ,
+				ElementID: elem.Id,
+				Label:     elem.Label,
+				Payload:   elem.Payload,
+			})
+		case InteractiveElementType_SELECT:
+			for _, opt := range elem.Options {
+				res = append(res, InteractiveChoice{
+					Typ:          elem.Typ,
+					ElementID:    elem.Id,
+					ElementLabel: elem.Label,
+					Label:        opt.Label,
+					Payload:      opt.Payload,
+				})
+			}
+		}
+	}
+	return res
+}
+
+// FindChoice looks up the choice a user made, payload can be left empty for
+// buttons.
+func (m MessageInteractive) FindChoice(elementID, payload string) (InteractiveChoice, bool) {
+	for _, choice := range m.Choices() {
+		if choice.ElementID == elementID && (payload == "" || choice.Payload == payload) {
+			if payload == "" && choice.Typ == InteractiveElementType_SELECT {
+				// a select option must be picked explicitly
+				return InteractiveChoice{}, false
+			}
+			return choice, true
+		}
+	}
+	return InteractiveChoice{}, false
+}
+
 func (u MessageUnfurl) SearchableText() string {
 	typ, err := u.Unfurl.Unfurl.UnfurlType()
 	if err != nil {
@@ -2811,6 +2958,16 @@ func (u MessageUnfurl) SearchableText() string {
 			res += " " + *generic.Description
 		}
 		return res
+	case UnfurlType_EMBED:
+		embed := u.Unfurl.Unfurl.Embed()
+		res := embed.Title
+		if embed.Description != nil {
+			res += " " + *embed.Description
+		}
+		if embed.Snippet != nil {
+			res += " " + *embed.Snippet
+		}
+		return res
 	}
 	return ""
 }
@@ -2834,6 +2991,8 @@ func (u UnfurlRaw) GetUrl() string {
 		if u.Giphy().ImageUrl != nil {
 			return *u.Giphy().ImageUrl
 		}
+	case UnfurlType_EMBED:
+		return u.Embed().Url
 	}
 	return ""
 }
@@ -2848,6 +3007,8 @@ func (u UnfurlRaw) UnsafeDebugString() string {
 		return u.Generic().UnsafeDebugString()
 	case UnfurlType_GIPHY:
 		return u.Giphy().UnsafeDebugString()
+	case UnfurlType_EMBED:
+		return u.Embed().UnsafeDebugString()
 	}
 	return "<unknown>"
 }
@@ -2882,6 +3043,19 @@ ImageUrl: %s
 Video: %s`, yieldStr(g.FaviconUrl), yieldStr(g.ImageUrl), g.Video)
 }
 
+func (g UnfurlEmbedRaw) UnsafeDebugString() string {
+	return fmt.Sprintf(`EMBED %v
+Title: %s
+Url: %s
+SiteName: %s
+AuthorName: %s
+Description: %s
+ThumbnailUrl: %s
+PlayerUrl: %s (%dx%d)
+FaviconUrl: %s`, g.EmbedType, g.Title, g.Url, g.SiteName, yieldStr(g.AuthorName), yieldStr(g.Description),
+		yieldStr(g.ThumbnailUrl), yieldStr(g.PlayerUrl), g.Width, g.Height, yieldStr(g.FaviconUrl))
+}
+
 func (v UnfurlVideo) String() string {
 	return fmt.Sprintf("[url: %s width: %d height: %d mime: %s]", v.Url, v.Width, v.Height, v.MimeType)
 }
@@ -2919,6 +3093,8 @@ func (g GlobalAppNotificationSetting) Usage() string {
 		return "Disable sending/receiving typing notifications"
 	case GlobalAppNotificationSetting_CONVERTHEIC:
 		return "Convert HEIC images to JPEG for chat attachments (macOS and iOS only)"
+	case GlobalAppNotificationSetting_STRIPMETADATA:
+		return "Strip location and other identifying metadata from chat attachments"
 	default:
 		return ""
 	}
@@ -2938,6 +3114,8 @@ func (g GlobalAppNotificationSetting) FlagName() string {
 		return "disable-typing"
 	case GlobalAppNotificationSetting_CONVERTHEIC:
 		return "convert-heic"
+	case GlobalAppNotificationSetting_STRIPMETADATA:
+		return "strip-metadata"
 	default:
 		return ""
 	}
@@ -3297,6 +3475,7 @@ func (c UserBotCommandInput) ToOutput(username string) UserBotCommandOutput {
 		Usage:               c.Usage,
 		ExtendedDescription: c.ExtendedDescription,
 		Username:            username,
+		Args:                c.Args,
 	}
 }
 
@@ -197,6 +197,52 @@ func (o LiveLocation) DeepCopy() LiveLocation {
 	}
 }
 
+type BotCommandArgValue struct {
+	Name   string `codec:"name" json:"name"`
+	Typ    string `codec:"typ" json:"type"`
+	Value  string `codec:"value" json:"value"`
+	Number *int64 `codec:"number,omitempty" json:"number,omitempty"`
+}
+
+func (o BotCommandArgValue) DeepCopy() BotCommandArgValue {
+	return BotCommandArgValue{
+		Name:  o.Name,
+		Typ:   o.Typ,
+		Value: o.Value,
+		Number: (func(x *int64) *int64 {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Number),
+	}
+}
+
+type BotCommandInvocation struct {
+	Name     string               `codec:"name" json:"name"`
+	Username string               `codec:"username" json:"username"`
+	Args     []BotCommandArgValue `codec:"args" json:"args"`
+}
+
+func (o BotCommandInvocation) DeepCopy() BotCommandInvocation {
+	return BotCommandInvocation{
+		Name:     o.Name,
+		Username: o.Username,
+		Args: (func(x []BotCommandArgValue) []BotCommandArgValue {
+			if x == nil {
+				return nil
+			}
+			ret := make([]BotCommandArgValue, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Args),
+	}
+}
+
 type MessageText struct {
 	Body         string                    `codec:"body" json:"body"`
 	Payments     []TextPayment             `codec:"payments" json:"payments"`
@@ -206,6 +252,7 @@ type MessageText struct {
 	TeamMentions []KnownTeamMention        `codec:"teamMentions" json:"teamMentions"`
 	LiveLocation *LiveLocation             `codec:"liveLocation,omitempty" json:"liveLocation,omitempty"`
 	Emojis       map[string]HarvestedEmoji `codec:"emojis" json:"emojis"`
+	BotCommand   *BotCommandInvocation     `codec:"botCommand,omitempty" json:"botCommand,omitempty"`
 }
 
 func (o MessageText) DeepCopy() MessageText {
@@ -277,6 +324,13 @@ func (o MessageText) DeepCopy() MessageText {
 			}
 			return ret
 		})(o.Emojis),
+		BotCommand: (func(x *BotCommandInvocation) *BotCommandInvocation {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.BotCommand),
 	}
 }
 
@@ -430,6 +484,166 @@ func (o MessagePin) DeepCopy() MessagePin {
 	}
 }
 
+type MessagePoll struct {
+	Question    string        `codec:"question" json:"question"`
+	Options     []string      `codec:"options" json:"options"`
+	MultiChoice bool          `codec:"multiChoice" json:"multiChoice"`
+	Anonymous   bool          `codec:"anonymous" json:"anonymous"`
+	CloseTime   *gregor1.Time `codec:"closeTime,omitempty" json:"closeTime,omitempty"`
+}
+
+func (o MessagePoll) DeepCopy() MessagePoll {
+	return MessagePoll{
+		Question: o.Question,
+		Options: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+		MultiChoice: o.MultiChoice,
+		Anonymous:   o.Anonymous,
+		CloseTime: (func(x *gregor1.Time) *gregor1.Time {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.CloseTime),
+	}
+}
+
+type MessagePollVote struct {
+	MessageID MessageID `codec:"messageID" json:"messageID"`
+	Choices   []int     `codec:"choices" json:"choices"`
+}
+
+func (o MessagePollVote) DeepCopy() MessagePollVote {
+	return MessagePollVote{
+		MessageID: o.MessageID.DeepCopy(),
+		Choices: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Choices),
+	}
+}
+
+type InteractiveElementType int
+
+const (
+	InteractiveElementType_BUTTON InteractiveElementType = 0
+	InteractiveElementType_SELECT InteractiveElementType = 1
+)
+
+func (o InteractiveElementType) DeepCopy() InteractiveElementType { return o }
+
+var InteractiveElementTypeMap = map[string]InteractiveElementType{
+	"BUTTON": 0,
+	"SELECT": 1,
+}
+
+var InteractiveElementTypeRevMap = map[InteractiveElementType]string{
+	0: "BUTTON",
+	1: "SELECT",
+}
+
+func (o InteractiveElementType) String() string {
+	if v, ok := InteractiveElementTypeRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type InteractiveOption struct {
+	Label   string `codec:"label" json:"label"`
+	Payload string `codec:"payload" json:"payload"`
+}
+
+func (o InteractiveOption) DeepCopy() InteractiveOption {
+	return InteractiveOption{
+		Label:   o.Label,
+		Payload: o.Payload,
+	}
+}
+
+type InteractiveElement struct {
+	Typ     InteractiveElementType `codec:"typ" json:"typ"`
+	Id      string                 `codec:"id" json:"id"`
+	Label   string                 `codec:"label" json:"label"`
+	Payload string                 `codec:"payload" json:"payload"`
+	Options []InteractiveOption    `codec:"options" json:"options"`
+}
+
+func (o InteractiveElement) DeepCopy() InteractiveElement {
+	return InteractiveElement{
+		Typ:     o.Typ.DeepCopy(),
+		Id:      o.Id,
+		Label:   o.Label,
+		Payload: o.Payload,
+		Options: (func(x []InteractiveOption) []InteractiveOption {
+			if x == nil {
+				return nil
+			}
+			ret := make([]InteractiveOption, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Options),
+	}
+}
+
+type MessageInteractive struct {
+	Body     string               `codec:"body" json:"body"`
+	Elements []InteractiveElement `codec:"elements" json:"elements"`
+}
+
+func (o MessageInteractive) DeepCopy() MessageInteractive {
+	return MessageInteractive{
+		Body: o.Body,
+		Elements: (func(x []InteractiveElement) []InteractiveElement {
+			if x == nil {
+				return nil
+			}
+			ret := make([]InteractiveElement, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Elements),
+	}
+}
+
+type MessageInteractiveAction struct {
+	MessageID MessageID   `codec:"messageID" json:"messageID"`
+	BotUID    gregor1.UID `codec:"botUID" json:"botUID"`
+	ElementID string      `codec:"elementID" json:"elementID"`
+	Payload   string      `codec:"payload" json:"payload"`
+}
+
+func (o MessageInteractiveAction) DeepCopy() MessageInteractiveAction {
+	return MessageInteractiveAction{
+		MessageID: o.MessageID.DeepCopy(),
+		BotUID:    o.BotUID.DeepCopy(),
+		ElementID: o.ElementID,
+		Payload:   o.Payload,
+	}
+}
+
 type MessageSystemType int
 
 const (
@@ -1230,6 +1444,10 @@ type MessageBody struct {
 	Unfurl__             *MessageUnfurl               `codec:"unfurl,omitempty" json:"unfurl,omitempty"`
 	Flip__               *MessageFlip                 `codec:"flip,omitempty" json:"flip,omitempty"`
 	Pin__                *MessagePin                  `codec:"pin,omitempty" json:"pin,omitempty"`
+	Poll__               *MessagePoll                 `codec:"poll,omitempty" json:"poll,omitempty"`
+	Pollvote__           *MessagePollVote             `codec:"pollvote,omitempty" json:"pollvote,omitempty"`
+	Interactive__        *MessageInteractive          `codec:"interactive,omitempty" json:"interactive,omitempty"`
+	Interactiveaction__  *MessageInteractiveAction    `codec:"interactiveaction,omitempty" json:"interactiveaction,omitempty"`
 }
 
 func (o *MessageBody) MessageType() (ret MessageType, err error) {
@@ -1319,6 +1537,26 @@ func (o *MessageBody) MessageType() (ret MessageType, err error) {
 			err = errors.New("unexpected nil value for Pin__")
 			return ret, err
 		}
+	case MessageType_POLL:
+		if o.Poll__ == nil {
+			err = errors.New("unexpected nil value for Poll__")
+			return ret, err
+		}
+	case MessageType_POLLVOTE:
+		if o.Pollvote__ == nil {
+			err = errors.New("unexpected nil value for Pollvote__")
+			return ret, err
+		}
+	case MessageType_INTERACTIVE:
+		if o.Interactive__ == nil {
+			err = errors.New("unexpected nil value for Interactive__")
+			return ret, err
+		}
+	case MessageType_INTERACTIVEACTION:
+		if o.Interactiveaction__ == nil {
+			err = errors.New("unexpected nil value for Interactiveaction__")
+			return ret, err
+		}
 	}
 	return o.MessageType__, nil
 }
@@ -1493,6 +1731,46 @@ func (o MessageBody) Pin() (res MessagePin) {
 	return *o.Pin__
 }
 
+func (o MessageBody) Poll() (res MessagePoll) {
+	if o.MessageType__ != MessageType_POLL {
+		panic("wrong case accessed")
+	}
+	if o.Poll__ == nil {
+		return
+	}
+	return *o.Poll__
+}
+
+func (o MessageBody) Pollvote() (res MessagePollVote) {
+	if o.MessageType__ != MessageType_POLLVOTE {
+		panic("wrong case accessed")
+	}
+	if o.Pollvote__ == nil {
+		return
+	}
+	return *o.Pollvote__
+}
+
+func (o MessageBody) Interactive() (res MessageInteractive) {
+	if o.MessageType__ != MessageType_INTERACTIVE {
+		panic("wrong case accessed")
+	}
+	if o.Interactive__ == nil {
+		return
+	}
+	return *o.Interactive__
+}
+
+func (o MessageBody) Interactiveaction() (res MessageInteractiveAction) {
+	if o.MessageType__ != MessageType_INTERACTIVEACTION {
+		panic("wrong case accessed")
+	}
+	if o.Interactiveaction__ == nil {
+		return
+	}
+	return *o.Interactiveaction__
+}
+
 func NewMessageBodyWithText(v MessageText) MessageBody {
 	return MessageBody{
 		MessageType__: MessageType_TEXT,
@@ -1612,6 +1890,34 @@ func NewMessageBodyWithPin(v MessagePin) MessageBody {
 	}
 }
 
+func NewMessageBodyWithPoll(v MessagePoll) MessageBody {
+	return MessageBody{
+		MessageType__: MessageType_POLL,
+		Poll__:        &v,
+	}
+}
+
+func NewMessageBodyWithPollvote(v MessagePollVote) MessageBody {
+	return MessageBody{
+		MessageType__: MessageType_POLLVOTE,
+		Pollvote__:    &v,
+	}
+}
+
+func NewMessageBodyWithInteractive(v MessageInteractive) MessageBody {
+	return MessageBody{
+		MessageType__: MessageType_INTERACTIVE,
+		Interactive__: &v,
+	}
+}
+
+func NewMessageBodyWithInteractiveaction(v MessageInteractiveAction) MessageBody {
+	return MessageBody{
+		MessageType__:       MessageType_INTERACTIVEACTION,
+		Interactiveaction__: &v,
+	}
+}
+
 func (o MessageBody) DeepCopy() MessageBody {
 	return MessageBody{
 		MessageType__: o.MessageType__.DeepCopy(),
@@ -1734,6 +2040,34 @@ func (o MessageBody) DeepCopy() MessageBody {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Pin__),
+		Poll__: (func(x *MessagePoll) *MessagePoll {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Poll__),
+		Pollvote__: (func(x *MessagePollVote) *MessagePollVote {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Pollvote__),
+		Interactive__: (func(x *MessageInteractive) *MessageInteractive {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Interactive__),
+		Interactiveaction__: (func(x *MessageInteractiveAction) *MessageInteractiveAction {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Interactiveaction__),
 	}
 }
 
@@ -2926,6 +3260,7 @@ type MessageUnboxedValid struct {
 	MaybeMentions         []MaybeMention              `codec:"maybeMentions" json:"maybeMentions"`
 	ChannelNameMentions   []ChannelNameMention        `codec:"channelNameMentions" json:"channelNameMentions"`
 	Reactions             ReactionMap                 `codec:"reactions" json:"reactions"`
+	PollVotes             PollVoteMap                 `codec:"pollVotes" json:"pollVotes"`
 	Unfurls               map[MessageID]UnfurlResult  `codec:"unfurls" json:"unfurls"`
 	Emojis                []HarvestedEmoji            `codec:"emojis" json:"emojis"`
 	ReplyTo               *MessageUnboxed             `codec:"replyTo,omitempty" json:"replyTo,omitempty"`
@@ -3014,6 +3349,7 @@ func (o MessageUnboxedValid) DeepCopy() MessageUnboxedValid {
 			return ret
 		})(o.ChannelNameMentions),
 		Reactions: o.Reactions.DeepCopy(),
+		PollVotes: o.PollVotes.DeepCopy(),
 		Unfurls: (func(x map[MessageID]UnfurlResult) map[MessageID]UnfurlResult {
 			if x == nil {
 				return nil
@@ -4801,6 +5137,7 @@ type PostFileAttachmentArg struct {
 	CallerPreview     *MakePreviewRes              `codec:"callerPreview,omitempty" json:"callerPreview,omitempty"`
 	OutboxID          *OutboxID                    `codec:"outboxID,omitempty" json:"outboxID,omitempty"`
 	EphemeralLifetime *gregor1.DurationSec         `codec:"ephemeralLifetime,omitempty" json:"ephemeralLifetime,omitempty"`
+	KeepMetadata      bool                         `codec:"keepMetadata" json:"keepMetadata"`
 }
 
 func (o PostFileAttachmentArg) DeepCopy() PostFileAttachmentArg {
@@ -4838,6 +5175,7 @@ func (o PostFileAttachmentArg) DeepCopy() PostFileAttachmentArg {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.EphemeralLifetime),
+		KeepMetadata: o.KeepMetadata,
 	}
 }
 
@@ -5973,6 +6311,7 @@ type UserBotCommandOutput struct {
 	Usage               string                      `codec:"usage" json:"usage"`
 	ExtendedDescription *UserBotExtendedDescription `codec:"extendedDescription,omitempty" json:"extended_description,omitempty"`
 	Username            string                      `codec:"username" json:"username"`
+	Args                []UserBotCommandArg         `codec:"args,omitempty" json:"args,omitempty"`
 }
 
 func (o UserBotCommandOutput) DeepCopy() UserBotCommandOutput {
@@ -5988,6 +6327,17 @@ func (o UserBotCommandOutput) DeepCopy() UserBotCommandOutput {
 			return &tmp
 		})(o.ExtendedDescription),
 		Username: o.Username,
+		Args: (func(x []UserBotCommandArg) []UserBotCommandArg {
+			if x == nil {
+				return nil
+			}
+			ret := make([]UserBotCommandArg, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Args),
 	}
 }
 
@@ -5996,6 +6346,7 @@ type UserBotCommandInput struct {
 	Description         string                      `codec:"description" json:"description"`
 	Usage               string                      `codec:"usage" json:"usage"`
 	ExtendedDescription *UserBotExtendedDescription `codec:"extendedDescription,omitempty" json:"extended_description,omitempty"`
+	Args                []UserBotCommandArg         `codec:"args,omitempty" json:"args,omitempty"`
 }
 
 func (o UserBotCommandInput) DeepCopy() UserBotCommandInput {
@@ -6010,6 +6361,17 @@ func (o UserBotCommandInput) DeepCopy() UserBotCommandInput {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.ExtendedDescription),
+		Args: (func(x []UserBotCommandArg) []UserBotCommandArg {
+			if x == nil {
+				return nil
+			}
+			ret := make([]UserBotCommandArg, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Args),
 	}
 }
 
@@ -6736,45 +7098,365 @@ func (o ArchiveChatHistory) DeepCopy() ArchiveChatHistory {
 	}
 }
 
-type GetThreadLocalArg struct {
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	Reason           GetThreadReason              `codec:"reason" json:"reason"`
-	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
-	Pagination       *Pagination                  `codec:"pagination,omitempty" json:"pagination,omitempty"`
+type ScheduledMessage struct {
+	OutboxID         OutboxID                     `codec:"outboxID" json:"outboxID"`
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	SendTime         gregor1.Time                 `codec:"sendTime" json:"sendTime"`
+	Ctime            gregor1.Time                 `codec:"ctime" json:"ctime"`
+	Msg              MessagePlaintext             `codec:"msg" json:"msg"`
 	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
-type GetThreadNonblockArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
-	CbMode           GetThreadNonblockCbMode      `codec:"cbMode" json:"cbMode"`
-	Reason           GetThreadReason              `codec:"reason" json:"reason"`
-	Pgmode           GetThreadNonblockPgMode      `codec:"pgmode" json:"pgmode"`
-	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
-	KnownRemotes     []string                     `codec:"knownRemotes" json:"knownRemotes"`
-	Pagination       *UIPagination                `codec:"pagination,omitempty" json:"pagination,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ScheduledMessage) DeepCopy() ScheduledMessage {
+	return ScheduledMessage{
+		OutboxID:         o.OutboxID.DeepCopy(),
+		ConvID:           o.ConvID.DeepCopy(),
+		SendTime:         o.SendTime.DeepCopy(),
+		Ctime:            o.Ctime.DeepCopy(),
+		Msg:              o.Msg.DeepCopy(),
+		IdentifyBehavior: o.IdentifyBehavior.DeepCopy(),
+	}
 }
 
-type GetUnreadlineArg struct {
-	SessionID        int                          `codec:"sessionID" json:"sessionID"`
-	ConvID           ConversationID               `codec:"convID" json:"convID"`
-	ReadMsgID        MessageID                    `codec:"readMsgID" json:"readMsgID"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ChatWebhook struct {
+	Id             string           `codec:"id" json:"id"`
+	Url            string           `codec:"url" json:"url"`
+	Secret         string           `codec:"secret" json:"secret"`
+	FilterChannels []ChatChannel    `codec:"filterChannels" json:"filterChannels"`
+	FilterConvIDs  []ConversationID `codec:"filterConvIDs" json:"filterConvIDs"`
+	Convs          bool             `codec:"convs" json:"convs"`
+	Wallet         bool             `codec:"wallet" json:"wallet"`
+	Ctime          gregor1.Time     `codec:"ctime" json:"ctime"`
 }
 
-type GetInboxAndUnboxLocalArg struct {
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+func (o ChatWebhook) DeepCopy() ChatWebhook {
+	return ChatWebhook{
+		Id:     o.Id,
+		Url:    o.Url,
+		Secret: o.Secret,
+		FilterChannels: (func(x []ChatChannel) []ChatChannel {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChatChannel, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.FilterChannels),
+		FilterConvIDs: (func(x []ConversationID) []ConversationID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ConversationID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.FilterConvIDs),
+		Convs:  o.Convs,
+		Wallet: o.Wallet,
+		Ctime:  o.Ctime.DeepCopy(),
+	}
 }
 
-type GetInboxAndUnboxUILocalArg struct {
-	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
-	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+type ChatIncomingWebhook struct {
+	Id        string         `codec:"id" json:"id"`
+	Secret    string         `codec:"secret" json:"secret"`
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	ConvName  string         `codec:"convName" json:"convName"`
+	Template  string         `codec:"template" json:"template"`
+	RateLimit int            `codec:"rateLimit" json:"rateLimit"`
+	Ctime     gregor1.Time   `codec:"ctime" json:"ctime"`
 }
 
-type RequestInboxLayoutArg struct {
-	ReselectMode InboxLayoutReselectMode `codec:"reselectMode" json:"reselectMode"`
+func (o ChatIncomingWebhook) DeepCopy() ChatIncomingWebhook {
+	return ChatIncomingWebhook{
+		Id:        o.Id,
+		Secret:    o.Secret,
+		ConvID:    o.ConvID.DeepCopy(),
+		ConvName:  o.ConvName,
+		Template:  o.Template,
+		RateLimit: o.RateLimit,
+		Ctime:     o.Ctime.DeepCopy(),
+	}
+}
+
+type ChatHighlights struct {
+	Keywords []string `codec:"keywords" json:"keywords"`
+	Regexes  []string `codec:"regexes" json:"regexes"`
+}
+
+func (o ChatHighlights) DeepCopy() ChatHighlights {
+	return ChatHighlights{
+		Keywords: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Keywords),
+		Regexes: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Regexes),
+	}
+}
+
+type ChatDNDWindow struct {
+	Weekdays    []int `codec:"weekdays" json:"weekdays"`
+	StartMinute int   `codec:"startMinute" json:"startMinute"`
+	EndMinute   int   `codec:"endMinute" json:"endMinute"`
+}
+
+func (o ChatDNDWindow) DeepCopy() ChatDNDWindow {
+	return ChatDNDWindow{
+		Weekdays: (func(x []int) []int {
+			if x == nil {
+				return nil
+			}
+			ret := make([]int, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Weekdays),
+		StartMinute: o.StartMinute,
+		EndMinute:   o.EndMinute,
+	}
+}
+
+type ChatDNDSchedule struct {
+	Enabled      bool             `codec:"enabled" json:"enabled"`
+	Timezone     string           `codec:"timezone" json:"timezone"`
+	Windows      []ChatDNDWindow  `codec:"windows" json:"windows"`
+	AllowDMs     bool             `codec:"allowDMs" json:"allowDMs"`
+	AllowConvIDs []ConversationID `codec:"allowConvIDs" json:"allowConvIDs"`
+}
+
+func (o ChatDNDSchedule) DeepCopy() ChatDNDSchedule {
+	return ChatDNDSchedule{
+		Enabled:  o.Enabled,
+		Timezone: o.Timezone,
+		Windows: (func(x []ChatDNDWindow) []ChatDNDWindow {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ChatDNDWindow, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Windows),
+		AllowDMs: o.AllowDMs,
+		AllowConvIDs: (func(x []ConversationID) []ConversationID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]ConversationID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.AllowConvIDs),
+	}
+}
+
+type GetChatDNDScheduleLocalRes struct {
+	Schedule ChatDNDSchedule `codec:"schedule" json:"schedule"`
+	Active   bool            `codec:"active" json:"active"`
+}
+
+func (o GetChatDNDScheduleLocalRes) DeepCopy() GetChatDNDScheduleLocalRes {
+	return GetChatDNDScheduleLocalRes{
+		Schedule: o.Schedule.DeepCopy(),
+		Active:   o.Active,
+	}
+}
+
+type GetReplyThreadLocalRes struct {
+	RootMsgID        MessageID                     `codec:"rootMsgID" json:"rootMsgID"`
+	Messages         []MessageUnboxed              `codec:"messages" json:"messages"`
+	Offline          bool                          `codec:"offline" json:"offline"`
+	RateLimits       []RateLimit                   `codec:"rateLimits" json:"rateLimits"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
+}
+
+func (o GetReplyThreadLocalRes) DeepCopy() GetReplyThreadLocalRes {
+	return GetReplyThreadLocalRes{
+		RootMsgID: o.RootMsgID.DeepCopy(),
+		Messages: (func(x []MessageUnboxed) []MessageUnboxed {
+			if x == nil {
+				return nil
+			}
+			ret := make([]MessageUnboxed, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Messages),
+		Offline: o.Offline,
+		RateLimits: (func(x []RateLimit) []RateLimit {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimit, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
+}
+
+type ChatBookmark struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+	Note   string         `codec:"note" json:"note"`
+	Ctime  gregor1.Time   `codec:"ctime" json:"ctime"`
+}
+
+func (o ChatBookmark) DeepCopy() ChatBookmark {
+	return ChatBookmark{
+		ConvID: o.ConvID.DeepCopy(),
+		MsgID:  o.MsgID.DeepCopy(),
+		Note:   o.Note,
+		Ctime:  o.Ctime.DeepCopy(),
+	}
+}
+
+type BookmarkedMessage struct {
+	Bookmark ChatBookmark    `codec:"bookmark" json:"bookmark"`
+	Message  *MessageUnboxed `codec:"message,omitempty" json:"message,omitempty"`
+}
+
+func (o BookmarkedMessage) DeepCopy() BookmarkedMessage {
+	return BookmarkedMessage{
+		Bookmark: o.Bookmark.DeepCopy(),
+		Message: (func(x *MessageUnboxed) *MessageUnboxed {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Message),
+	}
+}
+
+type GetChatBookmarksLocalRes struct {
+	Bookmarks        []BookmarkedMessage           `codec:"bookmarks" json:"bookmarks"`
+	Offline          bool                          `codec:"offline" json:"offline"`
+	RateLimits       []RateLimit                   `codec:"rateLimits" json:"rateLimits"`
+	IdentifyFailures []keybase1.TLFIdentifyFailure `codec:"identifyFailures" json:"identifyFailures"`
+}
+
+func (o GetChatBookmarksLocalRes) DeepCopy() GetChatBookmarksLocalRes {
+	return GetChatBookmarksLocalRes{
+		Bookmarks: (func(x []BookmarkedMessage) []BookmarkedMessage {
+			if x == nil {
+				return nil
+			}
+			ret := make([]BookmarkedMessage, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Bookmarks),
+		Offline: o.Offline,
+		RateLimits: (func(x []RateLimit) []RateLimit {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RateLimit, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.RateLimits),
+		IdentifyFailures: (func(x []keybase1.TLFIdentifyFailure) []keybase1.TLFIdentifyFailure {
+			if x == nil {
+				return nil
+			}
+			ret := make([]keybase1.TLFIdentifyFailure, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.IdentifyFailures),
+	}
+}
+
+type GetThreadLocalArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	Reason           GetThreadReason              `codec:"reason" json:"reason"`
+	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
+	Pagination       *Pagination                  `codec:"pagination,omitempty" json:"pagination,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetThreadNonblockArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	CbMode           GetThreadNonblockCbMode      `codec:"cbMode" json:"cbMode"`
+	Reason           GetThreadReason              `codec:"reason" json:"reason"`
+	Pgmode           GetThreadNonblockPgMode      `codec:"pgmode" json:"pgmode"`
+	Query            *GetThreadQuery              `codec:"query,omitempty" json:"query,omitempty"`
+	KnownRemotes     []string                     `codec:"knownRemotes" json:"knownRemotes"`
+	Pagination       *UIPagination                `codec:"pagination,omitempty" json:"pagination,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetUnreadlineArg struct {
+	SessionID        int                          `codec:"sessionID" json:"sessionID"`
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	ReadMsgID        MessageID                    `codec:"readMsgID" json:"readMsgID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetInboxAndUnboxLocalArg struct {
+	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetInboxAndUnboxUILocalArg struct {
+	Query            *GetInboxLocalQuery          `codec:"query,omitempty" json:"query,omitempty"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type RequestInboxLayoutArg struct {
+	ReselectMode InboxLayoutReselectMode `codec:"reselectMode" json:"reselectMode"`
 }
 
 type RequestInboxUnboxArg struct {
@@ -7234,6 +7916,15 @@ type SaveUnfurlSettingsArg struct {
 	Whitelist []string   `codec:"whitelist" json:"whitelist"`
 }
 
+type SetTeamUnfurlPolicyArg struct {
+	TeamID keybase1.TeamID  `codec:"teamID" json:"teamID"`
+	Policy TeamUnfurlPolicy `codec:"policy" json:"policy"`
+}
+
+type GetTeamUnfurlPolicyArg struct {
+	TeamID keybase1.TeamID `codec:"teamID" json:"teamID"`
+}
+
 type ToggleMessageCollapseArg struct {
 	ConvID   ConversationID `codec:"convID" json:"convID"`
 	MsgID    MessageID      `codec:"msgID" json:"msgID"`
@@ -7471,6 +8162,91 @@ type ArchiveChatResumeArg struct {
 	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
 }
 
+type ScheduleMessageLocalArg struct {
+	ConversationID   ConversationID               `codec:"conversationID" json:"conversationID"`
+	Msg              MessagePlaintext             `codec:"msg" json:"msg"`
+	SendTime         gregor1.Time                 `codec:"sendTime" json:"sendTime"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetScheduledMessagesLocalArg struct {
+	ConvID *ConversationID `codec:"convID,omitempty" json:"convID,omitempty"`
+}
+
+type CancelScheduledMessageLocalArg struct {
+	OutboxID OutboxID `codec:"outboxID" json:"outboxID"`
+}
+
+type AddChatWebhookLocalArg struct {
+	Url            string           `codec:"url" json:"url"`
+	FilterChannels []ChatChannel    `codec:"filterChannels" json:"filterChannels"`
+	FilterConvIDs  []ConversationID `codec:"filterConvIDs" json:"filterConvIDs"`
+	Convs          bool             `codec:"convs" json:"convs"`
+	Wallet         bool             `codec:"wallet" json:"wallet"`
+}
+
+type ListChatWebhooksLocalArg struct {
+}
+
+type RemoveChatWebhookLocalArg struct {
+	Id string `codec:"id" json:"id"`
+}
+
+type AddChatIncomingWebhookLocalArg struct {
+	ConvID    ConversationID `codec:"convID" json:"convID"`
+	Template  string         `codec:"template" json:"template"`
+	RateLimit int            `codec:"rateLimit" json:"rateLimit"`
+}
+
+type ListChatIncomingWebhooksLocalArg struct {
+}
+
+type RemoveChatIncomingWebhookLocalArg struct {
+	Id string `codec:"id" json:"id"`
+}
+
+type StartChatWebhookServerLocalArg struct {
+	Port int `codec:"port" json:"port"`
+}
+
+type StopChatWebhookServerLocalArg struct {
+}
+
+type GetChatHighlightsLocalArg struct {
+}
+
+type SetChatHighlightsLocalArg struct {
+	Highlights ChatHighlights `codec:"highlights" json:"highlights"`
+}
+
+type GetChatDNDScheduleLocalArg struct {
+}
+
+type SetChatDNDScheduleLocalArg struct {
+	Schedule ChatDNDSchedule `codec:"schedule" json:"schedule"`
+}
+
+type GetReplyThreadLocalArg struct {
+	ConvID           ConversationID               `codec:"convID" json:"convID"`
+	MsgID            MessageID                    `codec:"msgID" json:"msgID"`
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type GetChatBookmarksLocalArg struct {
+	IdentifyBehavior keybase1.TLFIdentifyBehavior `codec:"identifyBehavior" json:"identifyBehavior"`
+}
+
+type AddChatBookmarkLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+	Note   string         `codec:"note" json:"note"`
+}
+
+type RemoveChatBookmarkLocalArg struct {
+	ConvID ConversationID `codec:"convID" json:"convID"`
+	MsgID  MessageID      `codec:"msgID" json:"msgID"`
+}
+
 type LocalInterface interface {
 	GetThreadLocal(context.Context, GetThreadLocalArg) (GetThreadLocalRes, error)
 	GetThreadNonblock(context.Context, GetThreadNonblockArg) (NonblockFetchRes, error)
@@ -7553,6 +8329,8 @@ type LocalInterface interface {
 	ResolveUnfurlPrompt(context.Context, ResolveUnfurlPromptArg) error
 	GetUnfurlSettings(context.Context) (UnfurlSettingsDisplay, error)
 	SaveUnfurlSettings(context.Context, SaveUnfurlSettingsArg) error
+	SetTeamUnfurlPolicy(context.Context, SetTeamUnfurlPolicyArg) error
+	GetTeamUnfurlPolicy(context.Context, keybase1.TeamID) (TeamUnfurlPolicy, error)
 	ToggleMessageCollapse(context.Context, ToggleMessageCollapseArg) error
 	BulkAddToConv(context.Context, BulkAddToConvArg) error
 	BulkAddToManyConvs(context.Context, BulkAddToManyConvsArg) error
@@ -7601,6 +8379,25 @@ type LocalInterface interface {
 	ArchiveChatDelete(context.Context, ArchiveChatDeleteArg) error
 	ArchiveChatPause(context.Context, ArchiveChatPauseArg) error
 	ArchiveChatResume(context.Context, ArchiveChatResumeArg) error
+	ScheduleMessageLocal(context.Context, ScheduleMessageLocalArg) (ScheduledMessage, error)
+	GetScheduledMessagesLocal(context.Context, *ConversationID) ([]ScheduledMessage, error)
+	CancelScheduledMessageLocal(context.Context, OutboxID) error
+	AddChatWebhookLocal(context.Context, AddChatWebhookLocalArg) (ChatWebhook, error)
+	ListChatWebhooksLocal(context.Context) ([]ChatWebhook, error)
+	RemoveChatWebhookLocal(context.Context, string) error
+	AddChatIncomingWebhookLocal(context.Context, AddChatIncomingWebhookLocalArg) (ChatIncomingWebhook, error)
+	ListChatIncomingWebhooksLocal(context.Context) ([]ChatIncomingWebhook, error)
+	RemoveChatIncomingWebhookLocal(context.Context, string) error
+	StartChatWebhookServerLocal(context.Context, int) (string, error)
+	StopChatWebhookServerLocal(context.Context) error
+	GetChatHighlightsLocal(context.Context) (ChatHighlights, error)
+	SetChatHighlightsLocal(context.Context, ChatHighlights) error
+	GetChatDNDScheduleLocal(context.Context) (GetChatDNDScheduleLocalRes, error)
+	SetChatDNDScheduleLocal(context.Context, ChatDNDSchedule) error
+	GetReplyThreadLocal(context.Context, GetReplyThreadLocalArg) (GetReplyThreadLocalRes, error)
+	GetChatBookmarksLocal(context.Context, keybase1.TLFIdentifyBehavior) (GetChatBookmarksLocalRes, error)
+	AddChatBookmarkLocal(context.Context, AddChatBookmarkLocalArg) error
+	RemoveChatBookmarkLocal(context.Context, RemoveChatBookmarkLocalArg) error
 }
 
 func LocalProtocol(i LocalInterface) rpc.Protocol {
@@ -8743,37 +9540,67 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return &ret
 				},
 				Handler: func(ctx context.Context, args any) (ret any, err error) {
-					typedArgs, ok := args.(*[1]ResolveUnfurlPromptArg)
+					typedArgs, ok := args.(*[1]ResolveUnfurlPromptArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ResolveUnfurlPromptArg)(nil), args)
+						return
+					}
+					err = i.ResolveUnfurlPrompt(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getUnfurlSettings": {
+				MakeArg: func() any {
+					var ret [1]GetUnfurlSettingsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.GetUnfurlSettings(ctx)
+					return
+				},
+			},
+			"saveUnfurlSettings": {
+				MakeArg: func() any {
+					var ret [1]SaveUnfurlSettingsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]SaveUnfurlSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]ResolveUnfurlPromptArg)(nil), args)
+						err = rpc.NewTypeError((*[1]SaveUnfurlSettingsArg)(nil), args)
 						return
 					}
-					err = i.ResolveUnfurlPrompt(ctx, typedArgs[0])
+					err = i.SaveUnfurlSettings(ctx, typedArgs[0])
 					return
 				},
 			},
-			"getUnfurlSettings": {
+			"setTeamUnfurlPolicy": {
 				MakeArg: func() any {
-					var ret [1]GetUnfurlSettingsArg
+					var ret [1]SetTeamUnfurlPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args any) (ret any, err error) {
-					ret, err = i.GetUnfurlSettings(ctx)
+					typedArgs, ok := args.(*[1]SetTeamUnfurlPolicyArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetTeamUnfurlPolicyArg)(nil), args)
+						return
+					}
+					err = i.SetTeamUnfurlPolicy(ctx, typedArgs[0])
 					return
 				},
 			},
-			"saveUnfurlSettings": {
+			"getTeamUnfurlPolicy": {
 				MakeArg: func() any {
-					var ret [1]SaveUnfurlSettingsArg
+					var ret [1]GetTeamUnfurlPolicyArg
 					return &ret
 				},
 				Handler: func(ctx context.Context, args any) (ret any, err error) {
-					typedArgs, ok := args.(*[1]SaveUnfurlSettingsArg)
+					typedArgs, ok := args.(*[1]GetTeamUnfurlPolicyArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]SaveUnfurlSettingsArg)(nil), args)
+						err = rpc.NewTypeError((*[1]GetTeamUnfurlPolicyArg)(nil), args)
 						return
 					}
-					err = i.SaveUnfurlSettings(ctx, typedArgs[0])
+					ret, err = i.GetTeamUnfurlPolicy(ctx, typedArgs[0].TeamID)
 					return
 				},
 			},
@@ -9492,6 +10319,266 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"scheduleMessageLocal": {
+				MakeArg: func() any {
+					var ret [1]ScheduleMessageLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]ScheduleMessageLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ScheduleMessageLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.ScheduleMessageLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getScheduledMessagesLocal": {
+				MakeArg: func() any {
+					var ret [1]GetScheduledMessagesLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]GetScheduledMessagesLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetScheduledMessagesLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetScheduledMessagesLocal(ctx, typedArgs[0].ConvID)
+					return
+				},
+			},
+			"cancelScheduledMessageLocal": {
+				MakeArg: func() any {
+					var ret [1]CancelScheduledMessageLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CancelScheduledMessageLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CancelScheduledMessageLocalArg)(nil), args)
+						return
+					}
+					err = i.CancelScheduledMessageLocal(ctx, typedArgs[0].OutboxID)
+					return
+				},
+			},
+			"addChatWebhookLocal": {
+				MakeArg: func() any {
+					var ret [1]AddChatWebhookLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]AddChatWebhookLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]AddChatWebhookLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.AddChatWebhookLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"listChatWebhooksLocal": {
+				MakeArg: func() any {
+					var ret [1]ListChatWebhooksLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.ListChatWebhooksLocal(ctx)
+					return
+				},
+			},
+			"removeChatWebhookLocal": {
+				MakeArg: func() any {
+					var ret [1]RemoveChatWebhookLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]RemoveChatWebhookLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RemoveChatWebhookLocalArg)(nil), args)
+						return
+					}
+					err = i.RemoveChatWebhookLocal(ctx, typedArgs[0].Id)
+					return
+				},
+			},
+			"addChatIncomingWebhookLocal": {
+				MakeArg: func() any {
+					var ret [1]AddChatIncomingWebhookLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]AddChatIncomingWebhookLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]AddChatIncomingWebhookLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.AddChatIncomingWebhookLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"listChatIncomingWebhooksLocal": {
+				MakeArg: func() any {
+					var ret [1]ListChatIncomingWebhooksLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.ListChatIncomingWebhooksLocal(ctx)
+					return
+				},
+			},
+			"removeChatIncomingWebhookLocal": {
+				MakeArg: func() any {
+					var ret [1]RemoveChatIncomingWebhookLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]RemoveChatIncomingWebhookLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RemoveChatIncomingWebhookLocalArg)(nil), args)
+						return
+					}
+					err = i.RemoveChatIncomingWebhookLocal(ctx, typedArgs[0].Id)
+					return
+				},
+			},
+			"startChatWebhookServerLocal": {
+				MakeArg: func() any {
+					var ret [1]StartChatWebhookServerLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]StartChatWebhookServerLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]StartChatWebhookServerLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.StartChatWebhookServerLocal(ctx, typedArgs[0].Port)
+					return
+				},
+			},
+			"stopChatWebhookServerLocal": {
+				MakeArg: func() any {
+					var ret [1]StopChatWebhookServerLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					err = i.StopChatWebhookServerLocal(ctx)
+					return
+				},
+			},
+			"getChatHighlightsLocal": {
+				MakeArg: func() any {
+					var ret [1]GetChatHighlightsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.GetChatHighlightsLocal(ctx)
+					return
+				},
+			},
+			"setChatHighlightsLocal": {
+				MakeArg: func() any {
+					var ret [1]SetChatHighlightsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]SetChatHighlightsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetChatHighlightsLocalArg)(nil), args)
+						return
+					}
+					err = i.SetChatHighlightsLocal(ctx, typedArgs[0].Highlights)
+					return
+				},
+			},
+			"getChatDNDScheduleLocal": {
+				MakeArg: func() any {
+					var ret [1]GetChatDNDScheduleLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.GetChatDNDScheduleLocal(ctx)
+					return
+				},
+			},
+			"setChatDNDScheduleLocal": {
+				MakeArg: func() any {
+					var ret [1]SetChatDNDScheduleLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]SetChatDNDScheduleLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetChatDNDScheduleLocalArg)(nil), args)
+						return
+					}
+					err = i.SetChatDNDScheduleLocal(ctx, typedArgs[0].Schedule)
+					return
+				},
+			},
+			"getReplyThreadLocal": {
+				MakeArg: func() any {
+					var ret [1]GetReplyThreadLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]GetReplyThreadLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetReplyThreadLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetReplyThreadLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getChatBookmarksLocal": {
+				MakeArg: func() any {
+					var ret [1]GetChatBookmarksLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]GetChatBookmarksLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetChatBookmarksLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetChatBookmarksLocal(ctx, typedArgs[0].IdentifyBehavior)
+					return
+				},
+			},
+			"addChatBookmarkLocal": {
+				MakeArg: func() any {
+					var ret [1]AddChatBookmarkLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]AddChatBookmarkLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]AddChatBookmarkLocalArg)(nil), args)
+						return
+					}
+					err = i.AddChatBookmarkLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"removeChatBookmarkLocal": {
+				MakeArg: func() any {
+					var ret [1]RemoveChatBookmarkLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]RemoveChatBookmarkLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RemoveChatBookmarkLocalArg)(nil), args)
+						return
+					}
+					err = i.RemoveChatBookmarkLocal(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -9921,6 +11008,17 @@ func (c LocalClient) SaveUnfurlSettings(ctx context.Context, __arg SaveUnfurlSet
 	return
 }
 
+func (c LocalClient) SetTeamUnfurlPolicy(ctx context.Context, __arg SetTeamUnfurlPolicyArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.setTeamUnfurlPolicy", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetTeamUnfurlPolicy(ctx context.Context, teamID keybase1.TeamID) (res TeamUnfurlPolicy, err error) {
+	__arg := GetTeamUnfurlPolicyArg{TeamID: teamID}
+	err = c.Cli.Call(ctx, "chat.1.local.getTeamUnfurlPolicy", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) ToggleMessageCollapse(ctx context.Context, __arg ToggleMessageCollapseArg) (err error) {
 	err = c.Cli.Call(ctx, "chat.1.local.toggleMessageCollapse", []any{__arg}, nil, 0*time.Millisecond)
 	return
@@ -10179,3 +11277,106 @@ func (c LocalClient) ArchiveChatResume(ctx context.Context, __arg ArchiveChatRes
 	err = c.Cli.Call(ctx, "chat.1.local.archiveChatResume", []any{__arg}, nil, 0*time.Millisecond)
 	return
 }
+
+func (c LocalClient) ScheduleMessageLocal(ctx context.Context, __arg ScheduleMessageLocalArg) (res ScheduledMessage, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.scheduleMessageLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetScheduledMessagesLocal(ctx context.Context, convID *ConversationID) (res []ScheduledMessage, err error) {
+	__arg := GetScheduledMessagesLocalArg{ConvID: convID}
+	err = c.Cli.Call(ctx, "chat.1.local.getScheduledMessagesLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) CancelScheduledMessageLocal(ctx context.Context, outboxID OutboxID) (err error) {
+	__arg := CancelScheduledMessageLocalArg{OutboxID: outboxID}
+	err = c.Cli.Call(ctx, "chat.1.local.cancelScheduledMessageLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AddChatWebhookLocal(ctx context.Context, __arg AddChatWebhookLocalArg) (res ChatWebhook, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.addChatWebhookLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ListChatWebhooksLocal(ctx context.Context) (res []ChatWebhook, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.listChatWebhooksLocal", []any{ListChatWebhooksLocalArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) RemoveChatWebhookLocal(ctx context.Context, id string) (err error) {
+	__arg := RemoveChatWebhookLocalArg{Id: id}
+	err = c.Cli.Call(ctx, "chat.1.local.removeChatWebhookLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AddChatIncomingWebhookLocal(ctx context.Context, __arg AddChatIncomingWebhookLocalArg) (res ChatIncomingWebhook, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.addChatIncomingWebhookLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ListChatIncomingWebhooksLocal(ctx context.Context) (res []ChatIncomingWebhook, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.listChatIncomingWebhooksLocal", []any{ListChatIncomingWebhooksLocalArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) RemoveChatIncomingWebhookLocal(ctx context.Context, id string) (err error) {
+	__arg := RemoveChatIncomingWebhookLocalArg{Id: id}
+	err = c.Cli.Call(ctx, "chat.1.local.removeChatIncomingWebhookLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) StartChatWebhookServerLocal(ctx context.Context, port int) (res string, err error) {
+	__arg := StartChatWebhookServerLocalArg{Port: port}
+	err = c.Cli.Call(ctx, "chat.1.local.startChatWebhookServerLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) StopChatWebhookServerLocal(ctx context.Context) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.stopChatWebhookServerLocal", []any{StopChatWebhookServerLocalArg{}}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetChatHighlightsLocal(ctx context.Context) (res ChatHighlights, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getChatHighlightsLocal", []any{GetChatHighlightsLocalArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetChatHighlightsLocal(ctx context.Context, highlights ChatHighlights) (err error) {
+	__arg := SetChatHighlightsLocalArg{Highlights: highlights}
+	err = c.Cli.Call(ctx, "chat.1.local.setChatHighlightsLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetChatDNDScheduleLocal(ctx context.Context) (res GetChatDNDScheduleLocalRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getChatDNDScheduleLocal", []any{GetChatDNDScheduleLocalArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SetChatDNDScheduleLocal(ctx context.Context, schedule ChatDNDSchedule) (err error) {
+	__arg := SetChatDNDScheduleLocalArg{Schedule: schedule}
+	err = c.Cli.Call(ctx, "chat.1.local.setChatDNDScheduleLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetReplyThreadLocal(ctx context.Context, __arg GetReplyThreadLocalArg) (res GetReplyThreadLocalRes, err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.getReplyThreadLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetChatBookmarksLocal(ctx context.Context, identifyBehavior keybase1.TLFIdentifyBehavior) (res GetChatBookmarksLocalRes, err error) {
+	__arg := GetChatBookmarksLocalArg{IdentifyBehavior: identifyBehavior}
+	err = c.Cli.Call(ctx, "chat.1.local.getChatBookmarksLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AddChatBookmarkLocal(ctx context.Context, __arg AddChatBookmarkLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.addChatBookmarkLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) RemoveChatBookmarkLocal(ctx context.Context, __arg RemoveChatBookmarkLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "chat.1.local.removeChatBookmarkLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
@@ -18,6 +18,7 @@ const (
 	UnfurlType_YOUTUBE UnfurlType = 1
 	UnfurlType_GIPHY   UnfurlType = 2
 	UnfurlType_MAPS    UnfurlType = 3
+	UnfurlType_EMBED   UnfurlType = 4
 )
 
 func (o UnfurlType) DeepCopy() UnfurlType { return o }
@@ -27,6 +28,7 @@ var UnfurlTypeMap = map[string]UnfurlType{
 	"YOUTUBE": 1,
 	"GIPHY":   2,
 	"MAPS":    3,
+	"EMBED":   4,
 }
 
 var UnfurlTypeRevMap = map[UnfurlType]string{
@@ -34,6 +36,7 @@ var UnfurlTypeRevMap = map[UnfurlType]string{
 	1: "YOUTUBE",
 	2: "GIPHY",
 	3: "MAPS",
+	4: "EMBED",
 }
 
 func (o UnfurlType) String() string {
@@ -43,6 +46,41 @@ func (o UnfurlType) String() string {
 	return fmt.Sprintf("%v", int(o))
 }
 
+type UnfurlEmbedType int
+
+const (
+	UnfurlEmbedType_VIDEO UnfurlEmbedType = 0
+	UnfurlEmbedType_AUDIO UnfurlEmbedType = 1
+	UnfurlEmbedType_POST  UnfurlEmbedType = 2
+	UnfurlEmbedType_CODE  UnfurlEmbedType = 3
+	UnfurlEmbedType_RICH  UnfurlEmbedType = 4
+)
+
+func (o UnfurlEmbedType) DeepCopy() UnfurlEmbedType { return o }
+
+var UnfurlEmbedTypeMap = map[string]UnfurlEmbedType{
+	"VIDEO": 0,
+	"AUDIO": 1,
+	"POST":  2,
+	"CODE":  3,
+	"RICH":  4,
+}
+
+var UnfurlEmbedTypeRevMap = map[UnfurlEmbedType]string{
+	0: "VIDEO",
+	1: "AUDIO",
+	2: "POST",
+	3: "CODE",
+	4: "RICH",
+}
+
+func (o UnfurlEmbedType) String() string {
+	if v, ok := UnfurlEmbedTypeRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
 type UnfurlVideo struct {
 	Url      string `codec:"url" json:"url"`
 	MimeType string `codec:"mimeType" json:"mimeType"`
@@ -192,12 +230,81 @@ func (o UnfurlMapsRaw) DeepCopy() UnfurlMapsRaw {
 	}
 }
 
+type UnfurlEmbedRaw struct {
+	EmbedType    UnfurlEmbedType `codec:"embedType" json:"embedType"`
+	Title        string          `codec:"title" json:"title"`
+	Url          string          `codec:"url" json:"url"`
+	SiteName     string          `codec:"siteName" json:"siteName"`
+	AuthorName   *string         `codec:"authorName,omitempty" json:"authorName,omitempty"`
+	Description  *string         `codec:"description,omitempty" json:"description,omitempty"`
+	FaviconUrl   *string         `codec:"faviconUrl,omitempty" json:"faviconUrl,omitempty"`
+	ThumbnailUrl *string         `codec:"thumbnailUrl,omitempty" json:"thumbnailUrl,omitempty"`
+	PlayerUrl    *string         `codec:"playerUrl,omitempty" json:"playerUrl,omitempty"`
+	Width        int             `codec:"width" json:"width"`
+	Height       int             `codec:"height" json:"height"`
+	Snippet      *string         `codec:"snippet,omitempty" json:"snippet,omitempty"`
+}
+
+func (o UnfurlEmbedRaw) DeepCopy() UnfurlEmbedRaw {
+	return UnfurlEmbedRaw{
+		EmbedType: o.EmbedType.DeepCopy(),
+		Title:     o.Title,
+		Url:       o.Url,
+		SiteName:  o.SiteName,
+		AuthorName: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.AuthorName),
+		Description: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Description),
+		FaviconUrl: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.FaviconUrl),
+		ThumbnailUrl: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.ThumbnailUrl),
+		PlayerUrl: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.PlayerUrl),
+		Width:  o.Width,
+		Height: o.Height,
+		Snippet: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Snippet),
+	}
+}
+
 type UnfurlRaw struct {
 	UnfurlType__ UnfurlType        `codec:"unfurlType" json:"unfurlType"`
 	Generic__    *UnfurlGenericRaw `codec:"generic,omitempty" json:"generic,omitempty"`
 	Youtube__    *UnfurlYoutubeRaw `codec:"youtube,omitempty" json:"youtube,omitempty"`
 	Giphy__      *UnfurlGiphyRaw   `codec:"giphy,omitempty" json:"giphy,omitempty"`
 	Maps__       *UnfurlMapsRaw    `codec:"maps,omitempty" json:"maps,omitempty"`
+	Embed__      *UnfurlEmbedRaw   `codec:"embed,omitempty" json:"embed,omitempty"`
 }
 
 func (o *UnfurlRaw) UnfurlType() (ret UnfurlType, err error) {
@@ -222,6 +329,11 @@ func (o *UnfurlRaw) UnfurlType() (ret UnfurlType, err error) {
 			err = errors.New("unexpected nil value for Maps__")
 			return ret, err
 		}
+	case UnfurlType_EMBED:
+		if o.Embed__ == nil {
+			err = errors.New("unexpected nil value for Embed__")
+			return ret, err
+		}
 	}
 	return o.UnfurlType__, nil
 }
@@ -266,6 +378,16 @@ func (o UnfurlRaw) Maps() (res UnfurlMapsRaw) {
 	return *o.Maps__
 }
 
+func (o UnfurlRaw) Embed() (res UnfurlEmbedRaw) {
+	if o.UnfurlType__ != UnfurlType_EMBED {
+		panic("wrong case accessed")
+	}
+	if o.Embed__ == nil {
+		return
+	}
+	return *o.Embed__
+}
+
 func NewUnfurlRawWithGeneric(v UnfurlGenericRaw) UnfurlRaw {
 	return UnfurlRaw{
 		UnfurlType__: UnfurlType_GENERIC,
@@ -294,6 +416,13 @@ func NewUnfurlRawWithMaps(v UnfurlMapsRaw) UnfurlRaw {
 	}
 }
 
+func NewUnfurlRawWithEmbed(v UnfurlEmbedRaw) UnfurlRaw {
+	return UnfurlRaw{
+		UnfurlType__: UnfurlType_EMBED,
+		Embed__:      &v,
+	}
+}
+
 func (o UnfurlRaw) DeepCopy() UnfurlRaw {
 	return UnfurlRaw{
 		UnfurlType__: o.UnfurlType__.DeepCopy(),
@@ -325,6 +454,13 @@ func (o UnfurlRaw) DeepCopy() UnfurlRaw {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Maps__),
+		Embed__: (func(x *UnfurlEmbedRaw) *UnfurlEmbedRaw {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Embed__),
 	}
 }
 
@@ -443,11 +579,80 @@ func (o UnfurlGiphy) DeepCopy() UnfurlGiphy {
 	}
 }
 
+type UnfurlEmbed struct {
+	EmbedType   UnfurlEmbedType `codec:"embedType" json:"embedType"`
+	Title       string          `codec:"title" json:"title"`
+	Url         string          `codec:"url" json:"url"`
+	SiteName    string          `codec:"siteName" json:"siteName"`
+	AuthorName  *string         `codec:"authorName,omitempty" json:"authorName,omitempty"`
+	Description *string         `codec:"description,omitempty" json:"description,omitempty"`
+	Favicon     *Asset          `codec:"favicon,omitempty" json:"favicon,omitempty"`
+	Thumbnail   *Asset          `codec:"thumbnail,omitempty" json:"thumbnail,omitempty"`
+	PlayerUrl   *string         `codec:"playerUrl,omitempty" json:"playerUrl,omitempty"`
+	Width       int             `codec:"width" json:"width"`
+	Height      int             `codec:"height" json:"height"`
+	Snippet     *string         `codec:"snippet,omitempty" json:"snippet,omitempty"`
+}
+
+func (o UnfurlEmbed) DeepCopy() UnfurlEmbed {
+	return UnfurlEmbed{
+		EmbedType: o.EmbedType.DeepCopy(),
+		Title:     o.Title,
+		Url:       o.Url,
+		SiteName:  o.SiteName,
+		AuthorName: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.AuthorName),
+		Description: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Description),
+		Favicon: (func(x *Asset) *Asset {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Favicon),
+		Thumbnail: (func(x *Asset) *Asset {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Thumbnail),
+		PlayerUrl: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.PlayerUrl),
+		Width:  o.Width,
+		Height: o.Height,
+		Snippet: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Snippet),
+	}
+}
+
 type Unfurl struct {
 	UnfurlType__ UnfurlType     `codec:"unfurlType" json:"unfurlType"`
 	Generic__    *UnfurlGeneric `codec:"generic,omitempty" json:"generic,omitempty"`
 	Youtube__    *UnfurlYoutube `codec:"youtube,omitempty" json:"youtube,omitempty"`
 	Giphy__      *UnfurlGiphy   `codec:"giphy,omitempty" json:"giphy,omitempty"`
+	Embed__      *UnfurlEmbed   `codec:"embed,omitempty" json:"embed,omitempty"`
 }
 
 func (o *Unfurl) UnfurlType() (ret UnfurlType, err error) {
@@ -467,6 +672,11 @@ func (o *Unfurl) UnfurlType() (ret UnfurlType, err error) {
 			err = errors.New("unexpected nil value for Giphy__")
 			return ret, err
 		}
+	case UnfurlType_EMBED:
+		if o.Embed__ == nil {
+			err = errors.New("unexpected nil value for Embed__")
+			return ret, err
+		}
 	}
 	return o.UnfurlType__, nil
 }
@@ -501,6 +711,16 @@ func (o Unfurl) Giphy() (res UnfurlGiphy) {
 	return *o.Giphy__
 }
 
+func (o Unfurl) Embed() (res UnfurlEmbed) {
+	if o.UnfurlType__ != UnfurlType_EMBED {
+		panic("wrong case accessed")
+	}
+	if o.Embed__ == nil {
+		return
+	}
+	return *o.Embed__
+}
+
 func NewUnfurlWithGeneric(v UnfurlGeneric) Unfurl {
 	return Unfurl{
 		UnfurlType__: UnfurlType_GENERIC,
@@ -522,6 +742,13 @@ func NewUnfurlWithGiphy(v UnfurlGiphy) Unfurl {
 	}
 }
 
+func NewUnfurlWithEmbed(v UnfurlEmbed) Unfurl {
+	return Unfurl{
+		UnfurlType__: UnfurlType_EMBED,
+		Embed__:      &v,
+	}
+}
+
 func (o Unfurl) DeepCopy() Unfurl {
 	return Unfurl{
 		UnfurlType__: o.UnfurlType__.DeepCopy(),
@@ -546,6 +773,13 @@ func (o Unfurl) DeepCopy() Unfurl {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Giphy__),
+		Embed__: (func(x *UnfurlEmbed) *UnfurlEmbed {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Embed__),
 	}
 }
 
@@ -670,11 +904,80 @@ func (o UnfurlGiphyDisplay) DeepCopy() UnfurlGiphyDisplay {
 	}
 }
 
+type UnfurlEmbedDisplay struct {
+	EmbedType   UnfurlEmbedType     `codec:"embedType" json:"embedType"`
+	Title       string              `codec:"title" json:"title"`
+	Url         string              `codec:"url" json:"url"`
+	SiteName    string              `codec:"siteName" json:"siteName"`
+	AuthorName  *string             `codec:"authorName,omitempty" json:"authorName,omitempty"`
+	Description *string             `codec:"description,omitempty" json:"description,omitempty"`
+	Favicon     *UnfurlImageDisplay `codec:"favicon,omitempty" json:"favicon,omitempty"`
+	Thumbnail   *UnfurlImageDisplay `codec:"thumbnail,omitempty" json:"thumbnail,omitempty"`
+	PlayerUrl   *string             `codec:"playerUrl,omitempty" json:"playerUrl,omitempty"`
+	Width       int                 `codec:"width" json:"width"`
+	Height      int                 `codec:"height" json:"height"`
+	Snippet     *string             `codec:"snippet,omitempty" json:"snippet,omitempty"`
+}
+
+func (o UnfurlEmbedDisplay) DeepCopy() UnfurlEmbedDisplay {
+	return UnfurlEmbedDisplay{
+		EmbedType: o.EmbedType.DeepCopy(),
+		Title:     o.Title,
+		Url:       o.Url,
+		SiteName:  o.SiteName,
+		AuthorName: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.AuthorName),
+		Description: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Description),
+		Favicon: (func(x *UnfurlImageDisplay) *UnfurlImageDisplay {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Favicon),
+		Thumbnail: (func(x *UnfurlImageDisplay) *UnfurlImageDisplay {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Thumbnail),
+		PlayerUrl: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.PlayerUrl),
+		Width:  o.Width,
+		Height: o.Height,
+		Snippet: (func(x *string) *string {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x)
+			return &tmp
+		})(o.Snippet),
+	}
+}
+
 type UnfurlDisplay struct {
 	UnfurlType__ UnfurlType            `codec:"unfurlType" json:"unfurlType"`
 	Generic__    *UnfurlGenericDisplay `codec:"generic,omitempty" json:"generic,omitempty"`
 	Youtube__    *UnfurlYoutubeDisplay `codec:"youtube,omitempty" json:"youtube,omitempty"`
 	Giphy__      *UnfurlGiphyDisplay   `codec:"giphy,omitempty" json:"giphy,omitempty"`
+	Embed__      *UnfurlEmbedDisplay   `codec:"embed,omitempty" json:"embed,omitempty"`
 }
 
 func (o *UnfurlDisplay) UnfurlType() (ret UnfurlType, err error) {
@@ -694,6 +997,11 @@ func (o *UnfurlDisplay) UnfurlType() (ret UnfurlType, err error) {
 			err = errors.New("unexpected nil value for Giphy__")
 			return ret, err
 		}
+	case UnfurlType_EMBED:
+		if o.Embed__ == nil {
+			err = errors.New("unexpected nil value for Embed__")
+			return ret, err
+		}
 	}
 	return o.UnfurlType__, nil
 }
@@ -728,6 +1036,16 @@ func (o UnfurlDisplay) Giphy() (res UnfurlGiphyDisplay) {
 	return *o.Giphy__
 }
 
+func (o UnfurlDisplay) Embed() (res UnfurlEmbedDisplay) {
+	if o.UnfurlType__ != UnfurlType_EMBED {
+		panic("wrong case accessed")
+	}
+	if o.Embed__ == nil {
+		return
+	}
+	return *o.Embed__
+}
+
 func NewUnfurlDisplayWithGeneric(v UnfurlGenericDisplay) UnfurlDisplay {
 	return UnfurlDisplay{
 		UnfurlType__: UnfurlType_GENERIC,
@@ -749,6 +1067,13 @@ func NewUnfurlDisplayWithGiphy(v UnfurlGiphyDisplay) UnfurlDisplay {
 	}
 }
 
+func NewUnfurlDisplayWithEmbed(v UnfurlEmbedDisplay) UnfurlDisplay {
+	return UnfurlDisplay{
+		UnfurlType__: UnfurlType_EMBED,
+		Embed__:      &v,
+	}
+}
+
 func (o UnfurlDisplay) DeepCopy() UnfurlDisplay {
 	return UnfurlDisplay{
 		UnfurlType__: o.UnfurlType__.DeepCopy(),
@@ -773,6 +1098,13 @@ func (o UnfurlDisplay) DeepCopy() UnfurlDisplay {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Giphy__),
+		Embed__: (func(x *UnfurlEmbedDisplay) *UnfurlEmbedDisplay {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Embed__),
 	}
 }
 
@@ -850,6 +1182,57 @@ func (o UnfurlSettingsDisplay) DeepCopy() UnfurlSettingsDisplay {
 	}
 }
 
+type TeamUnfurlMode int
+
+const (
+	TeamUnfurlMode_DEFAULT   TeamUnfurlMode = 0
+	TeamUnfurlMode_DISABLED  TeamUnfurlMode = 1
+	TeamUnfurlMode_ALLOWLIST TeamUnfurlMode = 2
+)
+
+func (o TeamUnfurlMode) DeepCopy() TeamUnfurlMode { return o }
+
+var TeamUnfurlModeMap = map[string]TeamUnfurlMode{
+	"DEFAULT":   0,
+	"DISABLED":  1,
+	"ALLOWLIST": 2,
+}
+
+var TeamUnfurlModeRevMap = map[TeamUnfurlMode]string{
+	0: "DEFAULT",
+	1: "DISABLED",
+	2: "ALLOWLIST",
+}
+
+func (o TeamUnfurlMode) String() string {
+	if v, ok := TeamUnfurlModeRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type TeamUnfurlPolicy struct {
+	Mode      TeamUnfurlMode `codec:"mode" json:"mode"`
+	Allowlist []string       `codec:"allowlist" json:"allowlist"`
+}
+
+func (o TeamUnfurlPolicy) DeepCopy() TeamUnfurlPolicy {
+	return TeamUnfurlPolicy{
+		Mode: o.Mode.DeepCopy(),
+		Allowlist: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Allowlist),
+	}
+}
+
 type UnfurlInterface interface {
 }
 
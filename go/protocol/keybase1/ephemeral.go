@@ -4,8 +4,10 @@
 package keybase1
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 )
@@ -424,16 +426,205 @@ func (o TeamEphemeralKeyBoxed) DeepCopy() TeamEphemeralKeyBoxed {
 	}
 }
 
+type EkStorageLayer int
+
+const (
+	EkStorageLayer_DEVICE  EkStorageLayer = 0
+	EkStorageLayer_USER    EkStorageLayer = 1
+	EkStorageLayer_TEAM    EkStorageLayer = 2
+	EkStorageLayer_TEAMBOT EkStorageLayer = 3
+)
+
+func (o EkStorageLayer) DeepCopy() EkStorageLayer { return o }
+
+var EkStorageLayerMap = map[string]EkStorageLayer{
+	"DEVICE":  0,
+	"USER":    1,
+	"TEAM":    2,
+	"TEAMBOT": 3,
+}
+
+var EkStorageLayerRevMap = map[EkStorageLayer]string{
+	0: "DEVICE",
+	1: "USER",
+	2: "TEAM",
+	3: "TEAMBOT",
+}
+
+func (o EkStorageLayer) String() string {
+	if v, ok := EkStorageLayerRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type EkGenerationStatus struct {
+	Generation EkGeneration `codec:"generation" json:"generation"`
+	Ctime      Time         `codec:"ctime" json:"ctime"`
+	Error      string       `codec:"error" json:"error"`
+}
+
+func (o EkGenerationStatus) DeepCopy() EkGenerationStatus {
+	return EkGenerationStatus{
+		Generation: o.Generation.DeepCopy(),
+		Ctime:      o.Ctime.DeepCopy(),
+		Error:      o.Error,
+	}
+}
+
+type EkStorageStatus struct {
+	Layer       EkStorageLayer       `codec:"layer" json:"layer"`
+	TeamID      TeamID               `codec:"teamID" json:"teamID"`
+	TeamName    string               `codec:"teamName" json:"teamName"`
+	Generations []EkGenerationStatus `codec:"generations" json:"generations"`
+	Gaps        []EkGeneration       `codec:"gaps" json:"gaps"`
+}
+
+func (o EkStorageStatus) DeepCopy() EkStorageStatus {
+	return EkStorageStatus{
+		Layer:    o.Layer.DeepCopy(),
+		TeamID:   o.TeamID.DeepCopy(),
+		TeamName: o.TeamName,
+		Generations: (func(x []EkGenerationStatus) []EkGenerationStatus {
+			if x == nil {
+				return nil
+			}
+			ret := make([]EkGenerationStatus, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Generations),
+		Gaps: (func(x []EkGeneration) []EkGeneration {
+			if x == nil {
+				return nil
+			}
+			ret := make([]EkGeneration, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Gaps),
+	}
+}
+
+type EkRepairRes struct {
+	DryRun    bool              `codec:"dryRun" json:"dryRun"`
+	Actions   []string          `codec:"actions" json:"actions"`
+	Deleted   int               `codec:"deleted" json:"deleted"`
+	Refetched int               `codec:"refetched" json:"refetched"`
+	Errors    []string          `codec:"errors" json:"errors"`
+	Storages  []EkStorageStatus `codec:"storages" json:"storages"`
+}
+
+func (o EkRepairRes) DeepCopy() EkRepairRes {
+	return EkRepairRes{
+		DryRun: o.DryRun,
+		Actions: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Actions),
+		Deleted:   o.Deleted,
+		Refetched: o.Refetched,
+		Errors: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Errors),
+		Storages: (func(x []EkStorageStatus) []EkStorageStatus {
+			if x == nil {
+				return nil
+			}
+			ret := make([]EkStorageStatus, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Storages),
+	}
+}
+
+type GetEkStatusArg struct {
+	SessionID int `codec:"sessionID" json:"sessionID"`
+}
+
+type RepairEksArg struct {
+	SessionID int  `codec:"sessionID" json:"sessionID"`
+	Force     bool `codec:"force" json:"force"`
+}
+
 type EphemeralInterface interface {
+	GetEkStatus(context.Context, int) ([]EkStorageStatus, error)
+	// Without force, only lists what would be repaired.
+	RepairEks(context.Context, RepairEksArg) (EkRepairRes, error)
 }
 
 func EphemeralProtocol(i EphemeralInterface) rpc.Protocol {
 	return rpc.Protocol{
-		Name:    "keybase.1.ephemeral",
-		Methods: map[string]rpc.ServeHandlerDescription{},
+		Name: "keybase.1.ephemeral",
+		Methods: map[string]rpc.ServeHandlerDescription{
+			"getEkStatus": {
+				MakeArg: func() any {
+					var ret [1]GetEkStatusArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]GetEkStatusArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetEkStatusArg)(nil), args)
+						return
+					}
+					ret, err = i.GetEkStatus(ctx, typedArgs[0].SessionID)
+					return
+				},
+			},
+			"repairEks": {
+				MakeArg: func() any {
+					var ret [1]RepairEksArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]RepairEksArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RepairEksArg)(nil), args)
+						return
+					}
+					ret, err = i.RepairEks(ctx, typedArgs[0])
+					return
+				},
+			},
+		},
 	}
 }
 
 type EphemeralClient struct {
 	Cli rpc.GenericClient
 }
+
+func (c EphemeralClient) GetEkStatus(ctx context.Context, sessionID int) (res []EkStorageStatus, err error) {
+	__arg := GetEkStatusArg{SessionID: sessionID}
+	err = c.Cli.Call(ctx, "keybase.1.ephemeral.getEkStatus", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+// Without force, only lists what would be repaired.
+func (c EphemeralClient) RepairEks(ctx context.Context, __arg RepairEksArg) (res EkRepairRes, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.ephemeral.repairEks", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
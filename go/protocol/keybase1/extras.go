@@ -3993,6 +3993,13 @@ func (s *TeamBotSettings) ConvIDAllowed(strCID string) bool {
 	return len(s.Convs) == 0
 }
 
+func (s *TeamBotSettings) SenderAllowed(uid UID) bool {
+	if s == nil || len(s.Senders) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(s.Senders, uid.Equal)
+}
+
 func (b UserBlockedBody) Summarize() UserBlockedSummary {
 	ret := UserBlockedSummary{
 		Blocker: b.Username,
@@ -3524,6 +3524,8 @@ type TeamBotSettings struct {
 	Mentions bool     `codec:"mentions" json:"mentions"`
 	Triggers []string `codec:"triggers" json:"triggers"`
 	Convs    []string `codec:"convs" json:"convs"`
+	CmdsOnly bool     `codec:"cmdsOnly" json:"cmdsOnly"`
+	Senders  []UID    `codec:"senders" json:"senders"`
 }
 
 func (o TeamBotSettings) DeepCopy() TeamBotSettings {
@@ -3552,6 +3554,18 @@ func (o TeamBotSettings) DeepCopy() TeamBotSettings {
 			}
 			return ret
 		})(o.Convs),
+		CmdsOnly: o.CmdsOnly,
+		Senders: (func(x []UID) []UID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]UID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Senders),
 	}
 }
 
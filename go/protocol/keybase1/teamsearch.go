@@ -5,6 +5,7 @@ package keybase1
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
@@ -91,6 +92,58 @@ func (o TeamSearchRes) DeepCopy() TeamSearchRes {
 	}
 }
 
+type TeamSearchSortOrder int
+
+const (
+	TeamSearchSortOrder_RELEVANCE    TeamSearchSortOrder = 0
+	TeamSearchSortOrder_MEMBER_COUNT TeamSearchSortOrder = 1
+	TeamSearchSortOrder_LAST_ACTIVE  TeamSearchSortOrder = 2
+	TeamSearchSortOrder_NAME         TeamSearchSortOrder = 3
+)
+
+func (o TeamSearchSortOrder) DeepCopy() TeamSearchSortOrder { return o }
+
+var TeamSearchSortOrderMap = map[string]TeamSearchSortOrder{
+	"RELEVANCE":    0,
+	"MEMBER_COUNT": 1,
+	"LAST_ACTIVE":  2,
+	"NAME":         3,
+}
+
+var TeamSearchSortOrderRevMap = map[TeamSearchSortOrder]string{
+	0: "RELEVANCE",
+	1: "MEMBER_COUNT",
+	2: "LAST_ACTIVE",
+	3: "NAME",
+}
+
+func (o TeamSearchSortOrder) String() string {
+	if v, ok := TeamSearchSortOrderRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type TeamSearchFilter struct {
+	MinMemberCount int   `codec:"minMemberCount" json:"minMemberCount"`
+	MaxMemberCount int   `codec:"maxMemberCount" json:"maxMemberCount"`
+	ActiveSince    *Time `codec:"activeSince,omitempty" json:"activeSince,omitempty"`
+}
+
+func (o TeamSearchFilter) DeepCopy() TeamSearchFilter {
+	return TeamSearchFilter{
+		MinMemberCount: o.MinMemberCount,
+		MaxMemberCount: o.MaxMemberCount,
+		ActiveSince: (func(x *Time) *Time {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.ActiveSince),
+	}
+}
+
 type TeamSearchArg struct {
 	Uid       *UID   `codec:"uid,omitempty" json:"uid,omitempty"`
 	Query     string `codec:"query" json:"query"`
@@ -98,8 +151,17 @@ type TeamSearchArg struct {
 	UseRemote bool   `codec:"useRemote" json:"useRemote"`
 }
 
+type TeamSearchDirectoryArg struct {
+	Query        string              `codec:"query" json:"query"`
+	Limit        int                 `codec:"limit" json:"limit"`
+	Filter       TeamSearchFilter    `codec:"filter" json:"filter"`
+	SortOrder    TeamSearchSortOrder `codec:"sortOrder" json:"sortOrder"`
+	ForceRefresh bool                `codec:"forceRefresh" json:"forceRefresh"`
+}
+
 type TeamSearchInterface interface {
 	TeamSearch(context.Context, TeamSearchArg) (TeamSearchRes, error)
+	TeamSearchDirectory(context.Context, TeamSearchDirectoryArg) (TeamSearchRes, error)
 }
 
 func TeamSearchProtocol(i TeamSearchInterface) rpc.Protocol {
@@ -121,6 +183,21 @@ func TeamSearchProtocol(i TeamSearchInterface) rpc.Protocol {
 					return
 				},
 			},
+			"teamSearchDirectory": {
+				MakeArg: func() any {
+					var ret [1]TeamSearchDirectoryArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]TeamSearchDirectoryArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]TeamSearchDirectoryArg)(nil), args)
+						return
+					}
+					ret, err = i.TeamSearchDirectory(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -133,3 +210,8 @@ func (c TeamSearchClient) TeamSearch(ctx context.Context, __arg TeamSearchArg) (
 	err = c.Cli.Call(ctx, "keybase.1.teamSearch.teamSearch", []any{__arg}, &res, 0*time.Millisecond)
 	return
 }
+
+func (c TeamSearchClient) TeamSearchDirectory(ctx context.Context, __arg TeamSearchDirectoryArg) (res TeamSearchRes, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.teamSearch.teamSearchDirectory", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
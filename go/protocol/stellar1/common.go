@@ -361,6 +361,66 @@ func (o NoteContents) DeepCopy() NoteContents {
 	}
 }
 
+type InvoiceLineItem struct {
+	Description string `codec:"description" json:"description"`
+	Quantity    string `codec:"quantity" json:"quantity"`
+	UnitAmount  string `codec:"unitAmount" json:"unitAmount"`
+	Amount      string `codec:"amount" json:"amount"`
+}
+
+func (o InvoiceLineItem) DeepCopy() InvoiceLineItem {
+	return InvoiceLineItem{
+		Description: o.Description,
+		Quantity:    o.Quantity,
+		UnitAmount:  o.UnitAmount,
+		Amount:      o.Amount,
+	}
+}
+
+type InvoiceContents struct {
+	LineItems []InvoiceLineItem `codec:"lineItems" json:"lineItems"`
+	DueDate   TimeMs            `codec:"dueDate" json:"dueDate"`
+	Reference string            `codec:"reference" json:"reference"`
+}
+
+func (o InvoiceContents) DeepCopy() InvoiceContents {
+	return InvoiceContents{
+		LineItems: (func(x []InvoiceLineItem) []InvoiceLineItem {
+			if x == nil {
+				return nil
+			}
+			ret := make([]InvoiceLineItem, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.LineItems),
+		DueDate:   o.DueDate.DeepCopy(),
+		Reference: o.Reference,
+	}
+}
+
+type RequestPayment struct {
+	KbTxID          KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+	TxID            TransactionID        `codec:"txID" json:"txID"`
+	Amount          string               `codec:"amount" json:"amount"`
+	DisplayAmount   string               `codec:"displayAmount" json:"displayAmount"`
+	DisplayCurrency string               `codec:"displayCurrency" json:"displayCurrency"`
+	Ctime           TimeMs               `codec:"ctime" json:"ctime"`
+}
+
+func (o RequestPayment) DeepCopy() RequestPayment {
+	return RequestPayment{
+		KbTxID:          o.KbTxID.DeepCopy(),
+		TxID:            o.TxID.DeepCopy(),
+		Amount:          o.Amount,
+		DisplayAmount:   o.DisplayAmount,
+		DisplayCurrency: o.DisplayCurrency,
+		Ctime:           o.Ctime.DeepCopy(),
+	}
+}
+
 type EncryptedRelaySecret struct {
 	V   int                           `codec:"v" json:"v"`
 	E   []byte                        `codec:"e" json:"e"`
@@ -85,6 +85,18 @@ func (k KeybaseRequestID) Eq(b KeybaseRequestID) bool {
 	return k == b
 }
 
+// String formats a line item for plain text output, e.g. "Hosting: 2 x 10 = 20".
+func (i InvoiceLineItem) String() string {
+	if i.UnitAmount == "" {
+		return fmt.Sprintf("%s: %s", i.Description, i.Amount)
+	}
+	quantity := i.Quantity
+	if quantity == "" {
+		quantity = "1"
+	}
+	return fmt.Sprintf("%s: %s x %s = %s", i.Description, quantity, i.UnitAmount, i.Amount)
+}
+
 func ToTimeMs(t time.Time) TimeMs {
 	// the result of calling UnixNano on the zero Time is undefined.
 	// https://golang.org/pkg/time/#Time.UnixNano
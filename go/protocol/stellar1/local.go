@@ -622,17 +622,22 @@ func (o BuildRequestResLocal) DeepCopy() BuildRequestResLocal {
 }
 
 type RequestDetailsLocal struct {
-	Id                 KeybaseRequestID     `codec:"id" json:"id"`
-	FromAssertion      string               `codec:"fromAssertion" json:"fromAssertion"`
-	FromCurrentUser    bool                 `codec:"fromCurrentUser" json:"fromCurrentUser"`
-	ToUserType         ParticipantType      `codec:"toUserType" json:"toUserType"`
-	ToAssertion        string               `codec:"toAssertion" json:"toAssertion"`
-	Amount             string               `codec:"amount" json:"amount"`
-	Asset              *Asset               `codec:"asset,omitempty" json:"asset,omitempty"`
-	Currency           *OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
-	AmountDescription  string               `codec:"amountDescription" json:"amountDescription"`
-	WorthAtRequestTime string               `codec:"worthAtRequestTime" json:"worthAtRequestTime"`
-	Status             RequestStatus        `codec:"status" json:"status"`
+	Id                    KeybaseRequestID     `codec:"id" json:"id"`
+	FromAssertion         string               `codec:"fromAssertion" json:"fromAssertion"`
+	FromCurrentUser       bool                 `codec:"fromCurrentUser" json:"fromCurrentUser"`
+	ToUserType            ParticipantType      `codec:"toUserType" json:"toUserType"`
+	ToAssertion           string               `codec:"toAssertion" json:"toAssertion"`
+	Amount                string               `codec:"amount" json:"amount"`
+	Asset                 *Asset               `codec:"asset,omitempty" json:"asset,omitempty"`
+	Currency              *OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
+	AmountDescription     string               `codec:"amountDescription" json:"amountDescription"`
+	WorthAtRequestTime    string               `codec:"worthAtRequestTime" json:"worthAtRequestTime"`
+	Status                RequestStatus        `codec:"status" json:"status"`
+	Invoice               *InvoiceContents     `codec:"invoice,omitempty" json:"invoice,omitempty"`
+	AmountPaid            string               `codec:"amountPaid" json:"amountPaid"`
+	AmountPaidDescription string               `codec:"amountPaidDescription" json:"amountPaidDescription"`
+	Payments              []RequestPayment     `codec:"payments" json:"payments"`
+	Ctime                 TimeMs               `codec:"ctime" json:"ctime"`
 }
 
 func (o RequestDetailsLocal) DeepCopy() RequestDetailsLocal {
@@ -660,6 +665,27 @@ func (o RequestDetailsLocal) DeepCopy() RequestDetailsLocal {
 		AmountDescription:  o.AmountDescription,
 		WorthAtRequestTime: o.WorthAtRequestTime,
 		Status:             o.Status.DeepCopy(),
+		Invoice: (func(x *InvoiceContents) *InvoiceContents {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.Invoice),
+		AmountPaid:            o.AmountPaid,
+		AmountPaidDescription: o.AmountPaidDescription,
+		Payments: (func(x []RequestPayment) []RequestPayment {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RequestPayment, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Payments),
+		Ctime: o.Ctime.DeepCopy(),
 	}
 }
 
@@ -1012,6 +1038,26 @@ func (o PaymentCLILocal) DeepCopy() PaymentCLILocal {
 	}
 }
 
+type PaymentExportCLILocal struct {
+	Payment       PaymentCLILocal `codec:"payment" json:"payment"`
+	Sent          bool            `codec:"sent" json:"sent"`
+	Counterparty  string          `codec:"counterparty" json:"counterparty"`
+	FeeCharged    string          `codec:"feeCharged" json:"feeCharged"`
+	Worth         string          `codec:"worth" json:"worth"`
+	WorthCurrency string          `codec:"worthCurrency" json:"worthCurrency"`
+}
+
+func (o PaymentExportCLILocal) DeepCopy() PaymentExportCLILocal {
+	return PaymentExportCLILocal{
+		Payment:       o.Payment.DeepCopy(),
+		Sent:          o.Sent,
+		Counterparty:  o.Counterparty,
+		FeeCharged:    o.FeeCharged,
+		Worth:         o.Worth,
+		WorthCurrency: o.WorthCurrency,
+	}
+}
+
 type OwnAccountCLILocal struct {
 	AccountID    AccountID            `codec:"accountID" json:"accountID"`
 	IsPrimary    bool                 `codec:"isPrimary" json:"isPrimary"`
@@ -1300,6 +1346,218 @@ func (o SignXdrResult) DeepCopy() SignXdrResult {
 	}
 }
 
+type CosignSigner struct {
+	AccountID AccountID `codec:"accountID" json:"accountID"`
+	Weight    int       `codec:"weight" json:"weight"`
+}
+
+func (o CosignSigner) DeepCopy() CosignSigner {
+	return CosignSigner{
+		AccountID: o.AccountID.DeepCopy(),
+		Weight:    o.Weight,
+	}
+}
+
+type CosignStatusLocal struct {
+	AccountID       AccountID      `codec:"accountID" json:"accountID"`
+	TxID            TransactionID  `codec:"txID" json:"txID"`
+	Operations      []string       `codec:"operations" json:"operations"`
+	Threshold       int            `codec:"threshold" json:"threshold"`
+	CollectedWeight int            `codec:"collectedWeight" json:"collectedWeight"`
+	Signers         []CosignSigner `codec:"signers" json:"signers"`
+	Signed          []AccountID    `codec:"signed" json:"signed"`
+	Ready           bool           `codec:"ready" json:"ready"`
+}
+
+func (o CosignStatusLocal) DeepCopy() CosignStatusLocal {
+	return CosignStatusLocal{
+		AccountID: o.AccountID.DeepCopy(),
+		TxID:      o.TxID.DeepCopy(),
+		Operations: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Operations),
+		Threshold:       o.Threshold,
+		CollectedWeight: o.CollectedWeight,
+		Signers: (func(x []CosignSigner) []CosignSigner {
+			if x == nil {
+				return nil
+			}
+			ret := make([]CosignSigner, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Signers),
+		Signed: (func(x []AccountID) []AccountID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]AccountID, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Signed),
+		Ready: o.Ready,
+	}
+}
+
+type CosignResultLocal struct {
+	EnvelopeXdr string            `codec:"envelopeXdr" json:"envelopeXdr"`
+	Status      CosignStatusLocal `codec:"status" json:"status"`
+	SubmitTxID  *TransactionID    `codec:"submitTxID,omitempty" json:"submitTxID,omitempty"`
+}
+
+func (o CosignResultLocal) DeepCopy() CosignResultLocal {
+	return CosignResultLocal{
+		EnvelopeXdr: o.EnvelopeXdr,
+		Status:      o.Status.DeepCopy(),
+		SubmitTxID: (func(x *TransactionID) *TransactionID {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.SubmitTxID),
+	}
+}
+
+type ScheduledPaymentRecurrence int
+
+const (
+	ScheduledPaymentRecurrence_ONCE    ScheduledPaymentRecurrence = 0
+	ScheduledPaymentRecurrence_WEEKLY  ScheduledPaymentRecurrence = 1
+	ScheduledPaymentRecurrence_MONTHLY ScheduledPaymentRecurrence = 2
+)
+
+func (o ScheduledPaymentRecurrence) DeepCopy() ScheduledPaymentRecurrence { return o }
+
+var ScheduledPaymentRecurrenceMap = map[string]ScheduledPaymentRecurrence{
+	"ONCE":    0,
+	"WEEKLY":  1,
+	"MONTHLY": 2,
+}
+
+var ScheduledPaymentRecurrenceRevMap = map[ScheduledPaymentRecurrence]string{
+	0: "ONCE",
+	1: "WEEKLY",
+	2: "MONTHLY",
+}
+
+func (o ScheduledPaymentRecurrence) String() string {
+	if v, ok := ScheduledPaymentRecurrenceRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type ScheduledPaymentRunStatus int
+
+const (
+	ScheduledPaymentRunStatus_SENT    ScheduledPaymentRunStatus = 0
+	ScheduledPaymentRunStatus_SKIPPED ScheduledPaymentRunStatus = 1
+	ScheduledPaymentRunStatus_FAILED  ScheduledPaymentRunStatus = 2
+)
+
+func (o ScheduledPaymentRunStatus) DeepCopy() ScheduledPaymentRunStatus { return o }
+
+var ScheduledPaymentRunStatusMap = map[string]ScheduledPaymentRunStatus{
+	"SENT":    0,
+	"SKIPPED": 1,
+	"FAILED":  2,
+}
+
+var ScheduledPaymentRunStatusRevMap = map[ScheduledPaymentRunStatus]string{
+	0: "SENT",
+	1: "SKIPPED",
+	2: "FAILED",
+}
+
+func (o ScheduledPaymentRunStatus) String() string {
+	if v, ok := ScheduledPaymentRunStatusRevMap[o]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(o))
+}
+
+type ScheduledPaymentID string
+
+func (o ScheduledPaymentID) DeepCopy() ScheduledPaymentID {
+	return o
+}
+
+type ScheduledPaymentRunLocal struct {
+	Time   TimeMs                    `codec:"time" json:"time"`
+	Status ScheduledPaymentRunStatus `codec:"status" json:"status"`
+	TxID   *TransactionID            `codec:"txID,omitempty" json:"txID,omitempty"`
+	Error  string                    `codec:"error" json:"error"`
+}
+
+func (o ScheduledPaymentRunLocal) DeepCopy() ScheduledPaymentRunLocal {
+	return ScheduledPaymentRunLocal{
+		Time:   o.Time.DeepCopy(),
+		Status: o.Status.DeepCopy(),
+		TxID: (func(x *TransactionID) *TransactionID {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.TxID),
+		Error: o.Error,
+	}
+}
+
+type ScheduledPaymentLocal struct {
+	Id         ScheduledPaymentID         `codec:"id" json:"id"`
+	From       AccountID                  `codec:"from" json:"from"`
+	To         string                     `codec:"to" json:"to"`
+	Amount     string                     `codec:"amount" json:"amount"`
+	Currency   OutsideCurrencyCode        `codec:"currency" json:"currency"`
+	Note       string                     `codec:"note" json:"note"`
+	PublicMemo string                     `codec:"publicMemo" json:"publicMemo"`
+	Recurrence ScheduledPaymentRecurrence `codec:"recurrence" json:"recurrence"`
+	Start      TimeMs                     `codec:"start" json:"start"`
+	NextRun    TimeMs                     `codec:"nextRun" json:"nextRun"`
+	Ctime      TimeMs                     `codec:"ctime" json:"ctime"`
+	Runs       int                        `codec:"runs" json:"runs"`
+	LastRun    *ScheduledPaymentRunLocal  `codec:"lastRun,omitempty" json:"lastRun,omitempty"`
+}
+
+func (o ScheduledPaymentLocal) DeepCopy() ScheduledPaymentLocal {
+	return ScheduledPaymentLocal{
+		Id:         o.Id.DeepCopy(),
+		From:       o.From.DeepCopy(),
+		To:         o.To,
+		Amount:     o.Amount,
+		Currency:   o.Currency.DeepCopy(),
+		Note:       o.Note,
+		PublicMemo: o.PublicMemo,
+		Recurrence: o.Recurrence.DeepCopy(),
+		Start:      o.Start.DeepCopy(),
+		NextRun:    o.NextRun.DeepCopy(),
+		Ctime:      o.Ctime.DeepCopy(),
+		Runs:       o.Runs,
+		LastRun: (func(x *ScheduledPaymentRunLocal) *ScheduledPaymentRunLocal {
+			if x == nil {
+				return nil
+			}
+			tmp := (*x).DeepCopy()
+			return &tmp
+		})(o.LastRun),
+	}
+}
+
 type StaticConfig struct {
 	PaymentNoteMaxLength int `codec:"paymentNoteMaxLength" json:"paymentNoteMaxLength"`
 	RequestNoteMaxLength int `codec:"requestNoteMaxLength" json:"requestNoteMaxLength"`
@@ -1518,6 +1776,12 @@ type MakeRequestLocalArg struct {
 	Currency  *OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
 	Amount    string               `codec:"amount" json:"amount"`
 	Note      string               `codec:"note" json:"note"`
+	Invoice   *InvoiceContents     `codec:"invoice,omitempty" json:"invoice,omitempty"`
+}
+
+type ListRequestsLocalArg struct {
+	SessionID     int  `codec:"sessionID" json:"sessionID"`
+	IncludeClosed bool `codec:"includeClosed" json:"includeClosed"`
 }
 
 type SetAccountMobileOnlyLocalArg struct {
@@ -1630,16 +1894,17 @@ type BalancesLocalArg struct {
 }
 
 type SendCLILocalArg struct {
-	Recipient       string         `codec:"recipient" json:"recipient"`
-	Amount          string         `codec:"amount" json:"amount"`
-	Asset           Asset          `codec:"asset" json:"asset"`
-	Note            string         `codec:"note" json:"note"`
-	DisplayAmount   string         `codec:"displayAmount" json:"displayAmount"`
-	DisplayCurrency string         `codec:"displayCurrency" json:"displayCurrency"`
-	ForceRelay      bool           `codec:"forceRelay" json:"forceRelay"`
-	PublicNote      string         `codec:"publicNote" json:"publicNote"`
-	PublicNoteType  PublicNoteType `codec:"publicNoteType" json:"publicNoteType"`
-	FromAccountID   AccountID      `codec:"fromAccountID" json:"fromAccountID"`
+	Recipient       string            `codec:"recipient" json:"recipient"`
+	Amount          string            `codec:"amount" json:"amount"`
+	Asset           Asset             `codec:"asset" json:"asset"`
+	Note            string            `codec:"note" json:"note"`
+	DisplayAmount   string            `codec:"displayAmount" json:"displayAmount"`
+	DisplayCurrency string            `codec:"displayCurrency" json:"displayCurrency"`
+	ForceRelay      bool              `codec:"forceRelay" json:"forceRelay"`
+	PublicNote      string            `codec:"publicNote" json:"publicNote"`
+	PublicNoteType  PublicNoteType    `codec:"publicNoteType" json:"publicNoteType"`
+	FromAccountID   AccountID         `codec:"fromAccountID" json:"fromAccountID"`
+	RequestID       *KeybaseRequestID `codec:"requestID,omitempty" json:"requestID,omitempty"`
 }
 
 type SendPathCLILocalArg struct {
@@ -1666,6 +1931,14 @@ type RecentPaymentsCLILocalArg struct {
 	AccountID *AccountID `codec:"accountID,omitempty" json:"accountID,omitempty"`
 }
 
+type ExportHistoryCLILocalArg struct {
+	AccountID   *AccountID `codec:"accountID,omitempty" json:"accountID,omitempty"`
+	From        *TimeMs    `codec:"from,omitempty" json:"from,omitempty"`
+	To          *TimeMs    `codec:"to,omitempty" json:"to,omitempty"`
+	Currency    string     `codec:"currency" json:"currency"`
+	IncludeFees bool       `codec:"includeFees" json:"includeFees"`
+}
+
 type PaymentDetailCLILocalArg struct {
 	TxID string `codec:"txID" json:"txID"`
 }
@@ -1716,6 +1989,7 @@ type MakeRequestCLILocalArg struct {
 	Currency  *OutsideCurrencyCode `codec:"currency,omitempty" json:"currency,omitempty"`
 	Amount    string               `codec:"amount" json:"amount"`
 	Note      string               `codec:"note" json:"note"`
+	Invoice   *InvoiceContents     `codec:"invoice,omitempty" json:"invoice,omitempty"`
 }
 
 type LookupCLILocalArg struct {
@@ -1763,6 +2037,51 @@ type SignTransactionXdrLocalArg struct {
 	Submit      bool       `codec:"submit" json:"submit"`
 }
 
+type CosignSetupLocalArg struct {
+	AccountID       AccountID      `codec:"accountID" json:"accountID"`
+	MasterWeight    int            `codec:"masterWeight" json:"masterWeight"`
+	Signers         []CosignSigner `codec:"signers" json:"signers"`
+	LowThreshold    int            `codec:"lowThreshold" json:"lowThreshold"`
+	MediumThreshold int            `codec:"mediumThreshold" json:"mediumThreshold"`
+	HighThreshold   int            `codec:"highThreshold" json:"highThreshold"`
+	Channel         string         `codec:"channel" json:"channel"`
+}
+
+type CosignRequestLocalArg struct {
+	From       AccountID `codec:"from" json:"from"`
+	To         AccountID `codec:"to" json:"to"`
+	Amount     string    `codec:"amount" json:"amount"`
+	PublicMemo string    `codec:"publicMemo" json:"publicMemo"`
+	Channel    string    `codec:"channel" json:"channel"`
+}
+
+type CosignStatusLocalArg struct {
+	EnvelopeXdr string `codec:"envelopeXdr" json:"envelopeXdr"`
+}
+
+type CosignSignLocalArg struct {
+	EnvelopeXdr string `codec:"envelopeXdr" json:"envelopeXdr"`
+	Channel     string `codec:"channel" json:"channel"`
+}
+
+type SchedulePaymentLocalArg struct {
+	From       AccountID                  `codec:"from" json:"from"`
+	To         string                     `codec:"to" json:"to"`
+	Amount     string                     `codec:"amount" json:"amount"`
+	Currency   OutsideCurrencyCode        `codec:"currency" json:"currency"`
+	Note       string                     `codec:"note" json:"note"`
+	PublicMemo string                     `codec:"publicMemo" json:"publicMemo"`
+	Recurrence ScheduledPaymentRecurrence `codec:"recurrence" json:"recurrence"`
+	Start      TimeMs                     `codec:"start" json:"start"`
+}
+
+type ListScheduledPaymentsLocalArg struct {
+}
+
+type CancelScheduledPaymentLocalArg struct {
+	Id ScheduledPaymentID `codec:"id" json:"id"`
+}
+
 type GetStaticConfigLocalArg struct {
 }
 
@@ -1801,6 +2120,7 @@ type LocalInterface interface {
 	GetRequestDetailsLocal(context.Context, GetRequestDetailsLocalArg) (RequestDetailsLocal, error)
 	CancelRequestLocal(context.Context, CancelRequestLocalArg) error
 	MakeRequestLocal(context.Context, MakeRequestLocalArg) (KeybaseRequestID, error)
+	ListRequestsLocal(context.Context, ListRequestsLocalArg) ([]RequestDetailsLocal, error)
 	SetAccountMobileOnlyLocal(context.Context, SetAccountMobileOnlyLocalArg) error
 	SetAccountAllDevicesLocal(context.Context, SetAccountAllDevicesLocalArg) error
 	IsAccountMobileOnlyLocal(context.Context, IsAccountMobileOnlyLocalArg) (bool, error)
@@ -1827,6 +2147,7 @@ type LocalInterface interface {
 	AccountMergeCLILocal(context.Context, AccountMergeCLILocalArg) (TransactionID, error)
 	ClaimCLILocal(context.Context, ClaimCLILocalArg) (RelayClaimResult, error)
 	RecentPaymentsCLILocal(context.Context, *AccountID) ([]PaymentOrErrorCLILocal, error)
+	ExportHistoryCLILocal(context.Context, ExportHistoryCLILocalArg) ([]PaymentExportCLILocal, error)
 	PaymentDetailCLILocal(context.Context, string) (PaymentCLILocal, error)
 	WalletInitLocal(context.Context) error
 	WalletDumpLocal(context.Context) (Bundle, error)
@@ -1847,6 +2168,13 @@ type LocalInterface interface {
 	ApprovePathURILocal(context.Context, ApprovePathURILocalArg) (TransactionID, error)
 	GetPartnerUrlsLocal(context.Context, int) ([]PartnerUrl, error)
 	SignTransactionXdrLocal(context.Context, SignTransactionXdrLocalArg) (SignXdrResult, error)
+	CosignSetupLocal(context.Context, CosignSetupLocalArg) (CosignResultLocal, error)
+	CosignRequestLocal(context.Context, CosignRequestLocalArg) (CosignResultLocal, error)
+	CosignStatusLocal(context.Context, string) (CosignStatusLocal, error)
+	CosignSignLocal(context.Context, CosignSignLocalArg) (CosignResultLocal, error)
+	SchedulePaymentLocal(context.Context, SchedulePaymentLocalArg) (ScheduledPaymentLocal, error)
+	ListScheduledPaymentsLocal(context.Context) ([]ScheduledPaymentLocal, error)
+	CancelScheduledPaymentLocal(context.Context, ScheduledPaymentID) error
 	GetStaticConfigLocal(context.Context) (StaticConfig, error)
 }
 
@@ -2364,6 +2692,21 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"listRequestsLocal": {
+				MakeArg: func() any {
+					var ret [1]ListRequestsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]ListRequestsLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ListRequestsLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.ListRequestsLocal(ctx, typedArgs[0])
+					return
+				},
+			},
 			"setAccountMobileOnlyLocal": {
 				MakeArg: func() any {
 					var ret [1]SetAccountMobileOnlyLocalArg
@@ -2754,6 +3097,21 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"exportHistoryCLILocal": {
+				MakeArg: func() any {
+					var ret [1]ExportHistoryCLILocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]ExportHistoryCLILocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ExportHistoryCLILocalArg)(nil), args)
+						return
+					}
+					ret, err = i.ExportHistoryCLILocal(ctx, typedArgs[0])
+					return
+				},
+			},
 			"paymentDetailCLILocal": {
 				MakeArg: func() any {
 					var ret [1]PaymentDetailCLILocalArg
@@ -3034,6 +3392,106 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"cosignSetupLocal": {
+				MakeArg: func() any {
+					var ret [1]CosignSetupLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CosignSetupLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CosignSetupLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.CosignSetupLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"cosignRequestLocal": {
+				MakeArg: func() any {
+					var ret [1]CosignRequestLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CosignRequestLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CosignRequestLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.CosignRequestLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"cosignStatusLocal": {
+				MakeArg: func() any {
+					var ret [1]CosignStatusLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CosignStatusLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CosignStatusLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.CosignStatusLocal(ctx, typedArgs[0].EnvelopeXdr)
+					return
+				},
+			},
+			"cosignSignLocal": {
+				MakeArg: func() any {
+					var ret [1]CosignSignLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CosignSignLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CosignSignLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.CosignSignLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"schedulePaymentLocal": {
+				MakeArg: func() any {
+					var ret [1]SchedulePaymentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]SchedulePaymentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SchedulePaymentLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.SchedulePaymentLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"listScheduledPaymentsLocal": {
+				MakeArg: func() any {
+					var ret [1]ListScheduledPaymentsLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					ret, err = i.ListScheduledPaymentsLocal(ctx)
+					return
+				},
+			},
+			"cancelScheduledPaymentLocal": {
+				MakeArg: func() any {
+					var ret [1]CancelScheduledPaymentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]CancelScheduledPaymentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]CancelScheduledPaymentLocalArg)(nil), args)
+						return
+					}
+					err = i.CancelScheduledPaymentLocal(ctx, typedArgs[0].Id)
+					return
+				},
+			},
 			"getStaticConfigLocal": {
 				MakeArg: func() any {
 					var ret [1]GetStaticConfigLocalArg
@@ -3227,6 +3685,11 @@ func (c LocalClient) MakeRequestLocal(ctx context.Context, __arg MakeRequestLoca
 	return
 }
 
+func (c LocalClient) ListRequestsLocal(ctx context.Context, __arg ListRequestsLocalArg) (res []RequestDetailsLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.listRequestsLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) SetAccountMobileOnlyLocal(ctx context.Context, __arg SetAccountMobileOnlyLocalArg) (err error) {
 	err = c.Cli.Call(ctx, "stellar.1.local.setAccountMobileOnlyLocal", []any{__arg}, nil, 0*time.Millisecond)
 	return
@@ -3363,6 +3826,11 @@ func (c LocalClient) RecentPaymentsCLILocal(ctx context.Context, accountID *Acco
 	return
 }
 
+func (c LocalClient) ExportHistoryCLILocal(ctx context.Context, __arg ExportHistoryCLILocalArg) (res []PaymentExportCLILocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.exportHistoryCLILocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) PaymentDetailCLILocal(ctx context.Context, txID string) (res PaymentCLILocal, err error) {
 	__arg := PaymentDetailCLILocalArg{TxID: txID}
 	err = c.Cli.Call(ctx, "stellar.1.local.paymentDetailCLILocal", []any{__arg}, &res, 0*time.Millisecond)
@@ -3469,6 +3937,43 @@ func (c LocalClient) SignTransactionXdrLocal(ctx context.Context, __arg SignTran
 	return
 }
 
+func (c LocalClient) CosignSetupLocal(ctx context.Context, __arg CosignSetupLocalArg) (res CosignResultLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.cosignSetupLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) CosignRequestLocal(ctx context.Context, __arg CosignRequestLocalArg) (res CosignResultLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.cosignRequestLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) CosignStatusLocal(ctx context.Context, envelopeXdr string) (res CosignStatusLocal, err error) {
+	__arg := CosignStatusLocalArg{EnvelopeXdr: envelopeXdr}
+	err = c.Cli.Call(ctx, "stellar.1.local.cosignStatusLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) CosignSignLocal(ctx context.Context, __arg CosignSignLocalArg) (res CosignResultLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.cosignSignLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) SchedulePaymentLocal(ctx context.Context, __arg SchedulePaymentLocalArg) (res ScheduledPaymentLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.schedulePaymentLocal", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) ListScheduledPaymentsLocal(ctx context.Context) (res []ScheduledPaymentLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.listScheduledPaymentsLocal", []any{ListScheduledPaymentsLocalArg{}}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) CancelScheduledPaymentLocal(ctx context.Context, id ScheduledPaymentID) (err error) {
+	__arg := CancelScheduledPaymentLocalArg{Id: id}
+	err = c.Cli.Call(ctx, "stellar.1.local.cancelScheduledPaymentLocal", []any{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) GetStaticConfigLocal(ctx context.Context) (res StaticConfig, err error) {
 	err = c.Cli.Call(ctx, "stellar.1.local.getStaticConfigLocal", []any{GetStaticConfigLocalArg{}}, &res, 0*time.Millisecond)
 	return
@@ -43,6 +43,10 @@ type RecentPaymentsUpdateArg struct {
 	FirstPage PaymentsPageLocal `codec:"firstPage" json:"firstPage"`
 }
 
+type ScheduledPaymentNotificationArg struct {
+	Payment ScheduledPaymentLocal `codec:"payment" json:"payment"`
+}
+
 type NotifyInterface interface {
 	PaymentNotification(context.Context, PaymentNotificationArg) error
 	PaymentStatusNotification(context.Context, PaymentStatusNotificationArg) error
@@ -51,6 +55,7 @@ type NotifyInterface interface {
 	AccountsUpdate(context.Context, []WalletAccountLocal) error
 	PendingPaymentsUpdate(context.Context, PendingPaymentsUpdateArg) error
 	RecentPaymentsUpdate(context.Context, RecentPaymentsUpdateArg) error
+	ScheduledPaymentNotification(context.Context, ScheduledPaymentLocal) error
 }
 
 func NotifyProtocol(i NotifyInterface) rpc.Protocol {
@@ -162,6 +167,21 @@ func NotifyProtocol(i NotifyInterface) rpc.Protocol {
 					return
 				},
 			},
+			"scheduledPaymentNotification": {
+				MakeArg: func() any {
+					var ret [1]ScheduledPaymentNotificationArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]ScheduledPaymentNotificationArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ScheduledPaymentNotificationArg)(nil), args)
+						return
+					}
+					err = i.ScheduledPaymentNotification(ctx, typedArgs[0].Payment)
+					return
+				},
+			},
 		},
 	}
 }
@@ -206,3 +226,9 @@ func (c NotifyClient) RecentPaymentsUpdate(ctx context.Context, __arg RecentPaym
 	err = c.Cli.Notify(ctx, "stellar.1.notify.recentPaymentsUpdate", []any{__arg}, 0*time.Millisecond)
 	return
 }
+
+func (c NotifyClient) ScheduledPaymentNotification(ctx context.Context, payment ScheduledPaymentLocal) (err error) {
+	__arg := ScheduledPaymentNotificationArg{Payment: payment}
+	err = c.Cli.Notify(ctx, "stellar.1.notify.scheduledPaymentNotification", []any{__arg}, 0*time.Millisecond)
+	return
+}
@@ -29,6 +29,7 @@ type PaymentDirectPost struct {
 	QuickReturn        bool                  `codec:"quickReturn" json:"quickReturn"`
 	ChatConversationID *ChatConversationID   `codec:"chatConversationID,omitempty" json:"chatConversationID,omitempty"`
 	BatchID            string                `codec:"batchID" json:"batchID"`
+	RequestID          *KeybaseRequestID     `codec:"requestID,omitempty" json:"requestID,omitempty"`
 }
 
 func (o PaymentDirectPost) DeepCopy() PaymentDirectPost {
@@ -54,6 +55,13 @@ func (o PaymentDirectPost) DeepCopy() PaymentDirectPost {
 			return &tmp
 		})(o.ChatConversationID),
 		BatchID: o.BatchID,
+		RequestID: (func(x *KeybaseRequestID) *KeybaseRequestID {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.RequestID),
 	}
 }
 
@@ -70,6 +78,7 @@ type PaymentRelayPost struct {
 	QuickReturn        bool                  `codec:"quickReturn" json:"quickReturn"`
 	ChatConversationID *ChatConversationID   `codec:"chatConversationID,omitempty" json:"chatConversationID,omitempty"`
 	BatchID            string                `codec:"batchID" json:"batchID"`
+	RequestID          *KeybaseRequestID     `codec:"requestID,omitempty" json:"requestID,omitempty"`
 }
 
 func (o PaymentRelayPost) DeepCopy() PaymentRelayPost {
@@ -98,6 +107,13 @@ func (o PaymentRelayPost) DeepCopy() PaymentRelayPost {
 			return &tmp
 		})(o.ChatConversationID),
 		BatchID: o.BatchID,
+		RequestID: (func(x *KeybaseRequestID) *KeybaseRequestID {
+			if x == nil {
+				return nil
+			}
+			tmp := x.DeepCopy()
+			return &tmp
+		})(o.RequestID),
 	}
 }
 
@@ -769,6 +785,7 @@ type RequestPost struct {
 	Amount      string                `codec:"amount" json:"amount"`
 	Asset       *Asset                `codec:"asset,omitempty" json:"asset,omitempty"`
 	Currency    *OutsideCurrencyCode  `codec:"currency,omitempty" json:"currency,omitempty"`
+	InvoiceB64  string                `codec:"invoiceB64" json:"invoiceB64"`
 }
 
 func (o RequestPost) DeepCopy() RequestPost {
@@ -796,6 +813,7 @@ func (o RequestPost) DeepCopy() RequestPost {
 			tmp := x.DeepCopy()
 			return &tmp
 		})(o.Currency),
+		InvoiceB64: o.InvoiceB64,
 	}
 }
 
@@ -813,6 +831,9 @@ type RequestDetails struct {
 	ToDisplayCurrency   string                `codec:"toDisplayCurrency" json:"toDisplayCurrency"`
 	FundingKbTxID       KeybaseTransactionID  `codec:"fundingKbTxID" json:"fundingKbTxID"`
 	Status              RequestStatus         `codec:"status" json:"status"`
+	InvoiceB64          string                `codec:"invoiceB64" json:"invoiceB64"`
+	Payments            []RequestPayment      `codec:"payments" json:"payments"`
+	Ctime               TimeMs                `codec:"ctime" json:"ctime"`
 }
 
 func (o RequestDetails) DeepCopy() RequestDetails {
@@ -848,6 +869,19 @@ func (o RequestDetails) DeepCopy() RequestDetails {
 		ToDisplayCurrency:   o.ToDisplayCurrency,
 		FundingKbTxID:       o.FundingKbTxID.DeepCopy(),
 		Status:              o.Status.DeepCopy(),
+		InvoiceB64:          o.InvoiceB64,
+		Payments: (func(x []RequestPayment) []RequestPayment {
+			if x == nil {
+				return nil
+			}
+			ret := make([]RequestPayment, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.Payments),
+		Ctime: o.Ctime.DeepCopy(),
 	}
 }
 
@@ -1013,6 +1047,11 @@ type RequestDetailsArg struct {
 	ReqID  KeybaseRequestID     `codec:"reqID" json:"reqID"`
 }
 
+type RequestsArg struct {
+	Caller        keybase1.UserVersion `codec:"caller" json:"caller"`
+	IncludeClosed bool                 `codec:"includeClosed" json:"includeClosed"`
+}
+
 type CancelRequestArg struct {
 	Caller keybase1.UserVersion `codec:"caller" json:"caller"`
 	ReqID  KeybaseRequestID     `codec:"reqID" json:"reqID"`
@@ -1088,6 +1127,7 @@ type RemoteInterface interface {
 	IsMasterKeyActive(context.Context, IsMasterKeyActiveArg) (bool, error)
 	SubmitRequest(context.Context, SubmitRequestArg) (KeybaseRequestID, error)
 	RequestDetails(context.Context, RequestDetailsArg) (RequestDetails, error)
+	Requests(context.Context, RequestsArg) ([]RequestDetails, error)
 	CancelRequest(context.Context, CancelRequestArg) error
 	SetInflationDestination(context.Context, SetInflationDestinationArg) error
 	Ping(context.Context) (string, error)
@@ -1376,6 +1416,21 @@ func RemoteProtocol(i RemoteInterface) rpc.Protocol {
 					return
 				},
 			},
+			"requests": {
+				MakeArg: func() any {
+					var ret [1]RequestsArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args any) (ret any, err error) {
+					typedArgs, ok := args.(*[1]RequestsArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RequestsArg)(nil), args)
+						return
+					}
+					ret, err = i.Requests(ctx, typedArgs[0])
+					return
+				},
+			},
 			"cancelRequest": {
 				MakeArg: func() any {
 					var ret [1]CancelRequestArg
@@ -1651,6 +1706,11 @@ func (c RemoteClient) RequestDetails(ctx context.Context, __arg RequestDetailsAr
 	return
 }
 
+func (c RemoteClient) Requests(ctx context.Context, __arg RequestsArg) (res []RequestDetails, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.remote.requests", []any{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c RemoteClient) CancelRequest(ctx context.Context, __arg CancelRequestArg) (err error) {
 	err = c.Cli.Call(ctx, "stellar.1.remote.cancelRequest", []any{__arg}, nil, 0*time.Millisecond)
 	return
@@ -0,0 +1,31 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package qrcode
+
+import (
+	"image"
+	_ "image/png" // register the PNG decoder
+	"io"
+
+	"github.com/makiuchi-d/gozxing"
+	gozxingqr "github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// Decode reads an image containing a single QR code (for example the PNG
+// produced by Encode, or a screenshot of one) and returns its contents.
+func Decode(r io.Reader) ([]byte, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, err
+	}
+	res, err := gozxingqr.NewQRCodeReader().Decode(bmp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.GetText()), nil
+}
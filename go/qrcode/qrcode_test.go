@@ -0,0 +1,27 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package qrcode
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		"aaaaaaaaaaaaaaaaa",
+		"keybase://team-invite?token=aaaaaaaaaaaaaaaaa&team=acme.ops&role=writer&expires=1790000000",
+	} {
+		enc, err := Encode([]byte(data))
+		require.NoError(t, err)
+		dec, err := Decode(bytes.NewReader(enc.PNG))
+		require.NoError(t, err)
+		require.Equal(t, data, string(dec))
+	}
+
+	_, err := Decode(bytes.NewReader([]byte("not a png")))
+	require.Error(t, err)
+}
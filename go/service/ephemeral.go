@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/teams"
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+)
+
+type EphemeralHandler struct {
+	libkb.Contextified
+	*BaseHandler
+}
+
+func NewEphemeralHandler(xp rpc.Transporter, g *libkb.GlobalContext) *EphemeralHandler {
+	return &EphemeralHandler{
+		Contextified: libkb.NewContextified(g),
+		BaseHandler:  NewBaseHandler(g, xp),
+	}
+}
+
+var _ keybase1.EphemeralInterface = (*EphemeralHandler)(nil)
+
+func (h *EphemeralHandler) ekLib(mctx libkb.MetaContext) (libkb.EKLib, error) {
+	ekLib := mctx.G().GetEKLib()
+	if ekLib == nil {
+		return nil, libkb.NewLoginRequiredError("ephemeral keys are not available")
+	}
+	return ekLib, nil
+}
+
+// fillTeamNames resolves team names for display, leaving the name empty if
+// we can't load the team (e.g. we were removed from it).
+func (h *EphemeralHandler) fillTeamNames(mctx libkb.MetaContext, storages []keybase1.EkStorageStatus) {
+	for i, storage := range storages {
+		if storage.TeamID.IsNil() {
+			continue
+		}
+		name, err := teams.ResolveIDToName(mctx.Ctx(), mctx.G(), storage.TeamID)
+		if err != nil {
+			mctx.Debug("EphemeralHandler: unable to resolve team name for %v: %v", storage.TeamID, err)
+			continue
+		}
+		storages[i].TeamName = name.String()
+	}
+}
+
+func (h *EphemeralHandler) GetEkStatus(ctx context.Context, sessionID int) (res []keybase1.EkStorageStatus, err error) {
+	mctx := libkb.NewMetaContext(ctx, h.G())
+	defer mctx.Trace("EphemeralHandler#GetEkStatus", &err)()
+	ekLib, err := h.ekLib(mctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err = ekLib.Status(mctx)
+	if err != nil {
+		return nil, err
+	}
+	h.fillTeamNames(mctx, res)
+	return res, nil
+}
+
+func (h *EphemeralHandler) RepairEks(ctx context.Context, arg keybase1.RepairEksArg) (res keybase1.EkRepairRes, err error) {
+	mctx := libkb.NewMetaContext(ctx, h.G())
+	defer mctx.Trace(fmt.Sprintf("EphemeralHandler#RepairEks: force:%v", arg.Force), &err)()
+	ekLib, err := h.ekLib(mctx)
+	if err != nil {
+		return res, err
+	}
+	res, err = ekLib.Repair(mctx, arg.Force)
+	if err != nil {
+		return res, err
+	}
+	h.fillTeamNames(mctx, res.Storages)
+	return res, nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/keybase/client/go/chat/types"
 	"github.com/keybase/client/go/chat/unfurl"
 	"github.com/keybase/client/go/chat/wallet"
+	"github.com/keybase/client/go/chat/webhooks"
 	"github.com/keybase/client/go/contacts"
 	"github.com/keybase/client/go/engine"
 	"github.com/keybase/client/go/ephemeral"
@@ -193,6 +194,7 @@ func (d *Service) RegisterProtocols(srv *rpc.Server, xp rpc.Transporter, connID
 		keybase1.InviteFriendsProtocol(NewInviteFriendsHandler(xp, g)),
 		keybase1.Identify3Protocol(newIdentify3Handler(xp, g)),
 		keybase1.AuditProtocol(NewAuditHandler(xp, g)),
+		keybase1.EphemeralProtocol(NewEphemeralHandler(xp, g)),
 		keybase1.UserSearchProtocol(NewUserSearchHandler(xp, g, contactsProv)),
 		keybase1.BotProtocol(NewBotHandler(xp, g)),
 		keybase1.FeaturedBotProtocol(NewFeaturedBotHandler(xp, g)),
@@ -383,6 +385,7 @@ func (d *Service) RunBackgroundOperations(uir *UIRouter) {
 	d.runBackgroundPerUserKeyUpgrade()
 	d.runBackgroundPerUserKeyUpkeep()
 	d.runBackgroundWalletUpkeep()
+	d.runBackgroundWalletScheduledPayments()
 	d.runBackgroundBoxAuditRetry()
 	d.runBackgroundBoxAuditScheduler()
 	d.runBackgroundContactSync()
@@ -485,6 +488,8 @@ func (d *Service) startChatModules() {
 		g.LiveLocationTracker.Start(context.Background(), uid)
 		g.BotCommandManager.Start(context.Background(), uid)
 		g.UIInboxLoader.Start(context.Background(), uid)
+		g.WebhookDispatcher.Start(context.Background(), uid)
+		g.WebhookServer.Start(context.Background(), uid)
 		g.PushShutdownHook(d.stopChatModules)
 	}
 	d.purgeOldChatAttachmentData()
@@ -507,6 +512,8 @@ func (d *Service) stopChatModules(m libkb.MetaContext) error {
 	<-d.ChatG().BotCommandManager.Stop(m.Ctx())
 	<-d.ChatG().UIInboxLoader.Stop(m.Ctx())
 	<-d.ChatG().JourneyCardManager.Stop(m.Ctx())
+	<-d.ChatG().WebhookDispatcher.Stop(m.Ctx())
+	<-d.ChatG().WebhookServer.Stop(m.Ctx())
 	return nil
 }
 
@@ -582,8 +589,9 @@ func (d *Service) SetupChatModules(ri func() chat1.RemoteInterface) {
 
 	convStorage := chat.NewDevConversationBackedStorage(g, ri)
 
-	g.Unfurler = unfurl.NewUnfurler(g, store, s3signer, convStorage, chat.NewNonblockingSender(g, sender),
-		ri)
+	g.TeamUnfurlPolicySource = chat.NewTeamUnfurlPolicySource(g, ri)
+	g.Unfurler = unfurl.NewUnfurler(g, store, s3signer, convStorage, g.TeamUnfurlPolicySource,
+		chat.NewNonblockingSender(g, sender), ri)
 	g.CommandsSource = commands.NewSource(g)
 	g.CoinFlipManager = chat.NewFlipManager(g, ri)
 	g.JourneyCardManager = chat.NewJourneyCardManager(g, ri)
@@ -599,6 +607,11 @@ func (d *Service) SetupChatModules(ri func() chat1.RemoteInterface) {
 	g.UIThreadLoader = chat.NewUIThreadLoader(g, ri)
 	g.ParticipantsSource = chat.NewCachingParticipantSource(g, ri)
 	g.EmojiSource = chat.NewDevConvEmojiSource(g, ri)
+	g.WebhookDispatcher = webhooks.NewDispatcher(g)
+	g.WebhookServer = webhooks.NewServer(g, sender)
+	g.ReplyThreadSource = chat.NewReplyThreadSource(g)
+	g.BookmarkManager = chat.NewBookmarkManager(g, convStorage)
+	g.HighlightManager = chat.NewHighlightManager(g, convStorage)
 
 	// Set up Offlinables on Syncer
 	chatSyncer.RegisterOfflinable(g.InboxSource)
@@ -934,6 +947,23 @@ func (d *Service) runBackgroundWalletUpkeep() {
 	})
 }
 
+func (d *Service) runBackgroundWalletScheduledPayments() {
+	eng := engine.NewWalletScheduledPaymentsBackground(d.G(), &engine.WalletScheduledPaymentsBackgroundArgs{})
+	go func() {
+		m := libkb.NewMetaContextBackground(d.G())
+		err := engine.RunEngine2(m, eng)
+		if err != nil {
+			m.Warning("background WalletScheduledPayments error: %v", err)
+		}
+	}()
+
+	d.G().PushShutdownHook(func(mctx libkb.MetaContext) error {
+		d.G().Log.Debug("stopping background WalletScheduledPayments")
+		eng.Shutdown()
+		return nil
+	})
+}
+
 func (d *Service) runBackgroundBoxAuditRetry() {
 	eng := engine.NewBoxAuditRetryBackground(d.G())
 	go func() {
@@ -47,3 +47,18 @@ func (h *TeamSearchHandler) TeamSearch(ctx context.Context, arg keybase1.TeamSea
 	res.Results = hits
 	return res, nil
 }
+
+func (h *TeamSearchHandler) TeamSearchDirectory(ctx context.Context, arg keybase1.TeamSearchDirectoryArg) (res keybase1.TeamSearchRes, err error) {
+	ctx = libkb.WithLogTag(ctx, "TS")
+	if err := assertLoggedIn(ctx, h.G()); err != nil {
+		return res, err
+	}
+
+	mctx := libkb.NewMetaContext(ctx, h.G())
+	hits, err := opensearch.Directory(mctx, arg)
+	if err != nil {
+		return res, err
+	}
+	res.Results = hits
+	return res, nil
+}
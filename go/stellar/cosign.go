@@ -0,0 +1,515 @@
+package stellar
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/chat1"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+	"github.com/keybase/stellarnet"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+// Multisig accounts have several signers that each carry a weight. A
+// transaction is accepted by the network once the weights of its valid
+// signatures reach the account's threshold for the transaction's operations.
+// The keybase bundle only ever holds one signer per account, so the other
+// signatures are collected by passing the envelope around over chat.
+
+// maxCosignWeight is the largest weight or threshold stellar accepts.
+const maxCosignWeight = 255
+
+// cosignTimeout is how long a transaction stays valid while its signatures are
+// collected over chat. The envelope is built for the next sequence number of
+// its account, so any other transaction from the account in the meantime makes
+// it invalid, and signers are told to ask for a new one.
+const cosignTimeout = 7 * 24 * time.Hour
+
+// CosignSetup replaces the extra signers of one of the user's accounts and
+// sets its thresholds.
+func CosignSetup(mctx libkb.MetaContext, walletState *WalletState, arg stellar1.CosignSetupLocalArg) (res stellar1.CosignResultLocal, err error) {
+	defer mctx.Trace(fmt.Sprintf("Stellar.CosignSetup(%v)", arg.AccountID), &err)()
+
+	for _, w := range []int{arg.MasterWeight, arg.LowThreshold, arg.MediumThreshold, arg.HighThreshold} {
+		if w < 0 || w > maxCosignWeight {
+			return res, fmt.Errorf("weights and thresholds must be between 0 and %d", maxCosignWeight)
+		}
+	}
+	total := arg.MasterWeight
+	newSigners := make(map[stellar1.AccountID]bool)
+	for _, signer := range arg.Signers {
+		if signer.Weight <= 0 || signer.Weight > maxCosignWeight {
+			return res, fmt.Errorf("signer %v: weight must be between 1 and %d", signer.AccountID, maxCosignWeight)
+		}
+		if signer.AccountID.Eq(arg.AccountID) {
+			return res, errors.New("the account's own key is set with the master weight, not as a signer")
+		}
+		if newSigners[signer.AccountID] {
+			return res, fmt.Errorf("duplicate signer %v", signer.AccountID)
+		}
+		newSigners[signer.AccountID] = true
+		total += signer.Weight
+	}
+	// Guard against locking the account forever.
+	if total < arg.HighThreshold || total < arg.MediumThreshold || total < arg.LowThreshold {
+		return res, fmt.Errorf("signers have a total weight of %d which would not reach the thresholds", total)
+	}
+
+	current, err := walletState.AccountSigners(mctx, arg.AccountID)
+	if err != nil {
+		return res, err
+	}
+	var ops []xdr.Operation
+	for _, signer := range current.Signers {
+		if signer.AccountID.Eq(arg.AccountID) || newSigners[signer.AccountID] {
+			continue
+		}
+		op, err := cosignSignerOp(signer.AccountID, 0)
+		if err != nil {
+			return res, err
+		}
+		ops = append(ops, op)
+	}
+	for _, signer := range arg.Signers {
+		op, err := cosignSignerOp(signer.AccountID, signer.Weight)
+		if err != nil {
+			return res, err
+		}
+		ops = append(ops, op)
+	}
+	master := xdr.Uint32(arg.MasterWeight) //nolint:gosec // G115: Checked above
+	low := xdr.Uint32(arg.LowThreshold)    //nolint:gosec // G115: Checked above
+	med := xdr.Uint32(arg.MediumThreshold) //nolint:gosec // G115: Checked above
+	high := xdr.Uint32(arg.HighThreshold)  //nolint:gosec // G115: Checked above
+	body, err := xdr.NewOperationBody(xdr.OperationTypeSetOptions, xdr.SetOptionsOp{
+		MasterWeight:  &master,
+		LowThreshold:  &low,
+		MedThreshold:  &med,
+		HighThreshold: &high,
+	})
+	if err != nil {
+		return res, err
+	}
+	ops = append(ops, xdr.Operation{Body: body})
+
+	env, err := cosignBuildTx(mctx, walletState, arg.AccountID, ops, xdr.Memo{Type: xdr.MemoTypeMemoNone})
+	if err != nil {
+		return res, err
+	}
+	return cosignFinish(mctx, walletState, env, current, arg.Channel)
+}
+
+func cosignSignerOp(accountID stellar1.AccountID, weight int) (op xdr.Operation, err error) {
+	var key xdr.SignerKey
+	if err := key.SetAddress(accountID.String()); err != nil {
+		return op, fmt.Errorf("invalid signer %v: %v", accountID, err)
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypeSetOptions, xdr.SetOptionsOp{
+		Signer: &xdr.Signer{
+			Key:    key,
+			Weight: xdr.Uint32(weight), //nolint:gosec // G115: Weights are checked by the caller
+		},
+	})
+	if err != nil {
+		return op, err
+	}
+	return xdr.Operation{Body: body}, nil
+}
+
+// cosignBuildTx makes a transaction from `source` out of `ops` and signs it
+// with the user's key for `source`.
+func cosignBuildTx(mctx libkb.MetaContext, walletState *WalletState, source stellar1.AccountID,
+	ops []xdr.Operation, memo xdr.Memo,
+) (env xdr.TransactionEnvelope, err error) {
+	_, senderAccountBundle, err := LookupSender(mctx, source)
+	if err != nil {
+		return env, err
+	}
+	senderSeed, err := stellarnet.NewSeedStr(senderAccountBundle.Signers[0].SecureNoLogString())
+	if err != nil {
+		return env, err
+	}
+
+	sp, unlock := NewSeqnoProvider(mctx, walletState)
+	defer unlock()
+	seqno, err := sp.SequenceForAccount(source.String())
+	if err != nil {
+		return env, err
+	}
+	tb, err := cosignTimebounds(mctx, walletState)
+	if err != nil {
+		return env, err
+	}
+
+	var tx xdr.Transaction
+	if err := tx.SourceAccount.SetAddress(source.String()); err != nil {
+		return env, err
+	}
+	tx.SeqNum = seqno + 1
+	tx.Fee = xdr.Uint32(walletState.BaseFee(mctx) * uint64(len(ops))) //nolint:gosec // G115: Fees are far below the limit
+	tx.TimeBounds = &tb
+	tx.Memo = memo
+	tx.Operations = ops
+
+	return cosignAddSignature(senderSeed, xdr.TransactionEnvelope{Tx: tx})
+}
+
+// cosignTimebounds lets a transaction wait cosignTimeout for its other
+// signers, instead of the short deadline of payments we submit right away.
+// Like getTimeboundsForSending it goes by the server's clock.
+func cosignTimebounds(mctx libkb.MetaContext, walletState *WalletState) (tb xdr.TimeBounds, err error) {
+	serverTimes, err := walletState.ServerTimeboundsRecommendation(mctx.Ctx())
+	if err != nil {
+		return tb, err
+	}
+	if serverTimes.TimeNow == 0 {
+		return tb, errors.New("invalid server response for transaction timebounds")
+	}
+	deadline := serverTimes.TimeNow.Time().Add(cosignTimeout).Unix()
+	tb.MaxTime = xdr.TimePoint(deadline) //nolint:gosec // G115: Unix timestamp plus timeout, safe to convert
+	return tb, nil
+}
+
+func cosignAddSignature(seed stellarnet.SeedStr, env xdr.TransactionEnvelope) (res xdr.TransactionEnvelope, err error) {
+	signed, err := stellarnet.SignEnvelope(seed, env)
+	if err != nil {
+		return res, err
+	}
+	err = xdr.SafeUnmarshalBase64(signed.Signed, &res)
+	return res, err
+}
+
+// CosignRequest builds an XLM payment from a multisig account owned by the
+// user and signs it with the user's key.
+func CosignRequest(mctx libkb.MetaContext, walletState *WalletState, arg stellar1.CosignRequestLocalArg) (res stellar1.CosignResultLocal, err error) {
+	defer mctx.Trace(fmt.Sprintf("Stellar.CosignRequest(%v -> %v)", arg.From, arg.To), &err)()
+
+	to, err := stellarnet.NewAddressStr(arg.To.String())
+	if err != nil {
+		return res, fmt.Errorf("invalid recipient: %v", err)
+	}
+	var destination xdr.AccountId
+	if err := destination.SetAddress(to.String()); err != nil {
+		return res, err
+	}
+	amount, err := stellarnet.ParseStellarAmount(arg.Amount)
+	if err != nil {
+		return res, fmt.Errorf("invalid amount %q: %v", arg.Amount, err)
+	}
+	if amount <= 0 {
+		return res, fmt.Errorf("amount must be positive: %q", arg.Amount)
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypePayment, xdr.PaymentOp{
+		Destination: destination,
+		Asset:       xdr.Asset{Type: xdr.AssetTypeAssetTypeNative},
+		Amount:      xdr.Int64(amount),
+	})
+	if err != nil {
+		return res, err
+	}
+
+	memo := xdr.Memo{Type: xdr.MemoTypeMemoNone}
+	if len(arg.PublicMemo) > 28 {
+		return res, errors.New("public memo is too long, the maximum is 28 bytes")
+	}
+	if arg.PublicMemo != "" {
+		memo, err = xdr.NewMemo(xdr.MemoTypeMemoText, arg.PublicMemo)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	signers, err := walletState.AccountSigners(mctx, arg.From)
+	if err != nil {
+		return res, err
+	}
+	env, err := cosignBuildTx(mctx, walletState, arg.From, []xdr.Operation{{Body: body}}, memo)
+	if err != nil {
+		return res, err
+	}
+	return cosignFinish(mctx, walletState, env, signers, arg.Channel)
+}
+
+// CosignSign adds the signatures of all of the user's accounts that are
+// signers of the envelope's source account.
+func CosignSign(mctx libkb.MetaContext, walletState *WalletState, envelopeXdr string, channel string) (res stellar1.CosignResultLocal, err error) {
+	defer mctx.Trace("Stellar.CosignSign", &err)()
+
+	env, err := cosignUnpack(envelopeXdr)
+	if err != nil {
+		return res, err
+	}
+	source := stellar1.AccountID(env.Tx.SourceAccount.Address())
+	signers, err := walletState.AccountSigners(mctx, source)
+	if err != nil {
+		return res, err
+	}
+	status, err := CosignEnvelopeStatus(env, signers)
+	if err != nil {
+		return res, err
+	}
+	signed := make(map[stellar1.AccountID]bool)
+	for _, accountID := range status.Signed {
+		signed[accountID] = true
+	}
+	isSigner := make(map[stellar1.AccountID]bool)
+	for _, signer := range signers.Signers {
+		isSigner[signer.AccountID] = true
+	}
+
+	bundle, err := remote.FetchSecretlessBundle(mctx)
+	if err != nil {
+		return res, err
+	}
+	var mine []stellar1.AccountID
+	for _, entry := range bundle.Accounts {
+		if isSigner[entry.AccountID] {
+			mine = append(mine, entry.AccountID)
+		}
+	}
+	if len(mine) == 0 {
+		return res, fmt.Errorf("none of your accounts are signers of %v", source)
+	}
+	if err := cosignCheckSeqno(env, signers); err != nil {
+		return res, err
+	}
+	var added int
+	for _, accountID := range mine {
+		if signed[accountID] {
+			continue
+		}
+		_, accountBundle, err := LookupSender(mctx, accountID)
+		if err != nil {
+			return res, err
+		}
+		seed, err := stellarnet.NewSeedStr(accountBundle.Signers[0].SecureNoLogString())
+		if err != nil {
+			return res, err
+		}
+		if env, err = cosignAddSignature(seed, env); err != nil {
+			return res, err
+		}
+		mctx.Debug("CosignSign: signed %v with %v", status.TxID, accountID)
+		added++
+	}
+	if added == 0 && !status.Ready {
+		return res, errors.New("you have already signed this transaction")
+	}
+	return cosignFinish(mctx, walletState, env, signers, channel)
+}
+
+// CosignStatus describes an envelope and the signatures collected so far.
+func CosignStatus(mctx libkb.MetaContext, walletState *WalletState, envelopeXdr string) (res stellar1.CosignStatusLocal, err error) {
+	env, err := cosignUnpack(envelopeXdr)
+	if err != nil {
+		return res, err
+	}
+	signers, err := walletState.AccountSigners(mctx, stellar1.AccountID(env.Tx.SourceAccount.Address()))
+	if err != nil {
+		return res, err
+	}
+	return CosignEnvelopeStatus(env, signers)
+}
+
+// cosignCheckSeqno makes sure the envelope can still go through, which it
+// can't once its account has had another transaction since it was built.
+func cosignCheckSeqno(env xdr.TransactionEnvelope, signers remote.AccountSigners) error {
+	if uint64(env.Tx.SeqNum) <= signers.Seqno { //nolint:gosec // G115: Sequence numbers are positive
+		return fmt.Errorf("%v has made another transaction since this one was built, so it can no longer be submitted; ask for a new one",
+			env.Tx.SourceAccount.Address())
+	}
+	return nil
+}
+
+func cosignUnpack(envelopeXdr string) (env xdr.TransactionEnvelope, err error) {
+	err = xdr.SafeUnmarshalBase64(strings.TrimSpace(envelopeXdr), &env)
+	if err != nil {
+		return env, fmt.Errorf("decoding transaction envelope: %v", err)
+	}
+	return env, nil
+}
+
+// cosignFinish submits the envelope if it has enough signatures, and otherwise
+// passes it on to the other signers in `channel`.
+func cosignFinish(mctx libkb.MetaContext, walletState *WalletState, env xdr.TransactionEnvelope,
+	signers remote.AccountSigners, channel string,
+) (res stellar1.CosignResultLocal, err error) {
+	res.EnvelopeXdr, err = xdr.MarshalBase64(env)
+	if err != nil {
+		return res, err
+	}
+	res.Status, err = CosignEnvelopeStatus(env, signers)
+	if err != nil {
+		return res, err
+	}
+	if !res.Status.Ready {
+		if channel != "" {
+			if err := cosignChatSend(mctx, channel, cosignRequestText(res)); err != nil {
+				return res, fmt.Errorf("unable to send the transaction to %s: %v", channel, err)
+			}
+		}
+		return res, nil
+	}
+
+	if err := walletState.PostAnyTransaction(mctx, res.EnvelopeXdr); err != nil {
+		return res, err
+	}
+	txID := res.Status.TxID
+	res.SubmitTxID = &txID
+
+	loader := DefaultLoader(mctx.G())
+	loader.LoadPaymentSync(mctx.Ctx(), stellar1.PaymentID(txID))
+	if err := walletState.Refresh(mctx, res.Status.AccountID, "cosign submit"); err != nil {
+		mctx.Debug("CosignFinish ws.Refresh error: %s", err)
+	}
+	if channel != "" {
+		text := fmt.Sprintf("Stellar transaction %s from %s has been co-signed and submitted.", txID, res.Status.AccountID)
+		if err := cosignChatSend(mctx, channel, text); err != nil {
+			mctx.Debug("CosignFinish: failed to send chat message: %v", err)
+		}
+	}
+	return res, nil
+}
+
+func cosignRequestText(res stellar1.CosignResultLocal) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Stellar transaction from %s needs co-signers (weight %d of %d):\n",
+		res.Status.AccountID, res.Status.CollectedWeight, res.Status.Threshold)
+	for _, op := range res.Status.Operations {
+		fmt.Fprintf(&b, "> %s\n", op)
+	}
+	fmt.Fprintf(&b, "Approve it with:\n```\nkeybase wallet cosign sign %s\n```", res.EnvelopeXdr)
+	return b.String()
+}
+
+// cosignChatSend posts to `channel`, either a team channel `team#topic` or a
+// comma separated list of users.
+func cosignChatSend(mctx libkb.MetaContext, channel string, text string) error {
+	mctx.G().StartStandaloneChat()
+	if mctx.G().ChatHelper == nil {
+		return errors.New("chat helper is nil")
+	}
+	name := channel
+	membersType := chat1.ConversationMembersType_IMPTEAMNATIVE
+	var topicName *string
+	if team, topic, found := strings.Cut(channel, "#"); found {
+		name = team
+		topicName = &topic
+		membersType = chat1.ConversationMembersType_TEAM
+	} else {
+		me := mctx.CurrentUsername().String()
+		if !libkb.IsIn(me, strings.Split(name, ","), true) {
+			name = strings.Join([]string{me, name}, ",")
+		}
+	}
+	return mctx.G().ChatHelper.SendTextByName(mctx.Ctx(), name, topicName, membersType,
+		keybase1.TLFIdentifyBehavior_CHAT_SKIP, text)
+}
+
+// CosignEnvelopeStatus checks the signatures of an envelope against the
+// signers of its source account.
+func CosignEnvelopeStatus(env xdr.TransactionEnvelope, signers remote.AccountSigners) (res stellar1.CosignStatusLocal, err error) {
+	hash, err := network.HashTransaction(&env.Tx, stellarnet.NetworkPassphrase())
+	if err != nil {
+		return res, err
+	}
+	res.AccountID = stellar1.AccountID(env.Tx.SourceAccount.Address())
+	res.TxID = stellar1.TransactionID(hex.EncodeToString(hash[:]))
+	res.Signers = signers.Signers
+	res.Threshold = cosignThreshold(env.Tx.Operations, signers)
+	for _, op := range env.Tx.Operations {
+		if op.SourceAccount != nil && op.SourceAccount.Address() != res.AccountID.String() {
+			// those need the signatures of another account
+			return res, fmt.Errorf("operations on other accounts than %v are not supported", res.AccountID)
+		}
+		res.Operations = append(res.Operations, cosignDescribeOp(op))
+	}
+
+	for _, signer := range signers.Signers {
+		kp, err := keypair.Parse(signer.AccountID.String())
+		if err != nil {
+			return res, fmt.Errorf("invalid signer %v: %v", signer.AccountID, err)
+		}
+		hint := kp.Hint()
+		for _, sig := range env.Signatures {
+			if sig.Hint != xdr.SignatureHint(hint) {
+				continue
+			}
+			if kp.Verify(hash[:], sig.Signature) == nil {
+				res.Signed = append(res.Signed, signer.AccountID)
+				res.CollectedWeight += signer.Weight
+				break
+			}
+		}
+	}
+	res.Ready = res.CollectedWeight >= res.Threshold
+	return res, nil
+}
+
+// cosignThreshold is the weight a transaction needs, which is the highest
+// threshold of any of its operations: high for merging the account or changing
+// its signers, low for trust, sequence and inflation operations, and medium
+// for everything else. The network always wants at least one signature.
+func cosignThreshold(ops []xdr.Operation, signers remote.AccountSigners) int {
+	threshold := 1
+	for _, op := range ops {
+		t := signers.Medium
+		switch op.Body.Type {
+		case xdr.OperationTypeAccountMerge:
+			t = signers.High
+		case xdr.OperationTypeSetOptions:
+			setOpt := op.Body.MustSetOptionsOp()
+			if setOpt.Signer != nil || setOpt.MasterWeight != nil || setOpt.LowThreshold != nil ||
+				setOpt.MedThreshold != nil || setOpt.HighThreshold != nil {
+				t = signers.High
+			}
+		case xdr.OperationTypeAllowTrust, xdr.OperationTypeBumpSequence, xdr.OperationTypeInflation:
+			t = signers.Low
+		}
+		if t > threshold {
+			threshold = t
+		}
+	}
+	return threshold
+}
+
+func cosignDescribeOp(op xdr.Operation) string {
+	switch op.Body.Type {
+	case xdr.OperationTypePayment:
+		p := op.Body.MustPaymentOp()
+		asset := "XLM"
+		if p.Asset.Type != xdr.AssetTypeAssetTypeNative {
+			asset = p.Asset.String()
+		}
+		return fmt.Sprintf("pay %s %s to %s", stellarnet.StringFromStellarAmount(int64(p.Amount)), asset, p.Destination.Address())
+	case xdr.OperationTypeSetOptions:
+		setOpt := op.Body.MustSetOptionsOp()
+		var parts []string
+		if setOpt.Signer != nil {
+			if setOpt.Signer.Weight == 0 {
+				parts = append(parts, fmt.Sprintf("remove signer %s", setOpt.Signer.Key.Address()))
+			} else {
+				parts = append(parts, fmt.Sprintf("set signer %s with weight %d", setOpt.Signer.Key.Address(), setOpt.Signer.Weight))
+			}
+		}
+		if setOpt.MasterWeight != nil {
+			parts = append(parts, fmt.Sprintf("set master key weight to %d", *setOpt.MasterWeight))
+		}
+		if setOpt.LowThreshold != nil && setOpt.MedThreshold != nil && setOpt.HighThreshold != nil {
+			parts = append(parts, fmt.Sprintf("set thresholds to low %d, medium %d, high %d",
+				*setOpt.LowThreshold, *setOpt.MedThreshold, *setOpt.HighThreshold))
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, ", ")
+		}
+	}
+	return strings.TrimPrefix(op.Body.Type.String(), "OperationType")
+}
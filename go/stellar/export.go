@@ -0,0 +1,168 @@
+package stellar
+
+import (
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+)
+
+type ExportHistoryArg struct {
+	AccountID stellar1.AccountID
+	// From and To bound the payment time, nil means unbounded.
+	From *time.Time
+	To   *time.Time
+	// Currency is the outside currency to value payments in, empty for the
+	// account's display currency.
+	Currency stellar1.OutsideCurrencyCode
+	// PageLimit is the number of payments fetched per request, 0 for the
+	// server default.
+	PageLimit int
+	// IncludeFees looks up the fee of every sent payment. Payment summaries
+	// don't carry it, so this costs one PaymentDetails request per payment.
+	IncludeFees bool
+}
+
+// ExportHistory pages through every payment of an account with
+// RecentPayments and returns the ones in the requested time range, oldest
+// first. Unlike RecentPaymentsCLILocal a payment that cannot be localized
+// fails the whole export so that nothing is silently missing. The worth of a
+// payment is left empty unless it was recorded in the requested currency when
+// the payment was made.
+func ExportHistory(mctx libkb.MetaContext, remoter remote.Remoter, arg ExportHistoryArg) (res []stellar1.PaymentExportCLILocal, err error) {
+	defer mctx.Trace("Stellar.ExportHistory", &err)()
+
+	currency := arg.Currency
+	if currency == "" {
+		codeStr, err := remoter.GetAccountDisplayCurrency(mctx.Ctx(), arg.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if codeStr == "" {
+			codeStr = DefaultCurrencySetting
+		}
+		currency = stellar1.OutsideCurrencyCode(codeStr)
+	}
+	var cursor *stellar1.PageCursor
+	seenCursors := make(map[stellar1.PageCursor]bool)
+	seenTxs := make(map[stellar1.TransactionID]bool)
+paging:
+	for {
+		page, err := remoter.RecentPayments(mctx.Ctx(), remote.RecentPaymentsArg{
+			AccountID:       arg.AccountID,
+			Cursor:          cursor,
+			Limit:           arg.PageLimit,
+			IncludeAdvanced: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Pages are sorted most recent first.
+		for _, summary := range page.Payments {
+			p, err := localizePayment(mctx, summary)
+			if err != nil {
+				return nil, err
+			}
+			ctime := p.Time.Time()
+			if arg.From != nil && ctime.Before(*arg.From) {
+				break paging
+			}
+			if arg.To != nil && ctime.After(*arg.To) {
+				continue
+			}
+			// Pending payments may show up again on a later page.
+			if seenTxs[p.TxID] {
+				continue
+			}
+			seenTxs[p.TxID] = true
+
+			res = append(res, exportPayment(mctx, remoter, arg.AccountID, summary, p, currency, arg.IncludeFees))
+		}
+		if page.Cursor == nil || len(page.Payments) == 0 || seenCursors[*page.Cursor] {
+			break
+		}
+		seenCursors[*page.Cursor] = true
+		cursor = page.Cursor
+	}
+
+	// Reverse so the oldest payment comes first, like a bank statement.
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
+
+func exportPayment(mctx libkb.MetaContext, remoter remote.Remoter, accountID stellar1.AccountID,
+	summary stellar1.PaymentSummary, p stellar1.PaymentCLILocal, currency stellar1.OutsideCurrencyCode,
+	includeFees bool,
+) (res stellar1.PaymentExportCLILocal) {
+	res.Payment = p
+	res.Sent = p.FromStellar.Eq(accountID)
+	res.Counterparty = exportCounterparty(p, res.Sent)
+	res.WorthCurrency = string(currency)
+	res.Worth = exportWorthAtTxTime(summary, res.Sent, currency)
+
+	// Only the source account pays the fee.
+	if includeFees && res.Sent {
+		details, err := remoter.PaymentDetails(mctx.Ctx(), accountID, p.TxID.String())
+		if err != nil {
+			mctx.Debug("ExportHistory: unable to get fee of %v: %v", p.TxID, err)
+		} else {
+			res.FeeCharged = details.FeeCharged
+		}
+	}
+	return res
+}
+
+func exportCounterparty(p stellar1.PaymentCLILocal, sent bool) string {
+	if !sent {
+		if p.FromUsername != nil {
+			return *p.FromUsername
+		}
+		return p.FromStellar.String()
+	}
+	switch {
+	case p.ToUsername != nil:
+		return *p.ToUsername
+	case p.ToAssertion != nil:
+		return *p.ToAssertion
+	case p.ToStellar != nil:
+		return p.ToStellar.String()
+	default:
+		return ""
+	}
+}
+
+// exportWorthAtTxTime returns the worth of the payment in `currency` as it was
+// recorded when the payment was made, or "" if there is none.
+func exportWorthAtTxTime(summary stellar1.PaymentSummary, sent bool, currency stellar1.OutsideCurrencyCode) string {
+	type worth struct {
+		amount   *string
+		currency *string
+	}
+	var candidates []worth
+	typ, err := summary.Typ()
+	if err != nil {
+		return ""
+	}
+	switch typ {
+	case stellar1.PaymentSummaryType_DIRECT:
+		p := summary.Direct()
+		candidates = append(candidates, worth{p.DisplayAmount, p.DisplayCurrency})
+		if sent {
+			candidates = append(candidates, worth{&p.FromDisplayAmount, &p.FromDisplayCurrency})
+		} else {
+			candidates = append(candidates, worth{&p.ToDisplayAmount, &p.ToDisplayCurrency})
+		}
+	case stellar1.PaymentSummaryType_RELAY:
+		p := summary.Relay()
+		candidates = append(candidates, worth{p.DisplayAmount, p.DisplayCurrency})
+	}
+	for _, c := range candidates {
+		if c.amount != nil && c.currency != nil && len(*c.amount) > 0 && *c.currency == string(currency) {
+			return *c.amount
+		}
+	}
+	return ""
+}
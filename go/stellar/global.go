@@ -69,6 +69,8 @@ type Stellar struct {
 
 	reconnectSlot *slotctx.Slot
 
+	schedule *scheduledPaymentStore
+
 	badger *badges.Badger
 }
 
@@ -83,6 +85,7 @@ func NewStellar(g *libkb.GlobalContext, walletState *WalletState, badger *badges
 		federationClient: getFederationClient(g),
 		buildPaymentSlot: slotctx.NewPriority(),
 		reconnectSlot:    slotctx.New(),
+		schedule:         newScheduledPaymentStore(g),
 		badger:           badger,
 	}
 }
@@ -101,6 +104,10 @@ func (s *Stellar) Upkeep(ctx context.Context) error {
 	return Upkeep(libkb.NewMetaContext(ctx, s.G()))
 }
 
+func (s *Stellar) RunScheduledPayments(mctx libkb.MetaContext) error {
+	return RunScheduledPayments(mctx, s.walletState)
+}
+
 func (s *Stellar) OnLogout(mctx libkb.MetaContext) error {
 	s.Clear(mctx)
 	return nil
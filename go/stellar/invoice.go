@@ -0,0 +1,124 @@
+package stellar
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/stellarnet"
+)
+
+// validateInvoice checks the limits of an invoice before it is encrypted.
+func validateInvoice(invoice stellar1.InvoiceContents) error {
+	if len(invoice.LineItems) > libkb.MaxStellarInvoiceLineItems {
+		return fmt.Errorf("invoice has %d line items, the maximum is %d",
+			len(invoice.LineItems), libkb.MaxStellarInvoiceLineItems)
+	}
+	if len(invoice.Reference) > libkb.MaxStellarPaymentNoteLength {
+		return fmt.Errorf("Reference of size %d bytes exceeds the maximum length of %d bytes",
+			len(invoice.Reference), libkb.MaxStellarPaymentNoteLength)
+	}
+	for i, item := range invoice.LineItems {
+		if strings.TrimSpace(item.Description) == "" {
+			return fmt.Errorf("line item %d has no description", i+1)
+		}
+		if _, err := parsePositiveInvoiceAmount(item.Amount); err != nil {
+			return fmt.Errorf("line item %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// completeInvoice fills in the amount of line items that only have a quantity
+// and unit amount, and checks that the line items add up to the requested
+// amount.
+func completeInvoice(invoice *stellar1.InvoiceContents, amount string) error {
+	if len(invoice.LineItems) == 0 {
+		return nil
+	}
+	total := new(big.Rat)
+	for i := range invoice.LineItems {
+		item := &invoice.LineItems[i]
+		if item.UnitAmount != "" {
+			unit, err := parsePositiveInvoiceAmount(item.UnitAmount)
+			if err != nil {
+				return fmt.Errorf("line item %d: %v", i+1, err)
+			}
+			quantity := big.NewRat(1, 1)
+			if item.Quantity != "" {
+				quantity, err = parsePositiveInvoiceAmount(item.Quantity)
+				if err != nil {
+					return fmt.Errorf("line item %d quantity: %v", i+1, err)
+				}
+			}
+			lineAmount := formatInvoiceAmount(new(big.Rat).Mul(quantity, unit))
+			if item.Amount != "" && item.Amount != lineAmount {
+				return fmt.Errorf("line item %d: amount %s does not match %s x %s",
+					i+1, item.Amount, item.Quantity, item.UnitAmount)
+			}
+			item.Amount = lineAmount
+		}
+		a, err := parsePositiveInvoiceAmount(item.Amount)
+		if err != nil {
+			return fmt.Errorf("line item %d: %v", i+1, err)
+		}
+		total.Add(total, a)
+	}
+	requested, err := stellarnet.ParseAmount(amount)
+	if err != nil {
+		return err
+	}
+	if total.Cmp(requested) != 0 {
+		return fmt.Errorf("line items add up to %s, not the requested amount %s", formatInvoiceAmount(total), amount)
+	}
+	return nil
+}
+
+func parsePositiveInvoiceAmount(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing amount")
+	}
+	a, err := stellarnet.ParseAmount(s)
+	if err != nil {
+		return nil, err
+	}
+	if a.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive: %s", s)
+	}
+	return a, nil
+}
+
+// formatInvoiceAmount formats an amount with up to 7 decimal places and no
+// trailing zeros.
+func formatInvoiceAmount(a *big.Rat) string {
+	s := a.FloatString(7)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// requestAmountPaid adds up the payments made toward a request, in the
+// request's asset or currency. Payments toward an outside currency request
+// count with their display amount and only if they were made in that
+// currency.
+func requestAmountPaid(mctx libkb.MetaContext, details stellar1.RequestDetails) string {
+	paid := new(big.Rat)
+	for _, p := range details.Payments {
+		amount := p.Amount
+		if details.Currency != nil {
+			if p.DisplayCurrency != string(*details.Currency) {
+				mctx.Debug("request %s: skipping payment %s in %q", details.Id, p.KbTxID, p.DisplayCurrency)
+				continue
+			}
+			amount = p.DisplayAmount
+		}
+		a, err := stellarnet.ParseAmount(amount)
+		if err != nil {
+			mctx.Debug("request %s: skipping payment %s: %v", details.Id, p.KbTxID, err)
+			continue
+		}
+		paid.Add(paid, a)
+	}
+	return formatInvoiceAmount(paid)
+}
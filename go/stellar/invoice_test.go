@@ -0,0 +1,64 @@
+package stellar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteInvoice(t *testing.T) {
+	invoice := stellar1.InvoiceContents{
+		LineItems: []stellar1.InvoiceLineItem{
+			{Description: "Hosting", Quantity: "3", UnitAmount: "2.5"},
+			{Description: "Setup", UnitAmount: "1.25"},
+			{Description: "Domain", Amount: "0.0000001"},
+		},
+	}
+	err := completeInvoice(&invoice, "8.7500001")
+	require.NoError(t, err)
+	require.Equal(t, "7.5", invoice.LineItems[0].Amount)
+	require.Equal(t, "1.25", invoice.LineItems[1].Amount)
+	require.Equal(t, "Hosting: 3 x 2.5 = 7.5", invoice.LineItems[0].String())
+	require.Equal(t, "Domain: 0.0000001", invoice.LineItems[2].String())
+	require.NoError(t, validateInvoice(invoice))
+
+	err = completeInvoice(&invoice, "8.75")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line items add up to 8.7500001")
+
+	for _, item := range []stellar1.InvoiceLineItem{
+		{Description: "Negative", Amount: "-1"},
+		{Description: "Zero quantity", Quantity: "0", UnitAmount: "1"},
+		{Description: "Mismatch", Quantity: "2", UnitAmount: "1", Amount: "3"},
+		{Description: "No amount"},
+	} {
+		invoice := stellar1.InvoiceContents{LineItems: []stellar1.InvoiceLineItem{item}}
+		require.Error(t, completeInvoice(&invoice, "1"), item.Description)
+	}
+	require.Error(t, validateInvoice(stellar1.InvoiceContents{
+		LineItems: []stellar1.InvoiceLineItem{{Amount: "1"}},
+	}), "missing description")
+}
+
+func TestInvoiceRoundtrip(t *testing.T) {
+	sk := randomSymmetricKey(t)
+	pre := stellar1.InvoiceContents{
+		LineItems: []stellar1.InvoiceLineItem{
+			{Description: "Hosting", Quantity: "2", UnitAmount: "10", Amount: "20"},
+		},
+		DueDate:   stellar1.TimeMs(1790000000000),
+		Reference: "INV-1",
+	}
+	encInvoice, err := noteSeal(pre, sk)
+	require.NoError(t, err)
+	var post stellar1.InvoiceContents
+	err = noteOpen(encInvoice, sk, &post)
+	require.NoError(t, err)
+	require.Equal(t, pre, post)
+
+	// An invoice does not decrypt as a note with another key.
+	_, err = noteDecryptHelper(context.Background(), encInvoice, randomSymmetricKey(t))
+	require.Error(t, err)
+}
@@ -411,6 +411,10 @@ func (p *Loader) uiRequestInfo(m libkb.MetaContext, details *stellar1.RequestDet
 		Currency:           details.Currency,
 		Status:             details.Status,
 		WorthAtRequestTime: details.WorthAtRequestTime,
+		Invoice:            details.Invoice,
+	}
+	if details.AmountPaid != "0" {
+		info.AmountPaidDescription = details.AmountPaidDescription
 	}
 
 	return &info
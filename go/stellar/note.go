@@ -181,8 +181,13 @@ func noteEncrypt(mctx libkb.MetaContext, note stellar1.NoteContents, other *keyb
 
 // noteEncryptHelper does the encryption part and returns a partially populated result.
 func noteEncryptHelper(ctx context.Context, note stellar1.NoteContents, symmetricKey libkb.NaclSecretBoxKey) (res stellar1.EncryptedNote, err error) {
+	return noteSeal(note, symmetricKey)
+}
+
+// noteSeal msgpacks and secretboxes a note or an invoice.
+func noteSeal(clear any, symmetricKey libkb.NaclSecretBoxKey) (res stellar1.EncryptedNote, err error) {
 	// Msgpack
-	clearpack, err := msgpack.Encode(note)
+	clearpack, err := msgpack.Encode(clear)
 	if err != nil {
 		return res, err
 	}
@@ -203,16 +208,20 @@ func noteEncryptHelper(ctx context.Context, note stellar1.NoteContents, symmetri
 }
 
 func NoteDecryptB64(mctx libkb.MetaContext, noteB64 string) (res stellar1.NoteContents, err error) {
-	pack, err := base64.StdEncoding.DecodeString(noteB64)
+	obj, err := noteUnpackB64(noteB64)
 	if err != nil {
 		return res, err
 	}
-	var obj stellar1.EncryptedNote
-	err = msgpack.Decode(&obj, pack)
+	return noteDecrypt(mctx, obj)
+}
+
+func noteUnpackB64(noteB64 string) (res stellar1.EncryptedNote, err error) {
+	pack, err := base64.StdEncoding.DecodeString(noteB64)
 	if err != nil {
 		return res, err
 	}
-	return noteDecrypt(mctx, obj)
+	err = msgpack.Decode(&res, pack)
+	return res, err
 }
 
 func noteDecrypt(mctx libkb.MetaContext, encNote stellar1.EncryptedNote) (res stellar1.NoteContents, err error) {
@@ -227,15 +236,68 @@ func noteDecrypt(mctx libkb.MetaContext, encNote stellar1.EncryptedNote) (res st
 }
 
 func noteDecryptHelper(ctx context.Context, encNote stellar1.EncryptedNote, symmetricKey libkb.NaclSecretBoxKey) (res stellar1.NoteContents, err error) {
+	err = noteOpen(encNote, symmetricKey, &res)
+	return res, err
+}
+
+// noteOpen opens the secretbox of a note or an invoice and decodes it into `clear`.
+func noteOpen(encNote stellar1.EncryptedNote, symmetricKey libkb.NaclSecretBoxKey, clear any) error {
 	// Secretbox
 	clearpack, ok := secretbox.Open(nil, encNote.E,
 		(*[libkb.NaclDHNonceSize]byte)(&encNote.N),
 		(*[libkb.NaclSecretBoxKeySize]byte)(&symmetricKey))
 	if !ok {
-		return res, errors.New("could not decrypt note secretbox")
+		return errors.New("could not decrypt note secretbox")
 	}
 
 	// Msgpack
-	err = msgpack.Decode(&res, clearpack)
+	return msgpack.Decode(clear, clearpack)
+}
+
+// InvoiceEncryptB64 encrypts the invoice of a payment request with the same
+// keys as a payment note: for the logged-in user and optionally for `other`.
+func InvoiceEncryptB64(mctx libkb.MetaContext, invoice stellar1.InvoiceContents, other *keybase1.UserVersion) (invoiceB64 string, err error) {
+	if err := validateInvoice(invoice); err != nil {
+		return "", err
+	}
+	nbs, err := noteSymmetricKey(mctx, other)
+	if err != nil {
+		return "", fmt.Errorf("error getting encryption key for invoice: %v", err)
+	}
+	if nbs.symmetricKey.IsZero() {
+		// This should never happen
+		return "", fmt.Errorf("unexpected zero key")
+	}
+	obj, err := noteSeal(invoice, nbs.symmetricKey)
+	if err != nil {
+		return "", err
+	}
+	obj.Sender = nbs.sender
+	obj.Recipient = nbs.recipient
+	pack, err := msgpack.Encode(obj)
+	if err != nil {
+		return "", err
+	}
+	invoiceB64 = base64.StdEncoding.EncodeToString(pack)
+	if len(invoiceB64) > libkb.MaxStellarBoxedInvoiceLength {
+		return "", fmt.Errorf("Encrypted invoice of size %d bytes exceeds the maximum length of %d bytes",
+			len(invoiceB64), libkb.MaxStellarBoxedInvoiceLength)
+	}
+	return invoiceB64, nil
+}
+
+func InvoiceDecryptB64(mctx libkb.MetaContext, invoiceB64 string) (res stellar1.InvoiceContents, err error) {
+	obj, err := noteUnpackB64(invoiceB64)
+	if err != nil {
+		return res, err
+	}
+	if obj.V != 1 {
+		return res, fmt.Errorf("unsupported invoice version: %v", obj.V)
+	}
+	symmetricKey, err := noteSymmetricKeyForDecryption(mctx, obj)
+	if err != nil {
+		return res, err
+	}
+	err = noteOpen(obj, symmetricKey, &res)
 	return res, err
 }
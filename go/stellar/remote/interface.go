@@ -15,6 +15,17 @@ type RecentPaymentsArg struct {
 	IncludeAdvanced bool
 }
 
+// AccountSigners are the keys that can sign for an account and the weight
+// they have to reach for low, medium and high threshold operations.
+type AccountSigners struct {
+	Signers []stellar1.CosignSigner
+	Low     int
+	Medium  int
+	High    int
+	// Seqno is the sequence number of the last transaction of the account
+	Seqno uint64
+}
+
 type Remoter interface {
 	AccountSeqno(ctx context.Context, accountID stellar1.AccountID) (uint64, error)
 	Balances(ctx context.Context, accountID stellar1.AccountID) ([]stellar1.Balance, error)
@@ -35,6 +46,7 @@ type Remoter interface {
 	ExchangeRate(ctx context.Context, currency string) (stellar1.OutsideExchangeRate, error)
 	SubmitRequest(ctx context.Context, post stellar1.RequestPost) (stellar1.KeybaseRequestID, error)
 	RequestDetails(ctx context.Context, requestID stellar1.KeybaseRequestID) (stellar1.RequestDetails, error)
+	Requests(ctx context.Context, includeClosed bool) ([]stellar1.RequestDetails, error)
 	CancelRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error
 	MarkAsRead(ctx context.Context, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error
 	IsAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) (bool, error)
@@ -49,6 +61,7 @@ type Remoter interface {
 	ChangeTrustline(ctx context.Context, signedTx string) error
 	FindPaymentPath(mctx libkb.MetaContext, query stellar1.PaymentPathQuery) (stellar1.PaymentPath, error)
 	PostAnyTransaction(mctx libkb.MetaContext, signedTx string) error
+	AccountSigners(mctx libkb.MetaContext, accountID stellar1.AccountID) (AccountSigners, error)
 	FuzzyAssetSearch(mctx libkb.MetaContext, arg stellar1.FuzzyAssetSearchArg) ([]stellar1.Asset, error)
 	ListPopularAssets(mctx libkb.MetaContext, arg stellar1.ListPopularAssetsArg) (stellar1.AssetListResult, error)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/keybase/client/go/protocol/keybase1"
 	"github.com/keybase/client/go/protocol/stellar1"
 	"github.com/keybase/client/go/stellar/bundle"
+	"github.com/keybase/stellarnet"
 )
 
 var ErrAccountIDMissing = errors.New("account id parameter missing")
@@ -763,6 +764,30 @@ func RequestDetails(ctx context.Context, g *libkb.GlobalContext, requestID stell
 	return res.Request, nil
 }
 
+type requestsResult struct {
+	libkb.AppStatusEmbed
+	Requests []stellar1.RequestDetails `json:"requests"`
+}
+
+func Requests(ctx context.Context, g *libkb.GlobalContext, includeClosed bool) (ret []stellar1.RequestDetails, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/requests",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		Args: libkb.HTTPArgs{
+			"include_closed": libkb.B{Val: includeClosed},
+		},
+		RetryCount:      3,
+		RetryMultiplier: 1.5,
+		InitialTimeout:  10 * time.Second,
+	}
+	var res requestsResult
+	if err := mctx.G().API.GetDecode(mctx, apiArg, &res); err != nil {
+		return ret, err
+	}
+	return res.Requests, nil
+}
+
 func CancelRequest(ctx context.Context, g *libkb.GlobalContext, requestID stellar1.KeybaseRequestID) (err error) {
 	payload := make(libkb.JSONPayload)
 	payload["id"] = requestID
@@ -1141,6 +1166,33 @@ func PostAnyTransaction(mctx libkb.MetaContext, signedTx string) (err error) {
 	return err
 }
 
+// FetchAccountSigners loads the signers of an account from horizon, since
+// the keybase server only knows about the accounts in bundles. Signers that are
+// not plain ed25519 keys (pre-authorized transactions, hashes) are skipped.
+func FetchAccountSigners(mctx libkb.MetaContext, accountID stellar1.AccountID) (res AccountSigners, err error) {
+	defer mctx.Trace(fmt.Sprintf("remote.FetchAccountSigners(%v)", accountID), &err)()
+	acct, err := stellarnet.Client().LoadAccount(accountID.String())
+	if err != nil {
+		return res, err
+	}
+	for _, signer := range acct.Signers {
+		if signer.Type != "ed25519_public_key" || signer.Weight == 0 {
+			continue
+		}
+		res.Signers = append(res.Signers, stellar1.CosignSigner{
+			AccountID: stellar1.AccountID(signer.Key),
+			Weight:    int(signer.Weight),
+		})
+	}
+	res.Low = int(acct.Thresholds.LowThreshold)
+	res.Medium = int(acct.Thresholds.MedThreshold)
+	res.High = int(acct.Thresholds.HighThreshold)
+	if res.Seqno, err = strconv.ParseUint(acct.Sequence, 10, 64); err != nil {
+		return res, fmt.Errorf("invalid sequence number %q: %v", acct.Sequence, err)
+	}
+	return res, nil
+}
+
 type fuzzyAssetSearchResult struct {
 	libkb.AppStatusEmbed
 	Assets []stellar1.Asset `json:"matches"`
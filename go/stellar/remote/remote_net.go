@@ -94,6 +94,10 @@ func (r *RemoteNet) RequestDetails(ctx context.Context, requestID stellar1.Keyba
 	return RequestDetails(ctx, r.G(), requestID)
 }
 
+func (r *RemoteNet) Requests(ctx context.Context, includeClosed bool) ([]stellar1.RequestDetails, error) {
+	return Requests(ctx, r.G(), includeClosed)
+}
+
 func (r *RemoteNet) CancelRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error {
 	return CancelRequest(ctx, r.G(), requestID)
 }
@@ -150,6 +154,10 @@ func (r *RemoteNet) PostAnyTransaction(mctx libkb.MetaContext, signedTx string)
 	return PostAnyTransaction(mctx, signedTx)
 }
 
+func (r *RemoteNet) AccountSigners(mctx libkb.MetaContext, accountID stellar1.AccountID) (AccountSigners, error) {
+	return FetchAccountSigners(mctx, accountID)
+}
+
 func (r *RemoteNet) FuzzyAssetSearch(mctx libkb.MetaContext, arg stellar1.FuzzyAssetSearchArg) ([]stellar1.Asset, error) {
 	return FuzzyAssetSearch(mctx, arg)
 }
@@ -0,0 +1,400 @@
+package stellar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keybase/client/go/encrypteddb"
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/stellarcommon"
+	"github.com/keybase/stellarnet"
+)
+
+const scheduledPaymentsDBVersion = 1
+
+// Finished schedules are kept around this long so that `list` can show how
+// their last run went.
+const scheduledPaymentsKeepFinished = 30 * 24 * time.Hour
+
+type scheduledPaymentsDBEntry struct {
+	Version  int
+	Payments []stellar1.ScheduledPaymentLocal
+}
+
+// scheduledPaymentStore keeps the scheduled payments of the logged in user in
+// the local db, encrypted since they hold notes and recipients.
+type scheduledPaymentStore struct {
+	sync.Mutex
+	edb *encrypteddb.EncryptedDB
+}
+
+func newScheduledPaymentStore(g *libkb.GlobalContext) *scheduledPaymentStore {
+	keyFn := func(ctx context.Context) ([32]byte, error) {
+		return encrypteddb.GetSecretBoxKey(ctx, g, libkb.EncryptionReasonStellarSchedule,
+			"encrypt stellar scheduled payments")
+	}
+	dbFn := func(g *libkb.GlobalContext) *libkb.JSONLocalDb {
+		return g.LocalDb
+	}
+	return &scheduledPaymentStore{
+		edb: encrypteddb.New(g, dbFn, keyFn),
+	}
+}
+
+func (s *scheduledPaymentStore) dbKey(mctx libkb.MetaContext) (libkb.DbKey, error) {
+	uv, err := mctx.G().GetMeUV(mctx.Ctx())
+	if err != nil {
+		return libkb.DbKey{}, err
+	}
+	return libkb.DbKey{
+		Typ: libkb.DBStellarScheduledPayments,
+		Key: uv.String(),
+	}, nil
+}
+
+func (s *scheduledPaymentStore) loadLocked(mctx libkb.MetaContext) ([]stellar1.ScheduledPaymentLocal, error) {
+	key, err := s.dbKey(mctx)
+	if err != nil {
+		return nil, err
+	}
+	var entry scheduledPaymentsDBEntry
+	found, err := s.edb.Get(mctx.Ctx(), key, &entry)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	if entry.Version != scheduledPaymentsDBVersion {
+		mctx.Debug("scheduledPaymentStore: ignoring entry with version %d", entry.Version)
+		return nil, nil
+	}
+	return entry.Payments, nil
+}
+
+func (s *scheduledPaymentStore) saveLocked(mctx libkb.MetaContext, payments []stellar1.ScheduledPaymentLocal) error {
+	key, err := s.dbKey(mctx)
+	if err != nil {
+		return err
+	}
+	return s.edb.Put(mctx.Ctx(), key, scheduledPaymentsDBEntry{
+		Version:  scheduledPaymentsDBVersion,
+		Payments: payments,
+	})
+}
+
+type SchedulePaymentArg struct {
+	From       stellar1.AccountID // Optional. Defaults to primary account.
+	To         stellarcommon.RecipientInput
+	Amount     string
+	Currency   stellar1.OutsideCurrencyCode // Optional. Empty when Amount is in XLM.
+	SecretNote string
+	PublicMemo string
+	Recurrence stellar1.ScheduledPaymentRecurrence
+	Start      time.Time // Zero for now.
+}
+
+// SchedulePayment validates a payment and stores it to be sent at
+// `arg.Start`, and then weekly or monthly if it recurs. Nothing is sent here,
+// the payments go out from RunScheduledPayments.
+func SchedulePayment(mctx libkb.MetaContext, walletState *WalletState, arg SchedulePaymentArg) (res stellar1.ScheduledPaymentLocal, err error) {
+	defer mctx.Trace("Stellar.SchedulePayment", &err)()
+
+	if _, ok := stellar1.ScheduledPaymentRecurrenceRevMap[arg.Recurrence]; !ok {
+		return res, fmt.Errorf("invalid recurrence: %v", arg.Recurrence)
+	}
+	if arg.To == "" {
+		return res, errors.New("missing recipient")
+	}
+	if len(arg.SecretNote) > libkb.MaxStellarPaymentNoteLength {
+		return res, fmt.Errorf("note of size %d bytes exceeds the maximum length of %d bytes",
+			len(arg.SecretNote), libkb.MaxStellarPaymentNoteLength)
+	}
+	if _, err := scheduledPaymentMemo(arg.PublicMemo); err != nil {
+		return res, err
+	}
+	if _, err := stellarnet.ParseStellarAmount(arg.Amount); err != nil {
+		return res, fmt.Errorf("invalid amount %q: %v", arg.Amount, err)
+	}
+	if arg.Currency != "" {
+		if _, err := walletState.ExchangeRate(mctx.Ctx(), string(arg.Currency)); err != nil {
+			return res, fmt.Errorf("unable to get exchange rate for %v: %v", arg.Currency, err)
+		}
+	}
+
+	from := arg.From
+	if from.IsNil() {
+		from, err = GetOwnPrimaryAccountID(mctx)
+		if err != nil {
+			return res, err
+		}
+	}
+	// Make sure this device can sign for the account when the time comes.
+	if _, _, err := LookupSender(mctx, from); err != nil {
+		return res, err
+	}
+	// The recipient is looked up again at each run, this only catches typos.
+	if _, err := LookupRecipient(mctx, arg.To, false /* isCLI */); err != nil {
+		return res, err
+	}
+
+	now := mctx.G().Clock().Now()
+	start := arg.Start
+	if start.IsZero() {
+		start = now
+	}
+	id, err := libkb.RandHexString("", 8)
+	if err != nil {
+		return res, err
+	}
+	res = stellar1.ScheduledPaymentLocal{
+		Id:         stellar1.ScheduledPaymentID(id),
+		From:       from,
+		To:         string(arg.To),
+		Amount:     arg.Amount,
+		Currency:   arg.Currency,
+		Note:       arg.SecretNote,
+		PublicMemo: arg.PublicMemo,
+		Recurrence: arg.Recurrence,
+		Start:      stellar1.ToTimeMs(start),
+		NextRun:    stellar1.ToTimeMs(start),
+		Ctime:      stellar1.ToTimeMs(now),
+	}
+
+	store := getGlobal(mctx.G()).schedule
+	store.Lock()
+	defer store.Unlock()
+	payments, err := store.loadLocked(mctx)
+	if err != nil {
+		return res, err
+	}
+	payments = append(payments, res)
+	if err := store.saveLocked(mctx, payments); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// ListScheduledPayments returns the scheduled payments of the logged in user,
+// including finished ones that have not been pruned yet.
+func ListScheduledPayments(mctx libkb.MetaContext) (res []stellar1.ScheduledPaymentLocal, err error) {
+	defer mctx.Trace("Stellar.ListScheduledPayments", &err)()
+	store := getGlobal(mctx.G()).schedule
+	store.Lock()
+	defer store.Unlock()
+	return store.loadLocked(mctx)
+}
+
+// CancelScheduledPayment removes a schedule. A run that is already in flight
+// still goes out.
+func CancelScheduledPayment(mctx libkb.MetaContext, id stellar1.ScheduledPaymentID) (err error) {
+	defer mctx.Trace(fmt.Sprintf("Stellar.CancelScheduledPayment(%v)", id), &err)()
+	store := getGlobal(mctx.G()).schedule
+	store.Lock()
+	defer store.Unlock()
+	payments, err := store.loadLocked(mctx)
+	if err != nil {
+		return err
+	}
+	for i, p := range payments {
+		if p.Id == id {
+			payments = append(payments[:i], payments[i+1:]...)
+			return store.saveLocked(mctx, payments)
+		}
+	}
+	return fmt.Errorf("scheduled payment %v not found", id)
+}
+
+// RunScheduledPayments sends the scheduled payments that are due. Runs that
+// were missed while the device was offline are not made up for: a schedule
+// that is late sends one payment and moves on to its next future run. A
+// payment that the available balance cannot cover is skipped.
+func RunScheduledPayments(mctx libkb.MetaContext, walletState *WalletState) (err error) {
+	defer mctx.Trace("Stellar.RunScheduledPayments", &err)()
+
+	now := mctx.G().Clock().Now()
+	due, err := takeDueScheduledPayments(mctx, now)
+	if err != nil {
+		return err
+	}
+	for _, p := range due {
+		run := runScheduledPayment(mctx, walletState, p)
+		mctx.Debug("RunScheduledPayments: %v -> %v %v", p.Id, run.Status, run.Error)
+		updated, err := recordScheduledPaymentRun(mctx, p, run)
+		if err != nil {
+			mctx.Debug("RunScheduledPayments: unable to record run of %v: %v", p.Id, err)
+		}
+		mctx.G().NotifyRouter.HandleWalletScheduledPaymentNotification(mctx.Ctx(), updated)
+	}
+	return nil
+}
+
+// takeDueScheduledPayments advances the schedules that are due and saves them
+// before anything is sent, so that a crash mid-run cannot pay twice.
+func takeDueScheduledPayments(mctx libkb.MetaContext, now time.Time) (due []stellar1.ScheduledPaymentLocal, err error) {
+	store := getGlobal(mctx.G()).schedule
+	store.Lock()
+	defer store.Unlock()
+	payments, err := store.loadLocked(mctx)
+	if err != nil {
+		return nil, err
+	}
+	var keep []stellar1.ScheduledPaymentLocal
+	for _, p := range payments {
+		if p.NextRun == 0 {
+			if p.LastRun == nil || now.Sub(p.LastRun.Time.Time()) < scheduledPaymentsKeepFinished {
+				keep = append(keep, p)
+			}
+			continue
+		}
+		if !p.NextRun.Time().After(now) {
+			due = append(due, p.DeepCopy())
+			p.NextRun = stellar1.ToTimeMs(scheduledPaymentNextRun(p.Recurrence, p.Start.Time(), now))
+		}
+		keep = append(keep, p)
+	}
+	if len(due) == 0 && len(keep) == len(payments) {
+		return nil, nil
+	}
+	if err := store.saveLocked(mctx, keep); err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+func recordScheduledPaymentRun(mctx libkb.MetaContext, p stellar1.ScheduledPaymentLocal,
+	run stellar1.ScheduledPaymentRunLocal,
+) (res stellar1.ScheduledPaymentLocal, err error) {
+	store := getGlobal(mctx.G()).schedule
+	store.Lock()
+	defer store.Unlock()
+	// What gets reported if the schedule was canceled while sending.
+	res = p.DeepCopy()
+	res.NextRun = 0
+	res.Runs++
+	res.LastRun = &run
+	payments, err := store.loadLocked(mctx)
+	if err != nil {
+		return res, err
+	}
+	for i := range payments {
+		if payments[i].Id == p.Id {
+			payments[i].Runs++
+			payments[i].LastRun = &run
+			res = payments[i].DeepCopy()
+			return res, store.saveLocked(mctx, payments)
+		}
+	}
+	return res, nil
+}
+
+func runScheduledPayment(mctx libkb.MetaContext, walletState *WalletState, p stellar1.ScheduledPaymentLocal) (run stellar1.ScheduledPaymentRunLocal) {
+	run.Time = stellar1.ToTimeMs(mctx.G().Clock().Now())
+	fail := func(err error) stellar1.ScheduledPaymentRunLocal {
+		run.Status = stellar1.ScheduledPaymentRunStatus_FAILED
+		run.Error = err.Error()
+		return run
+	}
+
+	amount := p.Amount
+	var displayBalance DisplayBalance
+	if p.Currency != "" {
+		rate, err := walletState.ExchangeRate(mctx.Ctx(), string(p.Currency))
+		if err != nil {
+			return fail(fmt.Errorf("unable to get exchange rate for %v: %v", p.Currency, err))
+		}
+		amount, err = stellarnet.ConvertOutsideToXLM(p.Amount, rate.Rate)
+		if err != nil {
+			return fail(err)
+		}
+		displayBalance = DisplayBalance{
+			Amount:   p.Amount,
+			Currency: string(p.Currency),
+		}
+	}
+
+	details, err := walletState.Details(mctx.Ctx(), p.From)
+	if err != nil {
+		return fail(err)
+	}
+	cmp, err := stellarnet.CompareStellarAmounts(details.Available, amount)
+	if err != nil {
+		return fail(err)
+	}
+	if cmp < 0 {
+		run.Status = stellar1.ScheduledPaymentRunStatus_SKIPPED
+		run.Error = fmt.Sprintf("available balance of %s XLM does not cover %s XLM", details.Available, amount)
+		return run
+	}
+
+	memo, err := scheduledPaymentMemo(p.PublicMemo)
+	if err != nil {
+		return fail(err)
+	}
+	// There is nobody to answer identify prompts in the background, so
+	// send the way the GUI does.
+	sendRes, err := SendPaymentGUI(mctx, walletState, SendPaymentArg{
+		From:           p.From,
+		To:             stellarcommon.RecipientInput(p.To),
+		Amount:         amount,
+		DisplayBalance: displayBalance,
+		SecretNote:     p.Note,
+		PublicMemo:     memo,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	run.Status = stellar1.ScheduledPaymentRunStatus_SENT
+	run.TxID = &sendRes.TxID
+	return run
+}
+
+func scheduledPaymentMemo(publicMemo string) (*stellarnet.Memo, error) {
+	if publicMemo == "" {
+		return nil, nil
+	}
+	return stellarnet.NewMemoFromStrings(publicMemo, stellar1.PublicNoteType_TEXT.String())
+}
+
+// scheduledPaymentNextRun returns the first run of a schedule strictly after
+// `after`, or the zero time once there are no more runs. Monthly runs that
+// start on a day the month does not have fall on its last day.
+func scheduledPaymentNextRun(recurrence stellar1.ScheduledPaymentRecurrence, start, after time.Time) time.Time {
+	var n int
+	switch recurrence {
+	case stellar1.ScheduledPaymentRecurrence_WEEKLY:
+		n = int(after.Sub(start)/(7*24*time.Hour)) - 1
+	case stellar1.ScheduledPaymentRecurrence_MONTHLY:
+		n = (after.Year()-start.Year())*12 + int(after.Month()-start.Month()) - 1
+	default:
+		return time.Time{}
+	}
+	if n < 1 {
+		n = 1
+	}
+	for {
+		t := scheduledPaymentOccurrence(recurrence, start, n)
+		if t.After(after) {
+			return t
+		}
+		n++
+	}
+}
+
+// scheduledPaymentOccurrence returns the n-th run after `start`.
+func scheduledPaymentOccurrence(recurrence stellar1.ScheduledPaymentRecurrence, start time.Time, n int) time.Time {
+	if recurrence == stellar1.ScheduledPaymentRecurrence_WEEKLY {
+		return start.AddDate(0, 0, 7*n)
+	}
+	year, month, day := start.Date()
+	firstOfMonth := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+	if last := firstOfMonth.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day,
+		start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+}
@@ -0,0 +1,39 @@
+package stellar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPaymentNextRun(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.UTC)
+	}
+	weekly := stellar1.ScheduledPaymentRecurrence_WEEKLY
+	monthly := stellar1.ScheduledPaymentRecurrence_MONTHLY
+	start := day(2026, time.January, 31)
+
+	for _, tc := range []struct {
+		recurrence stellar1.ScheduledPaymentRecurrence
+		after      time.Time
+		expected   time.Time
+	}{
+		{stellar1.ScheduledPaymentRecurrence_ONCE, start, time.Time{}},
+		{weekly, start, day(2026, time.February, 7)},
+		{weekly, day(2026, time.February, 7), day(2026, time.February, 14)},
+		// Missed runs are skipped.
+		{weekly, day(2026, time.March, 30), day(2026, time.April, 4)},
+		// Short months get their last day.
+		{monthly, start, day(2026, time.February, 28)},
+		{monthly, day(2026, time.February, 28), day(2026, time.March, 31)},
+		{monthly, day(2026, time.April, 1), day(2026, time.April, 30)},
+		{monthly, day(2027, time.December, 31), day(2028, time.January, 31)},
+		{monthly, day(2028, time.January, 31), day(2028, time.February, 29)},
+	} {
+		require.Equal(t, tc.expected, scheduledPaymentNextRun(tc.recurrence, start, tc.after),
+			"%v after %v", tc.recurrence, tc.after)
+	}
+}
@@ -557,6 +557,7 @@ type SendPaymentArg struct {
 	PublicMemo     *stellarnet.Memo // Optional.
 	ForceRelay     bool
 	QuickReturn    bool
+	RequestID      *stellar1.KeybaseRequestID // Optional. Request this payment is toward.
 }
 
 type SendPaymentResult struct {
@@ -611,7 +612,7 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 	if recipient.AccountID == nil || sendArg.ForceRelay {
 		return sendRelayPayment(mctx, walletState,
 			senderSeed, recipient, sendArg.Amount, sendArg.DisplayBalance,
-			sendArg.SecretNote, sendArg.PublicMemo, sendArg.QuickReturn, senderEntry.IsPrimary, baseFee)
+			sendArg.SecretNote, sendArg.PublicMemo, sendArg.QuickReturn, senderEntry.IsPrimary, baseFee, sendArg.RequestID)
 	}
 
 	ownRecipient, _, err := OwnAccount(mctx, stellar1.AccountID(recipient.AccountID.String()))
@@ -642,6 +643,7 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 		DisplayAmount:   sendArg.DisplayBalance.Amount,
 		DisplayCurrency: sendArg.DisplayBalance.Currency,
 		QuickReturn:     sendArg.QuickReturn,
+		RequestID:       sendArg.RequestID,
 	}
 	if recipient.User != nil {
 		post.To = &recipient.User.UV
@@ -1257,6 +1259,7 @@ func prepareMiniChatPaymentRelay(mctx libkb.MetaContext, remoter remote.Remoter,
 func sendRelayPayment(mctx libkb.MetaContext, walletState *WalletState,
 	from stellar1.SecretKey, recipient stellarcommon.Recipient, amount string, displayBalance DisplayBalance,
 	secretNote string, publicMemo *stellarnet.Memo, quickReturn bool, senderEntryPrimary bool, baseFee uint64,
+	requestID *stellar1.KeybaseRequestID,
 ) (res SendPaymentResult, err error) {
 	defer mctx.Trace("Stellar.sendRelayPayment", &err)()
 	appKey, teamID, err := relays.GetKey(mctx, recipient)
@@ -1306,6 +1309,7 @@ func sendRelayPayment(mctx libkb.MetaContext, walletState *WalletState,
 		DisplayAmount:     displayBalance.Amount,
 		DisplayCurrency:   displayBalance.Currency,
 		QuickReturn:       quickReturn,
+		RequestID:         requestID,
 	}
 	if recipient.User != nil {
 		post.To = &recipient.User.UV
@@ -1800,6 +1804,7 @@ type MakeRequestArg struct {
 	Asset    *stellar1.Asset
 	Currency *stellar1.OutsideCurrencyCode
 	Note     string
+	Invoice  *stellar1.InvoiceContents
 }
 
 func MakeRequestGUI(m libkb.MetaContext, remoter remote.Remoter, arg MakeRequestArg) (ret stellar1.KeybaseRequestID, err error) {
@@ -1873,6 +1878,22 @@ func makeRequest(m libkb.MetaContext, remoter remote.Remoter, arg MakeRequestArg
 		return ret, fmt.Errorf("expected username or user assertion as recipient")
 	}
 
+	if arg.Invoice != nil {
+		// The invoice is encrypted for both users, so it cannot be sent to
+		// someone who is not on keybase yet.
+		if recipient.User == nil {
+			return ret, fmt.Errorf("invoices can only be sent to keybase users")
+		}
+		invoice := arg.Invoice.DeepCopy()
+		if err := completeInvoice(&invoice, arg.Amount); err != nil {
+			return ret, err
+		}
+		post.InvoiceB64, err = InvoiceEncryptB64(m, invoice, &recipient.User.UV)
+		if err != nil {
+			return ret, err
+		}
+	}
+
 	requestID, err := remoter.SubmitRequest(m.Ctx(), post)
 	if err != nil {
 		return ret, err
@@ -0,0 +1,114 @@
+package stellarsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosign(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+	alice := tcs[0].Backend.ImportAccountsForUser(tcs[0])[0].accountID
+	bob := tcs[1].Backend.ImportAccountsForUser(tcs[1])[0].accountID
+	tcs[0].Backend.Gift(alice, "100")
+	tcs[0].Backend.Gift(bob, "100")
+	ctx := context.Background()
+
+	// Alice is the only signer so far, the setup goes through right away.
+	setup, err := tcs[0].Srv.CosignSetupLocal(ctx, stellar1.CosignSetupLocalArg{
+		AccountID:       alice,
+		MasterWeight:    1,
+		Signers:         []stellar1.CosignSigner{{AccountID: bob, Weight: 1}},
+		LowThreshold:    1,
+		MediumThreshold: 2,
+		HighThreshold:   2,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, setup.SubmitTxID)
+	require.Len(t, setup.Status.Operations, 2)
+	signers, err := tcs[0].Backend.AccountSigners(alice)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []stellar1.CosignSigner{{AccountID: alice, Weight: 1}, {AccountID: bob, Weight: 1}}, signers.Signers)
+	require.Equal(t, 2, signers.Medium)
+
+	// A payment now needs bob's approval.
+	req, err := tcs[0].Srv.CosignRequestLocal(ctx, stellar1.CosignRequestLocalArg{
+		From:       alice,
+		To:         bob,
+		Amount:     "10",
+		PublicMemo: "rent",
+	})
+	require.NoError(t, err)
+	require.Nil(t, req.SubmitTxID)
+	require.False(t, req.Status.Ready)
+	require.Equal(t, 1, req.Status.CollectedWeight)
+	require.Equal(t, 2, req.Status.Threshold)
+	require.Equal(t, []stellar1.AccountID{alice}, req.Status.Signed)
+	require.Equal(t, []string{"pay 10.0000000 XLM to " + bob.String()}, req.Status.Operations)
+
+	_, err = tcs[0].Srv.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{EnvelopeXdr: req.EnvelopeXdr})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already signed")
+
+	status, err := tcs[1].Srv.CosignStatusLocal(ctx, req.EnvelopeXdr)
+	require.NoError(t, err)
+	require.Equal(t, req.Status, status)
+
+	// A signature over a different transaction doesn't count.
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(req.EnvelopeXdr, &env))
+	// The request stays valid long enough for bob to get to it.
+	require.NotNil(t, env.Tx.TimeBounds)
+	require.True(t, time.Unix(int64(env.Tx.TimeBounds.MaxTime), 0).After(time.Now().Add(24*time.Hour)))
+	payment := env.Tx.Operations[0].Body.MustPaymentOp()
+	payment.Amount *= 10
+	env.Tx.Operations[0].Body.PaymentOp = &payment
+	tampered, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	status, err = tcs[1].Srv.CosignStatusLocal(ctx, tampered)
+	require.NoError(t, err)
+	require.Zero(t, status.CollectedWeight)
+	require.Empty(t, status.Signed)
+
+	tcs[0].Backend.AssertBalance(alice, "100.0000000")
+	res, err := tcs[1].Srv.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{EnvelopeXdr: req.EnvelopeXdr})
+	require.NoError(t, err)
+	require.True(t, res.Status.Ready)
+	require.Equal(t, 2, res.Status.CollectedWeight)
+	require.NotNil(t, res.SubmitTxID)
+	require.Equal(t, req.Status.TxID, *res.SubmitTxID)
+	tcs[0].Backend.AssertBalance(alice, "90.0000000")
+	tcs[0].Backend.AssertBalance(bob, "110.0000000")
+	// Its sequence number is used up now.
+	_, err = tcs[1].Srv.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{EnvelopeXdr: req.EnvelopeXdr})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ask for a new one")
+
+	// Changing the signers needs the high threshold now.
+	setup, err = tcs[0].Srv.CosignSetupLocal(ctx, stellar1.CosignSetupLocalArg{
+		AccountID:    alice,
+		MasterWeight: 1,
+	})
+	require.NoError(t, err)
+	require.Nil(t, setup.SubmitTxID)
+	require.Equal(t, []string{"remove signer " + bob.String(), "set master key weight to 1, set thresholds to low 0, medium 0, high 0"},
+		setup.Status.Operations)
+	_, err = tcs[1].Srv.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{EnvelopeXdr: setup.EnvelopeXdr})
+	require.NoError(t, err)
+	signers, err = tcs[0].Backend.AccountSigners(alice)
+	require.NoError(t, err)
+	require.Equal(t, []stellar1.CosignSigner{{AccountID: alice, Weight: 1}}, signers.Signers)
+
+	// Bob is no longer a signer.
+	_, err = tcs[1].Srv.CosignSignLocal(ctx, stellar1.CosignSignLocalArg{EnvelopeXdr: req.EnvelopeXdr})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "none of your accounts")
+}
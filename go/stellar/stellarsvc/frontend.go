@@ -847,9 +847,37 @@ func (s *Server) MakeRequestLocal(ctx context.Context, arg stellar1.MakeRequestL
 		Asset:    arg.Asset,
 		Currency: arg.Currency,
 		Note:     arg.Note,
+		Invoice:  arg.Invoice,
 	})
 }
 
+func (s *Server) ListRequestsLocal(ctx context.Context, arg stellar1.ListRequestsLocalArg) (res []stellar1.RequestDetailsLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "ListRequestsLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.remoter.Requests(mctx.Ctx(), arg.IncludeClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	res = make([]stellar1.RequestDetailsLocal, 0, len(requests))
+	for _, details := range requests {
+		local, err := stellar.TransformRequestDetails(mctx, details)
+		if err != nil {
+			mctx.Debug("skipping request %s: %s", details.Id, err)
+			continue
+		}
+		res = append(res, *local)
+	}
+	return res, nil
+}
+
 func (s *Server) CancelRequestLocal(ctx context.Context, arg stellar1.CancelRequestLocalArg) (err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName: "CancelRequestLocal",
@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
 	"sync"
 	"testing"
@@ -14,6 +16,7 @@ import (
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/keybase1"
 	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar"
 	"github.com/keybase/client/go/stellar/remote"
 	"github.com/keybase/stellarnet"
 	"github.com/stellar/go/keypair"
@@ -321,6 +324,16 @@ type FakeAccount struct {
 	otherBalances []stellar1.Balance // other assets
 	subentries    int
 	inflationDest stellar1.AccountID
+	multisig      *remote.AccountSigners // nil while the master key is the only signer
+}
+
+func (a *FakeAccount) signers() remote.AccountSigners {
+	if a.multisig != nil {
+		return *a.multisig
+	}
+	return remote.AccountSigners{
+		Signers: []stellar1.CosignSigner{{AccountID: a.accountID, Weight: 1}},
+	}
 }
 
 func (a *FakeAccount) AddBalance(amt string) {
@@ -495,6 +508,10 @@ func (r *RemoteClientMock) RequestDetails(ctx context.Context, requestID stellar
 	return r.Backend.RequestDetails(ctx, r.Tc, requestID)
 }
 
+func (r *RemoteClientMock) Requests(ctx context.Context, includeClosed bool) ([]stellar1.RequestDetails, error) {
+	return r.Backend.Requests(ctx, r.Tc, includeClosed)
+}
+
 func (r *RemoteClientMock) CancelRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error {
 	return r.Backend.CancelRequest(ctx, r.Tc, requestID)
 }
@@ -588,8 +605,12 @@ func (r *RemoteClientMock) ListPopularAssets(_ libkb.MetaContext, _ stellar1.Lis
 	return stellar1.AssetListResult{}, errors.New("not mocked")
 }
 
-func (r *RemoteClientMock) PostAnyTransaction(_ libkb.MetaContext, _ string) error {
-	return errors.New("post any transaction is not mocked")
+func (r *RemoteClientMock) PostAnyTransaction(_ libkb.MetaContext, signedTx string) error {
+	return r.Backend.PostAnyTransaction(r.Tc, signedTx)
+}
+
+func (r *RemoteClientMock) AccountSigners(_ libkb.MetaContext, accountID stellar1.AccountID) (remote.AccountSigners, error) {
+	return r.Backend.AccountSigners(accountID)
 }
 
 var _ remote.Remoter = (*RemoteClientMock)(nil)
@@ -782,6 +803,16 @@ func (r *BackendMock) SubmitPayment(ctx context.Context, tc *TestContext, post s
 		MemoType:      memoType,
 		ExternalTxURL: fmt.Sprintf("https://stellar.expert/explorer/public/tx/%s", txIDPrecalc),
 	})
+	if post.RequestID != nil {
+		r.addRequestPayment(*post.RequestID, stellar1.RequestPayment{
+			KbTxID:          kbTxID,
+			TxID:            stellar1.TransactionID(txIDPrecalc),
+			Amount:          extract.Amount,
+			DisplayAmount:   post.DisplayAmount,
+			DisplayCurrency: post.DisplayCurrency,
+			Ctime:           stellar1.ToTimeMs(time.Now()),
+		})
+	}
 
 	return stellar1.PaymentResult{
 		StellarID: stellar1.TransactionID(txIDPrecalc),
@@ -856,6 +887,16 @@ func (r *BackendMock) SubmitRelayPayment(ctx context.Context, tc *TestContext, p
 		TeamID:          post.TeamID,
 	})
 	r.addPayment(extract.From, stellar1.PaymentDetails{Summary: summary})
+	if post.RequestID != nil {
+		r.addRequestPayment(*post.RequestID, stellar1.RequestPayment{
+			KbTxID:          kbTxID,
+			TxID:            stellar1.TransactionID(txIDPrecalc),
+			Amount:          extract.Amount,
+			DisplayAmount:   post.DisplayAmount,
+			DisplayCurrency: post.DisplayCurrency,
+			Ctime:           stellar1.ToTimeMs(time.Now()),
+		})
+	}
 
 	return stellar1.PaymentResult{
 		StellarID: stellar1.TransactionID(txIDPrecalc),
@@ -971,10 +1012,24 @@ func (r *BackendMock) RecentPayments(ctx context.Context, tc *TestContext, accou
 	defer tc.G.CTrace(ctx, "BackendMock.RecentPayments", &err)()
 	r.Lock()
 	defer r.Unlock()
+	// The mock cursor is just the offset of the next page in HorizonCursor.
+	var offset int
 	if cursor != nil {
-		return res, errors.New("cursor not mocked")
+		offset, err = strconv.Atoi(cursor.HorizonCursor)
+		if err != nil {
+			return res, fmt.Errorf("invalid mock cursor: %v", err)
+		}
+	}
+	payments := r.txLog.Filter(ctx, tc, accountID, 0, skipPending)
+	if offset > len(payments) {
+		offset = len(payments)
 	}
-	res.Payments = r.txLog.Filter(ctx, tc, accountID, limit, skipPending)
+	payments = payments[offset:]
+	if limit > 0 && len(payments) > limit {
+		payments = payments[:limit]
+		res.Cursor = &stellar1.PageCursor{HorizonCursor: strconv.Itoa(offset + limit)}
+	}
+	res.Payments = payments
 	return res, nil
 }
 
@@ -1232,10 +1287,56 @@ func (r *BackendMock) SubmitRequest(ctx context.Context, tc *TestContext, post s
 		Amount:      post.Amount,
 		Asset:       post.Asset,
 		Currency:    post.Currency,
+		InvoiceB64:  post.InvoiceB64,
+		Ctime:       stellar1.ToTimeMs(time.Now()),
 	}
 	return reqID, nil
 }
 
+// addRequestPayment records a payment toward a request and marks the request
+// done once it is paid in full. Must be called with the lock held.
+func (r *BackendMock) addRequestPayment(requestID stellar1.KeybaseRequestID, payment stellar1.RequestPayment) {
+	details, ok := r.requests[requestID]
+	require.True(r.T, ok, "payment toward unknown request %v", requestID)
+	details.Payments = append(details.Payments, payment)
+	paid := new(big.Rat)
+	for _, p := range details.Payments {
+		amount := p.Amount
+		if details.Currency != nil {
+			amount = p.DisplayAmount
+		}
+		a, err := stellarnet.ParseAmount(amount)
+		require.NoError(r.T, err)
+		paid.Add(paid, a)
+	}
+	requested, err := stellarnet.ParseAmount(details.Amount)
+	require.NoError(r.T, err)
+	if paid.Cmp(requested) >= 0 {
+		details.Status = stellar1.RequestStatus_DONE
+		details.FundingKbTxID = payment.KbTxID
+	}
+}
+
+func (r *BackendMock) Requests(ctx context.Context, tc *TestContext, includeClosed bool) (res []stellar1.RequestDetails, err error) {
+	caller, err := tc.G.GetMeUV(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get self UV: %v", err)
+	}
+	r.Lock()
+	defer r.Unlock()
+	for _, details := range r.requests {
+		if !details.FromUser.Eq(caller) && (details.ToUser == nil || !details.ToUser.Eq(caller)) {
+			continue
+		}
+		if !includeClosed && details.Status != stellar1.RequestStatus_OK {
+			continue
+		}
+		res = append(res, details.DeepCopy())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Ctime > res[j].Ctime })
+	return res, nil
+}
+
 func (r *BackendMock) RequestDetails(ctx context.Context, tc *TestContext, requestID stellar1.KeybaseRequestID) (res stellar1.RequestDetails, err error) {
 	details, ok := r.requests[requestID]
 	if !ok {
@@ -1394,6 +1495,109 @@ func (r *BackendMock) ChangeTrustline(ctx context.Context, tc *TestContext, sign
 	return nil
 }
 
+func (r *BackendMock) AccountSigners(accountID stellar1.AccountID) (res remote.AccountSigners, err error) {
+	defer r.trace(&err, "BackendMock.AccountSigners", "%v", accountID)()
+	r.Lock()
+	defer r.Unlock()
+	account, ok := r.accounts[accountID]
+	if !ok {
+		return res, libkb.NotFoundError{Msg: fmt.Sprintf("account %v not found", accountID)}
+	}
+	res = account.signers()
+	res.Seqno = r.seqnos[accountID]
+	return res, nil
+}
+
+// PostAnyTransaction checks the signatures of the transaction against the
+// signers of its source account like the network would, and then applies its
+// payment and signer operations.
+func (r *BackendMock) PostAnyTransaction(tc *TestContext, signedTx string) (err error) {
+	defer r.trace(&err, "BackendMock.PostAnyTransaction", "")()
+	unpackedTx, _, err := unpackTx(signedTx)
+	if err != nil {
+		return err
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	accountID := stellar1.AccountID(unpackedTx.Tx.SourceAccount.Address())
+	account, ok := r.accounts[accountID]
+	if !ok {
+		return fmt.Errorf("source account %v not found", accountID)
+	}
+	status, err := stellar.CosignEnvelopeStatus(unpackedTx, account.signers())
+	if err != nil {
+		return err
+	}
+	if !status.Ready {
+		return fmt.Errorf("tx_bad_auth: signature weight %d is below threshold %d", status.CollectedWeight, status.Threshold)
+	}
+
+	for _, op := range unpackedTx.Tx.Operations {
+		require.Nil(tc.T, op.SourceAccount)
+		switch op.Body.Type {
+		case xdr.OperationTypePayment:
+			payment := op.Body.MustPaymentOp()
+			require.Equal(tc.T, xdr.AssetTypeAssetTypeNative, payment.Asset.Type, "only XLM payments are mocked")
+			to, ok := r.accounts[stellar1.AccountID(payment.Destination.Address())]
+			if !ok {
+				return fmt.Errorf("op_no_destination")
+			}
+			available, err := stellarnet.ParseStellarAmount(account.availableBalance())
+			require.NoError(tc.T, err)
+			if available < int64(payment.Amount) {
+				return fmt.Errorf("op_underfunded")
+			}
+			account.AdjustBalance(-int64(payment.Amount))
+			to.AdjustBalance(int64(payment.Amount))
+		case xdr.OperationTypeSetOptions:
+			setOpt := op.Body.MustSetOptionsOp()
+			multisig := account.signers()
+			if setOpt.Signer != nil {
+				signer := stellar1.AccountID(setOpt.Signer.Key.Address())
+				var signers []stellar1.CosignSigner
+				for _, s := range multisig.Signers {
+					if !s.AccountID.Eq(signer) {
+						signers = append(signers, s)
+					}
+				}
+				if setOpt.Signer.Weight > 0 {
+					signers = append(signers, stellar1.CosignSigner{AccountID: signer, Weight: int(setOpt.Signer.Weight)})
+				}
+				multisig.Signers = signers
+			}
+			if setOpt.MasterWeight != nil {
+				var signers []stellar1.CosignSigner
+				for _, s := range multisig.Signers {
+					if s.AccountID.Eq(accountID) {
+						s.Weight = int(*setOpt.MasterWeight)
+					}
+					if s.Weight > 0 {
+						signers = append(signers, s)
+					}
+				}
+				multisig.Signers = signers
+			}
+			if setOpt.LowThreshold != nil {
+				multisig.Low = int(*setOpt.LowThreshold)
+			}
+			if setOpt.MedThreshold != nil {
+				multisig.Medium = int(*setOpt.MedThreshold)
+			}
+			if setOpt.HighThreshold != nil {
+				multisig.High = int(*setOpt.HighThreshold)
+			}
+			account.multisig = &multisig
+		default:
+			require.Fail(tc.T, "operation not mocked", "%v", op.Body.Type)
+		}
+	}
+	r.seqnos[accountID]++
+
+	tc.T.Logf("BackendMock posted transaction %s from %s", status.TxID, accountID)
+	return nil
+}
+
 // Friendbot sends someone XLM
 func (r *BackendMock) Gift(accountID stellar1.AccountID, amount string) {
 	r.Lock()
@@ -0,0 +1,95 @@
+package stellarsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPayments(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+	alice := tcs[0].Backend.ImportAccountsForUser(tcs[0])[0].accountID
+	bob := tcs[1].Backend.ImportAccountsForUser(tcs[1])[0].accountID
+	tcs[0].Backend.Gift(alice, "100")
+	tcs[0].Backend.Gift(bob, "100")
+	ctx := context.Background()
+	srv := tcs[0].Srv
+
+	// Started ten days ago, so it is due and its next run is in four days.
+	start := time.Now().Add(-10 * 24 * time.Hour).Round(time.Millisecond)
+	weekly, err := srv.SchedulePaymentLocal(ctx, stellar1.SchedulePaymentLocalArg{
+		To:         tcs[1].Fu.Username,
+		Amount:     "10",
+		Note:       "allowance",
+		Recurrence: stellar1.ScheduledPaymentRecurrence_WEEKLY,
+		Start:      stellar1.ToTimeMs(start),
+	})
+	require.NoError(t, err)
+	require.Equal(t, alice, weekly.From)
+	require.Equal(t, stellar1.ToTimeMs(start), weekly.NextRun)
+
+	tooMuch, err := srv.SchedulePaymentLocal(ctx, stellar1.SchedulePaymentLocalArg{
+		To:     bob.String(),
+		Amount: "500",
+	})
+	require.NoError(t, err)
+
+	_, err = srv.SchedulePaymentLocal(ctx, stellar1.SchedulePaymentLocalArg{
+		To:         bob.String(),
+		Amount:     "1",
+		PublicMemo: "this memo is far too long for a stellar transaction",
+	})
+	require.Error(t, err)
+
+	err = stellar.RunScheduledPayments(tcs[0].MetaContext(), srv.walletState)
+	require.NoError(t, err)
+
+	payments, err := srv.ListScheduledPaymentsLocal(ctx)
+	require.NoError(t, err)
+	require.Len(t, payments, 2)
+	byID := make(map[stellar1.ScheduledPaymentID]stellar1.ScheduledPaymentLocal)
+	for _, p := range payments {
+		byID[p.Id] = p
+	}
+
+	p := byID[weekly.Id]
+	require.Equal(t, 1, p.Runs)
+	require.NotNil(t, p.LastRun)
+	require.Equal(t, stellar1.ScheduledPaymentRunStatus_SENT, p.LastRun.Status, p.LastRun.Error)
+	require.NotNil(t, p.LastRun.TxID)
+	require.Equal(t, stellar1.ToTimeMs(start.AddDate(0, 0, 14)), p.NextRun)
+
+	p = byID[tooMuch.Id]
+	require.Equal(t, 1, p.Runs)
+	require.NotNil(t, p.LastRun)
+	require.Equal(t, stellar1.ScheduledPaymentRunStatus_SKIPPED, p.LastRun.Status)
+	require.Zero(t, p.NextRun)
+
+	balances, err := tcs[1].Srv.BalancesLocal(ctx, bob)
+	require.NoError(t, err)
+	require.Equal(t, "110.0000000", balances[0].Amount)
+
+	// Nothing is due anymore.
+	err = stellar.RunScheduledPayments(tcs[0].MetaContext(), srv.walletState)
+	require.NoError(t, err)
+	balances, err = tcs[1].Srv.BalancesLocal(ctx, bob)
+	require.NoError(t, err)
+	require.Equal(t, "110.0000000", balances[0].Amount)
+
+	err = srv.CancelScheduledPaymentLocal(ctx, weekly.Id)
+	require.NoError(t, err)
+	err = srv.CancelScheduledPaymentLocal(ctx, weekly.Id)
+	require.Error(t, err)
+	payments, err = srv.ListScheduledPaymentsLocal(ctx)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	require.Equal(t, tooMuch.Id, payments[0].Id)
+}
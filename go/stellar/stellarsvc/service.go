@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"time"
 
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/stellar1"
@@ -210,6 +211,7 @@ func (s *Server) SendCLILocal(ctx context.Context, arg stellar1.SendCLILocalArg)
 		ForceRelay:     arg.ForceRelay,
 		QuickReturn:    false,
 		PublicMemo:     memo,
+		RequestID:      arg.RequestID,
 	})
 	if err != nil {
 		return res, err
@@ -350,6 +352,40 @@ func (s *Server) RecentPaymentsCLILocal(ctx context.Context, accountID *stellar1
 	return stellar.RecentPaymentsCLILocal(mctx, s.remoter, selectAccountID)
 }
 
+func (s *Server) ExportHistoryCLILocal(ctx context.Context, arg stellar1.ExportHistoryCLILocalArg) (res []stellar1.PaymentExportCLILocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "ExportHistoryCLILocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return nil, err
+	}
+
+	exportArg := stellar.ExportHistoryArg{
+		Currency:    stellar1.OutsideCurrencyCode(arg.Currency),
+		IncludeFees: arg.IncludeFees,
+	}
+	if arg.AccountID == nil {
+		exportArg.AccountID, err = stellar.GetOwnPrimaryAccountID(mctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		exportArg.AccountID = *arg.AccountID
+	}
+	if arg.From != nil {
+		from := arg.From.Time()
+		exportArg.From = &from
+	}
+	if arg.To != nil {
+		to := arg.To.Time()
+		exportArg.To = &to
+	}
+	return stellar.ExportHistory(mctx, s.remoter, exportArg)
+}
+
 func (s *Server) PaymentDetailCLILocal(ctx context.Context, txID string) (res stellar1.PaymentCLILocal, err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName: "PaymentDetailCLILocal",
@@ -580,6 +616,7 @@ func (s *Server) MakeRequestCLILocal(ctx context.Context, arg stellar1.MakeReque
 		Asset:    arg.Asset,
 		Currency: arg.Currency,
 		Note:     arg.Note,
+		Invoice:  arg.Invoice,
 	})
 }
 
@@ -1099,6 +1136,108 @@ func (s *Server) SignTransactionXdrLocal(ctx context.Context, arg stellar1.SignT
 	return res, nil
 }
 
+func (s *Server) CosignSetupLocal(ctx context.Context, arg stellar1.CosignSetupLocalArg) (res stellar1.CosignResultLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "CosignSetupLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.CosignSetup(mctx, s.walletState, arg)
+}
+
+func (s *Server) CosignRequestLocal(ctx context.Context, arg stellar1.CosignRequestLocalArg) (res stellar1.CosignResultLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "CosignRequestLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.CosignRequest(mctx, s.walletState, arg)
+}
+
+func (s *Server) CosignStatusLocal(ctx context.Context, envelopeXdr string) (res stellar1.CosignStatusLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "CosignStatusLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.CosignStatus(mctx, s.walletState, envelopeXdr)
+}
+
+func (s *Server) CosignSignLocal(ctx context.Context, arg stellar1.CosignSignLocalArg) (res stellar1.CosignResultLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "CosignSignLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.CosignSign(mctx, s.walletState, arg.EnvelopeXdr, arg.Channel)
+}
+
+func (s *Server) SchedulePaymentLocal(ctx context.Context, arg stellar1.SchedulePaymentLocalArg) (res stellar1.ScheduledPaymentLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "SchedulePaymentLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	var start time.Time
+	if arg.Start != 0 {
+		start = arg.Start.Time()
+	}
+	return stellar.SchedulePayment(mctx, s.walletState, stellar.SchedulePaymentArg{
+		From:       arg.From,
+		To:         stellarcommon.RecipientInput(arg.To),
+		Amount:     arg.Amount,
+		Currency:   arg.Currency,
+		SecretNote: arg.Note,
+		PublicMemo: arg.PublicMemo,
+		Recurrence: arg.Recurrence,
+		Start:      start,
+	})
+}
+
+func (s *Server) ListScheduledPaymentsLocal(ctx context.Context) (res []stellar1.ScheduledPaymentLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "ListScheduledPaymentsLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+	return stellar.ListScheduledPayments(mctx)
+}
+
+func (s *Server) CancelScheduledPaymentLocal(ctx context.Context, id stellar1.ScheduledPaymentID) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "CancelScheduledPaymentLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+	return stellar.CancelScheduledPayment(mctx, id)
+}
+
 func postXDRToCallback(signed, callbackURL string) error {
 	u, err := url.Parse(callbackURL)
 	if err != nil {
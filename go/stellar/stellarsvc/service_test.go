@@ -492,6 +492,80 @@ func TestRecentPaymentsLocal(t *testing.T) {
 	checkPayment(payment)
 }
 
+func TestExportHistory(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+	tcs[0].Backend.ImportAccountsForUser(tcs[0])
+	tcs[1].Backend.ImportAccountsForUser(tcs[1])
+	accountIDSender := getPrimaryAccountID(tcs[0])
+	accountIDRecip := getPrimaryAccountID(tcs[1])
+	tcs[0].Backend.Gift(accountIDSender, "100")
+
+	for _, arg := range []stellar1.SendCLILocalArg{
+		{Amount: "10", Note: "rent"},
+		{Amount: "20", DisplayAmount: "6.37", DisplayCurrency: "USD"},
+		{Amount: "30", DisplayAmount: "5.59", DisplayCurrency: "EUR"},
+	} {
+		arg.Recipient = tcs[1].Fu.Username
+		arg.Asset = stellar1.AssetNative()
+		_, err := tcs[0].Srv.SendCLILocal(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	// A small page limit makes the export follow the cursor across pages.
+	rows, err := stellar.ExportHistory(tcs[0].MetaContext(), tcs[0].Srv.remoter, stellar.ExportHistoryArg{
+		AccountID: accountIDSender,
+		PageLimit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	for i, amount := range []string{"10.0000000", "20.0000000", "30.0000000"} {
+		row := rows[i]
+		require.Equal(t, amount, row.Payment.Amount)
+		require.True(t, row.Sent)
+		require.Equal(t, tcs[1].Fu.Username, row.Counterparty)
+		require.Equal(t, accountIDRecip, *row.Payment.ToStellar)
+		require.Equal(t, "USD", row.WorthCurrency)
+		// Fees are only looked up with IncludeFees.
+		require.Empty(t, row.FeeCharged)
+	}
+	require.Equal(t, "rent", rows[0].Payment.Note)
+	// The mock records the sender's worth as 123.23 USD.
+	require.Equal(t, "123.23", rows[0].Worth)
+	require.Equal(t, "6.37", rows[1].Worth)
+
+	// The recipient sees the same payments as received, valued in EUR.
+	recipRows, err := tcs[1].Srv.ExportHistoryCLILocal(context.Background(), stellar1.ExportHistoryCLILocalArg{
+		Currency: "EUR",
+	})
+	require.NoError(t, err)
+	require.Len(t, recipRows, 3)
+	for _, row := range recipRows {
+		require.False(t, row.Sent)
+		require.Equal(t, tcs[0].Fu.Username, row.Counterparty)
+		require.Empty(t, row.FeeCharged)
+	}
+	// Nothing was recorded in EUR at the time of the first payment.
+	require.Empty(t, recipRows[0].Worth)
+	require.Equal(t, "5.59", recipRows[2].Worth)
+
+	future := stellar1.ToTimeMs(time.Now().Add(time.Hour))
+	recipRows, err = tcs[1].Srv.ExportHistoryCLILocal(context.Background(), stellar1.ExportHistoryCLILocalArg{
+		From: &future,
+	})
+	require.NoError(t, err)
+	require.Empty(t, recipRows)
+	past := stellar1.ToTimeMs(time.Now().Add(-time.Hour))
+	recipRows, err = tcs[1].Srv.ExportHistoryCLILocal(context.Background(), stellar1.ExportHistoryCLILocalArg{
+		To: &past,
+	})
+	require.NoError(t, err)
+	require.Empty(t, recipRows)
+}
+
 func TestRelayTransferInnards(t *testing.T) {
 	tcs, cleanup := setupNTests(t, 2)
 	defer cleanup()
@@ -908,6 +982,94 @@ func TestRequestPaymentOutsideCurrency(t *testing.T) {
 	require.Equal(t, "$8.20 USD", details.AmountDescription)
 }
 
+func TestRequestPaymentInvoice(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+	tcs[0].Backend.ImportAccountsForUser(tcs[0])
+	payer := tcs[1].Backend.ImportAccountsForUser(tcs[1])[0].accountID
+	tcs[1].Backend.Gift(payer, "100")
+	ctx := context.Background()
+
+	xlm := stellar1.AssetNative()
+	due := stellar1.ToTimeMs(time.Now().Add(7 * 24 * time.Hour))
+	invoice := stellar1.InvoiceContents{
+		LineItems: []stellar1.InvoiceLineItem{
+			{Description: "Hosting", Quantity: "2", UnitAmount: "10"},
+			{Description: "Domain", Amount: "10"},
+		},
+		DueDate:   due,
+		Reference: "INV-7",
+	}
+	_, err := tcs[0].Srv.MakeRequestCLILocal(ctx, stellar1.MakeRequestCLILocalArg{
+		Recipient: tcs[1].Fu.Username,
+		Asset:     &xlm,
+		Amount:    "25",
+		Invoice:   &invoice,
+	})
+	require.Error(t, err, "line items do not add up to the amount")
+
+	reqID, err := tcs[0].Srv.MakeRequestCLILocal(ctx, stellar1.MakeRequestCLILocalArg{
+		Recipient: tcs[1].Fu.Username,
+		Asset:     &xlm,
+		Amount:    "30",
+		Invoice:   &invoice,
+	})
+	require.NoError(t, err)
+
+	// The payer can read the invoice.
+	details, err := tcs[1].Srv.GetRequestDetailsLocal(ctx, stellar1.GetRequestDetailsLocalArg{
+		ReqID: reqID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, details.Invoice)
+	require.Len(t, details.Invoice.LineItems, 2)
+	require.Equal(t, "20", details.Invoice.LineItems[0].Amount)
+	require.Equal(t, "10", details.Invoice.LineItems[1].Amount)
+	require.Equal(t, due, details.Invoice.DueDate)
+	require.Equal(t, "INV-7", details.Invoice.Reference)
+	require.Equal(t, "0", details.AmountPaid)
+
+	pay := func(amount string) {
+		_, err := tcs[1].Srv.SendCLILocal(ctx, stellar1.SendCLILocalArg{
+			Recipient: tcs[0].Fu.Username,
+			Amount:    amount,
+			Asset:     xlm,
+			RequestID: &reqID,
+		})
+		require.NoError(t, err)
+	}
+
+	pay("10")
+	details, err = tcs[0].Srv.GetRequestDetailsLocal(ctx, stellar1.GetRequestDetailsLocalArg{
+		ReqID: reqID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stellar1.RequestStatus_OK, details.Status)
+	require.Equal(t, "10", details.AmountPaid)
+	require.Equal(t, "10 XLM", details.AmountPaidDescription)
+	require.Len(t, details.Payments, 1)
+
+	requests, err := tcs[0].Srv.ListRequestsLocal(ctx, stellar1.ListRequestsLocalArg{})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, reqID, requests[0].Id)
+	require.NotNil(t, requests[0].Invoice)
+
+	pay("20")
+	requests, err = tcs[1].Srv.ListRequestsLocal(ctx, stellar1.ListRequestsLocalArg{})
+	require.NoError(t, err)
+	require.Len(t, requests, 0)
+	requests, err = tcs[1].Srv.ListRequestsLocal(ctx, stellar1.ListRequestsLocalArg{IncludeClosed: true})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, stellar1.RequestStatus_DONE, requests[0].Status)
+	require.Equal(t, "30", requests[0].AmountPaid)
+	require.Len(t, requests[0].Payments, 2)
+}
+
 func TestBundleFlows(t *testing.T) {
 	tcs, cleanup := setupNTests(t, 1)
 	defer cleanup()
@@ -1446,8 +1608,8 @@ func TestSignTransactionXdr(t *testing.T) {
 	require.Nil(t, res.SubmitErr)
 	require.Nil(t, res.SubmitTxID)
 
-	// Submitting - we are not mocking whole submit process, but we can test if
-	// the flag does anything.
+	// Submitting.
+	tcs[0].Backend.Gift(accounts[0].accountID, "1000")
 	res, err = tcs[0].Srv.SignTransactionXdrLocal(context.Background(), stellar1.SignTransactionXdrLocalArg{
 		EnvelopeXdr: unsigned,
 		Submit:      true,
@@ -1455,9 +1617,9 @@ func TestSignTransactionXdr(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, accounts[0].accountID, res.AccountID)
 	require.Equal(t, signRes.Signed, res.SingedTx)
-	require.NotNil(t, res.SubmitErr)
-	require.Contains(t, *res.SubmitErr, "post any transaction is not mocked")
-	require.Nil(t, res.SubmitTxID)
+	require.Nil(t, res.SubmitErr)
+	require.NotNil(t, res.SubmitTxID)
+	require.Equal(t, signRes.TxHash, res.SubmitTxID.String())
 
 	var emptyResult stellar1.SignXdrResult
 
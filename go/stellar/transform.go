@@ -64,6 +64,18 @@ func TransformRequestDetails(mctx libkb.MetaContext, details stellar1.RequestDet
 		Asset:           details.Asset,
 		Currency:        details.Currency,
 		Status:          details.Status,
+		AmountPaid:      requestAmountPaid(mctx, details),
+		Payments:        details.Payments,
+		Ctime:           details.Ctime,
+	}
+
+	if details.InvoiceB64 != "" {
+		invoice, err := InvoiceDecryptB64(mctx, details.InvoiceB64)
+		if err != nil {
+			mctx.Debug("error decrypting invoice of request %s: %s", details.Id, err)
+		} else {
+			loc.Invoice = &invoice
+		}
 	}
 
 	if details.ToUser != nil {
@@ -80,6 +92,11 @@ func TransformRequestDetails(mctx libkb.MetaContext, details stellar1.RequestDet
 			mctx.Debug("error formatting external currency: %s", err)
 		}
 		loc.AmountDescription = fmt.Sprintf("%s %s", amountDesc, *details.Currency)
+		paidDesc, err := FormatCurrency(mctx, loc.AmountPaid, *details.Currency, stellarnet.Round)
+		if err != nil {
+			paidDesc = loc.AmountPaid
+		}
+		loc.AmountPaidDescription = fmt.Sprintf("%s %s", paidDesc, *details.Currency)
 	case details.Asset != nil:
 		var code string
 		if details.Asset.IsNativeXLM() {
@@ -99,6 +116,11 @@ func TransformRequestDetails(mctx libkb.MetaContext, details stellar1.RequestDet
 			mctx.Debug("error formatting amount for asset: %s", err)
 		}
 		loc.AmountDescription = amountDesc
+		paidDesc, err := FormatAmountWithSuffix(mctx, loc.AmountPaid, false /* precisionTwo */, true /* simplify */, code)
+		if err != nil {
+			paidDesc = fmt.Sprintf("%s %s", loc.AmountPaid, code)
+		}
+		loc.AmountPaidDescription = paidDesc
 	default:
 		return nil, errors.New("malformed request - currency/asset not defined")
 	}
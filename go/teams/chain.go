@@ -2491,6 +2491,10 @@ func (t *teamSigchainPlayer) parseTeamBotSettings(bots []SCTeamBot, newState *Te
 		if bot.Convs != nil {
 			convs = *bot.Convs
 		}
+		var senders []keybase1.UID
+		if bot.Senders != nil {
+			senders = *bot.Senders
+		}
 		if newState.inner.Bots == nil {
 			// If an old client cached this as nil, then just make a new map here for this link
 			newState.inner.Bots = make(map[keybase1.UserVersion]keybase1.TeamBotSettings)
@@ -2500,6 +2504,8 @@ func (t *teamSigchainPlayer) parseTeamBotSettings(bots []SCTeamBot, newState *Te
 			Mentions: bot.Mentions,
 			Triggers: triggers,
 			Convs:    convs,
+			CmdsOnly: bot.CmdsOnly,
+			Senders:  senders,
 		}
 	}
 	return nil
@@ -157,6 +157,11 @@ type SCTeamBot struct {
 	Triggers *[]string `json:"triggers,omitempty"`
 	// Conversations the bot can participate in, `nil` indicates all
 	Convs *[]string `json:"convs,omitempty"`
+	// Should the bot only be summoned for !-commands, ignoring @-mentions and
+	// triggers
+	CmdsOnly bool `json:"cmds_only,omitempty"`
+	// Users whose messages the bot can be summoned for, `nil` indicates all
+	Senders *[]keybase1.UID `json:"senders,omitempty"`
 }
 
 func ToSCTeamBotUV(uv keybase1.UserVersion) SCTeamBotUV {
@@ -442,6 +447,12 @@ func CreateTeamBotSettings(bots map[keybase1.UserVersion]keybase1.TeamBotSetting
 				return nil, err
 			}
 		}
+		// Sanity check the senders are well formed
+		for _, sender := range botSettings.Senders {
+			if _, err := keybase1.UIDFromString(sender.String()); err != nil {
+				return nil, err
+			}
+		}
 		var convs, triggers *[]string
 		if len(botSettings.Triggers) > 0 {
 			triggers = &(botSettings.Triggers)
@@ -449,12 +460,18 @@ func CreateTeamBotSettings(bots map[keybase1.UserVersion]keybase1.TeamBotSetting
 		if len(botSettings.Convs) > 0 {
 			convs = &(botSettings.Convs)
 		}
+		var senders *[]keybase1.UID
+		if len(botSettings.Senders) > 0 {
+			senders = &(botSettings.Senders)
+		}
 		res = append(res, SCTeamBot{
 			Bot:      ToSCTeamBotUV(bot),
 			Cmds:     botSettings.Cmds,
 			Mentions: botSettings.Mentions,
 			Triggers: triggers,
 			Convs:    convs,
+			CmdsOnly: botSettings.CmdsOnly,
+			Senders:  senders,
 		})
 	}
 	return res, nil
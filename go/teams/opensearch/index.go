@@ -0,0 +1,170 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package opensearch
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+)
+
+// tokenIndex maps a lowercased token from a team name or description to the
+// teams containing it. It is stored alongside the cached open teams so that
+// searches only have to score teams sharing a token with the query.
+type tokenIndex struct {
+	IDs map[string][]keybase1.TeamID
+	// Tokens holds the keys of IDs in sorted order, for prefix lookups.
+	Tokens []string
+}
+
+// itemTokens returns every suffix of the team name and the words of its
+// description. ScoreName rewards a query token found anywhere in the name,
+// which is a prefix of one of its suffixes.
+func itemTokens(item keybase1.TeamSearchItem) (res []string) {
+	seen := make(map[string]bool)
+	add := func(tok string) {
+		if len(tok) == 0 || seen[tok] {
+			return
+		}
+		seen[tok] = true
+		res = append(res, tok)
+	}
+	name := strings.ToLower(item.Name)
+	for i := range name {
+		add(name[i:])
+	}
+	if item.Description != nil {
+		for tok := range strings.SplitSeq(strings.ToLower(*item.Description), " ") {
+			add(tok)
+		}
+	}
+	return res
+}
+
+func (idx *tokenIndex) add(item keybase1.TeamSearchItem) {
+	for _, tok := range itemTokens(item) {
+		if _, ok := idx.IDs[tok]; !ok {
+			i, _ := slices.BinarySearch(idx.Tokens, tok)
+			idx.Tokens = slices.Insert(idx.Tokens, i, tok)
+		}
+		idx.IDs[tok] = append(idx.IDs[tok], item.Id)
+	}
+}
+
+func (idx *tokenIndex) remove(item keybase1.TeamSearchItem) {
+	for _, tok := range itemTokens(item) {
+		ids := slices.DeleteFunc(idx.IDs[tok], func(id keybase1.TeamID) bool {
+			return id == item.Id
+		})
+		if len(ids) > 0 {
+			idx.IDs[tok] = ids
+			continue
+		}
+		delete(idx.IDs, tok)
+		if i, ok := slices.BinarySearch(idx.Tokens, tok); ok {
+			idx.Tokens = slices.Delete(idx.Tokens, i, i+1)
+		}
+	}
+}
+
+func newTokenIndex(items teamMap) *tokenIndex {
+	idx := &tokenIndex{IDs: make(map[string][]keybase1.TeamID)}
+	for _, item := range items {
+		for _, tok := range itemTokens(item) {
+			idx.IDs[tok] = append(idx.IDs[tok], item.Id)
+		}
+	}
+	idx.Tokens = slices.Sorted(maps.Keys(idx.IDs))
+	return idx
+}
+
+func sameTokens(a, b keybase1.TeamSearchItem) bool {
+	return slices.Equal(itemTokens(a), itemTokens(b))
+}
+
+// update brings the index from prev in line with next, only touching the
+// entries of teams which were added, removed or whose text changed.
+func (idx *tokenIndex) update(prev, next teamMap) (changed int) {
+	for id, item := range prev {
+		if nextItem, ok := next[id]; !ok || !sameTokens(item, nextItem) {
+			idx.remove(item)
+			changed++
+		}
+	}
+	for id, item := range next {
+		if prevItem, ok := prev[id]; !ok || !sameTokens(prevItem, item) {
+			idx.add(item)
+			changed++
+		}
+	}
+	return changed
+}
+
+// candidates returns the teams with a token starting with one of the query
+// tokens. Every team that can receive a nonzero score from
+// rankedSearchItem.Score is included.
+func (idx *tokenIndex) candidates(query string) map[keybase1.TeamID]bool {
+	res := make(map[keybase1.TeamID]bool)
+	for qtok := range strings.SplitSeq(strings.ToLower(query), " ") {
+		i, _ := slices.BinarySearch(idx.Tokens, qtok)
+		for ; i < len(idx.Tokens) && strings.HasPrefix(idx.Tokens[i], qtok); i++ {
+			for _, id := range idx.IDs[idx.Tokens[i]] {
+				res[id] = true
+			}
+		}
+	}
+	return res
+}
+
+func filterIsEmpty(filter keybase1.TeamSearchFilter) bool {
+	return filter.MinMemberCount <= 0 && filter.MaxMemberCount <= 0 &&
+		filter.ActiveSince == nil
+}
+
+func matchesFilter(item keybase1.TeamSearchItem, filter keybase1.TeamSearchFilter) bool {
+	if filter.MinMemberCount > 0 && item.MemberCount < filter.MinMemberCount {
+		return false
+	}
+	if filter.MaxMemberCount > 0 && item.MemberCount > filter.MaxMemberCount {
+		return false
+	}
+	if filter.ActiveSince != nil && item.LastActive < *filter.ActiveSince {
+		return false
+	}
+	return true
+}
+
+func sortResults(results []rankedSearchItem, order keybase1.TeamSearchSortOrder) {
+	var less func(a, b rankedSearchItem) bool
+	switch order {
+	case keybase1.TeamSearchSortOrder_MEMBER_COUNT:
+		less = func(a, b rankedSearchItem) bool {
+			return a.item.MemberCount > b.item.MemberCount
+		}
+	case keybase1.TeamSearchSortOrder_LAST_ACTIVE:
+		less = func(a, b rankedSearchItem) bool {
+			return a.item.LastActive > b.item.LastActive
+		}
+	case keybase1.TeamSearchSortOrder_NAME:
+		less = func(a, b rankedSearchItem) bool {
+			return strings.ToLower(a.item.Name) < strings.ToLower(b.item.Name)
+		}
+	default:
+		less = func(a, b rankedSearchItem) bool {
+			return a.score > b.score
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if less(results[i], results[j]) {
+			return true
+		}
+		if less(results[j], results[i]) {
+			return false
+		}
+		return results[i].item.Name < results[j].item.Name
+	})
+}
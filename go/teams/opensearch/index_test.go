@@ -0,0 +1,90 @@
+package opensearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func makeSearchItem(id, name, desc string, members int, lastActive time.Time) keybase1.TeamSearchItem {
+	return keybase1.TeamSearchItem{
+		Id:          keybase1.TeamID(id),
+		Name:        name,
+		Description: &desc,
+		MemberCount: members,
+		LastActive:  keybase1.ToTime(lastActive),
+	}
+}
+
+func indexSets(idx *tokenIndex) map[string]map[keybase1.TeamID]bool {
+	res := make(map[string]map[keybase1.TeamID]bool)
+	for tok, ids := range idx.IDs {
+		res[tok] = make(map[keybase1.TeamID]bool)
+		for _, id := range ids {
+			res[tok][id] = true
+		}
+	}
+	return res
+}
+
+func TestTokenIndexUpdate(t *testing.T) {
+	now := time.Now()
+	prev := teamMap{
+		"a": makeSearchItem("a", "keybasefriends", "hang out with friends", 100, now),
+		"b": makeSearchItem("b", "cryptography", "talk about crypto", 50, now),
+	}
+	idx := newTokenIndex(prev)
+	require.Equal(t, map[keybase1.TeamID]bool{"a": true}, idx.candidates("base"))
+	require.Equal(t, map[keybase1.TeamID]bool{"b": true}, idx.candidates("crypto"))
+
+	next := teamMap{
+		"a": prev["a"],
+		"b": makeSearchItem("b", "cryptography", "all about ciphers", 50, now),
+		"c": makeSearchItem("c", "gardening", "friends of plants", 10, now),
+	}
+	changed := idx.update(prev, next)
+	require.Equal(t, 3, changed)
+	require.Equal(t, indexSets(newTokenIndex(next)), indexSets(idx))
+	require.Equal(t, newTokenIndex(next).Tokens, idx.Tokens)
+	require.Equal(t, map[keybase1.TeamID]bool{"a": true, "c": true}, idx.candidates("friends"))
+	require.Equal(t, map[keybase1.TeamID]bool{"b": true}, idx.candidates("ciphers"))
+	require.Empty(t, idx.candidates("talk"))
+
+	delete(next, "a")
+	idx.update(teamMap{"a": prev["a"]}, teamMap{})
+	require.Equal(t, indexSets(newTokenIndex(next)), indexSets(idx))
+	require.Equal(t, newTokenIndex(next).Tokens, idx.Tokens)
+}
+
+func TestFilterAndSort(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+	small := makeSearchItem("a", "alpha", "", 5, now)
+	big := makeSearchItem("b", "bravo", "", 500, old)
+	mid := makeSearchItem("c", "charlie", "", 50, now.Add(-time.Hour))
+
+	since := keybase1.ToTime(now.Add(-7 * 24 * time.Hour))
+	require.True(t, filterIsEmpty(keybase1.TeamSearchFilter{}))
+	require.False(t, matchesFilter(small, keybase1.TeamSearchFilter{MinMemberCount: 10}))
+	require.False(t, matchesFilter(big, keybase1.TeamSearchFilter{MaxMemberCount: 100}))
+	require.False(t, matchesFilter(big, keybase1.TeamSearchFilter{ActiveSince: &since}))
+	require.True(t, matchesFilter(mid, keybase1.TeamSearchFilter{ActiveSince: &since, MaxMemberCount: 100}))
+
+	results := []rankedSearchItem{{item: small, score: 3}, {item: big, score: 1}, {item: mid, score: 2}}
+	names := func() (res []string) {
+		for _, r := range results {
+			res = append(res, r.item.Name)
+		}
+		return res
+	}
+	sortResults(results, keybase1.TeamSearchSortOrder_RELEVANCE)
+	require.Equal(t, []string{"alpha", "charlie", "bravo"}, names())
+	sortResults(results, keybase1.TeamSearchSortOrder_MEMBER_COUNT)
+	require.Equal(t, []string{"bravo", "charlie", "alpha"}, names())
+	sortResults(results, keybase1.TeamSearchSortOrder_LAST_ACTIVE)
+	require.Equal(t, []string{"alpha", "charlie", "bravo"}, names())
+	sortResults(results, keybase1.TeamSearchSortOrder_NAME)
+	require.Equal(t, []string{"alpha", "bravo", "charlie"}, names())
+}
@@ -5,7 +5,6 @@ package opensearch
 
 import (
 	"errors"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -43,12 +42,13 @@ type storageItem struct {
 	Items     teamMap
 	Suggested []keybase1.TeamID
 	Hash      string
+	Index     *tokenIndex
 }
 
 func dbKey() libkb.DbKey {
 	return libkb.DbKey{
 		Typ: libkb.DBOpenTeams,
-		Key: "v0",
+		Key: "v1",
 	}
 }
 
@@ -74,6 +74,10 @@ func getOpenTeams(mctx libkb.MetaContext) (res storageItem, err error) {
 		if !found {
 			return res, errors.New("no open teams found")
 		}
+		if res.Index == nil {
+			// written by a client which did not index the directory yet
+			res.Index = newTokenIndex(res.Items)
+		}
 		return res, nil
 	}
 	if res, err = get(); err != nil {
@@ -122,6 +126,7 @@ func refreshOpenTeams(mctx libkb.MetaContext, force bool) {
 	out.Items = apiRes.Items
 	out.Suggested = apiRes.Suggested
 	out.Hash = apiRes.Hash()
+	out.Index = updatedIndex(mctx, apiRes.Items)
 	if err := mctx.G().GetKVStore().PutObj(dbKey(), nil, out); err != nil {
 		mctx.Debug("OpenSearch.refreshOpenTeams: failed to put: %s", err)
 		saved = false
@@ -129,12 +134,46 @@ func refreshOpenTeams(mctx libkb.MetaContext, force bool) {
 	}
 }
 
+// updatedIndex builds the token index for a freshly fetched set of open teams,
+// reusing the previously stored index so only changed teams are reindexed.
+func updatedIndex(mctx libkb.MetaContext, items teamMap) *tokenIndex {
+	var prev storageItem
+	found, err := mctx.G().GetKVStore().GetInto(&prev, dbKey())
+	if err != nil {
+		mctx.Debug("OpenSearch.updatedIndex: failed to read: %s", err)
+	}
+	if !found || err != nil || prev.Index == nil {
+		return newTokenIndex(items)
+	}
+	changed := prev.Index.update(prev.Items, items)
+	mctx.Debug("OpenSearch.updatedIndex: reindexed %d teams", changed)
+	return prev.Index
+}
+
 // Local performs a local search for Keybase open teams.
 func Local(mctx libkb.MetaContext, query string, limit int) (res []keybase1.TeamSearchItem, err error) {
-	var si storageItem
 	mctx = mctx.WithLogTag("OTS")
 	tracer := mctx.G().CTimeTracer(mctx.Ctx(), "OpenSearch.Local", true)
 	defer tracer.Finish()
+	return search(mctx, query, limit, keybase1.TeamSearchFilter{}, keybase1.TeamSearchSortOrder_RELEVANCE)
+}
+
+// Directory searches the locally cached open team directory, restricting the
+// results to teams matching filter. With an empty query and a nonempty filter
+// every cached team matching the filter is returned.
+func Directory(mctx libkb.MetaContext, arg keybase1.TeamSearchDirectoryArg) (res []keybase1.TeamSearchItem, err error) {
+	mctx = mctx.WithLogTag("OTS")
+	tracer := mctx.G().CTimeTracer(mctx.Ctx(), "OpenSearch.Directory", true)
+	defer tracer.Finish()
+	if arg.ForceRefresh {
+		refreshOpenTeams(mctx, true)
+	}
+	return search(mctx, arg.Query, arg.Limit, arg.Filter, arg.SortOrder)
+}
+
+func search(mctx libkb.MetaContext, query string, limit int, filter keybase1.TeamSearchFilter,
+	order keybase1.TeamSearchSortOrder) (res []keybase1.TeamSearchItem, err error) {
+	var si storageItem
 	defer func() {
 		go refreshOpenTeams(mctx.BackgroundWithLogTags(), false)
 	}()
@@ -143,7 +182,8 @@ func Local(mctx libkb.MetaContext, query string, limit int) (res []keybase1.Team
 	}
 	query = strings.ToLower(query)
 	var results []rankedSearchItem
-	if len(query) == 0 {
+	switch {
+	case len(query) == 0 && filterIsEmpty(filter):
 		for index, id := range si.Suggested {
 			item, ok := si.Items[id]
 			if ok {
@@ -153,8 +193,22 @@ func Local(mctx libkb.MetaContext, query string, limit int) (res []keybase1.Team
 				})
 			}
 		}
-	} else {
+	case len(query) == 0:
 		for _, item := range si.Items {
+			if !matchesFilter(item, filter) {
+				continue
+			}
+			results = append(results, rankedSearchItem{
+				item:  item,
+				score: float64(item.MemberCount),
+			})
+		}
+	default:
+		for id := range si.Index.candidates(query) {
+			item, ok := si.Items[id]
+			if !ok || !matchesFilter(item, filter) {
+				continue
+			}
 			rankedItem := rankedSearchItem{
 				item: item,
 			}
@@ -165,16 +219,14 @@ func Local(mctx libkb.MetaContext, query string, limit int) (res []keybase1.Team
 			results = append(results, rankedItem)
 		}
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].score > results[j].score
-	})
+	sortResults(results, order)
 	for index, r := range results {
 		if index >= limit {
 			break
 		}
 		if r.item.InTeam, err = mctx.G().ChatHelper.InTeam(mctx.Ctx(),
 			gregor1.UID(mctx.G().GetMyUID().ToBytes()), r.item.Id); err != nil {
-			mctx.Debug("OpenSearch.Local: failed to get inTeam for: %s err: %s", r.item.Id, err)
+			mctx.Debug("OpenSearch.search: failed to get inTeam for: %s err: %s", r.item.Id, err)
 		}
 		res = append(res, r.item)
 	}
@@ -30,6 +30,8 @@ var (
 		{keyEscape, '[', '3', 'm'},
 		// Underline
 		{keyEscape, '[', '4', 'm'},
+		// Strikethrough
+		{keyEscape, '[', '9', 'm'},
 		// Reset bold (or doubly underline according to ECMA-48; fallback is code [22m)
 		// See https://en.wikipedia.org/wiki/ANSI_escape_code#SGR_(Select_Graphic_Rendition)_parameters
 		{keyEscape, '[', '2', '1', 'm'},
@@ -39,6 +41,8 @@ var (
 		{keyEscape, '[', '2', '3', 'm'},
 		// Reset underline
 		{keyEscape, '[', '2', '4', 'm'},
+		// Reset strikethrough
+		{keyEscape, '[', '2', '9', 'm'},
 		// Reset all formatting
 		{keyEscape, '[', '0', 'm'},
 	}
@@ -46,6 +46,8 @@ var tests = map[string]string{
 
 	// Colors are acceptable, including multiple in the same string,
 	"foo\x1b[30mbar": "foo\x1b[30mbar",
+	// and so are the other text styles.
+	"\x1b[1mb\x1b[22m \x1b[3mi\x1b[23m \x1b[9ms\x1b[29m": "\x1b[1mb\x1b[22m \x1b[3mi\x1b[23m \x1b[9ms\x1b[29m",
 
 	// But non-color escapes should be escaped properly
 	"fo\x1b[4Po\x1b[30mbarf\x1b[34moobarfo\x1b[0mobarfoobar\x1b312": "fo^[[4Po\x1b[30mbarf\x1b[34moobarfo\x1b[0mobarfoobar^[312",
@@ -159,6 +159,7 @@ export const uploadAttachments = (p: {
             conversationID: T.Chat.keyToConversationID(conversationIDKey),
             filename: Styles.unnormalizePath(pathInfo.path),
             identifyBehavior: T.RPCGen.TLFIdentifyBehavior.chatGui,
+            keepMetadata: false,
             metadata: new Uint8Array(),
             outboxID: outboxIDs[idx],
             title: titles[idx] ?? '',
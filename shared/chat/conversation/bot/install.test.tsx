@@ -64,7 +64,7 @@ test('useBotTeamRole refreshes role for the selected conversation and ignores st
 })
 
 test('useBotSettings refreshes only when enabled and hides stale conversation data', async () => {
-  const settings = {cmds: true, convs: [convID], mentions: false}
+  const settings = {cmds: true, cmdsOnly: false, convs: [convID], mentions: false}
   jest.spyOn(T.RPCChat, 'localGetBotMemberSettingsRpcPromise').mockResolvedValue(settings)
 
   const {rerender, result} = renderHook(
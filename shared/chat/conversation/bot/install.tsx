@@ -134,7 +134,7 @@ const addBotMember = async (p: {
     await T.RPCChat.localAddBotMemberRpcPromise(
       {
         botSettings: installWithRestrict
-          ? {cmds: installWithCommands, convs: installInConvs, mentions: installWithMentions}
+          ? {cmds: installWithCommands, cmdsOnly: false, convs: installInConvs, mentions: installWithMentions}
           : null,
         convID: T.Chat.keyToConversationID(conversationIDKey),
         role: installWithRestrict ? T.RPCGen.TeamRole.restrictedbot : T.RPCGen.TeamRole.bot,
@@ -249,7 +249,14 @@ const InstallBotPopup = (props: Props) => {
       try {
         await T.RPCChat.localSetBotMemberSettingsRpcPromise(
           {
-            botSettings: {cmds: installWithCommands, convs: convsToSave, mentions: installWithMentions},
+            botSettings: {
+              // keep settings the GUI doesn't manage (triggers, command-only, senders)
+              cmdsOnly: false,
+              ...settings,
+              cmds: installWithCommands,
+              convs: convsToSave,
+              mentions: installWithMentions,
+            },
             convID: T.Chat.keyToConversationID(conversationIDKey),
             username: botUsername,
           },
@@ -956,16 +956,22 @@ export const useAttachmentSections = (
               })
             } else {
               ;[...m.unfurls.values()].forEach((u, i) => {
-                if (u.unfurl.unfurlType === T.RPCChat.UnfurlType.generic) {
+                const link =
+                  u.unfurl.unfurlType === T.RPCChat.UnfurlType.generic
+                    ? u.unfurl.generic
+                    : u.unfurl.unfurlType === T.RPCChat.UnfurlType.embed
+                      ? u.unfurl.embed
+                      : undefined
+                if (link) {
                   l.push({
                     author: m.author,
                     ctime: m.timestamp,
                     id: m.id,
-                    key: `unfurl-${m.ordinal}-${i}-${m.author}-${m.timestamp}-${u.unfurl.generic.url}`,
+                    key: `unfurl-${m.ordinal}-${i}-${m.author}-${m.timestamp}-${link.url}`,
                     snippet: m.decoratedText?.stringValue() ?? '',
-                    title: u.unfurl.generic.title,
+                    title: link.title,
                     type: 'link',
-                    url: u.unfurl.generic.url,
+                    url: link.url,
                   })
                 }
               })
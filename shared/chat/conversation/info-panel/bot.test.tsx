@@ -20,8 +20,8 @@ afterEach(() => {
 })
 
 test('useBotSettings refreshes settings for the visible bot and supports local updates after edits', async () => {
-  const initialSettings = {cmds: true, convs: ['old-conv'], mentions: false}
-  const editedSettings = {cmds: true, convs: [convID, 'old-conv'], mentions: false}
+  const initialSettings = {cmds: true, cmdsOnly: false, convs: ['old-conv'], mentions: false}
+  const editedSettings = {cmds: true, cmdsOnly: false, convs: [convID, 'old-conv'], mentions: false}
   jest.spyOn(T.RPCChat, 'localGetBotMemberSettingsRpcPromise').mockResolvedValue(initialSettings)
 
   const {result} = renderHook(() => useBotSettings(convID, 'helperbot'))
@@ -48,8 +48,8 @@ test('useBotSettings clears visible settings while refreshing a different bot',
     async ({username}) => {
       await Promise.resolve()
       return username === 'helperbot'
-        ? {cmds: true, convs: ['helper-conv'], mentions: false}
-        : {cmds: false, convs: ['other-conv'], mentions: true}
+        ? {cmds: true, cmdsOnly: false, convs: ['helper-conv'], mentions: false}
+        : {cmds: false, cmdsOnly: false, convs: ['other-conv'], mentions: true}
     }
   )
 
@@ -61,7 +61,7 @@ test('useBotSettings clears visible settings while refreshing a different bot',
     await flushPromises()
   })
 
-  expect(result.current.settings).toEqual({cmds: true, convs: ['helper-conv'], mentions: false})
+  expect(result.current.settings).toEqual({cmds: true, cmdsOnly: false, convs: ['helper-conv'], mentions: false})
 
   rerender({username: 'otherbot'})
 
@@ -71,5 +71,5 @@ test('useBotSettings clears visible settings while refreshing a different bot',
     await flushPromises()
   })
 
-  expect(result.current.settings).toEqual({cmds: false, convs: ['other-conv'], mentions: true})
+  expect(result.current.settings).toEqual({cmds: false, cmdsOnly: false, convs: ['other-conv'], mentions: true})
 })
@@ -59,9 +59,8 @@ const AddToChannel = (props: AddToChannelProps) => {
         // if settings aren't loaded, don't even try to do anything
         if (settings && !readsAllChannels && !settings.convs.includes(conversationIDKey)) {
           const nextSettings = {
-            cmds: settings.cmds,
+            ...settings,
             convs: [conversationIDKey].concat(settings.convs ?? []),
-            mentions: settings.mentions,
           }
           editBotSettings(
             [
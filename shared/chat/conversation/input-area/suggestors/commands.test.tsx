@@ -4,7 +4,7 @@ import {act, cleanup, renderHook} from '@testing-library/react'
 import {notifyEngineActionListeners} from '@/engine/action-listener'
 import * as T from '@/constants/types'
 import {resetAllStores} from '@/util/zustand'
-import {useBotCommandsUpdateState} from './commands'
+import {getCommandUsage, useBotCommandsUpdateState} from './commands'
 
 const convID = T.Chat.conversationIDToKey(new Uint8Array([1, 2, 3, 4]))
 const otherConvID = T.Chat.conversationIDToKey(new Uint8Array([5, 6, 7, 8]))
@@ -67,3 +67,21 @@ test('useBotCommandsUpdateState preserves settings during non-uptodate updates a
   expect(result.current.status).toBe(T.RPCChat.UIBotCommandsUpdateStatusTyp.blank)
   expect(result.current.settings.size).toBe(0)
 })
+
+test('getCommandUsage prefers the advertised usage and falls back to typed arguments', () => {
+  const command: T.RPCChat.ConversationCommand = {
+    args: [
+      {name: 'env', typ: 'enum', values: ['prod', 'staging']},
+      {name: 'user', typ: 'user'},
+      {name: 'when', optional: true, typ: 'duration'},
+    ],
+    description: 'Deploy',
+    hasHelpText: false,
+    name: 'deploy',
+    usage: '',
+    username: 'deploybot',
+  }
+  expect(getCommandUsage(command)).toBe('<prod|staging> <user> [when]')
+  expect(getCommandUsage({...command, usage: '<env> <user>'})).toBe('<env> <user>')
+  expect(getCommandUsage({...command, args: undefined})).toBe('')
+})
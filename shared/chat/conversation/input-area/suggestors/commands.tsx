@@ -21,6 +21,19 @@ export const transformer = (
   return Common.standardTransformer(`${prefix}${command.name}`, tData, preview)
 }
 
+// bots that declare typed arguments can leave the usage empty, so build one like the service does
+export const getCommandUsage = (command: T.RPCChat.ConversationCommand) => {
+  if (command.usage || !command.args?.length) {
+    return command.usage
+  }
+  return command.args
+    .map(arg => {
+      const desc = arg.typ === 'enum' ? (arg.values ?? []).join('|') : arg.name
+      return arg.optional ? `[${desc}]` : `<${desc}>`
+    })
+    .join(' ')
+}
+
 const keyExtractor = (c: T.RPCChat.ConversationCommand) => c.name + String(c.username)
 
 type BotSettingsMap = ReadonlyMap<string, T.RPCChat.Keybase1.TeamBotSettings | undefined>
@@ -147,7 +160,7 @@ const ItemRenderer = (p: Common.ItemRendererProps<CommandType>) => {
             {prefix}
             {command.name}
           </Kb.Text>
-          <Kb.Text type="Body">{command.usage}</Kb.Text>
+          <Kb.Text type="Body">{getCommandUsage(command)}</Kb.Text>
         </Kb.Box2>
         {enabled ? (
           <Kb.Text type="BodySmall">{command.description}</Kb.Text>
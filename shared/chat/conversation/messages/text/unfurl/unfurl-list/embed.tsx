@@ -0,0 +1,170 @@
+import * as Kb from '@/common-adapters/index'
+import * as T from '@/constants/types'
+import UnfurlImage from './image'
+import {useActions} from './use-state'
+
+function UnfurlEmbed(p: {
+  author: string
+  conversationIDKey: T.Chat.ConversationIDKey
+  ordinal: T.Chat.Ordinal
+  unfurlInfo: T.RPCChat.UIMessageUnfurlInfo
+  youAreAuthor: boolean
+}) {
+  const styles = useStyles()
+  const theme = Kb.Styles.useTheme()
+  const {ordinal, unfurlInfo, youAreAuthor} = p
+  const {isCollapsed, unfurl, unfurlMessageID} = unfurlInfo
+  const {onClose, onToggleCollapse} = useActions(
+    youAreAuthor,
+    T.Chat.numberToMessageID(unfurlMessageID),
+    ordinal
+  )
+  const embed = unfurl.unfurlType === T.RPCChat.UnfurlType.embed ? unfurl.embed : undefined
+  const titleUrlProps = Kb.useClickURL(embed?.url ?? '')
+  if (!embed) {
+    return null
+  }
+  const {authorName, description, embedType, favicon, siteName, snippet, thumbnail, title, url} = embed
+  const isCode = embedType === T.RPCChat.UnfurlEmbedType.code
+  const isPlayer =
+    embedType === T.RPCChat.UnfurlEmbedType.video || embedType === T.RPCChat.UnfurlEmbedType.audio
+
+  const publisher = (
+    <Kb.Box2 alignSelf="flex-start" gap="tiny" fullWidth={true} direction="horizontal" style={styles.siteNameContainer}>
+      {favicon?.url ? <Kb.Image src={favicon.url} style={styles.favicon} /> : null}
+      <Kb.BoxGrow>
+        <Kb.Text type="BodySmall" lineClamp={1}>
+          {siteName}
+          {authorName && authorName !== title ? <Kb.Text type="BodySmall"> • {authorName}</Kb.Text> : null}
+        </Kb.Text>
+      </Kb.BoxGrow>
+      <Kb.Icon
+        style={styles.collapseBox}
+        onClick={onToggleCollapse}
+        sizeType="Tiny"
+        type={isCollapsed ? 'iconfont-caret-right' : 'iconfont-caret-down'}
+      />
+      {onClose ? (
+        <Kb.Icon
+          type="iconfont-close"
+          onClick={onClose}
+          style={styles.closeBox}
+          padding="xtiny"
+          className="unfurl-closebox"
+          fontSize={12}
+          color={theme.black_20}
+        />
+      ) : null}
+    </Kb.Box2>
+  )
+
+  const body = isCollapsed ? null : (
+    <>
+      {description ? (
+        <Kb.Text type="Body" lineClamp={3} selectable={true}>
+          {description}
+        </Kb.Text>
+      ) : null}
+      {snippet ? (
+        <Kb.Box2 direction="vertical" fullWidth={true} style={isCode ? styles.codeContainer : undefined}>
+          <Kb.Text type={isCode ? 'Terminal' : 'Body'} lineClamp={isCode ? undefined : 8} selectable={true}>
+            {snippet}
+          </Kb.Text>
+        </Kb.Box2>
+      ) : null}
+      {thumbnail?.url ? (
+        <Kb.Box2 direction="vertical" fullWidth={true}>
+          <UnfurlImage
+            url={thumbnail.url}
+            linkURL={url}
+            height={thumbnail.height}
+            width={thumbnail.width}
+            widthPadding={isMobile ? Kb.Styles.globalMargins.tiny : undefined}
+            style={styles.thumbnail}
+            isVideo={false}
+            autoplayVideo={false}
+          />
+          {isPlayer ? (
+            <Kb.Box2 direction="vertical" centerChildren={true} style={styles.playOverlay} pointerEvents="none">
+              <Kb.Icon type="iconfont-play" fontSize={32} color={theme.white} />
+            </Kb.Box2>
+          ) : null}
+        </Kb.Box2>
+      ) : null}
+    </>
+  )
+
+  return (
+    <Kb.Box2 alignSelf="flex-start" gap="tiny" direction="horizontal" style={styles.container}>
+      {!isMobile && <Kb.Box2 direction="horizontal" alignSelf="stretch" style={styles.quoteContainer} />}
+      <Kb.Box2 alignSelf="flex-start" gap="xxtiny" direction="vertical" fullWidth={true} style={styles.innerContainer}>
+        {publisher}
+        <Kb.Text type="BodyPrimaryLink" style={styles.url} {...titleUrlProps}>
+          {title}
+        </Kb.Text>
+        {body}
+      </Kb.Box2>
+    </Kb.Box2>
+  )
+}
+
+const useStyles = Kb.Styles.createStyleHook(
+  theme =>
+    ({
+      closeBox: Kb.Styles.platformStyles({
+        isElectron: {
+          alignSelf: 'flex-start',
+          marginLeft: 'auto',
+        },
+      }),
+      codeContainer: {
+        backgroundColor: theme.blueLighter3,
+        borderRadius: Kb.Styles.borderRadius,
+        padding: Kb.Styles.globalMargins.xtiny,
+      },
+      collapseBox: {alignSelf: 'center'},
+      container: Kb.Styles.platformStyles({
+        isElectron: {maxWidth: 500},
+        isTablet: {maxWidth: 500},
+      }),
+      favicon: Kb.Styles.platformStyles({
+        common: {
+          borderRadius: Kb.Styles.borderRadius,
+          ...Kb.Styles.size(16),
+        },
+      }),
+      innerContainer: Kb.Styles.platformStyles({
+        common: {
+          minWidth: 150,
+        },
+        isMobile: {
+          borderColor: theme.grey,
+          borderRadius: Kb.Styles.borderRadius,
+          borderWidth: 1,
+          padding: Kb.Styles.globalMargins.xtiny,
+        },
+      }),
+      playOverlay: {
+        ...Kb.Styles.globalStyles.fillAbsolute,
+      },
+      quoteContainer: Kb.Styles.platformStyles({
+        common: {
+          backgroundColor: theme.grey,
+          paddingLeft: Kb.Styles.globalMargins.xtiny,
+        },
+      }),
+      siteNameContainer: Kb.Styles.platformStyles({
+        isElectron: {minHeight: 16},
+        isMobile: {minHeight: 21},
+      }),
+      thumbnail: Kb.Styles.platformStyles({
+        common: {marginTop: Kb.Styles.globalMargins.xtiny},
+        isMobile: {alignSelf: 'center'},
+      }),
+      url: {
+        ...Kb.Styles.globalStyles.fontSemibold,
+      },
+    }) as const
+)
+
+export default UnfurlEmbed
@@ -1,5 +1,6 @@
 import * as T from '@/constants/types'
 import type * as React from 'react'
+import UnfurlEmbed from './embed'
 import UnfurlGeneric from './generic'
 import UnfurlGiphy from './giphy'
 import UnfurlMap from './map'
@@ -26,12 +27,13 @@ const useStyles = Kb.Styles.createStyleHook(
     }) as const
 )
 
-type UnfurlRenderType = 'generic' | 'map' | 'giphy'
+type UnfurlRenderType = 'generic' | 'map' | 'giphy' | 'embed'
 
 const renderTypeToClass = new Map<UnfurlRenderType, React.ComponentType<UnfurlItemProps>>([
   ['generic', UnfurlGeneric],
   ['map', UnfurlMap],
   ['giphy', UnfurlGiphy],
+  ['embed', UnfurlEmbed],
 ])
 
 function UnfurlListContainer({
@@ -60,6 +62,9 @@ function UnfurlListContainer({
           case T.RPCChat.UnfurlType.generic:
             renderType = unfurlInfo.unfurl.generic.mapInfo ? 'map' : 'generic'
             break
+          case T.RPCChat.UnfurlType.embed:
+            renderType = 'embed'
+            break
           default:
             renderType = 'none'
         }
@@ -184,6 +184,7 @@ export const useConversationSendActions = () => {
           conversationID: T.Chat.keyToConversationID(conversationIDKey),
           filename: path,
           identifyBehavior: T.RPCGen.TLFIdentifyBehavior.chatGui,
+          keepMetadata: false,
           metadata: new Uint8Array(),
           outboxID,
           title: '',
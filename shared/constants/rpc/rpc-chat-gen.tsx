@@ -843,6 +843,7 @@ export enum GlobalAppNotificationSetting {
   defaultsoundmobile = 3,
   disabletyping = 4,
   convertheic = 5,
+  stripmetadata = 6,
 }
 
 export enum HeaderPlaintextVersion {
@@ -873,6 +874,11 @@ export enum InboxResType {
   full = 1,
 }
 
+export enum InteractiveElementType {
+  button = 0,
+  select = 1,
+}
+
 export enum JourneycardType {
   welcome = 0,
   popularChannels = 1,
@@ -938,6 +944,10 @@ export enum MessageType {
   unfurl = 16,
   flip = 17,
   pin = 18,
+  poll = 19,
+  pollvote = 20,
+  interactive = 21,
+  interactiveaction = 22,
 }
 
 export enum MessageUnboxedErrorType {
@@ -1043,6 +1053,12 @@ export enum TeamType {
   complex = 2,
 }
 
+export enum TeamUnfurlMode {
+  default = 0,
+  disabled = 1,
+  allowlist = 2,
+}
+
 export enum TextPaymentResultTyp {
   sent = 0,
   error = 1,
@@ -1142,6 +1158,14 @@ export enum UIWatchPositionPerm {
   always = 1,
 }
 
+export enum UnfurlEmbedType {
+  video = 0,
+  audio = 1,
+  post = 2,
+  code = 3,
+  rich = 4,
+}
+
 export enum UnfurlMode {
   always = 0,
   never = 1,
@@ -1161,6 +1185,7 @@ export enum UnfurlType {
   youtube = 1,
   giphy = 2,
   maps = 3,
+  embed = 4,
 }
 export type AddEmojiAliasRes = {readonly rateLimit?: RateLimit | null,readonly error?: EmojiError | null,}
 export type AddEmojiRes = {readonly rateLimit?: RateLimit | null,readonly error?: EmojiError | null,}
@@ -1186,7 +1211,10 @@ export type BodyPlaintextMetaInfo = {readonly crit: boolean,}
 export type BodyPlaintextUnsupported = {readonly mi: BodyPlaintextMetaInfo,}
 export type BodyPlaintextV1 = {readonly messageBody: MessageBody,}
 export type BodyPlaintextV2 = {readonly messageBody: MessageBody,readonly mi: BodyPlaintextMetaInfo,}
+export type BookmarkedMessage = {readonly bookmark: ChatBookmark,readonly message?: MessageUnboxed | null,}
+export type BotCommandArgValue = {readonly name: string,readonly typ: string,readonly value: string,readonly number?: number | null,}
 export type BotCommandConv = {readonly uid: Gregor1.UID,readonly untrustedTeamRole: Keybase1.TeamRole,readonly convID: ConversationID,readonly vers: CommandConvVers,readonly mtime: Gregor1.Time,readonly typ: BotCommandsAdvertisementTyp,}
+export type BotCommandInvocation = {readonly name: string,readonly username: string,readonly args?: ReadonlyArray<BotCommandArgValue> | null,}
 export type BotInfo = {readonly serverHashVers: BotInfoHashVers,readonly clientHashVers: BotInfoHashVers,readonly commandConvs?: ReadonlyArray<BotCommandConv> | null,}
 export type BotInfoHash = Uint8Array
 export type BotInfoHashVers = number
@@ -1194,7 +1222,12 @@ export type BotInfoResponse ={ typ: BotInfoResponseTyp.uptodate } | { typ: BotIn
 export type BuiltinCommandGroup = {readonly typ: ConversationBuiltinCommandTyp,readonly commands?: ReadonlyArray<ConversationCommand> | null,}
 export type ChannelNameMention = {readonly convID: ConversationID,readonly topicName: string,}
 export type ChatActivity ={ activityType: ChatActivityType.incomingMessage, incomingMessage: IncomingMessage } | { activityType: ChatActivityType.readMessage, readMessage: ReadMessageInfo } | { activityType: ChatActivityType.newConversation, newConversation: NewConversationInfo } | { activityType: ChatActivityType.setStatus, setStatus: SetStatusInfo } | { activityType: ChatActivityType.failedMessage, failedMessage: FailedMessageInfo } | { activityType: ChatActivityType.membersUpdate, membersUpdate: MembersUpdateInfo } | { activityType: ChatActivityType.setAppNotificationSettings, setAppNotificationSettings: SetAppNotificationSettingsInfo } | { activityType: ChatActivityType.teamtype, teamtype: TeamTypeInfo } | { activityType: ChatActivityType.expunge, expunge: ExpungeInfo } | { activityType: ChatActivityType.ephemeralPurge, ephemeralPurge: EphemeralPurgeNotifInfo } | { activityType: ChatActivityType.reactionUpdate, reactionUpdate: ReactionUpdateNotif } | { activityType: ChatActivityType.messagesUpdated, messagesUpdated: MessagesUpdated } | { activityType: ChatActivityType.reserved}
+export type ChatBookmark = {readonly convID: ConversationID,readonly msgID: MessageID,readonly note: string,readonly ctime: Gregor1.Time,}
 export type ChatChannel = {readonly name: string,readonly public: boolean,readonly membersType: string,readonly topicType: string,readonly topicName: string,}
+export type ChatDNDSchedule = {readonly enabled: boolean,readonly timezone: string,readonly windows?: ReadonlyArray<ChatDNDWindow> | null,readonly allowDMs: boolean,readonly allowConvIDs?: ReadonlyArray<ConversationID> | null,}
+export type ChatDNDWindow = {readonly weekdays?: ReadonlyArray<number> | null,readonly startMinute: number,readonly endMinute: number,}
+export type ChatHighlights = {readonly keywords?: ReadonlyArray<string> | null,readonly regexes?: ReadonlyArray<string> | null,}
+export type ChatIncomingWebhook = {readonly id: string,readonly secret: string,readonly convID: ConversationID,readonly convName: string,readonly template: string,readonly rateLimit: number,readonly ctime: Gregor1.Time,}
 export type ChatList = {readonly conversations?: ReadonlyArray<ConvSummary> | null,readonly offline: boolean,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
 export type ChatMemberDetails = {readonly uid: Keybase1.UID,readonly username: string,readonly fullName: Keybase1.FullName,}
 export type ChatMembersDetails = {readonly owners?: ReadonlyArray<ChatMemberDetails> | null,readonly admins?: ReadonlyArray<ChatMemberDetails> | null,readonly writers?: ReadonlyArray<ChatMemberDetails> | null,readonly readers?: ReadonlyArray<ChatMemberDetails> | null,readonly bots?: ReadonlyArray<ChatMemberDetails> | null,readonly restrictedBots?: ReadonlyArray<ChatMemberDetails> | null,}
@@ -1208,6 +1241,7 @@ export type ChatSearchMatch = {readonly startIndex: number,readonly endIndex: nu
 export type ChatSyncIncrementalConv = {readonly conv: UnverifiedInboxUIItem,readonly shouldUnbox: boolean,}
 export type ChatSyncIncrementalInfo = {readonly items?: ReadonlyArray<ChatSyncIncrementalConv> | null,readonly removals?: ReadonlyArray<string> | null,}
 export type ChatSyncResult ={ syncType: SyncInboxResType.current } | { syncType: SyncInboxResType.clear } | { syncType: SyncInboxResType.incremental, incremental: ChatSyncIncrementalInfo }
+export type ChatWebhook = {readonly id: string,readonly url: string,readonly secret: string,readonly filterChannels?: ReadonlyArray<ChatChannel> | null,readonly filterConvIDs?: ReadonlyArray<ConversationID> | null,readonly convs: boolean,readonly wallet: boolean,readonly ctime: Gregor1.Time,}
 export type ClearBotCommandsFilter = {readonly typ: BotCommandsAdvertisementTyp,readonly teamName?: string | null,readonly convID?: ConversationID | null,}
 export type ClearBotCommandsLocalRes = {readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
 export type ClearBotCommandsRes = {readonly rateLimit?: RateLimit | null,}
@@ -1216,10 +1250,10 @@ export type CommandConvVers = number
 export type ConvIDStr = string
 export type ConvNotification = {readonly type: string,readonly conv?: ConvSummary | null,readonly error?: string | null,}
 export type ConvSearchHit = {readonly name: string,readonly convID: ConversationID,readonly isTeam: boolean,readonly parts?: ReadonlyArray<string> | null,}
-export type ConvSummary = {readonly id: ConvIDStr,readonly channel: ChatChannel,readonly isDefaultConv: boolean,readonly unread: boolean,readonly activeAt: number,readonly activeAtMs: number,readonly memberStatus: string,readonly resetUsers?: ReadonlyArray<string> | null,readonly finalizeInfo?: ConversationFinalizeInfo | null,readonly supersedes?: ReadonlyArray<string> | null,readonly supersededBy?: ReadonlyArray<string> | null,readonly error: string,readonly creatorInfo?: ConversationCreatorInfoLocal | null,}
+export type ConvSummary = {readonly id: ConvIDStr,readonly channel: ChatChannel,readonly isDefaultConv: boolean,readonly unread: boolean,readonly activeAt: number,readonly activeAtMs: number,readonly memberStatus: string,readonly resetUsers?: ReadonlyArray<string> | null,readonly finalizeInfo?: ConversationFinalizeInfo | null,readonly supersedes?: ReadonlyArray<string> | null,readonly supersededBy?: ReadonlyArray<string> | null,readonly error: string,readonly creatorInfo?: ConversationCreatorInfoLocal | null,readonly unreadThreads?: ReadonlyArray<ThreadUnreadSummary> | null,}
 export type ConvTypingUpdate = {readonly convID: ConversationID,readonly typers?: ReadonlyArray<TyperInfo> | null,}
 export type Conversation = {readonly metadata: ConversationMetadata,readonly readerInfo?: ConversationReaderInfo | null,readonly notifications?: ConversationNotificationInfo | null,readonly maxMsgs?: ReadonlyArray<MessageBoxed> | null,readonly maxMsgSummaries?: ReadonlyArray<MessageSummary> | null,readonly creatorInfo?: ConversationCreatorInfo | null,readonly pinnedMsg?: MessageID | null,readonly expunge: Expunge,readonly convRetention?: RetentionPolicy | null,readonly teamRetention?: RetentionPolicy | null,readonly cs /* convSettings */ ?: ConversationSettings | null,}
-export type ConversationCommand = {readonly description: string,readonly name: string,readonly usage: string,readonly hasHelpText: boolean,readonly username?: string | null,}
+export type ConversationCommand = {readonly description: string,readonly name: string,readonly usage: string,readonly hasHelpText: boolean,readonly username?: string | null,readonly args?: ReadonlyArray<UserBotCommandArg> | null,}
 export type ConversationCommandGroups ={ typ: ConversationCommandGroupsTyp.builtin, builtin: ConversationBuiltinCommandTyp } | { typ: ConversationCommandGroupsTyp.custom, custom: ConversationCommandGroupsCustom } | { typ: ConversationCommandGroupsTyp.none }
 export type ConversationCommandGroupsCustom = {readonly commands?: ReadonlyArray<ConversationCommand> | null,}
 export type ConversationCreatorInfo = {readonly ctime: Gregor1.Time,readonly uid: Gregor1.UID,}
@@ -1283,6 +1317,8 @@ export type GenericPayload = {readonly Action: string,readonly inboxVers: InboxV
 export type GetAllResetConvMembersRes = {readonly members?: ReadonlyArray<ResetConvMember> | null,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
 export type GetBotInfoRes = {readonly response: BotInfoResponse,readonly rateLimit?: RateLimit | null,}
 export type GetChannelMembershipsLocalRes = {readonly channels?: ReadonlyArray<ChannelNameMention> | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
+export type GetChatBookmarksLocalRes = {readonly bookmarks?: ReadonlyArray<BookmarkedMessage> | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
+export type GetChatDNDScheduleLocalRes = {readonly schedule: ChatDNDSchedule,readonly active: boolean,}
 export type GetConversationForCLILocalQuery = {readonly markAsRead: boolean,readonly MessageTypes?: ReadonlyArray<MessageType> | null,readonly Since?: string | null,readonly limit: UnreadFirstNumLimit,readonly conv: ConversationLocal,}
 export type GetConversationForCLILocalRes = {readonly conversation: ConversationLocal,readonly messages?: ReadonlyArray<MessageUnboxed> | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
 export type GetConversationMetadataRemoteRes = {readonly conv: Conversation,readonly rateLimit?: RateLimit | null,}
@@ -1305,6 +1341,7 @@ export type GetMutualTeamsLocalRes = {readonly teams?: ReadonlyArray<SharedTeam>
 export type GetNextAttachmentMessageLocalRes = {readonly message?: UIMessage | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type GetPublicConversationsRes = {readonly conversations?: ReadonlyArray<Conversation> | null,readonly rateLimit?: RateLimit | null,}
 export type GetRecentJoinsRes = {readonly numJoins: number,readonly rateLimit?: RateLimit | null,}
+export type GetReplyThreadLocalRes = {readonly rootMsgID: MessageID,readonly messages?: ReadonlyArray<MessageUnboxed> | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type GetResetConvMembersRes = {readonly members?: ReadonlyArray<ResetConvMemberAPI> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
 export type GetResetConversationsRes = {readonly resetConvs?: ReadonlyArray<ResetConversationMember> | null,readonly rateLimit?: RateLimit | null,}
 export type GetTLFConversationsLocalRes = {readonly convs?: ReadonlyArray<InboxUIItem> | null,readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
@@ -1322,7 +1359,7 @@ export type HeaderPlaintext ={ version: HeaderPlaintextVersion.v1, v1: HeaderPla
 export type HeaderPlaintextMetaInfo = {readonly crit: boolean,}
 export type HeaderPlaintextUnsupported = {readonly mi: HeaderPlaintextMetaInfo,}
 export type HeaderPlaintextV1 = {readonly conv: ConversationIDTriple,readonly tlfName: string,readonly tlfPublic: boolean,readonly messageType: MessageType,readonly prev?: ReadonlyArray<MessagePreviousPointer> | null,readonly sender: Gregor1.UID,readonly senderDevice: Gregor1.DeviceID,readonly kbfsCryptKeysUsed?: boolean | null,readonly bodyHash: Hash,readonly outboxInfo?: OutboxInfo | null,readonly outboxID?: OutboxID | null,readonly headerSignature?: SignatureInfo | null,readonly merkleRoot?: MerkleRoot | null,readonly em /* ephemeralMetadata */ ?: MsgEphemeralMetadata | null,readonly b /* botUID */ ?: Gregor1.UID | null,}
-export type InboxUIItem = {readonly convID: ConvIDStr,readonly tlfID: TLFIDStr,readonly topicType: TopicType,readonly isPublic: boolean,readonly isEmpty: boolean,readonly isDefaultConv: boolean,readonly name: string,readonly snippet: string,readonly snippetDecorated: string,readonly snippetDecoration: SnippetDecoration,readonly channel: string,readonly headline: string,readonly headlineDecorated: string,readonly draft?: string | null,readonly visibility: Keybase1.TLFVisibility,readonly participants?: ReadonlyArray<UIParticipant> | null,readonly resetParticipants?: ReadonlyArray<string> | null,readonly status: ConversationStatus,readonly membersType: ConversationMembersType,readonly memberStatus: ConversationMemberStatus,readonly teamType: TeamType,readonly time: Gregor1.Time,readonly notifications?: ConversationNotificationInfo | null,readonly creatorInfo?: ConversationCreatorInfoLocal | null,readonly version: ConversationVers,readonly localVersion: LocalConversationVers,readonly maxMsgID: MessageID,readonly maxVisibleMsgID: MessageID,readonly readMsgID: MessageID,readonly convRetention?: RetentionPolicy | null,readonly teamRetention?: RetentionPolicy | null,readonly convSettings?: ConversationSettingsLocal | null,readonly finalizeInfo?: ConversationFinalizeInfo | null,readonly supersedes?: ReadonlyArray<ConversationMetadata> | null,readonly supersededBy?: ReadonlyArray<ConversationMetadata> | null,readonly commands: ConversationCommandGroups,readonly botCommands: ConversationCommandGroups,readonly botAliases?: {[key: string]: string} | null,readonly pinnedMsg?: UIPinnedMessage | null,}
+export type InboxUIItem = {readonly convID: ConvIDStr,readonly tlfID: TLFIDStr,readonly topicType: TopicType,readonly isPublic: boolean,readonly isEmpty: boolean,readonly isDefaultConv: boolean,readonly name: string,readonly snippet: string,readonly snippetDecorated: string,readonly snippetDecoration: SnippetDecoration,readonly channel: string,readonly headline: string,readonly headlineDecorated: string,readonly draft?: string | null,readonly visibility: Keybase1.TLFVisibility,readonly participants?: ReadonlyArray<UIParticipant> | null,readonly resetParticipants?: ReadonlyArray<string> | null,readonly status: ConversationStatus,readonly membersType: ConversationMembersType,readonly memberStatus: ConversationMemberStatus,readonly teamType: TeamType,readonly time: Gregor1.Time,readonly notifications?: ConversationNotificationInfo | null,readonly creatorInfo?: ConversationCreatorInfoLocal | null,readonly version: ConversationVers,readonly localVersion: LocalConversationVers,readonly maxMsgID: MessageID,readonly maxVisibleMsgID: MessageID,readonly readMsgID: MessageID,readonly convRetention?: RetentionPolicy | null,readonly teamRetention?: RetentionPolicy | null,readonly convSettings?: ConversationSettingsLocal | null,readonly finalizeInfo?: ConversationFinalizeInfo | null,readonly supersedes?: ReadonlyArray<ConversationMetadata> | null,readonly supersededBy?: ReadonlyArray<ConversationMetadata> | null,readonly commands: ConversationCommandGroups,readonly botCommands: ConversationCommandGroups,readonly botAliases?: {[key: string]: string} | null,readonly pinnedMsg?: UIPinnedMessage | null,readonly threadUnreads?: ReadonlyArray<ThreadUnread> | null,}
 export type InboxUIItemError = {readonly typ: ConversationErrorType,readonly message: string,readonly unverifiedTLFName: string,readonly rekeyInfo?: ConversationErrorRekey | null,readonly remoteConv: UnverifiedInboxUIItem,}
 export type InboxUIItems = {readonly items?: ReadonlyArray<InboxUIItem> | null,readonly offline: boolean,}
 export type InboxVers = number
@@ -1330,6 +1367,8 @@ export type InboxVersInfo = {readonly uid: Gregor1.UID,readonly vers: InboxVers,
 export type InboxView ={ rtype: InboxResType.versionhit } | { rtype: InboxResType.full, full: InboxViewFull }
 export type InboxViewFull = {readonly vers: InboxVers,readonly conversations?: ReadonlyArray<Conversation> | null,readonly pagination?: Pagination | null,}
 export type IncomingMessage = {readonly message: UIMessage,readonly modifiedMessage?: UIMessage | null,readonly convID: ConversationID,readonly displayDesktopNotification: boolean,readonly desktopNotificationSnippet: string,readonly conv?: InboxUIItem | null,readonly pagination?: UIPagination | null,}
+export type InteractiveElement = {readonly typ: InteractiveElementType,readonly id: string,readonly label: string,readonly payload: string,readonly options?: ReadonlyArray<InteractiveOption> | null,}
+export type InteractiveOption = {readonly label: string,readonly payload: string,}
 export type JoinLeaveConversationLocalRes = {readonly offline: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
 export type JoinLeaveConversationRemoteRes = {readonly rateLimit?: RateLimit | null,}
 export type KBFSImpteamUpgradeUpdate = {readonly convID: ConversationID,readonly inboxVers: InboxVers,readonly topicType: TopicType,}
@@ -1340,6 +1379,7 @@ export type LastActiveStatusAll = {readonly teams?: {[key: string]: LastActiveSt
 export type LastActiveTimeAll = {readonly teams?: {[key: string]: Gregor1.Time} | null,readonly channels?: {[key: string]: Gregor1.Time} | null,}
 export type ListBotCommandsLocalRes = {readonly commands?: ReadonlyArray<UserBotCommandOutput> | null,readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
 export type ListCommandsRes = {readonly commands?: ReadonlyArray<UserBotCommandOutput> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
+export type ListScheduledRes = {readonly scheduled?: ReadonlyArray<ScheduledMsgSummary> | null,}
 export type LiveLocation = {readonly endTime: Gregor1.Time,}
 export type LoadFlipRes = {readonly status: UICoinFlipStatus,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type LoadGalleryRes = {readonly messages?: ReadonlyArray<UIMessage> | null,readonly last: boolean,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
@@ -1360,7 +1400,7 @@ export type MerkleRoot = {readonly seqno: number,readonly hash: Uint8Array,}
 export type Message = {readonly msg?: MsgSummary | null,readonly error?: string | null,}
 export type MessageAttachment = {readonly object: Asset,readonly preview?: Asset | null,readonly previews?: ReadonlyArray<Asset> | null,readonly metadata: Uint8Array,readonly uploaded: boolean,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,readonly emojis?: {[key: string]: HarvestedEmoji} | null,}
 export type MessageAttachmentUploaded = {readonly messageID: MessageID,readonly object: Asset,readonly previews?: ReadonlyArray<Asset> | null,readonly metadata: Uint8Array,}
-export type MessageBody ={ messageType: MessageType.text, text: MessageText } | { messageType: MessageType.attachment, attachment: MessageAttachment } | { messageType: MessageType.edit, edit: MessageEdit } | { messageType: MessageType.delete, delete: MessageDelete } | { messageType: MessageType.metadata, metadata: MessageConversationMetadata } | { messageType: MessageType.headline, headline: MessageHeadline } | { messageType: MessageType.attachmentuploaded, attachmentuploaded: MessageAttachmentUploaded } | { messageType: MessageType.join, join: MessageJoin } | { messageType: MessageType.leave, leave: MessageLeave } | { messageType: MessageType.system, system: MessageSystem } | { messageType: MessageType.deletehistory, deletehistory: MessageDeleteHistory } | { messageType: MessageType.reaction, reaction: MessageReaction } | { messageType: MessageType.sendpayment, sendpayment: MessageSendPayment } | { messageType: MessageType.requestpayment, requestpayment: MessageRequestPayment } | { messageType: MessageType.unfurl, unfurl: MessageUnfurl } | { messageType: MessageType.flip, flip: MessageFlip } | { messageType: MessageType.pin, pin: MessagePin } | { messageType: MessageType.none} | { messageType: MessageType.tlfname}
+export type MessageBody ={ messageType: MessageType.text, text: MessageText } | { messageType: MessageType.attachment, attachment: MessageAttachment } | { messageType: MessageType.edit, edit: MessageEdit } | { messageType: MessageType.delete, delete: MessageDelete } | { messageType: MessageType.metadata, metadata: MessageConversationMetadata } | { messageType: MessageType.headline, headline: MessageHeadline } | { messageType: MessageType.attachmentuploaded, attachmentuploaded: MessageAttachmentUploaded } | { messageType: MessageType.join, join: MessageJoin } | { messageType: MessageType.leave, leave: MessageLeave } | { messageType: MessageType.system, system: MessageSystem } | { messageType: MessageType.deletehistory, deletehistory: MessageDeleteHistory } | { messageType: MessageType.reaction, reaction: MessageReaction } | { messageType: MessageType.sendpayment, sendpayment: MessageSendPayment } | { messageType: MessageType.requestpayment, requestpayment: MessageRequestPayment } | { messageType: MessageType.unfurl, unfurl: MessageUnfurl } | { messageType: MessageType.flip, flip: MessageFlip } | { messageType: MessageType.pin, pin: MessagePin } | { messageType: MessageType.poll, poll: MessagePoll } | { messageType: MessageType.pollvote, pollvote: MessagePollVote } | { messageType: MessageType.interactive, interactive: MessageInteractive } | { messageType: MessageType.interactiveaction, interactiveaction: MessageInteractiveAction } | { messageType: MessageType.none} | { messageType: MessageType.tlfname}
 export type MessageBoxed = {readonly version: MessageBoxedVersion,readonly serverHeader?: MessageServerHeader | null,readonly clientHeader: MessageClientHeader,readonly headerCiphertext: SealedData,readonly bodyCiphertext: EncryptedData,readonly verifyKey: Uint8Array,readonly keyGeneration: number,}
 export type MessageClientHeader = {readonly conv: ConversationIDTriple,readonly tlfName: string,readonly tlfPublic: boolean,readonly messageType: MessageType,readonly supersedes: MessageID,readonly kbfsCryptKeysUsed?: boolean | null,readonly deletes?: ReadonlyArray<MessageID> | null,readonly prev?: ReadonlyArray<MessagePreviousPointer> | null,readonly deleteHistory?: MessageDeleteHistory | null,readonly sender: Gregor1.UID,readonly senderDevice: Gregor1.DeviceID,readonly merkleRoot?: MerkleRoot | null,readonly outboxID?: OutboxID | null,readonly outboxInfo?: OutboxInfo | null,readonly em /* ephemeralMetadata */ ?: MsgEphemeralMetadata | null,readonly pm /* pairwiseMacs */ ?: {[key: string]: Uint8Array} | null,readonly b /* botUID */ ?: Gregor1.UID | null,readonly t /* txID */ ?: Stellar1.TransactionID | null,}
 export type MessageClientHeaderVerified = {readonly conv: ConversationIDTriple,readonly tlfName: string,readonly tlfPublic: boolean,readonly messageType: MessageType,readonly prev?: ReadonlyArray<MessagePreviousPointer> | null,readonly sender: Gregor1.UID,readonly senderDevice: Gregor1.DeviceID,readonly kbfsCryptKeysUsed?: boolean | null,readonly merkleRoot?: MerkleRoot | null,readonly outboxID?: OutboxID | null,readonly outboxInfo?: OutboxInfo | null,readonly em /* ephemeralMetadata */ ?: MsgEphemeralMetadata | null,readonly rt /* rtime */ : Gregor1.Time,readonly pm /* hasPairwiseMacs */ : boolean,readonly b /* botUID */ ?: Gregor1.UID | null,}
@@ -1372,10 +1412,14 @@ export type MessageFlip = {readonly text: string,readonly gameID: FlipGameID,rea
 export type MessageHeadline = {readonly headline: string,readonly emojis?: {[key: string]: HarvestedEmoji} | null,}
 export type MessageID = number
 export type MessageIDControl = {readonly pivot?: MessageID | null,readonly mode: MessageIDControlMode,readonly num: number,}
+export type MessageInteractive = {readonly body: string,readonly elements?: ReadonlyArray<InteractiveElement> | null,}
+export type MessageInteractiveAction = {readonly messageID: MessageID,readonly botUID: Gregor1.UID,readonly elementID: string,readonly payload: string,}
 export type MessageJoin = {readonly joiners?: ReadonlyArray<string> | null,readonly leavers?: ReadonlyArray<string> | null,}
 export type MessageLeave = {}
 export type MessagePin = {readonly msgID: MessageID,}
 export type MessagePlaintext = {readonly clientHeader: MessageClientHeader,readonly messageBody: MessageBody,readonly supersedesOutboxID?: OutboxID | null,readonly emojis?: ReadonlyArray<HarvestedEmoji> | null,}
+export type MessagePoll = {readonly question: string,readonly options?: ReadonlyArray<string> | null,readonly multiChoice: boolean,readonly anonymous: boolean,readonly closeTime?: Gregor1.Time | null,}
+export type MessagePollVote = {readonly messageID: MessageID,readonly choices?: ReadonlyArray<number> | null,}
 export type MessagePreviousPointer = {readonly id: MessageID,readonly hash: Hash,}
 export type MessageReaction = {readonly m /* messageID */ : MessageID,readonly b /* body */ : string,readonly t /* targetUID */ ?: Gregor1.UID | null,readonly e /* emojis */ ?: {[key: string]: HarvestedEmoji} | null,}
 export type MessageRequestPayment = {readonly requestID: Stellar1.KeybaseRequestID,readonly note: string,}
@@ -1393,22 +1437,28 @@ export type MessageSystemGitPush = {readonly team: string,readonly pusher: strin
 export type MessageSystemInviteAddedToTeam = {readonly team: string,readonly inviter: string,readonly invitee: string,readonly adder: string,readonly inviteType: Keybase1.TeamInviteCategory,readonly role: Keybase1.TeamRole,}
 export type MessageSystemNewChannel = {readonly creator: string,readonly nameAtCreation: string,readonly convID: ConversationID,readonly convIDs?: ReadonlyArray<ConversationID> | null,}
 export type MessageSystemSbsResolve = {readonly assertionService: string,readonly assertionUsername: string,readonly prover: string,}
-export type MessageText = {readonly body: string,readonly payments?: ReadonlyArray<TextPayment> | null,readonly replyTo?: MessageID | null,readonly replyToUID?: Gregor1.UID | null,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,readonly liveLocation?: LiveLocation | null,readonly emojis?: {[key: string]: HarvestedEmoji} | null,}
+export type MessageText = {readonly body: string,readonly payments?: ReadonlyArray<TextPayment> | null,readonly replyTo?: MessageID | null,readonly replyToUID?: Gregor1.UID | null,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,readonly liveLocation?: LiveLocation | null,readonly emojis?: {[key: string]: HarvestedEmoji} | null,readonly botCommand?: BotCommandInvocation | null,}
 export type MessageUnboxed ={ state: MessageUnboxedState.valid, valid: MessageUnboxedValid } | { state: MessageUnboxedState.error, error: MessageUnboxedError } | { state: MessageUnboxedState.outbox, outbox: OutboxRecord } | { state: MessageUnboxedState.placeholder, placeholder: MessageUnboxedPlaceholder } | { state: MessageUnboxedState.journeycard, journeycard: MessageUnboxedJourneycard }
 export type MessageUnboxedError = {readonly errType: MessageUnboxedErrorType,readonly errMsg: string,readonly internalErrMsg: string,readonly versionKind: VersionKind,readonly versionNumber: number,readonly isCritical: boolean,readonly senderUsername: string,readonly senderDeviceName: string,readonly senderDeviceType: Keybase1.DeviceTypeV2,readonly messageID: MessageID,readonly messageType: MessageType,readonly ctime: Gregor1.Time,readonly isEphemeral: boolean,readonly explodedBy?: string | null,readonly etime: Gregor1.Time,readonly botUsername: string,}
 export type MessageUnboxedJourneycard = {readonly prevID: MessageID,readonly ordinal: number,readonly cardType: JourneycardType,readonly highlightMsgID: MessageID,readonly openTeam: boolean,}
 export type MessageUnboxedPlaceholder = {readonly messageID: MessageID,readonly hidden: boolean,}
-export type MessageUnboxedValid = {readonly clientHeader: MessageClientHeaderVerified,readonly serverHeader: MessageServerHeader,readonly messageBody: MessageBody,readonly senderUsername: string,readonly senderDeviceName: string,readonly senderDeviceType: Keybase1.DeviceTypeV2,readonly bodyHash: Hash,readonly headerHash: Hash,readonly headerSignature?: SignatureInfo | null,readonly verificationKey?: Uint8Array | null,readonly senderDeviceRevokedAt?: Gregor1.Time | null,readonly atMentionUsernames?: ReadonlyArray<string> | null,readonly atMentions?: ReadonlyArray<Gregor1.UID> | null,readonly channelMention: ChannelMention,readonly maybeMentions?: ReadonlyArray<MaybeMention> | null,readonly channelNameMentions?: ReadonlyArray<ChannelNameMention> | null,readonly reactions: ReactionMap,readonly unfurls?: {[key: string]: UnfurlResult} | null,readonly emojis?: ReadonlyArray<HarvestedEmoji> | null,readonly replyTo?: MessageUnboxed | null,readonly botUsername: string,}
+export type MessageUnboxedValid = {readonly clientHeader: MessageClientHeaderVerified,readonly serverHeader: MessageServerHeader,readonly messageBody: MessageBody,readonly senderUsername: string,readonly senderDeviceName: string,readonly senderDeviceType: Keybase1.DeviceTypeV2,readonly bodyHash: Hash,readonly headerHash: Hash,readonly headerSignature?: SignatureInfo | null,readonly verificationKey?: Uint8Array | null,readonly senderDeviceRevokedAt?: Gregor1.Time | null,readonly atMentionUsernames?: ReadonlyArray<string> | null,readonly atMentions?: ReadonlyArray<Gregor1.UID> | null,readonly channelMention: ChannelMention,readonly maybeMentions?: ReadonlyArray<MaybeMention> | null,readonly channelNameMentions?: ReadonlyArray<ChannelNameMention> | null,readonly reactions: ReactionMap,readonly pollVotes: PollVoteMap,readonly unfurls?: {[key: string]: UnfurlResult} | null,readonly emojis?: ReadonlyArray<HarvestedEmoji> | null,readonly replyTo?: MessageUnboxed | null,readonly botUsername: string,}
 export type MessageUnfurl = {readonly unfurl: UnfurlResult,readonly messageID: MessageID,}
 export type MessagesUpdated = {readonly convID: ConversationID,readonly updates?: ReadonlyArray<UIMessage> | null,}
 export type MsgBotInfo = {readonly botUID: Keybase1.UID,readonly botUsername: string,}
-export type MsgContent = {readonly typeName: string,readonly text?: MsgTextContent | null,readonly attachment?: MessageAttachment | null,readonly edit?: MessageEdit | null,readonly reaction?: MessageReaction | null,readonly delete?: MessageDelete | null,readonly metadata?: MessageConversationMetadata | null,readonly headline?: MessageHeadline | null,readonly attachmentUploaded?: MessageAttachmentUploaded | null,readonly system?: MessageSystem | null,readonly sendPayment?: MessageSendPayment | null,readonly requestPayment?: MessageRequestPayment | null,readonly unfurl?: MessageUnfurl | null,readonly flip?: MsgFlipContent | null,}
+export type MsgContent = {readonly typeName: string,readonly text?: MsgTextContent | null,readonly attachment?: MessageAttachment | null,readonly edit?: MessageEdit | null,readonly reaction?: MessageReaction | null,readonly delete?: MessageDelete | null,readonly metadata?: MessageConversationMetadata | null,readonly headline?: MessageHeadline | null,readonly attachmentUploaded?: MessageAttachmentUploaded | null,readonly system?: MessageSystem | null,readonly sendPayment?: MessageSendPayment | null,readonly requestPayment?: MessageRequestPayment | null,readonly unfurl?: MessageUnfurl | null,readonly flip?: MsgFlipContent | null,readonly poll?: MsgPollContent | null,readonly pollVote?: MsgPollVoteContent | null,readonly interactive?: MsgInteractiveContent | null,readonly interactiveAction?: MsgInteractiveActionContent | null,}
 export type MsgEphemeralMetadata = {readonly l /* lifetime */ : Gregor1.DurationSec,readonly g /* generation */ : Keybase1.EkGeneration,readonly u /* explodedBy */ ?: string | null,}
 export type MsgFlipContent = {readonly text: string,readonly gameID: FlipGameIDStr,readonly flipConvID: ConvIDStr,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,}
+export type MsgInteractiveActionContent = {readonly messageID: MessageID,readonly botUID: Keybase1.UID,readonly elementID: string,readonly payload: string,}
+export type MsgInteractiveContent = {readonly body: string,readonly elements?: ReadonlyArray<MsgInteractiveElement> | null,}
+export type MsgInteractiveElement = {readonly typ: string,readonly id: string,readonly label: string,readonly payload: string,readonly options?: ReadonlyArray<MsgInteractiveOption> | null,}
+export type MsgInteractiveOption = {readonly label: string,readonly payload: string,}
 export type MsgNotification = {readonly type: string,readonly source: string,readonly msg?: MsgSummary | null,readonly error?: string | null,readonly pagination?: UIPagination | null,}
+export type MsgPollContent = {readonly question: string,readonly multiChoice: boolean,readonly anonymous: boolean,readonly closeTime?: number | null,readonly closed: boolean,readonly totalVoters: number,readonly options?: ReadonlyArray<PollOptionResult> | null,}
+export type MsgPollVoteContent = {readonly messageID: MessageID,readonly choices?: ReadonlyArray<number> | null,}
 export type MsgSender = {readonly uid: Keybase1.UID,readonly username: string,readonly deviceID: Keybase1.DeviceID,readonly deviceName: string,}
 export type MsgSummary = {readonly id: MessageID,readonly convID: ConvIDStr,readonly channel: ChatChannel,readonly sender: MsgSender,readonly sentAt: number,readonly sentAtMs: number,readonly content: MsgContent,readonly prev?: ReadonlyArray<MessagePreviousPointer> | null,readonly unread: boolean,readonly revokedDevice: boolean,readonly offline: boolean,readonly kbfsEncrypted: boolean,readonly isEphemeral: boolean,readonly isEphemeralExpired: boolean,readonly eTime: Gregor1.Time,readonly reactions?: UIReactionMap | null,readonly hasPairwiseMacs: boolean,readonly atMentionUsernames?: ReadonlyArray<string> | null,readonly channelMention: string,readonly channelNameMentions?: ReadonlyArray<UIChannelNameMention> | null,readonly botInfo?: MsgBotInfo | null,}
-export type MsgTextContent = {readonly body: string,readonly payments?: ReadonlyArray<TextPayment> | null,readonly replyTo?: MessageID | null,readonly replyToUID?: string | null,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,readonly liveLocation?: LiveLocation | null,readonly emojis?: ReadonlyArray<EmojiContent> | null,}
+export type MsgTextContent = {readonly body: string,readonly payments?: ReadonlyArray<TextPayment> | null,readonly replyTo?: MessageID | null,readonly replyToUID?: string | null,readonly userMentions?: ReadonlyArray<KnownUserMention> | null,readonly teamMentions?: ReadonlyArray<KnownTeamMention> | null,readonly liveLocation?: LiveLocation | null,readonly emojis?: ReadonlyArray<EmojiContent> | null,readonly botCommand?: BotCommandInvocation | null,}
 export type NameQuery = {readonly name: string,readonly tlfID?: TLFID | null,readonly membersType: ConversationMembersType,}
 export type NewConvRes = {readonly id: ConvIDStr,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
 export type NewConversationInfo = {readonly convID: ConversationID,readonly conv?: InboxUIItem | null,}
@@ -1427,7 +1477,11 @@ export type OutboxState ={ state: OutboxStateType.sending, sending: number } | {
 export type OutboxStateError = {readonly message: string,readonly typ: OutboxErrorType,}
 export type Pagination = {readonly next: Uint8Array,readonly previous: Uint8Array,readonly num: number,readonly last: boolean,readonly forceFirstPage: boolean,}
 export type PinMessageRes = {readonly rateLimits?: ReadonlyArray<RateLimit> | null,}
-export type PostFileAttachmentArg = {readonly conversationID: ConversationID,readonly tlfName: string,readonly visibility: Keybase1.TLFVisibility,readonly filename: string,readonly title: string,readonly metadata: Uint8Array,readonly identifyBehavior: Keybase1.TLFIdentifyBehavior,readonly callerPreview?: MakePreviewRes | null,readonly outboxID?: OutboxID | null,readonly ephemeralLifetime?: Gregor1.DurationSec | null,}
+export type PollOptionResult = {readonly option: string,readonly votes: number,readonly voters?: ReadonlyArray<string> | null,}
+export type PollResultsRes = {readonly messageID: MessageID,readonly poll: MsgPollContent,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
+export type PollVote = {readonly ctime: Gregor1.Time,readonly voteMsgID: MessageID,readonly choices?: ReadonlyArray<number> | null,}
+export type PollVoteMap = {readonly votes?: {[key: string]: PollVote} | null,}
+export type PostFileAttachmentArg = {readonly conversationID: ConversationID,readonly tlfName: string,readonly visibility: Keybase1.TLFVisibility,readonly filename: string,readonly title: string,readonly metadata: Uint8Array,readonly identifyBehavior: Keybase1.TLFIdentifyBehavior,readonly callerPreview?: MakePreviewRes | null,readonly outboxID?: OutboxID | null,readonly ephemeralLifetime?: Gregor1.DurationSec | null,readonly keepMetadata: boolean,}
 export type PostLocalNonblockRes = {readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly outboxID: OutboxID,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type PostLocalRes = {readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly messageID: MessageID,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type PostRemoteRes = {readonly msgHeader: MessageServerHeader,readonly rateLimit?: RateLimit | null,}
@@ -1460,11 +1514,13 @@ export type ResetConvMember = {readonly username: string,readonly uid: Gregor1.U
 export type ResetConvMemberAPI = {readonly conversationID: ConvIDStr,readonly username: string,}
 export type ResetConversationMember = {readonly convID: ConversationID,readonly uid: Gregor1.UID,}
 export type RetentionPolicy ={ typ: RetentionPolicyType.retain, retain: RpRetain } | { typ: RetentionPolicyType.expire, expire: RpExpire } | { typ: RetentionPolicyType.inherit, inherit: RpInherit } | { typ: RetentionPolicyType.ephemeral, ephemeral: RpEphemeral } | { typ: RetentionPolicyType.none}
-export type RpEphemeral = {readonly age: Gregor1.DurationSec,}
+export type RpEphemeral = {readonly age: Gregor1.DurationSec,readonly allowNonExploding: boolean,}
 export type RpExpire = {readonly age: Gregor1.DurationSec,}
 export type RpInherit = {}
 export type RpRetain = {}
 export type S3Params = {readonly bucket: string,readonly objectKey: string,readonly accessKey: string,readonly acl: string,readonly regionName: string,readonly regionEndpoint: string,readonly regionBucketEndpoint: string,readonly token: string,}
+export type ScheduledMessage = {readonly outboxID: OutboxID,readonly convID: ConversationID,readonly sendTime: Gregor1.Time,readonly ctime: Gregor1.Time,readonly msg: MessagePlaintext,readonly identifyBehavior: Keybase1.TLFIdentifyBehavior,}
+export type ScheduledMsgSummary = {readonly id: string,readonly convID: ConvIDStr,readonly channel: ChatChannel,readonly body: string,readonly sendAt: number,readonly sendAtMs: number,readonly explodingLifetime?: string | null,}
 export type SealedData = {readonly v: number,readonly e: Uint8Array,readonly n: Uint8Array,}
 export type SearchInboxRes = {readonly offline: boolean,readonly res?: ChatSearchInboxResults | null,readonly rateLimits?: ReadonlyArray<RateLimit> | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,}
 export type SearchInboxResOutput = {readonly results?: ChatSearchInboxResults | null,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
@@ -1511,10 +1567,13 @@ export type TeamMember = {readonly uid: Gregor1.UID,readonly role: Keybase1.Team
 export type TeamMemberRoleUpdate = {readonly tlfID: TLFID,readonly role: Keybase1.TeamRole,}
 export type TeamTypeInfo = {readonly convID: ConversationID,readonly teamType: TeamType,readonly conv?: InboxUIItem | null,}
 export type TeamTypePayload = {readonly Action: string,readonly convID: ConversationID,readonly teamType: TeamType,readonly inboxVers: InboxVers,readonly topicType: TopicType,readonly unreadUpdate?: UnreadUpdate | null,}
+export type TeamUnfurlPolicy = {readonly mode: TeamUnfurlMode,readonly allowlist?: ReadonlyArray<string> | null,}
 export type TextPayment = {readonly username: string,readonly paymentText: string,readonly result: TextPaymentResult,}
 export type TextPaymentResult ={ resultTyp: TextPaymentResultTyp.error, error: string } | { resultTyp: TextPaymentResultTyp.sent, sent: Stellar1.PaymentID }
 export type Thread = {readonly messages?: ReadonlyArray<Message> | null,readonly pagination?: Pagination | null,readonly offline: boolean,readonly identifyFailures?: ReadonlyArray<Keybase1.TLFIdentifyFailure> | null,readonly rateLimits?: ReadonlyArray<RateLimitRes> | null,}
 export type ThreadID = Uint8Array
+export type ThreadUnread = {readonly rootMsgID: MessageID,readonly unreadCount: number,}
+export type ThreadUnreadSummary = {readonly rootID: MessageID,readonly unreadCount: number,}
 export type ThreadView = {readonly messages?: ReadonlyArray<MessageUnboxed> | null,readonly pagination?: Pagination | null,}
 export type ThreadViewBoxed = {readonly messages?: ReadonlyArray<MessageBoxed> | null,readonly pagination?: Pagination | null,}
 export type TopicID = Uint8Array
@@ -1560,11 +1619,14 @@ export type UIPaymentInfo = {readonly accountID?: Stellar1.AccountID | null,read
 export type UIPinnedMessage = {readonly message: UIMessage,readonly pinnerUsername: string,}
 export type UIReactionDesc = {readonly decorated: string,readonly users?: {[key: string]: Reaction} | null,}
 export type UIReactionMap = {readonly reactions?: {[key: string]: UIReactionDesc} | null,}
-export type UIRequestInfo = {readonly amount: string,readonly amountDescription: string,readonly asset?: Stellar1.Asset | null,readonly currency?: Stellar1.OutsideCurrencyCode | null,readonly worthAtRequestTime: string,readonly status: Stellar1.RequestStatus,}
+export type UIRequestInfo = {readonly amount: string,readonly amountDescription: string,readonly asset?: Stellar1.Asset | null,readonly currency?: Stellar1.OutsideCurrencyCode | null,readonly worthAtRequestTime: string,readonly status: Stellar1.RequestStatus,readonly amountPaidDescription: string,readonly invoice?: Stellar1.InvoiceContents | null,}
 export type UITeamMention = {readonly inTeam: boolean,readonly open: boolean,readonly description?: string | null,readonly numMembers?: number | null,readonly publicAdmins?: ReadonlyArray<string> | null,readonly convID?: ConvIDStr | null,}
 export type UITextDecoration ={ typ: UITextDecorationTyp.payment, payment: TextPayment } | { typ: UITextDecorationTyp.atmention, atmention: string } | { typ: UITextDecorationTyp.channelnamemention, channelnamemention: UIChannelNameMention } | { typ: UITextDecorationTyp.maybemention, maybemention: MaybeMention } | { typ: UITextDecorationTyp.link, link: UILinkDecoration } | { typ: UITextDecorationTyp.mailto, mailto: UILinkDecoration } | { typ: UITextDecorationTyp.kbfspath, kbfspath: KBFSPath } | { typ: UITextDecorationTyp.emoji, emoji: Emoji }
-export type Unfurl ={ unfurlType: UnfurlType.generic, generic: UnfurlGeneric } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutube } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphy } | { unfurlType: UnfurlType.maps}
-export type UnfurlDisplay ={ unfurlType: UnfurlType.generic, generic: UnfurlGenericDisplay } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutubeDisplay } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphyDisplay } | { unfurlType: UnfurlType.maps}
+export type Unfurl ={ unfurlType: UnfurlType.generic, generic: UnfurlGeneric } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutube } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphy } | { unfurlType: UnfurlType.embed, embed: UnfurlEmbed } | { unfurlType: UnfurlType.maps}
+export type UnfurlDisplay ={ unfurlType: UnfurlType.generic, generic: UnfurlGenericDisplay } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutubeDisplay } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphyDisplay } | { unfurlType: UnfurlType.embed, embed: UnfurlEmbedDisplay } | { unfurlType: UnfurlType.maps}
+export type UnfurlEmbed = {readonly embedType: UnfurlEmbedType,readonly title: string,readonly url: string,readonly siteName: string,readonly authorName?: string | null,readonly description?: string | null,readonly favicon?: Asset | null,readonly thumbnail?: Asset | null,readonly playerUrl?: string | null,readonly width: number,readonly height: number,readonly snippet?: string | null,}
+export type UnfurlEmbedDisplay = {readonly embedType: UnfurlEmbedType,readonly title: string,readonly url: string,readonly siteName: string,readonly authorName?: string | null,readonly description?: string | null,readonly favicon?: UnfurlImageDisplay | null,readonly thumbnail?: UnfurlImageDisplay | null,readonly playerUrl?: string | null,readonly width: number,readonly height: number,readonly snippet?: string | null,}
+export type UnfurlEmbedRaw = {readonly embedType: UnfurlEmbedType,readonly title: string,readonly url: string,readonly siteName: string,readonly authorName?: string | null,readonly description?: string | null,readonly faviconUrl?: string | null,readonly thumbnailUrl?: string | null,readonly playerUrl?: string | null,readonly width: number,readonly height: number,readonly snippet?: string | null,}
 export type UnfurlGeneric = {readonly title: string,readonly url: string,readonly siteName: string,readonly favicon?: Asset | null,readonly image?: Asset | null,readonly publishTime?: number | null,readonly description?: string | null,readonly mapInfo?: UnfurlGenericMapInfo | null,}
 export type UnfurlGenericDisplay = {readonly title: string,readonly url: string,readonly siteName: string,readonly favicon?: UnfurlImageDisplay | null,readonly media?: UnfurlImageDisplay | null,readonly publishTime?: number | null,readonly description?: string | null,readonly mapInfo?: UnfurlGenericMapInfo | null,}
 export type UnfurlGenericMapInfo = {readonly coord: Coordinate,readonly time: Gregor1.Time,readonly liveLocationEndTime?: Gregor1.Time | null,readonly isLiveLocationDone: boolean,}
@@ -1575,7 +1637,7 @@ export type UnfurlGiphyRaw = {readonly imageUrl?: string | null,readonly video?:
 export type UnfurlImageDisplay = {readonly url: string,readonly height: number,readonly width: number,readonly isVideo: boolean,}
 export type UnfurlMapsRaw = {readonly title: string,readonly url: string,readonly siteName: string,readonly imageUrl: string,readonly historyImageUrl?: string | null,readonly description: string,readonly coord: Coordinate,readonly time: Gregor1.Time,readonly liveLocationEndTime?: Gregor1.Time | null,readonly liveLocationDone: boolean,}
 export type UnfurlPromptResult ={ actionType: UnfurlPromptAction.always } | { actionType: UnfurlPromptAction.never } | { actionType: UnfurlPromptAction.notnow } | { actionType: UnfurlPromptAction.accept, accept: string } | { actionType: UnfurlPromptAction.onetime, onetime: string }
-export type UnfurlRaw ={ unfurlType: UnfurlType.generic, generic: UnfurlGenericRaw } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutubeRaw } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphyRaw } | { unfurlType: UnfurlType.maps, maps: UnfurlMapsRaw }
+export type UnfurlRaw ={ unfurlType: UnfurlType.generic, generic: UnfurlGenericRaw } | { unfurlType: UnfurlType.youtube, youtube: UnfurlYoutubeRaw } | { unfurlType: UnfurlType.giphy, giphy: UnfurlGiphyRaw } | { unfurlType: UnfurlType.maps, maps: UnfurlMapsRaw } | { unfurlType: UnfurlType.embed, embed: UnfurlEmbedRaw }
 export type UnfurlResult = {readonly unfurl: Unfurl,readonly url: string,}
 export type UnfurlSettings = {readonly mode: UnfurlMode,readonly whitelist?: {[key: string]: boolean} | null,}
 export type UnfurlSettingsDisplay = {readonly mode: UnfurlMode,readonly whitelist?: ReadonlyArray<string> | null,}
@@ -1592,8 +1654,9 @@ export type UnverifiedInboxUIItemMetadata = {readonly channelName: string,readon
 export type UnverifiedInboxUIItems = {readonly items?: ReadonlyArray<UnverifiedInboxUIItem> | null,readonly offline: boolean,}
 export type UpdateConversationMembership = {readonly inboxVers: InboxVers,readonly teamMemberRoleUpdate?: TeamMemberRoleUpdate | null,readonly joined?: ReadonlyArray<ConversationMember> | null,readonly removed?: ReadonlyArray<ConversationMember> | null,readonly reset?: ReadonlyArray<ConversationMember> | null,readonly previewed?: ReadonlyArray<ConversationID> | null,readonly unreadUpdate?: UnreadUpdate | null,readonly unreadUpdates?: ReadonlyArray<UnreadUpdate> | null,}
 export type UpdateConversations = {readonly inboxVers: InboxVers,readonly convUpdates?: ReadonlyArray<ConversationUpdate> | null,}
-export type UserBotCommandInput = {readonly name: string,readonly description: string,readonly usage: string,readonly extendedDescription?: UserBotExtendedDescription | null,}
-export type UserBotCommandOutput = {readonly name: string,readonly description: string,readonly usage: string,readonly extendedDescription?: UserBotExtendedDescription | null,readonly username: string,}
+export type UserBotCommandArg = {readonly name: string,readonly typ: string,readonly description?: string | null,readonly optional?: boolean | null,readonly values?: ReadonlyArray<string> | null,}
+export type UserBotCommandInput = {readonly name: string,readonly description: string,readonly usage: string,readonly extendedDescription?: UserBotExtendedDescription | null,readonly args?: ReadonlyArray<UserBotCommandArg> | null,}
+export type UserBotCommandOutput = {readonly name: string,readonly description: string,readonly usage: string,readonly extendedDescription?: UserBotExtendedDescription | null,readonly username: string,readonly args?: ReadonlyArray<UserBotCommandArg> | null,}
 export type UserBotExtendedDescription = {readonly title: string,readonly desktopBody: string,readonly mobileBody: string,}
 export type UserEmojiRes = {readonly emojis: UserEmojis,readonly rateLimit?: RateLimit | null,}
 export type UserEmojis = {readonly emojis?: ReadonlyArray<EmojiGroup> | null,}
@@ -1739,6 +1802,25 @@ export const localUserEmojisRpcPromise = createRpc('chat.1.local.userEmojis')
 // 'chat.1.local.getLastActiveAtLocal'
 // 'chat.1.local.getParticipants'
 // 'chat.1.local.addEmoji'
+// 'chat.1.local.scheduleMessageLocal'
+// 'chat.1.local.getScheduledMessagesLocal'
+// 'chat.1.local.cancelScheduledMessageLocal'
+// 'chat.1.local.addChatWebhookLocal'
+// 'chat.1.local.listChatWebhooksLocal'
+// 'chat.1.local.removeChatWebhookLocal'
+// 'chat.1.local.addChatIncomingWebhookLocal'
+// 'chat.1.local.listChatIncomingWebhooksLocal'
+// 'chat.1.local.removeChatIncomingWebhookLocal'
+// 'chat.1.local.startChatWebhookServerLocal'
+// 'chat.1.local.stopChatWebhookServerLocal'
+// 'chat.1.local.getChatHighlightsLocal'
+// 'chat.1.local.setChatHighlightsLocal'
+// 'chat.1.local.getChatDNDScheduleLocal'
+// 'chat.1.local.setChatDNDScheduleLocal'
+// 'chat.1.local.getReplyThreadLocal'
+// 'chat.1.local.getChatBookmarksLocal'
+// 'chat.1.local.addChatBookmarkLocal'
+// 'chat.1.local.removeChatBookmarkLocal'
 // 'chat.1.NotifyChat.ChatTLFResolve'
 // 'chat.1.NotifyChat.ChatJoinedConversation'
 // 'chat.1.NotifyChat.ChatLeftConversation'
@@ -1440,6 +1440,13 @@ export enum DismissReasonType {
   handledElsewhere = 1,
 }
 
+export enum EkStorageLayer {
+  device = 0,
+  user = 1,
+  team = 2,
+  teambot = 3,
+}
+
 export enum ExitCode {
   ok = 0,
   notok = 2,
@@ -2412,6 +2419,13 @@ export enum TeamRole {
   restrictedbot = 6,
 }
 
+export enum TeamSearchSortOrder {
+  relevance = 0,
+  memberCount = 1,
+  lastActive = 2,
+  name = 3,
+}
+
 export enum TeamStatus {
   none = 0,
   live = 1,
@@ -2604,6 +2618,9 @@ export type ED25519PublicKey = string | null
 export type ED25519Signature = string | null
 export type ED25519SignatureInfo = {readonly sig: ED25519Signature,readonly publicKey: ED25519PublicKey,}
 export type EkGeneration = number
+export type EkGenerationStatus = {readonly generation: EkGeneration,readonly ctime: Time,readonly error: string,}
+export type EkRepairRes = {readonly dryRun: boolean,readonly actions?: ReadonlyArray<string> | null,readonly deleted: number,readonly refetched: number,readonly errors?: ReadonlyArray<string> | null,readonly storages?: ReadonlyArray<EkStorageStatus> | null,}
+export type EkStorageStatus = {readonly layer: EkStorageLayer,readonly teamID: TeamID,readonly teamName: string,readonly generations?: ReadonlyArray<EkGenerationStatus> | null,readonly gaps?: ReadonlyArray<EkGeneration> | null,}
 export type Email = {readonly email: EmailAddress,readonly isVerified: boolean,readonly isPrimary: boolean,readonly visibility: IdentityVisibility,readonly lastVerifyEmailDate: UnixTime,}
 export type EmailAddress = string
 export type EmailAddressChangedMsg = {readonly email: EmailAddress,}
@@ -2978,7 +2995,7 @@ export type TeamAndMemberShowcase = {readonly teamShowcase: TeamShowcase,readonl
 export type TeamApplicationKey = {readonly application: TeamApplication,readonly keyGeneration: PerTeamKeyGeneration,readonly key: Bytes32,}
 export type TeamAvatar = {readonly avatarFilename: string,readonly crop?: ImageCropRect | null,}
 export type TeamBlock = {readonly teamName: string,readonly createTime: Time,}
-export type TeamBotSettings = {readonly cmds: boolean,readonly mentions: boolean,readonly triggers?: ReadonlyArray<string> | null,readonly convs?: ReadonlyArray<string> | null,}
+export type TeamBotSettings = {readonly cmds: boolean,readonly mentions: boolean,readonly triggers?: ReadonlyArray<string> | null,readonly convs?: ReadonlyArray<string> | null,readonly cmdsOnly: boolean,readonly senders?: ReadonlyArray<UID> | null,}
 export type TeamCLKRMsg = {readonly teamID: TeamID,readonly generation: PerTeamKeyGeneration,readonly score: number,readonly resetUsersUntrusted?: ReadonlyArray<TeamCLKRResetUser> | null,}
 export type TeamCLKRResetUser = {readonly uid: UID,readonly userEldestSeqno: Seqno,readonly memberEldestSeqno: Seqno,}
 export type TeamChangeReq = {readonly owners?: ReadonlyArray<UserVersion> | null,readonly admins?: ReadonlyArray<UserVersion> | null,readonly writers?: ReadonlyArray<UserVersion> | null,readonly readers?: ReadonlyArray<UserVersion> | null,readonly bots?: ReadonlyArray<UserVersion> | null,readonly restrictedBots?: {[key: string]: TeamBotSettings} | null,readonly none?: ReadonlyArray<UserVersion> | null,readonly completedInvites?: {[key: string]: UserVersionPercentForm} | null,readonly usedInvites?: ReadonlyArray<TeamUsedInvite> | null,}
@@ -3047,6 +3064,7 @@ export type TeamRoleMapStored = {readonly data: TeamRoleMapAndVersion,readonly c
 export type TeamRolePair = {readonly role: TeamRole,readonly implicitRole: TeamRole,}
 export type TeamSBSMsg = {readonly teamID: TeamID,readonly score: number,readonly invitees?: ReadonlyArray<TeamInvitee> | null,}
 export type TeamSearchExport = {readonly items?: {[key: string]: TeamSearchItem} | null,readonly suggested?: ReadonlyArray<TeamID> | null,}
+export type TeamSearchFilter = {readonly minMemberCount: number,readonly maxMemberCount: number,readonly activeSince?: Time | null,}
 export type TeamSearchItem = {readonly id: TeamID,readonly name: string,readonly description?: string | null,readonly memberCount: number,readonly lastActive: Time,readonly isDemoted: boolean,readonly inTeam: boolean,}
 export type TeamSearchRes = {readonly results?: ReadonlyArray<TeamSearchItem> | null,}
 export type TeamSeitanMsg = {readonly teamID: TeamID,readonly seitans?: ReadonlyArray<TeamSeitanRequest> | null,}
@@ -3458,6 +3476,8 @@ export const userUserCardRpcPromise = createRpc('keybase.1.user.userCard')
 // 'keybase.1.emails.editEmail'
 // 'keybase.1.emails.setVisibilityAllEmail'
 // 'keybase.1.emails.getEmails'
+// 'keybase.1.ephemeral.getEkStatus'
+// 'keybase.1.ephemeral.repairEks'
 // 'keybase.1.favorite.favoriteAdd'
 // 'keybase.1.favorite.getFavorites'
 // 'keybase.1.featuredBot.searchLocal'
@@ -3727,6 +3747,7 @@ export const userUserCardRpcPromise = createRpc('keybase.1.user.userCard')
 // 'keybase.1.teams.ftl'
 // 'keybase.1.teams.getAnnotatedTeamByName'
 // 'keybase.1.teamSearch.teamSearch'
+// 'keybase.1.teamSearch.teamSearchDirectory'
 // 'keybase.1.test.test'
 // 'keybase.1.test.testCallback'
 // 'keybase.1.test.panic'
@@ -140,6 +140,18 @@ export enum RequestStatus {
   done = 2,
 }
 
+export enum ScheduledPaymentRecurrence {
+  once = 0,
+  weekly = 1,
+  monthly = 2,
+}
+
+export enum ScheduledPaymentRunStatus {
+  sent = 0,
+  skipped = 1,
+  failed = 2,
+}
+
 export enum TransactionStatus {
   none = 0,
   pending = 1,
@@ -183,6 +195,9 @@ export type BundleVisibleEntryV2 = {readonly accountID: AccountID,readonly mode:
 export type BundleVisibleV2 = {readonly revision: BundleRevision,readonly prev: Hash,readonly accounts?: ReadonlyArray<BundleVisibleEntryV2> | null,}
 export type ChatConversationID = string
 export type ClaimSummary = {readonly txID: TransactionID,readonly txStatus: TransactionStatus,readonly txErrMsg: string,readonly dir: RelayDirection,readonly toStellar: AccountID,readonly to: Keybase1.UserVersion,}
+export type CosignResultLocal = {readonly envelopeXdr: string,readonly status: CosignStatusLocal,readonly submitTxID?: TransactionID | null,}
+export type CosignSigner = {readonly accountID: AccountID,readonly weight: number,}
+export type CosignStatusLocal = {readonly accountID: AccountID,readonly txID: TransactionID,readonly operations?: ReadonlyArray<string> | null,readonly threshold: number,readonly collectedWeight: number,readonly signers?: ReadonlyArray<CosignSigner> | null,readonly signed?: ReadonlyArray<AccountID> | null,readonly ready: boolean,}
 export type CurrencyLocal = {readonly description: string,readonly code: OutsideCurrencyCode,readonly symbol: string,readonly name: string,}
 export type CurrencySymbol = {readonly symbol: string,readonly ambigious: boolean,readonly postfix: boolean,}
 export type DetailsPlusPayments = {readonly details: AccountDetails,readonly recentPayments: PaymentsPage,readonly pendingPayments?: ReadonlyArray<PaymentSummary> | null,}
@@ -194,6 +209,8 @@ export type EncryptedRelaySecret = {readonly v: number,readonly e: Uint8Array,re
 export type Hash = Uint8Array
 export type InflationDestinationResultLocal = {readonly destination?: AccountID | null,readonly knownDestination?: PredefinedInflationDestination | null,readonly self: boolean,}
 export type InflationDestinationTag = string
+export type InvoiceContents = {readonly lineItems?: ReadonlyArray<InvoiceLineItem> | null,readonly dueDate: TimeMs,readonly reference: string,}
+export type InvoiceLineItem = {readonly description: string,readonly quantity: string,readonly unitAmount: string,readonly amount: string,}
 export type KeybaseRequestID = string
 export type KeybaseTransactionID = string
 export type LookupResultCLILocal = {readonly accountID: AccountID,readonly username?: string | null,}
@@ -211,7 +228,8 @@ export type PaymentCLILocal = {readonly txID: TransactionID,readonly time: TimeM
 export type PaymentDetails = {readonly summary: PaymentSummary,readonly memo: string,readonly memoType: string,readonly externalTxURL: string,readonly feeCharged: string,readonly pathIntermediate?: ReadonlyArray<Asset> | null,}
 export type PaymentDetailsLocal = {readonly summary: PaymentLocal,readonly details: PaymentDetailsOnlyLocal,}
 export type PaymentDetailsOnlyLocal = {readonly publicNote: string,readonly publicNoteType: string,readonly externalTxURL: string,readonly feeChargedDescription: string,readonly pathIntermediate?: ReadonlyArray<Asset> | null,}
-export type PaymentDirectPost = {readonly fromDeviceID: Keybase1.DeviceID,readonly to?: Keybase1.UserVersion | null,readonly displayAmount: string,readonly displayCurrency: string,readonly noteB64: string,readonly signedTransaction: string,readonly quickReturn: boolean,readonly chatConversationID?: ChatConversationID | null,readonly batchID: string,}
+export type PaymentDirectPost = {readonly fromDeviceID: Keybase1.DeviceID,readonly to?: Keybase1.UserVersion | null,readonly displayAmount: string,readonly displayCurrency: string,readonly noteB64: string,readonly signedTransaction: string,readonly quickReturn: boolean,readonly chatConversationID?: ChatConversationID | null,readonly batchID: string,readonly requestID?: KeybaseRequestID | null,}
+export type PaymentExportCLILocal = {readonly payment: PaymentCLILocal,readonly sent: boolean,readonly counterparty: string,readonly feeCharged: string,readonly worth: string,readonly worthCurrency: string,}
 export type PaymentID = string
 export type PaymentLocal = {readonly id: PaymentID,readonly txID: TransactionID,readonly time: TimeMs,readonly statusSimplified: PaymentStatus,readonly statusDescription: string,readonly statusDetail: string,readonly showCancel: boolean,readonly amountDescription: string,readonly delta: BalanceDelta,readonly worth: string,readonly worthAtSendTime: string,readonly issuerDescription: string,readonly issuerAccountID?: AccountID | null,readonly fromType: ParticipantType,readonly toType: ParticipantType,readonly assetCode: string,readonly fromAccountID: AccountID,readonly fromAccountName: string,readonly fromUsername: string,readonly toAccountID?: AccountID | null,readonly toAccountName: string,readonly toUsername: string,readonly toAssertion: string,readonly originalToAssertion: string,readonly note: string,readonly noteErr: string,readonly sourceAmountMax: string,readonly sourceAmountActual: string,readonly sourceAsset: Asset,readonly sourceConvRate: string,readonly isAdvanced: boolean,readonly summaryAdvanced: string,readonly operations?: ReadonlyArray<string> | null,readonly unread: boolean,readonly batchID: string,readonly fromAirdrop: boolean,readonly isInflation: boolean,readonly inflationSource?: string | null,readonly trustline?: PaymentTrustlineLocal | null,}
 export type PaymentMultiPost = {readonly fromDeviceID: Keybase1.DeviceID,readonly signedTransaction: string,readonly operations?: ReadonlyArray<PaymentOp> | null,readonly batchID: string,}
@@ -222,7 +240,7 @@ export type PaymentOrErrorLocal = {readonly payment?: PaymentLocal | null,readon
 export type PaymentPath = {readonly sourceAmount: string,readonly sourceAmountMax: string,readonly sourceAsset: Asset,readonly path?: ReadonlyArray<Asset> | null,readonly destinationAmount: string,readonly destinationAsset: Asset,readonly sourceInsufficientBalance: string,}
 export type PaymentPathLocal = {readonly sourceDisplay: string,readonly sourceMaxDisplay: string,readonly destinationDisplay: string,readonly exchangeRate: string,readonly amountError: string,readonly destinationAccount: AccountID,readonly fullPath: PaymentPath,}
 export type PaymentPathQuery = {readonly source: AccountID,readonly destination: AccountID,readonly sourceAsset: Asset,readonly destinationAsset: Asset,readonly amount: string,}
-export type PaymentRelayPost = {readonly fromDeviceID: Keybase1.DeviceID,readonly to?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly relayAccount: AccountID,readonly teamID: Keybase1.TeamID,readonly displayAmount: string,readonly displayCurrency: string,readonly boxB64: string,readonly signedTransaction: string,readonly quickReturn: boolean,readonly chatConversationID?: ChatConversationID | null,readonly batchID: string,}
+export type PaymentRelayPost = {readonly fromDeviceID: Keybase1.DeviceID,readonly to?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly relayAccount: AccountID,readonly teamID: Keybase1.TeamID,readonly displayAmount: string,readonly displayCurrency: string,readonly boxB64: string,readonly signedTransaction: string,readonly quickReturn: boolean,readonly chatConversationID?: ChatConversationID | null,readonly batchID: string,readonly requestID?: KeybaseRequestID | null,}
 export type PaymentResult = {readonly senderAccountID: AccountID,readonly keybaseID: KeybaseTransactionID,readonly stellarID: TransactionID,readonly pending: boolean,}
 export type PaymentStatusMsg = {readonly accountID: AccountID,readonly kbTxID: KeybaseTransactionID,readonly txID: TransactionID,}
 export type PaymentSummary ={ typ: PaymentSummaryType.stellar, stellar: PaymentSummaryStellar } | { typ: PaymentSummaryType.direct, direct: PaymentSummaryDirect } | { typ: PaymentSummaryType.relay, relay: PaymentSummaryRelay } | { typ: PaymentSummaryType.none}
@@ -238,10 +256,14 @@ export type RelayClaimPost = {readonly keybaseID: KeybaseTransactionID,readonly
 export type RelayClaimResult = {readonly claimStellarID: TransactionID,}
 export type RelayContents = {readonly stellarID: TransactionID,readonly sk: SecretKey,readonly note: string,}
 export type RelayOp = {readonly toAssertion: string,readonly relayAccount: AccountID,readonly teamID: Keybase1.TeamID,readonly boxB64: string,}
-export type RequestDetails = {readonly id: KeybaseRequestID,readonly fromUser: Keybase1.UserVersion,readonly toUser?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,readonly fromDisplayAmount: string,readonly fromDisplayCurrency: string,readonly toDisplayAmount: string,readonly toDisplayCurrency: string,readonly fundingKbTxID: KeybaseTransactionID,readonly status: RequestStatus,}
-export type RequestDetailsLocal = {readonly id: KeybaseRequestID,readonly fromAssertion: string,readonly fromCurrentUser: boolean,readonly toUserType: ParticipantType,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,readonly amountDescription: string,readonly worthAtRequestTime: string,readonly status: RequestStatus,}
-export type RequestPost = {readonly toUser?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,}
+export type RequestDetails = {readonly id: KeybaseRequestID,readonly fromUser: Keybase1.UserVersion,readonly toUser?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,readonly fromDisplayAmount: string,readonly fromDisplayCurrency: string,readonly toDisplayAmount: string,readonly toDisplayCurrency: string,readonly fundingKbTxID: KeybaseTransactionID,readonly status: RequestStatus,readonly invoiceB64: string,readonly payments?: ReadonlyArray<RequestPayment> | null,readonly ctime: TimeMs,}
+export type RequestDetailsLocal = {readonly id: KeybaseRequestID,readonly fromAssertion: string,readonly fromCurrentUser: boolean,readonly toUserType: ParticipantType,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,readonly amountDescription: string,readonly worthAtRequestTime: string,readonly status: RequestStatus,readonly invoice?: InvoiceContents | null,readonly amountPaid: string,readonly amountPaidDescription: string,readonly payments?: ReadonlyArray<RequestPayment> | null,readonly ctime: TimeMs,}
+export type RequestPayment = {readonly kbTxID: KeybaseTransactionID,readonly txID: TransactionID,readonly amount: string,readonly displayAmount: string,readonly displayCurrency: string,readonly ctime: TimeMs,}
+export type RequestPost = {readonly toUser?: Keybase1.UserVersion | null,readonly toAssertion: string,readonly amount: string,readonly asset?: Asset | null,readonly currency?: OutsideCurrencyCode | null,readonly invoiceB64: string,}
 export type RequestStatusMsg = {readonly reqID: KeybaseRequestID,}
+export type ScheduledPaymentID = string
+export type ScheduledPaymentLocal = {readonly id: ScheduledPaymentID,readonly from: AccountID,readonly to: string,readonly amount: string,readonly currency: OutsideCurrencyCode,readonly note: string,readonly publicMemo: string,readonly recurrence: ScheduledPaymentRecurrence,readonly start: TimeMs,readonly nextRun: TimeMs,readonly ctime: TimeMs,readonly runs: number,readonly lastRun?: ScheduledPaymentRunLocal | null,}
+export type ScheduledPaymentRunLocal = {readonly time: TimeMs,readonly status: ScheduledPaymentRunStatus,readonly txID?: TransactionID | null,readonly error: string,}
 export type SecretKey = string
 export type SendAssetChoiceLocal = {readonly asset: Asset,readonly enabled: boolean,readonly left: string,readonly right: string,readonly subtext: string,}
 export type SendBannerLocal = {readonly level: string,readonly message: string,readonly proofsChanged: boolean,readonly offerAdvancedSendForm: AdvancedBanner,}
@@ -300,6 +322,7 @@ export const localHasAcceptedDisclaimerLocalRpcPromise = createRpc('stellar.1.lo
 // 'stellar.1.local.getRequestDetailsLocal'
 // 'stellar.1.local.cancelRequestLocal'
 // 'stellar.1.local.makeRequestLocal'
+// 'stellar.1.local.listRequestsLocal'
 // 'stellar.1.local.setAccountMobileOnlyLocal'
 // 'stellar.1.local.setAccountAllDevicesLocal'
 // 'stellar.1.local.isAccountMobileOnlyLocal'
@@ -326,6 +349,7 @@ export const localHasAcceptedDisclaimerLocalRpcPromise = createRpc('stellar.1.lo
 // 'stellar.1.local.accountMergeCLILocal'
 // 'stellar.1.local.claimCLILocal'
 // 'stellar.1.local.recentPaymentsCLILocal'
+// 'stellar.1.local.exportHistoryCLILocal'
 // 'stellar.1.local.paymentDetailCLILocal'
 // 'stellar.1.local.walletInitLocal'
 // 'stellar.1.local.walletDumpLocal'
@@ -346,6 +370,13 @@ export const localHasAcceptedDisclaimerLocalRpcPromise = createRpc('stellar.1.lo
 // 'stellar.1.local.approvePathURILocal'
 // 'stellar.1.local.getPartnerUrlsLocal'
 // 'stellar.1.local.signTransactionXdrLocal'
+// 'stellar.1.local.cosignSetupLocal'
+// 'stellar.1.local.cosignRequestLocal'
+// 'stellar.1.local.cosignStatusLocal'
+// 'stellar.1.local.cosignSignLocal'
+// 'stellar.1.local.schedulePaymentLocal'
+// 'stellar.1.local.listScheduledPaymentsLocal'
+// 'stellar.1.local.cancelScheduledPaymentLocal'
 // 'stellar.1.local.getStaticConfigLocal'
 // 'stellar.1.notify.paymentNotification'
 // 'stellar.1.notify.paymentStatusNotification'
@@ -354,6 +385,7 @@ export const localHasAcceptedDisclaimerLocalRpcPromise = createRpc('stellar.1.lo
 // 'stellar.1.notify.accountsUpdate'
 // 'stellar.1.notify.pendingPaymentsUpdate'
 // 'stellar.1.notify.recentPaymentsUpdate'
+// 'stellar.1.notify.scheduledPaymentNotification'
 // 'stellar.1.remote.balances'
 // 'stellar.1.remote.details'
 // 'stellar.1.remote.recentPayments'
@@ -372,6 +404,7 @@ export const localHasAcceptedDisclaimerLocalRpcPromise = createRpc('stellar.1.lo
 // 'stellar.1.remote.isMasterKeyActive'
 // 'stellar.1.remote.submitRequest'
 // 'stellar.1.remote.requestDetails'
+// 'stellar.1.remote.requests'
 // 'stellar.1.remote.cancelRequest'
 // 'stellar.1.remote.setInflationDestination'
 // 'stellar.1.remote.ping'
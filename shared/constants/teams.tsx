@@ -467,7 +467,7 @@ export const retentionPolicyToServiceRetentionPolicy = (
     case 'expire':
       return {expire: {age: policy.seconds}, typ: T.RPCChat.RetentionPolicyType.expire}
     case 'explode':
-      return {ephemeral: {age: policy.seconds}, typ: T.RPCChat.RetentionPolicyType.ephemeral}
+      return {ephemeral: {age: policy.seconds, allowNonExploding: false}, typ: T.RPCChat.RetentionPolicyType.ephemeral}
     case 'inherit':
       return {inherit: {}, typ: T.RPCChat.RetentionPolicyType.inherit}
   }
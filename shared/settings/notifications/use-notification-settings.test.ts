@@ -96,6 +96,7 @@ test('buildNotificationGroups merges API and chat-global notification settings',
       [`${T.RPCChat.GlobalAppNotificationSetting.plaintextdesktop}`]: false,
       [`${T.RPCChat.GlobalAppNotificationSetting.disabletyping}`]: false,
       [`${T.RPCChat.GlobalAppNotificationSetting.convertheic}`]: true,
+      [`${T.RPCChat.GlobalAppNotificationSetting.stripmetadata}`]: false,
     })
   )
 
@@ -108,7 +109,9 @@ test('buildNotificationGroups merges API and chat-global notification settings',
   expect(groups?.get('security')?.settings[0]?.subscribed).toBe(true)
   expect(groups?.get('security')?.settings[1]?.subscribed).toBe(false)
   expect(groups?.get('security')?.settings[2]?.subscribed).toBe(true)
+  expect(groups?.get('misc')?.settings.map(setting => setting.name)).toEqual(['convertheic', 'stripmetadata'])
   expect(groups?.get('misc')?.settings[0]?.subscribed).toBe(true)
+  expect(groups?.get('misc')?.settings[1]?.subscribed).toBe(false)
 })
 
 test('toggleNotificationGroup and buildNotificationSavePayload preserve optimistic toggle semantics', () => {
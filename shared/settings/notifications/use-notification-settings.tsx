@@ -19,6 +19,7 @@ type NotificationSetting = {
     | 'defaultsoundmobile'
     | 'disabletyping'
     | 'convertheic'
+    | 'stripmetadata'
   subscribed: boolean
   description: string
 }
@@ -110,6 +111,12 @@ export const buildNotificationGroups = (
         name: 'convertheic',
         subscribed: !!chatGlobalSettings.settings?.[`${T.RPCChat.GlobalAppNotificationSetting.convertheic}`],
       },
+      {
+        description: 'Strip location and other identifying metadata from chat attachments',
+        description_h: 'Strip location and other identifying metadata from chat attachments',
+        name: 'stripmetadata',
+        subscribed: !!chatGlobalSettings.settings?.[`${T.RPCChat.GlobalAppNotificationSetting.stripmetadata}`],
+      },
     ],
     unsub: false,
   }